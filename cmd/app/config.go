@@ -1,44 +1,139 @@
 package main
 
 import (
+	"errors"
 	"github.com/spf13/viper"
 )
 
+// signingSecretMinLength is the shortest secret accepted to sign the links
+// and tokens that are not access tokens, 32 bytes as the HMAC-SHA256 key.
+const signingSecretMinLength = 32
+
+var (
+	ErrSigningSecretTooShort = errors.New("SIGNING_SECRET should have at least 32 characters")
+	ErrJwtSecretEmpty        = errors.New("JWT_SECRET should not be empty when JWT_PRIVATE_KEY_FILE is not set")
+	ErrJwtDurationRenamed    = errors.New("JWT_DURATION was replaced by JWT_ACCESS_DURATION in minutes and JWT_REFRESH_DURATION in hours, remove it")
+)
+
 type AppConfig struct {
-	AppHost            string `mapstructure:"APP_HOST"`
-	AppPort            int    `mapstructure:"APP_PORT"`
-	DatabaseName       string `mapstructure:"DB_NAME"`
-	DatabaseHost       string `mapstructure:"DB_HOST"`
-	DatabasePort       int    `mapstructure:"DB_PORT"`
-	DatabaseUsername   string `mapstructure:"DB_USERNAME"`
-	DatabasePassword   string `mapstructure:"DB_PASSWORD"`
-	JwtSecret          string `mapstructure:"JWT_SECRET"`
-	JwtDuration        int    `mapstructure:"JWT_DURATION"`
-	AwsAccessKeyID     string `mapstructure:"AWS_ACCESS_KEY_ID"`
-	AwsSecretAccessKey string `mapstructure:"AWS_SECRET_ACCESS_KEY"`
-	AwsRegion          string `mapstructure:"AWS_REGION"`
-	S3BucketName       string `mapstructure:"S3_BUCKET_NAME"`
-	SentryDSN          string `mapstructure:"SENTRY_DSN"`
-	SmtpHost           string `mapstructure:"SMTP_HOST"`
-	SmtpPort           int    `mapstructure:"SMTP_PORT"`
-	SmtpEmail          string `mapstructure:"SMTP_EMAIL"`
-	SmtpPassword       string `mapstructure:"SMTP_PASSWORD"`
+	AppHost                        string `mapstructure:"APP_HOST"`
+	AppPort                        int    `mapstructure:"APP_PORT"`
+	AppURL                         string `mapstructure:"APP_URL"`
+	DatabaseName                   string `mapstructure:"DB_NAME"`
+	DatabaseHost                   string `mapstructure:"DB_HOST"`
+	DatabasePort                   int    `mapstructure:"DB_PORT"`
+	DatabaseUsername               string `mapstructure:"DB_USERNAME"`
+	DatabasePassword               string `mapstructure:"DB_PASSWORD"`
+	SigningSecret                  string `mapstructure:"SIGNING_SECRET"`
+	JwtSecret                      string `mapstructure:"JWT_SECRET"`
+	JwtPrivateKeyFile              string `mapstructure:"JWT_PRIVATE_KEY_FILE"`
+	JwtPublicKeyFiles              string `mapstructure:"JWT_PUBLIC_KEY_FILES"`
+	JwtAccessDuration              int    `mapstructure:"JWT_ACCESS_DURATION"`
+	JwtRefreshDuration             int    `mapstructure:"JWT_REFRESH_DURATION"`
+	PasswordResetDuration          int    `mapstructure:"PASSWORD_RESET_DURATION"`
+	EmailVerificationDuration      int    `mapstructure:"EMAIL_VERIFICATION_DURATION"`
+	LoginMaxFailedAttempts         int    `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS"`
+	LoginMaxFailedAttemptsPerIP    int    `mapstructure:"LOGIN_MAX_FAILED_ATTEMPTS_PER_IP"`
+	LoginLockoutDuration           int    `mapstructure:"LOGIN_LOCKOUT_DURATION"`
+	Argon2Memory                   int    `mapstructure:"ARGON2_MEMORY"`
+	Argon2Iterations               int    `mapstructure:"ARGON2_ITERATIONS"`
+	Argon2Parallelism              int    `mapstructure:"ARGON2_PARALLELISM"`
+	Argon2MaxMemory                int    `mapstructure:"ARGON2_MAX_MEMORY"`
+	OIDCIssuerURL                  string `mapstructure:"OIDC_ISSUER_URL"`
+	OIDCClientID                   string `mapstructure:"OIDC_CLIENT_ID"`
+	OIDCClientSecret               string `mapstructure:"OIDC_CLIENT_SECRET"`
+	AwsAccessKeyID                 string `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AwsSecretAccessKey             string `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	AwsRegion                      string `mapstructure:"AWS_REGION"`
+	S3BucketName                   string `mapstructure:"S3_BUCKET_NAME"`
+	SentryDSN                      string `mapstructure:"SENTRY_DSN"`
+	SmtpHost                       string `mapstructure:"SMTP_HOST"`
+	SmtpPort                       int    `mapstructure:"SMTP_PORT"`
+	SmtpEmail                      string `mapstructure:"SMTP_EMAIL"`
+	SmtpPassword                   string `mapstructure:"SMTP_PASSWORD"`
+	SmtpFromName                   string `mapstructure:"SMTP_FROM_NAME"`
+	ImageMetadataRemoval           bool   `mapstructure:"IMAGE_METADATA_REMOVAL"`
+	ImageJpegQuality               int    `mapstructure:"IMAGE_JPEG_QUALITY"`
+	ImageMaxSize                   int    `mapstructure:"IMAGE_MAX_SIZE"`
+	OutboxPollInterval             int    `mapstructure:"OUTBOX_POLL_INTERVAL"`
+	OutboxMaxAttempts              int    `mapstructure:"OUTBOX_MAX_ATTEMPTS"`
+	OutboxBaseBackoff              int    `mapstructure:"OUTBOX_BASE_BACKOFF"`
+	OutboxMaxBackoff               int    `mapstructure:"OUTBOX_MAX_BACKOFF"`
+	OutboxWorkers                  int    `mapstructure:"OUTBOX_WORKERS"`
+	WebhookPollInterval            int    `mapstructure:"WEBHOOK_POLL_INTERVAL"`
+	WebhookMaxAttempts             int    `mapstructure:"WEBHOOK_MAX_ATTEMPTS"`
+	WebhookBaseBackoff             int    `mapstructure:"WEBHOOK_BASE_BACKOFF"`
+	WebhookMaxBackoff              int    `mapstructure:"WEBHOOK_MAX_BACKOFF"`
+	WebhookAllowPrivateNetworks    bool   `mapstructure:"WEBHOOK_ALLOW_PRIVATE_NETWORKS"`
+	NotificationDigestPollInterval int    `mapstructure:"NOTIFICATION_DIGEST_POLL_INTERVAL"`
+	MailPollInterval               int    `mapstructure:"MAIL_POLL_INTERVAL"`
+	MailMaxAttempts                int    `mapstructure:"MAIL_MAX_ATTEMPTS"`
+	MailBaseBackoff                int    `mapstructure:"MAIL_BASE_BACKOFF"`
+	MailMaxBackoff                 int    `mapstructure:"MAIL_MAX_BACKOFF"`
+	MailRetention                  int    `mapstructure:"MAIL_RETENTION"`
+	AdminUserIDs                   string `mapstructure:"ADMIN_USER_IDS"`
+	TrustedProxies                 string `mapstructure:"TRUSTED_PROXIES"`
 }
 
 func ReadConfig() (*AppConfig, error) {
 	viper.AddConfigPath(".")
 	viper.SetConfigName("app")
 	viper.SetConfigType("env")
+	viper.SetDefault("JWT_ACCESS_DURATION", 15)
+	viper.SetDefault("JWT_REFRESH_DURATION", 720)
+	viper.SetDefault("PASSWORD_RESET_DURATION", 60)
+	viper.SetDefault("EMAIL_VERIFICATION_DURATION", 48)
+	viper.SetDefault("LOGIN_MAX_FAILED_ATTEMPTS", 10)
+	viper.SetDefault("LOGIN_MAX_FAILED_ATTEMPTS_PER_IP", 50)
+	viper.SetDefault("LOGIN_LOCKOUT_DURATION", 15)
+	viper.SetDefault("ARGON2_MEMORY", 65536)
+	viper.SetDefault("ARGON2_ITERATIONS", 3)
+	viper.SetDefault("ARGON2_PARALLELISM", 2)
+	viper.SetDefault("ARGON2_MAX_MEMORY", 262144)
+	viper.SetDefault("SMTP_FROM_NAME", "Home Inventory")
+	viper.SetDefault("IMAGE_METADATA_REMOVAL", true)
+	viper.SetDefault("IMAGE_JPEG_QUALITY", 90)
+	viper.SetDefault("IMAGE_MAX_SIZE", 20)
+	viper.SetDefault("OUTBOX_POLL_INTERVAL", 5)
+	viper.SetDefault("OUTBOX_MAX_ATTEMPTS", 10)
+	viper.SetDefault("OUTBOX_BASE_BACKOFF", 30)
+	viper.SetDefault("OUTBOX_MAX_BACKOFF", 60)
+	viper.SetDefault("OUTBOX_WORKERS", 4)
+	viper.SetDefault("WEBHOOK_POLL_INTERVAL", 5)
+	viper.SetDefault("WEBHOOK_MAX_ATTEMPTS", 8)
+	viper.SetDefault("WEBHOOK_BASE_BACKOFF", 60)
+	viper.SetDefault("WEBHOOK_MAX_BACKOFF", 360)
+	viper.SetDefault("WEBHOOK_ALLOW_PRIVATE_NETWORKS", false)
+	viper.SetDefault("NOTIFICATION_DIGEST_POLL_INTERVAL", 60)
+	viper.SetDefault("MAIL_POLL_INTERVAL", 5)
+	viper.SetDefault("MAIL_MAX_ATTEMPTS", 8)
+	viper.SetDefault("MAIL_BASE_BACKOFF", 30)
+	viper.SetDefault("MAIL_MAX_BACKOFF", 60)
+	viper.SetDefault("MAIL_RETENTION", 7)
 
 	err := viper.ReadInConfig()
 	if err != nil {
 		return nil, err
 	}
 
+	// JWT_DURATION was in hours, so keeping it as the access duration would
+	// give long lived access tokens without anybody noticing.
+	if viper.IsSet("JWT_DURATION") {
+		return nil, ErrJwtDurationRenamed
+	}
+
 	config := &AppConfig{}
 	if err := viper.Unmarshal(&config); err != nil {
 		return nil, err
 	}
 
+	if len(config.SigningSecret) < signingSecretMinLength {
+		return nil, ErrSigningSecretTooShort
+	}
+
+	if config.JwtPrivateKeyFile == "" && config.JwtSecret == "" {
+		return nil, ErrJwtSecretEmpty
+	}
+
 	return config, nil
 }
@@ -5,8 +5,11 @@ import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/http"
 	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/jibaru/home-inventory-api/m/notifier"
+	"net"
 	"strconv"
+	"strings"
 	"time"
+	_ "time/tzdata"
 )
 
 func main() {
@@ -37,19 +40,94 @@ func main() {
 		return
 	}
 
+	appURL := config.AppURL
+	if appURL == "" {
+		appURL = "http://" + config.AppHost + ":" + strconv.Itoa(config.AppPort)
+	}
+
+	jwtPublicKeyFiles := make([]string, 0)
+	for _, file := range strings.Split(config.JwtPublicKeyFiles, ",") {
+		if file = strings.TrimSpace(file); file != "" {
+			jwtPublicKeyFiles = append(jwtPublicKeyFiles, file)
+		}
+	}
+
+	adminUserIDs := make([]string, 0)
+	for _, id := range strings.Split(config.AdminUserIDs, ",") {
+		if id = strings.TrimSpace(id); id != "" {
+			adminUserIDs = append(adminUserIDs, id)
+		}
+	}
+
+	trustedProxies := make([]*net.IPNet, 0)
+	for _, cidr := range strings.Split(config.TrustedProxies, ",") {
+		if cidr = strings.TrimSpace(cidr); cidr == "" {
+			continue
+		}
+
+		_, trustedProxy, err := net.ParseCIDR(cidr)
+		if err != nil {
+			logger.LogError(err)
+			return
+		}
+
+		trustedProxies = append(trustedProxies, trustedProxy)
+	}
+
 	http.RunServer(
-		config.AppHost,
-		strconv.Itoa(config.AppPort),
-		config.JwtSecret,
-		time.Duration(config.JwtDuration)*time.Hour,
-		config.AwsAccessKeyID,
-		config.AwsSecretAccessKey,
-		config.AwsRegion,
-		config.S3BucketName,
-		config.SmtpHost,
-		config.SmtpPort,
-		config.SmtpEmail,
-		config.SmtpPassword,
+		http.ServerConfig{
+			Host:                           config.AppHost,
+			Port:                           strconv.Itoa(config.AppPort),
+			AppURL:                         appURL,
+			SigningSecret:                  config.SigningSecret,
+			JwtSecret:                      config.JwtSecret,
+			JwtPrivateKeyFile:              config.JwtPrivateKeyFile,
+			JwtPublicKeyFiles:              jwtPublicKeyFiles,
+			JwtAccessDuration:              time.Duration(config.JwtAccessDuration) * time.Minute,
+			JwtRefreshDuration:             time.Duration(config.JwtRefreshDuration) * time.Hour,
+			PasswordResetDuration:          time.Duration(config.PasswordResetDuration) * time.Minute,
+			EmailVerificationDuration:      time.Duration(config.EmailVerificationDuration) * time.Hour,
+			LoginMaxFailedAttempts:         config.LoginMaxFailedAttempts,
+			LoginMaxFailedAttemptsPerIP:    config.LoginMaxFailedAttemptsPerIP,
+			LoginLockoutDuration:           time.Duration(config.LoginLockoutDuration) * time.Minute,
+			Argon2Memory:                   uint32(config.Argon2Memory),
+			Argon2Iterations:               uint32(config.Argon2Iterations),
+			Argon2Parallelism:              uint8(config.Argon2Parallelism),
+			Argon2MaxMemory:                uint32(config.Argon2MaxMemory),
+			OIDCIssuerURL:                  config.OIDCIssuerURL,
+			OIDCClientID:                   config.OIDCClientID,
+			OIDCClientSecret:               config.OIDCClientSecret,
+			AwsAccessKeyID:                 config.AwsAccessKeyID,
+			AwsSecretAccessKey:             config.AwsSecretAccessKey,
+			AwsRegion:                      config.AwsRegion,
+			S3BucketName:                   config.S3BucketName,
+			SmtpHost:                       config.SmtpHost,
+			SmtpPort:                       config.SmtpPort,
+			SmtpEmail:                      config.SmtpEmail,
+			SmtpPassword:                   config.SmtpPassword,
+			SmtpFromName:                   config.SmtpFromName,
+			ImageMetadataRemoval:           config.ImageMetadataRemoval,
+			ImageJpegQuality:               config.ImageJpegQuality,
+			ImageMaxSize:                   int64(config.ImageMaxSize) << 20,
+			OutboxPollInterval:             time.Duration(config.OutboxPollInterval) * time.Second,
+			OutboxMaxAttempts:              config.OutboxMaxAttempts,
+			OutboxBaseBackoff:              time.Duration(config.OutboxBaseBackoff) * time.Second,
+			OutboxMaxBackoff:               time.Duration(config.OutboxMaxBackoff) * time.Minute,
+			OutboxWorkers:                  config.OutboxWorkers,
+			WebhookPollInterval:            time.Duration(config.WebhookPollInterval) * time.Second,
+			WebhookMaxAttempts:             config.WebhookMaxAttempts,
+			WebhookBaseBackoff:             time.Duration(config.WebhookBaseBackoff) * time.Second,
+			WebhookMaxBackoff:              time.Duration(config.WebhookMaxBackoff) * time.Minute,
+			WebhookAllowPrivateNetworks:    config.WebhookAllowPrivateNetworks,
+			NotificationDigestPollInterval: time.Duration(config.NotificationDigestPollInterval) * time.Second,
+			MailPollInterval:               time.Duration(config.MailPollInterval) * time.Second,
+			MailMaxAttempts:                config.MailMaxAttempts,
+			MailBaseBackoff:                time.Duration(config.MailBaseBackoff) * time.Second,
+			MailMaxBackoff:                 time.Duration(config.MailMaxBackoff) * time.Minute,
+			MailRetention:                  time.Duration(config.MailRetention) * 24 * time.Hour,
+			AdminUserIDs:                   adminUserIDs,
+			TrustedProxies:                 trustedProxies,
+		},
 		db,
 	)
 }
@@ -0,0 +1,32 @@
+package main
+
+import (
+	"github.com/spf13/viper"
+)
+
+type OutboxReportConfig struct {
+	DatabaseName     string `mapstructure:"DB_NAME"`
+	DatabaseHost     string `mapstructure:"DB_HOST"`
+	DatabasePort     int    `mapstructure:"DB_PORT"`
+	DatabaseUsername string `mapstructure:"DB_USERNAME"`
+	DatabasePassword string `mapstructure:"DB_PASSWORD"`
+	SentryDSN        string `mapstructure:"SENTRY_DSN"`
+}
+
+func ReadConfig() (*OutboxReportConfig, error) {
+	viper.AddConfigPath(".")
+	viper.SetConfigName("app")
+	viper.SetConfigType("env")
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &OutboxReportConfig{}
+	if err := viper.Unmarshal(&config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
@@ -0,0 +1,99 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/database"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/gorm"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"time"
+)
+
+func main() {
+	requeueDead := flag.Bool("requeue", false, "make the dead events pending again")
+	deadLimit := flag.Int("limit", 20, "how many dead events are listed")
+	flag.Parse()
+
+	config, err := ReadConfig()
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+
+	err = notifier.Init(config.SentryDSN)
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+	defer notifier.Flush()
+
+	db, err := database.CreateConnection(
+		database.DBConfig{
+			Name:     config.DatabaseName,
+			Host:     config.DatabaseHost,
+			Port:     config.DatabasePort,
+			Username: config.DatabaseUsername,
+			Password: config.DatabasePassword,
+		},
+	)
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+
+	outboxService := services.NewOutboxService(gorm.NewOutboxRepository(db))
+
+	report, err := outboxService.GetReport(*deadLimit)
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+
+	printReport(report)
+
+	if !*requeueDead {
+		if report.Dead > 0 {
+			fmt.Println("use -requeue to deliver the dead events again")
+		}
+		return
+	}
+
+	requeued, err := outboxService.RequeueDead()
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+
+	fmt.Printf("dead events requeued: %d\n", requeued)
+}
+
+func printReport(report *services.OutboxReport) {
+	fmt.Printf("pending events: %d\n", report.Pending)
+	if report.OldestPending != nil {
+		fmt.Printf(
+			"  oldest %s %s (created %s, %d attempts)\n",
+			report.OldestPending.ID,
+			report.OldestPending.Type,
+			report.OldestPending.CreatedAt.Format(time.RFC3339),
+			report.OldestPending.Attempts,
+		)
+	}
+
+	fmt.Printf("dead events: %d\n", report.Dead)
+	for _, event := range report.DeadEvents {
+		lastError := ""
+		if event.LastError != nil {
+			lastError = *event.LastError
+		}
+		fmt.Printf(
+			"  %s %s (created %s, %d attempts): %s\n",
+			event.ID,
+			event.Type,
+			event.CreatedAt.Format(time.RFC3339),
+			event.Attempts,
+			lastError,
+		)
+	}
+}
@@ -0,0 +1,36 @@
+package main
+
+import (
+	"github.com/spf13/viper"
+)
+
+type ReconcileConfig struct {
+	DatabaseName       string `mapstructure:"DB_NAME"`
+	DatabaseHost       string `mapstructure:"DB_HOST"`
+	DatabasePort       int    `mapstructure:"DB_PORT"`
+	DatabaseUsername   string `mapstructure:"DB_USERNAME"`
+	DatabasePassword   string `mapstructure:"DB_PASSWORD"`
+	AwsAccessKeyID     string `mapstructure:"AWS_ACCESS_KEY_ID"`
+	AwsSecretAccessKey string `mapstructure:"AWS_SECRET_ACCESS_KEY"`
+	AwsRegion          string `mapstructure:"AWS_REGION"`
+	S3BucketName       string `mapstructure:"S3_BUCKET_NAME"`
+	SentryDSN          string `mapstructure:"SENTRY_DSN"`
+}
+
+func ReadConfig() (*ReconcileConfig, error) {
+	viper.AddConfigPath(".")
+	viper.SetConfigName("app")
+	viper.SetConfigType("env")
+
+	err := viper.ReadInConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	config := &ReconcileConfig{}
+	if err := viper.Unmarshal(&config); err != nil {
+		return nil, err
+	}
+
+	return config, nil
+}
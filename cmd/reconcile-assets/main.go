@@ -0,0 +1,105 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/database"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/gorm"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/aws"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/imaging"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"time"
+)
+
+func main() {
+	deleteOrphans := flag.Bool("delete", false, "delete the orphaned files and assets found")
+	gracePeriod := flag.Duration("grace", 24*time.Hour, "ignore files and assets newer than this duration")
+	flag.Parse()
+
+	config, err := ReadConfig()
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+
+	err = notifier.Init(config.SentryDSN)
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+	defer notifier.Flush()
+
+	db, err := database.CreateConnection(
+		database.DBConfig{
+			Name:     config.DatabaseName,
+			Host:     config.DatabaseHost,
+			Port:     config.DatabasePort,
+			Username: config.DatabaseUsername,
+			Password: config.DatabasePassword,
+		},
+	)
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+
+	fileManager := aws.NewFileManager(
+		config.AwsAccessKeyID,
+		config.AwsSecretAccessKey,
+		config.AwsRegion,
+		config.S3BucketName,
+	)
+	// Reconciliation never uploads files, so images are not processed.
+	imageProcessor := imaging.NewMetadataRemover(false, 0, 0)
+	// Reconciliation never sends invitations, so no event bus or mail sender is needed.
+	householdService := services.NewHouseholdService(
+		gorm.NewHouseholdRepository(db),
+		gorm.NewUserRepository(db),
+		nil,
+		nil,
+	)
+	assetService := services.NewAssetService(
+		fileManager,
+		imageProcessor,
+		gorm.NewAssetRepository(db),
+		gorm.NewItemRepository(db),
+		gorm.NewRoomRepository(db),
+		gorm.NewBoxRepository(db),
+		gorm.NewAttachmentRepository(db),
+		gorm.NewTransactionManager(db),
+		householdService,
+	)
+
+	report, err := assetService.Reconcile(time.Now().Add(-*gracePeriod), *deleteOrphans)
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+
+	printReport(report)
+}
+
+func printReport(report *services.AssetReconciliationReport) {
+	fmt.Printf("files without asset: %d\n", len(report.FilesWithoutAsset))
+	for _, file := range report.FilesWithoutAsset {
+		fmt.Printf("  %s%s (modified %s)\n", file.ID, file.Extension, file.LastModified.Format(time.RFC3339))
+	}
+
+	fmt.Printf("assets without file: %d\n", len(report.AssetsWithoutFile))
+	for _, asset := range report.AssetsWithoutFile {
+		fmt.Printf("  %s -> %s%s\n", asset.ID, asset.FileID, asset.Extension)
+	}
+
+	fmt.Printf("assets without entity: %d\n", len(report.AssetsWithoutEntity))
+	for _, asset := range report.AssetsWithoutEntity {
+		fmt.Printf("  %s -> %s %s\n", asset.ID, asset.EntityName, asset.EntityID)
+	}
+
+	if report.Deleted {
+		fmt.Println("orphans deleted")
+	} else {
+		fmt.Println("dry run, nothing deleted (use -delete to remove orphans)")
+	}
+}
@@ -18,7 +18,7 @@ func NewCreateAddBoxTransactionListener(
 	}
 }
 
-func (l *CreateAddBoxTransactionListener) Handle(event domain.Event) {
+func (l *CreateAddBoxTransactionListener) Handle(event domain.Event) error {
 	if e, ok := event.(domain.BoxItemAddedEvent); ok {
 		_, err := l.boxService.CreateAddBoxTransaction(
 			e.Quantity,
@@ -28,9 +28,11 @@ func (l *CreateAddBoxTransactionListener) Handle(event domain.Event) {
 		)
 		if err != nil {
 			logger.LogError(err)
-			return
+			return err
 		}
 
+		// The box transaction is already saved, so a failed notification is
+		// not retried to avoid saving it twice.
 		err = l.boxService.NotifyBoxItemAdded(
 			e.Quantity,
 			e.BoxID,
@@ -39,7 +41,8 @@ func (l *CreateAddBoxTransactionListener) Handle(event domain.Event) {
 		)
 		if err != nil {
 			logger.LogError(err)
-			return
 		}
 	}
+
+	return nil
 }
@@ -18,7 +18,7 @@ func NewCreateRemoveBoxTransactionListener(
 	}
 }
 
-func (l *CreateRemoveBoxTransactionListener) Handle(event domain.Event) {
+func (l *CreateRemoveBoxTransactionListener) Handle(event domain.Event) error {
 	if e, ok := event.(domain.BoxItemRemovedEvent); ok {
 		_, err := l.boxService.CreateRemoveBoxTransaction(
 			e.Quantity,
@@ -28,9 +28,11 @@ func (l *CreateRemoveBoxTransactionListener) Handle(event domain.Event) {
 		)
 		if err != nil {
 			logger.LogError(err)
-			return
+			return err
 		}
 
+		// The box transaction is already saved, so a failed notification is
+		// not retried to avoid saving it twice.
 		err = l.boxService.NotifyBoxItemRemoved(
 			e.Quantity,
 			e.BoxID,
@@ -39,7 +41,8 @@ func (l *CreateRemoveBoxTransactionListener) Handle(event domain.Event) {
 		)
 		if err != nil {
 			logger.LogError(err)
-			return
 		}
 	}
+
+	return nil
 }
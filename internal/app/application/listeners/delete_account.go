@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type DeleteAccountListener struct {
+	accountDeletionService *services.AccountDeletionService
+}
+
+func NewDeleteAccountListener(
+	accountDeletionService *services.AccountDeletionService,
+) *DeleteAccountListener {
+	return &DeleteAccountListener{
+		accountDeletionService: accountDeletionService,
+	}
+}
+
+func (l *DeleteAccountListener) Handle(event domain.Event) error {
+	if e, ok := event.(domain.AccountDeletionRequestedEvent); ok {
+		err := l.accountDeletionService.Delete(e.User.ID)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type DeliverWebhooksListener struct {
+	webhookService *services.WebhookService
+}
+
+func NewDeliverWebhooksListener(
+	webhookService *services.WebhookService,
+) *DeliverWebhooksListener {
+	return &DeliverWebhooksListener{
+		webhookService: webhookService,
+	}
+}
+
+// Handle only queues the deliveries, they are sent apart so a slow endpoint
+// does not hold the other events back.
+func (l *DeliverWebhooksListener) Handle(event domain.Event) error {
+	err := l.webhookService.Enqueue(event)
+	if err != nil {
+		logger.LogError(err)
+		return err
+	}
+
+	return nil
+}
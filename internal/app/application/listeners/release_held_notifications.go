@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type ReleaseHeldNotificationsListener struct {
+	notificationService *services.NotificationService
+}
+
+func NewReleaseHeldNotificationsListener(
+	notificationService *services.NotificationService,
+) *ReleaseHeldNotificationsListener {
+	return &ReleaseHeldNotificationsListener{
+		notificationService: notificationService,
+	}
+}
+
+func (l *ReleaseHeldNotificationsListener) Handle(event domain.Event) error {
+	if e, ok := event.(domain.EmailVerifiedEvent); ok {
+		err := l.notificationService.ReleaseHeld(e.User.ID)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	return nil
+}
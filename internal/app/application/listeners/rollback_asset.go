@@ -19,18 +19,23 @@ func NewRollbackAssetListener(
 	}
 }
 
-func (l *RollbackAssetListener) Handle(event domain.Event) {
+func (l *RollbackAssetListener) Handle(event domain.Event) error {
 	var asset *entities.Asset
 	if e, ok := event.(domain.ItemNotCreatedEvent); ok {
 		asset = &e.Asset
 	} else if e, ok := event.(domain.ItemKeywordsNotCreatedEvent); ok {
 		asset = &e.Asset
+	} else if e, ok := event.(domain.AttachmentNotCreatedEvent); ok {
+		asset = &e.Asset
 	}
 
 	if asset != nil {
 		err := l.assetService.Delete(asset)
 		if err != nil {
 			logger.LogError(err)
+			return err
 		}
 	}
+
+	return nil
 }
@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type SendEmailChangeConfirmationListener struct {
+	userService *services.UserService
+}
+
+func NewSendEmailChangeConfirmationListener(
+	userService *services.UserService,
+) *SendEmailChangeConfirmationListener {
+	return &SendEmailChangeConfirmationListener{
+		userService: userService,
+	}
+}
+
+func (l *SendEmailChangeConfirmationListener) Handle(event domain.Event) error {
+	if e, ok := event.(domain.EmailChangeRequestedEvent); ok {
+		err := l.userService.SendEmailChangeConfirmation(&e.User, e.NewEmail)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type SendEmailVerificationListener struct {
+	userService *services.UserService
+}
+
+func NewSendEmailVerificationListener(
+	userService *services.UserService,
+) *SendEmailVerificationListener {
+	return &SendEmailVerificationListener{
+		userService: userService,
+	}
+}
+
+func (l *SendEmailVerificationListener) Handle(event domain.Event) error {
+	if e, ok := event.(domain.UserCreatedEvent); ok {
+		err := l.userService.SendEmailVerification(&e.User)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	return nil
+}
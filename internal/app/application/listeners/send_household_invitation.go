@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type SendHouseholdInvitationListener struct {
+	householdService *services.HouseholdService
+}
+
+func NewSendHouseholdInvitationListener(
+	householdService *services.HouseholdService,
+) *SendHouseholdInvitationListener {
+	return &SendHouseholdInvitationListener{
+		householdService: householdService,
+	}
+}
+
+func (l *SendHouseholdInvitationListener) Handle(event domain.Event) error {
+	if e, ok := event.(domain.HouseholdInvitationCreatedEvent); ok {
+		err := l.householdService.SendInvitation(&e.Invitation)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	return nil
+}
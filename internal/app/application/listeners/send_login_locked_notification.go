@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type SendLoginLockedNotificationListener struct {
+	loginThrottleService *services.LoginThrottleService
+}
+
+func NewSendLoginLockedNotificationListener(
+	loginThrottleService *services.LoginThrottleService,
+) *SendLoginLockedNotificationListener {
+	return &SendLoginLockedNotificationListener{
+		loginThrottleService: loginThrottleService,
+	}
+}
+
+func (l *SendLoginLockedNotificationListener) Handle(event domain.Event) error {
+	if e, ok := event.(domain.LoginLockedEvent); ok {
+		err := l.loginThrottleService.SendLockedNotification(&e.User)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	return nil
+}
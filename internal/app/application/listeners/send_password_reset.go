@@ -0,0 +1,31 @@
+package listeners
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+type SendPasswordResetListener struct {
+	userService *services.UserService
+}
+
+func NewSendPasswordResetListener(
+	userService *services.UserService,
+) *SendPasswordResetListener {
+	return &SendPasswordResetListener{
+		userService: userService,
+	}
+}
+
+func (l *SendPasswordResetListener) Handle(event domain.Event) error {
+	if e, ok := event.(domain.PasswordResetRequestedEvent); ok {
+		err := l.userService.SendPasswordReset(&e.User)
+		if err != nil {
+			logger.LogError(err)
+			return err
+		}
+	}
+
+	return nil
+}
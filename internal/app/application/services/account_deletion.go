@@ -0,0 +1,393 @@
+package services
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+)
+
+const accountDeletionBatchSize = 100
+
+type AccountDeletionService struct {
+	userRepository                   repositories.UserRepository
+	refreshTokenRepository           repositories.RefreshTokenRepository
+	passwordResetTokenRepository     repositories.PasswordResetTokenRepository
+	personalAccessTokenRepository    repositories.PersonalAccessTokenRepository
+	userIdentityRepository           repositories.UserIdentityRepository
+	twoFactorRepository              repositories.TwoFactorRepository
+	webhookRepository                repositories.WebhookRepository
+	notificationPreferenceRepository repositories.NotificationPreferenceRepository
+	notificationDigestRepository     repositories.NotificationDigestRepository
+	householdRepository              repositories.HouseholdRepository
+	roomRepository                   repositories.RoomRepository
+	boxRepository                    repositories.BoxRepository
+	itemRepository                   repositories.ItemRepository
+	itemKeywordRepository            repositories.ItemKeywordRepository
+	attachmentRepository             repositories.AttachmentRepository
+	assetService                     AssetServiceInterface
+	eventBus                         services.EventBus
+}
+
+func NewAccountDeletionService(
+	userRepository repositories.UserRepository,
+	refreshTokenRepository repositories.RefreshTokenRepository,
+	passwordResetTokenRepository repositories.PasswordResetTokenRepository,
+	personalAccessTokenRepository repositories.PersonalAccessTokenRepository,
+	userIdentityRepository repositories.UserIdentityRepository,
+	twoFactorRepository repositories.TwoFactorRepository,
+	webhookRepository repositories.WebhookRepository,
+	notificationPreferenceRepository repositories.NotificationPreferenceRepository,
+	notificationDigestRepository repositories.NotificationDigestRepository,
+	householdRepository repositories.HouseholdRepository,
+	roomRepository repositories.RoomRepository,
+	boxRepository repositories.BoxRepository,
+	itemRepository repositories.ItemRepository,
+	itemKeywordRepository repositories.ItemKeywordRepository,
+	attachmentRepository repositories.AttachmentRepository,
+	assetService AssetServiceInterface,
+	eventBus services.EventBus,
+) *AccountDeletionService {
+	return &AccountDeletionService{
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	}
+}
+
+// Request keeps the user from signing in again, ends every session and token
+// of the user right away and leaves the removal of their data to a listener,
+// because it can take a while.
+func (s *AccountDeletionService) Request(userID string) error {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	user.RequestDeletion()
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		return err
+	}
+
+	err = s.refreshTokenRepository.RevokeByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.personalAccessTokenRepository.RevokeByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.eventBus.Publish(services.AccountDeletionRequestedEvent{
+		User: *user,
+	})
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return nil
+}
+
+// Delete removes the households where the user is the only member, with all
+// their rooms, boxes, items and files, and leaves the shared ones. The files
+// the user uploaded on their own are deleted too. When the
+// user was their last owner, the oldest remaining member becomes the owner.
+// The files of a row are deleted before the row, so a failure never leaves
+// files that nothing points to. It only deletes what is left, so it can run
+// again after a failure.
+func (s *AccountDeletionService) Delete(userID string) error {
+	err := s.webhookRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.notificationPreferenceRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.notificationDigestRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	members, err := s.householdRepository.GetMembersByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	for _, member := range members {
+		err = s.leaveHousehold(member)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.householdRepository.DeleteInvitationsByInviter(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.refreshTokenRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.passwordResetTokenRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.personalAccessTokenRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.userIdentityRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.twoFactorRepository.DeleteRecoveryCodesByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.twoFactorRepository.DeleteByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	// The files uploaded on their own belong to the user.
+	err = s.deleteAssets(entities.NewIdentifiableEntity(userID))
+	if err != nil {
+		return err
+	}
+
+	return s.userRepository.Delete(userID)
+}
+
+func (s *AccountDeletionService) leaveHousehold(member *entities.HouseholdMember) error {
+	members, err := s.householdRepository.GetMembersByHouseholdID(member.HouseholdID)
+	if err != nil {
+		return err
+	}
+
+	others := make([]*entities.HouseholdMember, 0, len(members))
+	hasOtherOwner := false
+	for _, other := range members {
+		if other.ID == member.ID {
+			continue
+		}
+
+		others = append(others, other)
+		if other.Role == entities.HouseholdRoleOwner {
+			hasOtherOwner = true
+		}
+	}
+
+	if len(others) == 0 {
+		return s.deleteHousehold(member)
+	}
+
+	if !hasOtherOwner {
+		successor := others[0]
+		err = successor.ChangeRole(entities.HouseholdRoleOwner)
+		if err != nil {
+			return err
+		}
+
+		err = s.householdRepository.UpdateMember(successor)
+		if err != nil {
+			return err
+		}
+	}
+
+	return s.householdRepository.DeleteMember(member.ID)
+}
+
+func (s *AccountDeletionService) deleteHousehold(member *entities.HouseholdMember) error {
+	for {
+		rooms, err := s.roomRepository.GetByQueryFilters(
+			equalQueryFilter(entities.RoomHouseholdIDField, member.HouseholdID),
+			&repositories.PageFilter{Offset: 0, Limit: accountDeletionBatchSize},
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(rooms) == 0 {
+			break
+		}
+
+		for _, room := range rooms {
+			err = s.deleteRoom(room)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	for {
+		items, err := s.itemRepository.GetByQueryFilters(
+			equalQueryFilter("items.household_id", member.HouseholdID),
+			&repositories.PageFilter{Offset: 0, Limit: accountDeletionBatchSize},
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(items) == 0 {
+			break
+		}
+
+		for _, item := range items {
+			err = s.deleteItem(item)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := s.householdRepository.DeleteInvitationsByHouseholdID(member.HouseholdID)
+	if err != nil {
+		return err
+	}
+
+	err = s.householdRepository.DeleteMember(member.ID)
+	if err != nil {
+		return err
+	}
+
+	return s.householdRepository.Delete(member.HouseholdID)
+}
+
+func (s *AccountDeletionService) deleteRoom(room *entities.Room) error {
+	for {
+		boxes, err := s.boxRepository.GetByQueryFilters(
+			equalQueryFilter("boxes.room_id", room.ID),
+			&repositories.PageFilter{Offset: 0, Limit: accountDeletionBatchSize},
+		)
+		if err != nil {
+			return err
+		}
+
+		if len(boxes) == 0 {
+			break
+		}
+
+		for _, box := range boxes {
+			err = s.deleteBox(box)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	err := s.deleteAssets(room)
+	if err != nil {
+		return err
+	}
+
+	return s.roomRepository.Delete(room.ID)
+}
+
+func (s *AccountDeletionService) deleteBox(box *entities.Box) error {
+	err := s.boxRepository.DeleteBoxTransactionsByBoxID(box.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.boxRepository.DeleteBoxItemsByBoxID(box.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.deleteAssets(box)
+	if err != nil {
+		return err
+	}
+
+	return s.boxRepository.Delete(box.ID)
+}
+
+func (s *AccountDeletionService) deleteItem(item *entities.Item) error {
+	err := s.itemKeywordRepository.DeleteByItemID(item.ID)
+	if err != nil {
+		return err
+	}
+
+	attachments, err := s.attachmentRepository.GetByItemID(item.ID)
+	if err != nil {
+		return err
+	}
+
+	for _, attachment := range attachments {
+		err = s.deleteAssets(attachment)
+		if err != nil {
+			return err
+		}
+	}
+
+	err = s.attachmentRepository.DeleteByItemID(item.ID)
+	if err != nil {
+		return err
+	}
+
+	err = s.deleteAssets(item)
+	if err != nil {
+		return err
+	}
+
+	return s.itemRepository.Delete(item.ID)
+}
+
+func (s *AccountDeletionService) deleteAssets(entity entities.Entity) error {
+	assets, err := s.assetService.GetByEntity(entity, nil)
+	if err != nil {
+		return err
+	}
+
+	for _, asset := range assets {
+		err = s.assetService.Delete(asset)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func equalQueryFilter(field string, value interface{}) repositories.QueryFilter {
+	return repositories.QueryFilter{
+		ConditionGroups: []repositories.ConditionGroup{
+			{
+				Operator: repositories.AndLogicalOperator,
+				Conditions: []repositories.Condition{
+					{
+						Field:    field,
+						Operator: repositories.EqualComparisonOperator,
+						Value:    value,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,581 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	serviceStub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestAccountDeletionServiceRequest(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	twoFactorRepository := new(stub.TwoFactorRepositoryMock)
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	itemKeywordRepository := new(stub.ItemKeywordRepositoryMock)
+	attachmentRepository := new(stub.AttachmentRepositoryMock)
+	assetService := new(AssetServiceMock)
+	eventBus := new(serviceStub.EventBusMock)
+	service := NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("Update", user).Return(nil)
+	refreshTokenRepository.On("RevokeByUserID", user.ID).Return(nil)
+	personalAccessTokenRepository.On("RevokeByUserID", user.ID).Return(nil)
+	eventBus.On("Publish", mock.AnythingOfType("services.AccountDeletionRequestedEvent")).Return(nil)
+
+	err := service.Request(user.ID)
+
+	assert.NoError(t, err)
+	assert.True(t, user.IsDeletionRequested())
+	eventBus.AssertCalled(t, "Publish", services.AccountDeletionRequestedEvent{User: *user})
+	userRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	passwordResetTokenRepository.AssertExpectations(t)
+	personalAccessTokenRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	twoFactorRepository.AssertExpectations(t)
+	webhookRepository.AssertExpectations(t)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	householdRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	itemKeywordRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+	userRepository.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestAccountDeletionServiceDeleteOnlyMember(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	twoFactorRepository := new(stub.TwoFactorRepositoryMock)
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	itemKeywordRepository := new(stub.ItemKeywordRepositoryMock)
+	attachmentRepository := new(stub.AttachmentRepositoryMock)
+	assetService := new(AssetServiceMock)
+	eventBus := new(serviceStub.EventBusMock)
+	service := NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
+
+	userID := uuid.NewString()
+	member := &entities.HouseholdMember{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		UserID:      userID,
+		Role:        entities.HouseholdRoleOwner,
+	}
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: member.HouseholdID}
+	box := &entities.Box{ID: uuid.NewString(), RoomID: room.ID}
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: member.HouseholdID}
+	attachment := &entities.Attachment{ID: uuid.NewString(), ItemID: item.ID}
+	roomAsset := &entities.Asset{ID: uuid.NewString()}
+	attachmentAsset := &entities.Asset{ID: uuid.NewString()}
+
+	householdRepository.On("GetMembersByUserID", userID).Return([]*entities.HouseholdMember{member}, nil)
+	householdRepository.On("GetMembersByHouseholdID", member.HouseholdID).
+		Return([]*entities.HouseholdMember{member}, nil)
+
+	roomRepository.On("GetByQueryFilters", equalQueryFilter(entities.RoomHouseholdIDField, member.HouseholdID), mock.Anything).
+		Return([]*entities.Room{room}, nil).Once()
+	roomRepository.On("GetByQueryFilters", equalQueryFilter(entities.RoomHouseholdIDField, member.HouseholdID), mock.Anything).
+		Return([]*entities.Room{}, nil).Once()
+	boxRepository.On("GetByQueryFilters", equalQueryFilter("boxes.room_id", room.ID), mock.Anything).
+		Return([]*entities.Box{box}, nil).Once()
+	boxRepository.On("GetByQueryFilters", equalQueryFilter("boxes.room_id", room.ID), mock.Anything).
+		Return([]*entities.Box{}, nil).Once()
+	boxRepository.On("DeleteBoxTransactionsByBoxID", box.ID).Return(nil)
+	boxRepository.On("DeleteBoxItemsByBoxID", box.ID).Return(nil)
+	boxRepository.On("Delete", box.ID).Return(nil)
+	assetService.On("GetByEntity", box, mock.Anything).Return([]*entities.Asset{}, nil)
+	roomRepository.On("Delete", room.ID).Return(nil)
+	assetService.On("GetByEntity", room, mock.Anything).Return([]*entities.Asset{roomAsset}, nil)
+	assetService.On("Delete", roomAsset).Return(nil)
+
+	itemRepository.On("GetByQueryFilters", equalQueryFilter("items.household_id", member.HouseholdID), mock.Anything).
+		Return([]*entities.Item{item}, nil).Once()
+	itemRepository.On("GetByQueryFilters", equalQueryFilter("items.household_id", member.HouseholdID), mock.Anything).
+		Return([]*entities.Item{}, nil).Once()
+	itemKeywordRepository.On("DeleteByItemID", item.ID).Return(nil)
+	attachmentRepository.On("GetByItemID", item.ID).Return([]*entities.Attachment{attachment}, nil)
+	attachmentRepository.On("DeleteByItemID", item.ID).Return(nil)
+	assetService.On("GetByEntity", attachment, mock.Anything).Return([]*entities.Asset{attachmentAsset}, nil)
+	assetService.On("Delete", attachmentAsset).Return(nil)
+	itemRepository.On("Delete", item.ID).Return(nil)
+	assetService.On("GetByEntity", item, mock.Anything).Return([]*entities.Asset{}, nil)
+
+	householdRepository.On("DeleteInvitationsByHouseholdID", member.HouseholdID).Return(nil)
+	householdRepository.On("DeleteMember", member.ID).Return(nil)
+	householdRepository.On("Delete", member.HouseholdID).Return(nil)
+	webhookRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationPreferenceRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationDigestRepository.On("DeleteByUserID", userID).Return(nil)
+	householdRepository.On("DeleteInvitationsByInviter", userID).Return(nil)
+	refreshTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	passwordResetTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	personalAccessTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	userIdentityRepository.On("DeleteByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteRecoveryCodesByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteByUserID", userID).Return(nil)
+	userAsset := &entities.Asset{ID: uuid.NewString(), FileID: uuid.NewString(), Extension: ".pdf"}
+	assetService.On("GetByEntity", entities.NewIdentifiableEntity(userID), mock.Anything).Return([]*entities.Asset{userAsset}, nil)
+	assetService.On("Delete", userAsset).Return(nil)
+	userRepository.On("Delete", userID).Return(nil)
+
+	err := service.Delete(userID)
+
+	assert.NoError(t, err)
+	userRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	passwordResetTokenRepository.AssertExpectations(t)
+	personalAccessTokenRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	twoFactorRepository.AssertExpectations(t)
+	webhookRepository.AssertExpectations(t)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	householdRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	itemKeywordRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+}
+
+func TestAccountDeletionServiceDeleteLastOwnerOfSharedHousehold(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	twoFactorRepository := new(stub.TwoFactorRepositoryMock)
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	itemKeywordRepository := new(stub.ItemKeywordRepositoryMock)
+	attachmentRepository := new(stub.AttachmentRepositoryMock)
+	assetService := new(AssetServiceMock)
+	eventBus := new(serviceStub.EventBusMock)
+	service := NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+	member := &entities.HouseholdMember{ID: uuid.NewString(), HouseholdID: householdID, UserID: userID, Role: entities.HouseholdRoleOwner}
+	oldest := &entities.HouseholdMember{ID: uuid.NewString(), HouseholdID: householdID, UserID: uuid.NewString(), Role: entities.HouseholdRoleViewer}
+	newest := &entities.HouseholdMember{ID: uuid.NewString(), HouseholdID: householdID, UserID: uuid.NewString(), Role: entities.HouseholdRoleEditor}
+
+	householdRepository.On("GetMembersByUserID", userID).Return([]*entities.HouseholdMember{member}, nil)
+	householdRepository.On("GetMembersByHouseholdID", householdID).
+		Return([]*entities.HouseholdMember{member, oldest, newest}, nil)
+	householdRepository.On("UpdateMember", oldest).Return(nil)
+	householdRepository.On("DeleteMember", member.ID).Return(nil)
+	webhookRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationPreferenceRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationDigestRepository.On("DeleteByUserID", userID).Return(nil)
+	householdRepository.On("DeleteInvitationsByInviter", userID).Return(nil)
+	refreshTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	passwordResetTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	personalAccessTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	userIdentityRepository.On("DeleteByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteRecoveryCodesByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteByUserID", userID).Return(nil)
+	assetService.On("GetByEntity", entities.NewIdentifiableEntity(userID), mock.Anything).Return([]*entities.Asset{}, nil)
+	userRepository.On("Delete", userID).Return(nil)
+
+	err := service.Delete(userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.HouseholdRoleOwner, oldest.Role)
+	assert.Equal(t, entities.HouseholdRoleEditor, newest.Role)
+	userRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	passwordResetTokenRepository.AssertExpectations(t)
+	personalAccessTokenRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	twoFactorRepository.AssertExpectations(t)
+	webhookRepository.AssertExpectations(t)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	householdRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	itemKeywordRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdRepository.AssertNotCalled(t, "Delete", mock.Anything)
+	roomRepository.AssertNotCalled(t, "GetByQueryFilters", mock.Anything, mock.Anything)
+}
+
+func TestAccountDeletionServiceDeleteMemberOfHouseholdWithOtherOwner(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	twoFactorRepository := new(stub.TwoFactorRepositoryMock)
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	itemKeywordRepository := new(stub.ItemKeywordRepositoryMock)
+	attachmentRepository := new(stub.AttachmentRepositoryMock)
+	assetService := new(AssetServiceMock)
+	eventBus := new(serviceStub.EventBusMock)
+	service := NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+	member := &entities.HouseholdMember{ID: uuid.NewString(), HouseholdID: householdID, UserID: userID, Role: entities.HouseholdRoleEditor}
+	owner := &entities.HouseholdMember{ID: uuid.NewString(), HouseholdID: householdID, UserID: uuid.NewString(), Role: entities.HouseholdRoleOwner}
+
+	householdRepository.On("GetMembersByUserID", userID).Return([]*entities.HouseholdMember{member}, nil)
+	householdRepository.On("GetMembersByHouseholdID", householdID).
+		Return([]*entities.HouseholdMember{owner, member}, nil)
+	householdRepository.On("DeleteMember", member.ID).Return(nil)
+	webhookRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationPreferenceRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationDigestRepository.On("DeleteByUserID", userID).Return(nil)
+	householdRepository.On("DeleteInvitationsByInviter", userID).Return(nil)
+	refreshTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	passwordResetTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	personalAccessTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	userIdentityRepository.On("DeleteByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteRecoveryCodesByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteByUserID", userID).Return(nil)
+	assetService.On("GetByEntity", entities.NewIdentifiableEntity(userID), mock.Anything).Return([]*entities.Asset{}, nil)
+	userRepository.On("Delete", userID).Return(nil)
+
+	err := service.Delete(userID)
+
+	assert.NoError(t, err)
+	userRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	passwordResetTokenRepository.AssertExpectations(t)
+	personalAccessTokenRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	twoFactorRepository.AssertExpectations(t)
+	webhookRepository.AssertExpectations(t)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	householdRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	itemKeywordRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdRepository.AssertNotCalled(t, "UpdateMember", mock.Anything)
+}
+
+func TestAccountDeletionServiceRequestErrorUpdatingUser(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	twoFactorRepository := new(stub.TwoFactorRepositoryMock)
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	itemKeywordRepository := new(stub.ItemKeywordRepositoryMock)
+	attachmentRepository := new(stub.AttachmentRepositoryMock)
+	assetService := new(AssetServiceMock)
+	eventBus := new(serviceStub.EventBusMock)
+	service := NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("Update", user).Return(repositories.ErrUserRepositoryCanNotUpdateUser)
+
+	err := service.Request(user.ID)
+
+	assert.ErrorIs(t, err, repositories.ErrUserRepositoryCanNotUpdateUser)
+	refreshTokenRepository.AssertNotCalled(t, "RevokeByUserID", mock.Anything)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestAccountDeletionServiceDeleteKeepsRowsWhoseFilesCanNotBeDeleted(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	twoFactorRepository := new(stub.TwoFactorRepositoryMock)
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	itemKeywordRepository := new(stub.ItemKeywordRepositoryMock)
+	attachmentRepository := new(stub.AttachmentRepositoryMock)
+	assetService := new(AssetServiceMock)
+	eventBus := new(serviceStub.EventBusMock)
+	service := NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
+
+	userID := uuid.NewString()
+	member := &entities.HouseholdMember{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		UserID:      userID,
+		Role:        entities.HouseholdRoleOwner,
+	}
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: member.HouseholdID}
+	roomAsset := &entities.Asset{ID: uuid.NewString()}
+
+	webhookRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationPreferenceRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationDigestRepository.On("DeleteByUserID", userID).Return(nil)
+	householdRepository.On("GetMembersByUserID", userID).Return([]*entities.HouseholdMember{member}, nil)
+	householdRepository.On("GetMembersByHouseholdID", member.HouseholdID).
+		Return([]*entities.HouseholdMember{member}, nil)
+	roomRepository.On("GetByQueryFilters", equalQueryFilter(entities.RoomHouseholdIDField, member.HouseholdID), mock.Anything).
+		Return([]*entities.Room{room}, nil)
+	boxRepository.On("GetByQueryFilters", equalQueryFilter("boxes.room_id", room.ID), mock.Anything).
+		Return([]*entities.Box{}, nil)
+	assetService.On("GetByEntity", room, mock.Anything).Return([]*entities.Asset{roomAsset}, nil)
+	assetService.On("Delete", roomAsset).Return(repositories.ErrStoredFileRepositoryCanNotDeleteFile)
+
+	err := service.Delete(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotDeleteFile)
+	userRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	passwordResetTokenRepository.AssertExpectations(t)
+	personalAccessTokenRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	twoFactorRepository.AssertExpectations(t)
+	webhookRepository.AssertExpectations(t)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	householdRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	itemKeywordRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	roomRepository.AssertNotCalled(t, "Delete", room.ID)
+	userRepository.AssertNotCalled(t, "Delete", userID)
+}
+
+func TestAccountDeletionServiceDeleteKeepsUserWhoseFilesCanNotBeDeleted(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	twoFactorRepository := new(stub.TwoFactorRepositoryMock)
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	itemKeywordRepository := new(stub.ItemKeywordRepositoryMock)
+	attachmentRepository := new(stub.AttachmentRepositoryMock)
+	assetService := new(AssetServiceMock)
+	eventBus := new(serviceStub.EventBusMock)
+	service := NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
+
+	userID := uuid.NewString()
+	userAsset := &entities.Asset{ID: uuid.NewString()}
+
+	webhookRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationPreferenceRepository.On("DeleteByUserID", userID).Return(nil)
+	notificationDigestRepository.On("DeleteByUserID", userID).Return(nil)
+	householdRepository.On("GetMembersByUserID", userID).Return([]*entities.HouseholdMember{}, nil)
+	householdRepository.On("DeleteInvitationsByInviter", userID).Return(nil)
+	refreshTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	passwordResetTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	personalAccessTokenRepository.On("DeleteByUserID", userID).Return(nil)
+	userIdentityRepository.On("DeleteByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteRecoveryCodesByUserID", userID).Return(nil)
+	twoFactorRepository.On("DeleteByUserID", userID).Return(nil)
+	assetService.On("GetByEntity", entities.NewIdentifiableEntity(userID), mock.Anything).Return([]*entities.Asset{userAsset}, nil)
+	assetService.On("Delete", userAsset).Return(repositories.ErrStoredFileRepositoryCanNotDeleteFile)
+
+	err := service.Delete(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotDeleteFile)
+	refreshTokenRepository.AssertExpectations(t)
+	passwordResetTokenRepository.AssertExpectations(t)
+	personalAccessTokenRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	twoFactorRepository.AssertExpectations(t)
+	webhookRepository.AssertExpectations(t)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	householdRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	userRepository.AssertNotCalled(t, "Delete", userID)
+}
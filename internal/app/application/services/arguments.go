@@ -1,6 +1,15 @@
 package services
 
+import "io"
+
 type PageFilter struct {
 	Page int
 	Size int
 }
+
+type FileUpload struct {
+	Name        string
+	Size        int64
+	ContentType string
+	Content     io.Reader
+}
@@ -1,59 +1,208 @@
 package services
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/stretchr/testify/mock"
-	"os"
+	"io"
+	"path/filepath"
+	"time"
+)
+
+// storedFileMaxAttempts is how many times a transaction that changes a stored
+// file runs when another one changes the same file at the same time.
+const storedFileMaxAttempts = 3
+
+var (
+	ErrAssetServiceAssetNotFound    = errors.New("asset not found")
+	ErrAssetServiceFileSizeMismatch = errors.New("uploaded file size does not match")
 )
 
 type AssetServiceInterface interface {
-	CreateFromFile(file *os.File, entity entities.Entity) (*entities.Asset, error)
+	CreateFromFile(file *FileUpload, entity entities.Entity) (*entities.Asset, error)
 	GetUrl(asset *entities.Asset) string
 	GetByEntity(entity entities.Entity, pageFilter *PageFilter) ([]*entities.Asset, error)
 	Delete(asset *entities.Asset) error
 	GetByEntities(entities []entities.Entity) ([]*entities.Asset, error)
-	UpdateByEntity(entity entities.Entity, file *os.File) (*entities.Asset, error)
+	UpdateByEntity(entity entities.Entity, file *FileUpload) (*entities.Asset, error)
+	GetByID(id string) (*entities.Asset, error)
+	GetContent(assetID string, userID string) (*entities.Asset, io.ReadSeekCloser, error)
+}
+
+type AssetReconciliationReport struct {
+	FilesWithoutAsset   []services.StoredFile
+	AssetsWithoutFile   []*entities.Asset
+	AssetsWithoutEntity []*entities.Asset
+	Deleted             bool
 }
 
 type AssetService struct {
-	fileManager     services.FileManager
-	assetRepository repositories.AssetRepository
+	fileManager          services.FileManager
+	imageProcessor       services.ImageProcessor
+	assetRepository      repositories.AssetRepository
+	itemRepository       repositories.ItemRepository
+	roomRepository       repositories.RoomRepository
+	boxRepository        repositories.BoxRepository
+	attachmentRepository repositories.AttachmentRepository
+	transactionManager   repositories.TransactionManager
+	householdService     HouseholdServiceInterface
 }
 
 func NewAssetService(
 	fileManager services.FileManager,
+	imageProcessor services.ImageProcessor,
 	assetRepository repositories.AssetRepository,
+	itemRepository repositories.ItemRepository,
+	roomRepository repositories.RoomRepository,
+	boxRepository repositories.BoxRepository,
+	attachmentRepository repositories.AttachmentRepository,
+	transactionManager repositories.TransactionManager,
+	householdService HouseholdServiceInterface,
 ) *AssetService {
 	return &AssetService{
 		fileManager,
+		imageProcessor,
 		assetRepository,
+		itemRepository,
+		roomRepository,
+		boxRepository,
+		attachmentRepository,
+		transactionManager,
+		householdService,
 	}
 }
 
 func (s *AssetService) CreateFromFile(
-	file *os.File,
+	file *FileUpload,
 	entity entities.Entity,
 ) (*entities.Asset, error) {
-	fileID, err := s.fileManager.Upload(file)
+	content, size, err := s.imageProcessor.RemoveMetadata(file.Content, file.Size)
+	if err != nil {
+		return nil, err
+	}
+
+	extension := filepath.Ext(file.Name)
+	hash := sha256.New()
+	counter := &byteCounter{}
+	content = io.TeeReader(content, io.MultiWriter(hash, counter))
+
+	fileID, err := s.fileManager.Upload(content, size, file.ContentType, extension)
 	if err != nil {
 		return nil, err
 	}
 
-	asset, err := entities.NewAssetFromFile(file, fileID, entity)
+	if size >= 0 && counter.count != size {
+		_ = s.fileManager.Delete(fileID, extension)
+		return nil, ErrAssetServiceFileSizeMismatch
+	}
+
+	asset, err := entities.NewAsset(file.Name, counter.count, hex.EncodeToString(hash.Sum(nil)), fileID, entity)
 	if err != nil {
+		_ = s.fileManager.Delete(fileID, extension)
 		return nil, err
 	}
 
-	err = s.assetRepository.Create(asset)
+	uploadedFileID := fileID
+	err = s.storedFileTransaction(func(tx repositories.Transaction) error {
+		asset.FileID = uploadedFileID
+		return s.createWithStoredFile(tx, asset)
+	})
 	if err != nil {
+		s.deleteFile(uploadedFileID, extension)
 		return nil, err
 	}
 
+	if asset.FileID != uploadedFileID {
+		s.deleteFile(uploadedFileID, extension)
+	}
+
 	return asset, nil
 }
 
+// createWithStoredFile points the asset to a stored file with the same
+// content when there is one, otherwise its own upload becomes a stored file.
+// The hash is only known once the content has been streamed, so the upload
+// can not be skipped. The stored file stays locked until the asset is saved,
+// so a Delete running at the same time can not remove it. Two first uploads
+// of the same content can not both create it, the unique hash makes the
+// second one retry and reuse the first.
+func (s *AssetService) createWithStoredFile(tx repositories.Transaction, asset *entities.Asset) error {
+	storedFile, err := tx.StoredFileRepository().GetByHashForUpdate(asset.Hash, asset.Extension)
+	if errors.Is(err, repositories.ErrStoredFileRepositoryFileNotFound) {
+		err = tx.StoredFileRepository().Create(entities.NewStoredFile(asset.FileID, asset.Extension, asset.Hash))
+	} else if err == nil {
+		err = s.addReference(tx, storedFile, asset)
+	}
+	if err != nil {
+		return err
+	}
+
+	return tx.AssetRepository().Create(asset)
+}
+
+// addReference points the asset to the stored file. When the file manager
+// lost the object of the stored file, the upload takes its place for every
+// asset of it, so uploading the content again repairs them.
+func (s *AssetService) addReference(
+	tx repositories.Transaction,
+	storedFile *entities.StoredFile,
+	asset *entities.Asset,
+) error {
+	exists, err := s.fileManager.Exists(storedFile.ID, storedFile.Extension)
+	if err != nil {
+		return err
+	}
+
+	if exists {
+		storedFile.AddReference(time.Now())
+		asset.FileID = storedFile.ID
+		return tx.StoredFileRepository().Update(storedFile)
+	}
+
+	err = tx.AssetRepository().UpdateFileID(storedFile.ID, asset.FileID)
+	if err != nil {
+		return err
+	}
+
+	err = tx.StoredFileRepository().Delete(storedFile.ID)
+	if err != nil {
+		return err
+	}
+
+	replacement := entities.NewStoredFile(asset.FileID, asset.Extension, asset.Hash)
+	replacement.ReferenceCount = storedFile.ReferenceCount + 1
+
+	return tx.StoredFileRepository().Create(replacement)
+}
+
+// storedFileTransaction runs fn again when another transaction created or
+// locked the same stored file first, the next run sees what it did.
+func (s *AssetService) storedFileTransaction(fn func(tx repositories.Transaction) error) error {
+	var err error
+	for attempt := 0; attempt < storedFileMaxAttempts; attempt++ {
+		err = s.transactionManager.Transaction(fn)
+		if !errors.Is(err, repositories.ErrStoredFileRepositoryFileConflict) {
+			return err
+		}
+	}
+
+	return err
+}
+
+// deleteFile does not fail the operation, a file left behind is reported by
+// Reconcile.
+func (s *AssetService) deleteFile(fileID string, extension string) {
+	err := s.fileManager.Delete(fileID, extension)
+	if err != nil {
+		logger.LogError(err)
+	}
+}
+
 func (s *AssetService) GetUrl(asset *entities.Asset) string {
 	return s.fileManager.GenerateUrl(asset.FileID, asset.Extension)
 }
@@ -76,18 +225,46 @@ func (s *AssetService) GetByEntity(entity entities.Entity, pageFilter *PageFilte
 	return assets, nil
 }
 
+// Delete removes the asset and, when no other asset shares its stored file,
+// the file too. The stored file is locked while its reference count changes,
+// so it is not removed under an asset that is reusing it.
 func (s *AssetService) Delete(asset *entities.Asset) error {
-	err := s.fileManager.Delete(asset.FileID, asset.Extension)
+	unreferenced := false
+
+	err := s.storedFileTransaction(func(tx repositories.Transaction) error {
+		unreferenced = false
+
+		err := tx.AssetRepository().Delete(asset.ID)
+		if err != nil {
+			return err
+		}
+
+		storedFile, err := tx.StoredFileRepository().GetByIDForUpdate(asset.FileID)
+		if errors.Is(err, repositories.ErrStoredFileRepositoryFileNotFound) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+
+		storedFile.RemoveReference(time.Now())
+		if storedFile.IsReferenced() {
+			return tx.StoredFileRepository().Update(storedFile)
+		}
+
+		unreferenced = true
+
+		return tx.StoredFileRepository().Delete(storedFile.ID)
+	})
 	if err != nil {
 		return err
 	}
 
-	err = s.assetRepository.Delete(asset.ID)
-	if err != nil {
-		return err
+	if !unreferenced {
+		return nil
 	}
 
-	return nil
+	return s.fileManager.Delete(asset.FileID, asset.Extension)
 }
 
 func (s *AssetService) GetByEntities(theEntities []entities.Entity) ([]*entities.Asset, error) {
@@ -129,7 +306,7 @@ func (s *AssetService) GetByEntities(theEntities []entities.Entity) ([]*entities
 	return assets, nil
 }
 
-func (s *AssetService) UpdateByEntity(theEntity entities.Entity, file *os.File) (*entities.Asset, error) {
+func (s *AssetService) UpdateByEntity(theEntity entities.Entity, file *FileUpload) (*entities.Asset, error) {
 	oldAssets, err := s.assetRepository.FindByEntity(theEntity, nil)
 	if err != nil {
 		return nil, err
@@ -150,11 +327,210 @@ func (s *AssetService) UpdateByEntity(theEntity entities.Entity, file *os.File)
 	return asset, nil
 }
 
+func (s *AssetService) GetByID(id string) (*entities.Asset, error) {
+	asset, err := s.assetRepository.GetByID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+// GetContent opens the stored file of an asset the user can see, because it
+// is their own or belongs to one of their households. Other assets are reported
+// as not found, so their existence is not revealed.
+func (s *AssetService) GetContent(assetID string, userID string) (*entities.Asset, io.ReadSeekCloser, error) {
+	asset, err := s.assetRepository.GetByID(assetID)
+	if err != nil {
+		return nil, nil, ErrAssetServiceAssetNotFound
+	}
+
+	err = s.checkCanView(asset, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	content, err := s.fileManager.Open(asset.FileID, asset.Extension)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return asset, content, nil
+}
+
+func (s *AssetService) checkCanView(asset *entities.Asset, userID string) error {
+	householdID, err := s.getHouseholdID(asset)
+	if err != nil {
+		return err
+	}
+
+	if householdID == "" {
+		if asset.EntityID != userID {
+			return ErrAssetServiceAssetNotFound
+		}
+
+		return nil
+	}
+
+	err = s.householdService.CheckCanView(householdID, userID)
+	if errors.Is(err, ErrHouseholdServiceHouseholdNotFound) {
+		return ErrAssetServiceAssetNotFound
+	}
+
+	return err
+}
+
+// getHouseholdID returns the household that owns the entity of the asset, it
+// is empty for assets that belong directly to a user.
+func (s *AssetService) getHouseholdID(asset *entities.Asset) (string, error) {
+	switch asset.EntityName {
+	case (&entities.Item{}).EntityName():
+		item, err := s.itemRepository.GetByID(asset.EntityID)
+		if err != nil {
+			return "", ErrAssetServiceAssetNotFound
+		}
+
+		return item.HouseholdID, nil
+	case (&entities.Room{}).EntityName():
+		room, err := s.roomRepository.GetByID(asset.EntityID)
+		if err != nil {
+			return "", ErrAssetServiceAssetNotFound
+		}
+
+		return room.HouseholdID, nil
+	case (&entities.Box{}).EntityName():
+		box, err := s.boxRepository.GetByID(asset.EntityID)
+		if err != nil {
+			return "", ErrAssetServiceAssetNotFound
+		}
+
+		room, err := s.roomRepository.GetByID(box.RoomID)
+		if err != nil {
+			return "", ErrAssetServiceAssetNotFound
+		}
+
+		return room.HouseholdID, nil
+	case (&entities.Attachment{}).EntityName():
+		attachment, err := s.attachmentRepository.GetByID(asset.EntityID)
+		if err != nil {
+			return "", ErrAssetServiceAssetNotFound
+		}
+
+		item, err := s.itemRepository.GetByID(attachment.ItemID)
+		if err != nil {
+			return "", ErrAssetServiceAssetNotFound
+		}
+
+		return item.HouseholdID, nil
+	default:
+		return "", nil
+	}
+}
+
+// Reconcile compares the stored files against the assets table. Files and assets
+// newer than olderThan are ignored, so uploads still in progress are not reported.
+// When deleteOrphans is true every reported file and asset is removed.
+func (s *AssetService) Reconcile(olderThan time.Time, deleteOrphans bool) (*AssetReconciliationReport, error) {
+	files, err := s.fileManager.List()
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := s.assetRepository.GetAll()
+	if err != nil {
+		return nil, err
+	}
+
+	assetsWithoutEntity, err := s.assetRepository.GetWithoutEntity()
+	if err != nil {
+		return nil, err
+	}
+
+	fileKeys := make(map[string]bool)
+	for _, file := range files {
+		fileKeys[file.ID+file.Extension] = true
+	}
+
+	assetKeys := make(map[string]bool)
+	for _, asset := range assets {
+		assetKeys[asset.FileID+asset.Extension] = true
+	}
+
+	report := &AssetReconciliationReport{
+		FilesWithoutAsset:   make([]services.StoredFile, 0),
+		AssetsWithoutFile:   make([]*entities.Asset, 0),
+		AssetsWithoutEntity: make([]*entities.Asset, 0),
+	}
+
+	for _, file := range files {
+		if !assetKeys[file.ID+file.Extension] && file.LastModified.Before(olderThan) {
+			report.FilesWithoutAsset = append(report.FilesWithoutAsset, file)
+		}
+	}
+
+	for _, asset := range assets {
+		if !fileKeys[asset.FileID+asset.Extension] && asset.CreatedAt.Before(olderThan) {
+			report.AssetsWithoutFile = append(report.AssetsWithoutFile, asset)
+		}
+	}
+
+	for _, asset := range assetsWithoutEntity {
+		if asset.CreatedAt.Before(olderThan) {
+			report.AssetsWithoutEntity = append(report.AssetsWithoutEntity, asset)
+		}
+	}
+
+	if !deleteOrphans {
+		return report, nil
+	}
+
+	for _, file := range report.FilesWithoutAsset {
+		err = s.fileManager.Delete(file.ID, file.Extension)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	deletedAssetIDs := make(map[string]bool)
+	for _, asset := range report.AssetsWithoutFile {
+		err = s.Delete(asset)
+		if err != nil {
+			return nil, err
+		}
+
+		deletedAssetIDs[asset.ID] = true
+	}
+
+	for _, asset := range report.AssetsWithoutEntity {
+		if deletedAssetIDs[asset.ID] {
+			continue
+		}
+
+		err = s.Delete(asset)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	report.Deleted = true
+
+	return report, nil
+}
+
+type byteCounter struct {
+	count int64
+}
+
+func (c *byteCounter) Write(p []byte) (int, error) {
+	c.count += int64(len(p))
+	return len(p), nil
+}
+
 type AssetServiceMock struct {
 	mock.Mock
 }
 
-func (s *AssetServiceMock) CreateFromFile(file *os.File, entity entities.Entity) (*entities.Asset, error) {
+func (s *AssetServiceMock) CreateFromFile(file *FileUpload, entity entities.Entity) (*entities.Asset, error) {
 	args := s.Called(file, entity)
 
 	if args.Get(0) == nil {
@@ -198,7 +574,7 @@ func (s *AssetServiceMock) GetByEntities(
 
 func (s *AssetServiceMock) UpdateByEntity(
 	theEntity entities.Entity,
-	file *os.File,
+	file *FileUpload,
 ) (*entities.Asset, error) {
 	args := s.Called(theEntity, file)
 
@@ -208,3 +584,23 @@ func (s *AssetServiceMock) UpdateByEntity(
 
 	return args.Get(0).(*entities.Asset), args.Error(1)
 }
+
+func (s *AssetServiceMock) GetByID(id string) (*entities.Asset, error) {
+	args := s.Called(id)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(*entities.Asset), args.Error(1)
+}
+
+func (s *AssetServiceMock) GetContent(assetID string, userID string) (*entities.Asset, io.ReadSeekCloser, error) {
+	args := s.Called(assetID, userID)
+
+	if args.Get(0) == nil {
+		return nil, nil, args.Error(2)
+	}
+
+	return args.Get(0).(*entities.Asset), args.Get(1).(io.ReadSeekCloser), args.Error(2)
+}
@@ -1,73 +1,117 @@
 package services
 
 import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
 	serviceStubs "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/labstack/gommon/random"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"os"
-	"path/filepath"
+	"io"
 	"testing"
+	"time"
 )
 
 func TestAssetServiceCreateFromFile(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
-
-	tempFile, err := os.CreateTemp("", "*_"+uuid.NewString())
-	defer tempFile.Close()
-	assert.NoError(t, err)
-
-	name := filepath.Base(tempFile.Name())
-	extension := filepath.Ext(tempFile.Name())
-	info, err := tempFile.Stat()
-	assert.NoError(t, err)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	content := []byte(random.String(255))
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        int64(len(content)),
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader(content),
+	}
+	expectedHash := sha256.Sum256(content)
 
 	entity := entities.NewIdentifiableEntity(uuid.NewString())
 	fileID := uuid.NewString()
 
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", hex.EncodeToString(expectedHash[:]), ".jpg").
+		Return(nil, repositories.ErrStoredFileRepositoryFileNotFound)
+	storedFileRepository.On("Create", mock.MatchedBy(func(storedFile *entities.StoredFile) bool {
+		return storedFile.ID == fileID && storedFile.ReferenceCount == 1
+	})).
+		Return(nil)
 	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
 		Return(nil)
-	fileManager.On("Upload", mock.AnythingOfType("*os.File")).
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".jpg").
+		Run(func(args mock.Arguments) {
+			_, _ = io.Copy(io.Discard, args.Get(0).(io.Reader))
+		}).
 		Return(fileID, nil)
 
-	asset, err := service.CreateFromFile(tempFile, entity)
+	asset, err := service.CreateFromFile(file, entity)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, asset)
 	assert.NotEmpty(t, asset.ID)
-	assert.Equal(t, name, asset.Name)
-	assert.Equal(t, extension, asset.Extension)
-	assert.Equal(t, info.Size(), asset.Size)
+	assert.Equal(t, file.Name, asset.Name)
+	assert.Equal(t, ".jpg", asset.Extension)
+	assert.Equal(t, file.Size, asset.Size)
+	assert.Equal(t, hex.EncodeToString(expectedHash[:]), asset.Hash)
 	assert.Equal(t, fileID, asset.FileID)
 	assert.Equal(t, entity.EntityID(), asset.EntityID)
 	assert.Equal(t, entity.EntityName(), asset.EntityName)
 	assert.NotEmpty(t, asset.CreatedAt)
 	assert.NotEmpty(t, asset.UpdatedAt)
 	assetRepository.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
 	fileManager.AssertExpectations(t)
 }
 
 func TestAssetServiceCreateFromFileErrorFromFileManager(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
-
-	tempFile, err := os.CreateTemp("", "*_"+uuid.NewString())
-	defer tempFile.Close()
-	assert.NoError(t, err)
-
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	file := &FileUpload{
+		Name:        "photo.png",
+		Size:        0,
+		ContentType: "image/png",
+		Content:     bytes.NewReader(nil),
+	}
 	entity := entities.NewIdentifiableEntity(uuid.NewString())
 
-	fileManager.On("Upload", mock.AnythingOfType("*os.File")).
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".png").
 		Return("", errors.New("file manager error"))
 
-	asset, err := service.CreateFromFile(tempFile, entity)
+	asset, err := service.CreateFromFile(file, entity)
 
 	assert.Error(t, err)
 	assert.Nil(t, asset)
@@ -75,23 +119,90 @@ func TestAssetServiceCreateFromFileErrorFromFileManager(t *testing.T) {
 	fileManager.AssertExpectations(t)
 }
 
-func TestAssetServiceCreateFromFileErrorFromFileAssetRepository(t *testing.T) {
+func TestAssetServiceCreateFromFileErrorFileSizeMismatch(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	file := &FileUpload{
+		Name:        "photo.png",
+		Size:        100,
+		ContentType: "image/png",
+		Content:     bytes.NewReader([]byte("truncated")),
+	}
+	entity := entities.NewIdentifiableEntity(uuid.NewString())
+	fileID := uuid.NewString()
 
-	tempFile, err := os.CreateTemp("", "*_"+uuid.NewString())
-	defer tempFile.Close()
-	assert.NoError(t, err)
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".png").
+		Run(func(args mock.Arguments) {
+			_, _ = io.Copy(io.Discard, args.Get(0).(io.Reader))
+		}).
+		Return(fileID, nil)
+	fileManager.On("Delete", fileID, ".png").
+		Return(nil)
+
+	asset, err := service.CreateFromFile(file, entity)
+
+	assert.ErrorIs(t, err, ErrAssetServiceFileSizeMismatch)
+	assert.Nil(t, asset)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
 
+func TestAssetServiceCreateFromFileErrorFromFileAssetRepository(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	file := &FileUpload{
+		Name:        "photo.png",
+		Size:        0,
+		ContentType: "image/png",
+		Content:     bytes.NewReader(nil),
+	}
 	entity := entities.NewIdentifiableEntity(uuid.NewString())
+	fileID := uuid.NewString()
 
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", mock.AnythingOfType("string"), ".png").
+		Return(nil, repositories.ErrStoredFileRepositoryFileNotFound)
+	storedFileRepository.On("Create", mock.MatchedBy(func(storedFile *entities.StoredFile) bool {
+		return storedFile.ID == fileID && storedFile.ReferenceCount == 1
+	})).
+		Return(nil)
 	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
 		Return(errors.New("repository error"))
-	fileManager.On("Upload", mock.AnythingOfType("*os.File")).
-		Return(uuid.NewString(), nil)
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".png").
+		Return(fileID, nil)
+	fileManager.On("Delete", fileID, ".png").
+		Return(nil)
 
-	asset, err := service.CreateFromFile(tempFile, entity)
+	asset, err := service.CreateFromFile(file, entity)
 
 	assert.Error(t, err)
 	assert.Nil(t, asset)
@@ -102,7 +213,14 @@ func TestAssetServiceCreateFromFileErrorFromFileAssetRepository(t *testing.T) {
 func TestAssetServiceGetUrl(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
 
 	asset := &entities.Asset{
 		Extension: ".png",
@@ -122,7 +240,14 @@ func TestAssetServiceGetUrl(t *testing.T) {
 func TestAssetServiceGetByEntityWithoutPageFilter(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
 
 	var expectedAssets []*entities.Asset
 	var repositoryPageFilter *repositories.PageFilter
@@ -142,7 +267,14 @@ func TestAssetServiceGetByEntityWithoutPageFilter(t *testing.T) {
 func TestAssetServiceGetByEntityWithPageFilter(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
 
 	var expectedAssets []*entities.Asset
 	pageFilter := &PageFilter{
@@ -169,7 +301,14 @@ func TestAssetServiceGetByEntityWithPageFilter(t *testing.T) {
 func TestAssetServiceGetByEntityErrorFromAssetRepository(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
 
 	pageFilter := &PageFilter{
 		Page: 1,
@@ -195,7 +334,16 @@ func TestAssetServiceGetByEntityErrorFromAssetRepository(t *testing.T) {
 func TestAssetServiceDelete(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
 
 	asset := &entities.Asset{
 		ID:        uuid.NewString(),
@@ -203,22 +351,86 @@ func TestAssetServiceDelete(t *testing.T) {
 		FileID:    uuid.NewString(),
 	}
 
+	assetRepository.On("Delete", asset.ID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByIDForUpdate", asset.FileID).
+		Return(&entities.StoredFile{ID: asset.FileID, Extension: asset.Extension, ReferenceCount: 1}, nil)
+	storedFileRepository.On("Delete", asset.FileID).
+		Return(nil)
 	fileManager.On("Delete", asset.FileID, asset.Extension).
 		Return(nil)
+
+	err := service.Delete(asset)
+
+	assert.NoError(t, err)
+	assetRepository.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceDeleteKeepsFileStillReferenced(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	asset := &entities.Asset{
+		ID:        uuid.NewString(),
+		Extension: ".png",
+		FileID:    uuid.NewString(),
+	}
+
 	assetRepository.On("Delete", asset.ID).
 		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByIDForUpdate", asset.FileID).
+		Return(&entities.StoredFile{ID: asset.FileID, Extension: asset.Extension, ReferenceCount: 2}, nil)
+	storedFileRepository.On("Update", mock.MatchedBy(func(storedFile *entities.StoredFile) bool {
+		return storedFile.ID == asset.FileID && storedFile.ReferenceCount == 1
+	})).
+		Return(nil)
 
 	err := service.Delete(asset)
 
 	assert.NoError(t, err)
 	assetRepository.AssertExpectations(t)
-	fileManager.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+	storedFileRepository.AssertNotCalled(t, "Delete", asset.FileID)
+	fileManager.AssertNotCalled(t, "Delete", asset.FileID, asset.Extension)
 }
 
 func TestAssetServiceDeleteErrorFromAssetRepository(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
 
 	asset := &entities.Asset{
 		ID:        uuid.NewString(),
@@ -226,8 +438,12 @@ func TestAssetServiceDeleteErrorFromAssetRepository(t *testing.T) {
 		FileID:    uuid.NewString(),
 	}
 
-	fileManager.On("Delete", asset.FileID, asset.Extension).
-		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
 	assetRepository.On("Delete", asset.ID).
 		Return(errors.New("repository error"))
 
@@ -238,10 +454,57 @@ func TestAssetServiceDeleteErrorFromAssetRepository(t *testing.T) {
 	fileManager.AssertExpectations(t)
 }
 
+func TestAssetServiceDeleteErrorGettingStoredFile(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	asset := &entities.Asset{
+		ID:        uuid.NewString(),
+		Extension: ".png",
+		FileID:    uuid.NewString(),
+	}
+
+	assetRepository.On("Delete", asset.ID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByIDForUpdate", asset.FileID).
+		Return(nil, repositories.ErrStoredFileRepositoryCanNotGetFile)
+
+	err := service.Delete(asset)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotGetFile)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
 func TestAssetServiceDeleteErrorFromFileManager(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
 
 	asset := &entities.Asset{
 		ID:        uuid.NewString(),
@@ -249,6 +512,18 @@ func TestAssetServiceDeleteErrorFromFileManager(t *testing.T) {
 		FileID:    uuid.NewString(),
 	}
 
+	assetRepository.On("Delete", asset.ID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByIDForUpdate", asset.FileID).
+		Return(&entities.StoredFile{ID: asset.FileID, Extension: asset.Extension, ReferenceCount: 1}, nil)
+	storedFileRepository.On("Delete", asset.FileID).
+		Return(nil)
 	fileManager.On("Delete", asset.FileID, asset.Extension).
 		Return(errors.New("file manager error"))
 
@@ -262,7 +537,14 @@ func TestAssetServiceDeleteErrorFromFileManager(t *testing.T) {
 func TestAssetServiceGetByEntities(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
 
 	theEntities := []entities.Entity{
 		entities.NewIdentifiableEntity(uuid.NewString()),
@@ -281,7 +563,14 @@ func TestAssetServiceGetByEntities(t *testing.T) {
 func TestAssetServiceGetByEntitiesErrorFromAssetRepository(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
 
 	theEntities := []entities.Entity{
 		entities.NewIdentifiableEntity(uuid.NewString()),
@@ -300,7 +589,16 @@ func TestAssetServiceGetByEntitiesErrorFromAssetRepository(t *testing.T) {
 func TestAssetServiceUpdateByEntity(t *testing.T) {
 	assetRepository := &stub.AssetRepositoryMock{}
 	fileManager := &serviceStubs.FileManagerMock{}
-	service := NewAssetService(fileManager, assetRepository)
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
 
 	entity := entities.NewIdentifiableEntity(uuid.NewString())
 	oldAssetId := uuid.NewString()
@@ -308,8 +606,12 @@ func TestAssetServiceUpdateByEntity(t *testing.T) {
 	oldExtension := ".png"
 	var filter *repositories.PageFilter
 
-	file, err := os.CreateTemp("", "*_"+uuid.NewString())
-	defer file.Close()
+	file := &FileUpload{
+		Name:        "photo.png",
+		Size:        0,
+		ContentType: "image/png",
+		Content:     bytes.NewReader(nil),
+	}
 
 	assetRepository.On("FindByEntity", entity, filter).
 		Return([]*entities.Asset{
@@ -321,9 +623,25 @@ func TestAssetServiceUpdateByEntity(t *testing.T) {
 		}, nil)
 	assetRepository.On("Delete", oldAssetId).
 		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByIDForUpdate", oldFileID).
+		Return(&entities.StoredFile{ID: oldFileID, Extension: oldExtension, ReferenceCount: 1}, nil)
+	storedFileRepository.On("Delete", oldFileID).
+		Return(nil)
+	storedFileRepository.On("GetByHashForUpdate", mock.AnythingOfType("string"), ".png").
+		Return(nil, repositories.ErrStoredFileRepositoryFileNotFound)
+	storedFileRepository.On("Create", mock.AnythingOfType("*entities.StoredFile")).
+		Return(nil)
 	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
 		Return(nil)
-	fileManager.On("Upload", file).
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".png").
 		Return(uuid.NewString(), nil)
 	fileManager.On("Delete", oldFileID, oldExtension).
 		Return(nil)
@@ -333,5 +651,848 @@ func TestAssetServiceUpdateByEntity(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotNil(t, asset)
 	assetRepository.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceGetByID(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	expectedAsset := &entities.Asset{
+		ID:        uuid.NewString(),
+		Extension: ".png",
+		FileID:    uuid.NewString(),
+	}
+
+	assetRepository.On("GetByID", expectedAsset.ID).
+		Return(expectedAsset, nil)
+
+	asset, err := service.GetByID(expectedAsset.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAsset, asset)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceGetByIDErrorFromAssetRepository(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	id := uuid.NewString()
+
+	assetRepository.On("GetByID", id).
+		Return(nil, repositories.ErrAssetRepositoryAssetNotFound)
+
+	asset, err := service.GetByID(id)
+
+	assert.Error(t, err)
+	assert.Nil(t, asset)
+	assert.ErrorIs(t, err, repositories.ErrAssetRepositoryAssetNotFound)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceReconcile(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	olderThan := time.Now().Add(-time.Hour)
+	before := olderThan.Add(-time.Hour)
+	after := olderThan.Add(time.Minute)
+
+	linkedAsset := &entities.Asset{ID: uuid.NewString(), FileID: uuid.NewString(), Extension: ".jpg", CreatedAt: before}
+	assetWithoutFile := &entities.Asset{ID: uuid.NewString(), FileID: uuid.NewString(), Extension: ".png", CreatedAt: before}
+	recentAssetWithoutFile := &entities.Asset{ID: uuid.NewString(), FileID: uuid.NewString(), Extension: ".png", CreatedAt: after}
+	assetWithoutEntity := &entities.Asset{ID: uuid.NewString(), FileID: linkedAsset.FileID, Extension: ".jpg", CreatedAt: before}
+
+	fileWithoutAsset := services.StoredFile{ID: uuid.NewString(), Extension: ".gif", LastModified: before}
+	recentFileWithoutAsset := services.StoredFile{ID: uuid.NewString(), Extension: ".gif", LastModified: after}
+	files := []services.StoredFile{
+		{ID: linkedAsset.FileID, Extension: linkedAsset.Extension, LastModified: before},
+		fileWithoutAsset,
+		recentFileWithoutAsset,
+	}
+
+	fileManager.On("List").Return(files, nil)
+	assetRepository.On("GetAll").
+		Return([]*entities.Asset{linkedAsset, assetWithoutFile, recentAssetWithoutFile}, nil)
+	assetRepository.On("GetWithoutEntity").
+		Return([]*entities.Asset{assetWithoutEntity}, nil)
+
+	report, err := service.Reconcile(olderThan, false)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []services.StoredFile{fileWithoutAsset}, report.FilesWithoutAsset)
+	assert.Equal(t, []*entities.Asset{assetWithoutFile}, report.AssetsWithoutFile)
+	assert.Equal(t, []*entities.Asset{assetWithoutEntity}, report.AssetsWithoutEntity)
+	assert.False(t, report.Deleted)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceReconcileDeletingOrphans(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	olderThan := time.Now()
+	before := olderThan.Add(-time.Hour)
+
+	assetWithoutFile := &entities.Asset{ID: uuid.NewString(), FileID: uuid.NewString(), Extension: ".png", CreatedAt: before}
+	assetWithoutEntity := &entities.Asset{ID: uuid.NewString(), FileID: uuid.NewString(), Extension: ".jpg", CreatedAt: before}
+	fileWithoutAsset := services.StoredFile{ID: uuid.NewString(), Extension: ".gif", LastModified: before}
+
+	fileManager.On("List").Return([]services.StoredFile{
+		fileWithoutAsset,
+		{ID: assetWithoutEntity.FileID, Extension: assetWithoutEntity.Extension, LastModified: before},
+	}, nil)
+	assetRepository.On("GetAll").
+		Return([]*entities.Asset{assetWithoutFile, assetWithoutEntity}, nil)
+	assetRepository.On("GetWithoutEntity").
+		Return([]*entities.Asset{assetWithoutFile, assetWithoutEntity}, nil)
+	fileManager.On("Delete", fileWithoutAsset.ID, fileWithoutAsset.Extension).Return(nil)
+	assetRepository.On("Delete", assetWithoutFile.ID).Return(nil).Once()
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByIDForUpdate", assetWithoutFile.FileID).
+		Return(nil, repositories.ErrStoredFileRepositoryFileNotFound)
+	storedFileRepository.On("GetByIDForUpdate", assetWithoutEntity.FileID).
+		Return(&entities.StoredFile{ID: assetWithoutEntity.FileID, Extension: assetWithoutEntity.Extension, ReferenceCount: 1}, nil)
+	storedFileRepository.On("Delete", assetWithoutEntity.FileID).
+		Return(nil)
+	fileManager.On("Delete", assetWithoutEntity.FileID, assetWithoutEntity.Extension).Return(nil)
+	assetRepository.On("Delete", assetWithoutEntity.ID).Return(nil).Once()
+
+	report, err := service.Reconcile(olderThan, true)
+
+	assert.NoError(t, err)
+	assert.Len(t, report.FilesWithoutAsset, 1)
+	assert.Len(t, report.AssetsWithoutFile, 1)
+	assert.Len(t, report.AssetsWithoutEntity, 2)
+	assert.True(t, report.Deleted)
+	assetRepository.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+	fileManager.AssertNotCalled(t, "Delete", assetWithoutFile.FileID, assetWithoutFile.Extension)
+}
+
+func TestAssetServiceReconcileErrorFromFileManager(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	fileManager.On("List").Return(nil, services.ErrFileManagerCanNotListFiles)
+
+	report, err := service.Reconcile(time.Now(), false)
+
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, services.ErrFileManagerCanNotListFiles)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceReconcileErrorFromAssetRepository(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	fileManager.On("List").Return([]services.StoredFile{}, nil)
+	assetRepository.On("GetAll").Return(nil, repositories.ErrAssetRepositoryCanNotGetAssets)
+
+	report, err := service.Reconcile(time.Now(), false)
+
+	assert.Nil(t, report)
+	assert.ErrorIs(t, err, repositories.ErrAssetRepositoryCanNotGetAssets)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+type readSeekNopCloser struct {
+	io.ReadSeeker
+}
+
+func (readSeekNopCloser) Close() error {
+	return nil
+}
+
+func TestAssetServiceGetContentOfRoomAsset(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		FileID:     uuid.NewString(),
+		Extension:  ".jpg",
+		EntityID:   room.ID,
+		EntityName: room.EntityName(),
+	}
+	content := readSeekNopCloser{bytes.NewReader([]byte("photo"))}
+
+	assetRepository.On("GetByID", asset.ID).Return(asset, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(nil)
+	fileManager.On("Open", asset.FileID, asset.Extension).Return(content, nil)
+
+	gotAsset, gotContent, err := service.GetContent(asset.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, gotAsset)
+	assert.Equal(t, content, gotContent)
+	assetRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceGetContentOfBoxAsset(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	box := &entities.Box{ID: uuid.NewString(), RoomID: room.ID}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		FileID:     uuid.NewString(),
+		Extension:  ".png",
+		EntityID:   box.ID,
+		EntityName: box.EntityName(),
+	}
+	content := readSeekNopCloser{bytes.NewReader([]byte("photo"))}
+
+	assetRepository.On("GetByID", asset.ID).Return(asset, nil)
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(nil)
+	fileManager.On("Open", asset.FileID, asset.Extension).Return(content, nil)
+
+	gotAsset, gotContent, err := service.GetContent(asset.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, gotAsset)
+	assert.Equal(t, content, gotContent)
+	assetRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceGetContentOfUserAsset(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	userID := uuid.NewString()
+	entity := entities.NewIdentifiableEntity(userID)
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		FileID:     uuid.NewString(),
+		Extension:  ".pdf",
+		EntityID:   entity.EntityID(),
+		EntityName: entity.EntityName(),
+	}
+	content := readSeekNopCloser{bytes.NewReader([]byte("manual"))}
+
+	assetRepository.On("GetByID", asset.ID).Return(asset, nil)
+	fileManager.On("Open", asset.FileID, asset.Extension).Return(content, nil)
+
+	gotAsset, _, err := service.GetContent(asset.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, gotAsset)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceGetContentOfAttachmentAsset(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	attachment := &entities.Attachment{ID: uuid.NewString(), ItemID: item.ID, Kind: entities.AttachmentKindReceipt}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		FileID:     uuid.NewString(),
+		Extension:  ".pdf",
+		EntityID:   attachment.ID,
+		EntityName: attachment.EntityName(),
+	}
+	content := readSeekNopCloser{bytes.NewReader([]byte("receipt"))}
+
+	assetRepository.On("GetByID", asset.ID).Return(asset, nil)
+	attachmentRepository.On("GetByID", attachment.ID).Return(attachment, nil)
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanView", item.HouseholdID, userID).Return(nil)
+	fileManager.On("Open", asset.FileID, asset.Extension).Return(content, nil)
+
+	gotAsset, gotContent, err := service.GetContent(asset.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, gotAsset)
+	assert.Equal(t, content, gotContent)
+	assetRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceGetContentErrorAssetOfAnotherHousehold(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		FileID:     uuid.NewString(),
+		Extension:  ".jpg",
+		EntityID:   item.ID,
+		EntityName: item.EntityName(),
+	}
+
+	assetRepository.On("GetByID", asset.ID).Return(asset, nil)
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanView", item.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	gotAsset, gotContent, err := service.GetContent(asset.ID, userID)
+
+	assert.ErrorIs(t, err, ErrAssetServiceAssetNotFound)
+	assert.Nil(t, gotAsset)
+	assert.Nil(t, gotContent)
+	assetRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceGetContentErrorAssetNotFound(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	assetID := uuid.NewString()
+
+	assetRepository.On("GetByID", assetID).Return(nil, repositories.ErrAssetRepositoryAssetNotFound)
+
+	gotAsset, gotContent, err := service.GetContent(assetID, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrAssetServiceAssetNotFound)
+	assert.Nil(t, gotAsset)
+	assert.Nil(t, gotContent)
+	assetRepository.AssertExpectations(t)
+}
+
+func TestAssetServiceGetContentErrorFromFileManager(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	userID := uuid.NewString()
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		FileID:     uuid.NewString(),
+		Extension:  ".jpg",
+		EntityID:   userID,
+		EntityName: entities.NewIdentifiableEntity(userID).EntityName(),
+	}
+
+	assetRepository.On("GetByID", asset.ID).Return(asset, nil)
+	fileManager.On("Open", asset.FileID, asset.Extension).Return(nil, services.ErrFileManagerCanNotOpenFile)
+
+	gotAsset, gotContent, err := service.GetContent(asset.ID, userID)
+
+	assert.ErrorIs(t, err, services.ErrFileManagerCanNotOpenFile)
+	assert.Nil(t, gotAsset)
+	assert.Nil(t, gotContent)
+	assetRepository.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+}
+
+func TestAssetServiceCreateFromFileUploadsProcessedImage(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        20,
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader([]byte("photo with metadata!")),
+	}
+	processed := []byte("photo")
+	expectedHash := sha256.Sum256(processed)
+	fileID := uuid.NewString()
+
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(bytes.NewReader(processed), int64(len(processed)), nil)
+	fileManager.On("Upload", mock.Anything, int64(len(processed)), file.ContentType, ".jpg").
+		Run(func(args mock.Arguments) {
+			_, _ = io.Copy(io.Discard, args.Get(0).(io.Reader))
+		}).
+		Return(fileID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", hex.EncodeToString(expectedHash[:]), ".jpg").
+		Return(nil, repositories.ErrStoredFileRepositoryFileNotFound)
+	storedFileRepository.On("Create", mock.MatchedBy(func(storedFile *entities.StoredFile) bool {
+		return storedFile.ID == fileID && storedFile.ReferenceCount == 1
+	})).
+		Return(nil)
+	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
+		Return(nil)
+
+	asset, err := service.CreateFromFile(file, entities.NewIdentifiableEntity(uuid.NewString()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(processed)), asset.Size)
+	assert.Equal(t, hex.EncodeToString(expectedHash[:]), asset.Hash)
+	imageProcessor.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+	assetRepository.AssertExpectations(t)
+}
+
+func TestAssetServiceCreateFromFileErrorFromImageProcessor(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        3,
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader([]byte{0xFF, 0xD8, 0xFF}),
+	}
+
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(nil, int64(0), services.ErrImageProcessorCanNotProcessImage)
+
+	asset, err := service.CreateFromFile(file, entities.NewIdentifiableEntity(uuid.NewString()))
+
+	assert.ErrorIs(t, err, services.ErrImageProcessorCanNotProcessImage)
+	assert.Nil(t, asset)
+	imageProcessor.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+	assetRepository.AssertExpectations(t)
+}
+
+func TestAssetServiceCreateFromFileReusesStoredFileWithSameContent(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	content := []byte("same product photo")
+	contentHash := sha256.Sum256(content)
+	hash := hex.EncodeToString(contentHash[:])
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        int64(len(content)),
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader(content),
+	}
+	existingAsset := &entities.Asset{
+		ID:        uuid.NewString(),
+		FileID:    uuid.NewString(),
+		Extension: ".jpg",
+		Hash:      hash,
+	}
+	uploadedFileID := uuid.NewString()
+
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".jpg").
+		Run(func(args mock.Arguments) {
+			_, _ = io.Copy(io.Discard, args.Get(0).(io.Reader))
+		}).
+		Return(uploadedFileID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", hash, ".jpg").
+		Return(&entities.StoredFile{ID: existingAsset.FileID, Extension: ".jpg", Hash: hash, ReferenceCount: 1}, nil)
+	fileManager.On("Exists", existingAsset.FileID, ".jpg").
+		Return(true, nil)
+	storedFileRepository.On("Update", mock.MatchedBy(func(storedFile *entities.StoredFile) bool {
+		return storedFile.ID == existingAsset.FileID && storedFile.ReferenceCount == 2
+	})).
+		Return(nil)
+	fileManager.On("Delete", uploadedFileID, ".jpg").
+		Return(nil)
+	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
+		Return(nil)
+
+	asset, err := service.CreateFromFile(file, entities.NewIdentifiableEntity(uuid.NewString()))
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, existingAsset.ID, asset.ID)
+	assert.Equal(t, existingAsset.FileID, asset.FileID)
+	assert.Equal(t, hash, asset.Hash)
+	imageProcessor.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+	assetRepository.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+}
+
+func TestAssetServiceCreateFromFileReusesStoredFileWhenCopyCanNotBeDeleted(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        0,
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader(nil),
+	}
+	existingAsset := &entities.Asset{ID: uuid.NewString(), FileID: uuid.NewString(), Extension: ".jpg"}
+	uploadedFileID := uuid.NewString()
+
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".jpg").
+		Return(uploadedFileID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", mock.AnythingOfType("string"), ".jpg").
+		Return(&entities.StoredFile{ID: existingAsset.FileID, Extension: ".jpg", ReferenceCount: 1}, nil)
+	fileManager.On("Exists", existingAsset.FileID, ".jpg").
+		Return(true, nil)
+	storedFileRepository.On("Update", mock.AnythingOfType("*entities.StoredFile")).
+		Return(nil)
+	fileManager.On("Delete", uploadedFileID, ".jpg").
+		Return(services.ErrFileManagerCanNotDeleteFile)
+	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
+		Return(nil)
+
+	asset, err := service.CreateFromFile(file, entities.NewIdentifiableEntity(uuid.NewString()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, existingAsset.FileID, asset.FileID)
+	imageProcessor.AssertExpectations(t)
+	fileManager.AssertExpectations(t)
+	assetRepository.AssertExpectations(t)
+}
+
+func TestAssetServiceCreateFromFileReplacesStoredFileWithoutObject(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	content := []byte("lost product photo")
+	contentHash := sha256.Sum256(content)
+	hash := hex.EncodeToString(contentHash[:])
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        int64(len(content)),
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader(content),
+	}
+	lostFileID := uuid.NewString()
+	uploadedFileID := uuid.NewString()
+
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".jpg").
+		Run(func(args mock.Arguments) {
+			_, _ = io.Copy(io.Discard, args.Get(0).(io.Reader))
+		}).
+		Return(uploadedFileID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", hash, ".jpg").
+		Return(&entities.StoredFile{ID: lostFileID, Extension: ".jpg", Hash: hash, ReferenceCount: 2}, nil)
+	fileManager.On("Exists", lostFileID, ".jpg").
+		Return(false, nil)
+	assetRepository.On("UpdateFileID", lostFileID, uploadedFileID).
+		Return(nil)
+	storedFileRepository.On("Delete", lostFileID).
+		Return(nil)
+	storedFileRepository.On("Create", mock.MatchedBy(func(storedFile *entities.StoredFile) bool {
+		return storedFile.ID == uploadedFileID && storedFile.Hash == hash && storedFile.ReferenceCount == 3
+	})).
+		Return(nil)
+	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
+		Return(nil)
+
+	asset, err := service.CreateFromFile(file, entities.NewIdentifiableEntity(uuid.NewString()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, uploadedFileID, asset.FileID)
+	fileManager.AssertExpectations(t)
+	assetRepository.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+	fileManager.AssertNotCalled(t, "Delete", mock.Anything, mock.Anything)
+}
+
+func TestAssetServiceCreateFromFileRetriesWhenStoredFileIsCreatedAtTheSameTime(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        0,
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader(nil),
+	}
+	otherFileID := uuid.NewString()
+	uploadedFileID := uuid.NewString()
+
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".jpg").
+		Return(uploadedFileID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", mock.AnythingOfType("string"), ".jpg").
+		Return(nil, repositories.ErrStoredFileRepositoryFileNotFound).
+		Once()
+	storedFileRepository.On("Create", mock.AnythingOfType("*entities.StoredFile")).
+		Return(repositories.ErrStoredFileRepositoryFileConflict).
+		Once()
+	storedFileRepository.On("GetByHashForUpdate", mock.AnythingOfType("string"), ".jpg").
+		Return(&entities.StoredFile{ID: otherFileID, Extension: ".jpg", ReferenceCount: 1}, nil).
+		Once()
+	fileManager.On("Exists", otherFileID, ".jpg").
+		Return(true, nil)
+	storedFileRepository.On("Update", mock.MatchedBy(func(storedFile *entities.StoredFile) bool {
+		return storedFile.ID == otherFileID && storedFile.ReferenceCount == 2
+	})).
+		Return(nil)
+	assetRepository.On("Create", mock.AnythingOfType("*entities.Asset")).
+		Return(nil)
+	fileManager.On("Delete", uploadedFileID, ".jpg").
+		Return(nil)
+
+	asset, err := service.CreateFromFile(file, entities.NewIdentifiableEntity(uuid.NewString()))
+
+	assert.NoError(t, err)
+	assert.Equal(t, otherFileID, asset.FileID)
+	fileManager.AssertExpectations(t)
+	assetRepository.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+	transactionManager.AssertNumberOfCalls(t, "Transaction", 2)
+}
+
+func TestAssetServiceCreateFromFileErrorGettingStoredFile(t *testing.T) {
+	assetRepository := &stub.AssetRepositoryMock{}
+	fileManager := &serviceStubs.FileManagerMock{}
+	imageProcessor := &serviceStubs.ImageProcessorMock{}
+	itemRepository := &stub.ItemRepositoryMock{}
+	roomRepository := &stub.RoomRepositoryMock{}
+	boxRepository := &stub.BoxRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	transactionManager := &stub.TransactionManagerMock{}
+	householdService := &HouseholdServiceMock{}
+	service := NewAssetService(fileManager, imageProcessor, assetRepository, itemRepository, roomRepository, boxRepository, attachmentRepository, transactionManager, householdService)
+	transaction := &stub.TransactionMock{}
+	storedFileRepository := &stub.StoredFileRepositoryMock{}
+
+	file := &FileUpload{
+		Name:        "photo.jpg",
+		Size:        0,
+		ContentType: "image/jpeg",
+		Content:     bytes.NewReader(nil),
+	}
+	uploadedFileID := uuid.NewString()
+
+	imageProcessor.On("RemoveMetadata", file.Content, file.Size).
+		Return(file.Content, file.Size, nil)
+	fileManager.On("Upload", mock.Anything, file.Size, file.ContentType, ".jpg").
+		Return(uploadedFileID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("AssetRepository").
+		Return(assetRepository)
+	transaction.On("StoredFileRepository").
+		Return(storedFileRepository)
+	storedFileRepository.On("GetByHashForUpdate", mock.AnythingOfType("string"), ".jpg").
+		Return(nil, repositories.ErrStoredFileRepositoryCanNotGetFile)
+	fileManager.On("Delete", uploadedFileID, ".jpg").
+		Return(nil)
+
+	asset, err := service.CreateFromFile(file, entities.NewIdentifiableEntity(uuid.NewString()))
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotGetFile)
+	assert.Nil(t, asset)
 	fileManager.AssertExpectations(t)
+	storedFileRepository.AssertExpectations(t)
+	assetRepository.AssertNotCalled(t, "Create", mock.Anything)
 }
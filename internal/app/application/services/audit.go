@@ -0,0 +1,206 @@
+package services
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+// AuditActor is the user behind an audited action and where the request
+// came from.
+type AuditActor struct {
+	UserID    string
+	IPAddress string
+	UserAgent string
+}
+
+type AuditLogFilter struct {
+	ActorID     string
+	Action      string
+	EntityType  string
+	EntityID    string
+	HouseholdID string
+	From        *time.Time
+	To          *time.Time
+}
+
+// AuditServiceInterface is used by the services that record actions that do
+// not go through a controller, like the failed logins.
+type AuditServiceInterface interface {
+	Record(
+		actor AuditActor,
+		action string,
+		entityType string,
+		entityID string,
+		householdID string,
+		before interface{},
+		after interface{},
+	) error
+}
+
+type AuditService struct {
+	auditLogRepository repositories.AuditLogRepository
+	householdService   HouseholdServiceInterface
+}
+
+func NewAuditService(
+	auditLogRepository repositories.AuditLogRepository,
+	householdService HouseholdServiceInterface,
+) *AuditService {
+	return &AuditService{
+		auditLogRepository,
+		householdService,
+	}
+}
+
+// Record appends an action to the audit log. The household is empty for the
+// actions on the account of the actor, only they can see those entries.
+func (s *AuditService) Record(
+	actor AuditActor,
+	action string,
+	entityType string,
+	entityID string,
+	householdID string,
+	before interface{},
+	after interface{},
+) error {
+	auditLog, err := entities.NewAuditLog(
+		actor.UserID,
+		action,
+		entityType,
+		entityID,
+		householdID,
+		before,
+		after,
+		actor.IPAddress,
+		actor.UserAgent,
+	)
+	if err != nil {
+		return err
+	}
+
+	return s.auditLogRepository.Create(auditLog)
+}
+
+// GetAll returns the newest entries first. A user sees what happened in
+// their households and their own actions on their account.
+func (s *AuditService) GetAll(
+	userID string,
+	filter AuditLogFilter,
+	pageFilter PageFilter,
+) ([]*entities.AuditLog, error) {
+	queryFilter, err := s.makeGetAllQueryFilter(userID, filter)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.auditLogRepository.GetByQueryFilters(*queryFilter, &repositories.PageFilter{
+		Offset: (pageFilter.Page - 1) * pageFilter.Size,
+		Limit:  pageFilter.Size,
+	})
+}
+
+func (s *AuditService) CountAll(userID string, filter AuditLogFilter) (int64, error) {
+	queryFilter, err := s.makeGetAllQueryFilter(userID, filter)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.auditLogRepository.CountByQueryFilters(*queryFilter)
+}
+
+func (s *AuditService) makeGetAllQueryFilter(
+	userID string,
+	filter AuditLogFilter,
+) (*repositories.QueryFilter, error) {
+	householdIDs, err := s.householdService.GetVisibleHouseholdIDs(filter.HouseholdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	visibleConditions := []repositories.Condition{
+		{
+			Field:    entities.AuditLogHouseholdIDField,
+			Operator: repositories.InComparisonOperator,
+			Value:    householdIDs,
+		},
+	}
+	if filter.HouseholdID == "" {
+		visibleConditions = append(visibleConditions, repositories.Condition{
+			Field:    entities.AuditLogActorIDField,
+			Operator: repositories.EqualComparisonOperator,
+			Value:    userID,
+		})
+	}
+
+	queryFilter := &repositories.QueryFilter{
+		ConditionGroups: []repositories.ConditionGroup{
+			{
+				Operator:   repositories.OrLogicalOperator,
+				Conditions: visibleConditions,
+			},
+		},
+	}
+
+	var conditions []repositories.Condition
+	for _, equal := range []struct {
+		field string
+		value string
+	}{
+		{entities.AuditLogActorIDField, filter.ActorID},
+		{entities.AuditLogActionField, filter.Action},
+		{entities.AuditLogEntityTypeField, filter.EntityType},
+		{entities.AuditLogEntityIDField, filter.EntityID},
+	} {
+		if equal.value != "" {
+			conditions = append(conditions, repositories.Condition{
+				Field:    equal.field,
+				Operator: repositories.EqualComparisonOperator,
+				Value:    equal.value,
+			})
+		}
+	}
+
+	if filter.From != nil {
+		conditions = append(conditions, repositories.Condition{
+			Field:    entities.AuditLogHappenedAtField,
+			Operator: repositories.GreaterOrEqualComparisonOperator,
+			Value:    *filter.From,
+		})
+	}
+
+	if filter.To != nil {
+		conditions = append(conditions, repositories.Condition{
+			Field:    entities.AuditLogHappenedAtField,
+			Operator: repositories.LessOrEqualComparisonOperator,
+			Value:    *filter.To,
+		})
+	}
+
+	if len(conditions) > 0 {
+		queryFilter.ConditionGroups = append(queryFilter.ConditionGroups, repositories.ConditionGroup{
+			Operator:   repositories.AndLogicalOperator,
+			Conditions: conditions,
+		})
+	}
+
+	return queryFilter, nil
+}
+
+type AuditServiceMock struct {
+	mock.Mock
+}
+
+func (m *AuditServiceMock) Record(
+	actor AuditActor,
+	action string,
+	entityType string,
+	entityID string,
+	householdID string,
+	before interface{},
+	after interface{},
+) error {
+	args := m.Called(actor, action, entityType, entityID, householdID, before, after)
+	return args.Error(0)
+}
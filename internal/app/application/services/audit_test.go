@@ -0,0 +1,194 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestAuditServiceRecord(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	actor := AuditActor{
+		UserID:    uuid.NewString(),
+		IPAddress: "127.0.0.1",
+		UserAgent: "curl/8.0",
+	}
+	room := &entities.Room{ID: uuid.NewString(), Name: "Kitchen", HouseholdID: uuid.NewString()}
+
+	auditLogRepository.On("Create", mock.MatchedBy(func(auditLog *entities.AuditLog) bool {
+		return auditLog.ActorID == actor.UserID &&
+			auditLog.Action == entities.AuditActionCreate &&
+			auditLog.EntityType == "room" &&
+			auditLog.EntityID == room.ID &&
+			*auditLog.HouseholdID == room.HouseholdID &&
+			auditLog.Before == nil &&
+			auditLog.After != nil &&
+			auditLog.IPAddress == actor.IPAddress &&
+			auditLog.UserAgent == actor.UserAgent
+	})).Return(nil)
+
+	err := auditService.Record(actor, entities.AuditActionCreate, room.EntityName(), room.ID, room.HouseholdID, nil, room)
+
+	assert.NoError(t, err)
+	auditLogRepository.AssertExpectations(t)
+}
+
+func TestAuditServiceRecordErrorInvalidAuditLog(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	err := auditService.Record(AuditActor{}, entities.AuditActionLogIn, entities.AuditEntityTypeUser, "", "", nil, nil)
+
+	assert.ErrorIs(t, err, entities.ErrAuditLogActorIDShouldNotBeEmpty)
+	auditLogRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuditServiceRecordErrorCanNotCreate(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	userID := uuid.NewString()
+
+	auditLogRepository.On("Create", mock.AnythingOfType("*entities.AuditLog")).
+		Return(repositories.ErrAuditLogRepositoryCanNotCreateAuditLog)
+
+	err := auditService.Record(
+		AuditActor{UserID: userID},
+		entities.AuditActionLogIn,
+		entities.AuditEntityTypeUser,
+		userID,
+		"",
+		nil,
+		nil,
+	)
+
+	assert.ErrorIs(t, err, repositories.ErrAuditLogRepositoryCanNotCreateAuditLog)
+	auditLogRepository.AssertExpectations(t)
+}
+
+func TestAuditServiceGetAll(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	userID := uuid.NewString()
+	householdIDs := []string{uuid.NewString()}
+	from := time.Now().Add(-24 * time.Hour)
+	auditLogs := []*entities.AuditLog{{ID: uuid.NewString()}}
+
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return(householdIDs, nil)
+	auditLogRepository.On("GetByQueryFilters", mock.AnythingOfType("repositories.QueryFilter"), &repositories.PageFilter{
+		Offset: 10,
+		Limit:  10,
+	}).Return(auditLogs, nil)
+
+	result, err := auditService.GetAll(
+		userID,
+		AuditLogFilter{Action: entities.AuditActionDelete, EntityType: "box", From: &from},
+		PageFilter{Page: 2, Size: 10},
+	)
+
+	assert.NoError(t, err)
+	assert.Equal(t, auditLogs, result)
+	householdService.AssertExpectations(t)
+	auditLogRepository.AssertExpectations(t)
+
+	queryFilter := auditLogRepository.Calls[0].Arguments.Get(0).(repositories.QueryFilter)
+	assert.Len(t, queryFilter.ConditionGroups, 2)
+	assert.Equal(t, repositories.OrLogicalOperator, queryFilter.ConditionGroups[0].Operator)
+	assert.Equal(t, []repositories.Condition{
+		{Field: entities.AuditLogHouseholdIDField, Operator: repositories.InComparisonOperator, Value: householdIDs},
+		{Field: entities.AuditLogActorIDField, Operator: repositories.EqualComparisonOperator, Value: userID},
+	}, queryFilter.ConditionGroups[0].Conditions)
+	assert.Equal(t, []repositories.Condition{
+		{Field: entities.AuditLogActionField, Operator: repositories.EqualComparisonOperator, Value: entities.AuditActionDelete},
+		{Field: entities.AuditLogEntityTypeField, Operator: repositories.EqualComparisonOperator, Value: "box"},
+		{Field: entities.AuditLogHappenedAtField, Operator: repositories.GreaterOrEqualComparisonOperator, Value: from},
+	}, queryFilter.ConditionGroups[1].Conditions)
+}
+
+func TestAuditServiceGetAllByHousehold(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	householdService.On("GetVisibleHouseholdIDs", householdID, userID).Return([]string{householdID}, nil)
+	auditLogRepository.On("GetByQueryFilters", mock.AnythingOfType("repositories.QueryFilter"), mock.AnythingOfType("*repositories.PageFilter")).
+		Return([]*entities.AuditLog{}, nil)
+
+	_, err := auditService.GetAll(userID, AuditLogFilter{HouseholdID: householdID}, PageFilter{Page: 1, Size: 10})
+
+	assert.NoError(t, err)
+	queryFilter := auditLogRepository.Calls[0].Arguments.Get(0).(repositories.QueryFilter)
+	assert.Len(t, queryFilter.ConditionGroups, 1)
+	assert.Equal(t, []repositories.Condition{
+		{Field: entities.AuditLogHouseholdIDField, Operator: repositories.InComparisonOperator, Value: []string{householdID}},
+	}, queryFilter.ConditionGroups[0].Conditions)
+}
+
+func TestAuditServiceGetAllErrorHouseholdNotFound(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	householdService.On("GetVisibleHouseholdIDs", householdID, userID).Return(nil, ErrHouseholdServiceHouseholdNotFound)
+
+	result, err := auditService.GetAll(userID, AuditLogFilter{HouseholdID: householdID}, PageFilter{Page: 1, Size: 10})
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceHouseholdNotFound)
+	assert.Nil(t, result)
+	auditLogRepository.AssertNotCalled(t, "GetByQueryFilters", mock.Anything, mock.Anything)
+}
+
+func TestAuditServiceCountAll(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	userID := uuid.NewString()
+	to := time.Now()
+
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{}, nil)
+	auditLogRepository.On("CountByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).Return(int64(4), nil)
+
+	count, err := auditService.CountAll(userID, AuditLogFilter{To: &to})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(4), count)
+	queryFilter := auditLogRepository.Calls[0].Arguments.Get(0).(repositories.QueryFilter)
+	assert.Equal(t, []repositories.Condition{
+		{Field: entities.AuditLogHappenedAtField, Operator: repositories.LessOrEqualComparisonOperator, Value: to},
+	}, queryFilter.ConditionGroups[1].Conditions)
+}
+
+func TestAuditServiceCountAllError(t *testing.T) {
+	auditLogRepository := new(stub.AuditLogRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	auditService := NewAuditService(auditLogRepository, householdService)
+
+	userID := uuid.NewString()
+
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return(nil, errors.New("database error"))
+
+	count, err := auditService.CountAll(userID, AuditLogFilter{})
+
+	assert.Error(t, err)
+	assert.Equal(t, int64(0), count)
+}
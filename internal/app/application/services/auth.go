@@ -5,68 +5,469 @@ import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"strings"
+	"time"
+)
+
+var (
+	ErrAuthServiceInvalidCredentials         = errors.New("invalid credentials")
+	ErrAuthServiceInvalidRefreshToken        = errors.New("invalid refresh token")
+	ErrAuthServiceSessionRevoked             = errors.New("session was revoked")
+	ErrAuthServiceInvalidPersonalAccessToken = errors.New("invalid personal access token")
+	ErrAuthServiceInvalidMFAToken            = errors.New("invalid or expired mfa token")
+	ErrAuthServiceAccountDeletionRequested   = errors.New("account deletion was requested")
+)
+
+// personalAccessTokenLastUsedPrecision avoids writing the last used time on
+// every request of a script that calls the API in a loop.
+const personalAccessTokenLastUsedPrecision = time.Minute
+
+const (
+	mfaSignaturePrefix = "mfa:"
+	// mfaTokenDuration is the time to type the code after the password.
+	mfaTokenDuration = 5 * time.Minute
 )
 
 type AuthService struct {
-	userRepository repositories.UserRepository
-	tokenGenerator services.TokenGenerator
+	userRepository                repositories.UserRepository
+	refreshTokenRepository        repositories.RefreshTokenRepository
+	personalAccessTokenRepository repositories.PersonalAccessTokenRepository
+	loginThrottleService          LoginThrottleServiceInterface
+	twoFactorService              TwoFactorServiceInterface
+	tokenGenerator                services.TokenGenerator
+	passwordHasher                entities.PasswordHasher
+	signer                        services.Signer
+	refreshTokenDuration          time.Duration
 }
 
 func NewAuthService(
 	userRepository repositories.UserRepository,
+	refreshTokenRepository repositories.RefreshTokenRepository,
+	personalAccessTokenRepository repositories.PersonalAccessTokenRepository,
+	loginThrottleService LoginThrottleServiceInterface,
+	twoFactorService TwoFactorServiceInterface,
 	tokenGenerator services.TokenGenerator,
+	passwordHasher entities.PasswordHasher,
+	signer services.Signer,
+	refreshTokenDuration time.Duration,
 ) *AuthService {
 	return &AuthService{
 		userRepository,
+		refreshTokenRepository,
+		personalAccessTokenRepository,
+		loginThrottleService,
+		twoFactorService,
 		tokenGenerator,
+		passwordHasher,
+		signer,
+		refreshTokenDuration,
 	}
 }
 
-func (s *AuthService) Authenticate(email, password string) (
+// Authenticate answers the same way to unknown emails and wrong passwords,
+// both count as a failed login of the email and of the ip. Passwords stored
+// with an older algorithm or parameters are hashed again on success. When
+// the user has two factor authentication, it only returns an mfa token to
+// exchange with a code in VerifyMFA. Users that requested the deletion of
+// their account can not sign in.
+func (s *AuthService) Authenticate(email, password, ip string) (
 	*struct {
-		User  *entities.User
-		Token string
+		User         *entities.User
+		Token        string
+		RefreshToken string
+		MFAToken     string
 	},
 	error,
 ) {
-	user, err := s.userRepository.FindByEmail(email)
+	err := s.loginThrottleService.Check(email, ip)
 	if err != nil {
 		return nil, err
 	}
 
-	if !user.HasEqualPassword(password) {
-		return nil, errors.New("invalid credentials")
+	user, err := s.userRepository.FindByEmail(email)
+	if err != nil && !errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+		return nil, err
+	}
+
+	if user == nil {
+		// Hashing takes as long as verifying, so unknown emails take as long
+		// as wrong passwords.
+		_, _ = s.passwordHasher.Hash(password)
+		s.registerFailedLogin(email, ip, nil)
+		return nil, ErrAuthServiceInvalidCredentials
+	}
+
+	if !user.HasEqualPassword(password, s.passwordHasher) {
+		s.registerFailedLogin(email, ip, user)
+		return nil, ErrAuthServiceInvalidCredentials
 	}
 
-	token, err := s.GenerateToken(user)
+	if user.IsDeletionRequested() {
+		return nil, ErrAuthServiceInvalidCredentials
+	}
+
+	if user.PasswordNeedsRehash(s.passwordHasher) {
+		s.rehashPassword(user, password)
+	}
+
+	session, err := s.StartSession(user)
 	if err != nil {
 		return nil, errors.New("can not authenticate")
 	}
 
+	// The failed logins are forgotten only once the second factor is
+	// verified, otherwise the password would reset the count of wrong codes.
+	if session.MFAToken == "" {
+		s.registerSuccessfulLogin(email)
+	}
+
+	return session, nil
+}
+
+// StartSession creates the session of a user that proved who they are, or
+// only returns an mfa token when they must also send a second factor.
+func (s *AuthService) StartSession(user *entities.User) (
+	*struct {
+		User         *entities.User
+		Token        string
+		RefreshToken string
+		MFAToken     string
+	},
+	error,
+) {
+	if user.IsDeletionRequested() {
+		return nil, ErrAuthServiceAccountDeletionRequested
+	}
+
+	enabled, err := s.twoFactorService.IsEnabled(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if enabled {
+		return &struct {
+			User         *entities.User
+			Token        string
+			RefreshToken string
+			MFAToken     string
+		}{
+			User:     user,
+			MFAToken: s.signer.Sign(mfaSignaturePrefix+user.ID, time.Now().Add(mfaTokenDuration)),
+		}, nil
+	}
+
+	session, err := s.createSession(user, "")
+	if err != nil {
+		return nil, err
+	}
+
 	return &struct {
-		User  *entities.User
-		Token string
+		User         *entities.User
+		Token        string
+		RefreshToken string
+		MFAToken     string
 	}{
-		user,
-		token,
+		User:         session.User,
+		Token:        session.Token,
+		RefreshToken: session.RefreshToken,
 	}, nil
 }
 
-func (s *AuthService) GenerateToken(user *entities.User) (string, error) {
-	return s.tokenGenerator.GenerateToken(user.ID, user.Email)
+// VerifyMFA exchanges the mfa token and a code of the authenticator app, or
+// a recovery code, for a session. Wrong codes count as failed logins, so they
+// can not be guessed while the token lasts.
+func (s *AuthService) VerifyMFA(mfaToken, code, ip string) (
+	*struct {
+		User         *entities.User
+		Token        string
+		RefreshToken string
+	},
+	error,
+) {
+	value, err := s.signer.Verify(mfaToken)
+	if err != nil {
+		return nil, ErrAuthServiceInvalidMFAToken
+	}
+
+	userID, found := strings.CutPrefix(value, mfaSignaturePrefix)
+	if !found {
+		return nil, ErrAuthServiceInvalidMFAToken
+	}
+
+	user, err := s.userRepository.GetByID(userID)
+	if errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+		return nil, ErrAuthServiceInvalidMFAToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeletionRequested() {
+		return nil, ErrAuthServiceInvalidMFAToken
+	}
+
+	err = s.loginThrottleService.Check(user.Email, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.twoFactorService.VerifyCode(user.ID, code)
+	if errors.Is(err, ErrTwoFactorServiceInvalidCode) {
+		s.registerFailedLogin(user.Email, ip, user)
+		return nil, err
+	}
+	if errors.Is(err, ErrTwoFactorServiceNotEnabled) {
+		return nil, ErrAuthServiceInvalidMFAToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s.registerSuccessfulLogin(user.Email)
+
+	return s.createSession(user, "")
+}
+
+// rehashPassword does not fail the login, the password is hashed again on the
+// next one.
+func (s *AuthService) rehashPassword(user *entities.User, password string) {
+	err := user.RehashPassword(password, s.passwordHasher)
+	if err == nil {
+		err = s.userRepository.Update(user)
+	}
+	if err != nil {
+		logger.LogError(err)
+	}
+}
+
+// Refresh exchanges a refresh token for a new access token and a new refresh
+// token of the same family. A token can be exchanged only once, presenting it
+// again revokes its family, so a stolen token stops working for both parties.
+func (s *AuthService) Refresh(refreshToken string) (
+	*struct {
+		User         *entities.User
+		Token        string
+		RefreshToken string
+	},
+	error,
+) {
+	token, err := s.refreshTokenRepository.GetByTokenHash(entities.HashRefreshToken(refreshToken))
+	if errors.Is(err, repositories.ErrRefreshTokenRepositoryRefreshTokenNotFound) {
+		return nil, ErrAuthServiceInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if token.WasUsed() {
+		s.revokeFamily(token.FamilyID)
+		return nil, ErrAuthServiceInvalidRefreshToken
+	}
+
+	if token.IsExpired() {
+		return nil, ErrAuthServiceInvalidRefreshToken
+	}
+
+	err = s.refreshTokenRepository.MarkAsUsed(token.ID)
+	if errors.Is(err, repositories.ErrRefreshTokenRepositoryRefreshTokenAlreadyUsed) {
+		s.revokeFamily(token.FamilyID)
+		return nil, ErrAuthServiceInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByID(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeletionRequested() {
+		return nil, ErrAuthServiceInvalidRefreshToken
+	}
+
+	return s.createSession(user, token.FamilyID)
+}
+
+// Logout revokes every refresh token of the family, which also ends the
+// access tokens issued for it, and returns the token that was presented.
+func (s *AuthService) Logout(refreshToken string) (*entities.RefreshToken, error) {
+	token, err := s.refreshTokenRepository.GetByTokenHash(entities.HashRefreshToken(refreshToken))
+	if errors.Is(err, repositories.ErrRefreshTokenRepositoryRefreshTokenNotFound) {
+		return nil, ErrAuthServiceInvalidRefreshToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refreshTokenRepository.RevokeFamily(token.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	return token, nil
+}
+
+func (s *AuthService) GenerateToken(user *entities.User, sessionID string) (string, error) {
+	return s.tokenGenerator.GenerateToken(user.ID, user.Email, sessionID)
 }
 
+func (s *AuthService) GetPublicKeys() []services.PublicKey {
+	return s.tokenGenerator.PublicKeys()
+}
+
+// ParseAuthentication accepts an access token or a personal access token,
+// with or without the "Bearer " prefix. Access tokens have the read_write
+// scope, personal access tokens the scope they were created with and no
+// session.
 func (s *AuthService) ParseAuthentication(token string) (
 	*struct {
-		ID    string
-		Email string
+		ID        string
+		Email     string
+		SessionID string
+		Scope     string
 	},
 	error,
 ) {
+	token = strings.TrimPrefix(token, "Bearer ")
+	if entities.IsPersonalAccessTokenValue(token) {
+		return s.parsePersonalAccessToken(token)
+	}
+
 	data, err := s.tokenGenerator.ParseToken(token)
 	if err != nil {
 		return nil, err
 	}
 
-	return data, nil
+	if data.SessionID == "" {
+		return nil, ErrAuthServiceSessionRevoked
+	}
+
+	revoked, err := s.refreshTokenRepository.IsFamilyRevoked(data.SessionID)
+	if err != nil {
+		return nil, err
+	}
+
+	if revoked {
+		return nil, ErrAuthServiceSessionRevoked
+	}
+
+	return &struct {
+		ID        string
+		Email     string
+		SessionID string
+		Scope     string
+	}{
+		data.ID,
+		data.Email,
+		data.SessionID,
+		entities.PersonalAccessTokenScopeReadWrite,
+	}, nil
+}
+
+func (s *AuthService) parsePersonalAccessToken(value string) (
+	*struct {
+		ID        string
+		Email     string
+		SessionID string
+		Scope     string
+	},
+	error,
+) {
+	token, err := s.personalAccessTokenRepository.GetByTokenHash(entities.HashPersonalAccessToken(value))
+	if errors.Is(err, repositories.ErrPersonalAccessTokenRepositoryPersonalAccessTokenNotFound) {
+		return nil, ErrAuthServiceInvalidPersonalAccessToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if token.IsRevoked() || token.IsExpired() {
+		return nil, ErrAuthServiceInvalidPersonalAccessToken
+	}
+
+	user, err := s.userRepository.GetByID(token.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.IsDeletionRequested() {
+		return nil, ErrAuthServiceInvalidPersonalAccessToken
+	}
+
+	now := time.Now()
+	if token.LastUsedAt == nil || now.Sub(*token.LastUsedAt) >= personalAccessTokenLastUsedPrecision {
+		err = s.personalAccessTokenRepository.UpdateLastUsedAt(token.ID, now)
+		if err != nil {
+			logger.LogError(err)
+		}
+	}
+
+	return &struct {
+		ID        string
+		Email     string
+		SessionID string
+		Scope     string
+	}{
+		user.ID,
+		user.Email,
+		"",
+		token.Scope,
+	}, nil
+}
+
+func (s *AuthService) createSession(user *entities.User, familyID string) (
+	*struct {
+		User         *entities.User
+		Token        string
+		RefreshToken string
+	},
+	error,
+) {
+	refreshToken, value, err := entities.NewRefreshToken(user.ID, familyID, s.refreshTokenDuration)
+	if err != nil {
+		return nil, err
+	}
+
+	token, err := s.GenerateToken(user, refreshToken.FamilyID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refreshTokenRepository.Create(refreshToken)
+	if err != nil {
+		return nil, err
+	}
+
+	return &struct {
+		User         *entities.User
+		Token        string
+		RefreshToken string
+	}{
+		user,
+		token,
+		value,
+	}, nil
+}
+
+func (s *AuthService) registerSuccessfulLogin(email string) {
+	err := s.loginThrottleService.RegisterSuccess(email)
+	if err != nil {
+		logger.LogError(err)
+	}
+}
+
+func (s *AuthService) registerFailedLogin(email string, ip string, user *entities.User) {
+	err := s.loginThrottleService.RegisterFailure(email, ip, user)
+	if err != nil {
+		logger.LogError(err)
+	}
+}
+
+func (s *AuthService) revokeFamily(familyID string) {
+	err := s.refreshTokenRepository.RevokeFamily(familyID)
+	if err != nil {
+		logger.LogError(err)
+	}
 }
@@ -6,78 +6,266 @@ import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
 	tokenstub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
 	"testing"
+	"time"
 
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
 )
 
 func TestAuthServiceAuthenticate(t *testing.T) {
 	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
 	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
 
-	authService := NewAuthService(userRepositoryMock, tokenGeneratorMock)
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
 
 	email := "test@example.com"
+	ip := "127.0.0.1"
 	password := "123abc"
 	user := &entities.User{
 		ID:       "test_user_id",
 		Email:    email,
-		Password: "$2a$14$9VTo1/y3dUttmnaRERp41etwpGvk4Atv8UkKWqwqU20dHlzYu/rDa",
+		Password: "hashed",
 	}
 
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
 	userRepositoryMock.On("FindByEmail", email).
 		Return(user, nil)
-	tokenGeneratorMock.On("GenerateToken", user.ID, user.Email).
+	passwordHasherMock.On("Verify", password, "hashed").Return(true)
+	passwordHasherMock.On("NeedsRehash", "hashed").Return(false)
+	twoFactorServiceMock.On("IsEnabled", user.ID).Return(false, nil)
+	loginThrottleServiceMock.On("RegisterSuccess", email).Return(nil)
+	tokenGeneratorMock.On("GenerateToken", user.ID, user.Email, mock.AnythingOfType("string")).
 		Return("fake_token", nil)
+	refreshTokenRepositoryMock.On("Create", mock.AnythingOfType("*entities.RefreshToken")).
+		Return(nil)
 
-	result, err := authService.Authenticate(email, password)
+	result, err := authService.Authenticate(email, password, ip)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
 	assert.Equal(t, user, result.User)
 	assert.Equal(t, "fake_token", result.Token)
+	assert.NotEmpty(t, result.RefreshToken)
 	userRepositoryMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertExpectations(t)
 	tokenGeneratorMock.AssertExpectations(t)
+	userRepositoryMock.AssertNotCalled(t, "Update", mock.Anything)
+
+	refreshToken := refreshTokenRepositoryMock.Calls[0].Arguments.Get(0).(*entities.RefreshToken)
+	assert.Equal(t, user.ID, refreshToken.UserID)
+	assert.Equal(t, refreshToken.ID, refreshToken.FamilyID)
+	assert.Equal(t, entities.HashRefreshToken(result.RefreshToken), refreshToken.TokenHash)
+	tokenGeneratorMock.AssertCalled(t, "GenerateToken", user.ID, user.Email, refreshToken.FamilyID)
+}
+
+func TestAuthServiceAuthenticateRehashesPassword(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
+
+	email := "test@example.com"
+	ip := "127.0.0.1"
+	password := "123abc"
+	user := &entities.User{
+		ID:       "test_user_id",
+		Email:    email,
+		Password: "$2a$14$9VTo1/y3dUttmnaRERp41etwpGvk4Atv8UkKWqwqU20dHlzYu/rDa",
+	}
+	oldHash := user.Password
+
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
+	userRepositoryMock.On("FindByEmail", email).Return(user, nil)
+	passwordHasherMock.On("Verify", password, oldHash).Return(true)
+	passwordHasherMock.On("NeedsRehash", oldHash).Return(true)
+	passwordHasherMock.On("Hash", password).Return("$argon2id$v=19$m=65536,t=3,p=2$salt$hash", nil)
+	userRepositoryMock.On("Update", user).Return(nil)
+	twoFactorServiceMock.On("IsEnabled", user.ID).Return(false, nil)
+	loginThrottleServiceMock.On("RegisterSuccess", email).Return(nil)
+	tokenGeneratorMock.On("GenerateToken", user.ID, user.Email, mock.AnythingOfType("string")).
+		Return("fake_token", nil)
+	refreshTokenRepositoryMock.On("Create", mock.AnythingOfType("*entities.RefreshToken")).
+		Return(nil)
+
+	result, err := authService.Authenticate(email, password, ip)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, result)
+	assert.Equal(t, "$argon2id$v=19$m=65536,t=3,p=2$salt$hash", user.Password)
+	userRepositoryMock.AssertExpectations(t)
+	passwordHasherMock.AssertExpectations(t)
 }
 
 func TestAuthServiceAuthenticateErrorInvalidCredentials(t *testing.T) {
 	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
 	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
 
-	authService := NewAuthService(userRepositoryMock, tokenGeneratorMock)
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
 
 	email := "test@example.com"
+	ip := "127.0.0.1"
 	password := "InvalidPassword"
 	user := &entities.User{
 		ID:       "test_user_id",
 		Email:    email,
-		Password: "$2a$14$9VTo1/y3dUttmnaRERp41etwpGvk4Atv8UkKWqwqU20dHlzYu/rDa",
+		Password: "hashed",
 	}
 
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
 	userRepositoryMock.On("FindByEmail", email).Return(user, nil)
+	passwordHasherMock.On("Verify", password, "hashed").Return(false)
+	loginThrottleServiceMock.On("RegisterFailure", email, ip, user).Return(nil)
 
-	result, err := authService.Authenticate(email, password)
+	result, err := authService.Authenticate(email, password, ip)
 
 	assert.Nil(t, result)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidCredentials)
 	assert.EqualError(t, err, "invalid credentials")
 	userRepositoryMock.AssertExpectations(t)
+	loginThrottleServiceMock.AssertExpectations(t)
+	tokenGeneratorMock.AssertNotCalled(t, "GenerateToken")
+}
+
+func TestAuthServiceAuthenticateErrorDeletionRequested(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
+
+	email := "test@example.com"
+	ip := "127.0.0.1"
+	password := "123abc"
+	user := &entities.User{ID: uuid.NewString(), Email: email, Password: "hashed"}
+	user.RequestDeletion()
+
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
+	userRepositoryMock.On("FindByEmail", email).
+		Return(user, nil)
+	passwordHasherMock.On("Verify", password, "hashed").Return(true)
+
+	result, err := authService.Authenticate(email, password, ip)
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidCredentials)
+	assert.Nil(t, result)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "Create", mock.Anything)
+	tokenGeneratorMock.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAuthServiceStartSessionErrorDeletionRequested(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	user.RequestDeletion()
+
+	result, err := authService.StartSession(user)
+
+	assert.ErrorIs(t, err, ErrAuthServiceAccountDeletionRequested)
+	assert.Nil(t, result)
+	twoFactorServiceMock.AssertNotCalled(t, "IsEnabled", mock.Anything)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthServiceAuthenticateErrorUnknownEmail(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
+
+	email := "unknown@example.com"
+	ip := "127.0.0.1"
+
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
+	userRepositoryMock.On("FindByEmail", email).Return(nil, repositories.ErrUserRepositoryUserNotFound)
+	passwordHasherMock.On("Hash", "123abc").Return("hashed", nil)
+	loginThrottleServiceMock.On("RegisterFailure", email, ip, (*entities.User)(nil)).Return(nil)
+
+	result, err := authService.Authenticate(email, "123abc", ip)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidCredentials)
+	userRepositoryMock.AssertExpectations(t)
+	loginThrottleServiceMock.AssertExpectations(t)
+	passwordHasherMock.AssertExpectations(t)
 	tokenGeneratorMock.AssertNotCalled(t, "GenerateToken")
 }
 
+func TestAuthServiceAuthenticateErrorTooManyAttempts(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	email := "test@example.com"
+	ip := "127.0.0.1"
+
+	loginThrottleServiceMock.On("Check", email, ip).Return(ErrLoginThrottleServiceTooManyAttempts)
+
+	result, err := authService.Authenticate(email, "123abc", ip)
+
+	assert.Nil(t, result)
+	assert.ErrorIs(t, err, ErrLoginThrottleServiceTooManyAttempts)
+	userRepositoryMock.AssertNotCalled(t, "FindByEmail", email)
+	loginThrottleServiceMock.AssertNotCalled(t, "RegisterFailure", mock.Anything, mock.Anything, mock.Anything)
+}
+
 func TestAuthServiceAuthenticateErrorInRepository(t *testing.T) {
 	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
 	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
 
-	authService := NewAuthService(userRepositoryMock, tokenGeneratorMock)
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
 
 	email := "test@example.com"
+	ip := "127.0.0.1"
 	password := "TestAuthServicePassword123"
 
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
 	userRepositoryMock.On("FindByEmail", email).
 		Return(nil, errors.New("repository error"))
 
-	result, err := authService.Authenticate(email, password)
+	result, err := authService.Authenticate(email, password, ip)
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
@@ -89,48 +277,68 @@ func TestAuthServiceAuthenticateErrorInRepository(t *testing.T) {
 
 func TestAuthServiceAuthenticateErrorInTokenGenerator(t *testing.T) {
 	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
 	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
 
-	authService := NewAuthService(userRepositoryMock, tokenGeneratorMock)
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
 
 	email := "test@example.com"
+	ip := "127.0.0.1"
 	password := "123abc"
 	user := &entities.User{
 		ID:       "test_user_id",
 		Email:    email,
-		Password: "$2a$14$9VTo1/y3dUttmnaRERp41etwpGvk4Atv8UkKWqwqU20dHlzYu/rDa",
+		Password: "hashed",
 	}
 
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
 	userRepositoryMock.On("FindByEmail", email).Return(user, nil)
-	tokenGeneratorMock.On("GenerateToken", user.ID, user.Email).
+	passwordHasherMock.On("Verify", password, "hashed").Return(true)
+	passwordHasherMock.On("NeedsRehash", "hashed").Return(false)
+	twoFactorServiceMock.On("IsEnabled", user.ID).Return(false, nil)
+	loginThrottleServiceMock.On("RegisterSuccess", email).Return(nil)
+	tokenGeneratorMock.On("GenerateToken", user.ID, user.Email, mock.AnythingOfType("string")).
 		Return("", errors.New("token generation error"))
 
-	result, err := authService.Authenticate(email, password)
+	result, err := authService.Authenticate(email, password, ip)
 
 	assert.Nil(t, result)
 	assert.Error(t, err)
 	assert.EqualError(t, err, "can not authenticate")
 	userRepositoryMock.AssertExpectations(t)
 	tokenGeneratorMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "Create")
 }
 
 func TestAuthServiceParseAuthentication(t *testing.T) {
 	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
 	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
 
-	authService := NewAuthService(userRepositoryMock, tokenGeneratorMock)
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
 
 	token := "valid_token"
 	expectedResult := &struct {
-		ID    string
-		Email string
+		ID        string
+		Email     string
+		SessionID string
 	}{
 		uuid.NewString(),
 		"test@email.com",
+		uuid.NewString(),
 	}
 
 	tokenGeneratorMock.On("ParseToken", token).
 		Return(expectedResult, nil)
+	refreshTokenRepositoryMock.On("IsFamilyRevoked", expectedResult.SessionID).
+		Return(false, nil)
 
 	data, err := authService.ParseAuthentication(token)
 
@@ -138,15 +346,22 @@ func TestAuthServiceParseAuthentication(t *testing.T) {
 	assert.NotNil(t, data)
 	assert.Equal(t, expectedResult.ID, data.ID)
 	assert.Equal(t, expectedResult.Email, data.Email)
+	assert.Equal(t, expectedResult.SessionID, data.SessionID)
+	assert.Equal(t, entities.PersonalAccessTokenScopeReadWrite, data.Scope)
 	userRepositoryMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertExpectations(t)
 	tokenGeneratorMock.AssertExpectations(t)
 }
 
 func TestAuthServiceErrorParseAuthentication(t *testing.T) {
 	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
 	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
 
-	authService := NewAuthService(userRepositoryMock, tokenGeneratorMock)
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
 
 	token := "invalid_token"
 
@@ -160,3 +375,601 @@ func TestAuthServiceErrorParseAuthentication(t *testing.T) {
 	userRepositoryMock.AssertExpectations(t)
 	tokenGeneratorMock.AssertExpectations(t)
 }
+
+func TestAuthServiceParseAuthenticationErrorSessionRevoked(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	token := "revoked_token"
+	parsed := &struct {
+		ID        string
+		Email     string
+		SessionID string
+	}{
+		uuid.NewString(),
+		"test@email.com",
+		uuid.NewString(),
+	}
+
+	tokenGeneratorMock.On("ParseToken", token).
+		Return(parsed, nil)
+	refreshTokenRepositoryMock.On("IsFamilyRevoked", parsed.SessionID).
+		Return(true, nil)
+
+	data, err := authService.ParseAuthentication(token)
+
+	assert.ErrorIs(t, err, ErrAuthServiceSessionRevoked)
+	assert.Nil(t, data)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+	tokenGeneratorMock.AssertExpectations(t)
+}
+
+func TestAuthServiceParseAuthenticationErrorTokenWithoutSession(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	token := "legacy_token"
+	parsed := &struct {
+		ID        string
+		Email     string
+		SessionID string
+	}{
+		uuid.NewString(),
+		"test@email.com",
+		"",
+	}
+
+	tokenGeneratorMock.On("ParseToken", token).
+		Return(parsed, nil)
+
+	data, err := authService.ParseAuthentication(token)
+
+	assert.ErrorIs(t, err, ErrAuthServiceSessionRevoked)
+	assert.Nil(t, data)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "IsFamilyRevoked")
+}
+
+func TestAuthServiceParseAuthenticationWithBearerPrefix(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	parsed := &struct {
+		ID        string
+		Email     string
+		SessionID string
+	}{
+		uuid.NewString(),
+		"test@email.com",
+		uuid.NewString(),
+	}
+
+	tokenGeneratorMock.On("ParseToken", "valid_token").
+		Return(parsed, nil)
+	refreshTokenRepositoryMock.On("IsFamilyRevoked", parsed.SessionID).
+		Return(false, nil)
+
+	data, err := authService.ParseAuthentication("Bearer valid_token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, parsed.ID, data.ID)
+	tokenGeneratorMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+}
+
+func TestAuthServiceParseAuthenticationPersonalAccessToken(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@email.com"}
+	token, value, _ := entities.NewPersonalAccessToken(user.ID, "home assistant", entities.PersonalAccessTokenScopeRead, nil)
+
+	personalAccessTokenRepositoryMock.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	personalAccessTokenRepositoryMock.On("UpdateLastUsedAt", token.ID, mock.AnythingOfType("time.Time")).Return(nil)
+
+	data, err := authService.ParseAuthentication("Bearer " + value)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user.ID, data.ID)
+	assert.Equal(t, user.Email, data.Email)
+	assert.Empty(t, data.SessionID)
+	assert.Equal(t, entities.PersonalAccessTokenScopeRead, data.Scope)
+	personalAccessTokenRepositoryMock.AssertExpectations(t)
+	userRepositoryMock.AssertExpectations(t)
+	tokenGeneratorMock.AssertNotCalled(t, "ParseToken", mock.Anything)
+}
+
+func TestAuthServiceParseAuthenticationPersonalAccessTokenErrorDeletionRequested(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@email.com"}
+	user.RequestDeletion()
+	token, value, _ := entities.NewPersonalAccessToken(user.ID, "home assistant", entities.PersonalAccessTokenScopeRead, nil)
+
+	personalAccessTokenRepositoryMock.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+
+	data, err := authService.ParseAuthentication(value)
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidPersonalAccessToken)
+	assert.Nil(t, data)
+	personalAccessTokenRepositoryMock.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything)
+}
+
+func TestAuthServiceParseAuthenticationPersonalAccessTokenRecentlyUsed(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@email.com"}
+	token, value, _ := entities.NewPersonalAccessToken(user.ID, "home assistant", entities.PersonalAccessTokenScopeReadWrite, nil)
+	lastUsedAt := time.Now().Add(-10 * time.Second)
+	token.LastUsedAt = &lastUsedAt
+
+	personalAccessTokenRepositoryMock.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+
+	data, err := authService.ParseAuthentication(value)
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.PersonalAccessTokenScopeReadWrite, data.Scope)
+	personalAccessTokenRepositoryMock.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything)
+}
+
+func TestAuthServiceParseAuthenticationPersonalAccessTokenErrors(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name      string
+		expiresAt *time.Time
+		revokedAt *time.Time
+		found     bool
+	}{
+		{"not found", nil, nil, false},
+		{"expired", &past, nil, true},
+		{"revoked", nil, &past, true},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			userRepositoryMock := new(stub.UserRepositoryMock)
+			refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+			personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+			loginThrottleServiceMock := new(LoginThrottleServiceMock)
+			twoFactorServiceMock := new(TwoFactorServiceMock)
+			tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+			authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+			token, value, _ := entities.NewPersonalAccessToken(uuid.NewString(), "home assistant", entities.PersonalAccessTokenScopeRead, nil)
+			token.ExpiresAt = testCase.expiresAt
+			token.RevokedAt = testCase.revokedAt
+
+			if testCase.found {
+				personalAccessTokenRepositoryMock.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+			} else {
+				personalAccessTokenRepositoryMock.On("GetByTokenHash", token.TokenHash).
+					Return(nil, repositories.ErrPersonalAccessTokenRepositoryPersonalAccessTokenNotFound)
+			}
+
+			data, err := authService.ParseAuthentication(value)
+
+			assert.ErrorIs(t, err, ErrAuthServiceInvalidPersonalAccessToken)
+			assert.Nil(t, data)
+			userRepositoryMock.AssertNotCalled(t, "GetByID", mock.Anything)
+			personalAccessTokenRepositoryMock.AssertNotCalled(t, "UpdateLastUsedAt", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthServiceRefresh(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	current, value, err := entities.NewRefreshToken(user.ID, "", time.Hour)
+	assert.NoError(t, err)
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", current.TokenHash).
+		Return(current, nil)
+	refreshTokenRepositoryMock.On("MarkAsUsed", current.ID).
+		Return(nil)
+	refreshTokenRepositoryMock.On("Create", mock.AnythingOfType("*entities.RefreshToken")).
+		Return(nil)
+	userRepositoryMock.On("GetByID", user.ID).
+		Return(user, nil)
+	tokenGeneratorMock.On("GenerateToken", user.ID, user.Email, current.FamilyID).
+		Return("new_token", nil)
+
+	result, err := authService.Refresh(value)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, result.User)
+	assert.Equal(t, "new_token", result.Token)
+	assert.NotEmpty(t, result.RefreshToken)
+	assert.NotEqual(t, value, result.RefreshToken)
+	userRepositoryMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+	tokenGeneratorMock.AssertExpectations(t)
+
+	rotated := refreshTokenRepositoryMock.Calls[2].Arguments.Get(0).(*entities.RefreshToken)
+	assert.Equal(t, current.FamilyID, rotated.FamilyID)
+	assert.NotEqual(t, current.ID, rotated.ID)
+	assert.Equal(t, entities.HashRefreshToken(result.RefreshToken), rotated.TokenHash)
+}
+
+func TestAuthServiceRefreshErrorDeletionRequested(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, new(tokenstub.SignerMock), time.Hour)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	user.RequestDeletion()
+	current, value, err := entities.NewRefreshToken(user.ID, "", time.Hour)
+	assert.NoError(t, err)
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", current.TokenHash).
+		Return(current, nil)
+	refreshTokenRepositoryMock.On("MarkAsUsed", current.ID).
+		Return(nil)
+	userRepositoryMock.On("GetByID", user.ID).
+		Return(user, nil)
+
+	result, err := authService.Refresh(value)
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidRefreshToken)
+	assert.Nil(t, result)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthServiceRefreshErrorUnknownToken(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", entities.HashRefreshToken("unknown")).
+		Return(nil, repositories.ErrRefreshTokenRepositoryRefreshTokenNotFound)
+
+	result, err := authService.Refresh("unknown")
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidRefreshToken)
+	assert.Nil(t, result)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+	tokenGeneratorMock.AssertNotCalled(t, "GenerateToken")
+}
+
+func TestAuthServiceRefreshErrorReusedTokenRevokesFamily(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	current, value, err := entities.NewRefreshToken(uuid.NewString(), uuid.NewString(), time.Hour)
+	assert.NoError(t, err)
+	usedAt := time.Now().Add(-time.Minute)
+	current.UsedAt = &usedAt
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", current.TokenHash).
+		Return(current, nil)
+	refreshTokenRepositoryMock.On("RevokeFamily", current.FamilyID).
+		Return(nil)
+
+	result, err := authService.Refresh(value)
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidRefreshToken)
+	assert.Nil(t, result)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "MarkAsUsed", current.ID)
+	tokenGeneratorMock.AssertNotCalled(t, "GenerateToken")
+}
+
+func TestAuthServiceRefreshErrorConcurrentUseRevokesFamily(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	current, value, err := entities.NewRefreshToken(uuid.NewString(), "", time.Hour)
+	assert.NoError(t, err)
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", current.TokenHash).
+		Return(current, nil)
+	refreshTokenRepositoryMock.On("MarkAsUsed", current.ID).
+		Return(repositories.ErrRefreshTokenRepositoryRefreshTokenAlreadyUsed)
+	refreshTokenRepositoryMock.On("RevokeFamily", current.FamilyID).
+		Return(nil)
+
+	result, err := authService.Refresh(value)
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidRefreshToken)
+	assert.Nil(t, result)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+	userRepositoryMock.AssertNotCalled(t, "GetByID")
+}
+
+func TestAuthServiceRefreshErrorExpiredToken(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	current, value, err := entities.NewRefreshToken(uuid.NewString(), "", -time.Minute)
+	assert.NoError(t, err)
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", current.TokenHash).
+		Return(current, nil)
+
+	result, err := authService.Refresh(value)
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidRefreshToken)
+	assert.Nil(t, result)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "MarkAsUsed", current.ID)
+}
+
+func TestAuthServiceLogout(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	current, value, err := entities.NewRefreshToken(uuid.NewString(), "", time.Hour)
+	assert.NoError(t, err)
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", current.TokenHash).
+		Return(current, nil)
+	refreshTokenRepositoryMock.On("RevokeFamily", current.FamilyID).
+		Return(nil)
+
+	token, err := authService.Logout(value)
+
+	assert.NoError(t, err)
+	assert.Equal(t, current, token)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+}
+
+func TestAuthServiceLogoutErrorUnknownToken(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), new(tokenstub.SignerMock), time.Hour)
+
+	refreshTokenRepositoryMock.On("GetByTokenHash", entities.HashRefreshToken("unknown")).
+		Return(nil, repositories.ErrRefreshTokenRepositoryRefreshTokenNotFound)
+
+	token, err := authService.Logout("unknown")
+
+	assert.ErrorIs(t, err, ErrAuthServiceInvalidRefreshToken)
+	assert.Nil(t, token)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "RevokeFamily")
+}
+
+func TestAuthServiceAuthenticateWithTwoFactor(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	passwordHasherMock := new(tokenstub.PasswordHasherMock)
+	signerMock := new(tokenstub.SignerMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, passwordHasherMock, signerMock, time.Hour)
+
+	email := "test@example.com"
+	ip := "127.0.0.1"
+	password := "123abc"
+	user := &entities.User{
+		ID:       "test_user_id",
+		Email:    email,
+		Password: "hashed",
+	}
+
+	loginThrottleServiceMock.On("Check", email, ip).Return(nil)
+	userRepositoryMock.On("FindByEmail", email).Return(user, nil)
+	passwordHasherMock.On("Verify", password, "hashed").Return(true)
+	passwordHasherMock.On("NeedsRehash", "hashed").Return(false)
+	twoFactorServiceMock.On("IsEnabled", user.ID).Return(true, nil)
+	signerMock.On("Sign", "mfa:"+user.ID, mock.AnythingOfType("time.Time")).Return("mfa_token")
+
+	result, err := authService.Authenticate(email, password, ip)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, result.User)
+	assert.Equal(t, "mfa_token", result.MFAToken)
+	assert.Empty(t, result.Token)
+	assert.Empty(t, result.RefreshToken)
+	signerMock.AssertExpectations(t)
+	assert.WithinDuration(t, time.Now().Add(mfaTokenDuration), signerMock.Calls[0].Arguments.Get(1).(time.Time), time.Second)
+	tokenGeneratorMock.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "Create", mock.Anything)
+	loginThrottleServiceMock.AssertNotCalled(t, "RegisterSuccess", mock.Anything)
+}
+
+func TestAuthServiceVerifyMFA(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	personalAccessTokenRepositoryMock := new(stub.PersonalAccessTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	tokenGeneratorMock := new(tokenstub.TokenGeneratorMock)
+	signerMock := new(tokenstub.SignerMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, personalAccessTokenRepositoryMock, loginThrottleServiceMock, twoFactorServiceMock, tokenGeneratorMock, new(tokenstub.PasswordHasherMock), signerMock, time.Hour)
+
+	ip := "127.0.0.1"
+	user := &entities.User{ID: "test_user_id", Email: "test@example.com"}
+
+	signerMock.On("Verify", "mfa_token").Return("mfa:"+user.ID, nil)
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, ip).Return(nil)
+	twoFactorServiceMock.On("VerifyCode", user.ID, "123456").Return(nil)
+	loginThrottleServiceMock.On("RegisterSuccess", user.Email).Return(nil)
+	tokenGeneratorMock.On("GenerateToken", user.ID, user.Email, mock.AnythingOfType("string")).
+		Return("fake_token", nil)
+	refreshTokenRepositoryMock.On("Create", mock.AnythingOfType("*entities.RefreshToken")).
+		Return(nil)
+
+	result, err := authService.VerifyMFA("mfa_token", "123456", ip)
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, result.User)
+	assert.Equal(t, "fake_token", result.Token)
+	assert.NotEmpty(t, result.RefreshToken)
+	loginThrottleServiceMock.AssertExpectations(t)
+	twoFactorServiceMock.AssertExpectations(t)
+	refreshTokenRepositoryMock.AssertExpectations(t)
+}
+
+func TestAuthServiceVerifyMFAErrorInvalidToken(t *testing.T) {
+	testCases := map[string]struct {
+		value string
+		err   error
+	}{
+		"invalid signature": {"", errors.New("invalid signature")},
+		"other purpose":     {"oidc:test_user_id", nil},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			userRepositoryMock := new(stub.UserRepositoryMock)
+			twoFactorServiceMock := new(TwoFactorServiceMock)
+			signerMock := new(tokenstub.SignerMock)
+
+			authService := NewAuthService(userRepositoryMock, new(stub.RefreshTokenRepositoryMock), new(stub.PersonalAccessTokenRepositoryMock), new(LoginThrottleServiceMock), twoFactorServiceMock, new(tokenstub.TokenGeneratorMock), new(tokenstub.PasswordHasherMock), signerMock, time.Hour)
+
+			signerMock.On("Verify", "mfa_token").Return(testCase.value, testCase.err)
+
+			result, err := authService.VerifyMFA("mfa_token", "123456", "127.0.0.1")
+
+			assert.ErrorIs(t, err, ErrAuthServiceInvalidMFAToken)
+			assert.Nil(t, result)
+			userRepositoryMock.AssertNotCalled(t, "GetByID", mock.Anything)
+			twoFactorServiceMock.AssertNotCalled(t, "VerifyCode", mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestAuthServiceVerifyMFAErrorInvalidCode(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	refreshTokenRepositoryMock := new(stub.RefreshTokenRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	signerMock := new(tokenstub.SignerMock)
+
+	authService := NewAuthService(userRepositoryMock, refreshTokenRepositoryMock, new(stub.PersonalAccessTokenRepositoryMock), loginThrottleServiceMock, twoFactorServiceMock, new(tokenstub.TokenGeneratorMock), new(tokenstub.PasswordHasherMock), signerMock, time.Hour)
+
+	ip := "127.0.0.1"
+	user := &entities.User{ID: "test_user_id", Email: "test@example.com"}
+
+	signerMock.On("Verify", "mfa_token").Return("mfa:"+user.ID, nil)
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, ip).Return(nil)
+	twoFactorServiceMock.On("VerifyCode", user.ID, "000000").Return(ErrTwoFactorServiceInvalidCode)
+	loginThrottleServiceMock.On("RegisterFailure", user.Email, ip, user).Return(nil)
+
+	result, err := authService.VerifyMFA("mfa_token", "000000", ip)
+
+	assert.ErrorIs(t, err, ErrTwoFactorServiceInvalidCode)
+	assert.Nil(t, result)
+	loginThrottleServiceMock.AssertExpectations(t)
+	loginThrottleServiceMock.AssertNotCalled(t, "RegisterSuccess", mock.Anything)
+	refreshTokenRepositoryMock.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestAuthServiceVerifyMFAErrorTooManyAttempts(t *testing.T) {
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorServiceMock := new(TwoFactorServiceMock)
+	signerMock := new(tokenstub.SignerMock)
+
+	authService := NewAuthService(userRepositoryMock, new(stub.RefreshTokenRepositoryMock), new(stub.PersonalAccessTokenRepositoryMock), loginThrottleServiceMock, twoFactorServiceMock, new(tokenstub.TokenGeneratorMock), new(tokenstub.PasswordHasherMock), signerMock, time.Hour)
+
+	ip := "127.0.0.1"
+	user := &entities.User{ID: "test_user_id", Email: "test@example.com"}
+
+	signerMock.On("Verify", "mfa_token").Return("mfa:"+user.ID, nil)
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, ip).Return(ErrLoginThrottleServiceTooManyAttempts)
+
+	result, err := authService.VerifyMFA("mfa_token", "123456", ip)
+
+	assert.ErrorIs(t, err, ErrLoginThrottleServiceTooManyAttempts)
+	assert.Nil(t, result)
+	twoFactorServiceMock.AssertNotCalled(t, "VerifyCode", mock.Anything, mock.Anything)
+}
@@ -12,15 +12,20 @@ import (
 var (
 	ErrBoxServiceRoomDoesNotExists                            = errors.New("room does not exists")
 	ErrBoxServiceQuantityShouldBeLessOrEqualToBoxItemQuantity = errors.New("quantity should be less than or equal to box item quantity")
+	ErrBoxServiceBoxNotFound                                  = errors.New("box not found")
+	ErrBoxServiceAssetNotFound                                = errors.New("asset not found")
+	ErrBoxServiceItemNotFound                                 = errors.New("item not found")
 )
 
 type BoxService struct {
-	boxRepository  repositories.BoxRepository
-	itemRepository repositories.ItemRepository
-	roomRepository repositories.RoomRepository
-	userRepository repositories.UserRepository
-	eventBus       services.EventBus
-	mailSender     services.MailSender
+	boxRepository       repositories.BoxRepository
+	itemRepository      repositories.ItemRepository
+	roomRepository      repositories.RoomRepository
+	userRepository      repositories.UserRepository
+	transactionManager  repositories.TransactionManager
+	notificationService NotificationServiceInterface
+	assetService        AssetServiceInterface
+	householdService    HouseholdServiceInterface
 }
 
 func NewBoxService(
@@ -28,35 +33,47 @@ func NewBoxService(
 	itemRepository repositories.ItemRepository,
 	roomRepository repositories.RoomRepository,
 	userRepository repositories.UserRepository,
-	eventBus services.EventBus,
-	mailSender services.MailSender,
+	transactionManager repositories.TransactionManager,
+	notificationService NotificationServiceInterface,
+	assetService AssetServiceInterface,
+	householdService HouseholdServiceInterface,
 ) *BoxService {
 	return &BoxService{
 		boxRepository,
 		itemRepository,
 		roomRepository,
 		userRepository,
-		eventBus,
-		mailSender,
+		transactionManager,
+		notificationService,
+		assetService,
+		householdService,
 	}
 }
 
-func (s *BoxService) Create(name string, description *string, roomID string) (*entities.Box, error) {
-	exists, err := s.roomRepository.ExistsByID(roomID)
+func (s *BoxService) Create(
+	name string,
+	description *string,
+	roomID string,
+	userID string,
+) (*entities.Box, error) {
+	room, err := s.getRoom(roomID, userID)
 	if err != nil {
 		return nil, err
 	}
 
-	if !exists {
-		return nil, ErrBoxServiceRoomDoesNotExists
-	}
-
 	box, err := entities.NewBox(name, description, roomID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.boxRepository.Create(box)
+	err = s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		err := tx.BoxRepository().Create(box)
+		if err != nil {
+			return err
+		}
+
+		return publishInTransaction(tx, services.BoxCreatedEvent{Box: *box, HouseholdID: room.HouseholdID})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -68,46 +85,50 @@ func (s *BoxService) AddItemIntoBox(
 	quantity float64,
 	boxID string,
 	itemID string,
+	userID string,
 ) (*entities.BoxItem, error) {
-	item, err := s.itemRepository.GetByID(itemID)
-	if item == nil {
+	item, err := s.getBoxAndItem(boxID, itemID, userID)
+	if err != nil {
 		return nil, err
 	}
 
-	boxItem, err := s.boxRepository.GetBoxItem(boxID, item.ID)
-	if err != nil && !errors.Is(err, repositories.ErrBoxRepositoryBoxItemNotFound) {
-		return nil, err
-	}
+	var boxItem *entities.BoxItem
+	err = s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		boxRepository := tx.BoxRepository()
 
-	if err != nil && errors.Is(err, repositories.ErrBoxRepositoryBoxItemNotFound) {
-		boxItem, err = entities.NewBoxItem(
-			quantity,
-			boxID,
-			*item,
-		)
-		if err != nil {
-			return nil, err
-		}
-		err = s.boxRepository.CreateBoxItem(boxItem)
-		if err != nil {
-			return nil, err
+		boxItem, err = boxRepository.GetBoxItem(boxID, item.ID)
+		if err != nil && !errors.Is(err, repositories.ErrBoxRepositoryBoxItemNotFound) {
+			return err
 		}
-	} else {
-		boxItem.Quantity += quantity
 
-		err = s.boxRepository.UpdateBoxItem(boxItem)
-		if err != nil {
-			return nil, err
+		if err != nil && errors.Is(err, repositories.ErrBoxRepositoryBoxItemNotFound) {
+			boxItem, err = entities.NewBoxItem(
+				quantity,
+				boxID,
+				*item,
+			)
+			if err != nil {
+				return err
+			}
+			err = boxRepository.CreateBoxItem(boxItem)
+			if err != nil {
+				return err
+			}
+		} else {
+			boxItem.Quantity += quantity
+
+			err = boxRepository.UpdateBoxItem(boxItem)
+			if err != nil {
+				return err
+			}
 		}
-	}
 
-	happenedAt := time.Now()
-
-	err = s.eventBus.Publish(services.BoxItemAddedEvent{
-		Quantity:   quantity,
-		BoxID:      boxID,
-		Item:       *item,
-		HappenedAt: happenedAt,
+		return publishInTransaction(tx, services.BoxItemAddedEvent{
+			Quantity:   quantity,
+			BoxID:      boxID,
+			Item:       *item,
+			HappenedAt: time.Now(),
+		})
 	})
 	if err != nil {
 		return nil, err
@@ -144,48 +165,46 @@ func (s *BoxService) RemoveItemFromBox(
 	quantity float64,
 	boxID string,
 	itemID string,
+	userID string,
 ) error {
-	item, err := s.itemRepository.GetByID(itemID)
-	if item == nil {
-		return err
-	}
-
-	boxItem, err := s.boxRepository.GetBoxItem(boxID, item.ID)
+	item, err := s.getBoxAndItem(boxID, itemID, userID)
 	if err != nil {
 		return err
 	}
 
-	if quantity > boxItem.Quantity {
-		return ErrBoxServiceQuantityShouldBeLessOrEqualToBoxItemQuantity
-	}
+	return s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		boxRepository := tx.BoxRepository()
 
-	if quantity == boxItem.Quantity {
-		err = s.boxRepository.DeleteBoxItem(boxID, item.ID)
+		boxItem, err := boxRepository.GetBoxItem(boxID, item.ID)
 		if err != nil {
 			return err
 		}
-	} else {
-		boxItem.Quantity -= quantity
 
-		err = s.boxRepository.UpdateBoxItem(boxItem)
-		if err != nil {
-			return err
+		if quantity > boxItem.Quantity {
+			return ErrBoxServiceQuantityShouldBeLessOrEqualToBoxItemQuantity
 		}
-	}
 
-	happenedAt := time.Now()
+		if quantity == boxItem.Quantity {
+			err = boxRepository.DeleteBoxItem(boxID, item.ID)
+			if err != nil {
+				return err
+			}
+		} else {
+			boxItem.Quantity -= quantity
+
+			err = boxRepository.UpdateBoxItem(boxItem)
+			if err != nil {
+				return err
+			}
+		}
 
-	err = s.eventBus.Publish(services.BoxItemRemovedEvent{
-		Quantity:   quantity,
-		BoxID:      boxID,
-		Item:       *item,
-		HappenedAt: happenedAt,
+		return publishInTransaction(tx, services.BoxItemRemovedEvent{
+			Quantity:   quantity,
+			BoxID:      boxID,
+			Item:       *item,
+			HappenedAt: time.Now(),
+		})
 	})
-	if err != nil {
-		return err
-	}
-
-	return nil
 }
 
 func (s *BoxService) CreateRemoveBoxTransaction(
@@ -217,8 +236,14 @@ func (s *BoxService) GetAll(
 	userID string,
 	search string,
 	pageFilter PageFilter,
-) ([]*entities.Box, error) {
-	queryFilter := s.makeGetAllQueryFilter(search, roomID, userID)
+) ([]struct {
+	Box    *entities.Box
+	Assets []*entities.Asset
+}, error) {
+	queryFilter, err := s.makeGetAllQueryFilter(search, roomID, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	boxes, err := s.boxRepository.GetByQueryFilters(*queryFilter, &repositories.PageFilter{
 		Offset: (pageFilter.Page - 1) * pageFilter.Size,
@@ -228,7 +253,35 @@ func (s *BoxService) GetAll(
 		return nil, err
 	}
 
-	return boxes, nil
+	var entitySlice []entities.Entity
+	for i := range boxes {
+		entitySlice = append(entitySlice, boxes[i])
+	}
+	assets, err := s.assetService.GetByEntities(entitySlice)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsByID := make(map[string][]*entities.Asset)
+	for i := range assets {
+		assetsByID[assets[i].EntityID] = append(assetsByID[assets[i].EntityID], assets[i])
+	}
+
+	output := make([]struct {
+		Box    *entities.Box
+		Assets []*entities.Asset
+	}, 0)
+	for i := range boxes {
+		output = append(output, struct {
+			Box    *entities.Box
+			Assets []*entities.Asset
+		}{
+			Box:    boxes[i],
+			Assets: assetsByID[boxes[i].EntityID()],
+		})
+	}
+
+	return output, nil
 }
 
 func (s *BoxService) CountAll(
@@ -236,7 +289,10 @@ func (s *BoxService) CountAll(
 	search string,
 	roomID string,
 ) (int64, error) {
-	queryFilter := s.makeGetAllQueryFilter(search, roomID, userID)
+	queryFilter, err := s.makeGetAllQueryFilter(search, roomID, userID)
+	if err != nil {
+		return 0, err
+	}
 
 	count, err := s.boxRepository.CountByQueryFilters(*queryFilter)
 	if err != nil {
@@ -250,16 +306,21 @@ func (s *BoxService) makeGetAllQueryFilter(
 	search string,
 	roomID string,
 	userID string,
-) *repositories.QueryFilter {
+) (*repositories.QueryFilter, error) {
+	householdIDs, err := s.householdService.GetVisibleHouseholdIDs("", userID)
+	if err != nil {
+		return nil, err
+	}
+
 	queryFilter := &repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
 			{
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "rooms.user_id",
-						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Field:    "rooms.household_id",
+						Operator: repositories.InComparisonOperator,
+						Value:    householdIDs,
 					},
 				},
 			},
@@ -305,14 +366,25 @@ func (s *BoxService) makeGetAllQueryFilter(
 		)
 	}
 
-	return queryFilter
+	return queryFilter, nil
 }
 
 func (s *BoxService) TransferItem(
 	fromBoxID string,
 	toBoxID string,
 	itemID string,
+	userID string,
 ) error {
+	_, _, err := s.getBox(toBoxID, userID, s.householdService.CheckCanEdit)
+	if err != nil {
+		return err
+	}
+
+	_, _, err = s.getBox(fromBoxID, userID, s.householdService.CheckCanEdit)
+	if err != nil {
+		return err
+	}
+
 	fromBoxItem, err := s.boxRepository.GetBoxItem(fromBoxID, itemID)
 	if err != nil {
 		return err
@@ -324,6 +396,7 @@ func (s *BoxService) TransferItem(
 		quantity,
 		fromBoxID,
 		itemID,
+		userID,
 	)
 	if err != nil {
 		return err
@@ -333,6 +406,7 @@ func (s *BoxService) TransferItem(
 		quantity,
 		toBoxID,
 		itemID,
+		userID,
 	)
 	if err != nil {
 		return err
@@ -341,31 +415,63 @@ func (s *BoxService) TransferItem(
 	return nil
 }
 
-func (s *BoxService) DeleteWithTransactionsAndItemQuantities(boxID string) error {
-	err := s.boxRepository.DeleteBoxTransactionsByBoxID(boxID)
+func (s *BoxService) DeleteWithTransactionsAndItemQuantities(boxID string, userID string) error {
+	box, room, err := s.getBox(boxID, userID, s.householdService.CheckCanEdit)
 	if err != nil {
 		return err
 	}
 
-	err = s.boxRepository.DeleteBoxItemsByBoxID(boxID)
+	err = s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		boxRepository := tx.BoxRepository()
+
+		err := boxRepository.DeleteBoxTransactionsByBoxID(boxID)
+		if err != nil {
+			return err
+		}
+
+		err = boxRepository.DeleteBoxItemsByBoxID(boxID)
+		if err != nil {
+			return err
+		}
+
+		err = boxRepository.Delete(boxID)
+		if err != nil {
+			return err
+		}
+
+		return publishInTransaction(tx, services.BoxDeletedEvent{Box: *box, HouseholdID: room.HouseholdID})
+	})
 	if err != nil {
 		return err
 	}
 
-	err = s.boxRepository.Delete(boxID)
+	assets, err := s.assetService.GetByEntity(&entities.Box{ID: boxID}, nil)
 	if err != nil {
 		return err
 	}
 
+	for _, asset := range assets {
+		err = s.assetService.Delete(asset)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// Get returns a box of a household of the user with the room where it is.
+func (s *BoxService) Get(boxID string, userID string) (*entities.Box, *entities.Room, error) {
+	return s.getBox(boxID, userID, s.householdService.CheckCanView)
+}
+
 func (s *BoxService) Update(
 	boxID string,
+	userID string,
 	name string,
 	description *string,
 ) (*entities.Box, error) {
-	box, err := s.boxRepository.GetByID(boxID)
+	box, room, err := s.getBox(boxID, userID, s.householdService.CheckCanEdit)
 	if err != nil {
 		return nil, err
 	}
@@ -375,7 +481,7 @@ func (s *BoxService) Update(
 		return nil, err
 	}
 
-	err = s.boxRepository.Update(box)
+	err = s.updateBox(box, room.HouseholdID)
 	if err != nil {
 		return nil, err
 	}
@@ -383,32 +489,56 @@ func (s *BoxService) Update(
 	return box, nil
 }
 
+// TransferToRoom moves the box to another room of the same household, as the
+// items inside it belong to that household.
 func (s *BoxService) TransferToRoom(
 	boxID string,
 	roomID string,
+	userID string,
 ) error {
-	box, err := s.boxRepository.GetByID(boxID)
+	box, currentRoom, err := s.getBox(boxID, userID, s.householdService.CheckCanEdit)
 	if err != nil {
 		return err
 	}
 
-	err = box.ChangeRoomID(roomID)
+	room, err := s.getRoom(roomID, userID)
 	if err != nil {
 		return err
 	}
 
-	err = s.boxRepository.Update(box)
+	if room.HouseholdID != currentRoom.HouseholdID {
+		return ErrBoxServiceRoomDoesNotExists
+	}
+
+	err = box.ChangeRoomID(roomID)
 	if err != nil {
 		return err
 	}
 
-	return nil
+	return s.updateBox(box, room.HouseholdID)
+}
+
+func (s *BoxService) updateBox(box *entities.Box, householdID string) error {
+	return s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		err := tx.BoxRepository().Update(box)
+		if err != nil {
+			return err
+		}
+
+		return publishInTransaction(tx, services.BoxUpdatedEvent{Box: *box, HouseholdID: householdID})
+	})
 }
 
 func (s *BoxService) GetBoxTransactions(
 	boxID string,
+	userID string,
 	pageFilter PageFilter,
 ) ([]*entities.BoxTransaction, error) {
+	_, _, err := s.getBox(boxID, userID, s.householdService.CheckCanView)
+	if err != nil {
+		return nil, err
+	}
+
 	queryFilter := s.makeGetBoxTransactionsQueryFilter(boxID)
 
 	boxTransactions, err := s.boxRepository.GetBoxTransactionsByQueryFilters(
@@ -427,7 +557,13 @@ func (s *BoxService) GetBoxTransactions(
 
 func (s *BoxService) CountBoxTransactions(
 	boxID string,
+	userID string,
 ) (int64, error) {
+	_, _, err := s.getBox(boxID, userID, s.householdService.CheckCanView)
+	if err != nil {
+		return 0, err
+	}
+
 	queryFilter := s.makeGetBoxTransactionsQueryFilter(boxID)
 
 	count, err := s.boxRepository.CountBoxTransactionsByQueryFilters(*queryFilter)
@@ -463,50 +599,217 @@ func (s *BoxService) NotifyBoxItemAdded(
 	item entities.Item,
 	happenedAt time.Time,
 ) error {
-	user, err := s.userRepository.GetUserByBoxID(boxID)
+	return s.notifyBoxMembers(
+		services.GetEventType(services.BoxItemAddedEvent{}),
+		services.MailTemplateBoxItemAdded,
+		"added into",
+		quantity,
+		boxID,
+		item,
+		happenedAt,
+	)
+}
+
+func (s *BoxService) NotifyBoxItemRemoved(
+	quantity float64,
+	boxID string,
+	item entities.Item,
+	happenedAt time.Time,
+) error {
+	return s.notifyBoxMembers(
+		services.GetEventType(services.BoxItemRemovedEvent{}),
+		services.MailTemplateBoxItemRemoved,
+		"removed from",
+		quantity,
+		boxID,
+		item,
+		happenedAt,
+	)
+}
+
+// notifyBoxMembers notifies every member of the household that owns the box,
+// as their notification preferences say and with the time in their timezone.
+// The notifications of the members who did not verify their email are held
+// until they do. A failed notification does not stop the others, the first error
+// is returned.
+func (s *BoxService) notifyBoxMembers(
+	eventType string,
+	template string,
+	action string,
+	quantity float64,
+	boxID string,
+	item entities.Item,
+	happenedAt time.Time,
+) error {
+	box, err := s.boxRepository.GetByID(boxID)
+	if err != nil {
+		return err
+	}
+
+	room, err := s.roomRepository.GetByID(box.RoomID)
+	if err != nil {
+		return err
+	}
+
+	users, err := s.userRepository.GetUsersByBoxID(boxID)
 	if err != nil {
 		return err
 	}
 
 	quantityStr := strconv.FormatFloat(quantity, 'f', -1, 64)
 
-	body := quantityStr + " " + item.Name + " added into box " + boxID + " at " + happenedAt.String()
+	var firstErr error
+	for _, user := range users {
+		happenedAtStr := formatNotificationTime(user, happenedAt)
+
+		err = s.notificationService.Notify(user, Notification{
+			EventType: eventType,
+			RoomID:    room.ID,
+			Subject:   item.Name + " " + action + " " + box.Name,
+			Message: quantityStr + " " + item.Name + " " + action + " the box " + box.Name +
+				" of the room " + room.Name + " at " + happenedAtStr,
+			Template: template,
+			Data: services.BoxItemMailData{
+				Quantity:   quantityStr,
+				ItemName:   item.Name,
+				BoxName:    box.Name,
+				RoomName:   room.Name,
+				HappenedAt: happenedAtStr,
+			},
+			HappenedAt: happenedAt,
+		})
+		if err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
 
-	err = s.mailSender.SendMail(
-		user.Email,
-		"Item added into box "+boxID,
-		body,
-	)
+	return firstErr
+}
+
+func (s *BoxService) CreateAsset(
+	boxID string,
+	userID string,
+	file *FileUpload,
+) (*entities.Asset, error) {
+	box, _, err := s.getBox(boxID, userID, s.householdService.CheckCanEdit)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	asset, err := s.assetService.CreateFromFile(file, box)
+	if err != nil {
+		return nil, err
+	}
+
+	return asset, nil
 }
 
-func (s *BoxService) NotifyBoxItemRemoved(
-	quantity float64,
+func (s *BoxService) GetAssets(
 	boxID string,
-	item entities.Item,
-	happenedAt time.Time,
+	userID string,
+) ([]*entities.Asset, error) {
+	box, _, err := s.getBox(boxID, userID, s.householdService.CheckCanView)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := s.assetService.GetByEntity(box, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+func (s *BoxService) DeleteAsset(
+	boxID string,
+	userID string,
+	assetID string,
 ) error {
-	user, err := s.userRepository.GetUserByBoxID(boxID)
+	box, _, err := s.getBox(boxID, userID, s.householdService.CheckCanEdit)
 	if err != nil {
 		return err
 	}
 
-	quantityStr := strconv.FormatFloat(quantity, 'f', -1, 64)
+	asset, err := s.assetService.GetByID(assetID)
+	if err != nil {
+		return err
+	}
 
-	body := quantityStr + " " + item.Name + " removed from box " + boxID + " at " + happenedAt.String()
+	if asset.EntityID != box.EntityID() || asset.EntityName != box.EntityName() {
+		return ErrBoxServiceAssetNotFound
+	}
 
-	err = s.mailSender.SendMail(
-		user.Email,
-		"Item removed from box "+boxID,
-		body,
-	)
+	err = s.assetService.Delete(asset)
 	if err != nil {
 		return err
 	}
 
 	return nil
 }
+
+// getBox returns the box and its room when check allows the user to act on
+// their household. Boxes of households the user is not a member of are not found.
+func (s *BoxService) getBox(
+	boxID string,
+	userID string,
+	check func(householdID string, userID string) error,
+) (*entities.Box, *entities.Room, error) {
+	box, err := s.boxRepository.GetByID(boxID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	room, err := s.roomRepository.GetByID(box.RoomID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	err = check(room.HouseholdID, userID)
+	if errors.Is(err, ErrHouseholdServiceHouseholdNotFound) {
+		return nil, nil, ErrBoxServiceBoxNotFound
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return box, room, nil
+}
+
+// getRoom returns a room where the user can put boxes.
+func (s *BoxService) getRoom(roomID string, userID string) (*entities.Room, error) {
+	room, err := s.roomRepository.GetByID(roomID)
+	if err != nil {
+		return nil, ErrBoxServiceRoomDoesNotExists
+	}
+
+	err = s.householdService.CheckCanEdit(room.HouseholdID, userID)
+	if errors.Is(err, ErrHouseholdServiceHouseholdNotFound) {
+		return nil, ErrBoxServiceRoomDoesNotExists
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+// getBoxAndItem checks the user can change the box and returns the item when
+// it belongs to the same household as the box.
+func (s *BoxService) getBoxAndItem(boxID string, itemID string, userID string) (*entities.Item, error) {
+	_, room, err := s.getBox(boxID, userID, s.householdService.CheckCanEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := s.itemRepository.GetByID(itemID)
+	if item == nil {
+		return nil, err
+	}
+
+	if item.HouseholdID != room.HouseholdID {
+		return nil, ErrBoxServiceItemNotFound
+	}
+
+	return item, nil
+}
@@ -1,37 +1,57 @@
 package services
 
 import (
+	"bytes"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
-	domainstub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
 	"github.com/labstack/gommon/random"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"testing"
+	"time"
 )
 
 func TestBoxServiceCreateBox(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	roomID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: roomID, HouseholdID: uuid.NewString()}
 
-	roomRepository.On("ExistsByID", roomID).
-		Return(true, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
 	boxRepository.On("Create", mock.AnythingOfType("*entities.Box")).
 		Return(nil)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxCreatedEvent"
+	})).
+		Return(nil)
 
-	box, err := boxService.Create(name, &description, roomID)
+	box, err := boxService.Create(name, &description, roomID, userID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, box)
@@ -42,58 +62,78 @@ func TestBoxServiceCreateBox(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceCreateBoxErrorInRoomRepository(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	name := random.String(100, random.Alphanumeric)
 	roomID := uuid.NewString()
+	userID := uuid.NewString()
 
-	mockError := errors.New("repository error")
-	roomRepository.On("ExistsByID", roomID).
-		Return(false, mockError)
+	roomRepository.On("GetByID", roomID).
+		Return(nil, errors.New("repository error"))
 
-	box, err := boxService.Create(name, nil, roomID)
+	box, err := boxService.Create(name, nil, roomID, userID)
 
 	assert.Error(t, err)
 	assert.Nil(t, box)
-	assert.EqualError(t, err, mockError.Error())
+	assert.ErrorIs(t, err, ErrBoxServiceRoomDoesNotExists)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceCreateBoxErrorInBoxRepository(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	name := random.String(100, random.Alphanumeric)
 	roomID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: roomID, HouseholdID: uuid.NewString()}
 
 	mockError := errors.New("repository error")
-	roomRepository.On("ExistsByID", roomID).
-		Return(true, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("Create", mock.AnythingOfType("*entities.Box")).
 		Return(mockError)
 
-	box, err := boxService.Create(name, nil, roomID)
+	box, err := boxService.Create(name, nil, roomID, userID)
 
 	assert.Error(t, err)
 	assert.Nil(t, box)
@@ -102,35 +142,60 @@ func TestBoxServiceCreateBoxErrorInBoxRepository(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceAddItemIntoBoxWhenThereIsNoBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	quantity := 1.0
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(nil, repositories.ErrBoxRepositoryBoxItemNotFound)
 	boxRepository.On("CreateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
 		Return(nil)
-	eventBus.On("Publish", mock.AnythingOfType("services.BoxItemAddedEvent")).
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxItemAddedEvent"
+	})).
 		Return(nil)
 
-	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID)
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, boxItem)
@@ -141,27 +206,48 @@ func TestBoxServiceAddItemIntoBoxWhenThereIsNoBoxItem(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceAddItemIntoBoxWhenThereIsBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	quantity := 1.0
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(&entities.BoxItem{
 			BoxID:    boxID,
@@ -170,10 +256,14 @@ func TestBoxServiceAddItemIntoBoxWhenThereIsBoxItem(t *testing.T) {
 		}, nil)
 	boxRepository.On("UpdateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
 		Return(nil)
-	eventBus.On("Publish", mock.AnythingOfType("services.BoxItemAddedEvent")).
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxItemAddedEvent"
+	})).
 		Return(nil)
 
-	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID)
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, boxItem)
@@ -184,28 +274,116 @@ func TestBoxServiceAddItemIntoBoxWhenThereIsBoxItem(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceAddItemIntoBoxErrorItemOfAnotherHousehold(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	quantity := 1.0
+	boxID := uuid.NewString()
+	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	itemRepository.On("GetByID", itemID).
+		Return(&entities.Item{
+			ID:          itemID,
+			HouseholdID: uuid.NewString(),
+		}, nil)
+
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
+
+	assert.ErrorIs(t, err, ErrBoxServiceItemNotFound)
+	assert.Nil(t, boxItem)
+	boxRepository.AssertNotCalled(t, "GetBoxItem", boxID, itemID)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceAddItemIntoBoxErrorNotAllowed(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	quantity := 1.0
+	boxID := uuid.NewString()
+	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(ErrHouseholdServiceNotAllowed)
+
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceNotAllowed)
+	assert.Nil(t, boxItem)
+	itemRepository.AssertNotCalled(t, "GetByID", itemID)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceAddItemIntoBoxErrorInItemRepository(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	quantity := 1.0
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(nil, mockError)
 
-	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID)
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.Nil(t, boxItem)
@@ -214,34 +392,52 @@ func TestBoxServiceAddItemIntoBoxErrorInItemRepository(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceAddItemIntoBoxErrorInBoxRepositoryOnCreateBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	quantity := 1.0
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(nil, repositories.ErrBoxRepositoryBoxItemNotFound)
 	boxRepository.On("CreateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
 		Return(mockError)
 
-	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID)
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.Nil(t, boxItem)
@@ -250,28 +446,47 @@ func TestBoxServiceAddItemIntoBoxErrorInBoxRepositoryOnCreateBoxItem(t *testing.
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceAddItemIntoBoxErrorInBoxRepositoryOnUpdateBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	quantity := 1.0
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(&entities.BoxItem{
 			BoxID:    boxID,
@@ -281,7 +496,7 @@ func TestBoxServiceAddItemIntoBoxErrorInBoxRepositoryOnUpdateBoxItem(t *testing.
 	boxRepository.On("UpdateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
 		Return(mockError)
 
-	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID)
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.Nil(t, boxItem)
@@ -290,32 +505,51 @@ func TestBoxServiceAddItemIntoBoxErrorInBoxRepositoryOnUpdateBoxItem(t *testing.
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceAddItemIntoBoxErrorInBoxRepositoryOnGetBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	quantity := 1.0
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(nil, mockError)
 
-	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID)
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.Nil(t, boxItem)
@@ -324,27 +558,151 @@ func TestBoxServiceAddItemIntoBoxErrorInBoxRepositoryOnGetBoxItem(t *testing.T)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceAddItemIntoBoxErrorInOutboxRepository(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	quantity := 1.0
+	boxID := uuid.NewString()
+	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	itemRepository.On("GetByID", itemID).
+		Return(&entities.Item{
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
+		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
+	boxRepository.On("GetBoxItem", boxID, itemID).
+		Return(nil, repositories.ErrBoxRepositoryBoxItemNotFound)
+	boxRepository.On("CreateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
+		Return(nil)
+	outboxRepository.On("Create", mock.AnythingOfType("*entities.OutboxEvent")).
+		Return(repositories.ErrOutboxRepositoryCanNotCreateEvent)
+
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotCreateEvent)
+	assert.Nil(t, boxItem)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceAddItemIntoBoxErrorCanNotBeginTransaction(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	quantity := 1.0
+	boxID := uuid.NewString()
+	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	itemRepository.On("GetByID", itemID).
+		Return(&entities.Item{
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
+		}, nil)
+	transactionManager.On("Transaction").
+		Return(nil, repositories.ErrTransactionManagerCanNotBegin)
+
+	boxItem, err := boxService.AddItemIntoBox(quantity, boxID, itemID, userID)
+
+	assert.ErrorIs(t, err, repositories.ErrTransactionManagerCanNotBegin)
+	assert.Nil(t, boxItem)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceRemoveItemFromBoxDeleteBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	quantity := 10.0
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(&entities.BoxItem{
 			BoxID:    boxID,
@@ -353,37 +711,62 @@ func TestBoxServiceRemoveItemFromBoxDeleteBoxItem(t *testing.T) {
 		}, nil)
 	boxRepository.On("DeleteBoxItem", boxID, itemID).
 		Return(nil)
-	eventBus.On("Publish", mock.AnythingOfType("services.BoxItemRemovedEvent")).
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxItemRemovedEvent"
+	})).
 		Return(nil)
 
-	err := boxService.RemoveItemFromBox(quantity, boxID, itemID)
+	err := boxService.RemoveItemFromBox(quantity, boxID, itemID, userID)
 
 	assert.NoError(t, err)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceRemoveItemFromBoxUpdateBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	quantity := 5.0
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(&entities.BoxItem{
 			BoxID:    boxID,
@@ -392,38 +775,56 @@ func TestBoxServiceRemoveItemFromBoxUpdateBoxItem(t *testing.T) {
 		}, nil)
 	boxRepository.On("UpdateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
 		Return(nil)
-	eventBus.On("Publish", mock.AnythingOfType("services.BoxItemRemovedEvent")).
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxItemRemovedEvent"
+	})).
 		Return(nil)
 
-	err := boxService.RemoveItemFromBox(quantity, boxID, itemID)
+	err := boxService.RemoveItemFromBox(quantity, boxID, itemID, userID)
 
 	assert.NoError(t, err)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceRemoveItemFromBoxErrorInItemRepository(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	quantity := 5.0
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(nil, mockError)
 
-	err := boxService.RemoveItemFromBox(quantity, boxID, itemID)
+	err := boxService.RemoveItemFromBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
@@ -431,32 +832,50 @@ func TestBoxServiceRemoveItemFromBoxErrorInItemRepository(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnGetBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	quantity := 5.0
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(nil, mockError)
 
-	err := boxService.RemoveItemFromBox(quantity, boxID, itemID)
+	err := boxService.RemoveItemFromBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
@@ -464,28 +883,47 @@ func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnGetBoxItem(t *testing.
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnDeleteBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	quantity := 10.0
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(&entities.BoxItem{
 			BoxID:    boxID,
@@ -495,7 +933,7 @@ func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnDeleteBoxItem(t *testi
 	boxRepository.On("DeleteBoxItem", boxID, itemID).
 		Return(mockError)
 
-	err := boxService.RemoveItemFromBox(quantity, boxID, itemID)
+	err := boxService.RemoveItemFromBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
@@ -503,28 +941,47 @@ func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnDeleteBoxItem(t *testi
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnUpdateBoxItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
 	itemID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	quantity := 5.0
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("GetBoxItem", boxID, itemID).
 		Return(&entities.BoxItem{
 			BoxID:    boxID,
@@ -534,7 +991,7 @@ func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnUpdateBoxItem(t *testi
 	boxRepository.On("UpdateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
 		Return(mockError)
 
-	err := boxService.RemoveItemFromBox(quantity, boxID, itemID)
+	err := boxService.RemoveItemFromBox(quantity, boxID, itemID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
@@ -542,19 +999,25 @@ func TestBoxServiceRemoveItemFromBoxErrorInBoxRepositoryOnUpdateBoxItem(t *testi
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceGetAll(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
+	boxID := uuid.NewString()
 	roomID := uuid.NewString()
 	userID := uuid.NewString()
 	search := "search"
@@ -564,6 +1027,8 @@ func TestBoxServiceGetAll(t *testing.T) {
 		Size: 10,
 	}
 
+	householdService.On("GetVisibleHouseholdIDs", "", userID).
+		Return([]string{uuid.NewString()}, nil)
 	boxRepository.On(
 		"GetByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
@@ -571,34 +1036,50 @@ func TestBoxServiceGetAll(t *testing.T) {
 	).
 		Return([]*entities.Box{
 			{
-				ID:          uuid.NewString(),
+				ID:          boxID,
 				Name:        "box",
 				Description: nil,
 				RoomID:      roomID,
 			},
 		}, nil)
+	assetService.On("GetByEntities", mock.AnythingOfType("[]entities.Entity")).
+		Return([]*entities.Asset{
+			{
+				ID:         uuid.NewString(),
+				Extension:  ".png",
+				FileID:     uuid.NewString(),
+				EntityID:   boxID,
+				EntityName: "box",
+			},
+		}, nil)
 
 	boxes, err := boxService.GetAll(roomID, userID, search, pageFilter)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, boxes)
 	assert.Len(t, boxes, 1)
+	assert.Equal(t, boxID, boxes[0].Box.ID)
+	assert.Len(t, boxes[0].Assets, 1)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceGetAllErrorInBoxRepository(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	roomID := uuid.NewString()
 	userID := uuid.NewString()
@@ -609,6 +1090,8 @@ func TestBoxServiceGetAllErrorInBoxRepository(t *testing.T) {
 		Size: 10,
 	}
 
+	householdService.On("GetVisibleHouseholdIDs", "", userID).
+		Return([]string{uuid.NewString()}, nil)
 	mockError := errors.New("repository error")
 	boxRepository.On(
 		"GetByQueryFilters",
@@ -626,24 +1109,30 @@ func TestBoxServiceGetAllErrorInBoxRepository(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceCountAll(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	roomID := uuid.NewString()
 	userID := uuid.NewString()
 	search := "search"
 	expectedCount := 10
 
+	householdService.On("GetVisibleHouseholdIDs", "", userID).
+		Return([]string{uuid.NewString()}, nil)
 	boxRepository.On(
 		"CountByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
@@ -658,23 +1147,29 @@ func TestBoxServiceCountAll(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceCountAllErrorInBoxRepository(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	roomID := uuid.NewString()
 	userID := uuid.NewString()
 	search := "search"
 
+	householdService.On("GetVisibleHouseholdIDs", "", userID).
+		Return([]string{uuid.NewString()}, nil)
 	mockError := errors.New("repository error")
 	boxRepository.On(
 		"CountByQueryFilters",
@@ -691,26 +1186,43 @@ func TestBoxServiceCountAllErrorInBoxRepository(t *testing.T) {
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceTransferItem(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	originBoxID := uuid.NewString()
 	destinationBoxID := uuid.NewString()
 	itemID := uuid.NewString()
-
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	boxRepository.On("GetByID", originBoxID).
+		Return(&entities.Box{ID: originBoxID, RoomID: room.ID}, nil)
+	boxRepository.On("GetByID", destinationBoxID).
+		Return(&entities.Box{ID: destinationBoxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	itemRepository.On("GetByID", itemID).
 		Return(&entities.Item{
-			ID: itemID,
+			ID:          itemID,
+			HouseholdID: room.HouseholdID,
 		}, nil)
 	boxRepository.On("GetBoxItem", originBoxID, itemID).
 		Return(&entities.BoxItem{
@@ -728,67 +1240,133 @@ func TestBoxServiceTransferItem(t *testing.T) {
 		Return(nil)
 	boxRepository.On("UpdateBoxItem", mock.AnythingOfType("*entities.BoxItem")).
 		Return(nil)
-	eventBus.On("Publish", mock.AnythingOfType("services.BoxItemRemovedEvent")).
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxItemRemovedEvent"
+	})).
 		Return(nil)
-	eventBus.On("Publish", mock.AnythingOfType("services.BoxItemAddedEvent")).
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxItemAddedEvent"
+	})).
 		Return(nil)
 
-	err := boxService.TransferItem(originBoxID, destinationBoxID, itemID)
+	err := boxService.TransferItem(originBoxID, destinationBoxID, itemID, userID)
 
 	assert.NoError(t, err)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceDeleteWithTransactionsAndItemQuantities(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
 	boxRepository.On("DeleteBoxTransactionsByBoxID", boxID).
 		Return(nil)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxDeletedEvent"
+	})).
+		Return(nil)
 	boxRepository.On("DeleteBoxItemsByBoxID", boxID).
 		Return(nil)
 	boxRepository.On("Delete", boxID).
 		Return(nil)
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   boxID,
+		EntityName: "box",
+	}
+	var pageFilter *PageFilter
+	assetService.On("GetByEntity", &entities.Box{ID: boxID}, pageFilter).
+		Return([]*entities.Asset{asset}, nil)
+	assetService.On("Delete", asset).
+		Return(nil)
 
-	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID)
+	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID, userID)
 
 	assert.NoError(t, err)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceDeleteWithTransactionsAndItemQuantitiesErrorInBoxRepositoryOnDeleteBoxTransactionsByBoxID(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("DeleteBoxTransactionsByBoxID", boxID).
 		Return(mockError)
 
-	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID)
+	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
@@ -796,28 +1374,47 @@ func TestBoxServiceDeleteWithTransactionsAndItemQuantitiesErrorInBoxRepositoryOn
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceDeleteWithTransactionsAndItemQuantitiesErrorInBoxRepositoryOnDeleteBoxItemsByBoxID(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("DeleteBoxTransactionsByBoxID", boxID).
 		Return(nil)
 	boxRepository.On("DeleteBoxItemsByBoxID", boxID).
 		Return(mockError)
 
-	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID)
+	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
@@ -825,55 +1422,143 @@ func TestBoxServiceDeleteWithTransactionsAndItemQuantitiesErrorInBoxRepositoryOn
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceDeleteWithTransactionsAndItemQuantitiesErrorInBoxRepositoryOnDeleteBox(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	boxRepository.On("DeleteBoxItemsByBoxID", boxID).
 		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("DeleteBoxTransactionsByBoxID", boxID).
 		Return(nil)
 	boxRepository.On("Delete", boxID).
 		Return(mockError)
 
-	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID)
+	err := boxService.DeleteWithTransactionsAndItemQuantities(boxID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceGet(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	box := &entities.Box{ID: uuid.NewString(), RoomID: room.ID}
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(nil)
+
+	resultBox, resultRoom, err := boxService.Get(box.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, box, resultBox)
+	assert.Equal(t, room, resultRoom)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceGetErrorBoxNotFound(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	box := &entities.Box{ID: uuid.NewString(), RoomID: room.ID}
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	resultBox, resultRoom, err := boxService.Get(box.ID, userID)
+
+	assert.ErrorIs(t, err, ErrBoxServiceBoxNotFound)
+	assert.Nil(t, resultBox)
+	assert.Nil(t, resultRoom)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceUpdate(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	name := "box"
 	description := "description"
 
@@ -882,11 +1567,26 @@ func TestBoxServiceUpdate(t *testing.T) {
 			ID:          boxID,
 			Name:        name,
 			Description: &description,
+			RoomID:      room.ID,
 		}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
 	boxRepository.On("Update", mock.AnythingOfType("*entities.Box")).
 		Return(nil)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxUpdatedEvent"
+	})).
+		Return(nil)
 
-	box, err := boxService.Update(boxID, name, &description)
+	box, err := boxService.Update(boxID, userID, name, &description)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, box)
@@ -896,22 +1596,30 @@ func TestBoxServiceUpdate(t *testing.T) {
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceUpdateErrorInBoxRepositoryOnGetByID(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
 	name := "box"
 	description := "description"
 
@@ -919,7 +1627,7 @@ func TestBoxServiceUpdateErrorInBoxRepositoryOnGetByID(t *testing.T) {
 	boxRepository.On("GetByID", boxID).
 		Return(nil, mockError)
 
-	box, err := boxService.Update(boxID, name, &description)
+	box, err := boxService.Update(boxID, userID, name, &description)
 
 	assert.Error(t, err)
 	assert.Nil(t, box)
@@ -927,22 +1635,29 @@ func TestBoxServiceUpdateErrorInBoxRepositoryOnGetByID(t *testing.T) {
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceUpdateErrorInBoxRepositoryOnUpdate(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 	name := "box"
 	description := "description"
 
@@ -952,11 +1667,20 @@ func TestBoxServiceUpdateErrorInBoxRepositoryOnUpdate(t *testing.T) {
 			ID:          boxID,
 			Name:        name,
 			Description: &description,
+			RoomID:      room.ID,
 		}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("Update", mock.AnythingOfType("*entities.Box")).
 		Return(mockError)
 
-	box, err := boxService.Update(boxID, name, &description)
+	box, err := boxService.Update(boxID, userID, name, &description)
 
 	assert.Error(t, err)
 	assert.Nil(t, box)
@@ -964,51 +1688,125 @@ func TestBoxServiceUpdateErrorInBoxRepositoryOnUpdate(t *testing.T) {
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceTransferToRoom(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
-	roomID := uuid.NewString()
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+	currentRoom := &entities.Room{ID: uuid.NewString(), HouseholdID: householdID}
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: householdID}
 
 	boxRepository.On("GetByID", boxID).
 		Return(&entities.Box{
-			ID: boxID,
+			ID:     boxID,
+			RoomID: currentRoom.ID,
 		}, nil)
+	roomRepository.On("GetByID", currentRoom.ID).
+		Return(currentRoom, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", householdID, userID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
 	boxRepository.On("Update", mock.AnythingOfType("*entities.Box")).
 		Return(nil)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "BoxUpdatedEvent"
+	})).
+		Return(nil)
 
-	err := boxService.TransferToRoom(boxID, roomID)
+	err := boxService.TransferToRoom(boxID, room.ID, userID)
 
 	assert.NoError(t, err)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceTransferToRoomErrorRoomOfAnotherHousehold(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	currentRoom := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{
+			ID:     boxID,
+			RoomID: currentRoom.ID,
+		}, nil)
+	roomRepository.On("GetByID", currentRoom.ID).
+		Return(currentRoom, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", currentRoom.HouseholdID, userID).
+		Return(nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+
+	err := boxService.TransferToRoom(boxID, room.ID, userID)
+
+	assert.ErrorIs(t, err, ErrBoxServiceRoomDoesNotExists)
+	boxRepository.AssertNotCalled(t, "Update", mock.Anything)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceTransferToRoomErrorInBoxRepositoryOnGetByID(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
 	roomID := uuid.NewString()
@@ -1017,103 +1815,143 @@ func TestBoxServiceTransferToRoomErrorInBoxRepositoryOnGetByID(t *testing.T) {
 	boxRepository.On("GetByID", boxID).
 		Return(nil, mockError)
 
-	err := boxService.TransferToRoom(boxID, roomID)
+	err := boxService.TransferToRoom(boxID, roomID, uuid.NewString())
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceTransferToRoomErrorInBoxRepositoryOnUpdate(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
-	roomID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
 	mockError := errors.New("repository error")
 	boxRepository.On("GetByID", boxID).
-		Return(&entities.Box{}, nil)
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).
+		Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("BoxRepository").
+		Return(boxRepository)
 	boxRepository.On("Update", mock.AnythingOfType("*entities.Box")).
 		Return(mockError)
 
-	err := boxService.TransferToRoom(boxID, roomID)
+	err := boxService.TransferToRoom(boxID, room.ID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceGetBoxTransactions(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
 	pageFilter := PageFilter{
 		Page: 1,
 		Size: 1,
 	}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).
+		Return(nil)
 	boxRepository.On("GetBoxTransactionsByQueryFilters", mock.AnythingOfType("repositories.QueryFilter"), mock.AnythingOfType("*repositories.PageFilter")).
 		Return([]*entities.BoxTransaction{}, nil)
 
-	transactions, err := boxService.GetBoxTransactions(boxID, pageFilter)
+	transactions, err := boxService.GetBoxTransactions(boxID, userID, pageFilter)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, transactions)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceGetBoxTransactionsErrorInBoxRepositoryOnGetBoxTransactionsByQueryFilters(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
 	pageFilter := PageFilter{
 		Page: 1,
 		Size: 1,
 	}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	boxRepository.On("GetBoxTransactionsByQueryFilters", mock.AnythingOfType("repositories.QueryFilter"), mock.AnythingOfType("*repositories.PageFilter")).
 		Return(nil, mockError)
 
-	transactions, err := boxService.GetBoxTransactions(boxID, pageFilter)
+	transactions, err := boxService.GetBoxTransactions(boxID, userID, pageFilter)
 
 	assert.Error(t, err)
 	assert.Nil(t, transactions)
@@ -1121,55 +1959,79 @@ func TestBoxServiceGetBoxTransactionsErrorInBoxRepositoryOnGetBoxTransactionsByQ
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceCountBoxTransactions(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).
+		Return(nil)
 	boxRepository.On("CountBoxTransactionsByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).
 		Return(int64(1), nil)
 
-	count, err := boxService.CountBoxTransactions(boxID)
+	count, err := boxService.CountBoxTransactions(boxID, userID)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), count)
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestBoxServiceCountBoxTransactionsErrorInBoxRepositoryOnCountBoxTransactionsByQueryFilters(t *testing.T) {
 	boxRepository := new(stub.BoxRepositoryMock)
 	roomRepository := new(stub.RoomRepositoryMock)
 	itemRepository := new(stub.ItemRepositoryMock)
-	eventBus := new(domainstub.EventBusMock)
+	transactionManager := new(stub.TransactionManagerMock)
 	userRepository := new(stub.UserRepositoryMock)
-	mailSender := new(domainstub.MailSenderMock)
-	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, eventBus, mailSender)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
 
 	boxID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	boxRepository.On("GetByID", boxID).
+		Return(&entities.Box{ID: boxID, RoomID: room.ID}, nil)
+	roomRepository.On("GetByID", room.ID).
+		Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).
+		Return(nil)
 	mockError := errors.New("repository error")
 	boxRepository.On("CountBoxTransactionsByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).
 		Return(int64(0), mockError)
 
-	count, err := boxService.CountBoxTransactions(boxID)
+	count, err := boxService.CountBoxTransactions(boxID, userID)
 
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), count)
@@ -1177,8 +2039,428 @@ func TestBoxServiceCountBoxTransactionsErrorInBoxRepositoryOnCountBoxTransaction
 	boxRepository.AssertExpectations(t)
 	itemRepository.AssertExpectations(t)
 	roomRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceNotifyBoxItemAdded(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	boxService := NewBoxService(
+		boxRepository,
+		new(stub.ItemRepositoryMock),
+		roomRepository,
+		userRepository,
+		new(stub.TransactionManagerMock),
+		notificationService,
+		new(AssetServiceMock),
+		new(HouseholdServiceMock),
+	)
+
+	verifiedAt := time.Now()
+	user := &entities.User{
+		ID:         uuid.NewString(),
+		Email:      "test@example.com",
+		VerifiedAt: &verifiedAt,
+		Timezone:   "America/Lima",
+	}
+	room := &entities.Room{ID: uuid.NewString(), Name: "Garage"}
+	box := &entities.Box{ID: uuid.NewString(), Name: "Tools", RoomID: room.ID}
+	item := entities.Item{ID: uuid.NewString(), Name: "Screwdriver"}
+	happenedAt := time.Date(2026, 10, 19, 14, 20, 0, 0, time.UTC)
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	userRepository.On("GetUsersByBoxID", box.ID).Return([]*entities.User{user}, nil)
+	notificationService.On("Notify", user, Notification{
+		EventType: "BoxItemAddedEvent",
+		RoomID:    room.ID,
+		Subject:   "Screwdriver added into Tools",
+		Message:   "2 Screwdriver added into the box Tools of the room Garage at Mon, 19 Oct 2026 09:20 -05",
+		Template:  services.MailTemplateBoxItemAdded,
+		Data: services.BoxItemMailData{
+			Quantity:   "2",
+			ItemName:   "Screwdriver",
+			BoxName:    "Tools",
+			RoomName:   "Garage",
+			HappenedAt: "Mon, 19 Oct 2026 09:20 -05",
+		},
+		HappenedAt: happenedAt,
+	}).Return(nil)
+
+	err := boxService.NotifyBoxItemAdded(2, box.ID, item, happenedAt)
+
+	assert.NoError(t, err)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+}
+
+func TestBoxServiceNotifyBoxItemAddedToEveryMember(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	boxService := NewBoxService(
+		boxRepository,
+		new(stub.ItemRepositoryMock),
+		roomRepository,
+		userRepository,
+		new(stub.TransactionManagerMock),
+		notificationService,
+		new(AssetServiceMock),
+		new(HouseholdServiceMock),
+	)
+
+	verifiedAt := time.Now()
+	owner := &entities.User{ID: uuid.NewString(), Email: "owner@example.com", VerifiedAt: &verifiedAt}
+	editor := &entities.User{ID: uuid.NewString(), Email: "editor@example.com", VerifiedAt: &verifiedAt, Timezone: "Europe/Madrid"}
+	viewer := &entities.User{ID: uuid.NewString(), Email: "viewer@example.com"}
+	room := &entities.Room{ID: uuid.NewString(), Name: "Garage"}
+	box := &entities.Box{ID: uuid.NewString(), Name: "Tools", RoomID: room.ID}
+	item := entities.Item{ID: uuid.NewString(), Name: "Screwdriver"}
+	happenedAt := time.Date(2026, 10, 19, 14, 20, 0, 0, time.UTC)
+	notifyErr := errors.New("can not send mail")
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	userRepository.On("GetUsersByBoxID", box.ID).Return([]*entities.User{owner, editor, viewer}, nil)
+	notificationService.On("Notify", owner, mock.MatchedBy(func(notification Notification) bool {
+		return notification.Data.(services.BoxItemMailData).HappenedAt == "Mon, 19 Oct 2026 14:20 UTC"
+	})).Return(notifyErr)
+	notificationService.On("Notify", editor, mock.MatchedBy(func(notification Notification) bool {
+		return notification.Data.(services.BoxItemMailData).HappenedAt == "Mon, 19 Oct 2026 16:20 CEST"
+	})).Return(nil)
+	notificationService.On("Notify", viewer, mock.AnythingOfType("services.Notification")).Return(nil)
+
+	err := boxService.NotifyBoxItemAdded(2, box.ID, item, happenedAt)
+
+	assert.ErrorIs(t, err, notifyErr)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+}
+
+func TestBoxServiceNotifyBoxItemRemoved(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	boxService := NewBoxService(
+		boxRepository,
+		new(stub.ItemRepositoryMock),
+		roomRepository,
+		userRepository,
+		new(stub.TransactionManagerMock),
+		notificationService,
+		new(AssetServiceMock),
+		new(HouseholdServiceMock),
+	)
+
+	verifiedAt := time.Now()
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", VerifiedAt: &verifiedAt}
+	room := &entities.Room{ID: uuid.NewString(), Name: "Garage"}
+	box := &entities.Box{ID: uuid.NewString(), Name: "Tools", RoomID: room.ID}
+	item := entities.Item{ID: uuid.NewString(), Name: "Hammer"}
+	happenedAt := time.Date(2026, 10, 19, 14, 20, 0, 0, time.UTC)
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	userRepository.On("GetUsersByBoxID", box.ID).Return([]*entities.User{user}, nil)
+	notificationService.On("Notify", user, Notification{
+		EventType: "BoxItemRemovedEvent",
+		RoomID:    room.ID,
+		Subject:   "Hammer removed from Tools",
+		Message:   "1 Hammer removed from the box Tools of the room Garage at Mon, 19 Oct 2026 14:20 UTC",
+		Template:  services.MailTemplateBoxItemRemoved,
+		Data: services.BoxItemMailData{
+			Quantity:   "1",
+			ItemName:   "Hammer",
+			BoxName:    "Tools",
+			RoomName:   "Garage",
+			HappenedAt: "Mon, 19 Oct 2026 14:20 UTC",
+		},
+		HappenedAt: happenedAt,
+	}).Return(nil)
+
+	err := boxService.NotifyBoxItemRemoved(1, box.ID, item, happenedAt)
+
+	assert.NoError(t, err)
+	boxRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+}
+
+func TestBoxServiceNotifyBoxItemRemovedNotifiesUnverifiedUser(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	boxService := NewBoxService(
+		boxRepository,
+		new(stub.ItemRepositoryMock),
+		roomRepository,
+		userRepository,
+		new(stub.TransactionManagerMock),
+		notificationService,
+		new(AssetServiceMock),
+		new(HouseholdServiceMock),
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	room := &entities.Room{ID: uuid.NewString(), Name: "Garage"}
+	box := &entities.Box{ID: uuid.NewString(), Name: "Tools", RoomID: room.ID}
+	item := entities.Item{ID: uuid.NewString(), Name: "item"}
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	userRepository.On("GetUsersByBoxID", box.ID).Return([]*entities.User{user}, nil)
+	notificationService.On("Notify", user, mock.MatchedBy(func(notification Notification) bool {
+		return notification.Subject == "item removed from Tools"
+	})).Return(nil)
+
+	err := boxService.NotifyBoxItemRemoved(2, box.ID, item, time.Now())
+
+	assert.NoError(t, err)
+	userRepository.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+}
+
+func TestBoxServiceCreateAsset(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+	}
+	box := &entities.Box{
+		ID:     uuid.NewString(),
+		Name:   "box",
+		RoomID: room.ID,
+	}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
+
+	expectedAsset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   box.ID,
+		EntityName: "box",
+	}
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	assetService.On("CreateFromFile", file, box).Return(expectedAsset, nil)
+
+	asset, err := boxService.CreateAsset(box.ID, userID, file)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAsset, asset)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceCreateAssetErrorBoxNotFound(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+	}
+	box := &entities.Box{
+		ID:     uuid.NewString(),
+		Name:   "box",
+		RoomID: room.ID,
+	}
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	asset, err := boxService.CreateAsset(box.ID, userID, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, asset)
+	assert.ErrorIs(t, err, ErrBoxServiceBoxNotFound)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceGetAssets(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+	}
+	box := &entities.Box{
+		ID:     uuid.NewString(),
+		Name:   "box",
+		RoomID: room.ID,
+	}
+	expectedAssets := []*entities.Asset{
+		{
+			ID:         uuid.NewString(),
+			EntityID:   box.ID,
+			EntityName: "box",
+		},
+	}
+	var pageFilter *PageFilter
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(nil)
+	assetService.On("GetByEntity", box, pageFilter).Return(expectedAssets, nil)
+
+	assets, err := boxService.GetAssets(box.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAssets, assets)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceDeleteAsset(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+	}
+	box := &entities.Box{
+		ID:     uuid.NewString(),
+		Name:   "box",
+		RoomID: room.ID,
+	}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   box.ID,
+		EntityName: "box",
+	}
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	assetService.On("GetByID", asset.ID).Return(asset, nil)
+	assetService.On("Delete", asset).Return(nil)
+
+	err := boxService.DeleteAsset(box.ID, userID, asset.ID)
+
+	assert.NoError(t, err)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestBoxServiceDeleteAssetErrorAssetNotFound(t *testing.T) {
+	boxRepository := new(stub.BoxRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	itemRepository := new(stub.ItemRepositoryMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	userRepository := new(stub.UserRepositoryMock)
+	notificationService := new(NotificationServiceMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	boxService := NewBoxService(boxRepository, itemRepository, roomRepository, userRepository, transactionManager, notificationService, assetService, householdService)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+	}
+	box := &entities.Box{
+		ID:     uuid.NewString(),
+		Name:   "box",
+		RoomID: room.ID,
+	}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   box.ID,
+		EntityName: "item",
+	}
+
+	boxRepository.On("GetByID", box.ID).Return(box, nil)
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	assetService.On("GetByID", asset.ID).Return(asset, nil)
+
+	err := boxService.DeleteAsset(box.ID, userID, asset.ID)
+
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrBoxServiceAssetNotFound)
+	boxRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
 	userRepository.AssertExpectations(t)
-	eventBus.AssertExpectations(t)
-	mailSender.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	notificationService.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
@@ -0,0 +1,55 @@
+package services
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"time"
+)
+
+// ClaimDuration is how long a claimed record is hidden from other workers. A
+// record whose worker died is processed again after it.
+const ClaimDuration = 5 * time.Minute
+
+// ProcessAvailable claims up to limit available records and saves each one
+// once process marks it as done or failed. The records that fail to be
+// claimed with alreadyClaimed are skipped. It returns how many records
+// process reported as done, and stops at the first error of process or of the
+// repository.
+func ProcessAvailable[T any](
+	repository repositories.ClaimableRepository[T],
+	alreadyClaimed error,
+	limit int,
+	process func(record T) (bool, error),
+) (int, error) {
+	records, err := repository.GetAvailable(time.Now(), limit)
+	if err != nil {
+		return 0, err
+	}
+
+	done := 0
+	for _, record := range records {
+		now := time.Now()
+		err = repository.Claim(record, now, now.Add(ClaimDuration))
+		if errors.Is(err, alreadyClaimed) {
+			continue
+		}
+		if err != nil {
+			return done, err
+		}
+
+		ok, err := process(record)
+		if err != nil {
+			return done, err
+		}
+		if ok {
+			done++
+		}
+
+		err = repository.Update(record)
+		if err != nil {
+			return done, err
+		}
+	}
+
+	return done, nil
+}
@@ -0,0 +1,409 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/stretchr/testify/mock"
+)
+
+const DefaultHouseholdName = "My home"
+
+var (
+	ErrHouseholdServiceHouseholdNotFound  = errors.New("household not found")
+	ErrHouseholdServiceNotAllowed         = errors.New("your role in the household does not allow this action")
+	ErrHouseholdServiceInvitationNotFound = errors.New("invitation not found")
+	ErrHouseholdServiceAlreadyMember      = errors.New("user is already a member of the household")
+	ErrHouseholdServiceEmailNotVerified   = errors.New("verify your email to answer the invitation")
+)
+
+// HouseholdServiceInterface is used by the services of the inventory to scope
+// what a user can see and change by their household memberships.
+type HouseholdServiceInterface interface {
+	GetVisibleHouseholdIDs(householdID string, userID string) ([]string, error)
+	CheckCanView(householdID string, userID string) error
+	CheckCanEdit(householdID string, userID string) error
+	ResolveHouseholdID(householdID string, userID string) (string, error)
+}
+
+type HouseholdService struct {
+	householdRepository repositories.HouseholdRepository
+	userRepository      repositories.UserRepository
+	eventBus            services.EventBus
+	mailSender          services.MailSender
+}
+
+func NewHouseholdService(
+	householdRepository repositories.HouseholdRepository,
+	userRepository repositories.UserRepository,
+	eventBus services.EventBus,
+	mailSender services.MailSender,
+) *HouseholdService {
+	return &HouseholdService{
+		householdRepository,
+		userRepository,
+		eventBus,
+		mailSender,
+	}
+}
+
+func (s *HouseholdService) Create(name string, userID string) (*entities.Household, error) {
+	household, err := entities.NewHousehold(name)
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := entities.NewHouseholdMember(household.ID, userID, entities.HouseholdRoleOwner)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.householdRepository.Create(household)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.householdRepository.CreateMember(member)
+	if err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+func (s *HouseholdService) GetAll(userID string) ([]struct {
+	Household *entities.Household
+	Role      string
+}, error) {
+	members, err := s.householdRepository.GetMembersByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]struct {
+		Household *entities.Household
+		Role      string
+	}, 0)
+	if len(members) == 0 {
+		return output, nil
+	}
+
+	rolesByHouseholdID := make(map[string]string)
+	householdIDs := make([]string, 0, len(members))
+	for i := range members {
+		rolesByHouseholdID[members[i].HouseholdID] = members[i].Role
+		householdIDs = append(householdIDs, members[i].HouseholdID)
+	}
+
+	households, err := s.householdRepository.GetByIDs(householdIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	for i := range households {
+		output = append(output, struct {
+			Household *entities.Household
+			Role      string
+		}{
+			Household: households[i],
+			Role:      rolesByHouseholdID[households[i].ID],
+		})
+	}
+
+	return output, nil
+}
+
+func (s *HouseholdService) GetMembers(householdID string, userID string) ([]*entities.HouseholdMember, error) {
+	err := s.CheckCanView(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.householdRepository.GetMembersByHouseholdID(householdID)
+}
+
+// Invite creates an invitation for the email, only owners can invite. The
+// email is sent by a listener.
+func (s *HouseholdService) Invite(
+	householdID string,
+	userID string,
+	email string,
+	role string,
+) (*entities.HouseholdInvitation, error) {
+	member, err := s.getMember(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if !member.CanManage() {
+		return nil, ErrHouseholdServiceNotAllowed
+	}
+
+	invitation, err := entities.NewHouseholdInvitation(householdID, email, role, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	invitedUser, err := s.userRepository.FindByEmail(email)
+	if err != nil && !errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+		return nil, err
+	}
+
+	if invitedUser != nil {
+		_, err = s.householdRepository.GetMember(householdID, invitedUser.ID)
+		if err == nil {
+			return nil, ErrHouseholdServiceAlreadyMember
+		}
+
+		if !errors.Is(err, repositories.ErrHouseholdRepositoryMemberNotFound) {
+			return nil, err
+		}
+	}
+
+	err = s.householdRepository.CreateInvitation(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.eventBus.Publish(services.HouseholdInvitationCreatedEvent{
+		Invitation: *invitation,
+	})
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return invitation, nil
+}
+
+func (s *HouseholdService) SendInvitation(invitation *entities.HouseholdInvitation) error {
+	households, err := s.householdRepository.GetByIDs([]string{invitation.HouseholdID})
+	if err != nil {
+		return err
+	}
+
+	if len(households) == 0 {
+		return ErrHouseholdServiceHouseholdNotFound
+	}
+
+	body := fmt.Sprintf(
+		"You were invited to join the household %s as %s.\n\n"+
+			"Sign in, or sign up with this email, to accept or decline the invitation.",
+		households[0].Name,
+		invitation.Role,
+	)
+
+	return s.mailSender.SendMail(invitation.Email, "You were invited to a household", body)
+}
+
+func (s *HouseholdService) GetInvitations(userID string) ([]*entities.HouseholdInvitation, error) {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.householdRepository.GetPendingInvitationsByEmail(user.Email)
+}
+
+func (s *HouseholdService) AcceptInvitation(invitationID string, userID string) (*entities.HouseholdMember, error) {
+	invitation, err := s.getUserInvitation(invitationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	_, err = s.householdRepository.GetMember(invitation.HouseholdID, userID)
+	if err == nil {
+		return nil, ErrHouseholdServiceAlreadyMember
+	}
+
+	if !errors.Is(err, repositories.ErrHouseholdRepositoryMemberNotFound) {
+		return nil, err
+	}
+
+	err = invitation.Accept()
+	if err != nil {
+		return nil, err
+	}
+
+	member, err := entities.NewHouseholdMember(invitation.HouseholdID, userID, invitation.Role)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.householdRepository.CreateMember(member)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.householdRepository.UpdateInvitation(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+func (s *HouseholdService) DeclineInvitation(invitationID string, userID string) (*entities.HouseholdInvitation, error) {
+	invitation, err := s.getUserInvitation(invitationID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = invitation.Decline()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.householdRepository.UpdateInvitation(invitation)
+	if err != nil {
+		return nil, err
+	}
+
+	return invitation, nil
+}
+
+// GetVisibleHouseholdIDs returns the households to search in: the given one
+// when the user is a member, or every household of the user when it is empty.
+func (s *HouseholdService) GetVisibleHouseholdIDs(householdID string, userID string) ([]string, error) {
+	if householdID != "" {
+		err := s.CheckCanView(householdID, userID)
+		if err != nil {
+			return nil, err
+		}
+
+		return []string{householdID}, nil
+	}
+
+	members, err := s.householdRepository.GetMembersByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	householdIDs := make([]string, 0, len(members))
+	for i := range members {
+		householdIDs = append(householdIDs, members[i].HouseholdID)
+	}
+
+	return householdIDs, nil
+}
+
+func (s *HouseholdService) CheckCanView(householdID string, userID string) error {
+	_, err := s.getMember(householdID, userID)
+	return err
+}
+
+func (s *HouseholdService) CheckCanEdit(householdID string, userID string) error {
+	member, err := s.getMember(householdID, userID)
+	if err != nil {
+		return err
+	}
+
+	if !member.CanEdit() {
+		return ErrHouseholdServiceNotAllowed
+	}
+
+	return nil
+}
+
+// ResolveHouseholdID returns the household where the user wants to create
+// something. When no household is given, the first one the user owns is
+// used, and a new one is created if the user does not own any.
+func (s *HouseholdService) ResolveHouseholdID(householdID string, userID string) (string, error) {
+	if householdID != "" {
+		err := s.CheckCanEdit(householdID, userID)
+		if err != nil {
+			return "", err
+		}
+
+		return householdID, nil
+	}
+
+	members, err := s.householdRepository.GetMembersByUserID(userID)
+	if err != nil {
+		return "", err
+	}
+
+	for i := range members {
+		if members[i].Role == entities.HouseholdRoleOwner {
+			return members[i].HouseholdID, nil
+		}
+	}
+
+	household, err := s.Create(DefaultHouseholdName, userID)
+	if err != nil {
+		return "", err
+	}
+
+	return household.ID, nil
+}
+
+func (s *HouseholdService) getMember(householdID string, userID string) (*entities.HouseholdMember, error) {
+	member, err := s.householdRepository.GetMember(householdID, userID)
+	if errors.Is(err, repositories.ErrHouseholdRepositoryMemberNotFound) {
+		return nil, ErrHouseholdServiceHouseholdNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return member, nil
+}
+
+// getUserInvitation returns the invitation only to the user it was sent to,
+// for anyone else it does not exist. The user must have verified the email,
+// anyone can sign up with an address they do not own.
+func (s *HouseholdService) getUserInvitation(invitationID string, userID string) (*entities.HouseholdInvitation, error) {
+	invitation, err := s.householdRepository.GetInvitationByID(invitationID)
+	if errors.Is(err, repositories.ErrHouseholdRepositoryInvitationNotFound) {
+		return nil, ErrHouseholdServiceInvitationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email != invitation.Email {
+		return nil, ErrHouseholdServiceInvitationNotFound
+	}
+
+	if !user.IsVerified() {
+		return nil, ErrHouseholdServiceEmailNotVerified
+	}
+
+	return invitation, nil
+}
+
+type HouseholdServiceMock struct {
+	mock.Mock
+}
+
+func (s *HouseholdServiceMock) GetVisibleHouseholdIDs(householdID string, userID string) ([]string, error) {
+	args := s.Called(householdID, userID)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]string), args.Error(1)
+}
+
+func (s *HouseholdServiceMock) CheckCanView(householdID string, userID string) error {
+	args := s.Called(householdID, userID)
+	return args.Error(0)
+}
+
+func (s *HouseholdServiceMock) CheckCanEdit(householdID string, userID string) error {
+	args := s.Called(householdID, userID)
+	return args.Error(0)
+}
+
+func (s *HouseholdServiceMock) ResolveHouseholdID(householdID string, userID string) (string, error) {
+	args := s.Called(householdID, userID)
+	return args.String(0), args.Error(1)
+}
@@ -0,0 +1,321 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	domainstub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestHouseholdServiceCreate(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	userID := uuid.NewString()
+
+	householdRepository.On("Create", mock.AnythingOfType("*entities.Household")).Return(nil)
+	householdRepository.On("CreateMember", mock.AnythingOfType("*entities.HouseholdMember")).Return(nil)
+
+	household, err := householdService.Create("Beach house", userID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, household)
+	assert.Equal(t, "Beach house", household.Name)
+	member := householdRepository.Calls[1].Arguments.Get(0).(*entities.HouseholdMember)
+	assert.Equal(t, household.ID, member.HouseholdID)
+	assert.Equal(t, userID, member.UserID)
+	assert.Equal(t, entities.HouseholdRoleOwner, member.Role)
+	householdRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceInvite(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+	email := "guest@example.com"
+
+	householdRepository.On("GetMember", householdID, userID).
+		Return(&entities.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: entities.HouseholdRoleOwner}, nil)
+	userRepository.On("FindByEmail", email).Return(nil, repositories.ErrUserRepositoryUserNotFound)
+	householdRepository.On("CreateInvitation", mock.AnythingOfType("*entities.HouseholdInvitation")).Return(nil)
+	eventBus.On("Publish", mock.AnythingOfType("services.HouseholdInvitationCreatedEvent")).Return(nil)
+
+	invitation, err := householdService.Invite(householdID, userID, email, entities.HouseholdRoleEditor)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, invitation)
+	assert.Equal(t, householdID, invitation.HouseholdID)
+	assert.Equal(t, email, invitation.Email)
+	assert.Equal(t, entities.HouseholdRoleEditor, invitation.Role)
+	assert.Equal(t, userID, invitation.InvitedBy)
+	assert.True(t, invitation.IsPending())
+	householdRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+}
+
+func TestHouseholdServiceInviteErrorNotAllowed(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+
+	householdRepository.On("GetMember", householdID, userID).
+		Return(&entities.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: entities.HouseholdRoleEditor}, nil)
+
+	invitation, err := householdService.Invite(householdID, userID, "guest@example.com", entities.HouseholdRoleViewer)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceNotAllowed)
+	assert.Nil(t, invitation)
+	householdRepository.AssertNotCalled(t, "CreateInvitation", mock.Anything)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestHouseholdServiceInviteErrorAlreadyMember(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+	invitedUser := &entities.User{ID: uuid.NewString(), Email: "guest@example.com"}
+
+	householdRepository.On("GetMember", householdID, userID).
+		Return(&entities.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: entities.HouseholdRoleOwner}, nil)
+	userRepository.On("FindByEmail", invitedUser.Email).Return(invitedUser, nil)
+	householdRepository.On("GetMember", householdID, invitedUser.ID).
+		Return(&entities.HouseholdMember{HouseholdID: householdID, UserID: invitedUser.ID, Role: entities.HouseholdRoleViewer}, nil)
+
+	invitation, err := householdService.Invite(householdID, userID, invitedUser.Email, entities.HouseholdRoleEditor)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceAlreadyMember)
+	assert.Nil(t, invitation)
+	householdRepository.AssertNotCalled(t, "CreateInvitation", mock.Anything)
+}
+
+func TestHouseholdServiceAcceptInvitation(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	verifiedAt := time.Now()
+	user := &entities.User{ID: uuid.NewString(), Email: "guest@example.com", VerifiedAt: &verifiedAt}
+	invitation, _ := entities.NewHouseholdInvitation(uuid.NewString(), user.Email, entities.HouseholdRoleViewer, uuid.NewString())
+
+	householdRepository.On("GetInvitationByID", invitation.ID).Return(invitation, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	householdRepository.On("GetMember", invitation.HouseholdID, user.ID).
+		Return(nil, repositories.ErrHouseholdRepositoryMemberNotFound)
+	householdRepository.On("CreateMember", mock.AnythingOfType("*entities.HouseholdMember")).Return(nil)
+	householdRepository.On("UpdateInvitation", invitation).Return(nil)
+
+	member, err := householdService.AcceptInvitation(invitation.ID, user.ID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, member)
+	assert.Equal(t, invitation.HouseholdID, member.HouseholdID)
+	assert.Equal(t, user.ID, member.UserID)
+	assert.Equal(t, entities.HouseholdRoleViewer, member.Role)
+	assert.Equal(t, entities.HouseholdInvitationStatusAccepted, invitation.Status)
+	householdRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceAcceptInvitationErrorInvitationOfAnotherEmail(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "other@example.com"}
+	invitation, _ := entities.NewHouseholdInvitation(uuid.NewString(), "guest@example.com", entities.HouseholdRoleViewer, uuid.NewString())
+
+	householdRepository.On("GetInvitationByID", invitation.ID).Return(invitation, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	member, err := householdService.AcceptInvitation(invitation.ID, user.ID)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceInvitationNotFound)
+	assert.Nil(t, member)
+	assert.True(t, invitation.IsPending())
+	householdRepository.AssertNotCalled(t, "CreateMember", mock.Anything)
+	householdRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceAcceptInvitationErrorEmailNotVerified(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "guest@example.com"}
+	invitation, _ := entities.NewHouseholdInvitation(uuid.NewString(), user.Email, entities.HouseholdRoleEditor, uuid.NewString())
+
+	householdRepository.On("GetInvitationByID", invitation.ID).Return(invitation, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	member, err := householdService.AcceptInvitation(invitation.ID, user.ID)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceEmailNotVerified)
+	assert.Nil(t, member)
+	assert.True(t, invitation.IsPending())
+	householdRepository.AssertNotCalled(t, "CreateMember", mock.Anything)
+	householdRepository.AssertNotCalled(t, "UpdateInvitation", mock.Anything)
+	householdRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceDeclineInvitation(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	householdService := NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+
+	verifiedAt := time.Now()
+	user := &entities.User{ID: uuid.NewString(), Email: "guest@example.com", VerifiedAt: &verifiedAt}
+	invitation, _ := entities.NewHouseholdInvitation(uuid.NewString(), user.Email, entities.HouseholdRoleEditor, uuid.NewString())
+
+	householdRepository.On("GetInvitationByID", invitation.ID).Return(invitation, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	householdRepository.On("UpdateInvitation", invitation).Return(nil)
+
+	declined, err := householdService.DeclineInvitation(invitation.ID, user.ID)
+
+	assert.NoError(t, err)
+	assert.Same(t, invitation, declined)
+	assert.Equal(t, entities.HouseholdInvitationStatusDeclined, invitation.Status)
+	householdRepository.AssertNotCalled(t, "CreateMember", mock.Anything)
+	householdRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceCheckCanEditErrorViewer(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	householdService := NewHouseholdService(householdRepository, new(stub.UserRepositoryMock), nil, nil)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+
+	householdRepository.On("GetMember", householdID, userID).
+		Return(&entities.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: entities.HouseholdRoleViewer}, nil)
+
+	assert.NoError(t, householdService.CheckCanView(householdID, userID))
+	assert.ErrorIs(t, householdService.CheckCanEdit(householdID, userID), ErrHouseholdServiceNotAllowed)
+	householdRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceCheckCanViewErrorNotMember(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	householdService := NewHouseholdService(householdRepository, new(stub.UserRepositoryMock), nil, nil)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+
+	householdRepository.On("GetMember", householdID, userID).
+		Return(nil, repositories.ErrHouseholdRepositoryMemberNotFound)
+
+	err := householdService.CheckCanView(householdID, userID)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceHouseholdNotFound)
+	householdRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceGetVisibleHouseholdIDs(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	householdService := NewHouseholdService(householdRepository, new(stub.UserRepositoryMock), nil, nil)
+
+	userID := uuid.NewString()
+	members := []*entities.HouseholdMember{
+		{HouseholdID: uuid.NewString(), UserID: userID, Role: entities.HouseholdRoleOwner},
+		{HouseholdID: uuid.NewString(), UserID: userID, Role: entities.HouseholdRoleViewer},
+	}
+
+	householdRepository.On("GetMembersByUserID", userID).Return(members, nil)
+
+	householdIDs, err := householdService.GetVisibleHouseholdIDs("", userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{members[0].HouseholdID, members[1].HouseholdID}, householdIDs)
+	householdRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceResolveHouseholdIDUsesOwnedHousehold(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	householdService := NewHouseholdService(householdRepository, new(stub.UserRepositoryMock), nil, nil)
+
+	userID := uuid.NewString()
+	members := []*entities.HouseholdMember{
+		{HouseholdID: uuid.NewString(), UserID: userID, Role: entities.HouseholdRoleEditor},
+		{HouseholdID: uuid.NewString(), UserID: userID, Role: entities.HouseholdRoleOwner},
+	}
+
+	householdRepository.On("GetMembersByUserID", userID).Return(members, nil)
+
+	householdID, err := householdService.ResolveHouseholdID("", userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, members[1].HouseholdID, householdID)
+	householdRepository.AssertNotCalled(t, "Create", mock.Anything)
+	householdRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceResolveHouseholdIDCreatesDefaultHousehold(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	householdService := NewHouseholdService(householdRepository, new(stub.UserRepositoryMock), nil, nil)
+
+	userID := uuid.NewString()
+
+	householdRepository.On("GetMembersByUserID", userID).Return([]*entities.HouseholdMember{}, nil)
+	householdRepository.On("Create", mock.AnythingOfType("*entities.Household")).Return(nil)
+	householdRepository.On("CreateMember", mock.AnythingOfType("*entities.HouseholdMember")).Return(nil)
+
+	householdID, err := householdService.ResolveHouseholdID("", userID)
+
+	assert.NoError(t, err)
+	household := householdRepository.Calls[1].Arguments.Get(0).(*entities.Household)
+	assert.Equal(t, household.ID, householdID)
+	assert.Equal(t, DefaultHouseholdName, household.Name)
+	householdRepository.AssertExpectations(t)
+}
+
+func TestHouseholdServiceResolveHouseholdIDErrorNotAllowed(t *testing.T) {
+	householdRepository := new(stub.HouseholdRepositoryMock)
+	householdService := NewHouseholdService(householdRepository, new(stub.UserRepositoryMock), nil, nil)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+
+	householdRepository.On("GetMember", householdID, userID).
+		Return(&entities.HouseholdMember{HouseholdID: householdID, UserID: userID, Role: entities.HouseholdRoleViewer}, nil)
+
+	resolvedID, err := householdService.ResolveHouseholdID(householdID, userID)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceNotAllowed)
+	assert.Empty(t, resolvedID)
+	householdRepository.AssertExpectations(t)
+}
@@ -1,31 +1,45 @@
 package services
 
 import (
+	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/jibaru/home-inventory-api/m/logger"
-	"os"
+	"io"
+	"time"
+)
+
+var (
+	ErrItemServiceItemNotFound         = errors.New("item not found")
+	ErrItemServiceAttachmentNotFound   = errors.New("attachment not found")
+	ErrItemServiceDaysShouldBePositive = errors.New("days should be greater than zero")
 )
 
 type ItemService struct {
 	itemRepository        repositories.ItemRepository
 	itemKeywordRepository repositories.ItemKeywordRepository
+	attachmentRepository  repositories.AttachmentRepository
 	assetService          AssetServiceInterface
 	eventBus              services.EventBus
+	householdService      HouseholdServiceInterface
 }
 
 func NewItemService(
 	itemRepository repositories.ItemRepository,
 	itemKeywordRepository repositories.ItemKeywordRepository,
+	attachmentRepository repositories.AttachmentRepository,
 	assetService AssetServiceInterface,
 	eventBus services.EventBus,
+	householdService HouseholdServiceInterface,
 ) *ItemService {
 	return &ItemService{
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	}
 }
 
@@ -34,11 +48,17 @@ func (s *ItemService) Create(
 	name string,
 	description *string,
 	unit string,
+	householdID string,
 	userID string,
 	keywords []string,
-	imageFile *os.File,
+	imageFile *FileUpload,
 ) (*entities.Item, error) {
-	item, err := entities.NewItem(sku, name, description, unit, userID)
+	householdID, err := s.householdService.ResolveHouseholdID(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	item, err := entities.NewItem(sku, name, description, unit, householdID)
 	if err != nil {
 		return nil, err
 	}
@@ -84,18 +104,24 @@ func (s *ItemService) Create(
 		return nil, err
 	}
 
+	s.publishAfterChange(services.ItemCreatedEvent{Item: *item})
+
 	return item, nil
 }
 
 func (s *ItemService) GetAll(
 	search string,
+	householdID string,
 	userID string,
 	pageFilter PageFilter,
 ) ([]struct {
 	Item   *entities.Item
 	Assets []*entities.Asset
 }, error) {
-	queryFilter := s.makeGetAllQueryFilter(search, userID)
+	queryFilter, err := s.makeGetAllQueryFilter(search, householdID, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	items, err := s.itemRepository.GetByQueryFilters(*queryFilter, &repositories.PageFilter{
 		Offset: (pageFilter.Page - 1) * pageFilter.Size,
@@ -138,9 +164,13 @@ func (s *ItemService) GetAll(
 
 func (s *ItemService) CountAll(
 	search string,
+	householdID string,
 	userID string,
 ) (int64, error) {
-	queryFilter := s.makeGetAllQueryFilter(search, userID)
+	queryFilter, err := s.makeGetAllQueryFilter(search, householdID, userID)
+	if err != nil {
+		return 0, err
+	}
 
 	count, err := s.itemRepository.CountByQueryFilters(*queryFilter)
 	if err != nil {
@@ -152,17 +182,23 @@ func (s *ItemService) CountAll(
 
 func (s *ItemService) makeGetAllQueryFilter(
 	search string,
+	householdID string,
 	userID string,
-) *repositories.QueryFilter {
+) (*repositories.QueryFilter, error) {
+	householdIDs, err := s.householdService.GetVisibleHouseholdIDs(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	queryFilter := &repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
 			{
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "items.user_id",
-						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Field:    "items.household_id",
+						Operator: repositories.InComparisonOperator,
+						Value:    householdIDs,
 					},
 				},
 			},
@@ -196,19 +232,25 @@ func (s *ItemService) makeGetAllQueryFilter(
 		)
 	}
 
-	return queryFilter
+	return queryFilter, nil
+}
+
+// Get returns an item of a household of the user.
+func (s *ItemService) Get(itemID string, userID string) (*entities.Item, error) {
+	return s.getItem(itemID, userID, s.householdService.CheckCanView)
 }
 
 func (s *ItemService) Update(
 	id string,
+	userID string,
 	name string,
 	sku string,
 	description *string,
 	unit string,
 	keywords []string,
-	imageFile *os.File,
+	imageFile *FileUpload,
 ) (*entities.Item, error) {
-	item, err := s.itemRepository.GetByID(id)
+	item, err := s.getItem(id, userID, s.householdService.CheckCanEdit)
 	if err != nil {
 		return nil, err
 	}
@@ -254,5 +296,211 @@ func (s *ItemService) Update(
 		}
 	}
 
+	s.publishAfterChange(services.ItemUpdatedEvent{Item: *item})
+
+	return item, nil
+}
+
+// publishAfterChange does not fail the change, as an item is saved with its
+// keywords and files in several steps that can not be undone together.
+func (s *ItemService) publishAfterChange(event services.Event) {
+	err := s.eventBus.Publish(event)
+	if err != nil {
+		logger.LogError(err)
+	}
+}
+
+func (s *ItemService) CreateAttachment(
+	itemID string,
+	userID string,
+	kind string,
+	purchasedAt *time.Time,
+	warrantyExpiresAt *time.Time,
+	file *FileUpload,
+) (*entities.Attachment, *entities.Asset, error) {
+	item, err := s.getItem(itemID, userID, s.householdService.CheckCanEdit)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := entities.NewAttachment(item.ID, kind, purchasedAt, warrantyExpiresAt)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	asset, err := s.assetService.CreateFromFile(file, attachment)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment.ChangeAssetID(asset.ID)
+
+	err = s.attachmentRepository.Create(attachment)
+	if err != nil {
+		err2 := s.eventBus.Publish(services.AttachmentNotCreatedEvent{
+			Attachment: *attachment,
+			Asset:      *asset,
+		})
+		if err2 != nil {
+			logger.LogError(err2)
+			return nil, nil, err2
+		}
+		return nil, nil, err
+	}
+
+	return attachment, asset, nil
+}
+
+func (s *ItemService) GetAttachments(
+	itemID string,
+	userID string,
+) ([]struct {
+	Attachment *entities.Attachment
+	Asset      *entities.Asset
+}, error) {
+	item, err := s.getItem(itemID, userID, s.householdService.CheckCanView)
+	if err != nil {
+		return nil, err
+	}
+
+	attachments, err := s.attachmentRepository.GetByItemID(item.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	var entitySlice []entities.Entity
+	for i := range attachments {
+		entitySlice = append(entitySlice, attachments[i])
+	}
+	assets, err := s.assetService.GetByEntities(entitySlice)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsByID := make(map[string]*entities.Asset)
+	for i := range assets {
+		assetsByID[assets[i].EntityID] = assets[i]
+	}
+
+	output := make([]struct {
+		Attachment *entities.Attachment
+		Asset      *entities.Asset
+	}, 0)
+	for i := range attachments {
+		output = append(output, struct {
+			Attachment *entities.Attachment
+			Asset      *entities.Asset
+		}{
+			Attachment: attachments[i],
+			Asset:      assetsByID[attachments[i].EntityID()],
+		})
+	}
+
+	return output, nil
+}
+
+func (s *ItemService) GetAttachmentContent(
+	itemID string,
+	userID string,
+	attachmentID string,
+) (*entities.Asset, io.ReadSeekCloser, error) {
+	item, err := s.getItem(itemID, userID, s.householdService.CheckCanView)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	attachment, err := s.attachmentRepository.GetByID(attachmentID)
+	if err != nil {
+		return nil, nil, ErrItemServiceAttachmentNotFound
+	}
+
+	if attachment.ItemID != item.ID {
+		return nil, nil, ErrItemServiceAttachmentNotFound
+	}
+
+	asset, content, err := s.assetService.GetContent(attachment.AssetID, userID)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return asset, content, nil
+}
+
+// GetExpiringWarranties returns the attachments in the households of the user whose warranty ends
+// today or within the next days, the ones expiring first come first.
+func (s *ItemService) GetExpiringWarranties(
+	userID string,
+	days int,
+) ([]struct {
+	Attachment *entities.Attachment
+	Item       *entities.Item
+}, error) {
+	if days <= 0 {
+		return nil, ErrItemServiceDaysShouldBePositive
+	}
+
+	householdIDs, err := s.householdService.GetVisibleHouseholdIDs("", userID)
+	if err != nil {
+		return nil, err
+	}
+
+	// The warranties end at the start of a day in UTC, so the ones ending
+	// today are still listed.
+	from := time.Now().UTC().Truncate(24 * time.Hour)
+	to := from.AddDate(0, 0, days)
+
+	attachments, err := s.attachmentRepository.GetByWarrantyExpiringBetween(householdIDs, from, to)
+	if err != nil {
+		return nil, err
+	}
+
+	output := make([]struct {
+		Attachment *entities.Attachment
+		Item       *entities.Item
+	}, 0)
+	itemsByID := make(map[string]*entities.Item)
+	for i := range attachments {
+		item, ok := itemsByID[attachments[i].ItemID]
+		if !ok {
+			item, err = s.itemRepository.GetByID(attachments[i].ItemID)
+			if err != nil {
+				return nil, err
+			}
+
+			itemsByID[item.ID] = item
+		}
+
+		output = append(output, struct {
+			Attachment *entities.Attachment
+			Item       *entities.Item
+		}{
+			Attachment: attachments[i],
+			Item:       item,
+		})
+	}
+
+	return output, nil
+}
+
+// getItem returns the item when check allows the user to act on its
+// household. Items of households the user is not a member of are not found.
+func (s *ItemService) getItem(
+	itemID string,
+	userID string,
+	check func(householdID string, userID string) error,
+) (*entities.Item, error) {
+	item, err := s.itemRepository.GetByID(itemID)
+	if err != nil {
+		return nil, ErrItemServiceItemNotFound
+	}
+
+	err = check(item.HouseholdID, userID)
+	if errors.Is(err, ErrHouseholdServiceHouseholdNotFound) {
+		return nil, ErrItemServiceItemNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
 	return item, nil
 }
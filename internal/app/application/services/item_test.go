@@ -1,6 +1,7 @@
 package services
 
 import (
+	"bytes"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
@@ -9,7 +10,6 @@ import (
 	"github.com/labstack/gommon/random"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
-	"os"
 	"testing"
 	"time"
 )
@@ -17,21 +17,27 @@ import (
 func TestItemServiceCreate(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
 	itemRepository.On("Create", mock.AnythingOfType("*entities.Item")).
 		Return(nil)
 	itemKeywordRepository.On("CreateMany", mock.AnythingOfType("[]*entities.ItemKeyword")).
 		Return(nil)
-	assetService.On("CreateFromFile", mock.AnythingOfType("*os.File"), mock.AnythingOfType("*entities.Item")).
+	eventBus.On("Publish", mock.AnythingOfType("services.ItemCreatedEvent")).
+		Return(nil)
+	assetService.On("CreateFromFile", mock.AnythingOfType("*services.FileUpload"), mock.AnythingOfType("*entities.Item")).
 		Return(&entities.Asset{
 			ID:         uuid.NewString(),
 			Name:       random.String(10, random.Alphanumeric),
@@ -53,13 +59,17 @@ func TestItemServiceCreate(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
+
+	householdID := uuid.NewString()
+	householdService.On("ResolveHouseholdID", householdID, userID).Return(householdID, nil)
 
 	item, err := itemService.Create(
 		sku,
 		name,
 		&description,
 		unit,
+		householdID,
 		userID,
 		keywords,
 		file,
@@ -71,29 +81,34 @@ func TestItemServiceCreate(t *testing.T) {
 	assert.Equal(t, name, item.Name)
 	assert.Equal(t, description, *item.Description)
 	assert.Equal(t, unit, item.Unit)
-	assert.Equal(t, userID, item.UserID)
+	assert.Equal(t, householdID, item.HouseholdID)
 	assert.NotEmpty(t, item.CreatedAt)
 	assert.NotEmpty(t, item.UpdatedAt)
 	itemRepository.AssertExpectations(t)
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceCreateErrorOnAssetService(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
-	assetService.On("CreateFromFile", mock.AnythingOfType("*os.File"), mock.AnythingOfType("*entities.Item")).
+	assetService.On("CreateFromFile", mock.AnythingOfType("*services.FileUpload"), mock.AnythingOfType("*entities.Item")).
 		Return(nil, errors.New("asset service error"))
 
 	sku := random.String(10, random.Alphanumeric)
@@ -105,13 +120,17 @@ func TestItemServiceCreateErrorOnAssetService(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
+
+	householdID := uuid.NewString()
+	householdService.On("ResolveHouseholdID", householdID, userID).Return(householdID, nil)
 
 	item, err := itemService.Create(
 		sku,
 		name,
 		&description,
 		unit,
+		householdID,
 		userID,
 		keywords,
 		file,
@@ -123,22 +142,27 @@ func TestItemServiceCreateErrorOnAssetService(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceCreateErrorOnItemRepository(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
-	assetService.On("CreateFromFile", mock.AnythingOfType("*os.File"), mock.AnythingOfType("*entities.Item")).
+	assetService.On("CreateFromFile", mock.AnythingOfType("*services.FileUpload"), mock.AnythingOfType("*entities.Item")).
 		Return(&entities.Asset{
 			ID:         uuid.NewString(),
 			Name:       random.String(10, random.Alphanumeric),
@@ -164,13 +188,17 @@ func TestItemServiceCreateErrorOnItemRepository(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
+
+	householdID := uuid.NewString()
+	householdService.On("ResolveHouseholdID", householdID, userID).Return(householdID, nil)
 
 	item, err := itemService.Create(
 		sku,
 		name,
 		&description,
 		unit,
+		householdID,
 		userID,
 		keywords,
 		file,
@@ -182,22 +210,27 @@ func TestItemServiceCreateErrorOnItemRepository(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceCreateErrorOnItemKeywordRepository(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
-	assetService.On("CreateFromFile", mock.AnythingOfType("*os.File"), mock.AnythingOfType("*entities.Item")).
+	assetService.On("CreateFromFile", mock.AnythingOfType("*services.FileUpload"), mock.AnythingOfType("*entities.Item")).
 		Return(&entities.Asset{
 			ID:         uuid.NewString(),
 			Name:       random.String(10, random.Alphanumeric),
@@ -225,13 +258,17 @@ func TestItemServiceCreateErrorOnItemKeywordRepository(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
+
+	householdID := uuid.NewString()
+	householdService.On("ResolveHouseholdID", householdID, userID).Return(householdID, nil)
 
 	item, err := itemService.Create(
 		sku,
 		name,
 		&description,
 		unit,
+		householdID,
 		userID,
 		keywords,
 		file,
@@ -243,21 +280,28 @@ func TestItemServiceCreateErrorOnItemKeywordRepository(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceGetAll(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
+	userID := uuid.NewString()
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{uuid.NewString()}, nil)
 	itemRepository.On(
 		"GetByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
@@ -270,7 +314,7 @@ func TestItemServiceGetAll(t *testing.T) {
 				Name:        random.String(10, random.Alphanumeric),
 				Description: nil,
 				Unit:        "unit",
-				UserID:      uuid.NewString(),
+				HouseholdID: uuid.NewString(),
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
 			},
@@ -290,7 +334,7 @@ func TestItemServiceGetAll(t *testing.T) {
 			},
 		}, nil)
 
-	items, err := itemService.GetAll("search", uuid.NewString(), PageFilter{
+	items, err := itemService.GetAll("search", "", userID, PageFilter{
 		Page: 1,
 		Size: 1,
 	})
@@ -302,21 +346,28 @@ func TestItemServiceGetAll(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceGetAllErrorOnItemRepository(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
+	userID := uuid.NewString()
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{uuid.NewString()}, nil)
 	itemRepository.On(
 		"GetByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
@@ -324,7 +375,7 @@ func TestItemServiceGetAllErrorOnItemRepository(t *testing.T) {
 	).
 		Return(nil, errors.New("item repository error"))
 
-	items, err := itemService.GetAll("search", uuid.NewString(), PageFilter{
+	items, err := itemService.GetAll("search", "", userID, PageFilter{
 		Page: 1,
 		Size: 1,
 	})
@@ -335,21 +386,28 @@ func TestItemServiceGetAllErrorOnItemRepository(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceGetAllErrorOnAssetService(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
+	userID := uuid.NewString()
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{uuid.NewString()}, nil)
 	itemRepository.On(
 		"GetByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
@@ -362,7 +420,7 @@ func TestItemServiceGetAllErrorOnAssetService(t *testing.T) {
 				Name:        random.String(10, random.Alphanumeric),
 				Description: nil,
 				Unit:        "unit",
-				UserID:      uuid.NewString(),
+				HouseholdID: uuid.NewString(),
 				CreatedAt:   time.Now(),
 				UpdatedAt:   time.Now(),
 			},
@@ -370,7 +428,7 @@ func TestItemServiceGetAllErrorOnAssetService(t *testing.T) {
 	assetService.On("GetByEntities", mock.AnythingOfType("[]entities.Entity")).
 		Return(nil, errors.New("asset service error"))
 
-	items, err := itemService.GetAll("search", uuid.NewString(), PageFilter{
+	items, err := itemService.GetAll("search", "", userID, PageFilter{
 		Page: 1,
 		Size: 1,
 	})
@@ -381,28 +439,35 @@ func TestItemServiceGetAllErrorOnAssetService(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceCountAll(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
+	userID := uuid.NewString()
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{uuid.NewString()}, nil)
 	itemRepository.On(
 		"CountByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
 	).
 		Return(int64(1), nil)
 
-	count, err := itemService.CountAll("search", uuid.NewString())
+	count, err := itemService.CountAll("search", "", userID)
 
 	assert.NoError(t, err)
 	assert.Equal(t, int64(1), count)
@@ -410,28 +475,35 @@ func TestItemServiceCountAll(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceCountAllErrorOnItemRepository(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
+	userID := uuid.NewString()
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{uuid.NewString()}, nil)
 	itemRepository.On(
 		"CountByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
 	).
 		Return(int64(0), errors.New("item repository error"))
 
-	count, err := itemService.CountAll("search", uuid.NewString())
+	count, err := itemService.CountAll("search", "", userID)
 
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), count)
@@ -439,22 +511,91 @@ func TestItemServiceCountAllErrorOnItemRepository(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestItemServiceGet(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanView", item.HouseholdID, userID).Return(nil)
+
+	result, err := itemService.Get(item.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, item, result)
+	itemRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestItemServiceGetErrorItemNotFound(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanView", item.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	result, err := itemService.Get(item.ID, userID)
+
+	assert.ErrorIs(t, err, ErrItemServiceItemNotFound)
+	assert.Nil(t, result)
+	itemRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceUpdate(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
 	id := uuid.NewString()
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	name := random.String(10, random.Alphanumeric)
 	sku := random.String(10, random.Alphanumeric)
 	description := random.String(100, random.Alphanumeric)
@@ -463,7 +604,7 @@ func TestItemServiceUpdate(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
 
 	itemRepository.On("GetByID", id).
 		Return(&entities.Item{
@@ -472,16 +613,19 @@ func TestItemServiceUpdate(t *testing.T) {
 			Name:        random.String(10, random.Alphanumeric),
 			Description: nil,
 			Unit:        "unit",
-			UserID:      uuid.NewString(),
+			HouseholdID: householdID,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}, nil)
+	householdService.On("CheckCanEdit", householdID, userID).Return(nil)
 	itemRepository.On("Update", mock.AnythingOfType("*entities.Item")).
 		Return(nil)
 	itemKeywordRepository.On("DeleteByItemID", id).
 		Return(nil)
 	itemKeywordRepository.On("CreateMany", mock.AnythingOfType("[]*entities.ItemKeyword")).
 		Return(nil)
+	eventBus.On("Publish", mock.AnythingOfType("services.ItemUpdatedEvent")).
+		Return(nil)
 	assetService.On("UpdateByEntity", mock.AnythingOfType("*entities.Item"), file).
 		Return(&entities.Asset{
 			ID:         uuid.NewString(),
@@ -497,6 +641,7 @@ func TestItemServiceUpdate(t *testing.T) {
 
 	item, err := itemService.Update(
 		id,
+		userID,
 		name,
 		sku,
 		&description,
@@ -516,22 +661,29 @@ func TestItemServiceUpdate(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceUpdateErrorOnItemRepository(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
 	id := uuid.NewString()
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	name := random.String(10, random.Alphanumeric)
 	sku := random.String(10, random.Alphanumeric)
 	description := random.String(100, random.Alphanumeric)
@@ -540,7 +692,7 @@ func TestItemServiceUpdateErrorOnItemRepository(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
 
 	itemRepository.On("GetByID", id).
 		Return(&entities.Item{
@@ -549,15 +701,17 @@ func TestItemServiceUpdateErrorOnItemRepository(t *testing.T) {
 			Name:        random.String(10, random.Alphanumeric),
 			Description: nil,
 			Unit:        "unit",
-			UserID:      uuid.NewString(),
+			HouseholdID: householdID,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}, nil)
+	householdService.On("CheckCanEdit", householdID, userID).Return(nil)
 	itemRepository.On("Update", mock.AnythingOfType("*entities.Item")).
 		Return(errors.New("item repository error"))
 
 	item, err := itemService.Update(
 		id,
+		userID,
 		name,
 		sku,
 		&description,
@@ -572,22 +726,29 @@ func TestItemServiceUpdateErrorOnItemRepository(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceUpdateErrorOnItemKeywordRepository(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
 	id := uuid.NewString()
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	name := random.String(10, random.Alphanumeric)
 	sku := random.String(10, random.Alphanumeric)
 	description := random.String(100, random.Alphanumeric)
@@ -596,7 +757,7 @@ func TestItemServiceUpdateErrorOnItemKeywordRepository(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
 
 	itemRepository.On("GetByID", id).
 		Return(&entities.Item{
@@ -605,10 +766,11 @@ func TestItemServiceUpdateErrorOnItemKeywordRepository(t *testing.T) {
 			Name:        random.String(10, random.Alphanumeric),
 			Description: nil,
 			Unit:        "unit",
-			UserID:      uuid.NewString(),
+			HouseholdID: householdID,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}, nil)
+	householdService.On("CheckCanEdit", householdID, userID).Return(nil)
 	itemRepository.On("Update", mock.AnythingOfType("*entities.Item")).
 		Return(nil)
 	itemKeywordRepository.On("DeleteByItemID", id).
@@ -616,6 +778,7 @@ func TestItemServiceUpdateErrorOnItemKeywordRepository(t *testing.T) {
 
 	item, err := itemService.Update(
 		id,
+		userID,
 		name,
 		sku,
 		&description,
@@ -630,22 +793,29 @@ func TestItemServiceUpdateErrorOnItemKeywordRepository(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestItemServiceUpdateErrorOnAssetService(t *testing.T) {
 	itemRepository := &stub.ItemRepositoryMock{}
 	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
 	assetService := &AssetServiceMock{}
 	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
 
 	itemService := NewItemService(
 		itemRepository,
 		itemKeywordRepository,
+		attachmentRepository,
 		assetService,
 		eventBus,
+		householdService,
 	)
 
 	id := uuid.NewString()
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	name := random.String(10, random.Alphanumeric)
 	sku := random.String(10, random.Alphanumeric)
 	description := random.String(100, random.Alphanumeric)
@@ -654,7 +824,7 @@ func TestItemServiceUpdateErrorOnAssetService(t *testing.T) {
 		random.String(10, random.Alphanumeric),
 		random.String(10, random.Alphanumeric),
 	}
-	file := &os.File{}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
 
 	itemRepository.On("GetByID", id).
 		Return(&entities.Item{
@@ -663,10 +833,11 @@ func TestItemServiceUpdateErrorOnAssetService(t *testing.T) {
 			Name:        random.String(10, random.Alphanumeric),
 			Description: nil,
 			Unit:        "unit",
-			UserID:      uuid.NewString(),
+			HouseholdID: householdID,
 			CreatedAt:   time.Now(),
 			UpdatedAt:   time.Now(),
 		}, nil)
+	householdService.On("CheckCanEdit", householdID, userID).Return(nil)
 	itemRepository.On("Update", mock.AnythingOfType("*entities.Item")).
 		Return(nil)
 	itemKeywordRepository.On("DeleteByItemID", id).
@@ -678,6 +849,7 @@ func TestItemServiceUpdateErrorOnAssetService(t *testing.T) {
 
 	item, err := itemService.Update(
 		id,
+		userID,
 		name,
 		sku,
 		&description,
@@ -692,4 +864,412 @@ func TestItemServiceUpdateErrorOnAssetService(t *testing.T) {
 	itemKeywordRepository.AssertExpectations(t)
 	assetService.AssertExpectations(t)
 	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestItemServiceCreateAttachment(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	purchasedAt := time.Now().AddDate(0, -1, 0)
+	warrantyExpiresAt := time.Now().AddDate(1, 0, 0)
+	file := &FileUpload{Name: "warranty.pdf", Content: bytes.NewReader(nil)}
+	asset := &entities.Asset{ID: uuid.NewString()}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanEdit", item.HouseholdID, userID).Return(nil)
+	assetService.On("CreateFromFile", file, mock.AnythingOfType("*entities.Attachment")).Return(asset, nil)
+	attachmentRepository.On("Create", mock.AnythingOfType("*entities.Attachment")).Return(nil)
+
+	attachment, gotAsset, err := itemService.CreateAttachment(
+		item.ID,
+		userID,
+		entities.AttachmentKindWarranty,
+		&purchasedAt,
+		&warrantyExpiresAt,
+		file,
+	)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, attachment)
+	assert.Equal(t, item.ID, attachment.ItemID)
+	assert.Equal(t, asset.ID, attachment.AssetID)
+	assert.Equal(t, entities.AttachmentKindWarranty, attachment.Kind)
+	assert.Equal(t, asset, gotAsset)
+	itemRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+}
+
+func TestItemServiceCreateAttachmentErrorItemOfAnotherHousehold(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	file := &FileUpload{Name: "receipt.pdf", Content: bytes.NewReader(nil)}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanEdit", item.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	attachment, asset, err := itemService.CreateAttachment(
+		item.ID,
+		userID,
+		entities.AttachmentKindReceipt,
+		nil,
+		nil,
+		file,
+	)
+
+	assert.ErrorIs(t, err, ErrItemServiceItemNotFound)
+	assert.Nil(t, attachment)
+	assert.Nil(t, asset)
+	itemRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	assetService.AssertNotCalled(t, "CreateFromFile")
+}
+
+func TestItemServiceCreateAttachmentErrorInvalidKind(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	file := &FileUpload{Name: "invoice.pdf", Content: bytes.NewReader(nil)}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanEdit", item.HouseholdID, userID).Return(nil)
+
+	attachment, asset, err := itemService.CreateAttachment(item.ID, userID, "invoice", nil, nil, file)
+
+	assert.ErrorIs(t, err, entities.ErrAttachmentKindIsInvalid)
+	assert.Nil(t, attachment)
+	assert.Nil(t, asset)
+	itemRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	assetService.AssertNotCalled(t, "CreateFromFile")
+}
+
+func TestItemServiceCreateAttachmentErrorRollbackAsset(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	file := &FileUpload{Name: "manual.pdf", Content: bytes.NewReader(nil)}
+	asset := &entities.Asset{ID: uuid.NewString()}
+	repositoryErr := errors.New("repository error")
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanEdit", item.HouseholdID, userID).Return(nil)
+	assetService.On("CreateFromFile", file, mock.AnythingOfType("*entities.Attachment")).Return(asset, nil)
+	attachmentRepository.On("Create", mock.AnythingOfType("*entities.Attachment")).Return(repositoryErr)
+	eventBus.On("Publish", mock.AnythingOfType("services.AttachmentNotCreatedEvent")).Return(nil)
+
+	attachment, gotAsset, err := itemService.CreateAttachment(
+		item.ID,
+		userID,
+		entities.AttachmentKindManual,
+		nil,
+		nil,
+		file,
+	)
+
+	assert.ErrorIs(t, err, repositoryErr)
+	assert.Nil(t, attachment)
+	assert.Nil(t, gotAsset)
+	itemRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestItemServiceGetAttachments(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	attachments := []*entities.Attachment{
+		{ID: uuid.NewString(), ItemID: item.ID, Kind: entities.AttachmentKindReceipt},
+		{ID: uuid.NewString(), ItemID: item.ID, Kind: entities.AttachmentKindManual},
+	}
+	assets := []*entities.Asset{
+		{ID: uuid.NewString(), EntityID: attachments[1].ID, EntityName: "attachment"},
+		{ID: uuid.NewString(), EntityID: attachments[0].ID, EntityName: "attachment"},
+	}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanView", item.HouseholdID, userID).Return(nil)
+	attachmentRepository.On("GetByItemID", item.ID).Return(attachments, nil)
+	assetService.On("GetByEntities", mock.AnythingOfType("[]entities.Entity")).Return(assets, nil)
+
+	output, err := itemService.GetAttachments(item.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, output, 2)
+	assert.Equal(t, attachments[0], output[0].Attachment)
+	assert.Equal(t, assets[1], output[0].Asset)
+	assert.Equal(t, attachments[1], output[1].Attachment)
+	assert.Equal(t, assets[0], output[1].Asset)
+	itemRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestItemServiceGetAttachmentContent(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	attachment := &entities.Attachment{ID: uuid.NewString(), ItemID: item.ID, AssetID: uuid.NewString()}
+	asset := &entities.Asset{ID: attachment.AssetID}
+	content := readSeekNopCloser{bytes.NewReader([]byte("receipt"))}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanView", item.HouseholdID, userID).Return(nil)
+	attachmentRepository.On("GetByID", attachment.ID).Return(attachment, nil)
+	assetService.On("GetContent", attachment.AssetID, userID).Return(asset, content, nil)
+
+	gotAsset, gotContent, err := itemService.GetAttachmentContent(item.ID, userID, attachment.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, asset, gotAsset)
+	assert.Equal(t, content, gotContent)
+	itemRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestItemServiceGetAttachmentContentErrorAttachmentOfAnotherItem(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	attachment := &entities.Attachment{ID: uuid.NewString(), ItemID: uuid.NewString(), AssetID: uuid.NewString()}
+
+	itemRepository.On("GetByID", item.ID).Return(item, nil)
+	householdService.On("CheckCanView", item.HouseholdID, userID).Return(nil)
+	attachmentRepository.On("GetByID", attachment.ID).Return(attachment, nil)
+
+	asset, content, err := itemService.GetAttachmentContent(item.ID, userID, attachment.ID)
+
+	assert.ErrorIs(t, err, ErrItemServiceAttachmentNotFound)
+	assert.Nil(t, asset)
+	assert.Nil(t, content)
+	itemRepository.AssertExpectations(t)
+	attachmentRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	assetService.AssertNotCalled(t, "GetContent")
+}
+
+func TestItemServiceGetExpiringWarranties(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	householdIDs := []string{uuid.NewString()}
+	item := &entities.Item{ID: uuid.NewString(), HouseholdID: householdIDs[0]}
+	attachments := []*entities.Attachment{
+		{ID: uuid.NewString(), ItemID: item.ID, Kind: entities.AttachmentKindWarranty},
+		{ID: uuid.NewString(), ItemID: item.ID, Kind: entities.AttachmentKindReceipt},
+	}
+
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return(householdIDs, nil)
+	attachmentRepository.On(
+		"GetByWarrantyExpiringBetween",
+		householdIDs,
+		mock.AnythingOfType("time.Time"),
+		mock.AnythingOfType("time.Time"),
+	).Return(attachments, nil)
+	itemRepository.On("GetByID", item.ID).Return(item, nil).Once()
+
+	output, err := itemService.GetExpiringWarranties(userID, 30)
+
+	assert.NoError(t, err)
+	assert.Len(t, output, 2)
+	assert.Equal(t, attachments[0], output[0].Attachment)
+	assert.Equal(t, item, output[0].Item)
+	assert.Equal(t, attachments[1], output[1].Attachment)
+	assert.Equal(t, item, output[1].Item)
+	attachmentRepository.AssertExpectations(t)
+	itemRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestItemServiceGetExpiringWarrantiesFromStartOfDay(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	userID := uuid.NewString()
+	householdIDs := []string{uuid.NewString()}
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return(householdIDs, nil)
+	attachmentRepository.On(
+		"GetByWarrantyExpiringBetween",
+		householdIDs,
+		mock.MatchedBy(func(from time.Time) bool {
+			// A warranty ending today at 00:00 UTC is inside the range.
+			return from.Equal(today)
+		}),
+		mock.MatchedBy(func(to time.Time) bool {
+			return to.Equal(today.AddDate(0, 0, 7))
+		}),
+	).Return([]*entities.Attachment{}, nil)
+
+	output, err := itemService.GetExpiringWarranties(userID, 7)
+
+	assert.NoError(t, err)
+	assert.Empty(t, output)
+	attachmentRepository.AssertExpectations(t)
+}
+
+func TestItemServiceGetExpiringWarrantiesErrorDaysShouldBePositive(t *testing.T) {
+	itemRepository := &stub.ItemRepositoryMock{}
+	itemKeywordRepository := &stub.ItemKeywordRepositoryMock{}
+	attachmentRepository := &stub.AttachmentRepositoryMock{}
+	assetService := &AssetServiceMock{}
+	eventBus := new(stub2.EventBusMock)
+	householdService := new(HouseholdServiceMock)
+
+	itemService := NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+		householdService,
+	)
+
+	output, err := itemService.GetExpiringWarranties(uuid.NewString(), 0)
+
+	assert.ErrorIs(t, err, ErrItemServiceDaysShouldBePositive)
+	assert.Nil(t, output)
+	attachmentRepository.AssertNotCalled(t, "GetByWarrantyExpiringBetween")
 }
@@ -0,0 +1,191 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+var (
+	ErrLoginThrottleServiceTooManyAttempts = errors.New("too many failed login attempts, try again later")
+)
+
+// LoginThrottleServiceInterface is used by the AuthService to slow down and
+// lock password guessing by account and by IP address.
+type LoginThrottleServiceInterface interface {
+	Check(email string, ip string) error
+	RegisterFailure(email string, ip string, user *entities.User) error
+	RegisterSuccess(email string) error
+}
+
+type LoginThrottleService struct {
+	loginThrottleRepository repositories.LoginThrottleRepository
+	eventBus                services.EventBus
+	mailSender              services.MailSender
+	auditService            AuditServiceInterface
+	accountPolicy           entities.LoginThrottlePolicy
+	ipPolicy                entities.LoginThrottlePolicy
+}
+
+func NewLoginThrottleService(
+	loginThrottleRepository repositories.LoginThrottleRepository,
+	eventBus services.EventBus,
+	mailSender services.MailSender,
+	auditService AuditServiceInterface,
+	accountPolicy entities.LoginThrottlePolicy,
+	ipPolicy entities.LoginThrottlePolicy,
+) *LoginThrottleService {
+	return &LoginThrottleService{
+		loginThrottleRepository,
+		eventBus,
+		mailSender,
+		auditService,
+		accountPolicy,
+		ipPolicy,
+	}
+}
+
+// Check fails while the account or the IP address is blocked, without looking
+// at the password. Failures old enough to be forgotten are removed here,
+// before a new failure is counted.
+func (s *LoginThrottleService) Check(email string, ip string) error {
+	throttles, err := s.loginThrottleRepository.GetByIdentifiers([]string{
+		entities.LoginThrottleIdentifierForEmail(email),
+		entities.LoginThrottleIdentifierForIP(ip),
+	})
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	for _, throttle := range throttles {
+		if throttle.IsBlocked(now) {
+			return ErrLoginThrottleServiceTooManyAttempts
+		}
+
+		if throttle.IsStale(now, s.policyOf(throttle)) {
+			err = s.loginThrottleRepository.DeleteByIdentifier(throttle.Identifier)
+			if err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// RegisterFailure counts a failed login for the account and the IP address.
+// The user is nil when the email has no account, it is only needed to audit
+// the failure and to mail the owner when the account gets locked.
+func (s *LoginThrottleService) RegisterFailure(email string, ip string, user *entities.User) error {
+	locked, err := s.registerFailure(entities.LoginThrottleIdentifierForEmail(email), s.accountPolicy)
+	if err != nil {
+		return err
+	}
+
+	if user != nil {
+		s.recordAudit(user, ip, entities.AuditActionFailedLogIn)
+	}
+
+	if locked && user != nil {
+		s.recordAudit(user, ip, entities.AuditActionLockOut)
+
+		err = s.eventBus.Publish(services.LoginLockedEvent{
+			User: *user,
+		})
+		if err != nil {
+			return err
+		}
+	}
+
+	_, err = s.registerFailure(entities.LoginThrottleIdentifierForIP(ip), s.ipPolicy)
+
+	return err
+}
+
+// RegisterSuccess forgets the failures of the account. The failures of the
+// IP address are kept, otherwise one valid account would be enough to keep
+// guessing the passwords of others.
+func (s *LoginThrottleService) RegisterSuccess(email string) error {
+	return s.loginThrottleRepository.DeleteByIdentifier(entities.LoginThrottleIdentifierForEmail(email))
+}
+
+func (s *LoginThrottleService) SendLockedNotification(user *entities.User) error {
+	body := fmt.Sprintf(
+		"We blocked the sign in to your account for %s after %d failed login attempts.\n\n"+
+			"If it was not you, someone may be trying to guess your password, consider resetting it.",
+		s.accountPolicy.LockoutDuration.String(),
+		s.accountPolicy.MaxFailedAttempts,
+	)
+
+	return s.mailSender.SendMail(user.Email, "Your account was temporarily locked", body)
+}
+
+func (s *LoginThrottleService) registerFailure(identifier string, policy entities.LoginThrottlePolicy) (bool, error) {
+	now := time.Now()
+
+	throttle, err := s.loginThrottleRepository.RegisterFailure(identifier, now)
+	if err != nil {
+		return false, err
+	}
+
+	locked := throttle.Block(now, policy)
+	if !throttle.IsBlocked(now) {
+		return false, nil
+	}
+
+	err = s.loginThrottleRepository.Update(throttle)
+	if err != nil {
+		return false, err
+	}
+
+	return locked, nil
+}
+
+// recordAudit does not fail the login, a missing entry is only logged.
+func (s *LoginThrottleService) recordAudit(user *entities.User, ip string, action string) {
+	err := s.auditService.Record(
+		AuditActor{UserID: user.ID, IPAddress: ip},
+		action,
+		entities.AuditEntityTypeUser,
+		user.ID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+}
+
+func (s *LoginThrottleService) policyOf(throttle *entities.LoginThrottle) entities.LoginThrottlePolicy {
+	if throttle.IsForIP() {
+		return s.ipPolicy
+	}
+
+	return s.accountPolicy
+}
+
+type LoginThrottleServiceMock struct {
+	mock.Mock
+}
+
+func (s *LoginThrottleServiceMock) Check(email string, ip string) error {
+	args := s.Called(email, ip)
+	return args.Error(0)
+}
+
+func (s *LoginThrottleServiceMock) RegisterFailure(email string, ip string, user *entities.User) error {
+	args := s.Called(email, ip, user)
+	return args.Error(0)
+}
+
+func (s *LoginThrottleServiceMock) RegisterSuccess(email string) error {
+	args := s.Called(email)
+	return args.Error(0)
+}
@@ -0,0 +1,261 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	domainstub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+var (
+	testLoginAccountPolicy = entities.LoginThrottlePolicy{MaxFailedAttempts: 10, LockoutDuration: 15 * time.Minute}
+	testLoginIPPolicy      = entities.LoginThrottlePolicy{MaxFailedAttempts: 50, LockoutDuration: 15 * time.Minute}
+)
+
+func TestLoginThrottleServiceCheck(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	email := "test@example.com"
+	ip := "127.0.0.1"
+	recent := &entities.LoginThrottle{
+		Identifier:     entities.LoginThrottleIdentifierForEmail(email),
+		FailedAttempts: 2,
+		LastFailedAt:   time.Now().Add(-time.Minute),
+	}
+
+	loginThrottleRepository.On("GetByIdentifiers", []string{
+		entities.LoginThrottleIdentifierForEmail(email),
+		entities.LoginThrottleIdentifierForIP(ip),
+	}).Return([]*entities.LoginThrottle{recent}, nil)
+
+	err := loginThrottleService.Check(email, ip)
+
+	assert.NoError(t, err)
+	loginThrottleRepository.AssertExpectations(t)
+	loginThrottleRepository.AssertNotCalled(t, "DeleteByIdentifier", mock.Anything)
+}
+
+func TestLoginThrottleServiceCheckDeletesStaleThrottles(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	email := "test@example.com"
+	ip := "127.0.0.1"
+	stale := &entities.LoginThrottle{
+		Identifier:     entities.LoginThrottleIdentifierForIP(ip),
+		FailedAttempts: 20,
+		LastFailedAt:   time.Now().Add(-time.Hour),
+	}
+
+	loginThrottleRepository.On("GetByIdentifiers", mock.Anything).Return([]*entities.LoginThrottle{stale}, nil)
+	loginThrottleRepository.On("DeleteByIdentifier", stale.Identifier).Return(nil)
+
+	err := loginThrottleService.Check(email, ip)
+
+	assert.NoError(t, err)
+	loginThrottleRepository.AssertExpectations(t)
+}
+
+func TestLoginThrottleServiceCheckErrorBlocked(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	ip := "127.0.0.1"
+	blockedUntil := time.Now().Add(time.Minute)
+	blocked := &entities.LoginThrottle{
+		Identifier:     entities.LoginThrottleIdentifierForIP(ip),
+		FailedAttempts: 50,
+		LastFailedAt:   time.Now(),
+		BlockedUntil:   &blockedUntil,
+	}
+
+	loginThrottleRepository.On("GetByIdentifiers", mock.Anything).Return([]*entities.LoginThrottle{blocked}, nil)
+
+	err := loginThrottleService.Check("test@example.com", ip)
+
+	assert.ErrorIs(t, err, ErrLoginThrottleServiceTooManyAttempts)
+	loginThrottleRepository.AssertExpectations(t)
+}
+
+func TestLoginThrottleServiceRegisterFailure(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	email := "test@example.com"
+	ip := "127.0.0.1"
+	user := &entities.User{ID: uuid.NewString(), Email: email}
+	accountThrottle := &entities.LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     entities.LoginThrottleIdentifierForEmail(email),
+		FailedAttempts: entities.LoginThrottleFreeAttempts + 1,
+	}
+	ipThrottle := &entities.LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     entities.LoginThrottleIdentifierForIP(ip),
+		FailedAttempts: 1,
+	}
+
+	loginThrottleRepository.On("RegisterFailure", accountThrottle.Identifier, mock.AnythingOfType("time.Time")).
+		Return(accountThrottle, nil)
+	loginThrottleRepository.On("Update", accountThrottle).Return(nil)
+	loginThrottleRepository.On("RegisterFailure", ipThrottle.Identifier, mock.AnythingOfType("time.Time")).
+		Return(ipThrottle, nil)
+	auditService.On("Record", AuditActor{UserID: user.ID, IPAddress: ip}, entities.AuditActionFailedLogIn, entities.AuditEntityTypeUser, user.ID, "", nil, nil).
+		Return(nil)
+
+	err := loginThrottleService.RegisterFailure(email, ip, user)
+
+	assert.NoError(t, err)
+	auditService.AssertExpectations(t)
+	auditService.AssertNotCalled(t, "Record", mock.Anything, entities.AuditActionLockOut, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	assert.NotNil(t, accountThrottle.BlockedUntil)
+	assert.Nil(t, ipThrottle.BlockedUntil)
+	loginThrottleRepository.AssertExpectations(t)
+	loginThrottleRepository.AssertNotCalled(t, "Update", ipThrottle)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestLoginThrottleServiceRegisterFailureLocksAccount(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	ip := "127.0.0.1"
+	accountThrottle := &entities.LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     entities.LoginThrottleIdentifierForEmail(user.Email),
+		FailedAttempts: testLoginAccountPolicy.MaxFailedAttempts,
+	}
+	ipThrottle := &entities.LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     entities.LoginThrottleIdentifierForIP(ip),
+		FailedAttempts: 1,
+	}
+
+	loginThrottleRepository.On("RegisterFailure", accountThrottle.Identifier, mock.AnythingOfType("time.Time")).
+		Return(accountThrottle, nil)
+	loginThrottleRepository.On("Update", accountThrottle).Return(nil)
+	loginThrottleRepository.On("RegisterFailure", ipThrottle.Identifier, mock.AnythingOfType("time.Time")).
+		Return(ipThrottle, nil)
+	eventBus.On("Publish", mock.AnythingOfType("services.LoginLockedEvent")).Return(nil)
+	auditService.On("Record", AuditActor{UserID: user.ID, IPAddress: ip}, entities.AuditActionFailedLogIn, entities.AuditEntityTypeUser, user.ID, "", nil, nil).
+		Return(nil)
+	auditService.On("Record", AuditActor{UserID: user.ID, IPAddress: ip}, entities.AuditActionLockOut, entities.AuditEntityTypeUser, user.ID, "", nil, nil).
+		Return(errors.New("audit error"))
+
+	err := loginThrottleService.RegisterFailure(user.Email, ip, user)
+
+	assert.NoError(t, err)
+	auditService.AssertExpectations(t)
+	assert.WithinDuration(t, time.Now().Add(testLoginAccountPolicy.LockoutDuration), *accountThrottle.BlockedUntil, time.Second)
+	loginThrottleRepository.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+}
+
+func TestLoginThrottleServiceRegisterFailureLocksUnknownEmailWithoutNotification(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	email := "unknown@example.com"
+	ip := "127.0.0.1"
+	accountThrottle := &entities.LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     entities.LoginThrottleIdentifierForEmail(email),
+		FailedAttempts: testLoginAccountPolicy.MaxFailedAttempts,
+	}
+	ipThrottle := &entities.LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     entities.LoginThrottleIdentifierForIP(ip),
+		FailedAttempts: 1,
+	}
+
+	loginThrottleRepository.On("RegisterFailure", accountThrottle.Identifier, mock.AnythingOfType("time.Time")).
+		Return(accountThrottle, nil)
+	loginThrottleRepository.On("Update", accountThrottle).Return(nil)
+	loginThrottleRepository.On("RegisterFailure", ipThrottle.Identifier, mock.AnythingOfType("time.Time")).
+		Return(ipThrottle, nil)
+
+	err := loginThrottleService.RegisterFailure(email, ip, nil)
+
+	assert.NoError(t, err)
+	assert.True(t, accountThrottle.IsBlocked(time.Now()))
+	loginThrottleRepository.AssertExpectations(t)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything)
+	auditService.AssertNotCalled(t, "Record", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestLoginThrottleServiceRegisterFailureError(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	loginThrottleRepository.On("RegisterFailure", mock.Anything, mock.AnythingOfType("time.Time")).
+		Return(nil, errors.New("repository error"))
+
+	err := loginThrottleService.RegisterFailure("test@example.com", "127.0.0.1", nil)
+
+	assert.Error(t, err)
+	loginThrottleRepository.AssertNumberOfCalls(t, "RegisterFailure", 1)
+}
+
+func TestLoginThrottleServiceRegisterSuccess(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	email := "Test@Example.com"
+
+	loginThrottleRepository.On("DeleteByIdentifier", "email:test@example.com").Return(nil)
+
+	err := loginThrottleService.RegisterSuccess(email)
+
+	assert.NoError(t, err)
+	loginThrottleRepository.AssertExpectations(t)
+}
+
+func TestLoginThrottleServiceSendLockedNotification(t *testing.T) {
+	loginThrottleRepository := new(stub.LoginThrottleRepositoryMock)
+	eventBus := new(domainstub.EventBusMock)
+	mailSender := new(domainstub.MailSenderMock)
+	auditService := new(AuditServiceMock)
+	loginThrottleService := NewLoginThrottleService(loginThrottleRepository, eventBus, mailSender, auditService, testLoginAccountPolicy, testLoginIPPolicy)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	mailSender.On("SendMail", user.Email, "Your account was temporarily locked", mock.MatchedBy(func(body string) bool {
+		return assert.Contains(t, body, "15m0s") && assert.Contains(t, body, "10 failed login attempts")
+	})).Return(nil)
+
+	err := loginThrottleService.SendLockedNotification(user)
+
+	assert.NoError(t, err)
+	mailSender.AssertExpectations(t)
+}
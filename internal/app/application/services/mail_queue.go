@@ -0,0 +1,149 @@
+package services
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"time"
+)
+
+var (
+	ErrMailQueueServiceMessageNotFound = errors.New("mail message not found")
+	ErrMailQueueServiceStatusIsInvalid = errors.New("status should be pending, sent or dead")
+)
+
+// MailQueueService sends the mails queued by the mailqueue.MailSender with the
+// mail sender that really sends them, decrypting their bodies with the same
+// cipher.
+type MailQueueService struct {
+	mailMessageRepository repositories.MailMessageRepository
+	mailSender            services.MailSender
+	cipher                services.Cipher
+	retryPolicy           entities.RetryPolicy
+	retention             time.Duration
+}
+
+func NewMailQueueService(
+	mailMessageRepository repositories.MailMessageRepository,
+	mailSender services.MailSender,
+	cipher services.Cipher,
+	retryPolicy entities.RetryPolicy,
+	retention time.Duration,
+) *MailQueueService {
+	return &MailQueueService{
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		retryPolicy,
+		retention,
+	}
+}
+
+// SendPending sends up to limit available messages and returns how many of
+// them were sent. A failed message waits for the backoff of the retry policy
+// and is dead once it runs out of attempts.
+func (s *MailQueueService) SendPending(limit int) (int, error) {
+	return ProcessAvailable(
+		s.mailMessageRepository,
+		repositories.ErrMailMessageRepositoryMessageAlreadyClaimed,
+		limit,
+		s.send,
+	)
+}
+
+// send sends the message and marks it as sent or failed.
+func (s *MailQueueService) send(message *entities.MailMessage) (bool, error) {
+	err := s.sendDecrypted(message)
+	if err != nil {
+		message.MarkFailed(err, time.Now(), s.retryPolicy)
+		if !message.IsPending() {
+			logger.LogError(fmt.Errorf("mail message %s is dead after %d attempts", message.ID, message.Attempts))
+		}
+		return false, nil
+	}
+
+	message.MarkSent(time.Now())
+	return true, nil
+}
+
+// sendDecrypted sends the message with its bodies decrypted. A body that can
+// not be decrypted fails like a mail that can not be sent, so the message is
+// dead once it runs out of attempts.
+func (s *MailQueueService) sendDecrypted(message *entities.MailMessage) error {
+	text, err := s.cipher.Decrypt(message.Text)
+	if err != nil {
+		return err
+	}
+
+	html, err := s.cipher.Decrypt(message.HTML)
+	if err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(services.Mail{
+		To:      message.Recipient,
+		Subject: message.Subject,
+		Text:    text,
+		HTML:    html,
+	})
+}
+
+// GetMessages returns the messages with the status, or all of them when it is
+// empty, newest first.
+func (s *MailQueueService) GetMessages(status string, pageFilter PageFilter) ([]*entities.MailMessage, error) {
+	if !isMailMessageStatus(status) {
+		return nil, ErrMailQueueServiceStatusIsInvalid
+	}
+
+	return s.mailMessageRepository.GetByStatus(status, &repositories.PageFilter{
+		Offset: (pageFilter.Page - 1) * pageFilter.Size,
+		Limit:  pageFilter.Size,
+	})
+}
+
+func (s *MailQueueService) CountMessages(status string) (int64, error) {
+	if !isMailMessageStatus(status) {
+		return 0, ErrMailQueueServiceStatusIsInvalid
+	}
+
+	return s.mailMessageRepository.CountByStatus(status)
+}
+
+// Retry puts a dead message back in the queue, it is sent on the next poll.
+func (s *MailQueueService) Retry(id string) (*entities.MailMessage, error) {
+	message, err := s.mailMessageRepository.GetByID(id)
+	if errors.Is(err, repositories.ErrMailMessageRepositoryMessageNotFound) {
+		return nil, ErrMailQueueServiceMessageNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = message.Retry(time.Now())
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.mailMessageRepository.Update(message)
+	if err != nil {
+		return nil, err
+	}
+
+	return message, nil
+}
+
+// Purge removes the sent and dead messages older than the retention, dead
+// messages keep their body until then so they can be retried.
+func (s *MailQueueService) Purge() (int64, error) {
+	return s.mailMessageRepository.DeleteFinishedBefore(time.Now().Add(-s.retention))
+}
+
+func isMailMessageStatus(status string) bool {
+	return status == "" ||
+		status == entities.MailMessageStatusPending ||
+		status == entities.MailMessageStatusSent ||
+		status == entities.MailMessageStatusDead
+}
@@ -0,0 +1,414 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	serviceStub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestMailQueueServiceSendPending(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	sent := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "test@example.com",
+		Subject:   "subject",
+		Text:      "encrypted text",
+		HTML:      "encrypted html",
+		Status:    entities.MailMessageStatusPending,
+	}
+	failed := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "failed@example.com",
+		Subject:   "subject",
+		Text:      "encrypted text",
+		HTML:      "encrypted html",
+		Status:    entities.MailMessageStatusPending,
+	}
+	claimed := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "claimed@example.com",
+		Subject:   "subject",
+		Text:      "encrypted text",
+		HTML:      "encrypted html",
+		Status:    entities.MailMessageStatusPending,
+	}
+	mail := services.Mail{To: sent.Recipient, Subject: "subject", Text: "text", HTML: "<p>html</p>"}
+	failedMail := services.Mail{To: failed.Recipient, Subject: "subject", Text: "text", HTML: "<p>html</p>"}
+
+	mailMessageRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.MailMessage{sent, failed, claimed}, nil)
+	mailMessageRepository.On("Claim", sent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	mailMessageRepository.On("Claim", failed, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	mailMessageRepository.On("Claim", claimed, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(repositories.ErrMailMessageRepositoryMessageAlreadyClaimed)
+	cipher.On("Decrypt", "encrypted text").Return("text", nil)
+	cipher.On("Decrypt", "encrypted html").Return("<p>html</p>", nil)
+	mailSender.On("Send", mail).Return(nil)
+	mailSender.On("Send", failedMail).Return(services.ErrMailSenderCannotSendMail)
+	mailMessageRepository.On("Update", sent).Return(nil)
+	mailMessageRepository.On("Update", failed).Return(nil)
+
+	count, err := mailQueueService.SendPending(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, entities.MailMessageStatusSent, sent.Status)
+	assert.NotNil(t, sent.SentAt)
+	assert.Empty(t, sent.Text)
+	assert.Empty(t, sent.HTML)
+	assert.Equal(t, entities.MailMessageStatusPending, failed.Status)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, services.ErrMailSenderCannotSendMail.Error(), *failed.LastError)
+	assert.True(t, failed.AvailableAt.After(time.Now()))
+	mailMessageRepository.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	cipher.AssertExpectations(t)
+	mailSender.AssertNotCalled(t, "Send", services.Mail{To: claimed.Recipient, Subject: "subject", Text: "text", HTML: "<p>html</p>"})
+}
+
+func TestMailQueueServiceSendPendingDeadLetter(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	message := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "test@example.com",
+		Subject:   "subject",
+		Text:      "encrypted text",
+		HTML:      "encrypted html",
+		Status:    entities.MailMessageStatusPending,
+		Attempts:  2,
+	}
+
+	mailMessageRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.MailMessage{message}, nil)
+	mailMessageRepository.On("Claim", message, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	cipher.On("Decrypt", "encrypted text").Return("text", nil)
+	cipher.On("Decrypt", "encrypted html").Return("<p>html</p>", nil)
+	mailSender.On("Send", mock.AnythingOfType("services.Mail")).Return(services.ErrMailSenderCannotSendMail)
+	mailMessageRepository.On("Update", message).Return(nil)
+
+	count, err := mailQueueService.SendPending(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, entities.MailMessageStatusDead, message.Status)
+	assert.Equal(t, 3, message.Attempts)
+	assert.Equal(t, "encrypted text", message.Text)
+	assert.Equal(t, "encrypted html", message.HTML)
+	mailMessageRepository.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	cipher.AssertExpectations(t)
+}
+
+func TestMailQueueServiceSendPendingErrorCanNotUpdate(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	message := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "test@example.com",
+		Subject:   "subject",
+		Text:      "encrypted text",
+		HTML:      "encrypted html",
+		Status:    entities.MailMessageStatusPending,
+	}
+	updateErr := errors.New("update error")
+
+	mailMessageRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.MailMessage{message}, nil)
+	mailMessageRepository.On("Claim", message, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	cipher.On("Decrypt", "encrypted text").Return("text", nil)
+	cipher.On("Decrypt", "encrypted html").Return("<p>html</p>", nil)
+	mailSender.On("Send", mock.AnythingOfType("services.Mail")).Return(nil)
+	mailMessageRepository.On("Update", message).Return(updateErr)
+
+	count, err := mailQueueService.SendPending(10)
+
+	assert.ErrorIs(t, err, updateErr)
+	assert.Equal(t, 1, count)
+	mailMessageRepository.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	cipher.AssertExpectations(t)
+}
+
+func TestMailQueueServiceSendPendingErrorCanNotDecrypt(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	message := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "test@example.com",
+		Subject:   "subject",
+		Text:      "text",
+		HTML:      "<p>html</p>",
+		Status:    entities.MailMessageStatusPending,
+	}
+
+	mailMessageRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.MailMessage{message}, nil)
+	mailMessageRepository.On("Claim", message, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	cipher.On("Decrypt", "text").Return("", services.ErrCipherCanNotDecrypt)
+	mailMessageRepository.On("Update", message).Return(nil)
+
+	count, err := mailQueueService.SendPending(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, entities.MailMessageStatusPending, message.Status)
+	assert.Equal(t, 1, message.Attempts)
+	assert.Equal(t, services.ErrCipherCanNotDecrypt.Error(), *message.LastError)
+	mailMessageRepository.AssertExpectations(t)
+	cipher.AssertExpectations(t)
+	mailSender.AssertNotCalled(t, "Send", mock.Anything)
+}
+
+func TestMailQueueServiceGetMessages(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	message := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "test@example.com",
+		Subject:   "subject",
+		Text:      "text",
+		HTML:      "<p>html</p>",
+		Status:    entities.MailMessageStatusDead,
+		Attempts:  3,
+	}
+	messages := []*entities.MailMessage{message}
+
+	mailMessageRepository.On("GetByStatus", entities.MailMessageStatusDead, &repositories.PageFilter{Offset: 10, Limit: 10}).
+		Return(messages, nil)
+
+	result, err := mailQueueService.GetMessages(entities.MailMessageStatusDead, PageFilter{Page: 2, Size: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, messages, result)
+	mailMessageRepository.AssertExpectations(t)
+}
+
+func TestMailQueueServiceGetMessagesErrorInvalidStatus(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	result, err := mailQueueService.GetMessages("failed", PageFilter{Page: 1, Size: 10})
+
+	assert.ErrorIs(t, err, ErrMailQueueServiceStatusIsInvalid)
+	assert.Nil(t, result)
+	mailMessageRepository.AssertExpectations(t)
+}
+
+func TestMailQueueServiceCountMessages(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	mailMessageRepository.On("CountByStatus", "").Return(int64(5), nil)
+
+	count, err := mailQueueService.CountMessages("")
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), count)
+	mailMessageRepository.AssertExpectations(t)
+}
+
+func TestMailQueueServiceRetry(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	message := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "test@example.com",
+		Subject:   "subject",
+		Text:      "text",
+		HTML:      "<p>html</p>",
+		Status:    entities.MailMessageStatusDead,
+		Attempts:  3,
+	}
+
+	mailMessageRepository.On("GetByID", message.ID).Return(message, nil)
+	mailMessageRepository.On("Update", message).Return(nil)
+
+	result, err := mailQueueService.Retry(message.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, message, result)
+	assert.Equal(t, entities.MailMessageStatusPending, message.Status)
+	assert.Equal(t, 0, message.Attempts)
+	mailMessageRepository.AssertExpectations(t)
+}
+
+func TestMailQueueServiceRetryErrorNotDead(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	message := &entities.MailMessage{
+		ID:        uuid.NewString(),
+		Recipient: "test@example.com",
+		Subject:   "subject",
+		Text:      "text",
+		HTML:      "<p>html</p>",
+		Status:    entities.MailMessageStatusSent,
+		Attempts:  1,
+	}
+
+	mailMessageRepository.On("GetByID", message.ID).Return(message, nil)
+
+	result, err := mailQueueService.Retry(message.ID)
+
+	assert.ErrorIs(t, err, entities.ErrMailMessageShouldBeDead)
+	assert.Nil(t, result)
+	mailMessageRepository.AssertNotCalled(t, "Update", mock.Anything)
+	mailMessageRepository.AssertExpectations(t)
+}
+
+func TestMailQueueServiceRetryErrorNotFound(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	id := uuid.NewString()
+
+	mailMessageRepository.On("GetByID", id).Return(nil, repositories.ErrMailMessageRepositoryMessageNotFound)
+
+	result, err := mailQueueService.Retry(id)
+
+	assert.ErrorIs(t, err, ErrMailQueueServiceMessageNotFound)
+	assert.Nil(t, result)
+	mailMessageRepository.AssertExpectations(t)
+}
+
+func TestMailQueueServicePurge(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	mailMessageRepository.On("DeleteFinishedBefore", mock.MatchedBy(func(before time.Time) bool {
+		expected := time.Now().Add(-7 * 24 * time.Hour)
+		return before.After(expected.Add(-time.Minute)) && before.Before(expected.Add(time.Minute))
+	})).Return(int64(2), nil)
+
+	count, err := mailQueueService.Purge()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	mailMessageRepository.AssertExpectations(t)
+}
+
+func TestMailQueueServicePurgeError(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	cipher := new(serviceStub.CipherMock)
+	mailQueueService := NewMailQueueService(
+		mailMessageRepository,
+		mailSender,
+		cipher,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+		7*24*time.Hour,
+	)
+
+	mailMessageRepository.On("DeleteFinishedBefore", mock.AnythingOfType("time.Time")).
+		Return(int64(0), repositories.ErrMailMessageRepositoryCanNotDeleteMessages)
+
+	count, err := mailQueueService.Purge()
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotDeleteMessages)
+	assert.Equal(t, int64(0), count)
+	mailMessageRepository.AssertExpectations(t)
+}
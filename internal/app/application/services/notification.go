@@ -0,0 +1,305 @@
+package services
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/stretchr/testify/mock"
+	"strconv"
+	"time"
+)
+
+// notificationTimeLayout is how the time of a notification is shown to the
+// user, in their timezone.
+const notificationTimeLayout = "Mon, 02 Jan 2006 15:04 MST"
+
+var (
+	ErrNotificationServicePreferenceNotFound = errors.New("notification preference not found")
+	ErrNotificationServiceRoomNotFound       = errors.New("room not found")
+)
+
+// Notification is what happened to a user. It is emailed rendering the
+// Template with the Data, while digests only list its Message.
+type Notification struct {
+	EventType  string
+	RoomID     string
+	Subject    string
+	Message    string
+	Template   string
+	Data       interface{}
+	HappenedAt time.Time
+}
+
+// NotificationServiceInterface is used by the BoxService to email the members
+// of a household as often as they asked to.
+type NotificationServiceInterface interface {
+	Notify(user *entities.User, notification Notification) error
+}
+
+type NotificationService struct {
+	notificationPreferenceRepository repositories.NotificationPreferenceRepository
+	notificationDigestRepository     repositories.NotificationDigestRepository
+	userRepository                   repositories.UserRepository
+	roomRepository                   repositories.RoomRepository
+	householdService                 HouseholdServiceInterface
+	mailSender                       services.MailSender
+	mailRenderer                     services.MailRenderer
+}
+
+func NewNotificationService(
+	notificationPreferenceRepository repositories.NotificationPreferenceRepository,
+	notificationDigestRepository repositories.NotificationDigestRepository,
+	userRepository repositories.UserRepository,
+	roomRepository repositories.RoomRepository,
+	householdService HouseholdServiceInterface,
+	mailSender services.MailSender,
+	mailRenderer services.MailRenderer,
+) *NotificationService {
+	return &NotificationService{
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	}
+}
+
+func (s *NotificationService) GetPreferences(userID string) ([]*entities.NotificationPreference, error) {
+	return s.notificationPreferenceRepository.GetByUserID(userID)
+}
+
+// SetPreference changes the frequency of the preference of the event type for
+// the room, or for every room when roomID is nil, and creates it when the user
+// does not have it yet.
+func (s *NotificationService) SetPreference(
+	userID string,
+	eventType string,
+	roomID *string,
+	frequency string,
+) (*entities.NotificationPreference, error) {
+	if roomID != nil {
+		room, err := s.roomRepository.GetByID(*roomID)
+		if err != nil {
+			return nil, ErrNotificationServiceRoomNotFound
+		}
+
+		err = s.householdService.CheckCanView(room.HouseholdID, userID)
+		if errors.Is(err, ErrHouseholdServiceHouseholdNotFound) {
+			return nil, ErrNotificationServiceRoomNotFound
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	preferences, err := s.notificationPreferenceRepository.GetByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, preference := range preferences {
+		if !preference.IsFor(eventType, roomID) {
+			continue
+		}
+
+		err = preference.ChangeFrequency(frequency)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.notificationPreferenceRepository.Update(preference)
+		if err != nil {
+			return nil, err
+		}
+
+		return preference, nil
+	}
+
+	preference, err := entities.NewNotificationPreference(userID, eventType, roomID, frequency)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.notificationPreferenceRepository.Create(preference)
+	if err != nil {
+		return nil, err
+	}
+
+	return preference, nil
+}
+
+// DeletePreference only deletes the preferences of the user, for anyone else
+// they do not exist.
+func (s *NotificationService) DeletePreference(id string, userID string) error {
+	preference, err := s.notificationPreferenceRepository.GetByID(id)
+	if errors.Is(err, repositories.ErrNotificationRepositoryPreferenceNotFound) {
+		return ErrNotificationServicePreferenceNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if preference.UserID != userID {
+		return ErrNotificationServicePreferenceNotFound
+	}
+
+	return s.notificationPreferenceRepository.Delete(preference.ID)
+}
+
+// Notify emails the notification to the user right away, queues its message
+// for their next digest or drops it, as their preference for the event type
+// and room says. Until the user verifies their email its message is held, and
+// ReleaseHeld sends it in a digest.
+func (s *NotificationService) Notify(user *entities.User, notification Notification) error {
+	preferences, err := s.notificationPreferenceRepository.GetByUserID(user.ID)
+	if err != nil {
+		return err
+	}
+
+	frequency := entities.ResolveNotificationFrequency(preferences, notification.EventType, notification.RoomID)
+	if frequency == entities.NotificationFrequencyOff {
+		return nil
+	}
+
+	var entry *entities.NotificationDigestEntry
+	switch {
+	case !user.IsVerified():
+		entry, err = entities.NewHeldNotificationDigestEntry(
+			user.ID,
+			notification.EventType,
+			notification.Message,
+			notification.HappenedAt,
+			time.Now(),
+		)
+	case frequency == entities.NotificationFrequencyInstant:
+		return s.sendMail(user.Email, notification.Subject, notification.Template, notification.Data)
+	default:
+		entry, err = entities.NewNotificationDigestEntry(
+			user.ID,
+			notification.EventType,
+			notification.Message,
+			notification.HappenedAt,
+			frequency,
+			time.Now(),
+		)
+	}
+	if err != nil {
+		return err
+	}
+
+	return s.notificationDigestRepository.Create(entry)
+}
+
+// ReleaseHeld makes the notifications held while the user had not verified
+// their email part of their next digest.
+func (s *NotificationService) ReleaseHeld(userID string) error {
+	return s.notificationDigestRepository.ReleaseByUserID(userID, time.Now())
+}
+
+// SendDigests emails up to limit users with due entries one summary of all
+// of their entries and returns how many digests were sent. A digest that can
+// not be sent is tried again once its claim expires.
+func (s *NotificationService) SendDigests(limit int) (int, error) {
+	now := time.Now()
+	// The due at is stored in seconds, so the claimed entries are found by
+	// the same until that was written.
+	until := now.Add(ClaimDuration).Truncate(time.Second)
+
+	userIDs, err := s.notificationDigestRepository.GetDueUserIDs(now, limit)
+	if err != nil {
+		return 0, err
+	}
+
+	sent := 0
+	for _, userID := range userIDs {
+		err = s.notificationDigestRepository.ClaimByUserID(userID, now, until)
+		if errors.Is(err, repositories.ErrNotificationRepositoryDigestAlreadyClaimed) {
+			continue
+		}
+		if err != nil {
+			return sent, err
+		}
+
+		entries, err := s.notificationDigestRepository.GetClaimedByUserID(userID, until)
+		if err != nil {
+			return sent, err
+		}
+
+		if len(entries) == 0 {
+			continue
+		}
+
+		user, err := s.userRepository.GetByID(userID)
+		if err != nil {
+			return sent, err
+		}
+
+		subject, data := makeNotificationDigest(entries)
+
+		err = s.sendMail(user.Email, subject, services.MailTemplateNotificationDigest, data)
+		if err != nil {
+			logger.LogError(err)
+			continue
+		}
+
+		ids := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			ids = append(ids, entry.ID)
+		}
+
+		err = s.notificationDigestRepository.DeleteByIDs(ids)
+		if err != nil {
+			return sent, err
+		}
+
+		sent++
+	}
+
+	return sent, nil
+}
+
+func (s *NotificationService) sendMail(to string, subject string, template string, data interface{}) error {
+	text, html, err := s.mailRenderer.Render(template, data)
+	if err != nil {
+		return err
+	}
+
+	return s.mailSender.Send(services.Mail{
+		To:      to,
+		Subject: subject,
+		Text:    text,
+		HTML:    html,
+	})
+}
+
+func makeNotificationDigest(entries []*entities.NotificationDigestEntry) (string, services.NotificationDigestMailData) {
+	subject := "Your inventory digest: " + strconv.Itoa(len(entries)) + " notifications"
+	if len(entries) == 1 {
+		subject = "Your inventory digest: 1 notification"
+	}
+
+	messages := make([]string, 0, len(entries))
+	for _, entry := range entries {
+		messages = append(messages, entry.Message)
+	}
+
+	return subject, services.NotificationDigestMailData{Messages: messages}
+}
+
+// formatNotificationTime formats the time in the timezone of the user.
+func formatNotificationTime(user *entities.User, t time.Time) string {
+	return t.In(user.Location()).Format(notificationTimeLayout)
+}
+
+type NotificationServiceMock struct {
+	mock.Mock
+}
+
+func (m *NotificationServiceMock) Notify(user *entities.User, notification Notification) error {
+	args := m.Called(user, notification)
+	return args.Error(0)
+}
@@ -0,0 +1,592 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	serviceStub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestNotificationServiceSetPreferenceCreatesIt(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	everyRoom := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		EventType: "BoxItemAddedEvent",
+		Frequency: entities.NotificationFrequencyDaily,
+	}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(nil)
+	notificationPreferenceRepository.On("GetByUserID", userID).
+		Return([]*entities.NotificationPreference{everyRoom}, nil)
+	notificationPreferenceRepository.On("Create", mock.AnythingOfType("*entities.NotificationPreference")).
+		Return(nil)
+
+	preference, err := notificationService.SetPreference(userID, "BoxItemAddedEvent", &room.ID, entities.NotificationFrequencyOff)
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, preference.UserID)
+	assert.Equal(t, &room.ID, preference.RoomID)
+	assert.Equal(t, entities.NotificationFrequencyOff, preference.Frequency)
+	assert.Equal(t, entities.NotificationFrequencyDaily, everyRoom.Frequency)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceSetPreferenceChangesIt(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	userID := uuid.NewString()
+	everyRoom := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		EventType: "BoxItemAddedEvent",
+		Frequency: entities.NotificationFrequencyDaily,
+	}
+
+	notificationPreferenceRepository.On("GetByUserID", userID).
+		Return([]*entities.NotificationPreference{everyRoom}, nil)
+	notificationPreferenceRepository.On("Update", everyRoom).Return(nil)
+
+	preference, err := notificationService.SetPreference(userID, "BoxItemAddedEvent", nil, entities.NotificationFrequencyHourly)
+
+	assert.NoError(t, err)
+	assert.Equal(t, everyRoom, preference)
+	assert.Equal(t, entities.NotificationFrequencyHourly, preference.Frequency)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceSetPreferenceErrorRoomNotFound(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	preference, err := notificationService.SetPreference(userID, "BoxItemAddedEvent", &room.ID, entities.NotificationFrequencyOff)
+
+	assert.ErrorIs(t, err, ErrNotificationServiceRoomNotFound)
+	assert.Nil(t, preference)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceDeletePreference(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	preference := &entities.NotificationPreference{ID: uuid.NewString(), UserID: uuid.NewString()}
+
+	notificationPreferenceRepository.On("GetByID", preference.ID).Return(preference, nil)
+	notificationPreferenceRepository.On("Delete", preference.ID).Return(nil)
+
+	err := notificationService.DeletePreference(preference.ID, preference.UserID)
+
+	assert.NoError(t, err)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceDeletePreferenceErrorOfOtherUser(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	preference := &entities.NotificationPreference{ID: uuid.NewString(), UserID: uuid.NewString()}
+
+	notificationPreferenceRepository.On("GetByID", preference.ID).Return(preference, nil)
+
+	err := notificationService.DeletePreference(preference.ID, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrNotificationServicePreferenceNotFound)
+	notificationPreferenceRepository.AssertNotCalled(t, "Delete", mock.Anything)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceDeletePreferenceErrorNotFound(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	id := uuid.NewString()
+
+	notificationPreferenceRepository.On("GetByID", id).
+		Return(nil, repositories.ErrNotificationRepositoryPreferenceNotFound)
+
+	err := notificationService.DeletePreference(id, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrNotificationServicePreferenceNotFound)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceNotify(t *testing.T) {
+	verifiedAt := time.Now()
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", VerifiedAt: &verifiedAt}
+	roomID := uuid.NewString()
+	happenedAt := time.Now()
+	data := services.BoxItemMailData{Quantity: "2", ItemName: "item"}
+	notification := Notification{
+		EventType:  "BoxItemAddedEvent",
+		RoomID:     roomID,
+		Subject:    "subject",
+		Message:    "message",
+		Template:   services.MailTemplateBoxItemAdded,
+		Data:       data,
+		HappenedAt: happenedAt,
+	}
+
+	testCases := []struct {
+		name      string
+		frequency string
+		setup     func(notificationDigestRepository *stub.NotificationDigestRepositoryMock, mailSender *serviceStub.MailSenderMock, mailRenderer *serviceStub.MailRendererMock)
+	}{
+		{
+			name:      "instant",
+			frequency: entities.NotificationFrequencyInstant,
+			setup: func(notificationDigestRepository *stub.NotificationDigestRepositoryMock, mailSender *serviceStub.MailSenderMock, mailRenderer *serviceStub.MailRendererMock) {
+				mailRenderer.On("Render", services.MailTemplateBoxItemAdded, data).Return("text", "<p>html</p>", nil)
+				mailSender.On("Send", services.Mail{
+					To:      user.Email,
+					Subject: "subject",
+					Text:    "text",
+					HTML:    "<p>html</p>",
+				}).Return(nil)
+			},
+		},
+		{
+			name:      "digest",
+			frequency: entities.NotificationFrequencyHourly,
+			setup: func(notificationDigestRepository *stub.NotificationDigestRepositoryMock, mailSender *serviceStub.MailSenderMock, mailRenderer *serviceStub.MailRendererMock) {
+				notificationDigestRepository.On("Create", mock.MatchedBy(func(entry *entities.NotificationDigestEntry) bool {
+					return entry.UserID == user.ID &&
+						entry.EventType == "BoxItemAddedEvent" &&
+						entry.Message == "message" &&
+						entry.HappenedAt.Equal(happenedAt) &&
+						entry.DueAt.After(time.Now()) &&
+						!entry.Held
+				})).Return(nil)
+			},
+		},
+		{
+			name:      "off",
+			frequency: entities.NotificationFrequencyOff,
+			setup: func(notificationDigestRepository *stub.NotificationDigestRepositoryMock, mailSender *serviceStub.MailSenderMock, mailRenderer *serviceStub.MailRendererMock) {
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+			notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+			userRepository := new(stub.UserRepositoryMock)
+			roomRepository := new(stub.RoomRepositoryMock)
+			householdService := new(HouseholdServiceMock)
+			mailSender := new(serviceStub.MailSenderMock)
+			mailRenderer := new(serviceStub.MailRendererMock)
+			notificationService := NewNotificationService(
+				notificationPreferenceRepository,
+				notificationDigestRepository,
+				userRepository,
+				roomRepository,
+				householdService,
+				mailSender,
+				mailRenderer,
+			)
+
+			notificationPreferenceRepository.On("GetByUserID", user.ID).
+				Return([]*entities.NotificationPreference{
+					{UserID: user.ID, EventType: "BoxItemAddedEvent", RoomID: &roomID, Frequency: testCase.frequency},
+				}, nil)
+			testCase.setup(notificationDigestRepository, mailSender, mailRenderer)
+
+			err := notificationService.Notify(user, notification)
+
+			assert.NoError(t, err)
+			notificationPreferenceRepository.AssertExpectations(t)
+			notificationDigestRepository.AssertExpectations(t)
+			userRepository.AssertExpectations(t)
+			roomRepository.AssertExpectations(t)
+			householdService.AssertExpectations(t)
+			mailSender.AssertExpectations(t)
+			mailRenderer.AssertExpectations(t)
+		})
+	}
+}
+
+func TestNotificationServiceNotifyHoldsForUnverifiedUser(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	happenedAt := time.Now()
+	notification := Notification{
+		EventType:  "BoxItemAddedEvent",
+		RoomID:     uuid.NewString(),
+		Subject:    "subject",
+		Message:    "message",
+		Template:   services.MailTemplateBoxItemAdded,
+		HappenedAt: happenedAt,
+	}
+
+	notificationPreferenceRepository.On("GetByUserID", user.ID).
+		Return([]*entities.NotificationPreference{}, nil)
+	notificationDigestRepository.On("Create", mock.MatchedBy(func(entry *entities.NotificationDigestEntry) bool {
+		return entry.UserID == user.ID &&
+			entry.EventType == "BoxItemAddedEvent" &&
+			entry.Message == "message" &&
+			entry.HappenedAt.Equal(happenedAt) &&
+			entry.Held
+	})).Return(nil)
+
+	err := notificationService.Notify(user, notification)
+
+	assert.NoError(t, err)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	mailSender.AssertNotCalled(t, "Send", mock.Anything)
+	mailRenderer.AssertNotCalled(t, "Render", mock.Anything, mock.Anything)
+}
+
+func TestNotificationServiceReleaseHeld(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	userID := uuid.NewString()
+
+	notificationDigestRepository.On("ReleaseByUserID", userID, mock.AnythingOfType("time.Time")).
+		Return(repositories.ErrNotificationRepositoryCanNotReleaseDigest)
+
+	err := notificationService.ReleaseHeld(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotReleaseDigest)
+	notificationDigestRepository.AssertExpectations(t)
+}
+
+func TestNotificationServiceSendDigests(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	first := &entities.User{ID: uuid.NewString(), Email: "first@example.com"}
+	second := &entities.User{ID: uuid.NewString(), Email: "second@example.com"}
+	entries := []*entities.NotificationDigestEntry{
+		{ID: uuid.NewString(), UserID: first.ID, Message: "2 item added into box 1"},
+		{ID: uuid.NewString(), UserID: first.ID, Message: "1 item removed from box 1"},
+		{ID: uuid.NewString(), UserID: second.ID, Message: "3 item added into box 2"},
+	}
+
+	notificationDigestRepository.On("GetDueUserIDs", mock.AnythingOfType("time.Time"), 10).
+		Return([]string{first.ID, second.ID}, nil)
+	notificationDigestRepository.On("ClaimByUserID", first.ID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	notificationDigestRepository.On("ClaimByUserID", second.ID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	notificationDigestRepository.On("GetClaimedByUserID", first.ID, mock.AnythingOfType("time.Time")).
+		Return(entries[:2], nil)
+	notificationDigestRepository.On("GetClaimedByUserID", second.ID, mock.AnythingOfType("time.Time")).
+		Return(entries[2:], nil)
+	userRepository.On("GetByID", first.ID).Return(first, nil)
+	userRepository.On("GetByID", second.ID).Return(second, nil)
+	mailRenderer.On("Render", services.MailTemplateNotificationDigest, services.NotificationDigestMailData{
+		Messages: []string{"2 item added into box 1", "1 item removed from box 1"},
+	}).Return("first text", "<p>first html</p>", nil)
+	mailRenderer.On("Render", services.MailTemplateNotificationDigest, services.NotificationDigestMailData{
+		Messages: []string{"3 item added into box 2"},
+	}).Return("second text", "<p>second html</p>", nil)
+	mailSender.On("Send", services.Mail{
+		To:      first.Email,
+		Subject: "Your inventory digest: 2 notifications",
+		Text:    "first text",
+		HTML:    "<p>first html</p>",
+	}).Return(nil)
+	mailSender.On("Send", services.Mail{
+		To:      second.Email,
+		Subject: "Your inventory digest: 1 notification",
+		Text:    "second text",
+		HTML:    "<p>second html</p>",
+	}).Return(errors.New("can not send mail"))
+	notificationDigestRepository.On("DeleteByIDs", []string{entries[0].ID, entries[1].ID}).Return(nil)
+
+	sent, err := notificationService.SendDigests(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	notificationDigestRepository.AssertNotCalled(t, "DeleteByIDs", []string{entries[2].ID})
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceSendDigestsSkipsClaimedDigest(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	userID := uuid.NewString()
+
+	notificationDigestRepository.On("GetDueUserIDs", mock.AnythingOfType("time.Time"), 10).
+		Return([]string{userID}, nil)
+	notificationDigestRepository.On("ClaimByUserID", userID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(repositories.ErrNotificationRepositoryDigestAlreadyClaimed)
+
+	sent, err := notificationService.SendDigests(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, sent)
+	notificationDigestRepository.AssertNotCalled(t, "GetClaimedByUserID", userID, mock.Anything)
+	mailSender.AssertNotCalled(t, "Send", mock.Anything)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
+
+func TestNotificationServiceSendDigestsLoadsClaimedEntries(t *testing.T) {
+	notificationPreferenceRepository := new(stub.NotificationPreferenceRepositoryMock)
+	notificationDigestRepository := new(stub.NotificationDigestRepositoryMock)
+	userRepository := new(stub.UserRepositoryMock)
+	roomRepository := new(stub.RoomRepositoryMock)
+	householdService := new(HouseholdServiceMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	mailRenderer := new(serviceStub.MailRendererMock)
+	notificationService := NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "user@example.com"}
+	entries := []*entities.NotificationDigestEntry{
+		{ID: uuid.NewString(), UserID: user.ID, Message: "2 item added into box 1"},
+		{ID: uuid.NewString(), UserID: user.ID, Message: "1 item removed from box 1"},
+		{ID: uuid.NewString(), UserID: user.ID, Message: "3 item added into box 2"},
+	}
+	var claimedUntil time.Time
+
+	notificationDigestRepository.On("GetDueUserIDs", mock.AnythingOfType("time.Time"), 1).
+		Return([]string{user.ID}, nil)
+	notificationDigestRepository.On("ClaimByUserID", user.ID, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Run(func(args mock.Arguments) {
+			claimedUntil = args.Get(2).(time.Time)
+		}).
+		Return(nil)
+	notificationDigestRepository.On("GetClaimedByUserID", user.ID, mock.MatchedBy(func(until time.Time) bool {
+		return until.Equal(claimedUntil) && until.Nanosecond() == 0
+	})).
+		Return(entries, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	mailRenderer.On("Render", services.MailTemplateNotificationDigest, services.NotificationDigestMailData{
+		Messages: []string{"2 item added into box 1", "1 item removed from box 1", "3 item added into box 2"},
+	}).Return("text", "<p>html</p>", nil)
+	mailSender.On("Send", mock.AnythingOfType("services.Mail")).Return(nil)
+	notificationDigestRepository.On("DeleteByIDs", []string{entries[0].ID, entries[1].ID, entries[2].ID}).Return(nil)
+
+	sent, err := notificationService.SendDigests(1)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, sent)
+	notificationPreferenceRepository.AssertExpectations(t)
+	notificationDigestRepository.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+	mailRenderer.AssertExpectations(t)
+}
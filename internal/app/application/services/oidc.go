@@ -0,0 +1,180 @@
+package services
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"strings"
+	"time"
+)
+
+var (
+	ErrOIDCServiceInvalidState       = errors.New("invalid or expired login state")
+	ErrOIDCServiceEmailNotVerified   = errors.New("identity provider did not verify the email")
+	ErrOIDCServiceAccountNotVerified = errors.New("verify the email of your account before signing in with an identity provider")
+)
+
+const (
+	oidcStateSignaturePrefix = "oidc:"
+	oidcStateDuration        = 10 * time.Minute
+)
+
+type OIDCService struct {
+	identityProvider       services.IdentityProvider
+	userRepository         repositories.UserRepository
+	userIdentityRepository repositories.UserIdentityRepository
+	authService            *AuthService
+	signer                 services.Signer
+}
+
+func NewOIDCService(
+	identityProvider services.IdentityProvider,
+	userRepository repositories.UserRepository,
+	userIdentityRepository repositories.UserIdentityRepository,
+	authService *AuthService,
+	signer services.Signer,
+) *OIDCService {
+	return &OIDCService{
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	}
+}
+
+// Start returns the url of the identity provider to send the user to, and a
+// signed state to keep in the browser until the callback. The state holds the
+// PKCE verifier and the nonce, so nothing is stored in the server.
+func (s *OIDCService) Start() (string, string, error) {
+	state, err := randomOIDCValue()
+	if err != nil {
+		return "", "", err
+	}
+
+	codeVerifier, err := randomOIDCValue()
+	if err != nil {
+		return "", "", err
+	}
+
+	nonce, err := randomOIDCValue()
+	if err != nil {
+		return "", "", err
+	}
+
+	challenge := sha256.Sum256([]byte(codeVerifier))
+
+	authorizationURL, err := s.identityProvider.AuthorizationURL(
+		state,
+		nonce,
+		base64.RawURLEncoding.EncodeToString(challenge[:]),
+	)
+	if err != nil {
+		return "", "", err
+	}
+
+	signedState := s.signer.Sign(
+		oidcStateSignaturePrefix+state+":"+codeVerifier+":"+nonce,
+		time.Now().Add(oidcStateDuration),
+	)
+
+	return authorizationURL, signedState, nil
+}
+
+// Finish exchanges the code for the identity of the user and signs them in.
+// An identity seen before signs in its user. Otherwise it is linked to the
+// user with the same email, only when both the provider and the account have
+// verified it, or to a new user without password. Users with two factor
+// authentication still have to send a code, as with a password.
+func (s *OIDCService) Finish(signedState string, state string, code string) (
+	*struct {
+		User         *entities.User
+		Token        string
+		RefreshToken string
+		MFAToken     string
+	},
+	error,
+) {
+	value, err := s.signer.Verify(signedState)
+	if err != nil {
+		return nil, ErrOIDCServiceInvalidState
+	}
+
+	value, found := strings.CutPrefix(value, oidcStateSignaturePrefix)
+	if !found {
+		return nil, ErrOIDCServiceInvalidState
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 || subtle.ConstantTimeCompare([]byte(parts[0]), []byte(state)) != 1 {
+		return nil, ErrOIDCServiceInvalidState
+	}
+	codeVerifier, nonce := parts[1], parts[2]
+
+	identity, err := s.identityProvider.Exchange(code, codeVerifier, nonce)
+	if err != nil {
+		return nil, err
+	}
+
+	user, err := s.findOrCreateUser(identity)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.authService.StartSession(user)
+}
+
+func (s *OIDCService) findOrCreateUser(identity *services.Identity) (*entities.User, error) {
+	userIdentity, err := s.userIdentityRepository.GetByIssuerAndSubject(identity.Issuer, identity.Subject)
+	if err == nil {
+		return s.userRepository.GetByID(userIdentity.UserID)
+	}
+	if !errors.Is(err, repositories.ErrUserIdentityRepositoryUserIdentityNotFound) {
+		return nil, err
+	}
+
+	if identity.Email == "" || !identity.EmailVerified {
+		return nil, ErrOIDCServiceEmailNotVerified
+	}
+
+	user, err := s.userRepository.FindByEmail(identity.Email)
+	if errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+		user, err = entities.NewUserWithoutPassword(identity.Email)
+		if err != nil {
+			return nil, err
+		}
+
+		err = s.userRepository.Create(user)
+	} else if err == nil && !user.IsVerified() {
+		return nil, ErrOIDCServiceAccountNotVerified
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	userIdentity, err = entities.NewUserIdentity(user.ID, identity.Issuer, identity.Subject, identity.Email)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userIdentityRepository.Create(userIdentity)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func randomOIDCValue() (string, error) {
+	bytes := make([]byte, 32)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(bytes), nil
+}
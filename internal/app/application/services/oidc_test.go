@@ -0,0 +1,539 @@
+package services
+
+import (
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	serviceStub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func expectOIDCFinishStarted(signer *serviceStub.SignerMock, identityProvider *serviceStub.IdentityProviderMock, identity *services.Identity) {
+	signer.On("Verify", "signed-state").Return("oidc:state:verifier:nonce", nil)
+	identityProvider.On("Exchange", "code", "verifier", "nonce").Return(identity, nil)
+}
+
+func expectOIDCSessionCreated(
+	twoFactorService *TwoFactorServiceMock,
+	tokenGenerator *serviceStub.TokenGeneratorMock,
+	refreshTokenRepository *stub.RefreshTokenRepositoryMock,
+	user *entities.User,
+) {
+	twoFactorService.On("IsEnabled", user.ID).Return(false, nil)
+	tokenGenerator.On("GenerateToken", user.ID, user.Email, mock.AnythingOfType("string")).
+		Return("fake_token", nil)
+	refreshTokenRepository.On("Create", mock.AnythingOfType("*entities.RefreshToken")).
+		Return(nil)
+}
+
+func TestOIDCServiceStart(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	identityProvider.On("AuthorizationURL", mock.AnythingOfType("string"), mock.AnythingOfType("string"), mock.AnythingOfType("string")).
+		Return("https://issuer.example.com/authorize", nil)
+	signer.On("Sign", mock.AnythingOfType("string"), mock.AnythingOfType("time.Time")).
+		Return("signed-state")
+
+	authorizationURL, signedState, err := service.Start()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "https://issuer.example.com/authorize", authorizationURL)
+	assert.Equal(t, "signed-state", signedState)
+	identityProvider.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	twoFactorService.AssertExpectations(t)
+	tokenGenerator.AssertExpectations(t)
+	signer.AssertExpectations(t)
+
+	state := identityProvider.Calls[0].Arguments.String(0)
+	nonce := identityProvider.Calls[0].Arguments.String(1)
+	codeChallenge := identityProvider.Calls[0].Arguments.String(2)
+
+	value := signer.Calls[0].Arguments.String(0)
+	parts := strings.Split(strings.TrimPrefix(value, "oidc:"), ":")
+	assert.True(t, strings.HasPrefix(value, "oidc:"))
+	assert.Len(t, parts, 3)
+	assert.Equal(t, state, parts[0])
+	assert.Equal(t, nonce, parts[2])
+
+	challenge := sha256.Sum256([]byte(parts[1]))
+	assert.Equal(t, base64.RawURLEncoding.EncodeToString(challenge[:]), codeChallenge)
+	assert.NotEqual(t, state, nonce)
+	assert.WithinDuration(t, time.Now().Add(oidcStateDuration), signer.Calls[0].Arguments.Get(1).(time.Time), time.Second)
+}
+
+func TestOIDCServiceStartError(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	identityProvider.On("AuthorizationURL", mock.Anything, mock.Anything, mock.Anything).
+		Return("", services.ErrIdentityProviderCanNotDiscover)
+
+	authorizationURL, signedState, err := service.Start()
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderCanNotDiscover)
+	assert.Empty(t, authorizationURL)
+	assert.Empty(t, signedState)
+	signer.AssertNotCalled(t, "Sign", mock.Anything, mock.Anything)
+}
+
+func TestOIDCServiceFinishWithLinkedIdentity(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	identity := &services.Identity{Issuer: "https://issuer.example.com", Subject: "subject", Email: "other@example.com"}
+	userIdentity := &entities.UserIdentity{ID: uuid.NewString(), UserID: user.ID, Issuer: identity.Issuer, Subject: identity.Subject}
+
+	expectOIDCFinishStarted(signer, identityProvider, identity)
+	userIdentityRepository.On("GetByIssuerAndSubject", identity.Issuer, identity.Subject).Return(userIdentity, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	expectOIDCSessionCreated(twoFactorService, tokenGenerator, refreshTokenRepository, user)
+
+	session, err := service.Finish("signed-state", "state", "code")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, session.User)
+	assert.Equal(t, "fake_token", session.Token)
+	assert.NotEmpty(t, session.RefreshToken)
+	identityProvider.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	twoFactorService.AssertExpectations(t)
+	tokenGenerator.AssertExpectations(t)
+	signer.AssertExpectations(t)
+	userIdentityRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOIDCServiceFinishWithTwoFactor(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	identity := &services.Identity{Issuer: "https://issuer.example.com", Subject: "subject"}
+	userIdentity := &entities.UserIdentity{ID: uuid.NewString(), UserID: user.ID, Issuer: identity.Issuer, Subject: identity.Subject}
+
+	expectOIDCFinishStarted(signer, identityProvider, identity)
+	userIdentityRepository.On("GetByIssuerAndSubject", identity.Issuer, identity.Subject).Return(userIdentity, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	twoFactorService.On("IsEnabled", user.ID).Return(true, nil)
+	signer.On("Sign", "mfa:"+user.ID, mock.AnythingOfType("time.Time")).Return("mfa-token")
+
+	session, err := service.Finish("signed-state", "state", "code")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, session.User)
+	assert.Equal(t, "mfa-token", session.MFAToken)
+	assert.Empty(t, session.Token)
+	assert.Empty(t, session.RefreshToken)
+	identityProvider.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	twoFactorService.AssertExpectations(t)
+	tokenGenerator.AssertExpectations(t)
+	signer.AssertExpectations(t)
+	refreshTokenRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOIDCServiceFinishLinksUserWithVerifiedEmail(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	verifiedAt := time.Now()
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", VerifiedAt: &verifiedAt}
+	identity := &services.Identity{Issuer: "https://issuer.example.com", Subject: "subject", Email: user.Email, EmailVerified: true}
+
+	expectOIDCFinishStarted(signer, identityProvider, identity)
+	userIdentityRepository.On("GetByIssuerAndSubject", identity.Issuer, identity.Subject).
+		Return(nil, repositories.ErrUserIdentityRepositoryUserIdentityNotFound)
+	userRepository.On("FindByEmail", user.Email).Return(user, nil)
+	userIdentityRepository.On("Create", mock.MatchedBy(func(userIdentity *entities.UserIdentity) bool {
+		return userIdentity.UserID == user.ID &&
+			userIdentity.Issuer == identity.Issuer &&
+			userIdentity.Subject == identity.Subject &&
+			userIdentity.Email == identity.Email
+	})).Return(nil)
+	expectOIDCSessionCreated(twoFactorService, tokenGenerator, refreshTokenRepository, user)
+
+	session, err := service.Finish("signed-state", "state", "code")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, session.User)
+	identityProvider.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	twoFactorService.AssertExpectations(t)
+	tokenGenerator.AssertExpectations(t)
+	signer.AssertExpectations(t)
+	userRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestOIDCServiceFinishCreatesUser(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	identity := &services.Identity{Issuer: "https://issuer.example.com", Subject: "subject", Email: "test@example.com", EmailVerified: true}
+
+	expectOIDCFinishStarted(signer, identityProvider, identity)
+	userIdentityRepository.On("GetByIssuerAndSubject", identity.Issuer, identity.Subject).
+		Return(nil, repositories.ErrUserIdentityRepositoryUserIdentityNotFound)
+	userRepository.On("FindByEmail", identity.Email).Return(nil, repositories.ErrUserRepositoryUserNotFound)
+	userRepository.On("Create", mock.AnythingOfType("*entities.User")).Return(nil)
+	userIdentityRepository.On("Create", mock.AnythingOfType("*entities.UserIdentity")).Return(nil)
+	twoFactorService.On("IsEnabled", mock.AnythingOfType("string")).Return(false, nil)
+	tokenGenerator.On("GenerateToken", mock.AnythingOfType("string"), identity.Email, mock.AnythingOfType("string")).
+		Return("fake_token", nil)
+	refreshTokenRepository.On("Create", mock.AnythingOfType("*entities.RefreshToken")).Return(nil)
+
+	session, err := service.Finish("signed-state", "state", "code")
+
+	assert.NoError(t, err)
+	assert.Equal(t, identity.Email, session.User.Email)
+	assert.True(t, session.User.IsVerified())
+	assert.False(t, session.User.HasPassword())
+	identityProvider.AssertExpectations(t)
+	userRepository.AssertExpectations(t)
+	userIdentityRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+	twoFactorService.AssertExpectations(t)
+	tokenGenerator.AssertExpectations(t)
+	signer.AssertExpectations(t)
+
+	userIdentity := userIdentityRepository.Calls[1].Arguments.Get(0).(*entities.UserIdentity)
+	assert.Equal(t, session.User.ID, userIdentity.UserID)
+}
+
+func TestOIDCServiceFinishErrorInvalidState(t *testing.T) {
+	testCases := map[string]struct {
+		value string
+		err   error
+		state string
+	}{
+		"invalid signature": {"", errors.New("invalid signature"), "state"},
+		"other purpose":     {"email-change:state:verifier:nonce", nil, "state"},
+		"malformed value":   {"oidc:state", nil, "state"},
+		"different state":   {"oidc:state:verifier:nonce", nil, "other-state"},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			identityProvider := new(serviceStub.IdentityProviderMock)
+			userRepository := new(stub.UserRepositoryMock)
+			userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+			refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+			twoFactorService := new(TwoFactorServiceMock)
+			tokenGenerator := new(serviceStub.TokenGeneratorMock)
+			signer := new(serviceStub.SignerMock)
+			authService := NewAuthService(
+				userRepository,
+				refreshTokenRepository,
+				new(stub.PersonalAccessTokenRepositoryMock),
+				new(LoginThrottleServiceMock),
+				twoFactorService,
+				tokenGenerator,
+				new(serviceStub.PasswordHasherMock),
+				signer,
+				time.Hour,
+			)
+
+			service := NewOIDCService(
+				identityProvider,
+				userRepository,
+				userIdentityRepository,
+				authService,
+				signer,
+			)
+
+			signer.On("Verify", "signed-state").Return(testCase.value, testCase.err)
+
+			session, err := service.Finish("signed-state", testCase.state, "code")
+
+			assert.ErrorIs(t, err, ErrOIDCServiceInvalidState)
+			assert.Nil(t, session)
+			identityProvider.AssertNotCalled(t, "Exchange", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
+
+func TestOIDCServiceFinishErrorExchange(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	signer.On("Verify", "signed-state").Return("oidc:state:verifier:nonce", nil)
+	identityProvider.On("Exchange", "code", "verifier", "nonce").
+		Return(nil, services.ErrIdentityProviderInvalidIDToken)
+
+	session, err := service.Finish("signed-state", "state", "code")
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderInvalidIDToken)
+	assert.Nil(t, session)
+	userIdentityRepository.AssertNotCalled(t, "GetByIssuerAndSubject", mock.Anything, mock.Anything)
+}
+
+func TestOIDCServiceFinishErrorEmailNotVerified(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	identity := &services.Identity{Issuer: "https://issuer.example.com", Subject: "subject", Email: "test@example.com"}
+
+	expectOIDCFinishStarted(signer, identityProvider, identity)
+	userIdentityRepository.On("GetByIssuerAndSubject", identity.Issuer, identity.Subject).
+		Return(nil, repositories.ErrUserIdentityRepositoryUserIdentityNotFound)
+
+	session, err := service.Finish("signed-state", "state", "code")
+
+	assert.ErrorIs(t, err, ErrOIDCServiceEmailNotVerified)
+	assert.Nil(t, session)
+	userRepository.AssertNotCalled(t, "FindByEmail", mock.Anything)
+}
+
+func TestOIDCServiceFinishErrorAccountNotVerified(t *testing.T) {
+	identityProvider := new(serviceStub.IdentityProviderMock)
+	userRepository := new(stub.UserRepositoryMock)
+	userIdentityRepository := new(stub.UserIdentityRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	twoFactorService := new(TwoFactorServiceMock)
+	tokenGenerator := new(serviceStub.TokenGeneratorMock)
+	signer := new(serviceStub.SignerMock)
+	authService := NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		new(stub.PersonalAccessTokenRepositoryMock),
+		new(LoginThrottleServiceMock),
+		twoFactorService,
+		tokenGenerator,
+		new(serviceStub.PasswordHasherMock),
+		signer,
+		time.Hour,
+	)
+
+	service := NewOIDCService(
+		identityProvider,
+		userRepository,
+		userIdentityRepository,
+		authService,
+		signer,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	identity := &services.Identity{Issuer: "https://issuer.example.com", Subject: "subject", Email: user.Email, EmailVerified: true}
+
+	expectOIDCFinishStarted(signer, identityProvider, identity)
+	userIdentityRepository.On("GetByIssuerAndSubject", identity.Issuer, identity.Subject).
+		Return(nil, repositories.ErrUserIdentityRepositoryUserIdentityNotFound)
+	userRepository.On("FindByEmail", user.Email).Return(user, nil)
+
+	session, err := service.Finish("signed-state", "state", "code")
+
+	assert.ErrorIs(t, err, ErrOIDCServiceAccountNotVerified)
+	assert.Nil(t, session)
+	userIdentityRepository.AssertNotCalled(t, "Create", mock.Anything)
+	tokenGenerator.AssertNotCalled(t, "GenerateToken", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,83 @@
+package services
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"time"
+)
+
+// OutboxReport tells how many events wait to be delivered and which ones
+// ran out of attempts.
+type OutboxReport struct {
+	Pending       int64
+	Dead          int64
+	OldestPending *entities.OutboxEvent
+	DeadEvents    []*entities.OutboxEvent
+}
+
+type OutboxService struct {
+	outboxRepository repositories.OutboxRepository
+}
+
+func NewOutboxService(outboxRepository repositories.OutboxRepository) *OutboxService {
+	return &OutboxService{
+		outboxRepository,
+	}
+}
+
+// GetReport lists up to deadLimit dead events, oldest first.
+func (s *OutboxService) GetReport(deadLimit int) (*OutboxReport, error) {
+	pending, err := s.outboxRepository.CountByStatus(entities.OutboxEventStatusPending)
+	if err != nil {
+		return nil, err
+	}
+
+	dead, err := s.outboxRepository.CountByStatus(entities.OutboxEventStatusDead)
+	if err != nil {
+		return nil, err
+	}
+
+	report := &OutboxReport{
+		Pending:    pending,
+		Dead:       dead,
+		DeadEvents: make([]*entities.OutboxEvent, 0),
+	}
+
+	if pending > 0 {
+		oldestPending, err := s.outboxRepository.GetByStatus(entities.OutboxEventStatusPending, 1)
+		if err != nil {
+			return nil, err
+		}
+
+		if len(oldestPending) > 0 {
+			report.OldestPending = oldestPending[0]
+		}
+	}
+
+	if dead > 0 {
+		report.DeadEvents, err = s.outboxRepository.GetByStatus(entities.OutboxEventStatusDead, deadLimit)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return report, nil
+}
+
+// RequeueDead gives the dead events a new set of attempts and returns how
+// many were requeued.
+func (s *OutboxService) RequeueDead() (int64, error) {
+	return s.outboxRepository.RequeueDead(time.Now())
+}
+
+// publishInTransaction writes the event to the outbox of the transaction, so
+// it is only delivered when the transaction commits.
+func publishInTransaction(tx repositories.Transaction, event services.Event) error {
+	outboxEvent, err := entities.NewOutboxEvent(services.GetEventType(event), event)
+	if err != nil {
+		return err
+	}
+
+	return tx.OutboxRepository().Create(outboxEvent)
+}
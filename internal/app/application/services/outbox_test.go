@@ -0,0 +1,102 @@
+package services
+
+import (
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestOutboxServiceGetReport(t *testing.T) {
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	outboxService := NewOutboxService(outboxRepository)
+
+	oldestPending := &entities.OutboxEvent{ID: uuid.NewString(), Status: entities.OutboxEventStatusPending}
+	deadEvents := []*entities.OutboxEvent{{ID: uuid.NewString(), Status: entities.OutboxEventStatusDead}}
+
+	outboxRepository.On("CountByStatus", entities.OutboxEventStatusPending).
+		Return(int64(4), nil)
+	outboxRepository.On("CountByStatus", entities.OutboxEventStatusDead).
+		Return(int64(1), nil)
+	outboxRepository.On("GetByStatus", entities.OutboxEventStatusPending, 1).
+		Return([]*entities.OutboxEvent{oldestPending}, nil)
+	outboxRepository.On("GetByStatus", entities.OutboxEventStatusDead, 20).
+		Return(deadEvents, nil)
+
+	report, err := outboxService.GetReport(20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &OutboxReport{
+		Pending:       4,
+		Dead:          1,
+		OldestPending: oldestPending,
+		DeadEvents:    deadEvents,
+	}, report)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestOutboxServiceGetReportWhenThereIsNothingPending(t *testing.T) {
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	outboxService := NewOutboxService(outboxRepository)
+
+	outboxRepository.On("CountByStatus", entities.OutboxEventStatusPending).
+		Return(int64(0), nil)
+	outboxRepository.On("CountByStatus", entities.OutboxEventStatusDead).
+		Return(int64(0), nil)
+
+	report, err := outboxService.GetReport(20)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &OutboxReport{DeadEvents: make([]*entities.OutboxEvent, 0)}, report)
+	outboxRepository.AssertExpectations(t)
+	outboxRepository.AssertNotCalled(t, "GetByStatus", mock.Anything, mock.Anything)
+}
+
+func TestOutboxServiceGetReportErrorCanNotCount(t *testing.T) {
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	outboxService := NewOutboxService(outboxRepository)
+
+	outboxRepository.On("CountByStatus", entities.OutboxEventStatusPending).
+		Return(int64(0), repositories.ErrOutboxRepositoryCanNotCountEvents)
+
+	report, err := outboxService.GetReport(20)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotCountEvents)
+	assert.Nil(t, report)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestOutboxServiceGetReportErrorCanNotGetDeadEvents(t *testing.T) {
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	outboxService := NewOutboxService(outboxRepository)
+
+	outboxRepository.On("CountByStatus", entities.OutboxEventStatusPending).
+		Return(int64(0), nil)
+	outboxRepository.On("CountByStatus", entities.OutboxEventStatusDead).
+		Return(int64(2), nil)
+	outboxRepository.On("GetByStatus", entities.OutboxEventStatusDead, 20).
+		Return(nil, repositories.ErrOutboxRepositoryCanNotGetEvents)
+
+	report, err := outboxService.GetReport(20)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotGetEvents)
+	assert.Nil(t, report)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestOutboxServiceRequeueDead(t *testing.T) {
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	outboxService := NewOutboxService(outboxRepository)
+
+	outboxRepository.On("RequeueDead", mock.AnythingOfType("time.Time")).
+		Return(int64(3), nil)
+
+	requeued, err := outboxService.RequeueDead()
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), requeued)
+	outboxRepository.AssertExpectations(t)
+}
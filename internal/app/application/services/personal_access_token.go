@@ -0,0 +1,65 @@
+package services
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"time"
+)
+
+var (
+	ErrPersonalAccessTokenServiceTokenNotFound = errors.New("personal access token not found")
+)
+
+type PersonalAccessTokenService struct {
+	personalAccessTokenRepository repositories.PersonalAccessTokenRepository
+}
+
+func NewPersonalAccessTokenService(
+	personalAccessTokenRepository repositories.PersonalAccessTokenRepository,
+) *PersonalAccessTokenService {
+	return &PersonalAccessTokenService{
+		personalAccessTokenRepository,
+	}
+}
+
+// Create returns the token and its plain value, the value can not be
+// recovered later because only its hash is stored.
+func (s *PersonalAccessTokenService) Create(
+	userID string,
+	name string,
+	scope string,
+	expiresAt *time.Time,
+) (*entities.PersonalAccessToken, string, error) {
+	token, value, err := entities.NewPersonalAccessToken(userID, name, scope, expiresAt)
+	if err != nil {
+		return nil, "", err
+	}
+
+	err = s.personalAccessTokenRepository.Create(token)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return token, value, nil
+}
+
+func (s *PersonalAccessTokenService) GetAll(userID string) ([]*entities.PersonalAccessToken, error) {
+	return s.personalAccessTokenRepository.GetByUserID(userID)
+}
+
+func (s *PersonalAccessTokenService) Revoke(id string, userID string) error {
+	token, err := s.personalAccessTokenRepository.GetByID(id)
+	if errors.Is(err, repositories.ErrPersonalAccessTokenRepositoryPersonalAccessTokenNotFound) {
+		return ErrPersonalAccessTokenServiceTokenNotFound
+	}
+	if err != nil {
+		return err
+	}
+
+	if token.UserID != userID || token.IsRevoked() {
+		return ErrPersonalAccessTokenServiceTokenNotFound
+	}
+
+	return s.personalAccessTokenRepository.Revoke(token.ID)
+}
@@ -0,0 +1,137 @@
+package services
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestPersonalAccessTokenServiceCreate(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	userID := uuid.NewString()
+	expiresAt := time.Now().Add(30 * 24 * time.Hour)
+
+	personalAccessTokenRepository.On("Create", mock.AnythingOfType("*entities.PersonalAccessToken")).Return(nil)
+
+	token, value, err := personalAccessTokenService.Create(userID, "home assistant", entities.PersonalAccessTokenScopeRead, &expiresAt)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.True(t, strings.HasPrefix(value, entities.PersonalAccessTokenPrefix))
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, entities.HashPersonalAccessToken(value), token.TokenHash)
+	personalAccessTokenRepository.AssertExpectations(t)
+}
+
+func TestPersonalAccessTokenServiceCreateErrorInvalidScope(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	token, value, err := personalAccessTokenService.Create(uuid.NewString(), "home assistant", "admin", nil)
+
+	assert.ErrorIs(t, err, entities.ErrPersonalAccessTokenScopeIsInvalid)
+	assert.Nil(t, token)
+	assert.Empty(t, value)
+	personalAccessTokenRepository.AssertNotCalled(t, "Create")
+}
+
+func TestPersonalAccessTokenServiceCreateErrorRepository(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	personalAccessTokenRepository.On("Create", mock.AnythingOfType("*entities.PersonalAccessToken")).
+		Return(repositories.ErrPersonalAccessTokenRepositoryCanNotCreatePersonalAccessToken)
+
+	token, value, err := personalAccessTokenService.Create(uuid.NewString(), "home assistant", entities.PersonalAccessTokenScopeRead, nil)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryCanNotCreatePersonalAccessToken)
+	assert.Nil(t, token)
+	assert.Empty(t, value)
+	personalAccessTokenRepository.AssertExpectations(t)
+}
+
+func TestPersonalAccessTokenServiceGetAll(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	userID := uuid.NewString()
+	tokens := []*entities.PersonalAccessToken{{ID: uuid.NewString(), UserID: userID}}
+
+	personalAccessTokenRepository.On("GetByUserID", userID).Return(tokens, nil)
+
+	result, err := personalAccessTokenService.GetAll(userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, tokens, result)
+	personalAccessTokenRepository.AssertExpectations(t)
+}
+
+func TestPersonalAccessTokenServiceRevoke(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	userID := uuid.NewString()
+	token := &entities.PersonalAccessToken{ID: uuid.NewString(), UserID: userID}
+
+	personalAccessTokenRepository.On("GetByID", token.ID).Return(token, nil)
+	personalAccessTokenRepository.On("Revoke", token.ID).Return(nil)
+
+	err := personalAccessTokenService.Revoke(token.ID, userID)
+
+	assert.NoError(t, err)
+	personalAccessTokenRepository.AssertExpectations(t)
+}
+
+func TestPersonalAccessTokenServiceRevokeErrorTokenOfAnotherUser(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	token := &entities.PersonalAccessToken{ID: uuid.NewString(), UserID: uuid.NewString()}
+
+	personalAccessTokenRepository.On("GetByID", token.ID).Return(token, nil)
+
+	err := personalAccessTokenService.Revoke(token.ID, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrPersonalAccessTokenServiceTokenNotFound)
+	personalAccessTokenRepository.AssertNotCalled(t, "Revoke", token.ID)
+}
+
+func TestPersonalAccessTokenServiceRevokeErrorNotFound(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	id := uuid.NewString()
+
+	personalAccessTokenRepository.On("GetByID", id).
+		Return(nil, repositories.ErrPersonalAccessTokenRepositoryPersonalAccessTokenNotFound)
+
+	err := personalAccessTokenService.Revoke(id, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrPersonalAccessTokenServiceTokenNotFound)
+	personalAccessTokenRepository.AssertExpectations(t)
+}
+
+func TestPersonalAccessTokenServiceRevokeError(t *testing.T) {
+	personalAccessTokenRepository := new(stub.PersonalAccessTokenRepositoryMock)
+	personalAccessTokenService := NewPersonalAccessTokenService(personalAccessTokenRepository)
+
+	id := uuid.NewString()
+
+	personalAccessTokenRepository.On("GetByID", id).
+		Return(nil, errors.New("repository error"))
+
+	err := personalAccessTokenService.Revoke(id, uuid.NewString())
+
+	assert.Error(t, err)
+	assert.NotErrorIs(t, err, ErrPersonalAccessTokenServiceTokenNotFound)
+	personalAccessTokenRepository.AssertExpectations(t)
+}
@@ -4,34 +4,63 @@ import (
 	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 )
 
 var (
 	ErrRoomServiceCanNotDeleteRoomWithBoxes = errors.New("can not delete room with boxes")
+	ErrRoomServiceRoomNotFound              = errors.New("room not found")
+	ErrRoomServiceAssetNotFound             = errors.New("asset not found")
 )
 
 type RoomService struct {
-	roomRepository repositories.RoomRepository
-	boxRepository  repositories.BoxRepository
+	roomRepository     repositories.RoomRepository
+	boxRepository      repositories.BoxRepository
+	assetService       AssetServiceInterface
+	householdService   HouseholdServiceInterface
+	transactionManager repositories.TransactionManager
 }
 
 func NewRoomService(
 	roomRepository repositories.RoomRepository,
 	boxRepository repositories.BoxRepository,
+	assetService AssetServiceInterface,
+	householdService HouseholdServiceInterface,
+	transactionManager repositories.TransactionManager,
 ) *RoomService {
 	return &RoomService{
 		roomRepository,
 		boxRepository,
+		assetService,
+		householdService,
+		transactionManager,
 	}
 }
 
-func (s *RoomService) Create(name string, description *string, userID string) (*entities.Room, error) {
-	room, err := entities.NewRoom(name, description, userID)
+func (s *RoomService) Create(
+	name string,
+	description *string,
+	householdID string,
+	userID string,
+) (*entities.Room, error) {
+	householdID, err := s.householdService.ResolveHouseholdID(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	room, err := entities.NewRoom(name, description, householdID)
 	if err != nil {
 		return nil, err
 	}
 
-	err = s.roomRepository.Create(room)
+	err = s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		err := tx.RoomRepository().Create(room)
+		if err != nil {
+			return err
+		}
+
+		return publishInTransaction(tx, services.RoomCreatedEvent{Room: *room})
+	})
 	if err != nil {
 		return nil, err
 	}
@@ -41,10 +70,17 @@ func (s *RoomService) Create(name string, description *string, userID string) (*
 
 func (s *RoomService) GetAll(
 	search string,
+	householdID string,
 	userID string,
 	pageFilter PageFilter,
-) ([]*entities.Room, error) {
-	queryFilter := s.makeGetAllQueryFilter(search, userID)
+) ([]struct {
+	Room   *entities.Room
+	Assets []*entities.Asset
+}, error) {
+	queryFilter, err := s.makeGetAllQueryFilter(search, householdID, userID)
+	if err != nil {
+		return nil, err
+	}
 
 	rooms, err := s.roomRepository.GetByQueryFilters(*queryFilter, &repositories.PageFilter{
 		Offset: (pageFilter.Page - 1) * pageFilter.Size,
@@ -54,14 +90,46 @@ func (s *RoomService) GetAll(
 		return nil, err
 	}
 
-	return rooms, nil
+	var entitySlice []entities.Entity
+	for i := range rooms {
+		entitySlice = append(entitySlice, rooms[i])
+	}
+	assets, err := s.assetService.GetByEntities(entitySlice)
+	if err != nil {
+		return nil, err
+	}
+
+	assetsByID := make(map[string][]*entities.Asset)
+	for i := range assets {
+		assetsByID[assets[i].EntityID] = append(assetsByID[assets[i].EntityID], assets[i])
+	}
+
+	output := make([]struct {
+		Room   *entities.Room
+		Assets []*entities.Asset
+	}, 0)
+	for i := range rooms {
+		output = append(output, struct {
+			Room   *entities.Room
+			Assets []*entities.Asset
+		}{
+			Room:   rooms[i],
+			Assets: assetsByID[rooms[i].EntityID()],
+		})
+	}
+
+	return output, nil
 }
 
 func (s *RoomService) CountAll(
 	search string,
+	householdID string,
 	userID string,
 ) (int64, error) {
-	queryFilter := s.makeGetAllQueryFilter(search, userID)
+	queryFilter, err := s.makeGetAllQueryFilter(search, householdID, userID)
+	if err != nil {
+		return 0, err
+	}
 
 	count, err := s.roomRepository.CountByQueryFilters(*queryFilter)
 	if err != nil {
@@ -73,17 +141,23 @@ func (s *RoomService) CountAll(
 
 func (s *RoomService) makeGetAllQueryFilter(
 	search string,
+	householdID string,
 	userID string,
-) *repositories.QueryFilter {
+) (*repositories.QueryFilter, error) {
+	householdIDs, err := s.householdService.GetVisibleHouseholdIDs(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
 	queryFilter := &repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
 			{
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    entities.RoomUserIDField,
-						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Field:    entities.RoomHouseholdIDField,
+						Operator: repositories.InComparisonOperator,
+						Value:    householdIDs,
 					},
 				},
 			},
@@ -112,10 +186,15 @@ func (s *RoomService) makeGetAllQueryFilter(
 		)
 	}
 
-	return queryFilter
+	return queryFilter, nil
 }
 
-func (s *RoomService) Delete(roomID string) error {
+func (s *RoomService) Delete(roomID string, userID string) error {
+	room, err := s.getRoom(roomID, userID, s.householdService.CheckCanEdit)
+	if err != nil {
+		return err
+	}
+
 	totalBoxes, err := s.boxRepository.CountByQueryFilters(repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
 			{
@@ -138,20 +217,45 @@ func (s *RoomService) Delete(roomID string) error {
 		return ErrRoomServiceCanNotDeleteRoomWithBoxes
 	}
 
-	err = s.roomRepository.Delete(roomID)
+	err = s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		err := tx.RoomRepository().Delete(roomID)
+		if err != nil {
+			return err
+		}
+
+		return publishInTransaction(tx, services.RoomDeletedEvent{Room: *room})
+	})
+	if err != nil {
+		return err
+	}
+
+	assets, err := s.assetService.GetByEntity(&entities.Room{ID: roomID}, nil)
 	if err != nil {
 		return err
 	}
 
+	for _, asset := range assets {
+		err = s.assetService.Delete(asset)
+		if err != nil {
+			return err
+		}
+	}
+
 	return nil
 }
 
+// Get returns a room of a household of the user.
+func (s *RoomService) Get(roomID string, userID string) (*entities.Room, error) {
+	return s.getRoom(roomID, userID, s.householdService.CheckCanView)
+}
+
 func (s *RoomService) Update(
 	roomID string,
+	userID string,
 	name string,
 	description *string,
 ) (*entities.Room, error) {
-	room, err := s.roomRepository.GetByID(roomID)
+	room, err := s.getRoom(roomID, userID, s.householdService.CheckCanEdit)
 	if err != nil {
 		return nil, err
 	}
@@ -161,7 +265,99 @@ func (s *RoomService) Update(
 		return nil, err
 	}
 
-	err = s.roomRepository.Update(room)
+	err = s.transactionManager.Transaction(func(tx repositories.Transaction) error {
+		err := tx.RoomRepository().Update(room)
+		if err != nil {
+			return err
+		}
+
+		return publishInTransaction(tx, services.RoomUpdatedEvent{Room: *room})
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return room, nil
+}
+
+func (s *RoomService) CreateAsset(
+	roomID string,
+	userID string,
+	file *FileUpload,
+) (*entities.Asset, error) {
+	room, err := s.getRoom(roomID, userID, s.householdService.CheckCanEdit)
+	if err != nil {
+		return nil, err
+	}
+
+	asset, err := s.assetService.CreateFromFile(file, room)
+	if err != nil {
+		return nil, err
+	}
+
+	return asset, nil
+}
+
+func (s *RoomService) GetAssets(
+	roomID string,
+	userID string,
+) ([]*entities.Asset, error) {
+	room, err := s.getRoom(roomID, userID, s.householdService.CheckCanView)
+	if err != nil {
+		return nil, err
+	}
+
+	assets, err := s.assetService.GetByEntity(room, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	return assets, nil
+}
+
+func (s *RoomService) DeleteAsset(
+	roomID string,
+	userID string,
+	assetID string,
+) error {
+	room, err := s.getRoom(roomID, userID, s.householdService.CheckCanEdit)
+	if err != nil {
+		return err
+	}
+
+	asset, err := s.assetService.GetByID(assetID)
+	if err != nil {
+		return err
+	}
+
+	if asset.EntityID != room.EntityID() || asset.EntityName != room.EntityName() {
+		return ErrRoomServiceAssetNotFound
+	}
+
+	err = s.assetService.Delete(asset)
+	if err != nil {
+		return err
+	}
+
+	return nil
+}
+
+// getRoom returns the room when check allows the user to act on its
+// household. Rooms of households the user is not a member of are not found.
+func (s *RoomService) getRoom(
+	roomID string,
+	userID string,
+	check func(householdID string, userID string) error,
+) (*entities.Room, error) {
+	room, err := s.roomRepository.GetByID(roomID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = check(room.HouseholdID, userID)
+	if errors.Is(err, ErrHouseholdServiceHouseholdNotFound) {
+		return nil, ErrRoomServiceRoomNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -1,9 +1,11 @@
 package services
 
 import (
+	"bytes"
 	"errors"
 	"github.com/google/uuid"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
 	"github.com/labstack/gommon/random"
 	"github.com/stretchr/testify/assert"
@@ -14,53 +16,114 @@ import (
 func TestRoomServiceCreateRoom(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	userID := uuid.NewString()
-
+	householdID := uuid.NewString()
+
+	householdService.On("ResolveHouseholdID", "", userID).Return(householdID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("RoomRepository").
+		Return(roomRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
 	roomRepository.On("Create", mock.AnythingOfType("*entities.Room")).
 		Return(nil)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "RoomCreatedEvent"
+	})).
+		Return(nil)
 
-	room, err := roomService.Create(name, &description, userID)
+	room, err := roomService.Create(name, &description, "", userID)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, room)
 	assert.Equal(t, name, room.Name)
 	assert.Equal(t, description, *room.Description)
-	assert.Equal(t, userID, room.UserID)
+	assert.Equal(t, householdID, room.HouseholdID)
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceCreateRoomErrorNotAllowed(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	householdService.On("ResolveHouseholdID", householdID, userID).Return("", ErrHouseholdServiceNotAllowed)
+
+	room, err := roomService.Create(random.String(100, random.Alphanumeric), nil, householdID, userID)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceNotAllowed)
+	assert.Nil(t, room)
+	roomRepository.AssertNotCalled(t, "Create", mock.Anything)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceCreateRoomErrorInRepository(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	name := random.String(100, random.Alphanumeric)
 	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	mockError := errors.New("repository error")
+	householdService.On("ResolveHouseholdID", householdID, userID).Return(householdID, nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("RoomRepository").
+		Return(roomRepository)
 	roomRepository.On("Create", mock.AnythingOfType("*entities.Room")).Return(mockError)
 
-	room, err := roomService.Create(name, nil, userID)
+	room, err := roomService.Create(name, nil, householdID, userID)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
 	assert.EqualError(t, err, mockError.Error())
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceGetAll(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	search := random.String(100, random.Alphanumeric)
 	userID := uuid.NewString()
+	householdIDs := []string{uuid.NewString(), uuid.NewString()}
 	pageFilter := PageFilter{
 		Page: 1,
 		Size: 10,
@@ -71,25 +134,71 @@ func TestRoomServiceGetAll(t *testing.T) {
 			ID:          uuid.NewString(),
 			Name:        random.String(100, random.Alphanumeric),
 			Description: nil,
-			UserID:      userID,
+			HouseholdID: householdIDs[0],
 		},
 	}
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return(householdIDs, nil)
 	roomRepository.On("GetByQueryFilters", mock.AnythingOfType("repositories.QueryFilter"), mock.AnythingOfType("*repositories.PageFilter")).
 		Return(rooms, nil)
+	assets := []*entities.Asset{
+		{
+			ID:         uuid.NewString(),
+			Extension:  ".png",
+			FileID:     uuid.NewString(),
+			EntityID:   rooms[0].ID,
+			EntityName: "room",
+		},
+	}
+	assetService.On("GetByEntities", mock.AnythingOfType("[]entities.Entity")).
+		Return(assets, nil)
 
-	result, err := roomService.GetAll(search, userID, pageFilter)
+	result, err := roomService.GetAll(search, "", userID, pageFilter)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, result)
-	assert.Equal(t, rooms, result)
+	assert.Len(t, result, 1)
+	assert.Equal(t, rooms[0], result[0].Room)
+	assert.Equal(t, assets, result[0].Assets)
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+
+	queryFilter := roomRepository.Calls[0].Arguments.Get(0).(repositories.QueryFilter)
+	householdCondition := queryFilter.ConditionGroups[0].Conditions[0]
+	assert.Equal(t, entities.RoomHouseholdIDField, householdCondition.Field)
+	assert.Equal(t, repositories.InComparisonOperator, householdCondition.Operator)
+	assert.Equal(t, householdIDs, householdCondition.Value)
+}
+
+func TestRoomServiceGetAllErrorHouseholdNotFound(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	householdService.On("GetVisibleHouseholdIDs", householdID, userID).
+		Return(nil, ErrHouseholdServiceHouseholdNotFound)
+
+	result, err := roomService.GetAll("", householdID, userID, PageFilter{Page: 1, Size: 10})
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceHouseholdNotFound)
+	assert.Nil(t, result)
+	roomRepository.AssertNotCalled(t, "GetByQueryFilters", mock.Anything, mock.Anything)
 }
 
 func TestRoomServiceGetAllErrorInRepository(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	search := random.String(100, random.Alphanumeric)
 	userID := uuid.NewString()
@@ -99,123 +208,267 @@ func TestRoomServiceGetAllErrorInRepository(t *testing.T) {
 	}
 
 	mockError := errors.New("repository error")
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{uuid.NewString()}, nil)
 	roomRepository.On(
 		"GetByQueryFilters",
 		mock.AnythingOfType("repositories.QueryFilter"),
 		mock.AnythingOfType("*repositories.PageFilter"),
 	).Return(nil, mockError)
 
-	result, err := roomService.GetAll(search, userID, pageFilter)
+	result, err := roomService.GetAll(search, "", userID, pageFilter)
 
 	assert.Error(t, err)
 	assert.Nil(t, result)
 	assert.EqualError(t, err, mockError.Error())
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceCountAll(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	search := random.String(100, random.Alphanumeric)
 	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	count := int64(10)
+	householdService.On("GetVisibleHouseholdIDs", householdID, userID).Return([]string{householdID}, nil)
 	roomRepository.On("CountByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).Return(count, nil)
 
-	result, err := roomService.CountAll(search, userID)
+	result, err := roomService.CountAll(search, householdID, userID)
 
 	assert.NoError(t, err)
 	assert.Equal(t, count, result)
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceCountAllErrorInRepository(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	search := random.String(100, random.Alphanumeric)
 	userID := uuid.NewString()
 
 	mockError := errors.New("repository error")
+	householdService.On("GetVisibleHouseholdIDs", "", userID).Return([]string{uuid.NewString()}, nil)
 	roomRepository.On("CountByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).Return(int64(0), mockError)
 
-	result, err := roomService.CountAll(search, userID)
+	result, err := roomService.CountAll(search, "", userID)
 
 	assert.Error(t, err)
 	assert.Equal(t, int64(0), result)
 	assert.EqualError(t, err, mockError.Error())
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceDelete(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
-	roomID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
 	boxRepository.On("CountByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).
 		Return(int64(0), nil)
-	roomRepository.On("Delete", roomID).
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("RoomRepository").
+		Return(roomRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
+	roomRepository.On("Delete", room.ID).
+		Return(nil)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "RoomDeletedEvent"
+	})).
+		Return(nil)
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   room.ID,
+		EntityName: "room",
+	}
+	var pageFilter *PageFilter
+	assetService.On("GetByEntity", &entities.Room{ID: room.ID}, pageFilter).
+		Return([]*entities.Asset{asset}, nil)
+	assetService.On("Delete", asset).
 		Return(nil)
 
-	err := roomService.Delete(roomID)
+	err := roomService.Delete(room.ID, userID)
 
 	assert.NoError(t, err)
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceDeleteErrorNotAllowed(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(ErrHouseholdServiceNotAllowed)
+
+	err := roomService.Delete(room.ID, userID)
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceNotAllowed)
+	roomRepository.AssertNotCalled(t, "Delete", mock.Anything)
+	boxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceDeleteErrorInRepository(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
-	roomID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
 	mockError := errors.New("repository error")
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
 	boxRepository.On("CountByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).
 		Return(int64(0), nil)
-	roomRepository.On("Delete", roomID).Return(mockError)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("RoomRepository").
+		Return(roomRepository)
+	roomRepository.On("Delete", room.ID).Return(mockError)
 
-	err := roomService.Delete(roomID)
+	err := roomService.Delete(room.ID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, mockError.Error())
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceDeleteErrorCanNotDeleteRoomWithBoxes(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
-	roomID := uuid.NewString()
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
 
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
 	boxRepository.On("CountByQueryFilters", mock.AnythingOfType("repositories.QueryFilter")).
 		Return(int64(1), nil)
 
-	err := roomService.Delete(roomID)
+	err := roomService.Delete(room.ID, userID)
 
 	assert.Error(t, err)
 	assert.EqualError(t, err, "can not delete room with boxes")
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceGet(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(nil)
+
+	result, err := roomService.Get(room.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, room, result)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceGetErrorRoomNotFound(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	result, err := roomService.Get(room.ID, userID)
+
+	assert.ErrorIs(t, err, ErrRoomServiceRoomNotFound)
+	assert.Nil(t, result)
+	roomRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceUpdate(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	outboxRepository := new(stub.OutboxRepositoryMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	roomID := uuid.NewString()
+	userID := uuid.NewString()
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 
@@ -223,12 +476,24 @@ func TestRoomServiceUpdate(t *testing.T) {
 		ID:          roomID,
 		Name:        name,
 		Description: &description,
+		HouseholdID: uuid.NewString(),
 	}
 
 	roomRepository.On("GetByID", roomID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("RoomRepository").
+		Return(roomRepository)
+	transaction.On("OutboxRepository").
+		Return(outboxRepository)
 	roomRepository.On("Update", room).Return(nil)
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "RoomUpdatedEvent"
+	})).
+		Return(nil)
 
-	room, err := roomService.Update(roomID, name, &description)
+	room, err := roomService.Update(roomID, userID, name, &description)
 
 	assert.NoError(t, err)
 	assert.NotNil(t, room)
@@ -236,12 +501,20 @@ func TestRoomServiceUpdate(t *testing.T) {
 	assert.Equal(t, description, *room.Description)
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	outboxRepository.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceUpdateErrorInRepositoryOnGetByID(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	roomID := uuid.NewString()
 	name := random.String(100, random.Alphanumeric)
@@ -250,21 +523,28 @@ func TestRoomServiceUpdateErrorInRepositoryOnGetByID(t *testing.T) {
 	mockError := errors.New("repository error")
 	roomRepository.On("GetByID", roomID).Return(nil, mockError)
 
-	room, err := roomService.Update(roomID, name, &description)
+	room, err := roomService.Update(roomID, uuid.NewString(), name, &description)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
 	assert.EqualError(t, err, mockError.Error())
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
 
 func TestRoomServiceUpdateErrorInRepositoryOnUpdate(t *testing.T) {
 	roomRepository := new(stub.RoomRepositoryMock)
 	boxRepository := new(stub.BoxRepositoryMock)
-	roomService := NewRoomService(roomRepository, boxRepository)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	transaction := new(stub.TransactionMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 
 	roomID := uuid.NewString()
+	userID := uuid.NewString()
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 
@@ -273,16 +553,196 @@ func TestRoomServiceUpdateErrorInRepositoryOnUpdate(t *testing.T) {
 		ID:          roomID,
 		Name:        name,
 		Description: &description,
+		HouseholdID: uuid.NewString(),
 	}
 
 	roomRepository.On("GetByID", roomID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	transactionManager.On("Transaction").
+		Return(transaction, nil)
+	transaction.On("RoomRepository").
+		Return(roomRepository)
 	roomRepository.On("Update", room).Return(mockError)
 
-	room, err := roomService.Update(roomID, name, &description)
+	room, err := roomService.Update(roomID, userID, name, &description)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
 	assert.EqualError(t, err, mockError.Error())
 	roomRepository.AssertExpectations(t)
 	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	transactionManager.AssertExpectations(t)
+	transaction.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceCreateAsset(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		Name:        random.String(100, random.Alphanumeric),
+		HouseholdID: uuid.NewString(),
+	}
+	file := &FileUpload{Name: "photo.jpg", Content: bytes.NewReader(nil)}
+
+	expectedAsset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   room.ID,
+		EntityName: "room",
+	}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	assetService.On("CreateFromFile", file, room).Return(expectedAsset, nil)
+
+	asset, err := roomService.CreateAsset(room.ID, userID, file)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAsset, asset)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceCreateAssetErrorRoomNotFound(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		Name:        random.String(100, random.Alphanumeric),
+		HouseholdID: uuid.NewString(),
+	}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	asset, err := roomService.CreateAsset(room.ID, userID, nil)
+
+	assert.Error(t, err)
+	assert.Nil(t, asset)
+	assert.ErrorIs(t, err, ErrRoomServiceRoomNotFound)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceGetAssets(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		Name:        random.String(100, random.Alphanumeric),
+		HouseholdID: uuid.NewString(),
+	}
+	expectedAssets := []*entities.Asset{
+		{
+			ID:         uuid.NewString(),
+			EntityID:   room.ID,
+			EntityName: "room",
+		},
+	}
+	var pageFilter *PageFilter
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanView", room.HouseholdID, userID).Return(nil)
+	assetService.On("GetByEntity", room, pageFilter).Return(expectedAssets, nil)
+
+	assets, err := roomService.GetAssets(room.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAssets, assets)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceDeleteAsset(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		Name:        random.String(100, random.Alphanumeric),
+		HouseholdID: uuid.NewString(),
+	}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   room.ID,
+		EntityName: "room",
+	}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	assetService.On("GetByID", asset.ID).Return(asset, nil)
+	assetService.On("Delete", asset).Return(nil)
+
+	err := roomService.DeleteAsset(room.ID, userID, asset.ID)
+
+	assert.NoError(t, err)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestRoomServiceDeleteAssetErrorAssetNotFound(t *testing.T) {
+	roomRepository := new(stub.RoomRepositoryMock)
+	boxRepository := new(stub.BoxRepositoryMock)
+	assetService := new(AssetServiceMock)
+	householdService := new(HouseholdServiceMock)
+	transactionManager := new(stub.TransactionManagerMock)
+	roomService := NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
+
+	userID := uuid.NewString()
+	room := &entities.Room{
+		ID:          uuid.NewString(),
+		Name:        random.String(100, random.Alphanumeric),
+		HouseholdID: uuid.NewString(),
+	}
+	asset := &entities.Asset{
+		ID:         uuid.NewString(),
+		EntityID:   uuid.NewString(),
+		EntityName: "room",
+	}
+
+	roomRepository.On("GetByID", room.ID).Return(room, nil)
+	householdService.On("CheckCanEdit", room.HouseholdID, userID).Return(nil)
+	assetService.On("GetByID", asset.ID).Return(asset, nil)
+
+	err := roomService.DeleteAsset(room.ID, userID, asset.ID)
+
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, ErrRoomServiceAssetNotFound)
+	roomRepository.AssertExpectations(t)
+	boxRepository.AssertExpectations(t)
+	assetService.AssertExpectations(t)
+	householdService.AssertExpectations(t)
 }
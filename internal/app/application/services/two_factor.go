@@ -0,0 +1,240 @@
+package services
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+var (
+	ErrTwoFactorServiceAlreadyEnabled = errors.New("two factor authentication is already enabled")
+	ErrTwoFactorServiceNotEnrolled    = errors.New("two factor authentication was not started")
+	ErrTwoFactorServiceNotEnabled     = errors.New("two factor authentication is not enabled")
+	ErrTwoFactorServiceInvalidCode    = errors.New("invalid two factor code")
+)
+
+// twoFactorIssuer is the name authenticator apps show next to the codes.
+const twoFactorIssuer = "Home Inventory"
+
+// TwoFactorServiceInterface is used by the AuthService to ask for a second
+// factor after the password.
+type TwoFactorServiceInterface interface {
+	IsEnabled(userID string) (bool, error)
+	VerifyCode(userID string, code string) error
+}
+
+type TwoFactorService struct {
+	twoFactorRepository  repositories.TwoFactorRepository
+	userRepository       repositories.UserRepository
+	loginThrottleService LoginThrottleServiceInterface
+}
+
+func NewTwoFactorService(
+	twoFactorRepository repositories.TwoFactorRepository,
+	userRepository repositories.UserRepository,
+	loginThrottleService LoginThrottleServiceInterface,
+) *TwoFactorService {
+	return &TwoFactorService{
+		twoFactorRepository,
+		userRepository,
+		loginThrottleService,
+	}
+}
+
+// Enroll creates a new secret and returns it with its provisioning uri. A
+// secret that was never confirmed is replaced, so the enrolment can start
+// again with another device.
+func (s *TwoFactorService) Enroll(userID string) (string, string, error) {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return "", "", err
+	}
+
+	current, err := s.twoFactorRepository.GetByUserID(user.ID)
+	if err != nil && !errors.Is(err, repositories.ErrTwoFactorRepositoryTwoFactorNotFound) {
+		return "", "", err
+	}
+
+	if current != nil {
+		if current.IsConfirmed() {
+			return "", "", ErrTwoFactorServiceAlreadyEnabled
+		}
+
+		err = s.twoFactorRepository.DeleteByUserID(user.ID)
+		if err != nil {
+			return "", "", err
+		}
+	}
+
+	twoFactor, err := entities.NewTwoFactor(user.ID)
+	if err != nil {
+		return "", "", err
+	}
+
+	err = s.twoFactorRepository.Create(twoFactor)
+	if err != nil {
+		return "", "", err
+	}
+
+	return twoFactor.Secret, twoFactor.ProvisioningURI(twoFactorIssuer, user.Email), nil
+}
+
+// Confirm enables the two factor authentication with a first code and
+// returns the recovery codes, the only time they are shown.
+func (s *TwoFactorService) Confirm(userID string, code string) ([]string, error) {
+	twoFactor, err := s.twoFactorRepository.GetByUserID(userID)
+	if errors.Is(err, repositories.ErrTwoFactorRepositoryTwoFactorNotFound) {
+		return nil, ErrTwoFactorServiceNotEnrolled
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = twoFactor.Confirm(code, time.Now())
+	if errors.Is(err, entities.ErrTwoFactorAlreadyConfirmed) {
+		return nil, ErrTwoFactorServiceAlreadyEnabled
+	}
+	if errors.Is(err, entities.ErrTwoFactorInvalidCode) {
+		return nil, ErrTwoFactorServiceInvalidCode
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.twoFactorRepository.Update(twoFactor)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(userID)
+}
+
+// Disable asks for a valid code, so a stolen session is not enough to turn
+// the second factor off.
+func (s *TwoFactorService) Disable(userID string, code string, ip string) error {
+	err := s.verifyUserCode(userID, code, ip)
+	if err != nil {
+		return err
+	}
+
+	err = s.twoFactorRepository.DeleteRecoveryCodesByUserID(userID)
+	if err != nil {
+		return err
+	}
+
+	return s.twoFactorRepository.DeleteByUserID(userID)
+}
+
+// RegenerateRecoveryCodes replaces every recovery code of the user, the old
+// ones stop working.
+func (s *TwoFactorService) RegenerateRecoveryCodes(userID string, code string, ip string) ([]string, error) {
+	err := s.verifyUserCode(userID, code, ip)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.replaceRecoveryCodes(userID)
+}
+
+func (s *TwoFactorService) IsEnabled(userID string) (bool, error) {
+	twoFactor, err := s.twoFactorRepository.GetByUserID(userID)
+	if errors.Is(err, repositories.ErrTwoFactorRepositoryTwoFactorNotFound) {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	return twoFactor.IsConfirmed(), nil
+}
+
+// VerifyCode accepts a code of the authenticator app or an unused recovery
+// code. Each of them is accepted only once.
+func (s *TwoFactorService) VerifyCode(userID string, code string) error {
+	twoFactor, err := s.twoFactorRepository.GetByUserID(userID)
+	if errors.Is(err, repositories.ErrTwoFactorRepositoryTwoFactorNotFound) {
+		return ErrTwoFactorServiceNotEnabled
+	}
+	if err != nil {
+		return err
+	}
+
+	if !twoFactor.IsConfirmed() {
+		return ErrTwoFactorServiceNotEnabled
+	}
+
+	if step, ok := twoFactor.MatchCode(code, time.Now()); ok {
+		err = s.twoFactorRepository.UseStep(twoFactor.ID, step)
+		if errors.Is(err, repositories.ErrTwoFactorRepositoryCodeAlreadyUsed) {
+			return ErrTwoFactorServiceInvalidCode
+		}
+
+		return err
+	}
+
+	err = s.twoFactorRepository.UseRecoveryCode(userID, entities.HashRecoveryCode(code))
+	if errors.Is(err, repositories.ErrTwoFactorRepositoryRecoveryCodeNotFound) {
+		return ErrTwoFactorServiceInvalidCode
+	}
+
+	return err
+}
+
+// verifyUserCode is VerifyCode for the actions of a signed in user. Wrong codes
+// count as failed logins, so a stolen session can not be used to guess them.
+func (s *TwoFactorService) verifyUserCode(userID string, code string, ip string) error {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	err = s.loginThrottleService.Check(user.Email, ip)
+	if err != nil {
+		return err
+	}
+
+	err = s.VerifyCode(userID, code)
+	if errors.Is(err, ErrTwoFactorServiceInvalidCode) {
+		if err := s.loginThrottleService.RegisterFailure(user.Email, ip, user); err != nil {
+			logger.LogError(err)
+		}
+	}
+
+	return err
+}
+
+func (s *TwoFactorService) replaceRecoveryCodes(userID string) ([]string, error) {
+	codes, values, err := entities.NewRecoveryCodes(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.twoFactorRepository.DeleteRecoveryCodesByUserID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.twoFactorRepository.CreateRecoveryCodes(codes)
+	if err != nil {
+		return nil, err
+	}
+
+	return values, nil
+}
+
+type TwoFactorServiceMock struct {
+	mock.Mock
+}
+
+func (s *TwoFactorServiceMock) IsEnabled(userID string) (bool, error) {
+	args := s.Called(userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (s *TwoFactorServiceMock) VerifyCode(userID string, code string) error {
+	args := s.Called(userID, code)
+	return args.Error(0)
+}
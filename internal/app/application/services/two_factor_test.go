@@ -0,0 +1,384 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha1"
+	"encoding/base32"
+	"encoding/binary"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strings"
+	"testing"
+	"time"
+)
+
+// totpCode computes the current code of the secret as an authenticator app.
+func totpCode(secret string) (string, int64) {
+	key, _ := base32.StdEncoding.WithPadding(base32.NoPadding).DecodeString(secret)
+	step := time.Now().Unix() / 30
+
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+	mac := hmac.New(sha1.New, key)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	return fmt.Sprintf("%06d", value%1000000), step
+}
+
+func newConfirmedTwoFactor(userID string) *entities.TwoFactor {
+	confirmedAt := time.Now()
+	return &entities.TwoFactor{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		Secret:      "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP",
+		ConfirmedAt: &confirmedAt,
+	}
+}
+
+func TestTwoFactorServiceEnroll(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, new(LoginThrottleServiceMock))
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	twoFactorRepositoryMock.On("GetByUserID", user.ID).Return(nil, repositories.ErrTwoFactorRepositoryTwoFactorNotFound)
+	twoFactorRepositoryMock.On("Create", mock.AnythingOfType("*entities.TwoFactor")).Return(nil)
+
+	secret, uri, err := twoFactorService.Enroll(user.ID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, secret)
+	assert.True(t, strings.HasPrefix(uri, "otpauth://totp/Home%20Inventory:test@example.com?"))
+	assert.Contains(t, uri, "secret="+secret)
+	twoFactorRepositoryMock.AssertExpectations(t)
+	twoFactorRepositoryMock.AssertNotCalled(t, "DeleteByUserID", mock.Anything)
+
+	twoFactor := twoFactorRepositoryMock.Calls[1].Arguments.Get(0).(*entities.TwoFactor)
+	assert.Equal(t, user.ID, twoFactor.UserID)
+	assert.Equal(t, secret, twoFactor.Secret)
+	assert.False(t, twoFactor.IsConfirmed())
+}
+
+func TestTwoFactorServiceEnrollReplacesUnconfirmed(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, new(LoginThrottleServiceMock))
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	current := &entities.TwoFactor{ID: uuid.NewString(), UserID: user.ID, Secret: "JBSWY3DPEHPK3PXP"}
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	twoFactorRepositoryMock.On("GetByUserID", user.ID).Return(current, nil)
+	twoFactorRepositoryMock.On("DeleteByUserID", user.ID).Return(nil)
+	twoFactorRepositoryMock.On("Create", mock.AnythingOfType("*entities.TwoFactor")).Return(nil)
+
+	secret, _, err := twoFactorService.Enroll(user.ID)
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, current.Secret, secret)
+	twoFactorRepositoryMock.AssertExpectations(t)
+}
+
+func TestTwoFactorServiceEnrollErrorAlreadyEnabled(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, new(LoginThrottleServiceMock))
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	twoFactorRepositoryMock.On("GetByUserID", user.ID).Return(newConfirmedTwoFactor(user.ID), nil)
+
+	secret, uri, err := twoFactorService.Enroll(user.ID)
+
+	assert.ErrorIs(t, err, ErrTwoFactorServiceAlreadyEnabled)
+	assert.Empty(t, secret)
+	assert.Empty(t, uri)
+	twoFactorRepositoryMock.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestTwoFactorServiceConfirm(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+	userID := uuid.NewString()
+	twoFactor := &entities.TwoFactor{ID: uuid.NewString(), UserID: userID, Secret: "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"}
+	code, step := totpCode(twoFactor.Secret)
+
+	twoFactorRepositoryMock.On("GetByUserID", userID).Return(twoFactor, nil)
+	twoFactorRepositoryMock.On("Update", twoFactor).Return(nil)
+	twoFactorRepositoryMock.On("DeleteRecoveryCodesByUserID", userID).Return(nil)
+	twoFactorRepositoryMock.On("CreateRecoveryCodes", mock.AnythingOfType("[]*entities.RecoveryCode")).Return(nil)
+
+	recoveryCodes, err := twoFactorService.Confirm(userID, code)
+
+	assert.NoError(t, err)
+	assert.Len(t, recoveryCodes, 10)
+	assert.True(t, twoFactor.IsConfirmed())
+	assert.Equal(t, step, twoFactor.LastUsedStep)
+	twoFactorRepositoryMock.AssertExpectations(t)
+
+	codes := twoFactorRepositoryMock.Calls[3].Arguments.Get(0).([]*entities.RecoveryCode)
+	for i, code := range codes {
+		assert.Equal(t, entities.HashRecoveryCode(recoveryCodes[i]), code.CodeHash)
+	}
+}
+
+func TestTwoFactorServiceConfirmErrors(t *testing.T) {
+	userID := uuid.NewString()
+
+	testCases := map[string]struct {
+		twoFactor *entities.TwoFactor
+		err       error
+		expected  error
+	}{
+		"not enrolled":     {nil, repositories.ErrTwoFactorRepositoryTwoFactorNotFound, ErrTwoFactorServiceNotEnrolled},
+		"already enabled":  {newConfirmedTwoFactor(userID), nil, ErrTwoFactorServiceAlreadyEnabled},
+		"invalid code":     {&entities.TwoFactor{ID: uuid.NewString(), UserID: userID, Secret: "JBSWY3DPEHPK3PXP"}, nil, ErrTwoFactorServiceInvalidCode},
+		"repository error": {nil, repositories.ErrTwoFactorRepositoryCanNotGetTwoFactor, repositories.ErrTwoFactorRepositoryCanNotGetTwoFactor},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+			twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+			twoFactorRepositoryMock.On("GetByUserID", userID).Return(testCase.twoFactor, testCase.err)
+
+			recoveryCodes, err := twoFactorService.Confirm(userID, "abcdef")
+
+			assert.ErrorIs(t, err, testCase.expected)
+			assert.Nil(t, recoveryCodes)
+			twoFactorRepositoryMock.AssertNotCalled(t, "Update", mock.Anything)
+			twoFactorRepositoryMock.AssertNotCalled(t, "CreateRecoveryCodes", mock.Anything)
+		})
+	}
+}
+
+func TestTwoFactorServiceIsEnabled(t *testing.T) {
+	userID := uuid.NewString()
+
+	testCases := map[string]struct {
+		twoFactor *entities.TwoFactor
+		err       error
+		expected  bool
+	}{
+		"confirmed":     {newConfirmedTwoFactor(userID), nil, true},
+		"not confirmed": {&entities.TwoFactor{ID: uuid.NewString(), UserID: userID}, nil, false},
+		"not enrolled":  {nil, repositories.ErrTwoFactorRepositoryTwoFactorNotFound, false},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+			twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+			twoFactorRepositoryMock.On("GetByUserID", userID).Return(testCase.twoFactor, testCase.err)
+
+			enabled, err := twoFactorService.IsEnabled(userID)
+
+			assert.NoError(t, err)
+			assert.Equal(t, testCase.expected, enabled)
+		})
+	}
+}
+
+func TestTwoFactorServiceVerifyCodeWithAuthenticatorCode(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+	userID := uuid.NewString()
+	twoFactor := newConfirmedTwoFactor(userID)
+	code, step := totpCode(twoFactor.Secret)
+
+	twoFactorRepositoryMock.On("GetByUserID", userID).Return(twoFactor, nil)
+	twoFactorRepositoryMock.On("UseStep", twoFactor.ID, step).Return(nil)
+
+	err := twoFactorService.VerifyCode(userID, code)
+
+	assert.NoError(t, err)
+	twoFactorRepositoryMock.AssertExpectations(t)
+	twoFactorRepositoryMock.AssertNotCalled(t, "UseRecoveryCode", mock.Anything, mock.Anything)
+}
+
+func TestTwoFactorServiceVerifyCodeErrorAuthenticatorCodeAlreadyUsed(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+	userID := uuid.NewString()
+	twoFactor := newConfirmedTwoFactor(userID)
+	code, step := totpCode(twoFactor.Secret)
+
+	twoFactorRepositoryMock.On("GetByUserID", userID).Return(twoFactor, nil)
+	twoFactorRepositoryMock.On("UseStep", twoFactor.ID, step).Return(repositories.ErrTwoFactorRepositoryCodeAlreadyUsed)
+
+	err := twoFactorService.VerifyCode(userID, code)
+
+	assert.ErrorIs(t, err, ErrTwoFactorServiceInvalidCode)
+}
+
+func TestTwoFactorServiceVerifyCodeWithRecoveryCode(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+	userID := uuid.NewString()
+
+	twoFactorRepositoryMock.On("GetByUserID", userID).Return(newConfirmedTwoFactor(userID), nil)
+	twoFactorRepositoryMock.On("UseRecoveryCode", userID, entities.HashRecoveryCode("abcde-fghij")).Return(nil)
+
+	err := twoFactorService.VerifyCode(userID, "ABCDE-FGHIJ")
+
+	assert.NoError(t, err)
+	twoFactorRepositoryMock.AssertExpectations(t)
+}
+
+func TestTwoFactorServiceVerifyCodeErrorInvalidCode(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+	userID := uuid.NewString()
+
+	twoFactorRepositoryMock.On("GetByUserID", userID).Return(newConfirmedTwoFactor(userID), nil)
+	twoFactorRepositoryMock.On("UseRecoveryCode", userID, mock.AnythingOfType("string")).
+		Return(repositories.ErrTwoFactorRepositoryRecoveryCodeNotFound)
+
+	err := twoFactorService.VerifyCode(userID, "abcde-fghij")
+
+	assert.ErrorIs(t, err, ErrTwoFactorServiceInvalidCode)
+}
+
+func TestTwoFactorServiceVerifyCodeErrorNotEnabled(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, new(stub.UserRepositoryMock), new(LoginThrottleServiceMock))
+
+	userID := uuid.NewString()
+
+	twoFactorRepositoryMock.On("GetByUserID", userID).
+		Return(&entities.TwoFactor{ID: uuid.NewString(), UserID: userID, Secret: "JBSWY3DPEHPK3PXP"}, nil)
+
+	err := twoFactorService.VerifyCode(userID, "123456")
+
+	assert.ErrorIs(t, err, ErrTwoFactorServiceNotEnabled)
+	twoFactorRepositoryMock.AssertNotCalled(t, "UseRecoveryCode", mock.Anything, mock.Anything)
+}
+
+func TestTwoFactorServiceDisable(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, loginThrottleServiceMock)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, "127.0.0.1").Return(nil)
+	twoFactorRepositoryMock.On("GetByUserID", user.ID).Return(newConfirmedTwoFactor(user.ID), nil)
+	twoFactorRepositoryMock.On("UseRecoveryCode", user.ID, entities.HashRecoveryCode("abcde-fghij")).Return(nil)
+	twoFactorRepositoryMock.On("DeleteRecoveryCodesByUserID", user.ID).Return(nil)
+	twoFactorRepositoryMock.On("DeleteByUserID", user.ID).Return(nil)
+
+	err := twoFactorService.Disable(user.ID, "abcde-fghij", "127.0.0.1")
+
+	assert.NoError(t, err)
+	twoFactorRepositoryMock.AssertExpectations(t)
+	loginThrottleServiceMock.AssertExpectations(t)
+	loginThrottleServiceMock.AssertNotCalled(t, "RegisterFailure", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestTwoFactorServiceDisableErrorInvalidCode(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, loginThrottleServiceMock)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, "127.0.0.1").Return(nil)
+	loginThrottleServiceMock.On("RegisterFailure", user.Email, "127.0.0.1", user).Return(nil)
+	twoFactorRepositoryMock.On("GetByUserID", user.ID).Return(newConfirmedTwoFactor(user.ID), nil)
+	twoFactorRepositoryMock.On("UseRecoveryCode", user.ID, mock.AnythingOfType("string")).
+		Return(repositories.ErrTwoFactorRepositoryRecoveryCodeNotFound)
+
+	err := twoFactorService.Disable(user.ID, "abcde-fghij", "127.0.0.1")
+
+	assert.ErrorIs(t, err, ErrTwoFactorServiceInvalidCode)
+	loginThrottleServiceMock.AssertExpectations(t)
+	twoFactorRepositoryMock.AssertNotCalled(t, "DeleteByUserID", mock.Anything)
+}
+
+func TestTwoFactorServiceDisableErrorTooManyAttempts(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, loginThrottleServiceMock)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, "127.0.0.1").Return(ErrLoginThrottleServiceTooManyAttempts)
+
+	err := twoFactorService.Disable(user.ID, "abcde-fghij", "127.0.0.1")
+
+	assert.ErrorIs(t, err, ErrLoginThrottleServiceTooManyAttempts)
+	twoFactorRepositoryMock.AssertNotCalled(t, "GetByUserID", mock.Anything)
+	twoFactorRepositoryMock.AssertNotCalled(t, "DeleteByUserID", mock.Anything)
+}
+
+func TestTwoFactorServiceRegenerateRecoveryCodes(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, loginThrottleServiceMock)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+	twoFactor := newConfirmedTwoFactor(user.ID)
+	code, step := totpCode(twoFactor.Secret)
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, "127.0.0.1").Return(nil)
+	twoFactorRepositoryMock.On("GetByUserID", user.ID).Return(twoFactor, nil)
+	twoFactorRepositoryMock.On("UseStep", twoFactor.ID, step).Return(nil)
+	twoFactorRepositoryMock.On("DeleteRecoveryCodesByUserID", user.ID).Return(nil)
+	twoFactorRepositoryMock.On("CreateRecoveryCodes", mock.AnythingOfType("[]*entities.RecoveryCode")).Return(nil)
+
+	recoveryCodes, err := twoFactorService.RegenerateRecoveryCodes(user.ID, code, "127.0.0.1")
+
+	assert.NoError(t, err)
+	assert.Len(t, recoveryCodes, 10)
+	twoFactorRepositoryMock.AssertExpectations(t)
+	loginThrottleServiceMock.AssertExpectations(t)
+}
+
+func TestTwoFactorServiceRegenerateRecoveryCodesErrorInvalidCode(t *testing.T) {
+	twoFactorRepositoryMock := new(stub.TwoFactorRepositoryMock)
+	userRepositoryMock := new(stub.UserRepositoryMock)
+	loginThrottleServiceMock := new(LoginThrottleServiceMock)
+	twoFactorService := NewTwoFactorService(twoFactorRepositoryMock, userRepositoryMock, loginThrottleServiceMock)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepositoryMock.On("GetByID", user.ID).Return(user, nil)
+	loginThrottleServiceMock.On("Check", user.Email, "127.0.0.1").Return(nil)
+	loginThrottleServiceMock.On("RegisterFailure", user.Email, "127.0.0.1", user).Return(nil)
+	twoFactorRepositoryMock.On("GetByUserID", user.ID).Return(newConfirmedTwoFactor(user.ID), nil)
+	twoFactorRepositoryMock.On("UseRecoveryCode", user.ID, mock.AnythingOfType("string")).
+		Return(repositories.ErrTwoFactorRepositoryRecoveryCodeNotFound)
+
+	recoveryCodes, err := twoFactorService.RegenerateRecoveryCodes(user.ID, "abcde-fghij", "127.0.0.1")
+
+	assert.ErrorIs(t, err, ErrTwoFactorServiceInvalidCode)
+	assert.Nil(t, recoveryCodes)
+	loginThrottleServiceMock.AssertExpectations(t)
+	twoFactorRepositoryMock.AssertNotCalled(t, "CreateRecoveryCodes", mock.Anything)
+}
@@ -2,20 +2,67 @@ package services
 
 import (
 	"errors"
+	"fmt"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"net/url"
+	"strings"
+	"time"
 )
 
+var (
+	ErrUserServiceInvalidPasswordResetToken = errors.New("invalid or expired password reset token")
+	ErrUserServiceInvalidVerificationLink   = errors.New("invalid or expired verification link")
+	ErrUserServiceInvalidCurrentPassword    = errors.New("current password is not valid")
+	ErrUserServiceEmailAlreadyTaken         = errors.New("email is already in use")
+	ErrUserServiceInvalidEmailChangeLink    = errors.New("invalid or expired email change link")
+)
+
+const emailChangeSignaturePrefix = "email-change:"
+
 type UserService struct {
-	userRepository repositories.UserRepository
+	userRepository               repositories.UserRepository
+	passwordResetTokenRepository repositories.PasswordResetTokenRepository
+	refreshTokenRepository       repositories.RefreshTokenRepository
+	eventBus                     services.EventBus
+	mailSender                   services.MailSender
+	signer                       services.Signer
+	passwordHasher               entities.PasswordHasher
+	appURL                       string
+	passwordResetTokenDuration   time.Duration
+	emailVerificationDuration    time.Duration
 }
 
-func NewUserService(userRepository repositories.UserRepository) *UserService {
-	return &UserService{userRepository}
+func NewUserService(
+	userRepository repositories.UserRepository,
+	passwordResetTokenRepository repositories.PasswordResetTokenRepository,
+	refreshTokenRepository repositories.RefreshTokenRepository,
+	eventBus services.EventBus,
+	mailSender services.MailSender,
+	signer services.Signer,
+	passwordHasher entities.PasswordHasher,
+	appURL string,
+	passwordResetTokenDuration time.Duration,
+	emailVerificationDuration time.Duration,
+) *UserService {
+	return &UserService{
+		userRepository,
+		passwordResetTokenRepository,
+		refreshTokenRepository,
+		eventBus,
+		mailSender,
+		signer,
+		passwordHasher,
+		appURL,
+		passwordResetTokenDuration,
+		emailVerificationDuration,
+	}
 }
 
 func (s *UserService) CreateUser(email, password string) (*entities.User, error) {
-	user, err := entities.NewUser(email, password)
+	user, err := entities.NewUser(email, password, s.passwordHasher)
 	if err != nil {
 		return nil, err
 	}
@@ -25,5 +72,352 @@ func (s *UserService) CreateUser(email, password string) (*entities.User, error)
 		return nil, errors.New("cannot create user")
 	}
 
+	err = s.eventBus.Publish(services.UserCreatedEvent{
+		User: *user,
+	})
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return user, nil
+}
+
+// SendEmailVerification mails a signed link that proves the user owns the
+// address. The email is part of the signed value, so a link stops working
+// once the address changes.
+func (s *UserService) SendEmailVerification(user *entities.User) error {
+	signed := s.signer.Sign(
+		user.ID+":"+user.Email,
+		time.Now().Add(s.emailVerificationDuration),
+	)
+
+	link := strings.TrimSuffix(s.appURL, "/") + "/api/v1/users/verify?token=" + url.QueryEscape(signed)
+
+	body := fmt.Sprintf(
+		"Welcome to Home Inventory.\n\n"+
+			"Open this link to verify your email, it expires in %s:\n\n%s\n\n"+
+			"Until then, your notifications are held and sent to you once it is verified.",
+		s.emailVerificationDuration.String(),
+		link,
+	)
+
+	return s.mailSender.SendMail(user.Email, "Verify your email", body)
+}
+
+func (s *UserService) VerifyEmail(token string) error {
+	value, err := s.signer.Verify(token)
+	if err != nil {
+		return ErrUserServiceInvalidVerificationLink
+	}
+
+	userID, email, found := strings.Cut(value, ":")
+	if !found {
+		return ErrUserServiceInvalidVerificationLink
+	}
+
+	user, err := s.userRepository.GetByID(userID)
+	if errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+		return ErrUserServiceInvalidVerificationLink
+	}
+	if err != nil {
+		return err
+	}
+
+	if user.Email != email {
+		return ErrUserServiceInvalidVerificationLink
+	}
+
+	if user.IsVerified() {
+		return nil
+	}
+
+	user.Verify()
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		return err
+	}
+
+	s.publishEmailVerified(user)
+
+	return nil
+}
+
+// publishEmailVerified lets the notifications held for the user go out.
+func (s *UserService) publishEmailVerified(user *entities.User) {
+	err := s.eventBus.Publish(services.EmailVerifiedEvent{
+		User: *user,
+	})
+	if err != nil {
+		logger.LogError(err)
+	}
+}
+
+// RequestPasswordReset answers the same way whether the email exists or not.
+// The token is created and mailed by a listener, so the time spent on known
+// emails does not differ from unknown ones either.
+func (s *UserService) RequestPasswordReset(email string) error {
+	user, err := s.userRepository.FindByEmail(email)
+	if err != nil {
+		if !errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+			logger.LogError(err)
+		}
+		return nil
+	}
+
+	err = s.eventBus.Publish(services.PasswordResetRequestedEvent{
+		User: *user,
+	})
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return nil
+}
+
+func (s *UserService) SendPasswordReset(user *entities.User) error {
+	token, value, err := entities.NewPasswordResetToken(user.ID, s.passwordResetTokenDuration)
+	if err != nil {
+		return err
+	}
+
+	err = s.passwordResetTokenRepository.Create(token)
+	if err != nil {
+		return err
+	}
+
+	body := fmt.Sprintf(
+		"We received a request to reset your password.\n\n"+
+			"Use this token to set a new password, it expires in %s and can be used only once:\n\n%s\n\n"+
+			"If you did not request it, you can ignore this email.",
+		s.passwordResetTokenDuration.String(),
+		value,
+	)
+
+	return s.mailSender.SendMail(user.Email, "Reset your password", body)
+}
+
+// ResetPassword sets the new password of the token owner and ends all of
+// their sessions.
+func (s *UserService) ResetPassword(token string, password string) (*entities.User, error) {
+	resetToken, err := s.passwordResetTokenRepository.GetByTokenHash(entities.HashPasswordResetToken(token))
+	if errors.Is(err, repositories.ErrPasswordResetTokenRepositoryTokenNotFound) {
+		return nil, ErrUserServiceInvalidPasswordResetToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if resetToken.WasUsed() || resetToken.IsExpired() {
+		return nil, ErrUserServiceInvalidPasswordResetToken
+	}
+
+	user, err := s.userRepository.GetByID(resetToken.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.ChangePassword(password, s.passwordHasher)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.passwordResetTokenRepository.MarkAsUsed(resetToken.ID)
+	if errors.Is(err, repositories.ErrPasswordResetTokenRepositoryTokenAlreadyUsed) {
+		return nil, ErrUserServiceInvalidPasswordResetToken
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.refreshTokenRepository.RevokeByUserID(user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	return user, nil
+}
+
+func (s *UserService) GetByID(userID string) (*entities.User, error) {
+	return s.userRepository.GetByID(userID)
+}
+
+// ChangePassword sets a new password when the current one is right and ends
+// every other session of the user, keeping the one that made the change.
+func (s *UserService) ChangePassword(userID, sessionID, currentPassword, newPassword string) error {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.HasEqualPassword(currentPassword, s.passwordHasher) {
+		return ErrUserServiceInvalidCurrentPassword
+	}
+
+	err = user.ChangePassword(newPassword, s.passwordHasher)
+	if err != nil {
+		return err
+	}
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		return err
+	}
+
+	return s.refreshTokenRepository.RevokeByUserIDExceptFamily(user.ID, sessionID)
+}
+
+// ChangeTimezone sets the timezone the times in the emails of the user are
+// shown in.
+func (s *UserService) ChangeTimezone(userID string, timezone string) (*entities.User, error) {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.ChangeTimezone(timezone)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
 	return user, nil
 }
+
+// RequestEmailChange does not change the email yet, a listener mails a link to
+// the new address and the change happens once that link is opened.
+func (s *UserService) RequestEmailChange(userID, newEmail, password string) error {
+	user, err := s.userRepository.GetByID(userID)
+	if err != nil {
+		return err
+	}
+
+	if !user.HasEqualPassword(password, s.passwordHasher) {
+		return ErrUserServiceInvalidCurrentPassword
+	}
+
+	changed := *user
+	err = changed.ChangeEmail(newEmail)
+	if err != nil {
+		return err
+	}
+
+	err = s.ensureEmailIsAvailable(newEmail)
+	if err != nil {
+		return err
+	}
+
+	err = s.eventBus.Publish(services.EmailChangeRequestedEvent{
+		User:     *user,
+		NewEmail: newEmail,
+	})
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return nil
+}
+
+// SendEmailChangeConfirmation mails a signed link to the new address. The
+// current email is part of the signed value, so only the latest request of the
+// user can be confirmed.
+func (s *UserService) SendEmailChangeConfirmation(user *entities.User, newEmail string) error {
+	signed := s.signer.Sign(
+		emailChangeSignaturePrefix+user.ID+":"+user.Email+":"+newEmail,
+		time.Now().Add(s.emailVerificationDuration),
+	)
+
+	link := strings.TrimSuffix(s.appURL, "/") + "/api/v1/me/email/confirm?token=" + url.QueryEscape(signed)
+
+	body := fmt.Sprintf(
+		"We received a request to use this address in your Home Inventory account.\n\n"+
+			"Open this link to confirm it, it expires in %s:\n\n%s\n\n"+
+			"If you did not request it, you can ignore this email.",
+		s.emailVerificationDuration.String(),
+		link,
+	)
+
+	return s.mailSender.SendMail(newEmail, "Confirm your new email", body)
+}
+
+func (s *UserService) ConfirmEmailChange(token string) (*entities.User, error) {
+	value, err := s.signer.Verify(token)
+	if err != nil {
+		return nil, ErrUserServiceInvalidEmailChangeLink
+	}
+
+	value, found := strings.CutPrefix(value, emailChangeSignaturePrefix)
+	if !found {
+		return nil, ErrUserServiceInvalidEmailChangeLink
+	}
+
+	parts := strings.Split(value, ":")
+	if len(parts) != 3 {
+		return nil, ErrUserServiceInvalidEmailChangeLink
+	}
+	userID, oldEmail, newEmail := parts[0], parts[1], parts[2]
+
+	user, err := s.userRepository.GetByID(userID)
+	if errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+		return nil, ErrUserServiceInvalidEmailChangeLink
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if user.Email != oldEmail {
+		return nil, ErrUserServiceInvalidEmailChangeLink
+	}
+
+	err = s.ensureEmailIsAvailable(newEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	err = user.ChangeEmail(newEmail)
+	if err != nil {
+		return nil, err
+	}
+
+	user.Verify()
+
+	err = s.userRepository.Update(user)
+	if err != nil {
+		return nil, err
+	}
+
+	s.publishEmailVerified(user)
+
+	err = s.mailSender.SendMail(
+		oldEmail,
+		"Your email was changed",
+		"The email of your Home Inventory account was changed to "+newEmail+".\n\n"+
+			"If you did not make this change, reset your password right away.",
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return user, nil
+}
+
+func (s *UserService) ensureEmailIsAvailable(email string) error {
+	_, err := s.userRepository.FindByEmail(email)
+	if err == nil {
+		return ErrUserServiceEmailAlreadyTaken
+	}
+	if !errors.Is(err, repositories.ErrUserRepositoryUserNotFound) {
+		return err
+	}
+
+	return nil
+}
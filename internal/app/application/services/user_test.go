@@ -2,7 +2,12 @@ package services
 
 import (
 	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	serviceStub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
 	"github.com/labstack/gommon/random"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
@@ -12,18 +17,36 @@ import (
 
 func TestUserServiceCreateUser(t *testing.T) {
 	mockRepo := new(stub.UserRepositoryMock)
-	userService := NewUserService(mockRepo)
+	eventBus := new(serviceStub.EventBusMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		mockRepo,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
 
 	email := "test@example.com"
 	password := random.String(5, random.Numeric) + random.String(5, random.Alphabetic)
+	passwordHasher.On("Hash", password).Return("hashed", nil)
 	mockRepo.On("Create", mock.Anything).Return(nil)
+	eventBus.On("Publish", mock.AnythingOfType("services.UserCreatedEvent")).Return(nil)
 
 	user, err := userService.CreateUser(email, password)
 
 	assert.NoError(t, err)
 	mockRepo.AssertCalled(t, "Create", mock.Anything)
+	eventBus.AssertExpectations(t)
+	assert.False(t, user.IsVerified())
 	assert.NotEmpty(t, user.ID)
 	assert.Equal(t, email, user.Email)
+	assert.Equal(t, "hashed", user.Password)
 
 	now := time.Now()
 	assert.WithinDuration(t, now, user.CreatedAt, 10*time.Second)
@@ -32,11 +55,24 @@ func TestUserServiceCreateUser(t *testing.T) {
 
 func TestUserServiceCreateUserErrorInRepository(t *testing.T) {
 	mockRepo := new(stub.UserRepositoryMock)
-	userService := NewUserService(mockRepo)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		mockRepo,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
 
 	email := "test@example.com"
 	password := random.String(5, random.Numeric) + random.String(5, random.Alphabetic)
 
+	passwordHasher.On("Hash", password).Return("hashed", nil)
 	expectedError := errors.New("repository error")
 	mockRepo.On("Create", mock.Anything).Return(expectedError)
 
@@ -47,3 +83,787 @@ func TestUserServiceCreateUserErrorInRepository(t *testing.T) {
 	assert.Nil(t, user)
 	mockRepo.AssertCalled(t, "Create", mock.Anything)
 }
+
+func TestUserServiceRequestPasswordReset(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(serviceStub.EventBusMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	userRepository.On("FindByEmail", user.Email).Return(user, nil)
+	eventBus.On("Publish", services.PasswordResetRequestedEvent{User: *user}).Return(nil)
+
+	err := userService.RequestPasswordReset(user.Email)
+
+	assert.NoError(t, err)
+	userRepository.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+}
+
+func TestUserServiceRequestPasswordResetUnknownEmail(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(serviceStub.EventBusMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	userRepository.On("FindByEmail", "unknown@example.com").
+		Return(nil, repositories.ErrUserRepositoryUserNotFound)
+
+	err := userService.RequestPasswordReset("unknown@example.com")
+
+	assert.NoError(t, err)
+	userRepository.AssertExpectations(t)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestUserServiceSendPasswordReset(t *testing.T) {
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	userService := NewUserService(
+		new(stub.UserRepositoryMock),
+		passwordResetTokenRepository,
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		mailSender,
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	passwordResetTokenRepository.On("Create", mock.AnythingOfType("*entities.PasswordResetToken")).Return(nil)
+	mailSender.On("SendMail", user.Email, "Reset your password", mock.AnythingOfType("string")).Return(nil)
+
+	err := userService.SendPasswordReset(user)
+
+	assert.NoError(t, err)
+	passwordResetTokenRepository.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+
+	token := passwordResetTokenRepository.Calls[0].Arguments.Get(0).(*entities.PasswordResetToken)
+	body := mailSender.Calls[0].Arguments.String(2)
+	assert.Equal(t, user.ID, token.UserID)
+	assert.NotContains(t, body, token.TokenHash)
+}
+
+func TestUserServiceSendPasswordResetErrorInRepository(t *testing.T) {
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	userService := NewUserService(
+		new(stub.UserRepositoryMock),
+		passwordResetTokenRepository,
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		mailSender,
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	passwordResetTokenRepository.On("Create", mock.AnythingOfType("*entities.PasswordResetToken")).
+		Return(repositories.ErrPasswordResetTokenRepositoryCanNotCreateToken)
+
+	err := userService.SendPasswordReset(user)
+
+	assert.ErrorIs(t, err, repositories.ErrPasswordResetTokenRepositoryCanNotCreateToken)
+	mailSender.AssertNotCalled(t, "SendMail", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestUserServiceResetPassword(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		userRepository,
+		passwordResetTokenRepository,
+		refreshTokenRepository,
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", Password: "old"}
+	token, value, err := entities.NewPasswordResetToken(user.ID, time.Hour)
+	assert.NoError(t, err)
+	password := "newPassword1"
+
+	passwordHasher.On("Hash", password).Return("hashed", nil)
+	passwordResetTokenRepository.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+	passwordResetTokenRepository.On("MarkAsUsed", token.ID).Return(nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("Update", user).Return(nil)
+	refreshTokenRepository.On("RevokeByUserID", user.ID).Return(nil)
+
+	updated, err := userService.ResetPassword(value, password)
+
+	assert.NoError(t, err)
+	assert.Same(t, user, updated)
+	assert.Equal(t, "hashed", user.Password)
+	userRepository.AssertExpectations(t)
+	passwordResetTokenRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+}
+
+func TestUserServiceResetPasswordErrorInvalidToken(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	userService := NewUserService(
+		userRepository,
+		passwordResetTokenRepository,
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	passwordResetTokenRepository.On("GetByTokenHash", entities.HashPasswordResetToken("unknown")).
+		Return(nil, repositories.ErrPasswordResetTokenRepositoryTokenNotFound)
+
+	updated, err := userService.ResetPassword("unknown", "newPassword1")
+
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, ErrUserServiceInvalidPasswordResetToken)
+	userRepository.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestUserServiceResetPasswordErrorUsedToken(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	userService := NewUserService(
+		userRepository,
+		passwordResetTokenRepository,
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	token, value, err := entities.NewPasswordResetToken(uuid.NewString(), time.Hour)
+	assert.NoError(t, err)
+	usedAt := time.Now()
+	token.UsedAt = &usedAt
+
+	passwordResetTokenRepository.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+
+	updated, err := userService.ResetPassword(value, "newPassword1")
+
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, ErrUserServiceInvalidPasswordResetToken)
+	userRepository.AssertNotCalled(t, "GetByID", mock.Anything)
+	passwordResetTokenRepository.AssertNotCalled(t, "MarkAsUsed", mock.Anything)
+}
+
+func TestUserServiceResetPasswordErrorExpiredToken(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	userService := NewUserService(
+		userRepository,
+		passwordResetTokenRepository,
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	token, value, err := entities.NewPasswordResetToken(uuid.NewString(), -time.Minute)
+	assert.NoError(t, err)
+
+	passwordResetTokenRepository.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+
+	updated, err := userService.ResetPassword(value, "newPassword1")
+
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, ErrUserServiceInvalidPasswordResetToken)
+	userRepository.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestUserServiceResetPasswordErrorInvalidPasswordKeepsToken(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	userService := NewUserService(
+		userRepository,
+		passwordResetTokenRepository,
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", Password: "old"}
+	token, value, err := entities.NewPasswordResetToken(user.ID, time.Hour)
+	assert.NoError(t, err)
+
+	passwordResetTokenRepository.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	updated, err := userService.ResetPassword(value, "short")
+
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, entities.ErrUserPasswordMustBeBetween6And100Chars)
+	passwordResetTokenRepository.AssertNotCalled(t, "MarkAsUsed", mock.Anything)
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUserServiceResetPasswordErrorConcurrentUse(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	passwordResetTokenRepository := new(stub.PasswordResetTokenRepositoryMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		userRepository,
+		passwordResetTokenRepository,
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", Password: "old"}
+	token, value, err := entities.NewPasswordResetToken(user.ID, time.Hour)
+	assert.NoError(t, err)
+
+	passwordHasher.On("Hash", mock.AnythingOfType("string")).Return("hashed", nil)
+	passwordResetTokenRepository.On("GetByTokenHash", token.TokenHash).Return(token, nil)
+	passwordResetTokenRepository.On("MarkAsUsed", token.ID).
+		Return(repositories.ErrPasswordResetTokenRepositoryTokenAlreadyUsed)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	updated, err := userService.ResetPassword(value, "newPassword1")
+
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, ErrUserServiceInvalidPasswordResetToken)
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUserServiceSendEmailVerification(t *testing.T) {
+	mailSender := new(serviceStub.MailSenderMock)
+	signer := new(serviceStub.SignerMock)
+	userService := NewUserService(
+		new(stub.UserRepositoryMock),
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		mailSender,
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost/",
+		time.Hour,
+		48*time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	signer.On("Sign", user.ID+":"+user.Email, mock.AnythingOfType("time.Time")).Return("signed+value")
+	mailSender.On("SendMail", user.Email, "Verify your email", mock.AnythingOfType("string")).Return(nil)
+
+	err := userService.SendEmailVerification(user)
+
+	assert.NoError(t, err)
+	signer.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+
+	expiresAt := signer.Calls[0].Arguments.Get(1).(time.Time)
+	body := mailSender.Calls[0].Arguments.String(2)
+	assert.WithinDuration(t, time.Now().Add(48*time.Hour), expiresAt, 10*time.Second)
+	assert.Contains(t, body, "http://localhost/api/v1/users/verify?token=signed%2Bvalue")
+}
+
+func TestUserServiceVerifyEmail(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	signer := new(serviceStub.SignerMock)
+	eventBus := new(serviceStub.EventBusMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		new(serviceStub.MailSenderMock),
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com"}
+
+	signer.On("Verify", "token").Return(user.ID+":"+user.Email, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("Update", user).Return(nil)
+	eventBus.On("Publish", mock.MatchedBy(func(event services.EmailVerifiedEvent) bool {
+		return event.User.ID == user.ID
+	})).Return(nil)
+
+	err := userService.VerifyEmail("token")
+
+	assert.NoError(t, err)
+	assert.True(t, user.IsVerified())
+	userRepository.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+}
+
+func TestUserServiceVerifyEmailAlreadyVerified(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	signer := new(serviceStub.SignerMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	verifiedAt := time.Now().Add(-time.Hour)
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", VerifiedAt: &verifiedAt}
+
+	signer.On("Verify", "token").Return(user.ID+":"+user.Email, nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	err := userService.VerifyEmail("token")
+
+	assert.NoError(t, err)
+	assert.Equal(t, verifiedAt, *user.VerifiedAt)
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUserServiceVerifyEmailErrorInvalidSignature(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	signer := new(serviceStub.SignerMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	signer.On("Verify", "token").Return("", services.ErrSignerSignatureExpired)
+
+	err := userService.VerifyEmail("token")
+
+	assert.ErrorIs(t, err, ErrUserServiceInvalidVerificationLink)
+	userRepository.AssertNotCalled(t, "GetByID", mock.Anything)
+}
+
+func TestUserServiceVerifyEmailErrorEmailChanged(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	signer := new(serviceStub.SignerMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "new@example.com"}
+
+	signer.On("Verify", "token").Return(user.ID+":old@example.com", nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	err := userService.VerifyEmail("token")
+
+	assert.ErrorIs(t, err, ErrUserServiceInvalidVerificationLink)
+	assert.False(t, user.IsVerified())
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUserServiceChangePassword(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		refreshTokenRepository,
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", Password: "current"}
+	sessionID := uuid.NewString()
+
+	passwordHasher.On("Verify", "current123", "current").Return(true)
+	passwordHasher.On("Hash", "new123").Return("new", nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("Update", user).Return(nil)
+	refreshTokenRepository.On("RevokeByUserIDExceptFamily", user.ID, sessionID).Return(nil)
+
+	err := userService.ChangePassword(user.ID, sessionID, "current123", "new123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new", user.Password)
+	userRepository.AssertExpectations(t)
+	refreshTokenRepository.AssertExpectations(t)
+}
+
+func TestUserServiceChangePasswordErrorInvalidCurrentPassword(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	refreshTokenRepository := new(stub.RefreshTokenRepositoryMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		refreshTokenRepository,
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", Password: "current"}
+
+	passwordHasher.On("Verify", "wrong123", "current").Return(false)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	err := userService.ChangePassword(user.ID, uuid.NewString(), "wrong123", "new123")
+
+	assert.ErrorIs(t, err, ErrUserServiceInvalidCurrentPassword)
+	assert.Equal(t, "current", user.Password)
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+	refreshTokenRepository.AssertNotCalled(t, "RevokeByUserIDExceptFamily", mock.Anything, mock.Anything)
+}
+
+func TestUserServiceChangeTimezone(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", Timezone: entities.UserDefaultTimezone}
+
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("Update", user).Return(nil)
+
+	result, err := userService.ChangeTimezone(user.ID, "Europe/Madrid")
+
+	assert.NoError(t, err)
+	assert.Equal(t, user, result)
+	assert.Equal(t, "Europe/Madrid", user.Timezone)
+	userRepository.AssertExpectations(t)
+}
+
+func TestUserServiceChangeTimezoneErrorInvalidTimezone(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "test@example.com", Timezone: entities.UserDefaultTimezone}
+
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	result, err := userService.ChangeTimezone(user.ID, "Nowhere/City")
+
+	assert.ErrorIs(t, err, entities.ErrUserTimezoneIsInvalid)
+	assert.Nil(t, result)
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUserServiceRequestEmailChange(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(serviceStub.EventBusMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "old@example.com", Password: "current"}
+
+	passwordHasher.On("Verify", "current123", "current").Return(true)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("FindByEmail", "new@example.com").Return(nil, repositories.ErrUserRepositoryUserNotFound)
+	eventBus.On("Publish", services.EmailChangeRequestedEvent{User: *user, NewEmail: "new@example.com"}).Return(nil)
+
+	err := userService.RequestEmailChange(user.ID, "new@example.com", "current123")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "old@example.com", user.Email)
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+	eventBus.AssertExpectations(t)
+}
+
+func TestUserServiceRequestEmailChangeErrorInvalidPassword(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(serviceStub.EventBusMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "old@example.com", Password: "current"}
+
+	passwordHasher.On("Verify", "wrong123", "current").Return(false)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	err := userService.RequestEmailChange(user.ID, "new@example.com", "wrong123")
+
+	assert.ErrorIs(t, err, ErrUserServiceInvalidCurrentPassword)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestUserServiceRequestEmailChangeErrorEmailAlreadyTaken(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	eventBus := new(serviceStub.EventBusMock)
+	passwordHasher := new(serviceStub.PasswordHasherMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		new(serviceStub.MailSenderMock),
+		new(serviceStub.SignerMock),
+		passwordHasher,
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "old@example.com", Password: "current"}
+	other := &entities.User{ID: uuid.NewString(), Email: "new@example.com"}
+
+	passwordHasher.On("Verify", "current123", "current").Return(true)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("FindByEmail", other.Email).Return(other, nil)
+
+	err := userService.RequestEmailChange(user.ID, other.Email, "current123")
+
+	assert.ErrorIs(t, err, ErrUserServiceEmailAlreadyTaken)
+	eventBus.AssertNotCalled(t, "Publish", mock.Anything)
+}
+
+func TestUserServiceSendEmailChangeConfirmation(t *testing.T) {
+	mailSender := new(serviceStub.MailSenderMock)
+	signer := new(serviceStub.SignerMock)
+	userService := NewUserService(
+		new(stub.UserRepositoryMock),
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		mailSender,
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost/",
+		time.Hour,
+		48*time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "old@example.com"}
+
+	signer.On("Sign", "email-change:"+user.ID+":old@example.com:new@example.com", mock.AnythingOfType("time.Time")).
+		Return("signed+value")
+	mailSender.On("SendMail", "new@example.com", "Confirm your new email", mock.AnythingOfType("string")).Return(nil)
+
+	err := userService.SendEmailChangeConfirmation(user, "new@example.com")
+
+	assert.NoError(t, err)
+	signer.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+
+	body := mailSender.Calls[0].Arguments.String(2)
+	assert.Contains(t, body, "http://localhost/api/v1/me/email/confirm?token=signed%2Bvalue")
+}
+
+func TestUserServiceConfirmEmailChange(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	mailSender := new(serviceStub.MailSenderMock)
+	signer := new(serviceStub.SignerMock)
+	eventBus := new(serviceStub.EventBusMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		eventBus,
+		mailSender,
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "old@example.com"}
+
+	signer.On("Verify", "token").Return("email-change:"+user.ID+":old@example.com:new@example.com", nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+	userRepository.On("FindByEmail", "new@example.com").Return(nil, repositories.ErrUserRepositoryUserNotFound)
+	userRepository.On("Update", user).Return(nil)
+	eventBus.On("Publish", mock.AnythingOfType("services.EmailVerifiedEvent")).Return(nil)
+	mailSender.On("SendMail", "old@example.com", "Your email was changed", mock.AnythingOfType("string")).Return(nil)
+
+	updated, err := userService.ConfirmEmailChange("token")
+
+	assert.NoError(t, err)
+	assert.Same(t, user, updated)
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.True(t, user.IsVerified())
+	userRepository.AssertExpectations(t)
+	eventBus.AssertExpectations(t)
+	mailSender.AssertExpectations(t)
+}
+
+func TestUserServiceConfirmEmailChangeErrorEmailChanged(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	signer := new(serviceStub.SignerMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	user := &entities.User{ID: uuid.NewString(), Email: "other@example.com"}
+
+	signer.On("Verify", "token").Return("email-change:"+user.ID+":old@example.com:new@example.com", nil)
+	userRepository.On("GetByID", user.ID).Return(user, nil)
+
+	updated, err := userService.ConfirmEmailChange("token")
+
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, ErrUserServiceInvalidEmailChangeLink)
+	assert.Equal(t, "other@example.com", user.Email)
+	userRepository.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestUserServiceConfirmEmailChangeErrorVerificationLink(t *testing.T) {
+	userRepository := new(stub.UserRepositoryMock)
+	signer := new(serviceStub.SignerMock)
+	userService := NewUserService(
+		userRepository,
+		new(stub.PasswordResetTokenRepositoryMock),
+		new(stub.RefreshTokenRepositoryMock),
+		new(serviceStub.EventBusMock),
+		new(serviceStub.MailSenderMock),
+		signer,
+		new(serviceStub.PasswordHasherMock),
+		"http://localhost",
+		time.Hour,
+		time.Hour,
+	)
+
+	signer.On("Verify", "token").Return(uuid.NewString()+":new@example.com", nil)
+
+	updated, err := userService.ConfirmEmailChange("token")
+
+	assert.Nil(t, updated)
+	assert.ErrorIs(t, err, ErrUserServiceInvalidEmailChangeLink)
+	userRepository.AssertNotCalled(t, "GetByID", mock.Anything)
+}
@@ -0,0 +1,309 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"time"
+)
+
+var (
+	ErrWebhookServiceWebhookNotFound     = errors.New("webhook not found")
+	ErrWebhookServiceDeliveryNotFound    = errors.New("webhook delivery not found")
+	ErrWebhookServiceCanNotEncodePayload = errors.New("can not encode webhook payload")
+)
+
+// WebhookPayload is the body of a delivery. ID is the same in every delivery
+// of an event, redeliveries included, so an endpoint can skip the ones it
+// already handled. Data is the event as it was published.
+type WebhookPayload struct {
+	ID          string      `json:"id"`
+	Event       string      `json:"event"`
+	HouseholdID string      `json:"household_id"`
+	OccurredAt  time.Time   `json:"occurred_at"`
+	Data        interface{} `json:"data"`
+}
+
+type WebhookService struct {
+	webhookRepository         repositories.WebhookRepository
+	webhookDeliveryRepository repositories.WebhookDeliveryRepository
+	webhookSender             services.WebhookSender
+	householdService          HouseholdServiceInterface
+	retryPolicy               entities.RetryPolicy
+}
+
+func NewWebhookService(
+	webhookRepository repositories.WebhookRepository,
+	webhookDeliveryRepository repositories.WebhookDeliveryRepository,
+	webhookSender services.WebhookSender,
+	householdService HouseholdServiceInterface,
+	retryPolicy entities.RetryPolicy,
+) *WebhookService {
+	return &WebhookService{
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		retryPolicy,
+	}
+}
+
+// Create returns the webhook with its secret, the endpoint needs it to check
+// the signature of the deliveries.
+func (s *WebhookService) Create(
+	userID string,
+	householdID string,
+	url string,
+	eventTypes []string,
+) (*entities.Webhook, error) {
+	householdID, err := s.householdService.ResolveHouseholdID(householdID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	webhook, err := entities.NewWebhook(userID, householdID, url, eventTypes)
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.webhookRepository.Create(webhook)
+	if err != nil {
+		return nil, err
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) GetAll(userID string) ([]*entities.Webhook, error) {
+	return s.webhookRepository.GetByUserID(userID)
+}
+
+// Get only returns the webhooks of the user, for anyone else they do not
+// exist.
+func (s *WebhookService) Get(id string, userID string) (*entities.Webhook, error) {
+	webhook, err := s.webhookRepository.GetByID(id)
+	if errors.Is(err, repositories.ErrWebhookRepositoryWebhookNotFound) {
+		return nil, ErrWebhookServiceWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if webhook.UserID != userID {
+		return nil, ErrWebhookServiceWebhookNotFound
+	}
+
+	return webhook, nil
+}
+
+func (s *WebhookService) Delete(id string, userID string) error {
+	webhook, err := s.Get(id, userID)
+	if err != nil {
+		return err
+	}
+
+	return s.webhookRepository.Delete(webhook.ID)
+}
+
+func (s *WebhookService) GetDeliveries(
+	webhookID string,
+	userID string,
+	pageFilter PageFilter,
+) ([]*entities.WebhookDelivery, error) {
+	webhook, err := s.Get(webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.webhookDeliveryRepository.GetByWebhookID(webhook.ID, &repositories.PageFilter{
+		Offset: (pageFilter.Page - 1) * pageFilter.Size,
+		Limit:  pageFilter.Size,
+	})
+}
+
+func (s *WebhookService) CountDeliveries(webhookID string, userID string) (int64, error) {
+	webhook, err := s.Get(webhookID, userID)
+	if err != nil {
+		return 0, err
+	}
+
+	return s.webhookDeliveryRepository.CountByWebhookID(webhook.ID)
+}
+
+// Redeliver queues the payload of a delivery again, whatever its status was,
+// and returns the new delivery.
+func (s *WebhookService) Redeliver(
+	webhookID string,
+	deliveryID string,
+	userID string,
+) (*entities.WebhookDelivery, error) {
+	webhook, err := s.Get(webhookID, userID)
+	if err != nil {
+		return nil, err
+	}
+
+	delivery, err := s.webhookDeliveryRepository.GetByID(deliveryID)
+	if errors.Is(err, repositories.ErrWebhookRepositoryDeliveryNotFound) {
+		return nil, ErrWebhookServiceDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	if delivery.WebhookID != webhook.ID {
+		return nil, ErrWebhookServiceDeliveryNotFound
+	}
+
+	redelivery, err := delivery.Redeliver()
+	if err != nil {
+		return nil, err
+	}
+
+	err = s.webhookDeliveryRepository.Create(redelivery)
+	if err != nil {
+		return nil, err
+	}
+
+	return redelivery, nil
+}
+
+// Enqueue creates a delivery for every webhook of the household of the event
+// that is subscribed to it. The webhooks of users that are no longer members
+// of the household are skipped. Events that do not belong to a household are
+// ignored.
+func (s *WebhookService) Enqueue(event services.Event) error {
+	householdID, ok := getWebhookEventHouseholdID(event)
+	if !ok {
+		return nil
+	}
+
+	webhooks, err := s.webhookRepository.GetByHouseholdID(householdID)
+	if err != nil {
+		return err
+	}
+
+	eventType := services.GetEventType(event)
+	var payload string
+	for _, webhook := range webhooks {
+		if !webhook.IsSubscribedTo(eventType) {
+			continue
+		}
+
+		err = s.householdService.CheckCanView(householdID, webhook.UserID)
+		if errors.Is(err, ErrHouseholdServiceHouseholdNotFound) {
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		if payload == "" {
+			payload, err = encodeWebhookPayload(eventType, householdID, event)
+			if err != nil {
+				return err
+			}
+		}
+
+		delivery, err := entities.NewWebhookDelivery(webhook.ID, eventType, payload)
+		if err != nil {
+			return err
+		}
+
+		err = s.webhookDeliveryRepository.Create(delivery)
+		if err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// DeliverPending sends up to limit available deliveries and returns how many
+// of them were delivered. A failed delivery waits for the backoff of the
+// retry policy and is dead once it runs out of attempts.
+func (s *WebhookService) DeliverPending(limit int) (int, error) {
+	return ProcessAvailable(
+		s.webhookDeliveryRepository,
+		repositories.ErrWebhookRepositoryDeliveryAlreadyClaimed,
+		limit,
+		s.deliver,
+	)
+}
+
+// deliver sends the delivery to its webhook and marks it as delivered or
+// failed. A delivery whose webhook was deleted fails until it is dead.
+func (s *WebhookService) deliver(delivery *entities.WebhookDelivery) (bool, error) {
+	webhook, err := s.webhookRepository.GetByID(delivery.WebhookID)
+	if errors.Is(err, repositories.ErrWebhookRepositoryWebhookNotFound) {
+		delivery.MarkFailed(ErrWebhookServiceWebhookNotFound, nil, time.Now(), s.retryPolicy)
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+
+	statusCode, err := s.webhookSender.Send(webhook.URL, webhook.Secret, delivery.ID, delivery.EventType, delivery.Payload)
+	if err != nil {
+		var responseStatus *int
+		if statusCode != 0 {
+			responseStatus = &statusCode
+		}
+
+		delivery.MarkFailed(err, responseStatus, time.Now(), s.retryPolicy)
+		if !delivery.IsPending() {
+			logger.LogError(fmt.Errorf("webhook delivery %s to %s is dead after %d attempts", delivery.ID, webhook.ID, delivery.Attempts))
+		}
+		return false, nil
+	}
+
+	delivery.MarkDelivered(statusCode, time.Now())
+	return true, nil
+}
+
+func encodeWebhookPayload(eventType string, householdID string, event services.Event) (string, error) {
+	payload, err := json.Marshal(WebhookPayload{
+		ID:          uuid.NewString(),
+		Event:       eventType,
+		HouseholdID: householdID,
+		OccurredAt:  time.Now(),
+		Data:        event,
+	})
+	if err != nil {
+		logger.LogError(err)
+		return "", ErrWebhookServiceCanNotEncodePayload
+	}
+
+	return string(payload), nil
+}
+
+func getWebhookEventHouseholdID(event services.Event) (string, bool) {
+	switch e := event.(type) {
+	case services.BoxItemAddedEvent:
+		return e.Item.HouseholdID, true
+	case services.BoxItemRemovedEvent:
+		return e.Item.HouseholdID, true
+	case services.ItemCreatedEvent:
+		return e.Item.HouseholdID, true
+	case services.ItemUpdatedEvent:
+		return e.Item.HouseholdID, true
+	case services.BoxCreatedEvent:
+		return e.HouseholdID, true
+	case services.BoxUpdatedEvent:
+		return e.HouseholdID, true
+	case services.BoxDeletedEvent:
+		return e.HouseholdID, true
+	case services.RoomCreatedEvent:
+		return e.Room.HouseholdID, true
+	case services.RoomUpdatedEvent:
+		return e.Room.HouseholdID, true
+	case services.RoomDeletedEvent:
+		return e.Room.HouseholdID, true
+	}
+
+	return "", false
+}
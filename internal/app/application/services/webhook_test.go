@@ -0,0 +1,706 @@
+package services
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	serviceStub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+	"time"
+)
+
+func TestWebhookServiceCreate(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	householdService.On("ResolveHouseholdID", "", userID).Return(householdID, nil)
+	webhookRepository.On("Create", mock.AnythingOfType("*entities.Webhook")).Return(nil)
+
+	webhook, err := webhookService.Create(userID, "", "https://example.com/hooks", []string{"BoxItemAddedEvent"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, userID, webhook.UserID)
+	assert.Equal(t, householdID, webhook.HouseholdID)
+	assert.Equal(t, "BoxItemAddedEvent", webhook.EventTypes)
+	assert.NotEmpty(t, webhook.Secret)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceCreateErrorNotAllowed(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	householdService.On("ResolveHouseholdID", householdID, userID).Return("", ErrHouseholdServiceNotAllowed)
+
+	webhook, err := webhookService.Create(userID, householdID, "https://example.com/hooks", []string{"BoxItemAddedEvent"})
+
+	assert.ErrorIs(t, err, ErrHouseholdServiceNotAllowed)
+	assert.Nil(t, webhook)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	webhookRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestWebhookServiceCreateErrorInvalidEventType(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+
+	householdService.On("ResolveHouseholdID", "", userID).Return(uuid.NewString(), nil)
+
+	webhook, err := webhookService.Create(userID, "", "https://example.com/hooks", []string{"UserCreatedEvent"})
+
+	assert.ErrorIs(t, err, entities.ErrWebhookEventTypeIsNotSupported)
+	assert.Nil(t, webhook)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	webhookRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestWebhookServiceGetAll(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	webhooks := []*entities.Webhook{webhook}
+
+	webhookRepository.On("GetByUserID", userID).Return(webhooks, nil)
+
+	result, err := webhookService.GetAll(userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, webhooks, result)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceDelete(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookRepository.On("Delete", webhook.ID).Return(nil)
+
+	err := webhookService.Delete(webhook.ID, userID)
+
+	assert.NoError(t, err)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceDeleteErrorWebhookOfAnotherUser(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+
+	err := webhookService.Delete(webhook.ID, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrWebhookServiceWebhookNotFound)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	webhookRepository.AssertNotCalled(t, "Delete", mock.Anything)
+}
+
+func TestWebhookServiceDeleteErrorWebhookNotFound(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	id := uuid.NewString()
+
+	webhookRepository.On("GetByID", id).Return(nil, repositories.ErrWebhookRepositoryWebhookNotFound)
+
+	err := webhookService.Delete(id, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrWebhookServiceWebhookNotFound)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceGetDeliveries(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	deliveries := []*entities.WebhookDelivery{{ID: uuid.NewString(), WebhookID: webhook.ID}}
+
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookDeliveryRepository.On("GetByWebhookID", webhook.ID, &repositories.PageFilter{Offset: 20, Limit: 10}).
+		Return(deliveries, nil)
+	webhookDeliveryRepository.On("CountByWebhookID", webhook.ID).Return(int64(21), nil)
+
+	result, err := webhookService.GetDeliveries(webhook.ID, userID, PageFilter{Page: 3, Size: 10})
+
+	assert.NoError(t, err)
+	assert.Equal(t, deliveries, result)
+
+	count, err := webhookService.CountDeliveries(webhook.ID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(21), count)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceRedeliver(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	delivery := &entities.WebhookDelivery{
+		ID:        uuid.NewString(),
+		WebhookID: webhook.ID,
+		EventType: "RoomCreatedEvent",
+		Payload:   `{"event":"RoomCreatedEvent"}`,
+		Status:    entities.WebhookDeliveryStatusDead,
+		Attempts:  3,
+	}
+
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookDeliveryRepository.On("GetByID", delivery.ID).Return(delivery, nil)
+	webhookDeliveryRepository.On("Create", mock.MatchedBy(func(redelivery *entities.WebhookDelivery) bool {
+		return redelivery.ID != delivery.ID &&
+			redelivery.WebhookID == webhook.ID &&
+			redelivery.Payload == delivery.Payload &&
+			redelivery.IsPending()
+	})).Return(nil)
+
+	redelivery, err := webhookService.Redeliver(webhook.ID, delivery.ID, userID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, redelivery)
+	assert.Equal(t, 0, redelivery.Attempts)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceRedeliverErrorDeliveryOfAnotherWebhook(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	delivery := &entities.WebhookDelivery{ID: uuid.NewString(), WebhookID: uuid.NewString(), EventType: "RoomCreatedEvent"}
+
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookDeliveryRepository.On("GetByID", delivery.ID).Return(delivery, nil)
+
+	redelivery, err := webhookService.Redeliver(webhook.ID, delivery.ID, userID)
+
+	assert.ErrorIs(t, err, ErrWebhookServiceDeliveryNotFound)
+	assert.Nil(t, redelivery)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	webhookDeliveryRepository.AssertNotCalled(t, "Create", mock.Anything)
+}
+
+func TestWebhookServiceRedeliverErrorDeliveryNotFound(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	userID := uuid.NewString()
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	deliveryID := uuid.NewString()
+
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookDeliveryRepository.On("GetByID", deliveryID).Return(nil, repositories.ErrWebhookRepositoryDeliveryNotFound)
+
+	redelivery, err := webhookService.Redeliver(webhook.ID, deliveryID, userID)
+
+	assert.ErrorIs(t, err, ErrWebhookServiceDeliveryNotFound)
+	assert.Nil(t, redelivery)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceEnqueue(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	householdID := uuid.NewString()
+	subscribed := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: householdID,
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "BoxItemAddedEvent,BoxItemRemovedEvent",
+	}
+	notSubscribed := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: householdID,
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	ofFormerMember := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: householdID,
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "BoxItemAddedEvent",
+	}
+	event := services.BoxItemAddedEvent{
+		Quantity:   2,
+		BoxID:      uuid.NewString(),
+		Item:       entities.Item{ID: uuid.NewString(), HouseholdID: householdID},
+		HappenedAt: time.Now(),
+	}
+
+	webhookRepository.On("GetByHouseholdID", householdID).
+		Return([]*entities.Webhook{subscribed, notSubscribed, ofFormerMember}, nil)
+	householdService.On("CheckCanView", householdID, subscribed.UserID).Return(nil)
+	householdService.On("CheckCanView", householdID, ofFormerMember.UserID).Return(ErrHouseholdServiceHouseholdNotFound)
+
+	var payload WebhookPayload
+	webhookDeliveryRepository.On("Create", mock.MatchedBy(func(delivery *entities.WebhookDelivery) bool {
+		return delivery.WebhookID == subscribed.ID &&
+			delivery.EventType == "BoxItemAddedEvent" &&
+			json.Unmarshal([]byte(delivery.Payload), &payload) == nil
+	})).Return(nil).Once()
+
+	err := webhookService.Enqueue(event)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, payload.ID)
+	assert.Equal(t, "BoxItemAddedEvent", payload.Event)
+	assert.Equal(t, householdID, payload.HouseholdID)
+	assert.NotNil(t, payload.Data)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceEnqueueIgnoresEventsWithoutHousehold(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	err := webhookService.Enqueue(services.UserCreatedEvent{})
+
+	assert.NoError(t, err)
+	webhookRepository.AssertNotCalled(t, "GetByHouseholdID", mock.Anything)
+}
+
+func TestWebhookServiceEnqueueErrorCanNotCreateDelivery(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	room := entities.Room{ID: uuid.NewString(), HouseholdID: uuid.NewString()}
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: room.HouseholdID,
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+
+	webhookRepository.On("GetByHouseholdID", room.HouseholdID).Return([]*entities.Webhook{webhook}, nil)
+	householdService.On("CheckCanView", room.HouseholdID, webhook.UserID).Return(nil)
+	webhookDeliveryRepository.On("Create", mock.AnythingOfType("*entities.WebhookDelivery")).
+		Return(repositories.ErrWebhookRepositoryCanNotCreateDelivery)
+
+	err := webhookService.Enqueue(services.RoomCreatedEvent{Room: room})
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotCreateDelivery)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceDeliverPending(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	delivered := &entities.WebhookDelivery{ID: uuid.NewString(), WebhookID: webhook.ID, EventType: "RoomCreatedEvent", Payload: "{}", Status: entities.WebhookDeliveryStatusPending}
+	failed := &entities.WebhookDelivery{ID: uuid.NewString(), WebhookID: webhook.ID, EventType: "RoomCreatedEvent", Payload: "{}", Status: entities.WebhookDeliveryStatusPending}
+	claimed := &entities.WebhookDelivery{ID: uuid.NewString(), WebhookID: webhook.ID, EventType: "RoomCreatedEvent", Payload: "{}", Status: entities.WebhookDeliveryStatusPending}
+
+	webhookDeliveryRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.WebhookDelivery{delivered, failed, claimed}, nil)
+	webhookDeliveryRepository.On("Claim", delivered, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	webhookDeliveryRepository.On("Claim", failed, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	webhookDeliveryRepository.On("Claim", claimed, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(repositories.ErrWebhookRepositoryDeliveryAlreadyClaimed)
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookSender.On("Send", webhook.URL, webhook.Secret, delivered.ID, "RoomCreatedEvent", "{}").Return(200, nil)
+	webhookSender.On("Send", webhook.URL, webhook.Secret, failed.ID, "RoomCreatedEvent", "{}").
+		Return(500, services.ErrWebhookSenderUnexpectedStatusCode)
+	webhookDeliveryRepository.On("Update", delivered).Return(nil)
+	webhookDeliveryRepository.On("Update", failed).Return(nil)
+
+	count, err := webhookService.DeliverPending(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, count)
+	assert.Equal(t, entities.WebhookDeliveryStatusDelivered, delivered.Status)
+	assert.Equal(t, 200, *delivered.ResponseStatus)
+	assert.Equal(t, entities.WebhookDeliveryStatusPending, failed.Status)
+	assert.Equal(t, 1, failed.Attempts)
+	assert.Equal(t, 500, *failed.ResponseStatus)
+	assert.Equal(t, services.ErrWebhookSenderUnexpectedStatusCode.Error(), *failed.LastError)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+	webhookSender.AssertNotCalled(t, "Send", webhook.URL, webhook.Secret, claimed.ID, "RoomCreatedEvent", "{}")
+}
+
+func TestWebhookServiceDeliverPendingWithoutResponse(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	delivery := &entities.WebhookDelivery{ID: uuid.NewString(), WebhookID: webhook.ID, EventType: "RoomCreatedEvent", Payload: "{}", Status: entities.WebhookDeliveryStatusPending, Attempts: 2}
+
+	webhookDeliveryRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.WebhookDelivery{delivery}, nil)
+	webhookDeliveryRepository.On("Claim", delivery, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookSender.On("Send", webhook.URL, webhook.Secret, delivery.ID, "RoomCreatedEvent", "{}").
+		Return(0, services.ErrWebhookSenderCanNotSend)
+	webhookDeliveryRepository.On("Update", delivery).Return(nil)
+
+	count, err := webhookService.DeliverPending(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, entities.WebhookDeliveryStatusDead, delivery.Status)
+	assert.Nil(t, delivery.ResponseStatus)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceDeliverPendingWebhookNotFound(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	delivery := &entities.WebhookDelivery{ID: uuid.NewString(), WebhookID: uuid.NewString(), EventType: "RoomCreatedEvent", Payload: "{}", Status: entities.WebhookDeliveryStatusPending}
+
+	webhookDeliveryRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.WebhookDelivery{delivery}, nil)
+	webhookDeliveryRepository.On("Claim", delivery, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	webhookRepository.On("GetByID", delivery.WebhookID).Return(nil, repositories.ErrWebhookRepositoryWebhookNotFound)
+	webhookDeliveryRepository.On("Update", delivery).Return(nil)
+
+	count, err := webhookService.DeliverPending(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, count)
+	assert.Equal(t, entities.WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 1, delivery.Attempts)
+	assert.NotNil(t, delivery.LastError)
+	assert.Equal(t, ErrWebhookServiceWebhookNotFound.Error(), *delivery.LastError)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
+
+func TestWebhookServiceDeliverPendingErrorCanNotUpdate(t *testing.T) {
+	webhookRepository := new(stub.WebhookRepositoryMock)
+	webhookDeliveryRepository := new(stub.WebhookDeliveryRepositoryMock)
+	webhookSender := new(serviceStub.WebhookSenderMock)
+	householdService := new(HouseholdServiceMock)
+	webhookService := NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour},
+	)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "RoomCreatedEvent",
+	}
+	delivery := &entities.WebhookDelivery{ID: uuid.NewString(), WebhookID: webhook.ID, EventType: "RoomCreatedEvent", Payload: "{}", Status: entities.WebhookDeliveryStatusPending}
+	updateErr := errors.New("update error")
+
+	webhookDeliveryRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.WebhookDelivery{delivery}, nil)
+	webhookDeliveryRepository.On("Claim", delivery, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).Return(nil)
+	webhookRepository.On("GetByID", webhook.ID).Return(webhook, nil)
+	webhookSender.On("Send", webhook.URL, webhook.Secret, delivery.ID, "RoomCreatedEvent", "{}").Return(204, nil)
+	webhookDeliveryRepository.On("Update", delivery).Return(updateErr)
+
+	count, err := webhookService.DeliverPending(10)
+
+	assert.ErrorIs(t, err, updateErr)
+	assert.Equal(t, 1, count)
+	webhookRepository.AssertExpectations(t)
+	webhookDeliveryRepository.AssertExpectations(t)
+	webhookSender.AssertExpectations(t)
+	householdService.AssertExpectations(t)
+}
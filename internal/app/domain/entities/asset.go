@@ -3,13 +3,13 @@ package entities
 import (
 	"errors"
 	"github.com/google/uuid"
-	"os"
 	"path/filepath"
 	"time"
 )
 
 var (
-	ErrCanNotCreateAssetFromFile = errors.New("can not create asset from file")
+	ErrAssetNameShouldNotBeEmpty    = errors.New("asset name should not be empty")
+	ErrAssetSizeShouldNotBeNegative = errors.New("asset size should not be negative")
 )
 
 type Asset struct {
@@ -17,6 +17,7 @@ type Asset struct {
 	Name       string
 	Extension  string
 	Size       int64
+	Hash       string
 	FileID     string
 	EntityID   string
 	EntityName string
@@ -24,23 +25,28 @@ type Asset struct {
 	UpdatedAt  time.Time
 }
 
-func NewAssetFromFile(
-	file *os.File,
+func NewAsset(
+	name string,
+	size int64,
+	hash string,
 	fileID string,
 	entity Entity,
 ) (*Asset, error) {
-	name := filepath.Base(file.Name())
-	extension := filepath.Ext(file.Name())
-	info, err := file.Stat()
-	if err != nil {
-		return nil, ErrCanNotCreateAssetFromFile
+	name = filepath.Base(name)
+	if name == "" || name == "." || name == string(filepath.Separator) {
+		return nil, ErrAssetNameShouldNotBeEmpty
+	}
+
+	if size < 0 {
+		return nil, ErrAssetSizeShouldNotBeNegative
 	}
 
 	return &Asset{
 		ID:         uuid.NewString(),
 		Name:       name,
-		Extension:  extension,
-		Size:       info.Size(),
+		Extension:  filepath.Ext(name),
+		Size:       size,
+		Hash:       hash,
 		FileID:     fileID,
 		EntityID:   entity.EntityID(),
 		EntityName: entity.EntityName(),
@@ -3,8 +3,6 @@ package entities
 import (
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
-	"os"
-	"path/filepath"
 	"testing"
 )
 
@@ -20,27 +18,21 @@ func (e *MockEntity) EntityName() string {
 	return "mock_entity"
 }
 
-func TestNewAssetFromFile(t *testing.T) {
-	tempFile, err := os.CreateTemp("", "*_"+uuid.NewString())
-	defer tempFile.Close()
-	assert.NoError(t, err)
-
-	name := filepath.Base(tempFile.Name())
-	extension := filepath.Ext(tempFile.Name())
-	info, err := tempFile.Stat()
-	assert.NoError(t, err)
-
+func TestNewAsset(t *testing.T) {
+	name := uuid.NewString() + ".jpg"
+	hash := "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
 	fileID := uuid.NewString()
 	entity := &MockEntity{uuid.NewString()}
 
-	asset, err := NewAssetFromFile(tempFile, fileID, entity)
+	asset, err := NewAsset(name, 89813, hash, fileID, entity)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, asset)
 	assert.NotEmpty(t, asset.ID)
 	assert.Equal(t, name, asset.Name)
-	assert.Equal(t, extension, asset.Extension)
-	assert.Equal(t, info.Size(), asset.Size)
+	assert.Equal(t, ".jpg", asset.Extension)
+	assert.Equal(t, int64(89813), asset.Size)
+	assert.Equal(t, hash, asset.Hash)
 	assert.Equal(t, fileID, asset.FileID)
 	assert.Equal(t, entity.EntityID(), asset.EntityID)
 	assert.Equal(t, entity.EntityName(), asset.EntityName)
@@ -48,14 +40,24 @@ func TestNewAssetFromFile(t *testing.T) {
 	assert.NotEmpty(t, asset.UpdatedAt)
 }
 
-func TestNewAssetFromFileErrorCanNotCreateAssetFromFile(t *testing.T) {
-	tempFile, err := os.CreateTemp("", "*_"+uuid.NewString())
-	tempFile.Close()
+func TestNewAssetRemovesDirectoryFromName(t *testing.T) {
+	asset, err := NewAsset("../photos/box.png", 10, "", uuid.NewString(), &MockEntity{uuid.NewString()})
+
 	assert.NoError(t, err)
+	assert.Equal(t, "box.png", asset.Name)
+	assert.Equal(t, ".png", asset.Extension)
+}
+
+func TestNewAssetErrorAssetNameShouldNotBeEmpty(t *testing.T) {
+	asset, err := NewAsset("", 10, "", uuid.NewString(), &MockEntity{uuid.NewString()})
+
+	assert.Nil(t, asset)
+	assert.ErrorIs(t, err, ErrAssetNameShouldNotBeEmpty)
+}
 
-	asset, err := NewAssetFromFile(tempFile, uuid.NewString(), &MockEntity{uuid.NewString()})
+func TestNewAssetErrorAssetSizeShouldNotBeNegative(t *testing.T) {
+	asset, err := NewAsset("photo.jpg", -1, "", uuid.NewString(), &MockEntity{uuid.NewString()})
 
-	assert.Error(t, err)
-	assert.Empty(t, asset)
-	assert.ErrorIs(t, err, ErrCanNotCreateAssetFromFile)
+	assert.Nil(t, asset)
+	assert.ErrorIs(t, err, ErrAssetSizeShouldNotBeNegative)
 }
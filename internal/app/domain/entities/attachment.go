@@ -0,0 +1,95 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	AttachmentKindPhoto    = "photo"
+	AttachmentKindReceipt  = "receipt"
+	AttachmentKindManual   = "manual"
+	AttachmentKindWarranty = "warranty"
+)
+
+var (
+	ErrAttachmentItemIDShouldNotBeEmpty                = errors.New("item id should not be empty")
+	ErrAttachmentKindIsInvalid                         = errors.New("kind should be photo, receipt, manual or warranty")
+	ErrAttachmentWarrantyShouldNotExpireBeforePurchase = errors.New("warranty should not expire before purchase date")
+)
+
+type Attachment struct {
+	ID                string
+	ItemID            string
+	AssetID           string
+	Kind              string
+	PurchasedAt       *time.Time
+	WarrantyExpiresAt *time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+func NewAttachment(
+	itemID string,
+	kind string,
+	purchasedAt *time.Time,
+	warrantyExpiresAt *time.Time,
+) (*Attachment, error) {
+	if strings.TrimSpace(itemID) == "" {
+		return nil, ErrAttachmentItemIDShouldNotBeEmpty
+	}
+
+	attachment := &Attachment{
+		ID:        uuid.NewString(),
+		ItemID:    itemID,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err := attachment.ChangeKind(kind)
+	if err != nil {
+		return nil, err
+	}
+
+	err = attachment.ChangeDates(purchasedAt, warrantyExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	return attachment, nil
+}
+
+func (a *Attachment) EntityID() string {
+	return a.ID
+}
+
+func (a *Attachment) EntityName() string {
+	return "attachment"
+}
+
+func (a *Attachment) ChangeKind(kind string) error {
+	switch kind {
+	case AttachmentKindPhoto, AttachmentKindReceipt, AttachmentKindManual, AttachmentKindWarranty:
+		a.Kind = kind
+		return nil
+	default:
+		return ErrAttachmentKindIsInvalid
+	}
+}
+
+func (a *Attachment) ChangeDates(purchasedAt *time.Time, warrantyExpiresAt *time.Time) error {
+	if purchasedAt != nil && warrantyExpiresAt != nil && warrantyExpiresAt.Before(*purchasedAt) {
+		return ErrAttachmentWarrantyShouldNotExpireBeforePurchase
+	}
+
+	a.PurchasedAt = purchasedAt
+	a.WarrantyExpiresAt = warrantyExpiresAt
+
+	return nil
+}
+
+func (a *Attachment) ChangeAssetID(assetID string) {
+	a.AssetID = assetID
+}
@@ -0,0 +1,81 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewAttachment(t *testing.T) {
+	itemID := uuid.NewString()
+	purchasedAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	warrantyExpiresAt := time.Date(2026, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	attachment, err := NewAttachment(itemID, AttachmentKindWarranty, &purchasedAt, &warrantyExpiresAt)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, attachment)
+	assert.NotEmpty(t, attachment.ID)
+	assert.Equal(t, itemID, attachment.ItemID)
+	assert.Empty(t, attachment.AssetID)
+	assert.Equal(t, AttachmentKindWarranty, attachment.Kind)
+	assert.Equal(t, &purchasedAt, attachment.PurchasedAt)
+	assert.Equal(t, &warrantyExpiresAt, attachment.WarrantyExpiresAt)
+	assert.NotEmpty(t, attachment.CreatedAt)
+	assert.NotEmpty(t, attachment.UpdatedAt)
+}
+
+func TestNewAttachmentWithoutDates(t *testing.T) {
+	attachment, err := NewAttachment(uuid.NewString(), AttachmentKindManual, nil, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, AttachmentKindManual, attachment.Kind)
+	assert.Nil(t, attachment.PurchasedAt)
+	assert.Nil(t, attachment.WarrantyExpiresAt)
+}
+
+func TestNewAttachmentErrorAttachmentItemIDShouldNotBeEmpty(t *testing.T) {
+	attachment, err := NewAttachment(" ", AttachmentKindReceipt, nil, nil)
+
+	assert.Nil(t, attachment)
+	assert.ErrorIs(t, err, ErrAttachmentItemIDShouldNotBeEmpty)
+}
+
+func TestNewAttachmentErrorAttachmentKindIsInvalid(t *testing.T) {
+	attachment, err := NewAttachment(uuid.NewString(), "invoice", nil, nil)
+
+	assert.Nil(t, attachment)
+	assert.ErrorIs(t, err, ErrAttachmentKindIsInvalid)
+}
+
+func TestNewAttachmentErrorAttachmentWarrantyShouldNotExpireBeforePurchase(t *testing.T) {
+	purchasedAt := time.Date(2024, 1, 10, 0, 0, 0, 0, time.UTC)
+	warrantyExpiresAt := time.Date(2023, 1, 10, 0, 0, 0, 0, time.UTC)
+
+	attachment, err := NewAttachment(uuid.NewString(), AttachmentKindWarranty, &purchasedAt, &warrantyExpiresAt)
+
+	assert.Nil(t, attachment)
+	assert.ErrorIs(t, err, ErrAttachmentWarrantyShouldNotExpireBeforePurchase)
+}
+
+func TestAttachmentEntityID(t *testing.T) {
+	attachment := &Attachment{ID: uuid.NewString()}
+
+	assert.Equal(t, attachment.ID, attachment.EntityID())
+}
+
+func TestAttachmentEntityName(t *testing.T) {
+	attachment := &Attachment{}
+
+	assert.Equal(t, "attachment", attachment.EntityName())
+}
+
+func TestAttachmentChangeAssetID(t *testing.T) {
+	attachment := &Attachment{}
+	assetID := uuid.NewString()
+
+	attachment.ChangeAssetID(assetID)
+
+	assert.Equal(t, assetID, attachment.AssetID)
+}
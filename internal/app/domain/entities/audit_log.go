@@ -0,0 +1,161 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	AuditActionCreate                  = "create"
+	AuditActionUpdate                  = "update"
+	AuditActionDelete                  = "delete"
+	AuditActionAddItem                 = "add_item"
+	AuditActionRemoveItem              = "remove_item"
+	AuditActionTransferItem            = "transfer_item"
+	AuditActionChangeRoom              = "change_room"
+	AuditActionSignOn                  = "sign_on"
+	AuditActionLogIn                   = "log_in"
+	AuditActionLogOut                  = "log_out"
+	AuditActionFailedLogIn             = "failed_log_in"
+	AuditActionLockOut                 = "lock_out"
+	AuditActionResetPassword           = "reset_password"
+	AuditActionChangePassword          = "change_password"
+	AuditActionRequestEmailChange      = "request_email_change"
+	AuditActionConfirmEmailChange      = "confirm_email_change"
+	AuditActionRequestDeletion         = "request_deletion"
+	AuditActionEnableTwoFactor         = "enable_two_factor"
+	AuditActionDisableTwoFactor        = "disable_two_factor"
+	AuditActionRegenerateRecoveryCodes = "regenerate_recovery_codes"
+	AuditActionRevoke                  = "revoke"
+	AuditActionRedeliver               = "redeliver"
+	AuditActionRetry                   = "retry"
+	AuditActionCreateHousehold         = "create_household"
+	AuditActionInviteHouseholdMember   = "invite_household_member"
+	AuditActionAcceptInvitation        = "accept_invitation"
+	AuditActionDeclineInvitation       = "decline_invitation"
+)
+
+const (
+	AuditEntityTypeUser                = "user"
+	AuditEntityTypeAsset               = "asset"
+	AuditEntityTypePersonalAccessToken = "personal_access_token"
+	AuditEntityTypeWebhook             = "webhook"
+	AuditEntityTypeMailMessage         = "mail_message"
+	AuditEntityTypeHousehold           = "household"
+	AuditEntityTypeHouseholdInvitation = "household_invitation"
+)
+
+const (
+	AuditLogActorIDField     = "actor_id"
+	AuditLogActionField      = "action"
+	AuditLogEntityTypeField  = "entity_type"
+	AuditLogEntityIDField    = "entity_id"
+	AuditLogHouseholdIDField = "household_id"
+	AuditLogHappenedAtField  = "happened_at"
+)
+
+const auditLogUserAgentMaxLength = 255
+
+var (
+	ErrAuditLogActorIDShouldNotBeEmpty    = errors.New("actor id should not be empty")
+	ErrAuditLogActionShouldNotBeEmpty     = errors.New("action should not be empty")
+	ErrAuditLogEntityTypeShouldNotBeEmpty = errors.New("entity type should not be empty")
+	ErrAuditLogCanNotEncodeValue          = errors.New("can not encode audited value")
+)
+
+// AuditLog is an entry of the append-only log of what users did. Before and
+// After keep the audited values as JSON, they are empty when there is nothing
+// to compare, like the before of a created room.
+type AuditLog struct {
+	ID          string
+	ActorID     string
+	Action      string
+	EntityType  string
+	EntityID    string
+	HouseholdID *string
+	Before      *string
+	After       *string
+	IPAddress   string
+	UserAgent   string
+	HappenedAt  time.Time
+	CreatedAt   time.Time
+}
+
+func NewAuditLog(
+	actorID string,
+	action string,
+	entityType string,
+	entityID string,
+	householdID string,
+	before interface{},
+	after interface{},
+	ipAddress string,
+	userAgent string,
+) (*AuditLog, error) {
+	if strings.TrimSpace(actorID) == "" {
+		return nil, ErrAuditLogActorIDShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(action) == "" {
+		return nil, ErrAuditLogActionShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(entityType) == "" {
+		return nil, ErrAuditLogEntityTypeShouldNotBeEmpty
+	}
+
+	encodedBefore, err := encodeAuditValue(before)
+	if err != nil {
+		return nil, err
+	}
+
+	encodedAfter, err := encodeAuditValue(after)
+	if err != nil {
+		return nil, err
+	}
+
+	var household *string
+	if householdID != "" {
+		household = &householdID
+	}
+
+	if len(userAgent) > auditLogUserAgentMaxLength {
+		userAgent = userAgent[:auditLogUserAgentMaxLength]
+	}
+
+	return &AuditLog{
+		ID:          uuid.NewString(),
+		ActorID:     actorID,
+		Action:      action,
+		EntityType:  entityType,
+		EntityID:    entityID,
+		HouseholdID: household,
+		Before:      encodedBefore,
+		After:       encodedAfter,
+		IPAddress:   ipAddress,
+		UserAgent:   userAgent,
+		HappenedAt:  time.Now(),
+		CreatedAt:   time.Now(),
+	}, nil
+}
+
+func encodeAuditValue(value interface{}) (*string, error) {
+	if value == nil {
+		return nil, nil
+	}
+
+	bytes, err := json.Marshal(value)
+	if err != nil {
+		return nil, ErrAuditLogCanNotEncodeValue
+	}
+
+	encoded := string(bytes)
+	if encoded == "null" {
+		return nil, nil
+	}
+
+	return &encoded, nil
+}
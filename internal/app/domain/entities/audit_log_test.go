@@ -0,0 +1,109 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNewAuditLog(t *testing.T) {
+	actorID := uuid.NewString()
+	householdID := uuid.NewString()
+	before := &Room{ID: "room-id", Name: "Kitchen"}
+	after := &Room{ID: "room-id", Name: "Garage"}
+
+	auditLog, err := NewAuditLog(
+		actorID,
+		AuditActionUpdate,
+		"room",
+		"room-id",
+		householdID,
+		before,
+		after,
+		"127.0.0.1",
+		"curl/8.0",
+	)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, auditLog.ID)
+	assert.Equal(t, actorID, auditLog.ActorID)
+	assert.Equal(t, AuditActionUpdate, auditLog.Action)
+	assert.Equal(t, "room", auditLog.EntityType)
+	assert.Equal(t, "room-id", auditLog.EntityID)
+	assert.Equal(t, householdID, *auditLog.HouseholdID)
+	assert.Contains(t, *auditLog.Before, `"Name":"Kitchen"`)
+	assert.Contains(t, *auditLog.After, `"Name":"Garage"`)
+	assert.Equal(t, "127.0.0.1", auditLog.IPAddress)
+	assert.Equal(t, "curl/8.0", auditLog.UserAgent)
+	assert.False(t, auditLog.HappenedAt.IsZero())
+}
+
+func TestNewAuditLogWithoutValues(t *testing.T) {
+	var room *Room
+
+	auditLog, err := NewAuditLog(
+		uuid.NewString(),
+		AuditActionLogIn,
+		AuditEntityTypeUser,
+		uuid.NewString(),
+		"",
+		room,
+		nil,
+		"127.0.0.1",
+		strings.Repeat("a", 300),
+	)
+
+	assert.NoError(t, err)
+	assert.Nil(t, auditLog.HouseholdID)
+	assert.Nil(t, auditLog.Before)
+	assert.Nil(t, auditLog.After)
+	assert.Len(t, auditLog.UserAgent, 255)
+}
+
+func TestNewAuditLogErrorEmptyFields(t *testing.T) {
+	testCases := []struct {
+		actorID    string
+		action     string
+		entityType string
+		err        error
+	}{
+		{"", AuditActionCreate, "room", ErrAuditLogActorIDShouldNotBeEmpty},
+		{uuid.NewString(), " ", "room", ErrAuditLogActionShouldNotBeEmpty},
+		{uuid.NewString(), AuditActionCreate, "", ErrAuditLogEntityTypeShouldNotBeEmpty},
+	}
+
+	for _, testCase := range testCases {
+		auditLog, err := NewAuditLog(
+			testCase.actorID,
+			testCase.action,
+			testCase.entityType,
+			uuid.NewString(),
+			"",
+			nil,
+			nil,
+			"127.0.0.1",
+			"curl/8.0",
+		)
+
+		assert.ErrorIs(t, err, testCase.err)
+		assert.Nil(t, auditLog)
+	}
+}
+
+func TestNewAuditLogErrorCanNotEncodeValue(t *testing.T) {
+	auditLog, err := NewAuditLog(
+		uuid.NewString(),
+		AuditActionCreate,
+		"room",
+		uuid.NewString(),
+		"",
+		nil,
+		make(chan int),
+		"127.0.0.1",
+		"curl/8.0",
+	)
+
+	assert.ErrorIs(t, err, ErrAuditLogCanNotEncodeValue)
+	assert.Nil(t, auditLog)
+}
@@ -48,6 +48,14 @@ func NewBox(
 	return box, nil
 }
 
+func (b *Box) EntityID() string {
+	return b.ID
+}
+
+func (b *Box) EntityName() string {
+	return "box"
+}
+
 func (b *Box) ChangeName(name string) error {
 	if strings.TrimSpace(name) == "" {
 		return ErrBoxNameShouldNotBeEmpty
@@ -182,3 +182,21 @@ func TestBoxUpdateErrorDescriptionShouldHave255OrLessChars(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrBoxDescriptionShouldHave255OrLessChars)
 }
+
+func TestBoxEntityID(t *testing.T) {
+	box := &Box{
+		ID:   uuid.NewString(),
+		Name: random.String(100, random.Alphanumeric),
+	}
+
+	assert.Equal(t, box.ID, box.EntityID())
+}
+
+func TestBoxEntityName(t *testing.T) {
+	box := &Box{
+		ID:   uuid.NewString(),
+		Name: random.String(100, random.Alphanumeric),
+	}
+
+	assert.Equal(t, "box", box.EntityName())
+}
@@ -18,7 +18,7 @@ func TestNewAddBoxTransaction(t *testing.T) {
 		Name:        random.String(10, random.Alphanumeric),
 		Description: &itemDescription,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -50,7 +50,7 @@ func TestNewAddBoxTransactionErrorBoxTransactionQuantityShouldBePositive(t *test
 		Name:        random.String(10, random.Alphanumeric),
 		Description: &itemDescription,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -73,7 +73,7 @@ func TestNewAddBoxTransactionErrorBoxTransactionBoxIDShouldNotBeEmpty(t *testing
 		Name:        random.String(10, random.Alphanumeric),
 		Description: &itemDescription,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrHouseholdNameShouldNotBeEmpty         = errors.New("name should not be empty")
+	ErrHouseholdNameShouldHave100OrLessChars = errors.New("name should have 100 or less characters")
+)
+
+// Household is a shared inventory, it owns the rooms and items its members
+// work with.
+type Household struct {
+	ID        string
+	Name      string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewHousehold(name string) (*Household, error) {
+	household := &Household{
+		ID:        uuid.NewString(),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	err := household.ChangeName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	return household, nil
+}
+
+func (h *Household) ChangeName(name string) error {
+	if strings.TrimSpace(name) == "" {
+		return ErrHouseholdNameShouldNotBeEmpty
+	}
+
+	if len(name) > 100 {
+		return ErrHouseholdNameShouldHave100OrLessChars
+	}
+
+	h.Name = name
+	return nil
+}
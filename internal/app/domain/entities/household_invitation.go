@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	HouseholdInvitationStatusPending  = "pending"
+	HouseholdInvitationStatusAccepted = "accepted"
+	HouseholdInvitationStatusDeclined = "declined"
+)
+
+var (
+	ErrHouseholdInvitationHouseholdIDShouldNotBeEmpty = errors.New("household id should not be empty")
+	ErrHouseholdInvitationInvitedByShouldNotBeEmpty   = errors.New("invited by should not be empty")
+	ErrHouseholdInvitationIsNotPending                = errors.New("invitation was already answered")
+)
+
+type HouseholdInvitation struct {
+	ID          string
+	HouseholdID string
+	Email       string
+	Role        string
+	InvitedBy   string
+	Status      string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewHouseholdInvitation(
+	householdID string,
+	email string,
+	role string,
+	invitedBy string,
+) (*HouseholdInvitation, error) {
+	if strings.TrimSpace(householdID) == "" {
+		return nil, ErrHouseholdInvitationHouseholdIDShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(invitedBy) == "" {
+		return nil, ErrHouseholdInvitationInvitedByShouldNotBeEmpty
+	}
+
+	if err := validateEmail(email); err != nil {
+		return nil, err
+	}
+
+	if err := validateHouseholdRole(role); err != nil {
+		return nil, err
+	}
+
+	return &HouseholdInvitation{
+		ID:          uuid.NewString(),
+		HouseholdID: householdID,
+		Email:       email,
+		Role:        role,
+		InvitedBy:   invitedBy,
+		Status:      HouseholdInvitationStatusPending,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+func (i *HouseholdInvitation) IsPending() bool {
+	return i.Status == HouseholdInvitationStatusPending
+}
+
+func (i *HouseholdInvitation) Accept() error {
+	return i.answer(HouseholdInvitationStatusAccepted)
+}
+
+func (i *HouseholdInvitation) Decline() error {
+	return i.answer(HouseholdInvitationStatusDeclined)
+}
+
+func (i *HouseholdInvitation) answer(status string) error {
+	if !i.IsPending() {
+		return ErrHouseholdInvitationIsNotPending
+	}
+
+	i.Status = status
+	i.UpdatedAt = time.Now()
+	return nil
+}
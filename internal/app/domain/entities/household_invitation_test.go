@@ -0,0 +1,64 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewHouseholdInvitation(t *testing.T) {
+	householdID := uuid.NewString()
+	invitedBy := uuid.NewString()
+
+	invitation, err := NewHouseholdInvitation(householdID, "test@example.com", HouseholdRoleViewer, invitedBy)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, invitation.ID)
+	assert.Equal(t, householdID, invitation.HouseholdID)
+	assert.Equal(t, "test@example.com", invitation.Email)
+	assert.Equal(t, HouseholdRoleViewer, invitation.Role)
+	assert.Equal(t, invitedBy, invitation.InvitedBy)
+	assert.True(t, invitation.IsPending())
+}
+
+func TestNewHouseholdInvitationErrorInvalidEmail(t *testing.T) {
+	invitation, err := NewHouseholdInvitation(uuid.NewString(), "invalid", HouseholdRoleViewer, uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrUserInvalidEmailAddress)
+	assert.Nil(t, invitation)
+}
+
+func TestNewHouseholdInvitationErrorRoleIsInvalid(t *testing.T) {
+	invitation, err := NewHouseholdInvitation(uuid.NewString(), "test@example.com", "", uuid.NewString())
+
+	assert.ErrorIs(t, err, ErrHouseholdRoleIsInvalid)
+	assert.Nil(t, invitation)
+}
+
+func TestHouseholdInvitationAccept(t *testing.T) {
+	invitation := &HouseholdInvitation{Status: HouseholdInvitationStatusPending}
+
+	err := invitation.Accept()
+
+	assert.NoError(t, err)
+	assert.Equal(t, HouseholdInvitationStatusAccepted, invitation.Status)
+	assert.False(t, invitation.IsPending())
+}
+
+func TestHouseholdInvitationDecline(t *testing.T) {
+	invitation := &HouseholdInvitation{Status: HouseholdInvitationStatusPending}
+
+	err := invitation.Decline()
+
+	assert.NoError(t, err)
+	assert.Equal(t, HouseholdInvitationStatusDeclined, invitation.Status)
+}
+
+func TestHouseholdInvitationErrorAlreadyAnswered(t *testing.T) {
+	invitation := &HouseholdInvitation{Status: HouseholdInvitationStatusDeclined}
+
+	err := invitation.Accept()
+
+	assert.ErrorIs(t, err, ErrHouseholdInvitationIsNotPending)
+	assert.Equal(t, HouseholdInvitationStatusDeclined, invitation.Status)
+}
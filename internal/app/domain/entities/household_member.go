@@ -0,0 +1,83 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	HouseholdRoleOwner  = "owner"
+	HouseholdRoleEditor = "editor"
+	HouseholdRoleViewer = "viewer"
+)
+
+var (
+	ErrHouseholdMemberHouseholdIDShouldNotBeEmpty = errors.New("household id should not be empty")
+	ErrHouseholdMemberUserIDShouldNotBeEmpty      = errors.New("user id should not be empty")
+	ErrHouseholdRoleIsInvalid                     = errors.New("role should be owner, editor or viewer")
+)
+
+type HouseholdMember struct {
+	ID          string
+	HouseholdID string
+	UserID      string
+	Role        string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewHouseholdMember(householdID string, userID string, role string) (*HouseholdMember, error) {
+	if strings.TrimSpace(householdID) == "" {
+		return nil, ErrHouseholdMemberHouseholdIDShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrHouseholdMemberUserIDShouldNotBeEmpty
+	}
+
+	if err := validateHouseholdRole(role); err != nil {
+		return nil, err
+	}
+
+	return &HouseholdMember{
+		ID:          uuid.NewString(),
+		HouseholdID: householdID,
+		UserID:      userID,
+		Role:        role,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+// CanEdit tells if the member can change the rooms, boxes and items of the
+// household. Every member can see them.
+func (m *HouseholdMember) CanEdit() bool {
+	return m.Role == HouseholdRoleOwner || m.Role == HouseholdRoleEditor
+}
+
+// CanManage tells if the member can invite other people to the household.
+func (m *HouseholdMember) CanManage() bool {
+	return m.Role == HouseholdRoleOwner
+}
+
+func (m *HouseholdMember) ChangeRole(role string) error {
+	if err := validateHouseholdRole(role); err != nil {
+		return err
+	}
+
+	m.Role = role
+	m.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func validateHouseholdRole(role string) error {
+	switch role {
+	case HouseholdRoleOwner, HouseholdRoleEditor, HouseholdRoleViewer:
+		return nil
+	}
+
+	return ErrHouseholdRoleIsInvalid
+}
@@ -0,0 +1,71 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewHouseholdMember(t *testing.T) {
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+
+	member, err := NewHouseholdMember(householdID, userID, HouseholdRoleEditor)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, member.ID)
+	assert.Equal(t, householdID, member.HouseholdID)
+	assert.Equal(t, userID, member.UserID)
+	assert.Equal(t, HouseholdRoleEditor, member.Role)
+}
+
+func TestNewHouseholdMemberErrorRoleIsInvalid(t *testing.T) {
+	member, err := NewHouseholdMember(uuid.NewString(), uuid.NewString(), "admin")
+
+	assert.ErrorIs(t, err, ErrHouseholdRoleIsInvalid)
+	assert.Nil(t, member)
+}
+
+func TestNewHouseholdMemberErrorUserIDShouldNotBeEmpty(t *testing.T) {
+	member, err := NewHouseholdMember(uuid.NewString(), "", HouseholdRoleViewer)
+
+	assert.ErrorIs(t, err, ErrHouseholdMemberUserIDShouldNotBeEmpty)
+	assert.Nil(t, member)
+}
+
+func TestHouseholdMemberPermissions(t *testing.T) {
+	testCases := []struct {
+		role      string
+		canEdit   bool
+		canManage bool
+	}{
+		{HouseholdRoleOwner, true, true},
+		{HouseholdRoleEditor, true, false},
+		{HouseholdRoleViewer, false, false},
+	}
+
+	for _, testCase := range testCases {
+		member := &HouseholdMember{Role: testCase.role}
+
+		assert.Equal(t, testCase.canEdit, member.CanEdit(), testCase.role)
+		assert.Equal(t, testCase.canManage, member.CanManage(), testCase.role)
+	}
+}
+
+func TestHouseholdMemberChangeRole(t *testing.T) {
+	member := &HouseholdMember{Role: HouseholdRoleViewer}
+
+	err := member.ChangeRole(HouseholdRoleOwner)
+
+	assert.NoError(t, err)
+	assert.Equal(t, HouseholdRoleOwner, member.Role)
+}
+
+func TestHouseholdMemberChangeRoleErrorRoleIsInvalid(t *testing.T) {
+	member := &HouseholdMember{Role: HouseholdRoleViewer}
+
+	err := member.ChangeRole("admin")
+
+	assert.ErrorIs(t, err, ErrHouseholdRoleIsInvalid)
+	assert.Equal(t, HouseholdRoleViewer, member.Role)
+}
@@ -0,0 +1,36 @@
+package entities
+
+import (
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewHousehold(t *testing.T) {
+	name := random.String(100, random.Alphanumeric)
+
+	household, err := NewHousehold(name)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, household.ID)
+	assert.Equal(t, name, household.Name)
+
+	now := time.Now()
+	assert.WithinDuration(t, now, household.CreatedAt, 10*time.Second)
+	assert.WithinDuration(t, now, household.UpdatedAt, 10*time.Second)
+}
+
+func TestNewHouseholdErrorNameShouldNotBeEmpty(t *testing.T) {
+	household, err := NewHousehold(" ")
+
+	assert.ErrorIs(t, err, ErrHouseholdNameShouldNotBeEmpty)
+	assert.Nil(t, household)
+}
+
+func TestNewHouseholdErrorNameShouldHave100OrLessChars(t *testing.T) {
+	household, err := NewHousehold(random.String(101, random.Alphanumeric))
+
+	assert.ErrorIs(t, err, ErrHouseholdNameShouldHave100OrLessChars)
+	assert.Nil(t, household)
+}
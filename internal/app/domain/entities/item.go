@@ -15,7 +15,7 @@ var (
 	ErrItemDescriptionShouldNotBeEmpty                  = errors.New("description should not be empty")
 	ErrItemDescriptionShouldHaveLessThan65535Characters = errors.New("description should have less than 65535 characters")
 	ErrItemUnitShouldBeValid                            = errors.New("unit should be valid")
-	ErrItemUserIDShouldNotBeEmpty                       = errors.New("user id should not be empty")
+	ErrItemHouseholdIDShouldNotBeEmpty                  = errors.New("household id should not be empty")
 
 	ValidItemUnits = map[string]bool{
 		"kg":    true,
@@ -44,13 +44,13 @@ type Item struct {
 	Name        string
 	Description *string
 	Unit        string
-	UserID      string
+	HouseholdID string
 	Keywords    []*ItemKeyword
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
-func NewItem(sku, name string, description *string, unit string, userID string) (*Item, error) {
+func NewItem(sku, name string, description *string, unit string, householdID string) (*Item, error) {
 	item := &Item{
 		ID:        uuid.NewString(),
 		CreatedAt: time.Now(),
@@ -62,7 +62,7 @@ func NewItem(sku, name string, description *string, unit string, userID string)
 		return nil, err
 	}
 
-	err = item.ChangeUserID(userID)
+	err = item.ChangeHouseholdID(householdID)
 	if err != nil {
 		return nil, err
 	}
@@ -153,11 +153,11 @@ func (i *Item) ChangeUnit(unit string) error {
 	return nil
 }
 
-func (i *Item) ChangeUserID(userID string) error {
-	if strings.TrimSpace(userID) == "" {
-		return ErrItemUserIDShouldNotBeEmpty
+func (i *Item) ChangeHouseholdID(householdID string) error {
+	if strings.TrimSpace(householdID) == "" {
+		return ErrItemHouseholdIDShouldNotBeEmpty
 	}
 
-	i.UserID = userID
+	i.HouseholdID = householdID
 	return nil
 }
@@ -13,14 +13,14 @@ func TestNewItem(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	unit := "unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.NoError(t, err)
@@ -29,7 +29,7 @@ func TestNewItem(t *testing.T) {
 	assert.Equal(t, name, item.Name)
 	assert.Equal(t, description, *item.Description)
 	assert.Equal(t, unit, item.Unit)
-	assert.Equal(t, userID, item.UserID)
+	assert.Equal(t, householdID, item.HouseholdID)
 
 	now := time.Now()
 	assert.WithinDuration(t, now, item.CreatedAt, 10*time.Second)
@@ -41,14 +41,14 @@ func TestNewItemErrorSkuShouldNotBeEmpty(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	unit := "unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
@@ -61,14 +61,14 @@ func TestNewItemErrorSkuShouldHaveLessThan100Characters(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	unit := "unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
@@ -81,14 +81,14 @@ func TestNewItemErrorNameShouldNotBeEmpty(t *testing.T) {
 	name := ""
 	description := random.String(255, random.Alphanumeric)
 	unit := "unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
@@ -101,14 +101,14 @@ func TestNewItemErrorNameShouldHaveLessThan255Characters(t *testing.T) {
 	name := random.String(255, random.Alphanumeric) + random.String(1, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	unit := "unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
@@ -121,14 +121,14 @@ func TestNewItemErrorDescriptionShouldNotBeEmpty(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := ""
 	unit := "unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
@@ -144,14 +144,14 @@ func TestNewItemErrorDescriptionShouldHaveLessThan65535Characters(t *testing.T)
 		description += random.String(1, random.Alphanumeric)
 	}
 	unit := "unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
@@ -159,24 +159,24 @@ func TestNewItemErrorDescriptionShouldHaveLessThan65535Characters(t *testing.T)
 	assert.ErrorIs(t, err, ErrItemDescriptionShouldHaveLessThan65535Characters)
 }
 
-func TestNewItemErrorUserIDShouldNotBeEmpty(t *testing.T) {
+func TestNewItemErrorHouseholdIDShouldNotBeEmpty(t *testing.T) {
 	sku := random.String(60, random.Alphanumeric)
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	unit := "unit"
-	userID := ""
+	householdID := ""
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
 	assert.Nil(t, item)
-	assert.ErrorIs(t, err, ErrItemUserIDShouldNotBeEmpty)
+	assert.ErrorIs(t, err, ErrItemHouseholdIDShouldNotBeEmpty)
 }
 
 func TestNewItemErrorUnitShouldBeValid(t *testing.T) {
@@ -184,14 +184,14 @@ func TestNewItemErrorUnitShouldBeValid(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
 	unit := "invalid-unit"
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	item, err := NewItem(
 		sku,
 		name,
 		&description,
 		unit,
-		userID,
+		householdID,
 	)
 
 	assert.Error(t, err)
@@ -206,7 +206,7 @@ func TestItemEntityID(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: nil,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -221,7 +221,7 @@ func TestItemEntityName(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: nil,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -0,0 +1,116 @@
+package entities
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	// LoginThrottleFreeAttempts is how many failures are allowed before each
+	// new failure makes the next attempt wait.
+	LoginThrottleFreeAttempts = 3
+	LoginThrottleBaseDelay    = time.Second
+
+	// LoginThrottleIdentifierMaxLength is the size of the identifier column.
+	LoginThrottleIdentifierMaxLength = 150
+
+	loginThrottleEmailPrefix = "email:"
+	loginThrottleIPPrefix    = "ip:"
+	loginThrottleHashPrefix  = "sha256:"
+)
+
+// LoginThrottlePolicy sets when a throttle locks and for how long.
+type LoginThrottlePolicy struct {
+	MaxFailedAttempts int
+	LockoutDuration   time.Duration
+}
+
+// LoginThrottle counts the failed logins of an account or of an IP address.
+// Its Identifier is built with LoginThrottleIdentifierForEmail or
+// LoginThrottleIdentifierForIP.
+type LoginThrottle struct {
+	ID             string
+	Identifier     string
+	FailedAttempts int
+	LastFailedAt   time.Time
+	BlockedUntil   *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewLoginThrottle(identifier string, failedAt time.Time) *LoginThrottle {
+	return &LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     identifier,
+		FailedAttempts: 1,
+		LastFailedAt:   failedAt,
+		CreatedAt:      failedAt,
+		UpdatedAt:      failedAt,
+	}
+}
+
+// LoginThrottleIdentifierForEmail does not need the account to exist, so
+// unknown emails are throttled the same way as known ones.
+func LoginThrottleIdentifierForEmail(email string) string {
+	return loginThrottleIdentifier(loginThrottleEmailPrefix, strings.ToLower(strings.TrimSpace(email)))
+}
+
+func LoginThrottleIdentifierForIP(ip string) string {
+	return loginThrottleIdentifier(loginThrottleIPPrefix, ip)
+}
+
+// loginThrottleIdentifier hashes the values that do not fit in the column,
+// so a long value is still counted on its own instead of failing to save.
+func loginThrottleIdentifier(prefix string, value string) string {
+	if len(prefix)+len(value) <= LoginThrottleIdentifierMaxLength {
+		return prefix + value
+	}
+
+	hash := sha256.Sum256([]byte(value))
+
+	return prefix + loginThrottleHashPrefix + hex.EncodeToString(hash[:])
+}
+
+func (t *LoginThrottle) IsForIP() bool {
+	return strings.HasPrefix(t.Identifier, loginThrottleIPPrefix)
+}
+
+func (t *LoginThrottle) IsBlocked(now time.Time) bool {
+	return t.BlockedUntil != nil && now.Before(*t.BlockedUntil)
+}
+
+// IsStale reports whether the failures are old enough to be forgotten. Blocked
+// attempts are not counted, so a lockout that ended is always stale.
+func (t *LoginThrottle) IsStale(now time.Time, policy LoginThrottlePolicy) bool {
+	return !t.IsBlocked(now) && now.Sub(t.LastFailedAt) >= policy.LockoutDuration
+}
+
+// Block makes the next attempt wait twice as long after every failure past
+// the free ones, and locks for the lockout duration once the failures reach
+// the maximum. It returns true only on the failure that locks.
+func (t *LoginThrottle) Block(now time.Time, policy LoginThrottlePolicy) bool {
+	if t.FailedAttempts >= policy.MaxFailedAttempts {
+		blockedUntil := now.Add(policy.LockoutDuration)
+		t.BlockedUntil = &blockedUntil
+		t.UpdatedAt = now
+		return t.FailedAttempts == policy.MaxFailedAttempts
+	}
+
+	if t.FailedAttempts <= LoginThrottleFreeAttempts {
+		return false
+	}
+
+	delay := LoginThrottleBaseDelay << (t.FailedAttempts - LoginThrottleFreeAttempts - 1)
+	if delay <= 0 || delay > policy.LockoutDuration {
+		delay = policy.LockoutDuration
+	}
+
+	blockedUntil := now.Add(delay)
+	t.BlockedUntil = &blockedUntil
+	t.UpdatedAt = now
+
+	return false
+}
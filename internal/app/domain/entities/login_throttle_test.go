@@ -0,0 +1,106 @@
+package entities
+
+import (
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewLoginThrottle(t *testing.T) {
+	now := time.Now()
+
+	throttle := NewLoginThrottle(LoginThrottleIdentifierForEmail(" Test@Example.com "), now)
+
+	assert.NotEmpty(t, throttle.ID)
+	assert.Equal(t, "email:test@example.com", throttle.Identifier)
+	assert.Equal(t, 1, throttle.FailedAttempts)
+	assert.Equal(t, now, throttle.LastFailedAt)
+	assert.Nil(t, throttle.BlockedUntil)
+	assert.False(t, throttle.IsBlocked(now))
+	assert.False(t, throttle.IsForIP())
+}
+
+func TestLoginThrottleIdentifierForIP(t *testing.T) {
+	throttle := NewLoginThrottle(LoginThrottleIdentifierForIP("127.0.0.1"), time.Now())
+
+	assert.Equal(t, "ip:127.0.0.1", throttle.Identifier)
+	assert.True(t, throttle.IsForIP())
+}
+
+func TestLoginThrottleIdentifierHashesLongValues(t *testing.T) {
+	email := strings.Repeat("a", 150) + "@example.com"
+	ip := strings.Repeat("1", 200)
+
+	emailIdentifier := LoginThrottleIdentifierForEmail(email)
+	ipIdentifier := LoginThrottleIdentifierForIP(ip)
+
+	assert.LessOrEqual(t, len(emailIdentifier), LoginThrottleIdentifierMaxLength)
+	assert.True(t, strings.HasPrefix(emailIdentifier, "email:sha256:"))
+	assert.Equal(t, emailIdentifier, LoginThrottleIdentifierForEmail(strings.ToUpper(email)))
+	assert.NotEqual(t, emailIdentifier, LoginThrottleIdentifierForEmail("b"+email))
+	assert.LessOrEqual(t, len(ipIdentifier), LoginThrottleIdentifierMaxLength)
+	assert.True(t, strings.HasPrefix(ipIdentifier, "ip:sha256:"))
+}
+
+func TestLoginThrottleBlock(t *testing.T) {
+	now := time.Now()
+	policy := LoginThrottlePolicy{MaxFailedAttempts: 10, LockoutDuration: 15 * time.Minute}
+
+	testCases := []struct {
+		name           string
+		failedAttempts int
+		expectedDelay  time.Duration
+		expectedLocked bool
+	}{
+		{"free attempt", LoginThrottleFreeAttempts, 0, false},
+		{"first delayed attempt", LoginThrottleFreeAttempts + 1, time.Second, false},
+		{"second delayed attempt", LoginThrottleFreeAttempts + 2, 2 * time.Second, false},
+		{"fourth delayed attempt", LoginThrottleFreeAttempts + 4, 8 * time.Second, false},
+		{"lock", 10, 15 * time.Minute, true},
+		{"already locked", 11, 15 * time.Minute, false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			throttle := &LoginThrottle{FailedAttempts: testCase.failedAttempts, LastFailedAt: now}
+
+			locked := throttle.Block(now, policy)
+
+			assert.Equal(t, testCase.expectedLocked, locked)
+			if testCase.expectedDelay == 0 {
+				assert.Nil(t, throttle.BlockedUntil)
+				assert.False(t, throttle.IsBlocked(now))
+				return
+			}
+			assert.Equal(t, now.Add(testCase.expectedDelay), *throttle.BlockedUntil)
+			assert.True(t, throttle.IsBlocked(now))
+			assert.False(t, throttle.IsBlocked(now.Add(testCase.expectedDelay)))
+		})
+	}
+}
+
+func TestLoginThrottleBlockDelayIsCappedByLockout(t *testing.T) {
+	now := time.Now()
+	policy := LoginThrottlePolicy{MaxFailedAttempts: 100, LockoutDuration: time.Minute}
+	throttle := &LoginThrottle{FailedAttempts: 50, LastFailedAt: now}
+
+	locked := throttle.Block(now, policy)
+
+	assert.False(t, locked)
+	assert.Equal(t, now.Add(time.Minute), *throttle.BlockedUntil)
+}
+
+func TestLoginThrottleIsStale(t *testing.T) {
+	now := time.Now()
+	policy := LoginThrottlePolicy{MaxFailedAttempts: 10, LockoutDuration: 15 * time.Minute}
+	blockedUntil := now.Add(time.Minute)
+
+	recent := &LoginThrottle{LastFailedAt: now.Add(-time.Minute)}
+	old := &LoginThrottle{LastFailedAt: now.Add(-20 * time.Minute)}
+	blocked := &LoginThrottle{LastFailedAt: now.Add(-20 * time.Minute), BlockedUntil: &blockedUntil}
+
+	assert.False(t, recent.IsStale(now, policy))
+	assert.True(t, old.IsStale(now, policy))
+	assert.False(t, blocked.IsStale(now, policy))
+}
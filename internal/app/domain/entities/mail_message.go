@@ -0,0 +1,114 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	MailMessageStatusPending = "pending"
+	MailMessageStatusSent    = "sent"
+	MailMessageStatusDead    = "dead"
+)
+
+const mailMessageLastErrorMaxLength = 1000
+
+var (
+	ErrMailMessageRecipientShouldNotBeEmpty = errors.New("recipient should not be empty")
+	ErrMailMessageSubjectShouldNotBeEmpty   = errors.New("subject should not be empty")
+	ErrMailMessageShouldBeDead              = errors.New("only dead mail messages can be retried")
+)
+
+// MailMessage is an email waiting in the queue to be sent, or already sent
+// or dead. It keeps the error of the last attempt so it can be inspected. The
+// body is removed once it is sent, as it can hold links to reset a password
+// or to verify an email.
+type MailMessage struct {
+	ID          string
+	Recipient   string
+	Subject     string
+	Text        string
+	HTML        string
+	Status      string
+	Attempts    int
+	LastError   *string
+	AvailableAt time.Time
+	SentAt      *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewMailMessage(recipient string, subject string, text string, html string) (*MailMessage, error) {
+	if strings.TrimSpace(recipient) == "" {
+		return nil, ErrMailMessageRecipientShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(subject) == "" {
+		return nil, ErrMailMessageSubjectShouldNotBeEmpty
+	}
+
+	now := time.Now()
+
+	return &MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   recipient,
+		Subject:     subject,
+		Text:        text,
+		HTML:        html,
+		Status:      MailMessageStatusPending,
+		AvailableAt: now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (m *MailMessage) IsPending() bool {
+	return m.Status == MailMessageStatusPending
+}
+
+func (m *MailMessage) MarkSent(now time.Time) {
+	m.Text = ""
+	m.HTML = ""
+	m.Status = MailMessageStatusSent
+	m.Attempts++
+	m.LastError = nil
+	m.SentAt = &now
+	m.UpdatedAt = now
+}
+
+// MarkFailed waits for the backoff of the policy, and leaves the message dead
+// once it runs out of attempts.
+func (m *MailMessage) MarkFailed(reason error, now time.Time, policy RetryPolicy) {
+	m.Attempts++
+
+	lastError := reason.Error()
+	if len(lastError) > mailMessageLastErrorMaxLength {
+		lastError = lastError[:mailMessageLastErrorMaxLength]
+	}
+	m.LastError = &lastError
+	m.UpdatedAt = now
+
+	if policy.IsExhausted(m.Attempts) {
+		m.Status = MailMessageStatusDead
+		return
+	}
+
+	m.AvailableAt = now.Add(policy.Backoff(m.Attempts))
+}
+
+// Retry puts a dead message back in the queue with all of its attempts. The
+// last error is kept until the next attempt.
+func (m *MailMessage) Retry(now time.Time) error {
+	if m.Status != MailMessageStatusDead {
+		return ErrMailMessageShouldBeDead
+	}
+
+	m.Status = MailMessageStatusPending
+	m.Attempts = 0
+	m.AvailableAt = now
+	m.UpdatedAt = now
+
+	return nil
+}
@@ -0,0 +1,125 @@
+package entities
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewMailMessage(t *testing.T) {
+	message, err := NewMailMessage("test@example.com", "Verify your email", "text", "<p>html</p>")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, message.ID)
+	assert.Equal(t, "test@example.com", message.Recipient)
+	assert.Equal(t, "Verify your email", message.Subject)
+	assert.Equal(t, "text", message.Text)
+	assert.Equal(t, "<p>html</p>", message.HTML)
+	assert.Equal(t, MailMessageStatusPending, message.Status)
+	assert.Equal(t, 0, message.Attempts)
+	assert.Nil(t, message.LastError)
+	assert.Nil(t, message.SentAt)
+	assert.Equal(t, message.CreatedAt, message.AvailableAt)
+	assert.True(t, message.IsPending())
+}
+
+func TestNewMailMessageErrors(t *testing.T) {
+	message, err := NewMailMessage(" ", "Verify your email", "text", "")
+
+	assert.Nil(t, message)
+	assert.ErrorIs(t, err, ErrMailMessageRecipientShouldNotBeEmpty)
+
+	message, err = NewMailMessage("test@example.com", "", "text", "")
+
+	assert.Nil(t, message)
+	assert.ErrorIs(t, err, ErrMailMessageSubjectShouldNotBeEmpty)
+}
+
+func TestMailMessageMarkSent(t *testing.T) {
+	now := time.Now()
+	lastError := "error"
+	message := &MailMessage{Text: "text", HTML: "<p>html</p>", Status: MailMessageStatusPending, Attempts: 1, LastError: &lastError}
+
+	message.MarkSent(now)
+
+	assert.Empty(t, message.Text)
+	assert.Empty(t, message.HTML)
+	assert.Equal(t, MailMessageStatusSent, message.Status)
+	assert.Equal(t, 2, message.Attempts)
+	assert.Nil(t, message.LastError)
+	assert.Equal(t, now, *message.SentAt)
+	assert.Equal(t, now, message.UpdatedAt)
+	assert.False(t, message.IsPending())
+}
+
+func TestMailMessageMarkFailed(t *testing.T) {
+	now := time.Now()
+	policy := RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour}
+
+	testCases := []struct {
+		name            string
+		attempts        int
+		expectedBackoff time.Duration
+		expectedStatus  string
+	}{
+		{"first failure", 0, time.Minute, MailMessageStatusPending},
+		{"second failure", 1, 2 * time.Minute, MailMessageStatusPending},
+		{"dead", 2, 0, MailMessageStatusDead},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			message := &MailMessage{Status: MailMessageStatusPending, Attempts: testCase.attempts, AvailableAt: now}
+
+			message.MarkFailed(errors.New("421 service not available"), now, policy)
+
+			assert.Equal(t, testCase.expectedStatus, message.Status)
+			assert.Equal(t, testCase.attempts+1, message.Attempts)
+			assert.Equal(t, "421 service not available", *message.LastError)
+			assert.Equal(t, now.Add(testCase.expectedBackoff), message.AvailableAt)
+			assert.Equal(t, now, message.UpdatedAt)
+		})
+	}
+}
+
+func TestMailMessageMarkFailedTruncatesLastError(t *testing.T) {
+	message := &MailMessage{Status: MailMessageStatusPending}
+
+	message.MarkFailed(errors.New(strings.Repeat("a", 2000)), time.Now(), RetryPolicy{MaxAttempts: 3})
+
+	assert.Len(t, *message.LastError, 1000)
+}
+
+func TestMailMessageRetry(t *testing.T) {
+	now := time.Now()
+	lastError := "421 service not available"
+	message := &MailMessage{
+		Status:      MailMessageStatusDead,
+		Attempts:    8,
+		LastError:   &lastError,
+		AvailableAt: now.Add(-time.Hour),
+	}
+
+	err := message.Retry(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, MailMessageStatusPending, message.Status)
+	assert.Equal(t, 0, message.Attempts)
+	assert.Equal(t, &lastError, message.LastError)
+	assert.Equal(t, now, message.AvailableAt)
+	assert.Equal(t, now, message.UpdatedAt)
+}
+
+func TestMailMessageRetryErrorNotDead(t *testing.T) {
+	for _, status := range []string{MailMessageStatusPending, MailMessageStatusSent} {
+		message := &MailMessage{Status: status, Attempts: 1}
+
+		err := message.Retry(time.Now())
+
+		assert.ErrorIs(t, err, ErrMailMessageShouldBeDead)
+		assert.Equal(t, status, message.Status)
+		assert.Equal(t, 1, message.Attempts)
+	}
+}
@@ -0,0 +1,99 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrNotificationDigestEntryUserIDShouldNotBeEmpty  = errors.New("user id should not be empty")
+	ErrNotificationDigestEntryMessageShouldNotBeEmpty = errors.New("message should not be empty")
+	ErrNotificationDigestEntryFrequencyIsNotADigest   = errors.New("frequency should be hourly or daily")
+)
+
+// NotificationDigestEntry is a notification waiting to be sent in the digest
+// of a user. DueAt is the end of the hour, or of the day in UTC, it was
+// queued in, so the entries of the same period are sent in one email. A Held
+// entry waits for its user to verify their email, whatever its due at.
+type NotificationDigestEntry struct {
+	ID         string
+	UserID     string
+	EventType  string
+	Message    string
+	HappenedAt time.Time
+	DueAt      time.Time
+	Held       bool
+	CreatedAt  time.Time
+}
+
+func NewNotificationDigestEntry(
+	userID string,
+	eventType string,
+	message string,
+	happenedAt time.Time,
+	frequency string,
+	now time.Time,
+) (*NotificationDigestEntry, error) {
+	if err := validateNotificationDigestEntry(userID, message); err != nil {
+		return nil, err
+	}
+
+	var dueAt time.Time
+	switch frequency {
+	case NotificationFrequencyHourly:
+		dueAt = now.UTC().Truncate(time.Hour).Add(time.Hour)
+	case NotificationFrequencyDaily:
+		dueAt = now.UTC().Truncate(24 * time.Hour).Add(24 * time.Hour)
+	default:
+		return nil, ErrNotificationDigestEntryFrequencyIsNotADigest
+	}
+
+	return &NotificationDigestEntry{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		EventType:  eventType,
+		Message:    message,
+		HappenedAt: happenedAt,
+		DueAt:      dueAt,
+		CreatedAt:  now,
+	}, nil
+}
+
+// NewHeldNotificationDigestEntry holds a notification of a user whose email
+// is not verified yet, it is sent in a digest once they verify it.
+func NewHeldNotificationDigestEntry(
+	userID string,
+	eventType string,
+	message string,
+	happenedAt time.Time,
+	now time.Time,
+) (*NotificationDigestEntry, error) {
+	if err := validateNotificationDigestEntry(userID, message); err != nil {
+		return nil, err
+	}
+
+	return &NotificationDigestEntry{
+		ID:         uuid.NewString(),
+		UserID:     userID,
+		EventType:  eventType,
+		Message:    message,
+		HappenedAt: happenedAt,
+		DueAt:      now,
+		Held:       true,
+		CreatedAt:  now,
+	}, nil
+}
+
+func validateNotificationDigestEntry(userID string, message string) error {
+	if strings.TrimSpace(userID) == "" {
+		return ErrNotificationDigestEntryUserIDShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(message) == "" {
+		return ErrNotificationDigestEntryMessageShouldNotBeEmpty
+	}
+
+	return nil
+}
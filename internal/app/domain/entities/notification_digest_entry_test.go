@@ -0,0 +1,88 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewNotificationDigestEntry(t *testing.T) {
+	userID := uuid.NewString()
+	happenedAt := time.Date(2026, 10, 19, 14, 20, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 19, 14, 25, 0, 0, time.UTC)
+
+	testCases := []struct {
+		name          string
+		frequency     string
+		expectedDueAt time.Time
+	}{
+		{"hourly", NotificationFrequencyHourly, time.Date(2026, 10, 19, 15, 0, 0, 0, time.UTC)},
+		{"daily", NotificationFrequencyDaily, time.Date(2026, 10, 20, 0, 0, 0, 0, time.UTC)},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			entry, err := NewNotificationDigestEntry(userID, "BoxItemAddedEvent", "2 item added", happenedAt, testCase.frequency, now)
+
+			assert.NoError(t, err)
+			assert.NotEmpty(t, entry.ID)
+			assert.Equal(t, userID, entry.UserID)
+			assert.Equal(t, "BoxItemAddedEvent", entry.EventType)
+			assert.Equal(t, "2 item added", entry.Message)
+			assert.Equal(t, happenedAt, entry.HappenedAt)
+			assert.True(t, testCase.expectedDueAt.Equal(entry.DueAt))
+			assert.False(t, entry.Held)
+			assert.Equal(t, now, entry.CreatedAt)
+		})
+	}
+}
+
+func TestNewNotificationDigestEntryErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		userID        string
+		message       string
+		frequency     string
+		expectedError error
+	}{
+		{"empty user id", " ", "2 item added", NotificationFrequencyDaily, ErrNotificationDigestEntryUserIDShouldNotBeEmpty},
+		{"empty message", uuid.NewString(), " ", NotificationFrequencyDaily, ErrNotificationDigestEntryMessageShouldNotBeEmpty},
+		{"instant frequency", uuid.NewString(), "2 item added", NotificationFrequencyInstant, ErrNotificationDigestEntryFrequencyIsNotADigest},
+		{"off frequency", uuid.NewString(), "2 item added", NotificationFrequencyOff, ErrNotificationDigestEntryFrequencyIsNotADigest},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			entry, err := NewNotificationDigestEntry(testCase.userID, "BoxItemAddedEvent", testCase.message, time.Now(), testCase.frequency, time.Now())
+
+			assert.Nil(t, entry)
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestNewHeldNotificationDigestEntry(t *testing.T) {
+	userID := uuid.NewString()
+	happenedAt := time.Date(2026, 10, 19, 14, 20, 0, 0, time.UTC)
+	now := time.Date(2026, 10, 19, 14, 25, 0, 0, time.UTC)
+
+	entry, err := NewHeldNotificationDigestEntry(userID, "BoxItemAddedEvent", "2 item added", happenedAt, now)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, entry.ID)
+	assert.Equal(t, userID, entry.UserID)
+	assert.Equal(t, "BoxItemAddedEvent", entry.EventType)
+	assert.Equal(t, "2 item added", entry.Message)
+	assert.Equal(t, happenedAt, entry.HappenedAt)
+	assert.Equal(t, now, entry.DueAt)
+	assert.True(t, entry.Held)
+	assert.Equal(t, now, entry.CreatedAt)
+}
+
+func TestNewHeldNotificationDigestEntryErrors(t *testing.T) {
+	entry, err := NewHeldNotificationDigestEntry(uuid.NewString(), "BoxItemAddedEvent", " ", time.Now(), time.Now())
+
+	assert.Nil(t, entry)
+	assert.ErrorIs(t, err, ErrNotificationDigestEntryMessageShouldNotBeEmpty)
+}
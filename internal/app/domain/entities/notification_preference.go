@@ -0,0 +1,128 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	NotificationFrequencyInstant = "instant"
+	NotificationFrequencyHourly  = "hourly"
+	NotificationFrequencyDaily   = "daily"
+	NotificationFrequencyOff     = "off"
+)
+
+// NotificationEventTypes are the events a user is emailed about, named as
+// services.GetEventType names them.
+var NotificationEventTypes = []string{
+	"BoxItemAddedEvent",
+	"BoxItemRemovedEvent",
+}
+
+var (
+	ErrNotificationPreferenceUserIDShouldNotBeEmpty  = errors.New("user id should not be empty")
+	ErrNotificationPreferenceEventTypeIsNotSupported = errors.New("event type is not supported")
+	ErrNotificationPreferenceRoomIDShouldNotBeEmpty  = errors.New("room id should not be empty")
+	ErrNotificationPreferenceFrequencyIsNotSupported = errors.New("frequency should be instant, hourly, daily or off")
+)
+
+// NotificationPreference is how often a user is emailed about an event type.
+// Without a RoomID it applies to every room, with one it only applies to the
+// boxes of that room and takes precedence over the one without.
+type NotificationPreference struct {
+	ID        string
+	UserID    string
+	EventType string
+	RoomID    *string
+	Frequency string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewNotificationPreference(
+	userID string,
+	eventType string,
+	roomID *string,
+	frequency string,
+) (*NotificationPreference, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrNotificationPreferenceUserIDShouldNotBeEmpty
+	}
+
+	if !containsString(NotificationEventTypes, eventType) {
+		return nil, ErrNotificationPreferenceEventTypeIsNotSupported
+	}
+
+	if roomID != nil && strings.TrimSpace(*roomID) == "" {
+		return nil, ErrNotificationPreferenceRoomIDShouldNotBeEmpty
+	}
+
+	if !isNotificationFrequency(frequency) {
+		return nil, ErrNotificationPreferenceFrequencyIsNotSupported
+	}
+
+	return &NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		EventType: eventType,
+		RoomID:    roomID,
+		Frequency: frequency,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+func (p *NotificationPreference) ChangeFrequency(frequency string) error {
+	if !isNotificationFrequency(frequency) {
+		return ErrNotificationPreferenceFrequencyIsNotSupported
+	}
+
+	p.Frequency = frequency
+	p.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// IsFor tells if the preference is the one of the event type for the room,
+// or for every room when roomID is nil.
+func (p *NotificationPreference) IsFor(eventType string, roomID *string) bool {
+	if p.EventType != eventType {
+		return false
+	}
+
+	if p.RoomID == nil || roomID == nil {
+		return p.RoomID == nil && roomID == nil
+	}
+
+	return *p.RoomID == *roomID
+}
+
+// ResolveNotificationFrequency returns the frequency of the preference of the
+// room, or else the one for every room, or else instant.
+func ResolveNotificationFrequency(
+	preferences []*NotificationPreference,
+	eventType string,
+	roomID string,
+) string {
+	frequency := NotificationFrequencyInstant
+	for _, preference := range preferences {
+		if preference.IsFor(eventType, &roomID) {
+			return preference.Frequency
+		}
+
+		if preference.IsFor(eventType, nil) {
+			frequency = preference.Frequency
+		}
+	}
+
+	return frequency
+}
+
+func isNotificationFrequency(frequency string) bool {
+	return frequency == NotificationFrequencyInstant ||
+		frequency == NotificationFrequencyHourly ||
+		frequency == NotificationFrequencyDaily ||
+		frequency == NotificationFrequencyOff
+}
@@ -0,0 +1,93 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewNotificationPreference(t *testing.T) {
+	userID := uuid.NewString()
+	roomID := uuid.NewString()
+
+	preference, err := NewNotificationPreference(userID, "BoxItemAddedEvent", &roomID, NotificationFrequencyHourly)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, preference)
+	assert.NotEmpty(t, preference.ID)
+	assert.Equal(t, userID, preference.UserID)
+	assert.Equal(t, "BoxItemAddedEvent", preference.EventType)
+	assert.Equal(t, &roomID, preference.RoomID)
+	assert.Equal(t, NotificationFrequencyHourly, preference.Frequency)
+}
+
+func TestNewNotificationPreferenceErrors(t *testing.T) {
+	emptyRoomID := " "
+
+	testCases := []struct {
+		name          string
+		userID        string
+		eventType     string
+		roomID        *string
+		frequency     string
+		expectedError error
+	}{
+		{"empty user id", " ", "BoxItemAddedEvent", nil, NotificationFrequencyDaily, ErrNotificationPreferenceUserIDShouldNotBeEmpty},
+		{"unsupported event type", uuid.NewString(), "RoomCreatedEvent", nil, NotificationFrequencyDaily, ErrNotificationPreferenceEventTypeIsNotSupported},
+		{"empty room id", uuid.NewString(), "BoxItemAddedEvent", &emptyRoomID, NotificationFrequencyDaily, ErrNotificationPreferenceRoomIDShouldNotBeEmpty},
+		{"unsupported frequency", uuid.NewString(), "BoxItemAddedEvent", nil, "weekly", ErrNotificationPreferenceFrequencyIsNotSupported},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			preference, err := NewNotificationPreference(testCase.userID, testCase.eventType, testCase.roomID, testCase.frequency)
+
+			assert.Nil(t, preference)
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
+
+func TestNotificationPreferenceChangeFrequency(t *testing.T) {
+	preference, _ := NewNotificationPreference(uuid.NewString(), "BoxItemAddedEvent", nil, NotificationFrequencyInstant)
+
+	err := preference.ChangeFrequency(NotificationFrequencyOff)
+
+	assert.NoError(t, err)
+	assert.Equal(t, NotificationFrequencyOff, preference.Frequency)
+
+	err = preference.ChangeFrequency("weekly")
+
+	assert.ErrorIs(t, err, ErrNotificationPreferenceFrequencyIsNotSupported)
+	assert.Equal(t, NotificationFrequencyOff, preference.Frequency)
+}
+
+func TestResolveNotificationFrequency(t *testing.T) {
+	roomID := uuid.NewString()
+	otherRoomID := uuid.NewString()
+	everyRoom := &NotificationPreference{EventType: "BoxItemAddedEvent", Frequency: NotificationFrequencyDaily}
+	room := &NotificationPreference{EventType: "BoxItemAddedEvent", RoomID: &roomID, Frequency: NotificationFrequencyOff}
+	removed := &NotificationPreference{EventType: "BoxItemRemovedEvent", Frequency: NotificationFrequencyHourly}
+
+	testCases := []struct {
+		name              string
+		preferences       []*NotificationPreference
+		eventType         string
+		roomID            string
+		expectedFrequency string
+	}{
+		{"no preferences", []*NotificationPreference{}, "BoxItemAddedEvent", roomID, NotificationFrequencyInstant},
+		{"preference of the room", []*NotificationPreference{everyRoom, room}, "BoxItemAddedEvent", roomID, NotificationFrequencyOff},
+		{"preference of the room first", []*NotificationPreference{room, everyRoom}, "BoxItemAddedEvent", roomID, NotificationFrequencyOff},
+		{"preference for every room", []*NotificationPreference{everyRoom, room}, "BoxItemAddedEvent", otherRoomID, NotificationFrequencyDaily},
+		{"preference of other event type", []*NotificationPreference{removed}, "BoxItemAddedEvent", roomID, NotificationFrequencyInstant},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			frequency := ResolveNotificationFrequency(testCase.preferences, testCase.eventType, testCase.roomID)
+
+			assert.Equal(t, testCase.expectedFrequency, frequency)
+		})
+	}
+}
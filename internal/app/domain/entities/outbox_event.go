@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"encoding/json"
+	"errors"
+	"github.com/google/uuid"
+	"time"
+)
+
+const (
+	OutboxEventStatusPending   = "pending"
+	OutboxEventStatusDelivered = "delivered"
+	OutboxEventStatusDead      = "dead"
+)
+
+const outboxEventLastErrorMaxLength = 1000
+
+var (
+	ErrOutboxEventTypeShouldNotBeEmpty = errors.New("outbox event type should not be empty")
+	ErrOutboxEventCanNotEncodePayload  = errors.New("can not encode outbox event payload")
+)
+
+// OutboxEvent is an event written in the same transaction as the change that
+// raised it. It stays pending until the dispatcher delivers it, and becomes
+// dead once its attempts run out.
+type OutboxEvent struct {
+	ID          string
+	Type        string
+	Payload     string
+	Status      string
+	Attempts    int
+	LastError   *string
+	AvailableAt time.Time
+	DeliveredAt *time.Time
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewOutboxEvent(eventType string, event interface{}) (*OutboxEvent, error) {
+	if eventType == "" {
+		return nil, ErrOutboxEventTypeShouldNotBeEmpty
+	}
+
+	payload, err := json.Marshal(event)
+	if err != nil {
+		return nil, ErrOutboxEventCanNotEncodePayload
+	}
+
+	now := time.Now()
+
+	return &OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        eventType,
+		Payload:     string(payload),
+		Status:      OutboxEventStatusPending,
+		AvailableAt: now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (e *OutboxEvent) IsPending() bool {
+	return e.Status == OutboxEventStatusPending
+}
+
+func (e *OutboxEvent) MarkDelivered(now time.Time) {
+	e.Status = OutboxEventStatusDelivered
+	e.Attempts++
+	e.LastError = nil
+	e.DeliveredAt = &now
+	e.UpdatedAt = now
+}
+
+// MarkFailed waits for the backoff of the policy, and leaves the event dead
+// once it runs out of attempts.
+func (e *OutboxEvent) MarkFailed(reason error, now time.Time, policy RetryPolicy) {
+	e.Attempts++
+
+	lastError := reason.Error()
+	if len(lastError) > outboxEventLastErrorMaxLength {
+		lastError = lastError[:outboxEventLastErrorMaxLength]
+	}
+	e.LastError = &lastError
+	e.UpdatedAt = now
+
+	if policy.IsExhausted(e.Attempts) {
+		e.Status = OutboxEventStatusDead
+		return
+	}
+
+	e.AvailableAt = now.Add(policy.Backoff(e.Attempts))
+}
@@ -0,0 +1,11 @@
+package entities
+
+import "time"
+
+// OutboxEventHandler records that a handler already got an outbox event, so
+// a retry of the event only runs the handlers that failed.
+type OutboxEventHandler struct {
+	EventID     string
+	Handler     string
+	DeliveredAt time.Time
+}
@@ -0,0 +1,92 @@
+package entities
+
+import (
+	"errors"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewOutboxEvent(t *testing.T) {
+	event, err := NewOutboxEvent("StringEvent", struct{ Value string }{"hello"})
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, event.ID)
+	assert.Equal(t, "StringEvent", event.Type)
+	assert.Equal(t, `{"Value":"hello"}`, event.Payload)
+	assert.Equal(t, OutboxEventStatusPending, event.Status)
+	assert.Equal(t, 0, event.Attempts)
+	assert.Nil(t, event.LastError)
+	assert.Nil(t, event.DeliveredAt)
+	assert.Equal(t, event.CreatedAt, event.AvailableAt)
+	assert.True(t, event.IsPending())
+}
+
+func TestNewOutboxEventErrorTypeShouldNotBeEmpty(t *testing.T) {
+	event, err := NewOutboxEvent("", struct{}{})
+
+	assert.Nil(t, event)
+	assert.ErrorIs(t, err, ErrOutboxEventTypeShouldNotBeEmpty)
+}
+
+func TestNewOutboxEventErrorCanNotEncodePayload(t *testing.T) {
+	event, err := NewOutboxEvent("ChannelEvent", make(chan int))
+
+	assert.Nil(t, event)
+	assert.ErrorIs(t, err, ErrOutboxEventCanNotEncodePayload)
+}
+
+func TestOutboxEventMarkDelivered(t *testing.T) {
+	now := time.Now()
+	lastError := "error"
+	event := &OutboxEvent{Status: OutboxEventStatusPending, Attempts: 1, LastError: &lastError}
+
+	event.MarkDelivered(now)
+
+	assert.Equal(t, OutboxEventStatusDelivered, event.Status)
+	assert.Equal(t, 2, event.Attempts)
+	assert.Nil(t, event.LastError)
+	assert.Equal(t, now, *event.DeliveredAt)
+	assert.Equal(t, now, event.UpdatedAt)
+	assert.False(t, event.IsPending())
+}
+
+func TestOutboxEventMarkFailed(t *testing.T) {
+	now := time.Now()
+	policy := RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Minute, MaxBackoff: 5 * time.Minute}
+
+	testCases := []struct {
+		name            string
+		attempts        int
+		expectedBackoff time.Duration
+		expectedStatus  string
+	}{
+		{"first failure", 0, time.Minute, OutboxEventStatusPending},
+		{"second failure", 1, 2 * time.Minute, OutboxEventStatusPending},
+		{"third failure", 2, 4 * time.Minute, OutboxEventStatusPending},
+		{"capped backoff", 3, 5 * time.Minute, OutboxEventStatusPending},
+		{"dead", 4, 0, OutboxEventStatusDead},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			event := &OutboxEvent{Status: OutboxEventStatusPending, Attempts: testCase.attempts, AvailableAt: now}
+
+			event.MarkFailed(errors.New("handler failed"), now, policy)
+
+			assert.Equal(t, testCase.attempts+1, event.Attempts)
+			assert.Equal(t, testCase.expectedStatus, event.Status)
+			assert.Equal(t, "handler failed", *event.LastError)
+			assert.Equal(t, now.Add(testCase.expectedBackoff), event.AvailableAt)
+		})
+	}
+}
+
+func TestOutboxEventMarkFailedTruncatesLastError(t *testing.T) {
+	event := &OutboxEvent{Status: OutboxEventStatusPending}
+
+	event.MarkFailed(errors.New(strings.Repeat("a", 2000)), time.Now(), RetryPolicy{MaxAttempts: 3})
+
+	assert.Len(t, *event.LastError, 1000)
+}
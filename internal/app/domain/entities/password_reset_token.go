@@ -0,0 +1,59 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrPasswordResetTokenUserIDShouldNotBeEmpty = errors.New("user id should not be empty")
+	ErrPasswordResetTokenCanNotGenerateValue    = errors.New("can not generate password reset token")
+)
+
+type PasswordResetToken struct {
+	ID        string
+	UserID    string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewPasswordResetToken returns the token and its plain value, only the hash
+// of the value is kept.
+func NewPasswordResetToken(userID string, duration time.Duration) (*PasswordResetToken, string, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, "", ErrPasswordResetTokenUserIDShouldNotBeEmpty
+	}
+
+	value, err := generateSecureToken()
+	if err != nil {
+		return nil, "", ErrPasswordResetTokenCanNotGenerateValue
+	}
+
+	token := &PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		TokenHash: HashPasswordResetToken(value),
+		ExpiresAt: time.Now().Add(duration),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	return token, value, nil
+}
+
+func HashPasswordResetToken(value string) string {
+	return hashSecureToken(value)
+}
+
+func (t *PasswordResetToken) IsExpired() bool {
+	return !time.Now().Before(t.ExpiresAt)
+}
+
+func (t *PasswordResetToken) WasUsed() bool {
+	return t.UsedAt != nil
+}
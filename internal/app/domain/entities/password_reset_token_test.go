@@ -0,0 +1,46 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewPasswordResetToken(t *testing.T) {
+	userID := uuid.NewString()
+
+	token, value, err := NewPasswordResetToken(userID, time.Hour)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.NotEmpty(t, value)
+	assert.NotEmpty(t, token.ID)
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, HashPasswordResetToken(value), token.TokenHash)
+	assert.NotEqual(t, value, token.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Second)
+	assert.False(t, token.IsExpired())
+	assert.False(t, token.WasUsed())
+}
+
+func TestNewPasswordResetTokenErrorUserIDShouldNotBeEmpty(t *testing.T) {
+	token, value, err := NewPasswordResetToken("", time.Hour)
+
+	assert.ErrorIs(t, err, ErrPasswordResetTokenUserIDShouldNotBeEmpty)
+	assert.Nil(t, token)
+	assert.Empty(t, value)
+}
+
+func TestPasswordResetTokenIsExpired(t *testing.T) {
+	token := &PasswordResetToken{ExpiresAt: time.Now().Add(-time.Second)}
+
+	assert.True(t, token.IsExpired())
+}
+
+func TestPasswordResetTokenWasUsed(t *testing.T) {
+	usedAt := time.Now()
+	token := &PasswordResetToken{UsedAt: &usedAt}
+
+	assert.True(t, token.WasUsed())
+}
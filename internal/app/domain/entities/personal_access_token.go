@@ -0,0 +1,108 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	PersonalAccessTokenPrefix         = "hi_pat_"
+	PersonalAccessTokenScopeRead      = "read"
+	PersonalAccessTokenScopeReadWrite = "read_write"
+)
+
+var (
+	ErrPersonalAccessTokenUserIDShouldNotBeEmpty       = errors.New("user id should not be empty")
+	ErrPersonalAccessTokenNameShouldNotBeEmpty         = errors.New("name should not be empty")
+	ErrPersonalAccessTokenNameShouldHave100CharsOrLess = errors.New("name should have 100 characters or less")
+	ErrPersonalAccessTokenScopeIsInvalid               = errors.New("scope should be read or read_write")
+	ErrPersonalAccessTokenExpiresAtShouldBeInTheFuture = errors.New("expires at should be in the future")
+	ErrPersonalAccessTokenCanNotGenerateValue          = errors.New("can not generate personal access token")
+)
+
+// PersonalAccessToken lets scripts and integrations call the API on behalf of
+// a user without their password. The value starts with PersonalAccessTokenPrefix
+// and only its hash is kept.
+type PersonalAccessToken struct {
+	ID         string
+	UserID     string
+	Name       string
+	Scope      string
+	TokenHash  string
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	RevokedAt  *time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
+// NewPersonalAccessToken returns the token and its plain value. A nil
+// expiresAt creates a token that does not expire.
+func NewPersonalAccessToken(
+	userID string,
+	name string,
+	scope string,
+	expiresAt *time.Time,
+) (*PersonalAccessToken, string, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, "", ErrPersonalAccessTokenUserIDShouldNotBeEmpty
+	}
+
+	name = strings.TrimSpace(name)
+	if name == "" {
+		return nil, "", ErrPersonalAccessTokenNameShouldNotBeEmpty
+	}
+
+	if len(name) > 100 {
+		return nil, "", ErrPersonalAccessTokenNameShouldHave100CharsOrLess
+	}
+
+	if scope != PersonalAccessTokenScopeRead && scope != PersonalAccessTokenScopeReadWrite {
+		return nil, "", ErrPersonalAccessTokenScopeIsInvalid
+	}
+
+	if expiresAt != nil && !expiresAt.After(time.Now()) {
+		return nil, "", ErrPersonalAccessTokenExpiresAtShouldBeInTheFuture
+	}
+
+	value, err := generateSecureToken()
+	if err != nil {
+		return nil, "", ErrPersonalAccessTokenCanNotGenerateValue
+	}
+	value = PersonalAccessTokenPrefix + value
+
+	token := &PersonalAccessToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      name,
+		Scope:     scope,
+		TokenHash: HashPersonalAccessToken(value),
+		ExpiresAt: expiresAt,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	return token, value, nil
+}
+
+func HashPersonalAccessToken(value string) string {
+	return hashSecureToken(value)
+}
+
+func IsPersonalAccessTokenValue(value string) bool {
+	return strings.HasPrefix(value, PersonalAccessTokenPrefix)
+}
+
+func (t *PersonalAccessToken) IsExpired() bool {
+	return t.ExpiresAt != nil && !time.Now().Before(*t.ExpiresAt)
+}
+
+func (t *PersonalAccessToken) IsRevoked() bool {
+	return t.RevokedAt != nil
+}
+
+func (t *PersonalAccessToken) CanWrite() bool {
+	return t.Scope == PersonalAccessTokenScopeReadWrite
+}
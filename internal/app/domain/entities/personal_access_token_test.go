@@ -0,0 +1,86 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/labstack/gommon/random"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewPersonalAccessToken(t *testing.T) {
+	userID := uuid.NewString()
+	expiresAt := time.Now().Add(24 * time.Hour)
+
+	token, value, err := NewPersonalAccessToken(userID, " home assistant ", PersonalAccessTokenScopeRead, &expiresAt)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.True(t, strings.HasPrefix(value, PersonalAccessTokenPrefix))
+	assert.True(t, IsPersonalAccessTokenValue(value))
+	assert.NotEmpty(t, token.ID)
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, "home assistant", token.Name)
+	assert.Equal(t, PersonalAccessTokenScopeRead, token.Scope)
+	assert.Equal(t, HashPersonalAccessToken(value), token.TokenHash)
+	assert.NotContains(t, token.TokenHash, value)
+	assert.Equal(t, &expiresAt, token.ExpiresAt)
+	assert.Nil(t, token.LastUsedAt)
+	assert.Nil(t, token.RevokedAt)
+	assert.False(t, token.IsExpired())
+	assert.False(t, token.IsRevoked())
+	assert.False(t, token.CanWrite())
+}
+
+func TestNewPersonalAccessTokenWithoutExpiry(t *testing.T) {
+	token, _, err := NewPersonalAccessToken(uuid.NewString(), "backup", PersonalAccessTokenScopeReadWrite, nil)
+
+	assert.NoError(t, err)
+	assert.Nil(t, token.ExpiresAt)
+	assert.False(t, token.IsExpired())
+	assert.True(t, token.CanWrite())
+}
+
+func TestNewPersonalAccessTokenErrors(t *testing.T) {
+	past := time.Now().Add(-time.Minute)
+
+	testCases := []struct {
+		name          string
+		userID        string
+		tokenName     string
+		scope         string
+		expiresAt     *time.Time
+		expectedError error
+	}{
+		{"empty user id", " ", "backup", PersonalAccessTokenScopeRead, nil, ErrPersonalAccessTokenUserIDShouldNotBeEmpty},
+		{"empty name", uuid.NewString(), " ", PersonalAccessTokenScopeRead, nil, ErrPersonalAccessTokenNameShouldNotBeEmpty},
+		{"long name", uuid.NewString(), random.String(101, random.Alphanumeric), PersonalAccessTokenScopeRead, nil, ErrPersonalAccessTokenNameShouldHave100CharsOrLess},
+		{"invalid scope", uuid.NewString(), "backup", "admin", nil, ErrPersonalAccessTokenScopeIsInvalid},
+		{"expires in the past", uuid.NewString(), "backup", PersonalAccessTokenScopeRead, &past, ErrPersonalAccessTokenExpiresAtShouldBeInTheFuture},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			token, value, err := NewPersonalAccessToken(testCase.userID, testCase.tokenName, testCase.scope, testCase.expiresAt)
+
+			assert.ErrorIs(t, err, testCase.expectedError)
+			assert.Nil(t, token)
+			assert.Empty(t, value)
+		})
+	}
+}
+
+func TestPersonalAccessTokenIsExpired(t *testing.T) {
+	expiresAt := time.Now().Add(-time.Minute)
+	token := &PersonalAccessToken{ExpiresAt: &expiresAt}
+
+	assert.True(t, token.IsExpired())
+}
+
+func TestPersonalAccessTokenIsRevoked(t *testing.T) {
+	revokedAt := time.Now()
+	token := &PersonalAccessToken{RevokedAt: &revokedAt}
+
+	assert.True(t, token.IsRevoked())
+}
@@ -0,0 +1,87 @@
+package entities
+
+import (
+	"crypto/rand"
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRecoveryCodeUserIDShouldNotBeEmpty = errors.New("user id should not be empty")
+	ErrRecoveryCodeCanNotGenerateValue    = errors.New("can not generate recovery code")
+)
+
+const (
+	recoveryCodesCount   = 10
+	recoveryCodeLength   = 10
+	recoveryCodeAlphabet = "abcdefghijkmnpqrstuvwxyz23456789"
+)
+
+// RecoveryCode is a single use code to log in when the authenticator app is
+// lost. Only the hash of the code is kept.
+type RecoveryCode struct {
+	ID        string
+	UserID    string
+	CodeHash  string
+	UsedAt    *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewRecoveryCodes returns a new set of codes and their plain values, which
+// are shown to the user only once. The values look like abcde-fghij, without
+// the characters that are easy to confuse.
+func NewRecoveryCodes(userID string) ([]*RecoveryCode, []string, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, nil, ErrRecoveryCodeUserIDShouldNotBeEmpty
+	}
+
+	codes := make([]*RecoveryCode, 0, recoveryCodesCount)
+	values := make([]string, 0, recoveryCodesCount)
+
+	for i := 0; i < recoveryCodesCount; i++ {
+		value, err := generateRecoveryCode()
+		if err != nil {
+			return nil, nil, ErrRecoveryCodeCanNotGenerateValue
+		}
+
+		codes = append(codes, &RecoveryCode{
+			ID:        uuid.NewString(),
+			UserID:    userID,
+			CodeHash:  HashRecoveryCode(value),
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+		values = append(values, value)
+	}
+
+	return codes, values, nil
+}
+
+// HashRecoveryCode ignores the case, the spaces and the dash of the code, so
+// it can be typed as the user prefers.
+func HashRecoveryCode(value string) string {
+	value = strings.ToLower(value)
+	value = strings.NewReplacer("-", "", " ", "").Replace(value)
+
+	return hashSecureToken(value)
+}
+
+func generateRecoveryCode() (string, error) {
+	bytes := make([]byte, recoveryCodeLength)
+	if _, err := rand.Read(bytes); err != nil {
+		return "", err
+	}
+
+	code := make([]byte, 0, recoveryCodeLength+1)
+	for i, b := range bytes {
+		if i == recoveryCodeLength/2 {
+			code = append(code, '-')
+		}
+		code = append(code, recoveryCodeAlphabet[int(b)%len(recoveryCodeAlphabet)])
+	}
+
+	return string(code), nil
+}
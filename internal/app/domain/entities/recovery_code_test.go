@@ -0,0 +1,43 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+)
+
+func TestNewRecoveryCodes(t *testing.T) {
+	userID := uuid.NewString()
+
+	codes, values, err := NewRecoveryCodes(userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, codes, recoveryCodesCount)
+	assert.Len(t, values, recoveryCodesCount)
+
+	seen := make(map[string]bool)
+	for i, code := range codes {
+		assert.NotEmpty(t, code.ID)
+		assert.Equal(t, userID, code.UserID)
+		assert.Equal(t, HashRecoveryCode(values[i]), code.CodeHash)
+		assert.Nil(t, code.UsedAt)
+		assert.Regexp(t, regexp.MustCompile(`^[a-z2-9]{5}-[a-z2-9]{5}$`), values[i])
+		assert.False(t, seen[values[i]])
+		seen[values[i]] = true
+	}
+}
+
+func TestNewRecoveryCodesErrorEmptyUserID(t *testing.T) {
+	codes, values, err := NewRecoveryCodes("")
+
+	assert.ErrorIs(t, err, ErrRecoveryCodeUserIDShouldNotBeEmpty)
+	assert.Nil(t, codes)
+	assert.Nil(t, values)
+}
+
+func TestHashRecoveryCode(t *testing.T) {
+	assert.Equal(t, HashRecoveryCode("abcde-fghij"), HashRecoveryCode("ABCDE FGHIJ"))
+	assert.Equal(t, HashRecoveryCode("abcde-fghij"), HashRecoveryCode("abcdefghij"))
+	assert.NotEqual(t, HashRecoveryCode("abcde-fghij"), HashRecoveryCode("abcde-fghik"))
+}
@@ -0,0 +1,72 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrRefreshTokenUserIDShouldNotBeEmpty = errors.New("user id should not be empty")
+	ErrRefreshTokenCanNotGenerateValue    = errors.New("can not generate refresh token")
+)
+
+// RefreshToken is a single use token to get a new access token. Every token
+// issued by rotating another one belongs to the same family, the family id is
+// the session id carried by the access tokens.
+type RefreshToken struct {
+	ID        string
+	UserID    string
+	FamilyID  string
+	TokenHash string
+	ExpiresAt time.Time
+	UsedAt    *time.Time
+	RevokedAt *time.Time
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// NewRefreshToken returns the token and its plain value, only the hash of the
+// value is kept. An empty familyID starts a new family.
+func NewRefreshToken(userID string, familyID string, duration time.Duration) (*RefreshToken, string, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, "", ErrRefreshTokenUserIDShouldNotBeEmpty
+	}
+
+	value, err := generateSecureToken()
+	if err != nil {
+		return nil, "", ErrRefreshTokenCanNotGenerateValue
+	}
+
+	id := uuid.NewString()
+	if familyID == "" {
+		familyID = id
+	}
+
+	token := &RefreshToken{
+		ID:        id,
+		UserID:    userID,
+		FamilyID:  familyID,
+		TokenHash: HashRefreshToken(value),
+		ExpiresAt: time.Now().Add(duration),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	return token, value, nil
+}
+
+func HashRefreshToken(value string) string {
+	return hashSecureToken(value)
+}
+
+func (t *RefreshToken) IsExpired() bool {
+	return !time.Now().Before(t.ExpiresAt)
+}
+
+// WasUsed reports whether the token was already exchanged or revoked, so
+// presenting it again means it leaked.
+func (t *RefreshToken) WasUsed() bool {
+	return t.UsedAt != nil || t.RevokedAt != nil
+}
@@ -0,0 +1,65 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewRefreshToken(t *testing.T) {
+	userID := uuid.NewString()
+
+	token, value, err := NewRefreshToken(userID, "", time.Hour)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, token)
+	assert.NotEmpty(t, value)
+	assert.NotEmpty(t, token.ID)
+	assert.Equal(t, userID, token.UserID)
+	assert.Equal(t, token.ID, token.FamilyID)
+	assert.Equal(t, HashRefreshToken(value), token.TokenHash)
+	assert.NotEqual(t, value, token.TokenHash)
+	assert.WithinDuration(t, time.Now().Add(time.Hour), token.ExpiresAt, time.Second)
+	assert.Nil(t, token.UsedAt)
+	assert.Nil(t, token.RevokedAt)
+	assert.False(t, token.IsExpired())
+	assert.False(t, token.WasUsed())
+}
+
+func TestNewRefreshTokenInFamily(t *testing.T) {
+	familyID := uuid.NewString()
+
+	first, firstValue, err := NewRefreshToken(uuid.NewString(), familyID, time.Hour)
+	assert.NoError(t, err)
+
+	second, secondValue, err := NewRefreshToken(first.UserID, familyID, time.Hour)
+	assert.NoError(t, err)
+
+	assert.Equal(t, familyID, first.FamilyID)
+	assert.Equal(t, familyID, second.FamilyID)
+	assert.NotEqual(t, first.ID, second.ID)
+	assert.NotEqual(t, firstValue, secondValue)
+}
+
+func TestNewRefreshTokenErrorUserIDShouldNotBeEmpty(t *testing.T) {
+	token, value, err := NewRefreshToken(" ", "", time.Hour)
+
+	assert.ErrorIs(t, err, ErrRefreshTokenUserIDShouldNotBeEmpty)
+	assert.Nil(t, token)
+	assert.Empty(t, value)
+}
+
+func TestRefreshTokenIsExpired(t *testing.T) {
+	token := &RefreshToken{ExpiresAt: time.Now().Add(-time.Minute)}
+
+	assert.True(t, token.IsExpired())
+}
+
+func TestRefreshTokenWasUsed(t *testing.T) {
+	now := time.Now()
+
+	assert.True(t, (&RefreshToken{UsedAt: &now}).WasUsed())
+	assert.True(t, (&RefreshToken{RevokedAt: &now}).WasUsed())
+	assert.False(t, (&RefreshToken{}).WasUsed())
+}
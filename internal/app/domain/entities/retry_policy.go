@@ -0,0 +1,30 @@
+package entities
+
+import "time"
+
+// RetryPolicy sets how many times a delivery is attempted before it is dead
+// and how long to wait between the attempts.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseBackoff time.Duration
+	MaxBackoff  time.Duration
+}
+
+func (p RetryPolicy) IsExhausted(attempts int) bool {
+	return attempts >= p.MaxAttempts
+}
+
+// Backoff is how long to wait after the given failed attempts, twice as long
+// after every attempt up to the max backoff.
+func (p RetryPolicy) Backoff(attempts int) time.Duration {
+	backoff := p.BaseBackoff
+	for i := 1; i < attempts && backoff < p.MaxBackoff; i++ {
+		backoff *= 2
+	}
+
+	if backoff > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+
+	return backoff
+}
@@ -0,0 +1,25 @@
+package entities
+
+import (
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyBackoff(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 5, BaseBackoff: time.Minute, MaxBackoff: 5 * time.Minute}
+
+	assert.Equal(t, time.Minute, policy.Backoff(1))
+	assert.Equal(t, 2*time.Minute, policy.Backoff(2))
+	assert.Equal(t, 4*time.Minute, policy.Backoff(3))
+	assert.Equal(t, 5*time.Minute, policy.Backoff(4))
+	assert.Equal(t, 5*time.Minute, policy.Backoff(40))
+}
+
+func TestRetryPolicyIsExhausted(t *testing.T) {
+	policy := RetryPolicy{MaxAttempts: 3}
+
+	assert.False(t, policy.IsExhausted(2))
+	assert.True(t, policy.IsExhausted(3))
+	assert.True(t, policy.IsExhausted(4))
+}
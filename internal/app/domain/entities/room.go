@@ -12,25 +12,25 @@ var (
 	ErrRoomDescriptionShouldNotBeEmpty         = errors.New("description should not be empty")
 	ErrRoomNameShouldHave100OrLessChars        = errors.New("name should have 100 or less characters")
 	ErrRoomNameShouldNotBeEmpty                = errors.New("name should not be empty")
-	ErrRoomUserIDShouldNotBeEmpty              = errors.New("user id should not be empty")
+	ErrRoomHouseholdIDShouldNotBeEmpty         = errors.New("household id should not be empty")
 )
 
 const (
 	RoomNameField        = "name"
 	RoomDescriptionField = "description"
-	RoomUserIDField      = "user_id"
+	RoomHouseholdIDField = "household_id"
 )
 
 type Room struct {
 	ID          string
 	Name        string
 	Description *string
-	UserID      string
+	HouseholdID string
 	CreatedAt   time.Time
 	UpdatedAt   time.Time
 }
 
-func NewRoom(name string, description *string, userID string) (*Room, error) {
+func NewRoom(name string, description *string, householdID string) (*Room, error) {
 	room := &Room{
 		ID:        uuid.NewString(),
 		CreatedAt: time.Now(),
@@ -42,7 +42,7 @@ func NewRoom(name string, description *string, userID string) (*Room, error) {
 		return nil, err
 	}
 
-	err = room.ChangeUserID(userID)
+	err = room.ChangeHouseholdID(householdID)
 	if err != nil {
 		return nil, err
 	}
@@ -64,12 +64,20 @@ func (r *Room) Update(name string, description *string) error {
 	return nil
 }
 
-func (r *Room) ChangeUserID(userID string) error {
-	if strings.TrimSpace(userID) == "" {
-		return ErrRoomUserIDShouldNotBeEmpty
+func (r *Room) EntityID() string {
+	return r.ID
+}
+
+func (r *Room) EntityName() string {
+	return "room"
+}
+
+func (r *Room) ChangeHouseholdID(householdID string) error {
+	if strings.TrimSpace(householdID) == "" {
+		return ErrRoomHouseholdIDShouldNotBeEmpty
 	}
 
-	r.UserID = userID
+	r.HouseholdID = householdID
 	return nil
 }
 
@@ -11,9 +11,9 @@ import (
 func TestNewRoom(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	room, err := NewRoom(name, &description, userID)
+	room, err := NewRoom(name, &description, householdID)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, room.ID)
@@ -29,9 +29,9 @@ func TestNewRoom(t *testing.T) {
 func TestNewRoomErrorNameShouldNotBeEmpty(t *testing.T) {
 	name := ""
 	description := random.String(255, random.Alphanumeric)
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	room, err := NewRoom(name, &description, userID)
+	room, err := NewRoom(name, &description, householdID)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
@@ -41,9 +41,9 @@ func TestNewRoomErrorNameShouldNotBeEmpty(t *testing.T) {
 func TestNewRoomErrorNameShouldHave100OrLessChars(t *testing.T) {
 	name := random.String(101, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	room, err := NewRoom(name, &description, userID)
+	room, err := NewRoom(name, &description, householdID)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
@@ -53,9 +53,9 @@ func TestNewRoomErrorNameShouldHave100OrLessChars(t *testing.T) {
 func TestNewRoomErrorDescriptionShouldNotBeEmpty(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := ""
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	room, err := NewRoom(name, &description, userID)
+	room, err := NewRoom(name, &description, householdID)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
@@ -65,25 +65,25 @@ func TestNewRoomErrorDescriptionShouldNotBeEmpty(t *testing.T) {
 func TestNewRoomErrorDescriptionShouldHave255OrLessChars(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric) + random.String(1, random.Alphanumeric)
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	room, err := NewRoom(name, &description, userID)
+	room, err := NewRoom(name, &description, householdID)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
 	assert.ErrorIs(t, err, ErrRoomDescriptionShouldHave255OrLessChars)
 }
 
-func TestNewRoomErrorUserIDShouldNotBeEmpty(t *testing.T) {
+func TestNewRoomErrorHouseholdIDShouldNotBeEmpty(t *testing.T) {
 	name := random.String(100, random.Alphanumeric)
 	description := random.String(255, random.Alphanumeric)
-	userID := ""
+	householdID := ""
 
-	room, err := NewRoom(name, &description, userID)
+	room, err := NewRoom(name, &description, householdID)
 
 	assert.Error(t, err)
 	assert.Nil(t, room)
-	assert.ErrorIs(t, err, ErrRoomUserIDShouldNotBeEmpty)
+	assert.ErrorIs(t, err, ErrRoomHouseholdIDShouldNotBeEmpty)
 }
 
 func TestRoomUpdate(t *testing.T) {
@@ -160,3 +160,21 @@ func TestRoomUpdateErrorDescriptionShouldHave255OrLessChars(t *testing.T) {
 	assert.Equal(t, *room.Description, *room.Description)
 	assert.ErrorIs(t, err, ErrRoomDescriptionShouldHave255OrLessChars)
 }
+
+func TestRoomEntityID(t *testing.T) {
+	room := &Room{
+		ID:   uuid.NewString(),
+		Name: random.String(100, random.Alphanumeric),
+	}
+
+	assert.Equal(t, room.ID, room.EntityID())
+}
+
+func TestRoomEntityName(t *testing.T) {
+	room := &Room{
+		ID:   uuid.NewString(),
+		Name: random.String(100, random.Alphanumeric),
+	}
+
+	assert.Equal(t, "room", room.EntityName())
+}
@@ -0,0 +1,44 @@
+package entities
+
+import "time"
+
+// StoredFile is a file of the file manager, shared by the assets with the
+// same content. ReferenceCount is how many assets point to it, the file is
+// deleted with the last of them.
+type StoredFile struct {
+	ID             string
+	Extension      string
+	Hash           string
+	ReferenceCount int
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewStoredFile(id string, extension string, hash string) *StoredFile {
+	now := time.Now()
+
+	return &StoredFile{
+		ID:             id,
+		Extension:      extension,
+		Hash:           hash,
+		ReferenceCount: 1,
+		CreatedAt:      now,
+		UpdatedAt:      now,
+	}
+}
+
+func (f *StoredFile) AddReference(now time.Time) {
+	f.ReferenceCount++
+	f.UpdatedAt = now
+}
+
+func (f *StoredFile) RemoveReference(now time.Time) {
+	if f.ReferenceCount > 0 {
+		f.ReferenceCount--
+	}
+	f.UpdatedAt = now
+}
+
+func (f *StoredFile) IsReferenced() bool {
+	return f.ReferenceCount > 0
+}
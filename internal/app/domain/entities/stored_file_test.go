@@ -0,0 +1,41 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+	"time"
+)
+
+func TestNewStoredFile(t *testing.T) {
+	id := uuid.NewString()
+
+	file := NewStoredFile(id, ".png", "hash")
+
+	assert.Equal(t, id, file.ID)
+	assert.Equal(t, ".png", file.Extension)
+	assert.Equal(t, "hash", file.Hash)
+	assert.Equal(t, 1, file.ReferenceCount)
+	assert.Equal(t, file.CreatedAt, file.UpdatedAt)
+	assert.True(t, file.IsReferenced())
+}
+
+func TestStoredFileReferences(t *testing.T) {
+	now := time.Now()
+	file := NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	file.AddReference(now)
+
+	assert.Equal(t, 2, file.ReferenceCount)
+	assert.Equal(t, now, file.UpdatedAt)
+
+	file.RemoveReference(now)
+	file.RemoveReference(now)
+
+	assert.Equal(t, 0, file.ReferenceCount)
+	assert.False(t, file.IsReferenced())
+
+	file.RemoveReference(now)
+
+	assert.Equal(t, 0, file.ReferenceCount)
+}
@@ -0,0 +1,150 @@
+package entities
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"github.com/google/uuid"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrTwoFactorUserIDShouldNotBeEmpty = errors.New("user id should not be empty")
+	ErrTwoFactorCanNotGenerateSecret   = errors.New("can not generate two factor secret")
+	ErrTwoFactorAlreadyConfirmed       = errors.New("two factor authentication is already confirmed")
+	ErrTwoFactorInvalidCode            = errors.New("invalid two factor code")
+)
+
+const (
+	totpSecretLength = 20
+	totpDigits       = 6
+	totpPeriod       = 30
+	// totpSkew accepts the codes of the previous and next periods, for clocks
+	// that are a little off.
+	totpSkew = 1
+)
+
+var totpSecretEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// TwoFactor is the TOTP (RFC 6238) secret of a user, with SHA-1, 6 digits
+// and 30 seconds periods, the parameters every authenticator app supports.
+// It only protects the logins once confirmed with a first code. The last
+// used step is kept so a code can not be used twice.
+type TwoFactor struct {
+	ID           string
+	UserID       string
+	Secret       string
+	LastUsedStep int64
+	ConfirmedAt  *time.Time
+	CreatedAt    time.Time
+	UpdatedAt    time.Time
+}
+
+func NewTwoFactor(userID string) (*TwoFactor, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrTwoFactorUserIDShouldNotBeEmpty
+	}
+
+	secret := make([]byte, totpSecretLength)
+	if _, err := rand.Read(secret); err != nil {
+		return nil, ErrTwoFactorCanNotGenerateSecret
+	}
+
+	return &TwoFactor{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Secret:    totpSecretEncoding.EncodeToString(secret),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// ProvisioningURI returns the otpauth uri that authenticator apps read from
+// a QR code.
+func (t *TwoFactor) ProvisioningURI(issuer string, accountName string) string {
+	query := url.Values{}
+	query.Set("secret", t.Secret)
+	query.Set("issuer", issuer)
+	query.Set("algorithm", "SHA1")
+	query.Set("digits", fmt.Sprint(totpDigits))
+	query.Set("period", fmt.Sprint(totpPeriod))
+
+	return "otpauth://totp/" + url.PathEscape(issuer+":"+accountName) + "?" + query.Encode()
+}
+
+func (t *TwoFactor) IsConfirmed() bool {
+	return t.ConfirmedAt != nil
+}
+
+// Confirm enables the two factor authentication with the first code of the
+// authenticator app, proving it has the secret.
+func (t *TwoFactor) Confirm(code string, at time.Time) error {
+	if t.IsConfirmed() {
+		return ErrTwoFactorAlreadyConfirmed
+	}
+
+	step, ok := t.MatchCode(code, at)
+	if !ok {
+		return ErrTwoFactorInvalidCode
+	}
+
+	t.LastUsedStep = step
+	t.ConfirmedAt = &at
+	t.UpdatedAt = at
+
+	return nil
+}
+
+// MatchCode returns the step of the code when it is valid at the given time
+// and newer than the last used one.
+func (t *TwoFactor) MatchCode(code string, at time.Time) (int64, bool) {
+	code = strings.ReplaceAll(code, " ", "")
+	if len(code) != totpDigits {
+		return 0, false
+	}
+
+	secret, err := totpSecretEncoding.DecodeString(t.Secret)
+	if err != nil {
+		return 0, false
+	}
+
+	current := at.Unix() / totpPeriod
+	for step := current - totpSkew; step <= current+totpSkew; step++ {
+		if step <= t.LastUsedStep {
+			continue
+		}
+
+		if subtle.ConstantTimeCompare([]byte(generateTOTP(secret, step)), []byte(code)) == 1 {
+			return step, true
+		}
+	}
+
+	return 0, false
+}
+
+// generateTOTP computes the HOTP value (RFC 4226) of the step.
+func generateTOTP(secret []byte, step int64) string {
+	counter := make([]byte, 8)
+	binary.BigEndian.PutUint64(counter, uint64(step))
+
+	mac := hmac.New(sha1.New, secret)
+	mac.Write(counter)
+	sum := mac.Sum(nil)
+
+	offset := sum[len(sum)-1] & 0x0f
+	value := binary.BigEndian.Uint32(sum[offset:offset+4]) & 0x7fffffff
+
+	modulo := uint32(1)
+	for i := 0; i < totpDigits; i++ {
+		modulo *= 10
+	}
+
+	return fmt.Sprintf("%0*d", totpDigits, value%modulo)
+}
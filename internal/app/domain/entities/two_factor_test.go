@@ -0,0 +1,122 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewTwoFactor(t *testing.T) {
+	userID := uuid.NewString()
+
+	twoFactor, err := NewTwoFactor(userID)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, twoFactor.ID)
+	assert.Equal(t, userID, twoFactor.UserID)
+	assert.Len(t, twoFactor.Secret, 32)
+	assert.False(t, twoFactor.IsConfirmed())
+	assert.Zero(t, twoFactor.LastUsedStep)
+}
+
+func TestNewTwoFactorErrorEmptyUserID(t *testing.T) {
+	twoFactor, err := NewTwoFactor(" ")
+
+	assert.ErrorIs(t, err, ErrTwoFactorUserIDShouldNotBeEmpty)
+	assert.Nil(t, twoFactor)
+}
+
+func TestTwoFactorProvisioningURI(t *testing.T) {
+	twoFactor := &TwoFactor{Secret: "JBSWY3DPEHPK3PXP"}
+
+	uri := twoFactor.ProvisioningURI("Home Inventory", "test@example.com")
+
+	assert.Equal(
+		t,
+		"otpauth://totp/Home%20Inventory:test@example.com?algorithm=SHA1&digits=6&issuer=Home+Inventory&period=30&secret=JBSWY3DPEHPK3PXP",
+		uri,
+	)
+}
+
+// The values are the SHA-1 test vectors of RFC 6238, truncated to 6 digits.
+func TestGenerateTOTP(t *testing.T) {
+	secret := []byte("12345678901234567890")
+
+	testCases := map[int64]string{
+		59:          "287082",
+		1111111109:  "081804",
+		1111111111:  "050471",
+		1234567890:  "005924",
+		2000000000:  "279037",
+		20000000000: "353130",
+	}
+
+	for at, code := range testCases {
+		assert.Equal(t, code, generateTOTP(secret, at/totpPeriod))
+	}
+}
+
+func TestTwoFactorMatchCode(t *testing.T) {
+	twoFactor := &TwoFactor{Secret: totpSecretEncoding.EncodeToString([]byte("12345678901234567890"))}
+	at := time.Unix(1111111111, 0)
+
+	step, ok := twoFactor.MatchCode("050471", at)
+	assert.True(t, ok)
+	assert.Equal(t, int64(1111111111/totpPeriod), step)
+
+	step, ok = twoFactor.MatchCode("050 471", at.Add(totpPeriod*time.Second))
+	assert.True(t, ok)
+	assert.Equal(t, int64(1111111111/totpPeriod), step)
+
+	_, ok = twoFactor.MatchCode("050471", at.Add(3*totpPeriod*time.Second))
+	assert.False(t, ok)
+
+	_, ok = twoFactor.MatchCode("123456", at)
+	assert.False(t, ok)
+
+	_, ok = twoFactor.MatchCode("05047", at)
+	assert.False(t, ok)
+
+	twoFactor.LastUsedStep = 1111111111 / totpPeriod
+	_, ok = twoFactor.MatchCode("050471", at)
+	assert.False(t, ok)
+}
+
+func TestTwoFactorConfirm(t *testing.T) {
+	twoFactor := &TwoFactor{Secret: totpSecretEncoding.EncodeToString([]byte("12345678901234567890"))}
+	at := time.Unix(1234567890, 0)
+
+	err := twoFactor.Confirm("005924", at)
+
+	assert.NoError(t, err)
+	assert.True(t, twoFactor.IsConfirmed())
+	assert.Equal(t, int64(1234567890/totpPeriod), twoFactor.LastUsedStep)
+
+	err = twoFactor.Confirm("005924", at)
+
+	assert.ErrorIs(t, err, ErrTwoFactorAlreadyConfirmed)
+}
+
+func TestTwoFactorConfirmErrorInvalidCode(t *testing.T) {
+	twoFactor := &TwoFactor{Secret: totpSecretEncoding.EncodeToString([]byte("12345678901234567890"))}
+
+	err := twoFactor.Confirm("000000", time.Unix(1234567890, 0))
+
+	assert.ErrorIs(t, err, ErrTwoFactorInvalidCode)
+	assert.False(t, twoFactor.IsConfirmed())
+}
+
+func TestTwoFactorMatchCodeWithGeneratedSecret(t *testing.T) {
+	twoFactor, err := NewTwoFactor(uuid.NewString())
+	assert.NoError(t, err)
+
+	secret, err := totpSecretEncoding.DecodeString(strings.ToUpper(twoFactor.Secret))
+	assert.NoError(t, err)
+
+	now := time.Now()
+	_, ok := twoFactor.MatchCode(generateTOTP(secret, now.Unix()/totpPeriod), now)
+
+	assert.True(t, ok)
+}
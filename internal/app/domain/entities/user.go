@@ -3,7 +3,6 @@ package entities
 import (
 	"errors"
 	"github.com/google/uuid"
-	"golang.org/x/crypto/bcrypt"
 	"regexp"
 	"time"
 )
@@ -13,17 +12,32 @@ var (
 	ErrUserInvalidEmailAddress                             = errors.New("invalid email address")
 	ErrUserPasswordMustBeBetween6And100Chars               = errors.New("password must be between 6 and 100 characters")
 	ErrUserPasswordMustContainAtLeastOneLetterAndOneNumber = errors.New("password must contain at least one letter and one number")
+	ErrUserTimezoneIsInvalid                               = errors.New("timezone should be a valid IANA time zone, like America/Lima")
 )
 
+// UserDefaultTimezone is the timezone of the users that did not choose one.
+const UserDefaultTimezone = "UTC"
+
+// PasswordHasher hashes passwords in a self-describing format, so hashes made
+// with other algorithms or parameters can still be verified and replaced.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	Verify(password string, hash string) bool
+	NeedsRehash(hash string) bool
+}
+
 type User struct {
-	ID        string
-	Email     string
-	Password  string
-	CreatedAt time.Time
-	UpdatedAt time.Time
+	ID                  string
+	Email               string
+	Password            string
+	VerifiedAt          *time.Time
+	Timezone            string
+	DeletionRequestedAt *time.Time
+	CreatedAt           time.Time
+	UpdatedAt           time.Time
 }
 
-func NewUser(email string, password string) (*User, error) {
+func NewUser(email string, password string, passwordHasher PasswordHasher) (*User, error) {
 	if err := validateEmail(email); err != nil {
 		return nil, err
 	}
@@ -32,7 +46,7 @@ func NewUser(email string, password string) (*User, error) {
 		return nil, err
 	}
 
-	hashedPassword, err := hashPassword(password)
+	hashedPassword, err := passwordHasher.Hash(password)
 	if err != nil {
 		return nil, errors.New("cannot hash password")
 	}
@@ -41,6 +55,7 @@ func NewUser(email string, password string) (*User, error) {
 		ID:        uuid.NewString(),
 		Email:     email,
 		Password:  hashedPassword,
+		Timezone:  UserDefaultTimezone,
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
@@ -48,14 +63,142 @@ func NewUser(email string, password string) (*User, error) {
 	return user, nil
 }
 
-func hashPassword(password string) (string, error) {
-	bytes, err := bcrypt.GenerateFromPassword([]byte(password), 14)
-	return string(bytes), err
+// NewUserWithoutPassword creates a verified user for someone that signed in
+// with an identity provider that verified their email. They can set a
+// password later by resetting it.
+func NewUserWithoutPassword(email string) (*User, error) {
+	if err := validateEmail(email); err != nil {
+		return nil, err
+	}
+
+	now := time.Now()
+
+	return &User{
+		ID:         uuid.NewString(),
+		Email:      email,
+		VerifiedAt: &now,
+		Timezone:   UserDefaultTimezone,
+		CreatedAt:  now,
+		UpdatedAt:  now,
+	}, nil
+}
+
+func (u *User) HasPassword() bool {
+	return u.Password != ""
+}
+
+func (u *User) HasEqualPassword(password string, passwordHasher PasswordHasher) bool {
+	if !u.HasPassword() {
+		return false
+	}
+
+	return passwordHasher.Verify(password, u.Password)
+}
+
+func (u *User) PasswordNeedsRehash(passwordHasher PasswordHasher) bool {
+	return passwordHasher.NeedsRehash(u.Password)
+}
+
+// RehashPassword stores the same password with the current algorithm. It does
+// not validate the password again, so it only makes sense after a successful
+// HasEqualPassword.
+func (u *User) RehashPassword(password string, passwordHasher PasswordHasher) error {
+	hashedPassword, err := passwordHasher.Hash(password)
+	if err != nil {
+		return errors.New("cannot hash password")
+	}
+
+	u.Password = hashedPassword
+
+	return nil
+}
+
+func (u *User) ChangePassword(password string, passwordHasher PasswordHasher) error {
+	if err := validatePassword(password); err != nil {
+		return err
+	}
+
+	hashedPassword, err := passwordHasher.Hash(password)
+	if err != nil {
+		return errors.New("cannot hash password")
+	}
+
+	u.Password = hashedPassword
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// ChangeEmail sets a new address that still has to be verified.
+func (u *User) ChangeEmail(email string) error {
+	if err := validateEmail(email); err != nil {
+		return err
+	}
+
+	u.Email = email
+	u.VerifiedAt = nil
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
+func (u *User) IsVerified() bool {
+	return u.VerifiedAt != nil
+}
+
+func (u *User) Verify() {
+	now := time.Now()
+	u.VerifiedAt = &now
+	u.UpdatedAt = now
+}
+
+// RequestDeletion keeps the user from signing in while their data is being
+// removed.
+func (u *User) RequestDeletion() {
+	now := time.Now()
+	u.DeletionRequestedAt = &now
+	u.UpdatedAt = now
+}
+
+func (u *User) IsDeletionRequested() bool {
+	return u.DeletionRequestedAt != nil
 }
 
-func (u *User) HasEqualPassword(password string) bool {
-	err := bcrypt.CompareHashAndPassword([]byte(u.Password), []byte(password))
-	return err == nil
+func (u *User) ChangeTimezone(timezone string) error {
+	if _, err := loadTimezone(timezone); err != nil {
+		return err
+	}
+
+	u.Timezone = timezone
+	u.UpdatedAt = time.Now()
+
+	return nil
+}
+
+// Location is the location of the timezone of the user, or UTC when it can
+// not be loaded.
+func (u *User) Location() *time.Location {
+	location, err := loadTimezone(u.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+
+	return location
+}
+
+// loadTimezone does not accept an empty timezone or "Local", which
+// time.LoadLocation takes as UTC and the timezone of the server.
+func loadTimezone(timezone string) (*time.Location, error) {
+	if timezone == "" || timezone == "Local" {
+		return nil, ErrUserTimezoneIsInvalid
+	}
+
+	location, err := time.LoadLocation(timezone)
+	if err != nil {
+		return nil, ErrUserTimezoneIsInvalid
+	}
+
+	return location, nil
 }
 
 func validateEmail(email string) error {
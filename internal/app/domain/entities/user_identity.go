@@ -0,0 +1,50 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+var (
+	ErrUserIdentityUserIDShouldNotBeEmpty  = errors.New("user id should not be empty")
+	ErrUserIdentityIssuerShouldNotBeEmpty  = errors.New("issuer should not be empty")
+	ErrUserIdentitySubjectShouldNotBeEmpty = errors.New("subject should not be empty")
+)
+
+// UserIdentity links a user to their account in an OpenID Connect provider.
+// The issuer and subject identify that account, the email may change later.
+type UserIdentity struct {
+	ID        string
+	UserID    string
+	Issuer    string
+	Subject   string
+	Email     string
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+func NewUserIdentity(userID string, issuer string, subject string, email string) (*UserIdentity, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrUserIdentityUserIDShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(issuer) == "" {
+		return nil, ErrUserIdentityIssuerShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(subject) == "" {
+		return nil, ErrUserIdentitySubjectShouldNotBeEmpty
+	}
+
+	return &UserIdentity{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Issuer:    issuer,
+		Subject:   subject,
+		Email:     email,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
@@ -0,0 +1,40 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestNewUserIdentity(t *testing.T) {
+	userID := uuid.NewString()
+
+	identity, err := NewUserIdentity(userID, "https://accounts.example.com", "248289761001", "test@example.com")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, identity.ID)
+	assert.Equal(t, userID, identity.UserID)
+	assert.Equal(t, "https://accounts.example.com", identity.Issuer)
+	assert.Equal(t, "248289761001", identity.Subject)
+	assert.Equal(t, "test@example.com", identity.Email)
+}
+
+func TestNewUserIdentityErrorEmptyFields(t *testing.T) {
+	testCases := []struct {
+		userID  string
+		issuer  string
+		subject string
+		err     error
+	}{
+		{"", "https://accounts.example.com", "248289761001", ErrUserIdentityUserIDShouldNotBeEmpty},
+		{uuid.NewString(), " ", "248289761001", ErrUserIdentityIssuerShouldNotBeEmpty},
+		{uuid.NewString(), "https://accounts.example.com", "", ErrUserIdentitySubjectShouldNotBeEmpty},
+	}
+
+	for _, testCase := range testCases {
+		identity, err := NewUserIdentity(testCase.userID, testCase.issuer, testCase.subject, "test@example.com")
+
+		assert.ErrorIs(t, err, testCase.err)
+		assert.Nil(t, identity)
+	}
+}
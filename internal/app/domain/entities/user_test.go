@@ -3,23 +3,41 @@ package entities
 import (
 	"github.com/labstack/gommon/random"
 	"github.com/stretchr/testify/assert"
-	"golang.org/x/crypto/bcrypt"
+	"strings"
 	"testing"
 	"time"
 )
 
+type MockPasswordHasher struct {
+	Algorithm string
+}
+
+func (h *MockPasswordHasher) Hash(password string) (string, error) {
+	return h.Algorithm + ":" + password, nil
+}
+
+func (h *MockPasswordHasher) Verify(password string, hash string) bool {
+	_, hashed, _ := strings.Cut(hash, ":")
+	return hashed == password
+}
+
+func (h *MockPasswordHasher) NeedsRehash(hash string) bool {
+	return !strings.HasPrefix(hash, h.Algorithm+":")
+}
+
 func TestNewUser(t *testing.T) {
 	email := "test@example.com"
 	password := random.String(6, random.Numeric) + random.String(6, random.Alphabetic)
 
-	user, err := NewUser(email, password)
+	user, err := NewUser(email, password, &MockPasswordHasher{"new"})
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, user.ID)
 	assert.Equal(t, email, user.Email)
+	assert.False(t, user.IsVerified())
+	assert.Equal(t, UserDefaultTimezone, user.Timezone)
 
-	err = bcrypt.CompareHashAndPassword([]byte(user.Password), []byte(password))
-	assert.NoError(t, err)
+	assert.Equal(t, "new:"+password, user.Password)
 
 	now := time.Now()
 	assert.WithinDuration(t, now, user.CreatedAt, 10*time.Second)
@@ -30,7 +48,7 @@ func TestNewUserErrorInvalidEmail(t *testing.T) {
 	invalidEmail := "invalidemail"
 	password := random.String(6, random.Numeric) + random.String(6, random.Alphabetic)
 
-	_, err := NewUser(invalidEmail, password)
+	_, err := NewUser(invalidEmail, password, &MockPasswordHasher{"new"})
 
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrUserInvalidEmailAddress)
@@ -40,7 +58,7 @@ func TestNewUserErrorEmailExceedsMaxLength(t *testing.T) {
 	invalidEmail := random.String(101) + "@email.com"
 	password := random.String(6, random.Numeric) + random.String(6, random.Alphabetic)
 
-	_, err := NewUser(invalidEmail, password)
+	_, err := NewUser(invalidEmail, password, &MockPasswordHasher{"new"})
 
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrUserEmailExceedsMaxLengthOf100Chars)
@@ -50,8 +68,8 @@ func TestNewUserErrorPasswordMustBeBetween6And100Chars(t *testing.T) {
 	smallPassword := random.String(5)
 	giantPassword := random.String(101)
 
-	_, err1 := NewUser("test@example.com", smallPassword)
-	_, err2 := NewUser("test@example.com", giantPassword)
+	_, err1 := NewUser("test@example.com", smallPassword, &MockPasswordHasher{"new"})
+	_, err2 := NewUser("test@example.com", giantPassword, &MockPasswordHasher{"new"})
 
 	assert.Error(t, err1)
 	assert.ErrorIs(t, err1, ErrUserPasswordMustBeBetween6And100Chars)
@@ -62,7 +80,7 @@ func TestNewUserErrorPasswordMustBeBetween6And100Chars(t *testing.T) {
 func TestNewUserErrorPasswordMustContainAtLeastOneNumber(t *testing.T) {
 	invalidPassword := random.String(6, random.Alphabetic)
 
-	_, err := NewUser("test@example.com", invalidPassword)
+	_, err := NewUser("test@example.com", invalidPassword, &MockPasswordHasher{"new"})
 
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrUserPasswordMustContainAtLeastOneLetterAndOneNumber)
@@ -71,8 +89,132 @@ func TestNewUserErrorPasswordMustContainAtLeastOneNumber(t *testing.T) {
 func TestNewUserErrorPasswordMustContainAtLeastOneLetter(t *testing.T) {
 	invalidPassword := random.String(6, random.Numeric)
 
-	_, err := NewUser("test@example.com", invalidPassword)
+	_, err := NewUser("test@example.com", invalidPassword, &MockPasswordHasher{"new"})
 
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, ErrUserPasswordMustContainAtLeastOneLetterAndOneNumber)
 }
+
+func TestUserChangePassword(t *testing.T) {
+	user := &User{Password: "old"}
+	password := random.String(6, random.Numeric) + random.String(6, random.Alphabetic)
+
+	err := user.ChangePassword(password, &MockPasswordHasher{"new"})
+
+	assert.NoError(t, err)
+	assert.True(t, user.HasEqualPassword(password, &MockPasswordHasher{"new"}))
+	assert.WithinDuration(t, time.Now(), user.UpdatedAt, 10*time.Second)
+}
+
+func TestUserChangePasswordErrorPasswordMustContainAtLeastOneLetterAndOneNumber(t *testing.T) {
+	user := &User{Password: "old"}
+
+	err := user.ChangePassword(random.String(8, random.Numeric), &MockPasswordHasher{"new"})
+
+	assert.ErrorIs(t, err, ErrUserPasswordMustContainAtLeastOneLetterAndOneNumber)
+	assert.Equal(t, "old", user.Password)
+}
+
+func TestNewUserWithoutPassword(t *testing.T) {
+	user, err := NewUserWithoutPassword("test@example.com")
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, user.ID)
+	assert.Equal(t, "test@example.com", user.Email)
+	assert.True(t, user.IsVerified())
+	assert.False(t, user.HasPassword())
+	assert.False(t, user.HasEqualPassword("", &MockPasswordHasher{"new"}))
+}
+
+func TestNewUserWithoutPasswordErrorInvalidEmail(t *testing.T) {
+	user, err := NewUserWithoutPassword("invalid")
+
+	assert.ErrorIs(t, err, ErrUserInvalidEmailAddress)
+	assert.Nil(t, user)
+}
+
+func TestUserRehashPassword(t *testing.T) {
+	passwordHasher := &MockPasswordHasher{"new"}
+	user := &User{Password: "old:secret1"}
+
+	assert.True(t, user.HasEqualPassword("secret1", passwordHasher))
+	assert.True(t, user.PasswordNeedsRehash(passwordHasher))
+
+	err := user.RehashPassword("secret1", passwordHasher)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new:secret1", user.Password)
+	assert.False(t, user.PasswordNeedsRehash(passwordHasher))
+}
+
+func TestUserVerify(t *testing.T) {
+	user := &User{}
+
+	user.Verify()
+
+	assert.True(t, user.IsVerified())
+	assert.WithinDuration(t, time.Now(), *user.VerifiedAt, 10*time.Second)
+}
+
+func TestUserRequestDeletion(t *testing.T) {
+	user := &User{}
+
+	user.RequestDeletion()
+
+	assert.True(t, user.IsDeletionRequested())
+	assert.WithinDuration(t, time.Now(), *user.DeletionRequestedAt, 10*time.Second)
+	assert.Equal(t, *user.DeletionRequestedAt, user.UpdatedAt)
+}
+
+func TestUserChangeEmail(t *testing.T) {
+	now := time.Now()
+	user := &User{Email: "old@example.com", VerifiedAt: &now}
+
+	err := user.ChangeEmail("new@example.com")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "new@example.com", user.Email)
+	assert.False(t, user.IsVerified())
+	assert.WithinDuration(t, now, user.UpdatedAt, 10*time.Second)
+}
+
+func TestUserChangeEmailErrorInvalidEmail(t *testing.T) {
+	user := &User{Email: "old@example.com"}
+
+	err := user.ChangeEmail("invalid")
+
+	assert.ErrorIs(t, err, ErrUserInvalidEmailAddress)
+	assert.Equal(t, "old@example.com", user.Email)
+}
+
+func TestUserChangeTimezone(t *testing.T) {
+	user := &User{Timezone: UserDefaultTimezone}
+
+	err := user.ChangeTimezone("America/Lima")
+
+	assert.NoError(t, err)
+	assert.Equal(t, "America/Lima", user.Timezone)
+	assert.Equal(t, "America/Lima", user.Location().String())
+	assert.WithinDuration(t, time.Now(), user.UpdatedAt, 10*time.Second)
+}
+
+func TestUserChangeTimezoneErrorInvalidTimezone(t *testing.T) {
+	testCases := []string{"", "Local", "Mars/Olympus_Mons"}
+
+	for _, timezone := range testCases {
+		t.Run(timezone, func(t *testing.T) {
+			user := &User{Timezone: UserDefaultTimezone}
+
+			err := user.ChangeTimezone(timezone)
+
+			assert.ErrorIs(t, err, ErrUserTimezoneIsInvalid)
+			assert.Equal(t, UserDefaultTimezone, user.Timezone)
+		})
+	}
+}
+
+func TestUserLocationDefaultsToUTC(t *testing.T) {
+	user := &User{}
+
+	assert.Equal(t, time.UTC, user.Location())
+}
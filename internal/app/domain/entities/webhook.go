@@ -0,0 +1,130 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"net/url"
+	"strings"
+	"time"
+)
+
+const WebhookSecretPrefix = "whsec_"
+
+const webhookURLMaxLength = 2048
+
+// WebhookEventTypes are the events a webhook can subscribe to, named as
+// services.GetEventType names them.
+var WebhookEventTypes = []string{
+	"BoxItemAddedEvent",
+	"BoxItemRemovedEvent",
+	"ItemCreatedEvent",
+	"ItemUpdatedEvent",
+	"BoxCreatedEvent",
+	"BoxUpdatedEvent",
+	"BoxDeletedEvent",
+	"RoomCreatedEvent",
+	"RoomUpdatedEvent",
+	"RoomDeletedEvent",
+}
+
+var (
+	ErrWebhookUserIDShouldNotBeEmpty       = errors.New("user id should not be empty")
+	ErrWebhookHouseholdIDShouldNotBeEmpty  = errors.New("household id should not be empty")
+	ErrWebhookURLIsInvalid                 = errors.New("url should be a valid http or https url")
+	ErrWebhookURLShouldHave2048CharsOrLess = errors.New("url should have 2048 characters or less")
+	ErrWebhookEventTypesShouldNotBeEmpty   = errors.New("event types should not be empty")
+	ErrWebhookEventTypeIsNotSupported      = errors.New("event type is not supported")
+	ErrWebhookCanNotGenerateSecret         = errors.New("can not generate webhook secret")
+)
+
+// Webhook is an endpoint of a user that receives the events of a household.
+// EventTypes keeps the subscribed types separated by commas, and Secret signs
+// the deliveries so the endpoint can tell they come from us.
+type Webhook struct {
+	ID          string
+	UserID      string
+	HouseholdID string
+	URL         string
+	Secret      string
+	EventTypes  string
+	CreatedAt   time.Time
+	UpdatedAt   time.Time
+}
+
+func NewWebhook(
+	userID string,
+	householdID string,
+	webhookURL string,
+	eventTypes []string,
+) (*Webhook, error) {
+	if strings.TrimSpace(userID) == "" {
+		return nil, ErrWebhookUserIDShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(householdID) == "" {
+		return nil, ErrWebhookHouseholdIDShouldNotBeEmpty
+	}
+
+	webhookURL = strings.TrimSpace(webhookURL)
+	if len(webhookURL) > webhookURLMaxLength {
+		return nil, ErrWebhookURLShouldHave2048CharsOrLess
+	}
+
+	parsedURL, err := url.Parse(webhookURL)
+	if err != nil || (parsedURL.Scheme != "http" && parsedURL.Scheme != "https") || parsedURL.Host == "" {
+		return nil, ErrWebhookURLIsInvalid
+	}
+
+	if len(eventTypes) == 0 {
+		return nil, ErrWebhookEventTypesShouldNotBeEmpty
+	}
+
+	uniqueEventTypes := make([]string, 0, len(eventTypes))
+	for _, eventType := range eventTypes {
+		if !isWebhookEventType(eventType) {
+			return nil, ErrWebhookEventTypeIsNotSupported
+		}
+
+		if !containsString(uniqueEventTypes, eventType) {
+			uniqueEventTypes = append(uniqueEventTypes, eventType)
+		}
+	}
+
+	secret, err := generateSecureToken()
+	if err != nil {
+		return nil, ErrWebhookCanNotGenerateSecret
+	}
+
+	return &Webhook{
+		ID:          uuid.NewString(),
+		UserID:      userID,
+		HouseholdID: householdID,
+		URL:         webhookURL,
+		Secret:      WebhookSecretPrefix + secret,
+		EventTypes:  strings.Join(uniqueEventTypes, ","),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}, nil
+}
+
+func (w *Webhook) GetEventTypes() []string {
+	return strings.Split(w.EventTypes, ",")
+}
+
+func (w *Webhook) IsSubscribedTo(eventType string) bool {
+	return containsString(w.GetEventTypes(), eventType)
+}
+
+func isWebhookEventType(eventType string) bool {
+	return containsString(WebhookEventTypes, eventType)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,103 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"strings"
+	"time"
+)
+
+const (
+	WebhookDeliveryStatusPending   = "pending"
+	WebhookDeliveryStatusDelivered = "delivered"
+	WebhookDeliveryStatusDead      = "dead"
+)
+
+const webhookDeliveryLastErrorMaxLength = 1000
+
+var (
+	ErrWebhookDeliveryWebhookIDShouldNotBeEmpty = errors.New("webhook id should not be empty")
+	ErrWebhookDeliveryEventTypeShouldNotBeEmpty = errors.New("event type should not be empty")
+)
+
+// WebhookDelivery is a payload sent, or waiting to be sent, to a webhook. It
+// keeps the status code of the last response so the log shows why an attempt
+// failed.
+type WebhookDelivery struct {
+	ID             string
+	WebhookID      string
+	EventType      string
+	Payload        string
+	Status         string
+	Attempts       int
+	ResponseStatus *int
+	LastError      *string
+	AvailableAt    time.Time
+	DeliveredAt    *time.Time
+	CreatedAt      time.Time
+	UpdatedAt      time.Time
+}
+
+func NewWebhookDelivery(webhookID string, eventType string, payload string) (*WebhookDelivery, error) {
+	if strings.TrimSpace(webhookID) == "" {
+		return nil, ErrWebhookDeliveryWebhookIDShouldNotBeEmpty
+	}
+
+	if strings.TrimSpace(eventType) == "" {
+		return nil, ErrWebhookDeliveryEventTypeShouldNotBeEmpty
+	}
+
+	now := time.Now()
+
+	return &WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   webhookID,
+		EventType:   eventType,
+		Payload:     payload,
+		Status:      WebhookDeliveryStatusPending,
+		AvailableAt: now,
+		CreatedAt:   now,
+		UpdatedAt:   now,
+	}, nil
+}
+
+func (d *WebhookDelivery) IsPending() bool {
+	return d.Status == WebhookDeliveryStatusPending
+}
+
+func (d *WebhookDelivery) MarkDelivered(responseStatus int, now time.Time) {
+	d.Status = WebhookDeliveryStatusDelivered
+	d.Attempts++
+	d.ResponseStatus = &responseStatus
+	d.LastError = nil
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// MarkFailed waits for the backoff of the policy, and leaves the delivery
+// dead once it runs out of attempts. The response status is nil when the
+// endpoint could not be reached.
+func (d *WebhookDelivery) MarkFailed(reason error, responseStatus *int, now time.Time, policy RetryPolicy) {
+	d.Attempts++
+	d.ResponseStatus = responseStatus
+
+	lastError := reason.Error()
+	if len(lastError) > webhookDeliveryLastErrorMaxLength {
+		lastError = lastError[:webhookDeliveryLastErrorMaxLength]
+	}
+	d.LastError = &lastError
+	d.UpdatedAt = now
+
+	if policy.IsExhausted(d.Attempts) {
+		d.Status = WebhookDeliveryStatusDead
+		return
+	}
+
+	d.AvailableAt = now.Add(policy.Backoff(d.Attempts))
+}
+
+// Redeliver returns a new pending delivery of the same payload, the original
+// one stays in the log as it was.
+func (d *WebhookDelivery) Redeliver() (*WebhookDelivery, error) {
+	return NewWebhookDelivery(d.WebhookID, d.EventType, d.Payload)
+}
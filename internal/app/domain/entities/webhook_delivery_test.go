@@ -0,0 +1,123 @@
+package entities
+
+import (
+	"errors"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestNewWebhookDelivery(t *testing.T) {
+	webhookID := uuid.NewString()
+
+	delivery, err := NewWebhookDelivery(webhookID, "RoomCreatedEvent", `{"event":"RoomCreatedEvent"}`)
+
+	assert.NoError(t, err)
+	assert.NotEmpty(t, delivery.ID)
+	assert.Equal(t, webhookID, delivery.WebhookID)
+	assert.Equal(t, "RoomCreatedEvent", delivery.EventType)
+	assert.Equal(t, `{"event":"RoomCreatedEvent"}`, delivery.Payload)
+	assert.Equal(t, WebhookDeliveryStatusPending, delivery.Status)
+	assert.Equal(t, 0, delivery.Attempts)
+	assert.Nil(t, delivery.ResponseStatus)
+	assert.Nil(t, delivery.LastError)
+	assert.Nil(t, delivery.DeliveredAt)
+	assert.Equal(t, delivery.CreatedAt, delivery.AvailableAt)
+	assert.True(t, delivery.IsPending())
+}
+
+func TestNewWebhookDeliveryErrors(t *testing.T) {
+	delivery, err := NewWebhookDelivery(" ", "RoomCreatedEvent", "{}")
+
+	assert.Nil(t, delivery)
+	assert.ErrorIs(t, err, ErrWebhookDeliveryWebhookIDShouldNotBeEmpty)
+
+	delivery, err = NewWebhookDelivery(uuid.NewString(), "", "{}")
+
+	assert.Nil(t, delivery)
+	assert.ErrorIs(t, err, ErrWebhookDeliveryEventTypeShouldNotBeEmpty)
+}
+
+func TestWebhookDeliveryMarkDelivered(t *testing.T) {
+	now := time.Now()
+	lastError := "error"
+	delivery := &WebhookDelivery{Status: WebhookDeliveryStatusPending, Attempts: 1, LastError: &lastError}
+
+	delivery.MarkDelivered(204, now)
+
+	assert.Equal(t, WebhookDeliveryStatusDelivered, delivery.Status)
+	assert.Equal(t, 2, delivery.Attempts)
+	assert.Equal(t, 204, *delivery.ResponseStatus)
+	assert.Nil(t, delivery.LastError)
+	assert.Equal(t, now, *delivery.DeliveredAt)
+	assert.Equal(t, now, delivery.UpdatedAt)
+	assert.False(t, delivery.IsPending())
+}
+
+func TestWebhookDeliveryMarkFailed(t *testing.T) {
+	now := time.Now()
+	policy := RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour}
+	responseStatus := 500
+
+	testCases := []struct {
+		name            string
+		attempts        int
+		expectedBackoff time.Duration
+		expectedStatus  string
+	}{
+		{"first failure", 0, time.Minute, WebhookDeliveryStatusPending},
+		{"second failure", 1, 2 * time.Minute, WebhookDeliveryStatusPending},
+		{"dead", 2, 0, WebhookDeliveryStatusDead},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			delivery := &WebhookDelivery{Status: WebhookDeliveryStatusPending, Attempts: testCase.attempts, AvailableAt: now}
+
+			delivery.MarkFailed(errors.New("unexpected status 500"), &responseStatus, now, policy)
+
+			assert.Equal(t, testCase.attempts+1, delivery.Attempts)
+			assert.Equal(t, testCase.expectedStatus, delivery.Status)
+			assert.Equal(t, 500, *delivery.ResponseStatus)
+			assert.Equal(t, "unexpected status 500", *delivery.LastError)
+			assert.Equal(t, now.Add(testCase.expectedBackoff), delivery.AvailableAt)
+		})
+	}
+}
+
+func TestWebhookDeliveryMarkFailedTruncatesLastError(t *testing.T) {
+	delivery := &WebhookDelivery{Status: WebhookDeliveryStatusPending}
+
+	delivery.MarkFailed(errors.New(strings.Repeat("a", 2000)), nil, time.Now(), RetryPolicy{MaxAttempts: 3})
+
+	assert.Len(t, *delivery.LastError, 1000)
+	assert.Nil(t, delivery.ResponseStatus)
+}
+
+func TestWebhookDeliveryRedeliver(t *testing.T) {
+	responseStatus := 500
+	lastError := "unexpected status 500"
+	delivery := &WebhookDelivery{
+		ID:             uuid.NewString(),
+		WebhookID:      uuid.NewString(),
+		EventType:      "RoomCreatedEvent",
+		Payload:        "{}",
+		Status:         WebhookDeliveryStatusDead,
+		Attempts:       5,
+		ResponseStatus: &responseStatus,
+		LastError:      &lastError,
+	}
+
+	redelivery, err := delivery.Redeliver()
+
+	assert.NoError(t, err)
+	assert.NotEqual(t, delivery.ID, redelivery.ID)
+	assert.Equal(t, delivery.WebhookID, redelivery.WebhookID)
+	assert.Equal(t, delivery.EventType, redelivery.EventType)
+	assert.Equal(t, delivery.Payload, redelivery.Payload)
+	assert.True(t, redelivery.IsPending())
+	assert.Equal(t, 0, redelivery.Attempts)
+	assert.Equal(t, WebhookDeliveryStatusDead, delivery.Status)
+}
@@ -0,0 +1,69 @@
+package entities
+
+import (
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestNewWebhook(t *testing.T) {
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	webhook, err := NewWebhook(
+		userID,
+		householdID,
+		" https://example.com/hooks/inventory ",
+		[]string{"BoxItemAddedEvent", "BoxItemRemovedEvent", "BoxItemAddedEvent"},
+	)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, webhook)
+	assert.NotEmpty(t, webhook.ID)
+	assert.Equal(t, userID, webhook.UserID)
+	assert.Equal(t, householdID, webhook.HouseholdID)
+	assert.Equal(t, "https://example.com/hooks/inventory", webhook.URL)
+	assert.True(t, strings.HasPrefix(webhook.Secret, WebhookSecretPrefix))
+	assert.Greater(t, len(webhook.Secret), len(WebhookSecretPrefix))
+	assert.Equal(t, "BoxItemAddedEvent,BoxItemRemovedEvent", webhook.EventTypes)
+	assert.Equal(t, []string{"BoxItemAddedEvent", "BoxItemRemovedEvent"}, webhook.GetEventTypes())
+	assert.True(t, webhook.IsSubscribedTo("BoxItemAddedEvent"))
+	assert.False(t, webhook.IsSubscribedTo("RoomCreatedEvent"))
+}
+
+func TestNewWebhookGeneratesDifferentSecrets(t *testing.T) {
+	first, _ := NewWebhook(uuid.NewString(), uuid.NewString(), "https://example.com", []string{"RoomCreatedEvent"})
+	second, _ := NewWebhook(uuid.NewString(), uuid.NewString(), "https://example.com", []string{"RoomCreatedEvent"})
+
+	assert.NotEqual(t, first.Secret, second.Secret)
+}
+
+func TestNewWebhookErrors(t *testing.T) {
+	testCases := []struct {
+		name          string
+		userID        string
+		householdID   string
+		url           string
+		eventTypes    []string
+		expectedError error
+	}{
+		{"empty user id", " ", uuid.NewString(), "https://example.com", []string{"RoomCreatedEvent"}, ErrWebhookUserIDShouldNotBeEmpty},
+		{"empty household id", uuid.NewString(), " ", "https://example.com", []string{"RoomCreatedEvent"}, ErrWebhookHouseholdIDShouldNotBeEmpty},
+		{"empty url", uuid.NewString(), uuid.NewString(), "", []string{"RoomCreatedEvent"}, ErrWebhookURLIsInvalid},
+		{"relative url", uuid.NewString(), uuid.NewString(), "/hooks", []string{"RoomCreatedEvent"}, ErrWebhookURLIsInvalid},
+		{"unsupported scheme", uuid.NewString(), uuid.NewString(), "ftp://example.com", []string{"RoomCreatedEvent"}, ErrWebhookURLIsInvalid},
+		{"long url", uuid.NewString(), uuid.NewString(), "https://example.com/" + strings.Repeat("a", 2048), []string{"RoomCreatedEvent"}, ErrWebhookURLShouldHave2048CharsOrLess},
+		{"no event types", uuid.NewString(), uuid.NewString(), "https://example.com", []string{}, ErrWebhookEventTypesShouldNotBeEmpty},
+		{"unsupported event type", uuid.NewString(), uuid.NewString(), "https://example.com", []string{"UserCreatedEvent"}, ErrWebhookEventTypeIsNotSupported},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			webhook, err := NewWebhook(testCase.userID, testCase.householdID, testCase.url, testCase.eventTypes)
+
+			assert.Nil(t, webhook)
+			assert.ErrorIs(t, err, testCase.expectedError)
+		})
+	}
+}
@@ -6,9 +6,11 @@ const (
 )
 
 const (
-	LikeComparisonOperator  ComparisonOperator = "LIKE"
-	EqualComparisonOperator ComparisonOperator = "="
-	InComparisonOperator    ComparisonOperator = "IN"
+	LikeComparisonOperator           ComparisonOperator = "LIKE"
+	EqualComparisonOperator          ComparisonOperator = "="
+	InComparisonOperator             ComparisonOperator = "IN"
+	GreaterOrEqualComparisonOperator ComparisonOperator = ">="
+	LessOrEqualComparisonOperator    ComparisonOperator = "<="
 )
 
 type ComparisonOperator string
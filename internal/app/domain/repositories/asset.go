@@ -6,10 +6,14 @@ import (
 )
 
 var (
-	ErrAssetRepositoryCanNotCreateAsset         = errors.New("can not create asset")
-	ErrAssetRepositoryCanNotDeleteAsset         = errors.New("can not delete asset")
-	ErrAssetRepositoryCanNotGetAssets           = errors.New("can not get assets")
-	ErrorAssetRepositoryCanNotGetByQueryFilters = errors.New("can not get by query filters")
+	ErrAssetRepositoryCanNotCountAssets            = errors.New("can not count assets")
+	ErrAssetRepositoryCanNotCreateAsset            = errors.New("can not create asset")
+	ErrAssetRepositoryCanNotDeleteAsset            = errors.New("can not delete asset")
+	ErrAssetRepositoryCanNotGetAssets              = errors.New("can not get assets")
+	ErrAssetRepositoryAssetNotFound                = errors.New("asset not found")
+	ErrorAssetRepositoryCanNotGetByQueryFilters    = errors.New("can not get by query filters")
+	ErrAssetRepositoryCanNotGetAssetsWithoutEntity = errors.New("can not get assets without entity")
+	ErrAssetRepositoryCanNotUpdateAssets           = errors.New("can not update assets")
 )
 
 type AssetRepository interface {
@@ -17,4 +21,9 @@ type AssetRepository interface {
 	FindByEntity(entity entities.Entity, page *PageFilter) ([]*entities.Asset, error)
 	Delete(id string) error
 	GetByQueryFilters(queryFilter QueryFilter) ([]*entities.Asset, error)
+	CountByQueryFilters(queryFilter QueryFilter) (int64, error)
+	GetByID(id string) (*entities.Asset, error)
+	GetAll() ([]*entities.Asset, error)
+	GetWithoutEntity() ([]*entities.Asset, error)
+	UpdateFileID(oldFileID string, newFileID string) error
 }
@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"time"
+)
+
+var (
+	ErrAttachmentRepositoryAttachmentNotFound      = errors.New("attachment not found")
+	ErrAttachmentRepositoryCanNotCreateAttachment  = errors.New("can not create attachment")
+	ErrAttachmentRepositoryCanNotGetAttachments    = errors.New("can not get attachments")
+	ErrAttachmentRepositoryCanNotDeleteAttachments = errors.New("can not delete attachments")
+)
+
+type AttachmentRepository interface {
+	Create(attachment *entities.Attachment) error
+	GetByID(id string) (*entities.Attachment, error)
+	GetByItemID(itemID string) ([]*entities.Attachment, error)
+	GetByWarrantyExpiringBetween(householdIDs []string, from time.Time, to time.Time) ([]*entities.Attachment, error)
+	DeleteByItemID(itemID string) error
+}
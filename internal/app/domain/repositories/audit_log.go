@@ -0,0 +1,20 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+)
+
+var (
+	ErrAuditLogRepositoryCanNotCreateAuditLog = errors.New("can not create audit log")
+	ErrAuditLogRepositoryCanNotGetAuditLogs   = errors.New("can not get audit logs")
+	ErrAuditLogRepositoryCanNotCountAuditLogs = errors.New("can not count audit logs")
+)
+
+// AuditLogRepository is append-only, the entries are never updated nor
+// deleted.
+type AuditLogRepository interface {
+	Create(auditLog *entities.AuditLog) error
+	GetByQueryFilters(queryFilter QueryFilter, pageFilter *PageFilter) ([]*entities.AuditLog, error)
+	CountByQueryFilters(queryFilter QueryFilter) (int64, error)
+}
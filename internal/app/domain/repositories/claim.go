@@ -0,0 +1,12 @@
+package repositories
+
+import "time"
+
+// ClaimableRepository is a queue whose records are processed by several
+// workers, each record by the one that claims it.
+type ClaimableRepository[T any] interface {
+	GetAvailable(now time.Time, limit int) ([]T, error)
+	// Claim fails when another worker claimed the record first.
+	Claim(record T, now time.Time, until time.Time) error
+	Update(record T) error
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+)
+
+var (
+	ErrHouseholdRepositoryCanNotCreateHousehold   = errors.New("can not create household")
+	ErrHouseholdRepositoryCanNotGetHouseholds     = errors.New("can not get households")
+	ErrHouseholdRepositoryCanNotCreateMember      = errors.New("can not create household member")
+	ErrHouseholdRepositoryCanNotGetMembers        = errors.New("can not get household members")
+	ErrHouseholdRepositoryMemberNotFound          = errors.New("household member not found")
+	ErrHouseholdRepositoryCanNotCreateInvitation  = errors.New("can not create household invitation")
+	ErrHouseholdRepositoryCanNotGetInvitations    = errors.New("can not get household invitations")
+	ErrHouseholdRepositoryCanNotUpdateInvitation  = errors.New("can not update household invitation")
+	ErrHouseholdRepositoryInvitationNotFound      = errors.New("household invitation not found")
+	ErrHouseholdRepositoryCanNotUpdateMember      = errors.New("can not update household member")
+	ErrHouseholdRepositoryCanNotDeleteHousehold   = errors.New("can not delete household")
+	ErrHouseholdRepositoryCanNotDeleteMember      = errors.New("can not delete household member")
+	ErrHouseholdRepositoryCanNotDeleteInvitations = errors.New("can not delete household invitations")
+)
+
+type HouseholdRepository interface {
+	Create(household *entities.Household) error
+	GetByIDs(ids []string) ([]*entities.Household, error)
+	CreateMember(member *entities.HouseholdMember) error
+	GetMember(householdID string, userID string) (*entities.HouseholdMember, error)
+	GetMembersByUserID(userID string) ([]*entities.HouseholdMember, error)
+	GetMembersByHouseholdID(householdID string) ([]*entities.HouseholdMember, error)
+	UpdateMember(member *entities.HouseholdMember) error
+	CreateInvitation(invitation *entities.HouseholdInvitation) error
+	GetInvitationByID(id string) (*entities.HouseholdInvitation, error)
+	GetPendingInvitationsByEmail(email string) ([]*entities.HouseholdInvitation, error)
+	UpdateInvitation(invitation *entities.HouseholdInvitation) error
+	Delete(id string) error
+	DeleteMember(id string) error
+	DeleteInvitationsByHouseholdID(householdID string) error
+	DeleteInvitationsByInviter(userID string) error
+}
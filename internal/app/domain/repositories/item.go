@@ -11,6 +11,7 @@ var (
 	ErrItemRepositoryCanNotGetByQueryFilters   = errors.New("can not get by query filters")
 	ErrItemRepositoryCanNotUpdateItem          = errors.New("can not update item")
 	ErrItemRepositoryItemNotFound              = errors.New("item not found")
+	ErrItemRepositoryCanNotDeleteItem          = errors.New("can not delete item")
 )
 
 type ItemRepository interface {
@@ -19,4 +20,5 @@ type ItemRepository interface {
 	GetByQueryFilters(queryFilter QueryFilter, pageFilter *PageFilter) ([]*entities.Item, error)
 	CountByQueryFilters(queryFilter QueryFilter) (int64, error)
 	Update(item *entities.Item) error
+	Delete(id string) error
 }
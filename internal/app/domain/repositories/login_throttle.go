@@ -0,0 +1,23 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"time"
+)
+
+var (
+	ErrLoginThrottleRepositoryCanNotGetLoginThrottles   = errors.New("can not get login throttles")
+	ErrLoginThrottleRepositoryCanNotRegisterFailure     = errors.New("can not register failed login")
+	ErrLoginThrottleRepositoryCanNotUpdateLoginThrottle = errors.New("can not update login throttle")
+	ErrLoginThrottleRepositoryCanNotDeleteLoginThrottle = errors.New("can not delete login throttle")
+)
+
+type LoginThrottleRepository interface {
+	GetByIdentifiers(identifiers []string) ([]*entities.LoginThrottle, error)
+	// RegisterFailure adds a failure to the throttle of the identifier,
+	// creating it when needed, and returns the throttle with the new count.
+	RegisterFailure(identifier string, failedAt time.Time) (*entities.LoginThrottle, error)
+	Update(throttle *entities.LoginThrottle) error
+	DeleteByIdentifier(identifier string) error
+}
@@ -0,0 +1,40 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"time"
+)
+
+var (
+	ErrMailMessageRepositoryCanNotCreateMessage   = errors.New("can not create mail message")
+	ErrMailMessageRepositoryMessageNotFound       = errors.New("mail message not found")
+	ErrMailMessageRepositoryCanNotGetMessage      = errors.New("can not get mail message")
+	ErrMailMessageRepositoryCanNotGetMessages     = errors.New("can not get mail messages")
+	ErrMailMessageRepositoryCanNotCountMessages   = errors.New("can not count mail messages")
+	ErrMailMessageRepositoryCanNotClaimMessage    = errors.New("can not claim mail message")
+	ErrMailMessageRepositoryMessageAlreadyClaimed = errors.New("mail message already claimed")
+	ErrMailMessageRepositoryCanNotUpdateMessage   = errors.New("can not update mail message")
+	ErrMailMessageRepositoryCanNotDeleteMessages  = errors.New("can not delete mail messages")
+)
+
+type MailMessageRepository interface {
+	Create(message *entities.MailMessage) error
+	GetByID(id string) (*entities.MailMessage, error)
+	// GetAvailable returns the pending messages whose available at has
+	// passed, oldest first.
+	GetAvailable(now time.Time, limit int) ([]*entities.MailMessage, error)
+	// Claim moves the available at of a pending message that is available at
+	// now to until, so no other worker sends it at the same time. It fails
+	// with ErrMailMessageRepositoryMessageAlreadyClaimed when another worker
+	// was first.
+	Claim(message *entities.MailMessage, now time.Time, until time.Time) error
+	Update(message *entities.MailMessage) error
+	// GetByStatus returns the messages with the status, or all of them when it
+	// is empty, newest first.
+	GetByStatus(status string, pageFilter *PageFilter) ([]*entities.MailMessage, error)
+	CountByStatus(status string) (int64, error)
+	// DeleteFinishedBefore removes the sent and dead messages last updated
+	// before the time and returns how many were removed.
+	DeleteFinishedBefore(before time.Time) (int64, error)
+}
@@ -0,0 +1,51 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"time"
+)
+
+var (
+	ErrNotificationRepositoryCanNotCreatePreference  = errors.New("can not create notification preference")
+	ErrNotificationRepositoryPreferenceNotFound      = errors.New("notification preference not found")
+	ErrNotificationRepositoryCanNotGetPreference     = errors.New("can not get notification preference")
+	ErrNotificationRepositoryCanNotGetPreferences    = errors.New("can not get notification preferences")
+	ErrNotificationRepositoryCanNotUpdatePreference  = errors.New("can not update notification preference")
+	ErrNotificationRepositoryCanNotDeletePreference  = errors.New("can not delete notification preference")
+	ErrNotificationRepositoryCanNotDeletePreferences = errors.New("can not delete notification preferences")
+	ErrNotificationRepositoryCanNotCreateDigestEntry = errors.New("can not create notification digest entry")
+	ErrNotificationRepositoryCanNotGetDigestEntries  = errors.New("can not get notification digest entries")
+	ErrNotificationRepositoryCanNotClaimDigest       = errors.New("can not claim notification digest")
+	ErrNotificationRepositoryDigestAlreadyClaimed    = errors.New("notification digest already claimed")
+	ErrNotificationRepositoryCanNotDeleteDigest      = errors.New("can not delete notification digest entries")
+	ErrNotificationRepositoryCanNotReleaseDigest     = errors.New("can not release notification digest entries")
+)
+
+type NotificationPreferenceRepository interface {
+	Create(preference *entities.NotificationPreference) error
+	GetByID(id string) (*entities.NotificationPreference, error)
+	GetByUserID(userID string) ([]*entities.NotificationPreference, error)
+	Update(preference *entities.NotificationPreference) error
+	Delete(id string) error
+	DeleteByUserID(userID string) error
+}
+
+type NotificationDigestRepository interface {
+	Create(entry *entities.NotificationDigestEntry) error
+	// GetDueUserIDs returns up to limit users with entries whose due at has
+	// passed, leaving out the held ones.
+	GetDueUserIDs(now time.Time, limit int) ([]string, error)
+	// ClaimByUserID moves the due at of the entries of the user that are due
+	// at now to until, so no other worker sends the digest at the same time.
+	// It fails with ErrNotificationRepositoryDigestAlreadyClaimed when another
+	// worker was first.
+	ClaimByUserID(userID string, now time.Time, until time.Time) error
+	// GetClaimedByUserID returns the entries of the user claimed until the
+	// given time, oldest first.
+	GetClaimedByUserID(userID string, until time.Time) ([]*entities.NotificationDigestEntry, error)
+	// ReleaseByUserID makes the held entries of the user due at now.
+	ReleaseByUserID(userID string, now time.Time) error
+	DeleteByIDs(ids []string) error
+	DeleteByUserID(userID string) error
+}
@@ -0,0 +1,39 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"time"
+)
+
+var (
+	ErrOutboxRepositoryCanNotCreateEvent   = errors.New("can not create outbox event")
+	ErrOutboxRepositoryCanNotGetEvents     = errors.New("can not get outbox events")
+	ErrOutboxRepositoryCanNotCountEvents   = errors.New("can not count outbox events")
+	ErrOutboxRepositoryCanNotClaimEvent    = errors.New("can not claim outbox event")
+	ErrOutboxRepositoryEventAlreadyClaimed = errors.New("outbox event already claimed")
+	ErrOutboxRepositoryCanNotUpdateEvent   = errors.New("can not update outbox event")
+	ErrOutboxRepositoryCanNotRequeueEvents = errors.New("can not requeue outbox events")
+	ErrOutboxRepositoryCanNotGetHandlers   = errors.New("can not get outbox event handlers")
+	ErrOutboxRepositoryCanNotCreateHandler = errors.New("can not create outbox event handler")
+)
+
+type OutboxRepository interface {
+	Create(event *entities.OutboxEvent) error
+	// GetAvailable returns the pending events whose available at has passed,
+	// oldest first.
+	GetAvailable(now time.Time, limit int) ([]*entities.OutboxEvent, error)
+	// Claim moves the available at of a pending event that is available at
+	// now to until, so no other dispatcher picks it while it is delivered. It
+	// fails with ErrOutboxRepositoryEventAlreadyClaimed when another
+	// dispatcher was first.
+	Claim(event *entities.OutboxEvent, now time.Time, until time.Time) error
+	Update(event *entities.OutboxEvent) error
+	GetByStatus(status string, limit int) ([]*entities.OutboxEvent, error)
+	CountByStatus(status string) (int64, error)
+	// RequeueDead makes the dead events pending again with no attempts.
+	RequeueDead(now time.Time) (int64, error)
+	// GetDeliveredHandlers returns the handlers that already got the event.
+	GetDeliveredHandlers(eventID string) ([]string, error)
+	CreateDeliveredHandler(handler *entities.OutboxEventHandler) error
+}
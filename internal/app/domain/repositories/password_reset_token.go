@@ -0,0 +1,22 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+)
+
+var (
+	ErrPasswordResetTokenRepositoryCanNotCreateToken      = errors.New("can not create password reset token")
+	ErrPasswordResetTokenRepositoryTokenNotFound          = errors.New("password reset token not found")
+	ErrPasswordResetTokenRepositoryCanNotGetToken         = errors.New("can not get password reset token")
+	ErrPasswordResetTokenRepositoryCanNotUseToken         = errors.New("can not use password reset token")
+	ErrPasswordResetTokenRepositoryTokenAlreadyUsed       = errors.New("password reset token already used")
+	ErrPasswordResetTokenRepositoryCanNotDeleteUserTokens = errors.New("can not delete user password reset tokens")
+)
+
+type PasswordResetTokenRepository interface {
+	Create(token *entities.PasswordResetToken) error
+	GetByTokenHash(tokenHash string) (*entities.PasswordResetToken, error)
+	MarkAsUsed(id string) error
+	DeleteByUserID(userID string) error
+}
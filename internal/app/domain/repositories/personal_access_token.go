@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"time"
+)
+
+var (
+	ErrPersonalAccessTokenRepositoryCanNotCreatePersonalAccessToken  = errors.New("can not create personal access token")
+	ErrPersonalAccessTokenRepositoryPersonalAccessTokenNotFound      = errors.New("personal access token not found")
+	ErrPersonalAccessTokenRepositoryCanNotGetPersonalAccessToken     = errors.New("can not get personal access token")
+	ErrPersonalAccessTokenRepositoryCanNotGetPersonalAccessTokens    = errors.New("can not get personal access tokens")
+	ErrPersonalAccessTokenRepositoryCanNotRevokePersonalAccessToken  = errors.New("can not revoke personal access token")
+	ErrPersonalAccessTokenRepositoryCanNotUpdateLastUsedAt           = errors.New("can not update personal access token last used at")
+	ErrPersonalAccessTokenRepositoryCanNotDeletePersonalAccessTokens = errors.New("can not delete personal access tokens")
+)
+
+type PersonalAccessTokenRepository interface {
+	Create(token *entities.PersonalAccessToken) error
+	GetByID(id string) (*entities.PersonalAccessToken, error)
+	GetByTokenHash(tokenHash string) (*entities.PersonalAccessToken, error)
+	GetByUserID(userID string) ([]*entities.PersonalAccessToken, error)
+	Revoke(id string) error
+	RevokeByUserID(userID string) error
+	UpdateLastUsedAt(id string, lastUsedAt time.Time) error
+	DeleteByUserID(userID string) error
+}
@@ -0,0 +1,29 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+)
+
+var (
+	ErrRefreshTokenRepositoryCanNotCreateRefreshToken = errors.New("can not create refresh token")
+	ErrRefreshTokenRepositoryRefreshTokenNotFound     = errors.New("refresh token not found")
+	ErrRefreshTokenRepositoryCanNotGetRefreshToken    = errors.New("can not get refresh token")
+	ErrRefreshTokenRepositoryCanNotUseRefreshToken    = errors.New("can not use refresh token")
+	ErrRefreshTokenRepositoryRefreshTokenAlreadyUsed  = errors.New("refresh token already used")
+	ErrRefreshTokenRepositoryCanNotRevokeFamily       = errors.New("can not revoke refresh token family")
+	ErrRefreshTokenRepositoryCanNotCheckFamily        = errors.New("can not check refresh token family")
+	ErrRefreshTokenRepositoryCanNotRevokeUserTokens   = errors.New("can not revoke user refresh tokens")
+	ErrRefreshTokenRepositoryCanNotDeleteUserTokens   = errors.New("can not delete user refresh tokens")
+)
+
+type RefreshTokenRepository interface {
+	Create(token *entities.RefreshToken) error
+	GetByTokenHash(tokenHash string) (*entities.RefreshToken, error)
+	MarkAsUsed(id string) error
+	RevokeFamily(familyID string) error
+	RevokeByUserID(userID string) error
+	RevokeByUserIDExceptFamily(userID string, familyID string) error
+	IsFamilyRevoked(familyID string) (bool, error)
+	DeleteByUserID(userID string) error
+}
@@ -0,0 +1,28 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+)
+
+var (
+	ErrStoredFileRepositoryCanNotCreateFile = errors.New("can not create stored file")
+	ErrStoredFileRepositoryFileNotFound     = errors.New("stored file not found")
+	ErrStoredFileRepositoryCanNotGetFile    = errors.New("can not get stored file")
+	ErrStoredFileRepositoryCanNotUpdateFile = errors.New("can not update stored file")
+	ErrStoredFileRepositoryCanNotDeleteFile = errors.New("can not delete stored file")
+	ErrStoredFileRepositoryFileConflict     = errors.New("stored file changed by another transaction")
+)
+
+// StoredFileRepository is used inside a Transaction. The files it gets are
+// locked until the transaction ends, so their reference count can not change
+// between reading and writing it. ErrStoredFileRepositoryFileConflict means
+// another transaction created or locked the same file first, and the whole
+// transaction can be retried.
+type StoredFileRepository interface {
+	Create(file *entities.StoredFile) error
+	GetByHashForUpdate(hash string, extension string) (*entities.StoredFile, error)
+	GetByIDForUpdate(id string) (*entities.StoredFile, error)
+	Update(file *entities.StoredFile) error
+	Delete(id string) error
+}
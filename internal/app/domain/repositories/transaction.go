@@ -0,0 +1,24 @@
+package repositories
+
+import "errors"
+
+var (
+	ErrTransactionManagerCanNotBegin  = errors.New("can not begin transaction")
+	ErrTransactionManagerCanNotCommit = errors.New("can not commit transaction")
+)
+
+// Transaction gives the repositories that write inside a running
+// transaction. Their changes are committed together or not at all.
+type Transaction interface {
+	RoomRepository() RoomRepository
+	BoxRepository() BoxRepository
+	OutboxRepository() OutboxRepository
+	AssetRepository() AssetRepository
+	StoredFileRepository() StoredFileRepository
+}
+
+type TransactionManager interface {
+	// Transaction commits when fn returns nil and rolls back otherwise,
+	// returning the error of fn as it is.
+	Transaction(fn func(tx Transaction) error) error
+}
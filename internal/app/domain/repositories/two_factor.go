@@ -0,0 +1,30 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+)
+
+var (
+	ErrTwoFactorRepositoryCanNotCreateTwoFactor     = errors.New("can not create two factor")
+	ErrTwoFactorRepositoryTwoFactorNotFound         = errors.New("two factor not found")
+	ErrTwoFactorRepositoryCanNotGetTwoFactor        = errors.New("can not get two factor")
+	ErrTwoFactorRepositoryCanNotUpdateTwoFactor     = errors.New("can not update two factor")
+	ErrTwoFactorRepositoryCodeAlreadyUsed           = errors.New("two factor code already used")
+	ErrTwoFactorRepositoryCanNotDeleteTwoFactor     = errors.New("can not delete two factor")
+	ErrTwoFactorRepositoryCanNotCreateRecoveryCodes = errors.New("can not create recovery codes")
+	ErrTwoFactorRepositoryCanNotUseRecoveryCode     = errors.New("can not use recovery code")
+	ErrTwoFactorRepositoryRecoveryCodeNotFound      = errors.New("recovery code not found")
+	ErrTwoFactorRepositoryCanNotDeleteRecoveryCodes = errors.New("can not delete recovery codes")
+)
+
+type TwoFactorRepository interface {
+	Create(twoFactor *entities.TwoFactor) error
+	GetByUserID(userID string) (*entities.TwoFactor, error)
+	Update(twoFactor *entities.TwoFactor) error
+	UseStep(id string, step int64) error
+	DeleteByUserID(userID string) error
+	CreateRecoveryCodes(codes []*entities.RecoveryCode) error
+	UseRecoveryCode(userID string, codeHash string) error
+	DeleteRecoveryCodesByUserID(userID string) error
+}
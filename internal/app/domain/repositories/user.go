@@ -6,14 +6,19 @@ import (
 )
 
 var (
-	ErrUserRepositoryCanNotCreateUser     = errors.New("can not create user")
-	ErrUserRepositoryCanNotGetUser        = errors.New("can not get user")
-	ErrUserRepositoryCanNotGetUserByBoxID = errors.New("can not get user by box id")
-	ErrUserRepositoryUserNotFound         = errors.New("user not found")
+	ErrUserRepositoryCanNotCreateUser      = errors.New("can not create user")
+	ErrUserRepositoryCanNotGetUser         = errors.New("can not get user")
+	ErrUserRepositoryCanNotGetUsersByBoxID = errors.New("can not get users by box id")
+	ErrUserRepositoryCanNotUpdateUser      = errors.New("can not update user")
+	ErrUserRepositoryUserNotFound          = errors.New("user not found")
+	ErrUserRepositoryCanNotDeleteUser      = errors.New("can not delete user")
 )
 
 type UserRepository interface {
 	Create(user *entities.User) error
 	FindByEmail(email string) (*entities.User, error)
-	GetUserByBoxID(boxID string) (*entities.User, error)
+	GetByID(id string) (*entities.User, error)
+	GetUsersByBoxID(boxID string) ([]*entities.User, error)
+	Update(user *entities.User) error
+	Delete(id string) error
 }
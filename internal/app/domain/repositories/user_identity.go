@@ -0,0 +1,19 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+)
+
+var (
+	ErrUserIdentityRepositoryCanNotCreateUserIdentity   = errors.New("can not create user identity")
+	ErrUserIdentityRepositoryUserIdentityNotFound       = errors.New("user identity not found")
+	ErrUserIdentityRepositoryCanNotGetUserIdentity      = errors.New("can not get user identity")
+	ErrUserIdentityRepositoryCanNotDeleteUserIdentities = errors.New("can not delete user identities")
+)
+
+type UserIdentityRepository interface {
+	Create(identity *entities.UserIdentity) error
+	GetByIssuerAndSubject(issuer string, subject string) (*entities.UserIdentity, error)
+	DeleteByUserID(userID string) error
+}
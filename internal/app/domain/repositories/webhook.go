@@ -0,0 +1,52 @@
+package repositories
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"time"
+)
+
+var (
+	ErrWebhookRepositoryCanNotCreateWebhook    = errors.New("can not create webhook")
+	ErrWebhookRepositoryWebhookNotFound        = errors.New("webhook not found")
+	ErrWebhookRepositoryCanNotGetWebhook       = errors.New("can not get webhook")
+	ErrWebhookRepositoryCanNotGetWebhooks      = errors.New("can not get webhooks")
+	ErrWebhookRepositoryCanNotDeleteWebhook    = errors.New("can not delete webhook")
+	ErrWebhookRepositoryCanNotDeleteWebhooks   = errors.New("can not delete webhooks")
+	ErrWebhookRepositoryCanNotCreateDelivery   = errors.New("can not create webhook delivery")
+	ErrWebhookRepositoryDeliveryNotFound       = errors.New("webhook delivery not found")
+	ErrWebhookRepositoryCanNotGetDelivery      = errors.New("can not get webhook delivery")
+	ErrWebhookRepositoryCanNotGetDeliveries    = errors.New("can not get webhook deliveries")
+	ErrWebhookRepositoryCanNotCountDeliveries  = errors.New("can not count webhook deliveries")
+	ErrWebhookRepositoryCanNotClaimDelivery    = errors.New("can not claim webhook delivery")
+	ErrWebhookRepositoryDeliveryAlreadyClaimed = errors.New("webhook delivery already claimed")
+	ErrWebhookRepositoryCanNotUpdateDelivery   = errors.New("can not update webhook delivery")
+)
+
+type WebhookRepository interface {
+	Create(webhook *entities.Webhook) error
+	GetByID(id string) (*entities.Webhook, error)
+	GetByUserID(userID string) ([]*entities.Webhook, error)
+	GetByHouseholdID(householdID string) ([]*entities.Webhook, error)
+	// Delete removes the webhook with its deliveries.
+	Delete(id string) error
+	// DeleteByUserID removes the webhooks of the user with their deliveries.
+	DeleteByUserID(userID string) error
+}
+
+type WebhookDeliveryRepository interface {
+	Create(delivery *entities.WebhookDelivery) error
+	GetByID(id string) (*entities.WebhookDelivery, error)
+	// GetAvailable returns the pending deliveries whose available at has
+	// passed, oldest first.
+	GetAvailable(now time.Time, limit int) ([]*entities.WebhookDelivery, error)
+	// Claim moves the available at of a pending delivery that is available at
+	// now to until, so no other worker sends it at the same time. It fails
+	// with ErrWebhookRepositoryDeliveryAlreadyClaimed when another worker was
+	// first.
+	Claim(delivery *entities.WebhookDelivery, now time.Time, until time.Time) error
+	Update(delivery *entities.WebhookDelivery) error
+	// GetByWebhookID returns the deliveries of the webhook, newest first.
+	GetByWebhookID(webhookID string, pageFilter *PageFilter) ([]*entities.WebhookDelivery, error)
+	CountByWebhookID(webhookID string) (int64, error)
+}
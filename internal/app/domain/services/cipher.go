@@ -0,0 +1,12 @@
+package services
+
+import "errors"
+
+var ErrCipherCanNotDecrypt = errors.New("can not decrypt value")
+
+// Cipher encrypts values that are stored and read back later, like the
+// bodies of the queued mails, so the database alone does not reveal them.
+type Cipher interface {
+	Encrypt(value string) (string, error)
+	Decrypt(encrypted string) (string, error)
+}
@@ -8,11 +8,16 @@ import (
 
 type Event interface{}
 
-type EventHandler func(event Event)
+type EventHandler func(event Event) error
 
+// EventBus runs the handlers of a Subscribe one after another, in the order
+// they were subscribed, and the ones of a SubscribeAsync at the same time as
+// the others. The name of a handler is what its deliveries are recorded with,
+// so it must not change between releases.
 type EventBus interface {
 	Publish(event Event) error
-	Subscribe(event Event, handler EventHandler)
+	Subscribe(event Event, name string, handler EventHandler)
+	SubscribeAsync(event Event, name string, handler EventHandler)
 }
 
 func GetEventType(event Event) string {
@@ -46,3 +51,72 @@ type ItemKeywordsNotCreatedEvent struct {
 	ItemKeywords []*entities.ItemKeyword
 	Asset        entities.Asset
 }
+
+type AttachmentNotCreatedEvent struct {
+	Attachment entities.Attachment
+	Asset      entities.Asset
+}
+
+type PasswordResetRequestedEvent struct {
+	User entities.User
+}
+
+type UserCreatedEvent struct {
+	User entities.User
+}
+
+type EmailVerifiedEvent struct {
+	User entities.User
+}
+
+type HouseholdInvitationCreatedEvent struct {
+	Invitation entities.HouseholdInvitation
+}
+
+type LoginLockedEvent struct {
+	User entities.User
+}
+
+type EmailChangeRequestedEvent struct {
+	User     entities.User
+	NewEmail string
+}
+
+type AccountDeletionRequestedEvent struct {
+	User entities.User
+}
+
+type RoomCreatedEvent struct {
+	Room entities.Room
+}
+
+type RoomUpdatedEvent struct {
+	Room entities.Room
+}
+
+type RoomDeletedEvent struct {
+	Room entities.Room
+}
+
+type BoxCreatedEvent struct {
+	Box         entities.Box
+	HouseholdID string
+}
+
+type BoxUpdatedEvent struct {
+	Box         entities.Box
+	HouseholdID string
+}
+
+type BoxDeletedEvent struct {
+	Box         entities.Box
+	HouseholdID string
+}
+
+type ItemCreatedEvent struct {
+	Item entities.Item
+}
+
+type ItemUpdatedEvent struct {
+	Item entities.Item
+}
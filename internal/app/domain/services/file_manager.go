@@ -2,18 +2,30 @@ package services
 
 import (
 	"errors"
-	"os"
+	"io"
+	"time"
 )
 
 var (
+	ErrFileManagerCanNotCheckFile  = errors.New("can not check file")
 	ErrFileManagerCanNotDeleteFile = errors.New("can not delete file")
-	ErrFileManagerCanNotSeekFile   = errors.New("can not seek file")
+	ErrFileManagerCanNotListFiles  = errors.New("can not list files")
+	ErrFileManagerCanNotOpenFile   = errors.New("can not open file")
 	ErrFileManagerCanNotUploadFile = errors.New("can not upload file")
 	ErrFileManagerUploadingFile    = errors.New("error uploading file")
 )
 
+type StoredFile struct {
+	ID           string
+	Extension    string
+	LastModified time.Time
+}
+
 type FileManager interface {
-	Upload(file *os.File) (string, error)
+	Upload(content io.Reader, size int64, contentType string, extension string) (string, error)
 	GenerateUrl(id string, extension string) string
 	Delete(id string, extension string) error
+	List() ([]StoredFile, error)
+	Open(id string, extension string) (io.ReadSeekCloser, error)
+	Exists(id string, extension string) (bool, error)
 }
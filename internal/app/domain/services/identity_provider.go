@@ -0,0 +1,25 @@
+package services
+
+import "errors"
+
+var (
+	ErrIdentityProviderCanNotDiscover     = errors.New("can not discover the identity provider")
+	ErrIdentityProviderCanNotExchangeCode = errors.New("can not exchange the authorization code")
+	ErrIdentityProviderInvalidIDToken     = errors.New("id token is not valid")
+)
+
+// Identity is the account of a user in an identity provider. Issuer and
+// Subject identify it, the email can change.
+type Identity struct {
+	Issuer        string
+	Subject       string
+	Email         string
+	EmailVerified bool
+}
+
+// IdentityProvider signs users in with the OpenID Connect authorization code
+// flow and PKCE.
+type IdentityProvider interface {
+	AuthorizationURL(state string, nonce string, codeChallenge string) (string, error)
+	Exchange(code string, codeVerifier string, nonce string) (*Identity, error)
+}
@@ -0,0 +1,15 @@
+package services
+
+import (
+	"errors"
+	"io"
+)
+
+var (
+	ErrImageProcessorCanNotProcessImage = errors.New("can not process image")
+	ErrImageProcessorImageTooLarge      = errors.New("image is too large")
+)
+
+type ImageProcessor interface {
+	RemoveMetadata(content io.Reader, size int64) (io.Reader, int64, error)
+}
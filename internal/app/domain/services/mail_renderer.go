@@ -0,0 +1,35 @@
+package services
+
+import "errors"
+
+var (
+	ErrMailRendererCanNotRenderMail = errors.New("mail renderer can not render mail")
+)
+
+const (
+	MailTemplateBoxItemAdded       = "box_item_added"
+	MailTemplateBoxItemRemoved     = "box_item_removed"
+	MailTemplateNotificationDigest = "notification_digest"
+)
+
+// MailRenderer renders the plain text and html bodies of the mail template
+// with the name.
+type MailRenderer interface {
+	Render(name string, data interface{}) (text string, html string, err error)
+}
+
+// BoxItemMailData is the data of the box item added and removed templates.
+// HappenedAt is already formatted in the timezone of the recipient.
+type BoxItemMailData struct {
+	Quantity   string
+	ItemName   string
+	BoxName    string
+	RoomName   string
+	HappenedAt string
+}
+
+// NotificationDigestMailData is the data of the notification digest
+// template, one message for every notification.
+type NotificationDigestMailData struct {
+	Messages []string
+}
@@ -6,6 +6,17 @@ var (
 	ErrMailSenderCannotSendMail = errors.New("mail sender cannot send mail")
 )
 
+// Mail is an email with a plain text body and an optional html one, sent as
+// alternatives of the same content.
+type Mail struct {
+	To      string
+	Subject string
+	Text    string
+	HTML    string
+}
+
 type MailSender interface {
+	// SendMail sends a plain text email.
 	SendMail(to string, subject string, body string) error
+	Send(mail Mail) error
 }
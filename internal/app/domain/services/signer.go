@@ -0,0 +1,18 @@
+package services
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrSignerSignatureIsNotValid = errors.New("signature is not valid")
+	ErrSignerSignatureExpired    = errors.New("signature expired")
+)
+
+// Signer signs values that travel outside the API, like links sent by email,
+// so they can be trusted when they come back.
+type Signer interface {
+	Sign(value string, expiresAt time.Time) string
+	Verify(signed string) (string, error)
+}
@@ -9,13 +9,28 @@ var (
 	ErrTokenGeneratorUnableToParseClaims = errors.New("unable to parse claims")
 )
 
+// PublicKey is a key that verifies access tokens, in the fields of a JSON Web
+// Key (RFC 7517). Modulus and Exponent are set for RSA keys, Curve and X for
+// Ed25519 keys.
+type PublicKey struct {
+	ID        string
+	Type      string
+	Algorithm string
+	Modulus   string
+	Exponent  string
+	Curve     string
+	X         string
+}
+
 type TokenGenerator interface {
-	GenerateToken(id string, email string) (string, error)
+	GenerateToken(id string, email string, sessionID string) (string, error)
 	ParseToken(token string) (
 		*struct {
-			ID    string
-			Email string
+			ID        string
+			Email     string
+			SessionID string
 		},
 		error,
 	)
+	PublicKeys() []PublicKey
 }
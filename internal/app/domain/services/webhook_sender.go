@@ -0,0 +1,18 @@
+package services
+
+import "errors"
+
+var (
+	ErrWebhookSenderCanNotSend           = errors.New("can not send webhook")
+	ErrWebhookSenderAddressIsNotAllowed  = errors.New("webhook address is not allowed")
+	ErrWebhookSenderUnexpectedStatusCode = errors.New("webhook endpoint answered with an unexpected status code")
+)
+
+// WebhookSender posts the payload of a delivery to a webhook, signed with its
+// secret so the endpoint can check where it comes from.
+type WebhookSender interface {
+	// Send returns the status code of the response, zero when the endpoint
+	// did not answer. A status code other than 2xx is an
+	// ErrWebhookSenderUnexpectedStatusCode.
+	Send(url string, secret string, deliveryID string, eventType string, payload string) (int, error)
+}
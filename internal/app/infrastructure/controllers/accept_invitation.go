@@ -0,0 +1,74 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type AcceptInvitationController struct {
+	householdService *services.HouseholdService
+	auditService     *services.AuditService
+}
+
+type AcceptInvitationRequest struct {
+	InvitationID string `param:"invitationID"`
+}
+
+type AcceptInvitationResponse struct {
+	HouseholdID string `json:"household_id"`
+	Role        string `json:"role"`
+}
+
+func NewAcceptInvitationController(
+	householdService *services.HouseholdService,
+	auditService *services.AuditService,
+) *AcceptInvitationController {
+	return &AcceptInvitationController{
+		householdService,
+		auditService,
+	}
+}
+
+func (c *AcceptInvitationController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := AcceptInvitationRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	member, err := c.householdService.AcceptInvitation(request.InvitationID, userID)
+	if errors.Is(err, services.ErrHouseholdServiceInvitationNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if errors.Is(err, services.ErrHouseholdServiceEmailNotVerified) {
+		return ctx.JSON(http.StatusForbidden, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionAcceptInvitation,
+		entities.AuditEntityTypeHouseholdInvitation,
+		request.InvitationID,
+		member.HouseholdID,
+		nil,
+		member,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&AcceptInvitationResponse{
+		HouseholdID: member.HouseholdID,
+		Role:        member.Role,
+	}))
+}
@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type AddItemIntoBoxController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	auditService *services.AuditService
 }
 
 type AddItemIntoBoxRequest struct {
@@ -24,14 +27,19 @@ type AddItemIntoBoxResponse struct {
 	BoxID    string  `json:"box_id"`
 }
 
-func NewAddItemIntoBoxController(boxService *services.BoxService) *AddItemIntoBoxController {
+func NewAddItemIntoBoxController(
+	boxService *services.BoxService,
+	auditService *services.AuditService,
+) *AddItemIntoBoxController {
 	return &AddItemIntoBoxController{
 		boxService,
+		auditService,
 	}
 }
 
 func (c *AddItemIntoBoxController) Handle(ctx echo.Context) error {
 	request := AddItemIntoBoxRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
@@ -43,15 +51,37 @@ func (c *AddItemIntoBoxController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	box, room, err := c.boxService.Get(request.BoxID, userID)
+	if err != nil {
+		return err
+	}
+
 	boxItem, err := c.boxService.AddItemIntoBox(
 		request.Quantity,
 		request.BoxID,
 		request.ItemID,
+		userID,
 	)
 	if err != nil {
 		return err
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionAddItem,
+		box.EntityName(),
+		box.ID,
+		room.HouseholdID,
+		nil,
+		map[string]interface{}{
+			"item_id":  boxItem.ItemID,
+			"quantity": request.Quantity,
+		},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&AddItemIntoBoxResponse{
 		ID:       boxItem.ID,
 		Quantity: boxItem.Quantity,
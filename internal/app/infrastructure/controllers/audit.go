@@ -0,0 +1,16 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/labstack/echo/v4"
+)
+
+// newAuditActor returns who made the request and from where, to record it in
+// the audit log.
+func newAuditActor(ctx echo.Context, userID string) services.AuditActor {
+	return services.AuditActor{
+		UserID:    userID,
+		IPAddress: ctx.RealIP(),
+		UserAgent: ctx.Request().UserAgent(),
+	}
+}
@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type ChangeBoxRoomController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	auditService *services.AuditService
 }
 
 type ChangeBoxRoomRequest struct {
@@ -16,14 +19,19 @@ type ChangeBoxRoomRequest struct {
 	RoomID string `json:"room_id"`
 }
 
-func NewChangeBoxRoomController(boxService *services.BoxService) *ChangeBoxRoomController {
+func NewChangeBoxRoomController(
+	boxService *services.BoxService,
+	auditService *services.AuditService,
+) *ChangeBoxRoomController {
 	return &ChangeBoxRoomController{
-		boxService: boxService,
+		boxService:   boxService,
+		auditService: auditService,
 	}
 }
 
 func (c *ChangeBoxRoomController) Handle(ctx echo.Context) error {
 	request := ChangeBoxRoomRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
@@ -35,10 +43,28 @@ func (c *ChangeBoxRoomController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	err = c.boxService.TransferToRoom(request.BoxID, request.RoomID)
+	box, room, err := c.boxService.Get(request.BoxID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.boxService.TransferToRoom(request.BoxID, request.RoomID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionChangeRoom,
+		box.EntityName(),
+		box.ID,
+		room.HouseholdID,
+		map[string]string{"room_id": box.RoomID},
+		map[string]string{"room_id": request.RoomID},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusNoContent, nil)
 }
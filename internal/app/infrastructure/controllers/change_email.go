@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type ChangeEmailController struct {
+	userService  *services.UserService
+	auditService *services.AuditService
+}
+
+type ChangeEmailRequest struct {
+	Email    string `json:"email"`
+	Password string `json:"password"`
+}
+
+func NewChangeEmailController(
+	userService *services.UserService,
+	auditService *services.AuditService,
+) *ChangeEmailController {
+	return &ChangeEmailController{
+		userService,
+		auditService,
+	}
+}
+
+func (c *ChangeEmailController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := ChangeEmailRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.userService.RequestEmailChange(userID, request.Email, request.Password)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionRequestEmailChange,
+		entities.AuditEntityTypeUser,
+		userID,
+		"",
+		nil,
+		map[string]string{"email": request.Email},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(
+		http.StatusAccepted,
+		responses.NewMessageResponse("open the link sent to the new email to confirm the change"),
+	)
+}
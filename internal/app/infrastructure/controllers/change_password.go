@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type ChangePasswordController struct {
+	userService  *services.UserService
+	auditService *services.AuditService
+}
+
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password"`
+	NewPassword     string `json:"new_password"`
+}
+
+func NewChangePasswordController(
+	userService *services.UserService,
+	auditService *services.AuditService,
+) *ChangePasswordController {
+	return &ChangePasswordController{
+		userService,
+		auditService,
+	}
+}
+
+func (c *ChangePasswordController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	sessionID := ctx.Get("auth_session_id").(string)
+	request := ChangePasswordRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.userService.ChangePassword(userID, sessionID, request.CurrentPassword, request.NewPassword)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionChangePassword,
+		entities.AuditEntityTypeUser,
+		userID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewMessageResponse("password changed successfully"))
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type ChangeTimezoneController struct {
+	userService *services.UserService
+}
+
+type ChangeTimezoneRequest struct {
+	Timezone string `json:"timezone"`
+}
+
+type ChangeTimezoneResponse struct {
+	Timezone string `json:"timezone"`
+}
+
+func NewChangeTimezoneController(userService *services.UserService) *ChangeTimezoneController {
+	return &ChangeTimezoneController{
+		userService,
+	}
+}
+
+func (c *ChangeTimezoneController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := ChangeTimezoneRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	user, err := c.userService.ChangeTimezone(userID, request.Timezone)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&ChangeTimezoneResponse{
+		Timezone: user.Timezone,
+	}))
+}
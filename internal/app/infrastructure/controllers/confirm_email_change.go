@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type ConfirmEmailChangeController struct {
+	userService  *services.UserService
+	auditService *services.AuditService
+}
+
+type ConfirmEmailChangeRequest struct {
+	Token string `query:"token"`
+}
+
+func NewConfirmEmailChangeController(
+	userService *services.UserService,
+	auditService *services.AuditService,
+) *ConfirmEmailChangeController {
+	return &ConfirmEmailChangeController{
+		userService,
+		auditService,
+	}
+}
+
+func (c *ConfirmEmailChangeController) Handle(ctx echo.Context) error {
+	request := ConfirmEmailChangeRequest{}
+
+	err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	user, err := c.userService.ConfirmEmailChange(request.Token)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, user.ID),
+		entities.AuditActionConfirmEmailChange,
+		entities.AuditEntityTypeUser,
+		user.ID,
+		"",
+		nil,
+		map[string]string{"email": user.Email},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewMessageResponse("email changed successfully"))
+}
@@ -0,0 +1,70 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type ConfirmTwoFactorController struct {
+	twoFactorService *services.TwoFactorService
+	auditService     *services.AuditService
+}
+
+type TwoFactorCodeRequest struct {
+	Code string `json:"code"`
+}
+
+type RecoveryCodesResponse struct {
+	RecoveryCodes []string `json:"recovery_codes"`
+}
+
+func NewConfirmTwoFactorController(
+	twoFactorService *services.TwoFactorService,
+	auditService *services.AuditService,
+) *ConfirmTwoFactorController {
+	return &ConfirmTwoFactorController{
+		twoFactorService,
+		auditService,
+	}
+}
+
+// Handle returns the recovery codes only once, they can not be listed later.
+func (c *ConfirmTwoFactorController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := TwoFactorCodeRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	recoveryCodes, err := c.twoFactorService.Confirm(userID, request.Code)
+	if errors.Is(err, services.ErrTwoFactorServiceAlreadyEnabled) {
+		return ctx.JSON(http.StatusConflict, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionEnableTwoFactor,
+		entities.AuditEntityTypeUser,
+		userID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&RecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}))
+}
@@ -1,16 +1,18 @@
 package controllers
 
 import (
+	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
-	"os"
 )
 
 type CreateAssetController struct {
 	assetService *services.AssetService
+	auditService *services.AuditService
 }
 
 type CreateAssetResponse struct {
@@ -26,29 +28,41 @@ type CreateAssetResponse struct {
 
 func NewCreateAssetController(
 	assetService *services.AssetService,
+	auditService *services.AuditService,
 ) *CreateAssetController {
-	return &CreateAssetController{assetService}
+	return &CreateAssetController{assetService, auditService}
 }
 
 func (c *CreateAssetController) Handle(ctx echo.Context) error {
-	fileHeader, err := ctx.FormFile("file")
+	file, err := readMultipartFile(ctx, "file")
 	if err != nil {
 		return err
 	}
-	tempDir, tempFile, err := mapFileHeaderToTempFolderAndFile(fileHeader)
-	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
-	}
-	defer os.RemoveAll(tempDir)
-	defer tempFile.Close()
+	defer file.Close()
 
 	userID := ctx.Get("auth_id").(string)
 
-	asset, err := c.assetService.CreateFromFile(tempFile, entities.NewIdentifiableEntity(userID))
+	asset, err := c.assetService.CreateFromFile(mapPartToFileUpload(file), entities.NewIdentifiableEntity(userID))
+	if errors.Is(file.Err(), errMultipartFileNotLast) {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(file.Err().Error()))
+	}
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		entities.AuditEntityTypeAsset,
+		asset.ID,
+		"",
+		nil,
+		asset,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&CreateAssetResponse{
 		ID:         asset.ID,
 		Name:       asset.Name,
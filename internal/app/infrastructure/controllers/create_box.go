@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type CreateBoxController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	auditService *services.AuditService
 }
 
 type CreateBoxRequest struct {
@@ -23,12 +26,19 @@ type CreateBoxResponse struct {
 	Description *string `json:"description"`
 }
 
-func NewCreateBoxController(boxService *services.BoxService) *CreateBoxController {
-	return &CreateBoxController{boxService}
+func NewCreateBoxController(
+	boxService *services.BoxService,
+	auditService *services.AuditService,
+) *CreateBoxController {
+	return &CreateBoxController{
+		boxService,
+		auditService,
+	}
 }
 
 func (c *CreateBoxController) Handle(ctx echo.Context) error {
 	request := CreateBoxRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
@@ -40,16 +50,32 @@ func (c *CreateBoxController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	room, err := c.boxService.Create(request.Name, request.Description, request.RoomID)
+	box, err := c.boxService.Create(request.Name, request.Description, request.RoomID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	_, room, err := c.boxService.Get(box.ID, userID)
+	if err == nil {
+		err = c.auditService.Record(
+			newAuditActor(ctx, userID),
+			entities.AuditActionCreate,
+			box.EntityName(),
+			box.ID,
+			room.HouseholdID,
+			nil,
+			box,
+		)
+	}
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(
 		&CreateBoxResponse{
-			ID:          room.ID,
-			Name:        room.Name,
-			Description: room.Description,
+			ID:          box.ID,
+			Name:        box.Name,
+			Description: box.Description,
 		},
 	))
 }
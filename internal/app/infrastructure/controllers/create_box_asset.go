@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type CreateBoxAssetController struct {
+	boxService   *services.BoxService
+	assetService *services.AssetService
+	auditService *services.AuditService
+}
+
+type CreateBoxAssetRequest struct {
+	BoxID string `param:"boxID"`
+}
+
+type CreateBoxAssetResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	Size      int64  `json:"size"`
+	Url       string `json:"url"`
+}
+
+func NewCreateBoxAssetController(
+	boxService *services.BoxService,
+	assetService *services.AssetService,
+	auditService *services.AuditService,
+) *CreateBoxAssetController {
+	return &CreateBoxAssetController{
+		boxService,
+		assetService,
+		auditService,
+	}
+}
+
+func (c *CreateBoxAssetController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := CreateBoxAssetRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	file, err := readMultipartFile(ctx, "file")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+	defer file.Close()
+
+	_, room, err := c.boxService.Get(request.BoxID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	asset, err := c.boxService.CreateAsset(request.BoxID, userID, mapPartToFileUpload(file))
+	if errors.Is(file.Err(), errMultipartFileNotLast) {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(file.Err().Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		entities.AuditEntityTypeAsset,
+		asset.ID,
+		room.HouseholdID,
+		nil,
+		asset,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&CreateBoxAssetResponse{
+		ID:        asset.ID,
+		Name:      asset.Name,
+		Extension: asset.Extension,
+		Size:      asset.Size,
+		Url:       c.assetService.GetUrl(asset),
+	}))
+}
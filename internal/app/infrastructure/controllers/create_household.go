@@ -0,0 +1,67 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type CreateHouseholdController struct {
+	householdService *services.HouseholdService
+	auditService     *services.AuditService
+}
+
+type CreateHouseholdRequest struct {
+	Name string `json:"name"`
+}
+
+type CreateHouseholdResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+func NewCreateHouseholdController(
+	householdService *services.HouseholdService,
+	auditService *services.AuditService,
+) *CreateHouseholdController {
+	return &CreateHouseholdController{
+		householdService,
+		auditService,
+	}
+}
+
+func (c *CreateHouseholdController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := CreateHouseholdRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	household, err := c.householdService.Create(request.Name, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreateHousehold,
+		entities.AuditEntityTypeHousehold,
+		household.ID,
+		household.ID,
+		nil,
+		household,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&CreateHouseholdResponse{
+		ID:   household.ID,
+		Name: household.Name,
+	}))
+}
@@ -1,15 +1,18 @@
 package controllers
 
 import (
+	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
-	"os"
 )
 
 type CreateItemController struct {
-	itemService *services.ItemService
+	itemService  *services.ItemService
+	auditService *services.AuditService
 }
 
 type CreateItemRequest struct {
@@ -17,6 +20,7 @@ type CreateItemRequest struct {
 	Name        string   `form:"name"`
 	Description *string  `form:"description"`
 	Unit        string   `form:"unit"`
+	HouseholdID string   `form:"household_id"`
 	Keywords    []string `form:"keywords[]"`
 }
 
@@ -31,9 +35,11 @@ type CreateItemResponse struct {
 
 func NewCreateItemController(
 	itemService *services.ItemService,
+	auditService *services.AuditService,
 ) *CreateItemController {
 	return &CreateItemController{
 		itemService,
+		auditService,
 	}
 }
 
@@ -41,34 +47,47 @@ func (c *CreateItemController) Handle(ctx echo.Context) error {
 	userID := ctx.Get("auth_id").(string)
 	request := CreateItemRequest{}
 
-	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
-	if err != nil {
-		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
-	}
-	fileHeader, err := ctx.FormFile("file")
+	file, err := readMultipartFile(ctx, "file")
 	if err != nil {
 		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
 	}
-	tempDir, tempFile, err := mapFileHeaderToTempFolderAndFile(fileHeader)
+	defer file.Close()
+
+	err = (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
-		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
-	defer os.RemoveAll(tempDir)
-	defer tempFile.Close()
 
 	item, err := c.itemService.Create(
 		request.Sku,
 		request.Name,
 		request.Description,
 		request.Unit,
+		request.HouseholdID,
 		userID,
 		request.Keywords,
-		tempFile,
+		mapPartToFileUpload(file),
 	)
+	if errors.Is(file.Err(), errMultipartFileNotLast) {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(file.Err().Error()))
+	}
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		item.EntityName(),
+		item.ID,
+		item.HouseholdID,
+		nil,
+		item,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&CreateItemResponse{
 		ID:          item.ID,
 		Sku:         item.Sku,
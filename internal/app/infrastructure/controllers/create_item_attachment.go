@@ -0,0 +1,137 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type CreateItemAttachmentController struct {
+	itemService  *services.ItemService
+	assetService *services.AssetService
+	auditService *services.AuditService
+}
+
+type CreateItemAttachmentRequest struct {
+	ItemID            string  `param:"itemID"`
+	Kind              string  `form:"kind"`
+	PurchasedAt       *string `form:"purchased_at"`
+	WarrantyExpiresAt *string `form:"warranty_expires_at"`
+}
+
+type CreateItemAttachmentResponse struct {
+	ID                string     `json:"id"`
+	ItemID            string     `json:"item_id"`
+	Kind              string     `json:"kind"`
+	PurchasedAt       *time.Time `json:"purchased_at"`
+	WarrantyExpiresAt *time.Time `json:"warranty_expires_at"`
+	Asset             struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Extension string `json:"extension"`
+		Size      int64  `json:"size"`
+		Url       string `json:"url"`
+	} `json:"asset"`
+}
+
+func NewCreateItemAttachmentController(
+	itemService *services.ItemService,
+	assetService *services.AssetService,
+	auditService *services.AuditService,
+) *CreateItemAttachmentController {
+	return &CreateItemAttachmentController{
+		itemService,
+		assetService,
+		auditService,
+	}
+}
+
+func (c *CreateItemAttachmentController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := CreateItemAttachmentRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	file, err := readMultipartFile(ctx, "file")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+	defer file.Close()
+
+	err = (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	purchasedAt, err := mapDateStringToTime(request.PurchasedAt)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	warrantyExpiresAt, err := mapDateStringToTime(request.WarrantyExpiresAt)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	item, err := c.itemService.Get(request.ItemID, userID)
+	if errors.Is(err, services.ErrItemServiceItemNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	attachment, asset, err := c.itemService.CreateAttachment(
+		request.ItemID,
+		userID,
+		request.Kind,
+		purchasedAt,
+		warrantyExpiresAt,
+		mapPartToFileUpload(file),
+	)
+	if errors.Is(file.Err(), errMultipartFileNotLast) {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(file.Err().Error()))
+	}
+	if errors.Is(err, services.ErrItemServiceItemNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		attachment.EntityName(),
+		attachment.ID,
+		item.HouseholdID,
+		nil,
+		attachment,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	response := &CreateItemAttachmentResponse{
+		ID:                attachment.ID,
+		ItemID:            attachment.ItemID,
+		Kind:              attachment.Kind,
+		PurchasedAt:       attachment.PurchasedAt,
+		WarrantyExpiresAt: attachment.WarrantyExpiresAt,
+	}
+	response.Asset.ID = asset.ID
+	response.Asset.Name = asset.Name
+	response.Asset.Extension = asset.Extension
+	response.Asset.Size = asset.Size
+	response.Asset.Url = c.assetService.GetUrl(asset)
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(response))
+}
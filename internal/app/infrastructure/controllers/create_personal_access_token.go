@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type CreatePersonalAccessTokenController struct {
+	personalAccessTokenService *services.PersonalAccessTokenService
+	auditService               *services.AuditService
+}
+
+type CreatePersonalAccessTokenRequest struct {
+	Name      string  `json:"name"`
+	Scope     string  `json:"scope"`
+	ExpiresAt *string `json:"expires_at"`
+}
+
+type CreatePersonalAccessTokenResponse struct {
+	ID        string     `json:"id"`
+	Name      string     `json:"name"`
+	Scope     string     `json:"scope"`
+	Token     string     `json:"token"`
+	ExpiresAt *time.Time `json:"expires_at"`
+	CreatedAt time.Time  `json:"created_at"`
+}
+
+func NewCreatePersonalAccessTokenController(
+	personalAccessTokenService *services.PersonalAccessTokenService,
+	auditService *services.AuditService,
+) *CreatePersonalAccessTokenController {
+	return &CreatePersonalAccessTokenController{
+		personalAccessTokenService,
+		auditService,
+	}
+}
+
+// Handle returns the token value only once, it can not be listed later.
+func (c *CreatePersonalAccessTokenController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := CreatePersonalAccessTokenRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	expiresAt, err := mapDateStringToTime(request.ExpiresAt)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	token, value, err := c.personalAccessTokenService.Create(userID, request.Name, request.Scope, expiresAt)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		entities.AuditEntityTypePersonalAccessToken,
+		token.ID,
+		"",
+		nil,
+		map[string]interface{}{
+			"name":       token.Name,
+			"scope":      token.Scope,
+			"expires_at": token.ExpiresAt,
+		},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&CreatePersonalAccessTokenResponse{
+		ID:        token.ID,
+		Name:      token.Name,
+		Scope:     token.Scope,
+		Token:     value,
+		ExpiresAt: token.ExpiresAt,
+		CreatedAt: token.CreatedAt,
+	}))
+}
@@ -2,31 +2,38 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type CreateRoomController struct {
-	roomService *services.RoomService
+	roomService  *services.RoomService
+	auditService *services.AuditService
 }
 
 type CreateRoomRequest struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
+	HouseholdID string  `json:"household_id"`
 }
 
 type CreateRoomResponse struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
+	HouseholdID string  `json:"household_id"`
 }
 
 func NewCreateRoomController(
 	roomService *services.RoomService,
+	auditService *services.AuditService,
 ) *CreateRoomController {
 	return &CreateRoomController{
 		roomService,
+		auditService,
 	}
 }
 
@@ -39,16 +46,30 @@ func (c *CreateRoomController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	room, err := c.roomService.Create(request.Name, request.Description, userID)
+	room, err := c.roomService.Create(request.Name, request.Description, request.HouseholdID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		room.EntityName(),
+		room.ID,
+		room.HouseholdID,
+		nil,
+		room,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(
 		&CreateRoomResponse{
 			ID:          room.ID,
 			Name:        room.Name,
 			Description: room.Description,
+			HouseholdID: room.HouseholdID,
 		},
 	))
 }
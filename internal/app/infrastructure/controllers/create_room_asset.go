@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type CreateRoomAssetController struct {
+	roomService  *services.RoomService
+	assetService *services.AssetService
+	auditService *services.AuditService
+}
+
+type CreateRoomAssetRequest struct {
+	RoomID string `param:"roomID"`
+}
+
+type CreateRoomAssetResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	Size      int64  `json:"size"`
+	Url       string `json:"url"`
+}
+
+func NewCreateRoomAssetController(
+	roomService *services.RoomService,
+	assetService *services.AssetService,
+	auditService *services.AuditService,
+) *CreateRoomAssetController {
+	return &CreateRoomAssetController{
+		roomService,
+		assetService,
+		auditService,
+	}
+}
+
+func (c *CreateRoomAssetController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := CreateRoomAssetRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	file, err := readMultipartFile(ctx, "file")
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+	defer file.Close()
+
+	room, err := c.roomService.Get(request.RoomID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	asset, err := c.roomService.CreateAsset(request.RoomID, userID, mapPartToFileUpload(file))
+	if errors.Is(file.Err(), errMultipartFileNotLast) {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(file.Err().Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		entities.AuditEntityTypeAsset,
+		asset.ID,
+		room.HouseholdID,
+		nil,
+		asset,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&CreateRoomAssetResponse{
+		ID:        asset.ID,
+		Name:      asset.Name,
+		Extension: asset.Extension,
+		Size:      asset.Size,
+		Url:       c.assetService.GetUrl(asset),
+	}))
+}
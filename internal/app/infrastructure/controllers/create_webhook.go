@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type CreateWebhookController struct {
+	webhookService *services.WebhookService
+	auditService   *services.AuditService
+}
+
+type CreateWebhookRequest struct {
+	URL         string   `json:"url"`
+	EventTypes  []string `json:"event_types"`
+	HouseholdID string   `json:"household_id"`
+}
+
+type CreateWebhookResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	HouseholdID string    `json:"household_id"`
+	Secret      string    `json:"secret"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewCreateWebhookController(
+	webhookService *services.WebhookService,
+	auditService *services.AuditService,
+) *CreateWebhookController {
+	return &CreateWebhookController{
+		webhookService,
+		auditService,
+	}
+}
+
+// Handle returns the secret only once, it is not listed later.
+func (c *CreateWebhookController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := CreateWebhookRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	webhook, err := c.webhookService.Create(userID, request.HouseholdID, request.URL, request.EventTypes)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionCreate,
+		entities.AuditEntityTypeWebhook,
+		webhook.ID,
+		webhook.HouseholdID,
+		nil,
+		map[string]interface{}{
+			"url":         webhook.URL,
+			"event_types": webhook.GetEventTypes(),
+		},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&CreateWebhookResponse{
+		ID:          webhook.ID,
+		URL:         webhook.URL,
+		EventTypes:  webhook.GetEventTypes(),
+		HouseholdID: webhook.HouseholdID,
+		Secret:      webhook.Secret,
+		CreatedAt:   webhook.CreatedAt,
+	}))
+}
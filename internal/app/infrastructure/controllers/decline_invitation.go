@@ -0,0 +1,66 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type DeclineInvitationController struct {
+	householdService *services.HouseholdService
+	auditService     *services.AuditService
+}
+
+type DeclineInvitationRequest struct {
+	InvitationID string `param:"invitationID"`
+}
+
+func NewDeclineInvitationController(
+	householdService *services.HouseholdService,
+	auditService *services.AuditService,
+) *DeclineInvitationController {
+	return &DeclineInvitationController{
+		householdService,
+		auditService,
+	}
+}
+
+func (c *DeclineInvitationController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := DeclineInvitationRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	invitation, err := c.householdService.DeclineInvitation(request.InvitationID, userID)
+	if errors.Is(err, services.ErrHouseholdServiceInvitationNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if errors.Is(err, services.ErrHouseholdServiceEmailNotVerified) {
+		return ctx.JSON(http.StatusForbidden, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionDeclineInvitation,
+		entities.AuditEntityTypeHouseholdInvitation,
+		invitation.ID,
+		invitation.HouseholdID,
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
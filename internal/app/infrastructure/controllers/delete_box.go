@@ -2,37 +2,63 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type DeleteBoxController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	auditService *services.AuditService
 }
 
 type DeleteBoxRequest struct {
 	BoxID string `param:"boxID"`
 }
 
-func NewDeleteBoxController(boxService *services.BoxService) *DeleteBoxController {
+func NewDeleteBoxController(
+	boxService *services.BoxService,
+	auditService *services.AuditService,
+) *DeleteBoxController {
 	return &DeleteBoxController{
 		boxService,
+		auditService,
 	}
 }
 
 func (c *DeleteBoxController) Handle(ctx echo.Context) error {
 	request := DeleteBoxRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	err = c.boxService.DeleteWithTransactionsAndItemQuantities(request.BoxID)
+	box, room, err := c.boxService.Get(request.BoxID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.boxService.DeleteWithTransactionsAndItemQuantities(request.BoxID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionDelete,
+		box.EntityName(),
+		box.ID,
+		room.HouseholdID,
+		box,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusNoContent, nil)
 }
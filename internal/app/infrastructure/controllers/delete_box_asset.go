@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type DeleteBoxAssetController struct {
+	boxService   *services.BoxService
+	assetService *services.AssetService
+	auditService *services.AuditService
+}
+
+type DeleteBoxAssetRequest struct {
+	BoxID   string `param:"boxID"`
+	AssetID string `param:"assetID"`
+}
+
+func NewDeleteBoxAssetController(
+	boxService *services.BoxService,
+	assetService *services.AssetService,
+	auditService *services.AuditService,
+) *DeleteBoxAssetController {
+	return &DeleteBoxAssetController{
+		boxService,
+		assetService,
+		auditService,
+	}
+}
+
+func (c *DeleteBoxAssetController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := DeleteBoxAssetRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	_, room, err := c.boxService.Get(request.BoxID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	asset, err := c.assetService.GetByID(request.AssetID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.boxService.DeleteAsset(request.BoxID, userID, request.AssetID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionDelete,
+		entities.AuditEntityTypeAsset,
+		asset.ID,
+		room.HouseholdID,
+		asset,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
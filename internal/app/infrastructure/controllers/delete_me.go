@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type DeleteMeController struct {
+	accountDeletionService *services.AccountDeletionService
+	auditService           *services.AuditService
+}
+
+func NewDeleteMeController(
+	accountDeletionService *services.AccountDeletionService,
+	auditService *services.AuditService,
+) *DeleteMeController {
+	return &DeleteMeController{
+		accountDeletionService,
+		auditService,
+	}
+}
+
+func (c *DeleteMeController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	err := c.accountDeletionService.Request(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionRequestDeletion,
+		entities.AuditEntityTypeUser,
+		userID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, responses.NewMessageResponse("account scheduled for deletion"))
+}
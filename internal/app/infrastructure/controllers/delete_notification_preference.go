@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type DeleteNotificationPreferenceController struct {
+	notificationService *services.NotificationService
+}
+
+type DeleteNotificationPreferenceRequest struct {
+	PreferenceID string `param:"preferenceID"`
+}
+
+func NewDeleteNotificationPreferenceController(
+	notificationService *services.NotificationService,
+) *DeleteNotificationPreferenceController {
+	return &DeleteNotificationPreferenceController{
+		notificationService,
+	}
+}
+
+func (c *DeleteNotificationPreferenceController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := DeleteNotificationPreferenceRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.notificationService.DeletePreference(request.PreferenceID, userID)
+	if errors.Is(err, services.ErrNotificationServicePreferenceNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
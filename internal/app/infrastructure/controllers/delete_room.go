@@ -2,37 +2,63 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type DeleteRoomController struct {
-	roomService *services.RoomService
+	roomService  *services.RoomService
+	auditService *services.AuditService
 }
 
 type DeleteRoomRequest struct {
 	RoomID string `param:"roomID"`
 }
 
-func NewDeleteRoomController(roomService *services.RoomService) *DeleteRoomController {
+func NewDeleteRoomController(
+	roomService *services.RoomService,
+	auditService *services.AuditService,
+) *DeleteRoomController {
 	return &DeleteRoomController{
 		roomService,
+		auditService,
 	}
 }
 
 func (c *DeleteRoomController) Handle(ctx echo.Context) error {
 	request := DeleteRoomRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	err = c.roomService.Delete(request.RoomID)
+	room, err := c.roomService.Get(request.RoomID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.roomService.Delete(request.RoomID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionDelete,
+		room.EntityName(),
+		room.ID,
+		room.HouseholdID,
+		room,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusNoContent, nil)
 }
@@ -0,0 +1,73 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type DeleteRoomAssetController struct {
+	roomService  *services.RoomService
+	assetService *services.AssetService
+	auditService *services.AuditService
+}
+
+type DeleteRoomAssetRequest struct {
+	RoomID  string `param:"roomID"`
+	AssetID string `param:"assetID"`
+}
+
+func NewDeleteRoomAssetController(
+	roomService *services.RoomService,
+	assetService *services.AssetService,
+	auditService *services.AuditService,
+) *DeleteRoomAssetController {
+	return &DeleteRoomAssetController{
+		roomService,
+		assetService,
+		auditService,
+	}
+}
+
+func (c *DeleteRoomAssetController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := DeleteRoomAssetRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	room, err := c.roomService.Get(request.RoomID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	asset, err := c.assetService.GetByID(request.AssetID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.roomService.DeleteAsset(request.RoomID, userID, request.AssetID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionDelete,
+		entities.AuditEntityTypeAsset,
+		asset.ID,
+		room.HouseholdID,
+		asset,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
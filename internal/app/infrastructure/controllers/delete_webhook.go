@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type DeleteWebhookController struct {
+	webhookService *services.WebhookService
+	auditService   *services.AuditService
+}
+
+type DeleteWebhookRequest struct {
+	WebhookID string `param:"webhookID"`
+}
+
+func NewDeleteWebhookController(
+	webhookService *services.WebhookService,
+	auditService *services.AuditService,
+) *DeleteWebhookController {
+	return &DeleteWebhookController{
+		webhookService,
+		auditService,
+	}
+}
+
+func (c *DeleteWebhookController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := DeleteWebhookRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	webhook, err := c.webhookService.Get(request.WebhookID, userID)
+	if errors.Is(err, services.ErrWebhookServiceWebhookNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.webhookService.Delete(webhook.ID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionDelete,
+		entities.AuditEntityTypeWebhook,
+		webhook.ID,
+		webhook.HouseholdID,
+		map[string]interface{}{
+			"url":         webhook.URL,
+			"event_types": webhook.GetEventTypes(),
+		},
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
@@ -0,0 +1,43 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type EnrollTwoFactorController struct {
+	twoFactorService *services.TwoFactorService
+}
+
+type EnrollTwoFactorResponse struct {
+	Secret          string `json:"secret"`
+	ProvisioningURI string `json:"provisioning_uri"`
+}
+
+func NewEnrollTwoFactorController(twoFactorService *services.TwoFactorService) *EnrollTwoFactorController {
+	return &EnrollTwoFactorController{
+		twoFactorService,
+	}
+}
+
+// Handle returns the provisioning uri to show as a QR code, the two factor
+// authentication is enabled once a first code is confirmed.
+func (c *EnrollTwoFactorController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	secret, provisioningURI, err := c.twoFactorService.Enroll(userID)
+	if errors.Is(err, services.ErrTwoFactorServiceAlreadyEnabled) {
+		return ctx.JSON(http.StatusConflict, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&EnrollTwoFactorResponse{
+		Secret:          secret,
+		ProvisioningURI: provisioningURI,
+	}))
+}
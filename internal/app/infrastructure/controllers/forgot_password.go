@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type ForgotPasswordController struct {
+	userService *services.UserService
+}
+
+type ForgotPasswordRequest struct {
+	Email string `json:"email"`
+}
+
+func NewForgotPasswordController(userService *services.UserService) *ForgotPasswordController {
+	return &ForgotPasswordController{
+		userService,
+	}
+}
+
+func (c *ForgotPasswordController) Handle(ctx echo.Context) error {
+	request := ForgotPasswordRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	_ = c.userService.RequestPasswordReset(request.Email)
+
+	return ctx.JSON(
+		http.StatusAccepted,
+		responses.NewMessageResponse("if the email is registered, a password reset token was sent"),
+	)
+}
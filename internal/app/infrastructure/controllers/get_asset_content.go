@@ -0,0 +1,88 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime"
+	"net/http"
+)
+
+type GetAssetContentController struct {
+	assetService *services.AssetService
+}
+
+type GetAssetContentRequest struct {
+	AssetID string `param:"assetID"`
+}
+
+func NewGetAssetContentController(assetService *services.AssetService) *GetAssetContentController {
+	return &GetAssetContentController{
+		assetService,
+	}
+}
+
+func (c *GetAssetContentController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetAssetContentRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	asset, content, err := c.assetService.GetContent(request.AssetID, userID)
+	if errors.Is(err, services.ErrAssetServiceAssetNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+	}
+	defer content.Close()
+
+	serveAssetContent(ctx, asset, content)
+
+	return nil
+}
+
+// inlineContentTypes are the types a browser shows without running anything
+// from them, any other content is downloaded.
+var inlineContentTypes = map[string]bool{
+	"image/jpeg":      true,
+	"image/png":       true,
+	"image/gif":       true,
+	"image/webp":      true,
+	"application/pdf": true,
+}
+
+func serveAssetContent(ctx echo.Context, asset *entities.Asset, content io.ReadSeeker) {
+	contentType := mime.TypeByExtension(asset.Extension)
+	if contentType == "" {
+		contentType = echo.MIMEOctetStream
+	}
+
+	// Stored files never change, so the hash (or the file id for assets uploaded
+	// before hashing) identifies the content.
+	etag := asset.Hash
+	if etag == "" {
+		etag = asset.FileID
+	}
+
+	disposition := "attachment"
+	if inlineContentTypes[contentType] {
+		disposition = "inline"
+	}
+
+	header := ctx.Response().Header()
+	header.Set(echo.HeaderContentType, contentType)
+	header.Set(echo.HeaderContentDisposition, mime.FormatMediaType(disposition, map[string]string{"filename": asset.Name}))
+	header.Set(echo.HeaderXContentTypeOptions, "nosniff")
+	header.Set(echo.HeaderContentSecurityPolicy, "sandbox")
+	header.Set("ETag", `"`+etag+`"`)
+	header.Set("Cache-Control", "private, no-cache")
+
+	http.ServeContent(ctx.Response(), ctx.Request(), asset.Name, asset.UpdatedAt, content)
+}
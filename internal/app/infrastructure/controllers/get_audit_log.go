@@ -0,0 +1,123 @@
+package controllers
+
+import (
+	"encoding/json"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetAuditLogController struct {
+	auditService *services.AuditService
+}
+
+type GetAuditLogRequest struct {
+	ActorID     string  `query:"actor_id"`
+	Action      string  `query:"action"`
+	EntityType  string  `query:"entity_type"`
+	EntityID    string  `query:"entity_id"`
+	HouseholdID string  `query:"household_id"`
+	From        *string `query:"from"`
+	To          *string `query:"to"`
+	Page        int     `query:"page"`
+	PerPage     int     `query:"per_page"`
+}
+
+type GetAuditLogResponse struct {
+	ID          string          `json:"id"`
+	ActorID     string          `json:"actor_id"`
+	Action      string          `json:"action"`
+	EntityType  string          `json:"entity_type"`
+	EntityID    string          `json:"entity_id"`
+	HouseholdID *string         `json:"household_id"`
+	Before      json.RawMessage `json:"before"`
+	After       json.RawMessage `json:"after"`
+	IPAddress   string          `json:"ip_address"`
+	UserAgent   string          `json:"user_agent"`
+	HappenedAt  time.Time       `json:"happened_at"`
+}
+
+func NewGetAuditLogController(auditService *services.AuditService) *GetAuditLogController {
+	return &GetAuditLogController{
+		auditService,
+	}
+}
+
+func (c *GetAuditLogController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetAuditLogRequest{}
+
+	err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	from, err := mapDateTimeStringToTime(request.From)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	to, err := mapDateTimeStringToTime(request.To)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	filter := services.AuditLogFilter{
+		ActorID:     request.ActorID,
+		Action:      request.Action,
+		EntityType:  request.EntityType,
+		EntityID:    request.EntityID,
+		HouseholdID: request.HouseholdID,
+		From:        from,
+		To:          to,
+	}
+
+	auditLogs, err := c.auditService.GetAll(userID, filter, services.PageFilter{
+		Page: request.Page,
+		Size: request.PerPage,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	total, err := c.auditService.CountAll(userID, filter)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseAuditLogs := make([]*GetAuditLogResponse, len(auditLogs))
+	for i, auditLog := range auditLogs {
+		responseAuditLogs[i] = &GetAuditLogResponse{
+			ID:          auditLog.ID,
+			ActorID:     auditLog.ActorID,
+			Action:      auditLog.Action,
+			EntityType:  auditLog.EntityType,
+			EntityID:    auditLog.EntityID,
+			HouseholdID: auditLog.HouseholdID,
+			Before:      mapAuditValueToJSON(auditLog.Before),
+			After:       mapAuditValueToJSON(auditLog.After),
+			IPAddress:   auditLog.IPAddress,
+			UserAgent:   auditLog.UserAgent,
+			HappenedAt:  auditLog.HappenedAt,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewPaginatedResponse(
+		responseAuditLogs,
+		total,
+		request.Page,
+		request.PerPage,
+		len(auditLogs),
+		ctx.Request().URL.Path,
+	))
+}
+
+func mapAuditValueToJSON(value *string) json.RawMessage {
+	if value == nil {
+		return json.RawMessage("null")
+	}
+
+	return json.RawMessage(*value)
+}
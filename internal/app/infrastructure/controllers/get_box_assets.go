@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type GetBoxAssetsController struct {
+	boxService   *services.BoxService
+	assetService *services.AssetService
+}
+
+type GetBoxAssetsRequest struct {
+	BoxID string `param:"boxID"`
+}
+
+type GetBoxAssetsResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	Size      int64  `json:"size"`
+	Url       string `json:"url"`
+}
+
+func NewGetBoxAssetsController(
+	boxService *services.BoxService,
+	assetService *services.AssetService,
+) *GetBoxAssetsController {
+	return &GetBoxAssetsController{
+		boxService,
+		assetService,
+	}
+}
+
+func (c *GetBoxAssetsController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetBoxAssetsRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	assets, err := c.boxService.GetAssets(request.BoxID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseAssets := make([]*GetBoxAssetsResponse, 0)
+	for _, asset := range assets {
+		responseAssets = append(responseAssets, &GetBoxAssetsResponse{
+			ID:        asset.ID,
+			Name:      asset.Name,
+			Extension: asset.Extension,
+			Size:      asset.Size,
+			Url:       c.assetService.GetUrl(asset),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseAssets))
+}
@@ -36,6 +36,7 @@ func NewGetBoxTransactionsController(boxService *services.BoxService) *GetBoxTra
 
 func (c *GetBoxTransactionsController) Handle(ctx echo.Context) error {
 	request := GetBoxTransactionsRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
 	if err != nil {
@@ -49,6 +50,7 @@ func (c *GetBoxTransactionsController) Handle(ctx echo.Context) error {
 
 	transactions, err := c.boxService.GetBoxTransactions(
 		request.BoxID,
+		userID,
 		services.PageFilter{
 			Page: request.Page,
 			Size: request.PerPage,
@@ -58,7 +60,7 @@ func (c *GetBoxTransactionsController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	total, err := c.boxService.CountBoxTransactions(request.BoxID)
+	total, err := c.boxService.CountBoxTransactions(request.BoxID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
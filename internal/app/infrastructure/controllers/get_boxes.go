@@ -8,7 +8,8 @@ import (
 )
 
 type GetBoxesController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	assetService *services.AssetService
 }
 
 type GetBoxesRequest struct {
@@ -23,10 +24,20 @@ type GetBoxesResponse struct {
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
 	RoomID      string  `json:"room_id"`
+	Assets      []struct {
+		ID  string `json:"id"`
+		Url string `json:"url"`
+	} `json:"assets"`
 }
 
-func NewGetBoxesController(boxService *services.BoxService) *GetBoxesController {
-	return &GetBoxesController{boxService}
+func NewGetBoxesController(
+	boxService *services.BoxService,
+	assetService *services.AssetService,
+) *GetBoxesController {
+	return &GetBoxesController{
+		boxService,
+		assetService,
+	}
 }
 
 func (c *GetBoxesController) Handle(ctx echo.Context) error {
@@ -62,12 +73,28 @@ func (c *GetBoxesController) Handle(ctx echo.Context) error {
 
 	responseBoxes := make([]*GetBoxesResponse, 0)
 	for _, box := range boxes {
-		responseBoxes = append(responseBoxes, &GetBoxesResponse{
-			ID:          box.ID,
-			Name:        box.Name,
-			Description: box.Description,
-			RoomID:      box.RoomID,
-		})
+		data := &GetBoxesResponse{
+			ID:          box.Box.ID,
+			Name:        box.Box.Name,
+			Description: box.Box.Description,
+			RoomID:      box.Box.RoomID,
+			Assets: make([]struct {
+				ID  string `json:"id"`
+				Url string `json:"url"`
+			}, 0),
+		}
+
+		for _, asset := range box.Assets {
+			data.Assets = append(data.Assets, struct {
+				ID  string `json:"id"`
+				Url string `json:"url"`
+			}{
+				ID:  asset.ID,
+				Url: c.assetService.GetUrl(asset),
+			})
+		}
+
+		responseBoxes = append(responseBoxes, data)
 	}
 
 	return ctx.JSON(http.StatusOK, responses.NewPaginatedResponse(
@@ -0,0 +1,69 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetExpiringWarrantiesController struct {
+	itemService *services.ItemService
+}
+
+type GetExpiringWarrantiesRequest struct {
+	Days int `query:"days"`
+}
+
+type GetExpiringWarrantiesResponse struct {
+	AttachmentID      string     `json:"attachment_id"`
+	Kind              string     `json:"kind"`
+	PurchasedAt       *time.Time `json:"purchased_at"`
+	WarrantyExpiresAt *time.Time `json:"warranty_expires_at"`
+	Item              struct {
+		ID   string `json:"id"`
+		Sku  string `json:"sku"`
+		Name string `json:"name"`
+	} `json:"item"`
+}
+
+func NewGetExpiringWarrantiesController(itemService *services.ItemService) *GetExpiringWarrantiesController {
+	return &GetExpiringWarrantiesController{
+		itemService,
+	}
+}
+
+func (c *GetExpiringWarrantiesController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetExpiringWarrantiesRequest{
+		Days: 30,
+	}
+
+	err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	warranties, err := c.itemService.GetExpiringWarranties(userID, request.Days)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseWarranties := make([]*GetExpiringWarrantiesResponse, 0)
+	for _, warranty := range warranties {
+		data := &GetExpiringWarrantiesResponse{
+			AttachmentID:      warranty.Attachment.ID,
+			Kind:              warranty.Attachment.Kind,
+			PurchasedAt:       warranty.Attachment.PurchasedAt,
+			WarrantyExpiresAt: warranty.Attachment.WarrantyExpiresAt,
+		}
+		data.Item.ID = warranty.Item.ID
+		data.Item.Sku = warranty.Item.Sku
+		data.Item.Name = warranty.Item.Name
+
+		responseWarranties = append(responseWarranties, data)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseWarranties))
+}
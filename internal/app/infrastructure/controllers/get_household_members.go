@@ -0,0 +1,58 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type GetHouseholdMembersController struct {
+	householdService *services.HouseholdService
+}
+
+type GetHouseholdMembersRequest struct {
+	HouseholdID string `param:"householdID"`
+}
+
+type GetHouseholdMembersResponse struct {
+	ID     string `json:"id"`
+	UserID string `json:"user_id"`
+	Role   string `json:"role"`
+}
+
+func NewGetHouseholdMembersController(householdService *services.HouseholdService) *GetHouseholdMembersController {
+	return &GetHouseholdMembersController{
+		householdService,
+	}
+}
+
+func (c *GetHouseholdMembersController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetHouseholdMembersRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	members, err := c.householdService.GetMembers(request.HouseholdID, userID)
+	if errors.Is(err, services.ErrHouseholdServiceHouseholdNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseMembers := make([]*GetHouseholdMembersResponse, 0)
+	for _, member := range members {
+		responseMembers = append(responseMembers, &GetHouseholdMembersResponse{
+			ID:     member.ID,
+			UserID: member.UserID,
+			Role:   member.Role,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseMembers))
+}
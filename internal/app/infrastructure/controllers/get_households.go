@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type GetHouseholdsController struct {
+	householdService *services.HouseholdService
+}
+
+type GetHouseholdsResponse struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+	Role string `json:"role"`
+}
+
+func NewGetHouseholdsController(householdService *services.HouseholdService) *GetHouseholdsController {
+	return &GetHouseholdsController{
+		householdService,
+	}
+}
+
+func (c *GetHouseholdsController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	households, err := c.householdService.GetAll(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseHouseholds := make([]*GetHouseholdsResponse, 0)
+	for _, household := range households {
+		responseHouseholds = append(responseHouseholds, &GetHouseholdsResponse{
+			ID:   household.Household.ID,
+			Name: household.Household.Name,
+			Role: household.Role,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseHouseholds))
+}
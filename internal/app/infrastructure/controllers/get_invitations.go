@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetInvitationsController struct {
+	householdService *services.HouseholdService
+}
+
+type GetInvitationsResponse struct {
+	ID          string    `json:"id"`
+	HouseholdID string    `json:"household_id"`
+	Role        string    `json:"role"`
+	InvitedBy   string    `json:"invited_by"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewGetInvitationsController(householdService *services.HouseholdService) *GetInvitationsController {
+	return &GetInvitationsController{
+		householdService,
+	}
+}
+
+func (c *GetInvitationsController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	invitations, err := c.householdService.GetInvitations(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseInvitations := make([]*GetInvitationsResponse, 0)
+	for _, invitation := range invitations {
+		responseInvitations = append(responseInvitations, &GetInvitationsResponse{
+			ID:          invitation.ID,
+			HouseholdID: invitation.HouseholdID,
+			Role:        invitation.Role,
+			InvitedBy:   invitation.InvitedBy,
+			CreatedAt:   invitation.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseInvitations))
+}
@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type GetItemAttachmentContentController struct {
+	itemService *services.ItemService
+}
+
+type GetItemAttachmentContentRequest struct {
+	ItemID       string `param:"itemID"`
+	AttachmentID string `param:"attachmentID"`
+}
+
+func NewGetItemAttachmentContentController(itemService *services.ItemService) *GetItemAttachmentContentController {
+	return &GetItemAttachmentContentController{
+		itemService,
+	}
+}
+
+func (c *GetItemAttachmentContentController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetItemAttachmentContentRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	asset, content, err := c.itemService.GetAttachmentContent(request.ItemID, userID, request.AttachmentID)
+	if errors.Is(err, services.ErrItemServiceItemNotFound) ||
+		errors.Is(err, services.ErrItemServiceAttachmentNotFound) ||
+		errors.Is(err, services.ErrAssetServiceAssetNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+	}
+	defer content.Close()
+
+	serveAssetContent(ctx, asset, content)
+
+	return nil
+}
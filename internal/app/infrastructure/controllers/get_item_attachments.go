@@ -0,0 +1,91 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetItemAttachmentsController struct {
+	itemService  *services.ItemService
+	assetService *services.AssetService
+}
+
+type GetItemAttachmentsRequest struct {
+	ItemID string `param:"itemID"`
+}
+
+type GetItemAttachmentsResponse struct {
+	ID                string     `json:"id"`
+	Kind              string     `json:"kind"`
+	PurchasedAt       *time.Time `json:"purchased_at"`
+	WarrantyExpiresAt *time.Time `json:"warranty_expires_at"`
+	Asset             *struct {
+		ID        string `json:"id"`
+		Name      string `json:"name"`
+		Extension string `json:"extension"`
+		Size      int64  `json:"size"`
+		Url       string `json:"url"`
+	} `json:"asset"`
+}
+
+func NewGetItemAttachmentsController(
+	itemService *services.ItemService,
+	assetService *services.AssetService,
+) *GetItemAttachmentsController {
+	return &GetItemAttachmentsController{
+		itemService,
+		assetService,
+	}
+}
+
+func (c *GetItemAttachmentsController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetItemAttachmentsRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	attachments, err := c.itemService.GetAttachments(request.ItemID, userID)
+	if errors.Is(err, services.ErrItemServiceItemNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseAttachments := make([]*GetItemAttachmentsResponse, 0)
+	for _, attachment := range attachments {
+		data := &GetItemAttachmentsResponse{
+			ID:                attachment.Attachment.ID,
+			Kind:              attachment.Attachment.Kind,
+			PurchasedAt:       attachment.Attachment.PurchasedAt,
+			WarrantyExpiresAt: attachment.Attachment.WarrantyExpiresAt,
+		}
+
+		if attachment.Asset != nil {
+			data.Asset = &struct {
+				ID        string `json:"id"`
+				Name      string `json:"name"`
+				Extension string `json:"extension"`
+				Size      int64  `json:"size"`
+				Url       string `json:"url"`
+			}{
+				ID:        attachment.Asset.ID,
+				Name:      attachment.Asset.Name,
+				Extension: attachment.Asset.Extension,
+				Size:      attachment.Asset.Size,
+				Url:       c.assetService.GetUrl(attachment.Asset),
+			}
+		}
+
+		responseAttachments = append(responseAttachments, data)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseAttachments))
+}
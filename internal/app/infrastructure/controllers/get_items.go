@@ -13,9 +13,10 @@ type GetItemsController struct {
 }
 
 type GetItemsRequest struct {
-	Search  string `query:"search"`
-	Page    int    `query:"page"`
-	PerPage int    `query:"per_page"`
+	Search      string `query:"search"`
+	HouseholdID string `query:"household_id"`
+	Page        int    `query:"page"`
+	PerPage     int    `query:"per_page"`
 }
 
 type GetItemsResponse struct {
@@ -51,6 +52,7 @@ func (c *GetItemsController) Handle(ctx echo.Context) error {
 
 	items, err := c.itemService.GetAll(
 		request.Search,
+		request.HouseholdID,
 		userID,
 		services.PageFilter{
 			Page: request.Page,
@@ -63,6 +65,7 @@ func (c *GetItemsController) Handle(ctx echo.Context) error {
 
 	total, err := c.itemService.CountAll(
 		request.Search,
+		request.HouseholdID,
 		userID,
 	)
 	if err != nil {
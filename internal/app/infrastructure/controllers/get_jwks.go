@@ -0,0 +1,54 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type GetJWKSController struct {
+	authService *services.AuthService
+}
+
+type GetJWKSResponse struct {
+	Keys []*GetJWKSKeyResponse `json:"keys"`
+}
+
+type GetJWKSKeyResponse struct {
+	KeyType   string `json:"kty"`
+	Use       string `json:"use"`
+	Algorithm string `json:"alg"`
+	KeyID     string `json:"kid"`
+	Modulus   string `json:"n,omitempty"`
+	Exponent  string `json:"e,omitempty"`
+	Curve     string `json:"crv,omitempty"`
+	X         string `json:"x,omitempty"`
+}
+
+func NewGetJWKSController(authService *services.AuthService) *GetJWKSController {
+	return &GetJWKSController{
+		authService,
+	}
+}
+
+// Handle answers with a JSON Web Key Set, without the data wrapper of the
+// other endpoints, so other services can use it as is to verify access tokens.
+func (c *GetJWKSController) Handle(ctx echo.Context) error {
+	keys := make([]*GetJWKSKeyResponse, 0)
+	for _, key := range c.authService.GetPublicKeys() {
+		keys = append(keys, &GetJWKSKeyResponse{
+			KeyType:   key.Type,
+			Use:       "sig",
+			Algorithm: key.Algorithm,
+			KeyID:     key.ID,
+			Modulus:   key.Modulus,
+			Exponent:  key.Exponent,
+			Curve:     key.Curve,
+			X:         key.X,
+		})
+	}
+
+	ctx.Response().Header().Set("Cache-Control", "public, max-age=300")
+
+	return ctx.JSON(http.StatusOK, &GetJWKSResponse{Keys: keys})
+}
@@ -0,0 +1,83 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetMailMessagesController struct {
+	mailQueueService *services.MailQueueService
+}
+
+type GetMailMessagesRequest struct {
+	Status  string `query:"status"`
+	Page    int    `query:"page"`
+	PerPage int    `query:"per_page"`
+}
+
+type GetMailMessagesResponse struct {
+	ID          string     `json:"id"`
+	Recipient   string     `json:"recipient"`
+	Subject     string     `json:"subject"`
+	Status      string     `json:"status"`
+	Attempts    int        `json:"attempts"`
+	LastError   *string    `json:"last_error"`
+	AvailableAt time.Time  `json:"available_at"`
+	SentAt      *time.Time `json:"sent_at"`
+	CreatedAt   time.Time  `json:"created_at"`
+}
+
+func NewGetMailMessagesController(mailQueueService *services.MailQueueService) *GetMailMessagesController {
+	return &GetMailMessagesController{
+		mailQueueService,
+	}
+}
+
+func (c *GetMailMessagesController) Handle(ctx echo.Context) error {
+	request := GetMailMessagesRequest{}
+
+	err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	messages, err := c.mailQueueService.GetMessages(request.Status, services.PageFilter{
+		Page: request.Page,
+		Size: request.PerPage,
+	})
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	total, err := c.mailQueueService.CountMessages(request.Status)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseMessages := make([]*GetMailMessagesResponse, len(messages))
+	for i, message := range messages {
+		responseMessages[i] = &GetMailMessagesResponse{
+			ID:          message.ID,
+			Recipient:   message.Recipient,
+			Subject:     message.Subject,
+			Status:      message.Status,
+			Attempts:    message.Attempts,
+			LastError:   message.LastError,
+			AvailableAt: message.AvailableAt,
+			SentAt:      message.SentAt,
+			CreatedAt:   message.CreatedAt,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewPaginatedResponse(
+		responseMessages,
+		total,
+		request.Page,
+		request.PerPage,
+		len(messages),
+		ctx.Request().URL.Path,
+	))
+}
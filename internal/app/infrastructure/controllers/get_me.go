@@ -0,0 +1,44 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetMeController struct {
+	userService *services.UserService
+}
+
+type GetMeResponse struct {
+	ID         string     `json:"id"`
+	Email      string     `json:"email"`
+	VerifiedAt *time.Time `json:"verified_at"`
+	Timezone   string     `json:"timezone"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewGetMeController(userService *services.UserService) *GetMeController {
+	return &GetMeController{
+		userService,
+	}
+}
+
+func (c *GetMeController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	user, err := c.userService.GetByID(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&GetMeResponse{
+		ID:         user.ID,
+		Email:      user.Email,
+		VerifiedAt: user.VerifiedAt,
+		Timezone:   user.Timezone,
+		CreatedAt:  user.CreatedAt,
+	}))
+}
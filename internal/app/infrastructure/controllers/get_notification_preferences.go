@@ -0,0 +1,51 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetNotificationPreferencesController struct {
+	notificationService *services.NotificationService
+}
+
+type NotificationPreferenceResponse struct {
+	ID        string    `json:"id"`
+	EventType string    `json:"event_type"`
+	RoomID    *string   `json:"room_id"`
+	Frequency string    `json:"frequency"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func NewGetNotificationPreferencesController(
+	notificationService *services.NotificationService,
+) *GetNotificationPreferencesController {
+	return &GetNotificationPreferencesController{
+		notificationService,
+	}
+}
+
+func (c *GetNotificationPreferencesController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	preferences, err := c.notificationService.GetPreferences(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responsePreferences := make([]*NotificationPreferenceResponse, 0)
+	for _, preference := range preferences {
+		responsePreferences = append(responsePreferences, &NotificationPreferenceResponse{
+			ID:        preference.ID,
+			EventType: preference.EventType,
+			RoomID:    preference.RoomID,
+			Frequency: preference.Frequency,
+			UpdatedAt: preference.UpdatedAt,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responsePreferences))
+}
@@ -0,0 +1,53 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetPersonalAccessTokensController struct {
+	personalAccessTokenService *services.PersonalAccessTokenService
+}
+
+type GetPersonalAccessTokensResponse struct {
+	ID         string     `json:"id"`
+	Name       string     `json:"name"`
+	Scope      string     `json:"scope"`
+	ExpiresAt  *time.Time `json:"expires_at"`
+	LastUsedAt *time.Time `json:"last_used_at"`
+	CreatedAt  time.Time  `json:"created_at"`
+}
+
+func NewGetPersonalAccessTokensController(
+	personalAccessTokenService *services.PersonalAccessTokenService,
+) *GetPersonalAccessTokensController {
+	return &GetPersonalAccessTokensController{
+		personalAccessTokenService,
+	}
+}
+
+func (c *GetPersonalAccessTokensController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	tokens, err := c.personalAccessTokenService.GetAll(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseTokens := make([]*GetPersonalAccessTokensResponse, 0)
+	for _, token := range tokens {
+		responseTokens = append(responseTokens, &GetPersonalAccessTokensResponse{
+			ID:         token.ID,
+			Name:       token.Name,
+			Scope:      token.Scope,
+			ExpiresAt:  token.ExpiresAt,
+			LastUsedAt: token.LastUsedAt,
+			CreatedAt:  token.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseTokens))
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type GetRoomAssetsController struct {
+	roomService  *services.RoomService
+	assetService *services.AssetService
+}
+
+type GetRoomAssetsRequest struct {
+	RoomID string `param:"roomID"`
+}
+
+type GetRoomAssetsResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Extension string `json:"extension"`
+	Size      int64  `json:"size"`
+	Url       string `json:"url"`
+}
+
+func NewGetRoomAssetsController(
+	roomService *services.RoomService,
+	assetService *services.AssetService,
+) *GetRoomAssetsController {
+	return &GetRoomAssetsController{
+		roomService,
+		assetService,
+	}
+}
+
+func (c *GetRoomAssetsController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetRoomAssetsRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	assets, err := c.roomService.GetAssets(request.RoomID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseAssets := make([]*GetRoomAssetsResponse, 0)
+	for _, asset := range assets {
+		responseAssets = append(responseAssets, &GetRoomAssetsResponse{
+			ID:        asset.ID,
+			Name:      asset.Name,
+			Extension: asset.Extension,
+			Size:      asset.Size,
+			Url:       c.assetService.GetUrl(asset),
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseAssets))
+}
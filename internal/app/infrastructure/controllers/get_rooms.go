@@ -8,25 +8,36 @@ import (
 )
 
 type GetRoomsController struct {
-	roomService *services.RoomService
+	roomService  *services.RoomService
+	assetService *services.AssetService
 }
 
 type GetRoomsRequest struct {
-	Search  string `query:"search"`
-	Page    int    `query:"page"`
-	PerPage int    `query:"per_page"`
+	Search      string `query:"search"`
+	HouseholdID string `query:"household_id"`
+	Page        int    `query:"page"`
+	PerPage     int    `query:"per_page"`
 }
 
 type GetRoomsResponse struct {
 	ID          string  `json:"id"`
 	Name        string  `json:"name"`
 	Description *string `json:"description"`
+	HouseholdID string  `json:"household_id"`
+	Assets      []struct {
+		ID  string `json:"id"`
+		Url string `json:"url"`
+	} `json:"assets"`
 }
 
 func NewGetRoomsController(
 	roomService *services.RoomService,
+	assetService *services.AssetService,
 ) *GetRoomsController {
-	return &GetRoomsController{roomService: roomService}
+	return &GetRoomsController{
+		roomService:  roomService,
+		assetService: assetService,
+	}
 }
 
 func (c *GetRoomsController) Handle(ctx echo.Context) error {
@@ -40,6 +51,7 @@ func (c *GetRoomsController) Handle(ctx echo.Context) error {
 
 	rooms, err := c.roomService.GetAll(
 		request.Search,
+		request.HouseholdID,
 		userID,
 		services.PageFilter{
 			Page: request.Page,
@@ -52,6 +64,7 @@ func (c *GetRoomsController) Handle(ctx echo.Context) error {
 
 	total, err := c.roomService.CountAll(
 		request.Search,
+		request.HouseholdID,
 		userID,
 	)
 	if err != nil {
@@ -60,11 +73,28 @@ func (c *GetRoomsController) Handle(ctx echo.Context) error {
 
 	responseRooms := make([]*GetRoomsResponse, 0)
 	for _, room := range rooms {
-		responseRooms = append(responseRooms, &GetRoomsResponse{
-			ID:          room.ID,
-			Name:        room.Name,
-			Description: room.Description,
-		})
+		data := &GetRoomsResponse{
+			ID:          room.Room.ID,
+			Name:        room.Room.Name,
+			Description: room.Room.Description,
+			HouseholdID: room.Room.HouseholdID,
+			Assets: make([]struct {
+				ID  string `json:"id"`
+				Url string `json:"url"`
+			}, 0),
+		}
+
+		for _, asset := range room.Assets {
+			data.Assets = append(data.Assets, struct {
+				ID  string `json:"id"`
+				Url string `json:"url"`
+			}{
+				ID:  asset.ID,
+				Url: c.assetService.GetUrl(asset),
+			})
+		}
+
+		responseRooms = append(responseRooms, data)
 	}
 
 	return ctx.JSON(http.StatusOK, responses.NewPaginatedResponse(
@@ -0,0 +1,93 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetWebhookDeliveriesController struct {
+	webhookService *services.WebhookService
+}
+
+type GetWebhookDeliveriesRequest struct {
+	WebhookID string `param:"webhookID"`
+	Page      int    `query:"page"`
+	PerPage   int    `query:"per_page"`
+}
+
+type GetWebhookDeliveriesResponse struct {
+	ID             string     `json:"id"`
+	EventType      string     `json:"event_type"`
+	Status         string     `json:"status"`
+	Attempts       int        `json:"attempts"`
+	ResponseStatus *int       `json:"response_status"`
+	LastError      *string    `json:"last_error"`
+	AvailableAt    time.Time  `json:"available_at"`
+	DeliveredAt    *time.Time `json:"delivered_at"`
+	CreatedAt      time.Time  `json:"created_at"`
+}
+
+func NewGetWebhookDeliveriesController(webhookService *services.WebhookService) *GetWebhookDeliveriesController {
+	return &GetWebhookDeliveriesController{
+		webhookService,
+	}
+}
+
+func (c *GetWebhookDeliveriesController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := GetWebhookDeliveriesRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	deliveries, err := c.webhookService.GetDeliveries(request.WebhookID, userID, services.PageFilter{
+		Page: request.Page,
+		Size: request.PerPage,
+	})
+	if errors.Is(err, services.ErrWebhookServiceWebhookNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	total, err := c.webhookService.CountDeliveries(request.WebhookID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseDeliveries := make([]*GetWebhookDeliveriesResponse, len(deliveries))
+	for i, delivery := range deliveries {
+		responseDeliveries[i] = &GetWebhookDeliveriesResponse{
+			ID:             delivery.ID,
+			EventType:      delivery.EventType,
+			Status:         delivery.Status,
+			Attempts:       delivery.Attempts,
+			ResponseStatus: delivery.ResponseStatus,
+			LastError:      delivery.LastError,
+			AvailableAt:    delivery.AvailableAt,
+			DeliveredAt:    delivery.DeliveredAt,
+			CreatedAt:      delivery.CreatedAt,
+		}
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewPaginatedResponse(
+		responseDeliveries,
+		total,
+		request.Page,
+		request.PerPage,
+		len(deliveries),
+		ctx.Request().URL.Path,
+	))
+}
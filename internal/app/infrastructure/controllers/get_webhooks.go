@@ -0,0 +1,49 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type GetWebhooksController struct {
+	webhookService *services.WebhookService
+}
+
+type GetWebhooksResponse struct {
+	ID          string    `json:"id"`
+	URL         string    `json:"url"`
+	EventTypes  []string  `json:"event_types"`
+	HouseholdID string    `json:"household_id"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewGetWebhooksController(webhookService *services.WebhookService) *GetWebhooksController {
+	return &GetWebhooksController{
+		webhookService,
+	}
+}
+
+func (c *GetWebhooksController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+
+	webhooks, err := c.webhookService.GetAll(userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	responseWebhooks := make([]*GetWebhooksResponse, 0)
+	for _, webhook := range webhooks {
+		responseWebhooks = append(responseWebhooks, &GetWebhooksResponse{
+			ID:          webhook.ID,
+			URL:         webhook.URL,
+			EventTypes:  webhook.GetEventTypes(),
+			HouseholdID: webhook.HouseholdID,
+			CreatedAt:   webhook.CreatedAt,
+		})
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(responseWebhooks))
+}
@@ -0,0 +1,87 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type InviteHouseholdMemberController struct {
+	householdService *services.HouseholdService
+	auditService     *services.AuditService
+}
+
+type InviteHouseholdMemberRequest struct {
+	HouseholdID string `param:"householdID"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+}
+
+type InviteHouseholdMemberResponse struct {
+	ID          string `json:"id"`
+	HouseholdID string `json:"household_id"`
+	Email       string `json:"email"`
+	Role        string `json:"role"`
+	Status      string `json:"status"`
+}
+
+func NewInviteHouseholdMemberController(
+	householdService *services.HouseholdService,
+	auditService *services.AuditService,
+) *InviteHouseholdMemberController {
+	return &InviteHouseholdMemberController{
+		householdService,
+		auditService,
+	}
+}
+
+func (c *InviteHouseholdMemberController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := InviteHouseholdMemberRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	invitation, err := c.householdService.Invite(request.HouseholdID, userID, request.Email, request.Role)
+	if errors.Is(err, services.ErrHouseholdServiceHouseholdNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if errors.Is(err, services.ErrHouseholdServiceNotAllowed) {
+		return ctx.JSON(http.StatusForbidden, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionInviteHouseholdMember,
+		entities.AuditEntityTypeHouseholdInvitation,
+		invitation.ID,
+		invitation.HouseholdID,
+		nil,
+		invitation,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusCreated, responses.NewDataResponse(&InviteHouseholdMemberResponse{
+		ID:          invitation.ID,
+		HouseholdID: invitation.HouseholdID,
+		Email:       invitation.Email,
+		Role:        invitation.Role,
+		Status:      invitation.Status,
+	}))
+}
@@ -1,14 +1,18 @@
 package controllers
 
 import (
+	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type LogInController struct {
-	authService *services.AuthService
+	authService  *services.AuthService
+	auditService *services.AuditService
 }
 
 type LogInRequest struct {
@@ -17,16 +21,27 @@ type LogInRequest struct {
 }
 
 type LogInResponse struct {
-	ID    string `json:"id"`
-	Email string `json:"email"`
-	Token string `json:"token"`
+	ID           string `json:"id"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+// LogInMFARequiredResponse is returned instead of the tokens to users with two
+// factor authentication, the mfa token is exchanged with a code at
+// /login/mfa.
+type LogInMFARequiredResponse struct {
+	MFARequired bool   `json:"mfa_required"`
+	MFAToken    string `json:"mfa_token"`
 }
 
 func NewLogInController(
 	authService *services.AuthService,
+	auditService *services.AuditService,
 ) *LogInController {
 	return &LogInController{
 		authService,
+		auditService,
 	}
 }
 
@@ -38,14 +53,38 @@ func (c *LogInController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	data, err := c.authService.Authenticate(request.Email, request.Password)
+	data, err := c.authService.Authenticate(request.Email, request.Password, ctx.RealIP())
+	if errors.Is(err, services.ErrLoginThrottleServiceTooManyAttempts) {
+		return ctx.JSON(http.StatusTooManyRequests, responses.NewMessageResponse(err.Error()))
+	}
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	if data.MFAToken != "" {
+		return ctx.JSON(http.StatusOK, responses.NewDataResponse(&LogInMFARequiredResponse{
+			MFARequired: true,
+			MFAToken:    data.MFAToken,
+		}))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, data.User.ID),
+		entities.AuditActionLogIn,
+		entities.AuditEntityTypeUser,
+		data.User.ID,
+		"",
+		nil,
+		map[string]string{"method": "password"},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&LogInResponse{
-		ID:    data.User.ID,
-		Email: data.User.Email,
-		Token: data.Token,
+		ID:           data.User.ID,
+		Email:        data.User.Email,
+		Token:        data.Token,
+		RefreshToken: data.RefreshToken,
 	}))
 }
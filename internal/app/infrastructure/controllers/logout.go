@@ -0,0 +1,62 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type LogOutController struct {
+	authService  *services.AuthService
+	auditService *services.AuditService
+}
+
+type LogOutRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+func NewLogOutController(
+	authService *services.AuthService,
+	auditService *services.AuditService,
+) *LogOutController {
+	return &LogOutController{
+		authService,
+		auditService,
+	}
+}
+
+func (c *LogOutController) Handle(ctx echo.Context) error {
+	request := LogOutRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	token, err := c.authService.Logout(request.RefreshToken)
+	if errors.Is(err, services.ErrAuthServiceInvalidRefreshToken) {
+		return ctx.JSON(http.StatusUnauthorized, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, token.UserID),
+		entities.AuditActionLogOut,
+		entities.AuditEntityTypeUser,
+		token.UserID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
@@ -1,32 +1,50 @@
 package controllers
 
 import (
-	"io"
-	"mime/multipart"
-	"os"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"time"
 )
 
-func mapFileHeaderToTempFolderAndFile(fileHeader *multipart.FileHeader) (string, *os.File, error) {
-	file, err := fileHeader.Open()
-	if err != nil {
-		return "", nil, err
+// mapPartToFileUpload streams the part, so its size is only known once it is
+// read.
+func mapPartToFileUpload(part *multipartFile) *services.FileUpload {
+	contentType := part.Header.Get("Content-Type")
+	if contentType == "" {
+		contentType = "application/octet-stream"
 	}
-	defer file.Close()
 
-	tempDir, err := os.MkdirTemp("", "temp_folder")
-	if err != nil {
-		return "", nil, err
+	return &services.FileUpload{
+		Name:        part.FileName(),
+		Size:        -1,
+		ContentType: contentType,
+		Content:     part,
+	}
+}
+
+func mapDateStringToTime(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
 	}
 
-	tempFile, err := os.CreateTemp(tempDir, "*_"+fileHeader.Filename)
+	date, err := time.Parse(time.DateOnly, *value)
 	if err != nil {
-		return "", nil, err
+		return nil, err
+	}
+
+	return &date, nil
+}
+
+// mapDateTimeStringToTime accepts a full RFC 3339 timestamp or a date, taken
+// as its first instant in UTC.
+func mapDateTimeStringToTime(value *string) (*time.Time, error) {
+	if value == nil || *value == "" {
+		return nil, nil
 	}
 
-	_, err = io.Copy(tempFile, file)
+	dateTime, err := time.Parse(time.RFC3339, *value)
 	if err != nil {
-		return "", nil, err
+		return mapDateStringToTime(value)
 	}
 
-	return tempDir, tempFile, nil
+	return &dateTime, nil
 }
@@ -0,0 +1,118 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/labstack/echo/v4"
+	"io"
+	"mime/multipart"
+	"net/http"
+	"net/url"
+)
+
+// maxMultipartValuesSize is how many bytes the form values sent before the
+// file can take together.
+const maxMultipartValuesSize = 1 << 20
+
+var (
+	errMultipartValuesTooLarge = errors.New("multipart form values are too large")
+	errMultipartFileNotLast    = errors.New("the file must be the last field of the multipart form")
+)
+
+// multipartFile is a file part that fails at its end when the form has more
+// parts after it, because those parts are never bound.
+type multipartFile struct {
+	*multipart.Part
+	reader *multipart.Reader
+	err    error
+	ended  bool
+}
+
+func (f *multipartFile) Read(p []byte) (int, error) {
+	if f.ended {
+		return 0, f.endError()
+	}
+
+	n, err := f.Part.Read(p)
+	if !errors.Is(err, io.EOF) {
+		return n, err
+	}
+
+	f.ended = true
+	_, err = f.reader.NextPart()
+	if err == nil {
+		f.err = errMultipartFileNotLast
+	} else if !errors.Is(err, io.EOF) {
+		f.err = err
+	}
+
+	return n, f.endError()
+}
+
+func (f *multipartFile) endError() error {
+	if f.err != nil {
+		return f.err
+	}
+
+	return io.EOF
+}
+
+// Err returns the error found at the end of the file, which explains why the
+// upload that read it failed.
+func (f *multipartFile) Err() error {
+	return f.err
+}
+
+// readMultipartFile streams the request body up to the file with the given
+// name, instead of buffering the whole form like ctx.FormFile does. The
+// values sent before the file become the form of the request, so BindBody
+// still binds them. The file must be the last part, reading it fails with
+// errMultipartFileNotLast otherwise. It returns http.ErrMissingFile when the
+// body has no such file or is not multipart.
+func readMultipartFile(ctx echo.Context, name string) (*multipartFile, error) {
+	request := ctx.Request()
+
+	reader, err := request.MultipartReader()
+	if errors.Is(err, http.ErrNotMultipart) {
+		return nil, http.ErrMissingFile
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	values := make(url.Values)
+	defer func() {
+		request.Form = values
+		request.PostForm = values
+		request.MultipartForm = &multipart.Form{Value: values}
+	}()
+
+	remaining := int64(maxMultipartValuesSize)
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, http.ErrMissingFile
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if part.FileName() != "" {
+			if part.FormName() == name {
+				return &multipartFile{Part: part, reader: reader}, nil
+			}
+			continue
+		}
+
+		value, err := io.ReadAll(io.LimitReader(part, remaining+1))
+		if err != nil {
+			return nil, err
+		}
+
+		remaining -= int64(len(value))
+		if remaining < 0 {
+			return nil, errMultipartValuesTooLarge
+		}
+
+		values.Add(part.FormName(), string(value))
+	}
+}
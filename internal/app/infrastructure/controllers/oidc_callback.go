@@ -0,0 +1,98 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type OIDCCallbackController struct {
+	oidcService  *services.OIDCService
+	auditService *services.AuditService
+}
+
+type OIDCCallbackRequest struct {
+	Code             string `query:"code"`
+	State            string `query:"state"`
+	Error            string `query:"error"`
+	ErrorDescription string `query:"error_description"`
+}
+
+func NewOIDCCallbackController(
+	oidcService *services.OIDCService,
+	auditService *services.AuditService,
+) *OIDCCallbackController {
+	return &OIDCCallbackController{
+		oidcService,
+		auditService,
+	}
+}
+
+func (c *OIDCCallbackController) Handle(ctx echo.Context) error {
+	request := OIDCCallbackRequest{}
+
+	err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	cookie, err := ctx.Cookie(oidcStateCookieName)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(services.ErrOIDCServiceInvalidState.Error()))
+	}
+
+	ctx.SetCookie(newOIDCStateCookie(ctx, "", -1))
+
+	if request.Error != "" {
+		message := request.Error
+		if request.ErrorDescription != "" {
+			message += ": " + request.ErrorDescription
+		}
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(message))
+	}
+
+	data, err := c.oidcService.Finish(cookie.Value, request.State, request.Code)
+	if errors.Is(err, services.ErrOIDCServiceEmailNotVerified) ||
+		errors.Is(err, services.ErrOIDCServiceAccountNotVerified) ||
+		errors.Is(err, services.ErrAuthServiceAccountDeletionRequested) {
+		return ctx.JSON(http.StatusForbidden, responses.NewMessageResponse(err.Error()))
+	}
+	if errors.Is(err, domain.ErrIdentityProviderCanNotDiscover) || errors.Is(err, domain.ErrIdentityProviderCanNotExchangeCode) {
+		return ctx.JSON(http.StatusBadGateway, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	if data.MFAToken != "" {
+		return ctx.JSON(http.StatusOK, responses.NewDataResponse(&LogInMFARequiredResponse{
+			MFARequired: true,
+			MFAToken:    data.MFAToken,
+		}))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, data.User.ID),
+		entities.AuditActionLogIn,
+		entities.AuditEntityTypeUser,
+		data.User.ID,
+		"",
+		nil,
+		map[string]string{"method": "oidc"},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&LogInResponse{
+		ID:           data.User.ID,
+		Email:        data.User.Email,
+		Token:        data.Token,
+		RefreshToken: data.RefreshToken,
+	}))
+}
@@ -0,0 +1,45 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+const oidcStateCookieName = "oidc_state"
+
+type OIDCLogInController struct {
+	oidcService *services.OIDCService
+}
+
+func NewOIDCLogInController(oidcService *services.OIDCService) *OIDCLogInController {
+	return &OIDCLogInController{
+		oidcService,
+	}
+}
+
+// Handle keeps the signed state in a cookie only sent back to the callback,
+// so the login can only be finished by the browser that started it.
+func (c *OIDCLogInController) Handle(ctx echo.Context) error {
+	authorizationURL, signedState, err := c.oidcService.Start()
+	if err != nil {
+		return ctx.JSON(http.StatusBadGateway, responses.NewMessageResponse(err.Error()))
+	}
+
+	ctx.SetCookie(newOIDCStateCookie(ctx, signedState, 600))
+
+	return ctx.Redirect(http.StatusFound, authorizationURL)
+}
+
+func newOIDCStateCookie(ctx echo.Context, value string, maxAge int) *http.Cookie {
+	return &http.Cookie{
+		Name:     oidcStateCookieName,
+		Value:    value,
+		Path:     "/api/v1/oidc",
+		MaxAge:   maxAge,
+		Secure:   ctx.Scheme() == "https",
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+}
@@ -0,0 +1,86 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type RedeliverWebhookDeliveryController struct {
+	webhookService *services.WebhookService
+	auditService   *services.AuditService
+}
+
+type RedeliverWebhookDeliveryRequest struct {
+	WebhookID  string `param:"webhookID"`
+	DeliveryID string `param:"deliveryID"`
+}
+
+type RedeliverWebhookDeliveryResponse struct {
+	ID          string    `json:"id"`
+	EventType   string    `json:"event_type"`
+	Status      string    `json:"status"`
+	AvailableAt time.Time `json:"available_at"`
+	CreatedAt   time.Time `json:"created_at"`
+}
+
+func NewRedeliverWebhookDeliveryController(
+	webhookService *services.WebhookService,
+	auditService *services.AuditService,
+) *RedeliverWebhookDeliveryController {
+	return &RedeliverWebhookDeliveryController{
+		webhookService,
+		auditService,
+	}
+}
+
+// Handle queues the payload again as a new delivery, it is sent with the
+// pending ones.
+func (c *RedeliverWebhookDeliveryController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := RedeliverWebhookDeliveryRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	delivery, err := c.webhookService.Redeliver(request.WebhookID, request.DeliveryID, userID)
+	if errors.Is(err, services.ErrWebhookServiceWebhookNotFound) ||
+		errors.Is(err, services.ErrWebhookServiceDeliveryNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionRedeliver,
+		entities.AuditEntityTypeWebhook,
+		request.WebhookID,
+		"",
+		map[string]interface{}{
+			"delivery_id": request.DeliveryID,
+		},
+		map[string]interface{}{
+			"delivery_id": delivery.ID,
+		},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, responses.NewDataResponse(&RedeliverWebhookDeliveryResponse{
+		ID:          delivery.ID,
+		EventType:   delivery.EventType,
+		Status:      delivery.Status,
+		AvailableAt: delivery.AvailableAt,
+		CreatedAt:   delivery.CreatedAt,
+	}))
+}
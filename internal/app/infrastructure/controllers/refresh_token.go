@@ -0,0 +1,56 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type RefreshTokenController struct {
+	authService *services.AuthService
+}
+
+type RefreshTokenRequest struct {
+	RefreshToken string `json:"refresh_token"`
+}
+
+type RefreshTokenResponse struct {
+	ID           string `json:"id"`
+	Email        string `json:"email"`
+	Token        string `json:"token"`
+	RefreshToken string `json:"refresh_token"`
+}
+
+func NewRefreshTokenController(
+	authService *services.AuthService,
+) *RefreshTokenController {
+	return &RefreshTokenController{
+		authService,
+	}
+}
+
+func (c *RefreshTokenController) Handle(ctx echo.Context) error {
+	request := RefreshTokenRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	data, err := c.authService.Refresh(request.RefreshToken)
+	if errors.Is(err, services.ErrAuthServiceInvalidRefreshToken) {
+		return ctx.JSON(http.StatusUnauthorized, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&RefreshTokenResponse{
+		ID:           data.User.ID,
+		Email:        data.User.Email,
+		Token:        data.Token,
+		RefreshToken: data.RefreshToken,
+	}))
+}
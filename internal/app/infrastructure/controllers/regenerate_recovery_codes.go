@@ -0,0 +1,61 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type RegenerateRecoveryCodesController struct {
+	twoFactorService *services.TwoFactorService
+	auditService     *services.AuditService
+}
+
+func NewRegenerateRecoveryCodesController(
+	twoFactorService *services.TwoFactorService,
+	auditService *services.AuditService,
+) *RegenerateRecoveryCodesController {
+	return &RegenerateRecoveryCodesController{
+		twoFactorService,
+		auditService,
+	}
+}
+
+func (c *RegenerateRecoveryCodesController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := TwoFactorCodeRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	recoveryCodes, err := c.twoFactorService.RegenerateRecoveryCodes(userID, request.Code, ctx.RealIP())
+	if errors.Is(err, services.ErrLoginThrottleServiceTooManyAttempts) {
+		return ctx.JSON(http.StatusTooManyRequests, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionRegenerateRecoveryCodes,
+		entities.AuditEntityTypeUser,
+		userID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&RecoveryCodesResponse{
+		RecoveryCodes: recoveryCodes,
+	}))
+}
@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type RemoveItemFromBoxController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	auditService *services.AuditService
 }
 
 type RemoveItemFromBoxRequest struct {
@@ -19,12 +22,17 @@ type RemoveItemFromBoxRequest struct {
 
 func NewRemoveItemFromBoxController(
 	boxService *services.BoxService,
+	auditService *services.AuditService,
 ) *RemoveItemFromBoxController {
-	return &RemoveItemFromBoxController{boxService}
+	return &RemoveItemFromBoxController{
+		boxService,
+		auditService,
+	}
 }
 
 func (c *RemoveItemFromBoxController) Handle(ctx echo.Context) error {
 	request := RemoveItemFromBoxRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
@@ -36,14 +44,36 @@ func (c *RemoveItemFromBoxController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	box, room, err := c.boxService.Get(request.BoxID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
 	err = c.boxService.RemoveItemFromBox(
 		request.Quantity,
 		request.BoxID,
 		request.ItemID,
+		userID,
 	)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionRemoveItem,
+		box.EntityName(),
+		box.ID,
+		room.HouseholdID,
+		map[string]interface{}{
+			"item_id":  request.ItemID,
+			"quantity": request.Quantity,
+		},
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusNoContent, nil)
 }
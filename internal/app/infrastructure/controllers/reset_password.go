@@ -0,0 +1,59 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type ResetPasswordController struct {
+	userService  *services.UserService
+	auditService *services.AuditService
+}
+
+type ResetPasswordRequest struct {
+	Token    string `json:"token"`
+	Password string `json:"password"`
+}
+
+func NewResetPasswordController(
+	userService *services.UserService,
+	auditService *services.AuditService,
+) *ResetPasswordController {
+	return &ResetPasswordController{
+		userService,
+		auditService,
+	}
+}
+
+func (c *ResetPasswordController) Handle(ctx echo.Context) error {
+	request := ResetPasswordRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	user, err := c.userService.ResetPassword(request.Token, request.Password)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, user.ID),
+		entities.AuditActionResetPassword,
+		entities.AuditEntityTypeUser,
+		user.ID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewMessageResponse("password reset successfully"))
+}
@@ -0,0 +1,82 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+	"time"
+)
+
+type RetryMailMessageController struct {
+	mailQueueService *services.MailQueueService
+	auditService     *services.AuditService
+}
+
+type RetryMailMessageRequest struct {
+	MessageID string `param:"messageID"`
+}
+
+type RetryMailMessageResponse struct {
+	ID          string    `json:"id"`
+	Recipient   string    `json:"recipient"`
+	Subject     string    `json:"subject"`
+	Status      string    `json:"status"`
+	Attempts    int       `json:"attempts"`
+	AvailableAt time.Time `json:"available_at"`
+}
+
+func NewRetryMailMessageController(
+	mailQueueService *services.MailQueueService,
+	auditService *services.AuditService,
+) *RetryMailMessageController {
+	return &RetryMailMessageController{
+		mailQueueService,
+		auditService,
+	}
+}
+
+// Handle puts a dead message back in the queue, it is sent with the pending
+// ones.
+func (c *RetryMailMessageController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := RetryMailMessageRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	message, err := c.mailQueueService.Retry(request.MessageID)
+	if errors.Is(err, services.ErrMailQueueServiceMessageNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionRetry,
+		entities.AuditEntityTypeMailMessage,
+		message.ID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusAccepted, responses.NewDataResponse(&RetryMailMessageResponse{
+		ID:          message.ID,
+		Recipient:   message.Recipient,
+		Subject:     message.Subject,
+		Status:      message.Status,
+		Attempts:    message.Attempts,
+		AvailableAt: message.AvailableAt,
+	}))
+}
@@ -0,0 +1,63 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type RevokePersonalAccessTokenController struct {
+	personalAccessTokenService *services.PersonalAccessTokenService
+	auditService               *services.AuditService
+}
+
+type RevokePersonalAccessTokenRequest struct {
+	TokenID string `param:"tokenID"`
+}
+
+func NewRevokePersonalAccessTokenController(
+	personalAccessTokenService *services.PersonalAccessTokenService,
+	auditService *services.AuditService,
+) *RevokePersonalAccessTokenController {
+	return &RevokePersonalAccessTokenController{
+		personalAccessTokenService,
+		auditService,
+	}
+}
+
+func (c *RevokePersonalAccessTokenController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := RevokePersonalAccessTokenRequest{}
+
+	err := (&echo.DefaultBinder{}).BindPathParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.personalAccessTokenService.Revoke(request.TokenID, userID)
+	if errors.Is(err, services.ErrPersonalAccessTokenServiceTokenNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionRevoke,
+		entities.AuditEntityTypePersonalAccessToken,
+		request.TokenID,
+		"",
+		nil,
+		nil,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusNoContent, nil)
+}
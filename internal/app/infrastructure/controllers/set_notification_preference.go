@@ -0,0 +1,55 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type SetNotificationPreferenceController struct {
+	notificationService *services.NotificationService
+}
+
+type SetNotificationPreferenceRequest struct {
+	EventType string  `json:"event_type"`
+	RoomID    *string `json:"room_id"`
+	Frequency string  `json:"frequency"`
+}
+
+func NewSetNotificationPreferenceController(
+	notificationService *services.NotificationService,
+) *SetNotificationPreferenceController {
+	return &SetNotificationPreferenceController{
+		notificationService,
+	}
+}
+
+// Handle sets the frequency of the event type for the room, or for every room
+// without a room id, creating the preference when it does not exist.
+func (c *SetNotificationPreferenceController) Handle(ctx echo.Context) error {
+	userID := ctx.Get("auth_id").(string)
+	request := SetNotificationPreferenceRequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	preference, err := c.notificationService.SetPreference(userID, request.EventType, request.RoomID, request.Frequency)
+	if errors.Is(err, services.ErrNotificationServiceRoomNotFound) {
+		return ctx.JSON(http.StatusNotFound, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&NotificationPreferenceResponse{
+		ID:        preference.ID,
+		EventType: preference.EventType,
+		RoomID:    preference.RoomID,
+		Frequency: preference.Frequency,
+		UpdatedAt: preference.UpdatedAt,
+	}))
+}
@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type SignOnController struct {
-	userService *services.UserService
+	userService  *services.UserService
+	auditService *services.AuditService
 }
 
 type SignOnRequest struct {
@@ -16,9 +19,13 @@ type SignOnRequest struct {
 	Password string `json:"password"`
 }
 
-func NewSignOnController(userService *services.UserService) *SignOnController {
+func NewSignOnController(
+	userService *services.UserService,
+	auditService *services.AuditService,
+) *SignOnController {
 	return &SignOnController{
 		userService,
+		auditService,
 	}
 }
 
@@ -30,10 +37,23 @@ func (c *SignOnController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	_, err = c.userService.CreateUser(request.Email, request.Password)
+	user, err := c.userService.CreateUser(request.Email, request.Password)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, user.ID),
+		entities.AuditActionSignOn,
+		entities.AuditEntityTypeUser,
+		user.ID,
+		"",
+		nil,
+		map[string]string{"email": user.Email},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusCreated, responses.NewMessageResponse("sign on successfully"))
 }
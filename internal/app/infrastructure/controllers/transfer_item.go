@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type TransferItemController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	auditService *services.AuditService
 }
 
 type TransferItemRequest struct {
@@ -17,12 +20,19 @@ type TransferItemRequest struct {
 	BoxDestinationID string `json:"box_destination_id"`
 }
 
-func NewTransferItemController(boxService *services.BoxService) *TransferItemController {
-	return &TransferItemController{boxService}
+func NewTransferItemController(
+	boxService *services.BoxService,
+	auditService *services.AuditService,
+) *TransferItemController {
+	return &TransferItemController{
+		boxService,
+		auditService,
+	}
 }
 
 func (c *TransferItemController) Handle(ctx echo.Context) error {
 	request := TransferItemRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
@@ -34,14 +44,33 @@ func (c *TransferItemController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	box, room, err := c.boxService.Get(request.BoxID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
 	err = c.boxService.TransferItem(
 		request.BoxID,
 		request.BoxDestinationID,
 		request.ItemID,
+		userID,
 	)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionTransferItem,
+		box.EntityName(),
+		box.ID,
+		room.HouseholdID,
+		map[string]string{"box_id": box.ID, "item_id": request.ItemID},
+		map[string]string{"box_id": request.BoxDestinationID, "item_id": request.ItemID},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusNoContent, nil)
 }
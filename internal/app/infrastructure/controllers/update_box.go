@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type UpdateBoxController struct {
-	boxService *services.BoxService
+	boxService   *services.BoxService
+	auditService *services.AuditService
 }
 
 type UpdateBoxRequest struct {
@@ -23,14 +26,19 @@ type UpdateBoxResponse struct {
 	Description *string `json:"description"`
 }
 
-func NewUpdateBoxController(boxService *services.BoxService) *UpdateBoxController {
+func NewUpdateBoxController(
+	boxService *services.BoxService,
+	auditService *services.AuditService,
+) *UpdateBoxController {
 	return &UpdateBoxController{
 		boxService,
+		auditService,
 	}
 }
 
 func (c *UpdateBoxController) Handle(ctx echo.Context) error {
 	request := UpdateBoxRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
@@ -42,11 +50,29 @@ func (c *UpdateBoxController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	box, err := c.boxService.Update(request.BoxID, request.Name, request.Description)
+	before, room, err := c.boxService.Get(request.BoxID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	box, err := c.boxService.Update(request.BoxID, userID, request.Name, request.Description)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionUpdate,
+		box.EntityName(),
+		box.ID,
+		room.HouseholdID,
+		before,
+		box,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusOK, responses.NewDataResponse(
 		&UpdateBoxResponse{
 			ID:          box.ID,
@@ -3,15 +3,16 @@ package controllers
 import (
 	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
 	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
-	"os"
 )
 
 type UpdateItemController struct {
-	itemService *services.ItemService
+	itemService  *services.ItemService
+	auditService *services.AuditService
 }
 
 type UpdateItemRequest struct {
@@ -32,16 +33,33 @@ type UpdateItemResponse struct {
 	Keywords    []string `json:"keywords"`
 }
 
-func NewUpdateItemController(itemService *services.ItemService) *UpdateItemController {
+func NewUpdateItemController(
+	itemService *services.ItemService,
+	auditService *services.AuditService,
+) *UpdateItemController {
 	return &UpdateItemController{
-		itemService: itemService,
+		itemService:  itemService,
+		auditService: auditService,
 	}
 }
 
 func (c *UpdateItemController) Handle(ctx echo.Context) error {
 	request := UpdateItemRequest{}
+	userID := ctx.Get("auth_id").(string)
 
-	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	file, err := readMultipartFile(ctx, "file")
+	if err != nil && !errors.Is(err, http.ErrMissingFile) {
+		logger.LogError(err)
+		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+	}
+
+	var imageFile *services.FileUpload
+	if file != nil {
+		defer file.Close()
+		imageFile = mapPartToFileUpload(file)
+	}
+
+	err = (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
@@ -50,36 +68,41 @@ func (c *UpdateItemController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	fileHeader, err := ctx.FormFile("file")
-	if err != nil && !errors.Is(err, http.ErrMissingFile) {
-		logger.LogError(err)
-		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
-	}
-
-	var tempFile *os.File
-	if fileHeader != nil {
-		var tempDir string
-		tempDir, tempFile, err = mapFileHeaderToTempFolderAndFile(fileHeader)
-		if err != nil {
-			return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
-		}
-		defer os.RemoveAll(tempDir)
-		defer tempFile.Close()
+	before, err := c.itemService.Get(request.ItemID, userID)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
 	item, err := c.itemService.Update(
 		request.ItemID,
+		userID,
 		request.Sku,
 		request.Name,
 		request.Description,
 		request.Unit,
 		request.Keywords,
-		tempFile,
+		imageFile,
 	)
+	if file != nil && errors.Is(file.Err(), errMultipartFileNotLast) {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(file.Err().Error()))
+	}
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionUpdate,
+		item.EntityName(),
+		item.ID,
+		item.HouseholdID,
+		before,
+		item,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	keywords := make([]string, len(item.Keywords))
 	for i, keyword := range item.Keywords {
 		keywords[i] = keyword.Value
@@ -2,13 +2,16 @@ package controllers
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"net/http"
 )
 
 type UpdateRoomController struct {
-	roomService *services.RoomService
+	roomService  *services.RoomService
+	auditService *services.AuditService
 }
 
 type UpdateRoomRequest struct {
@@ -23,14 +26,19 @@ type UpdateRoomResponse struct {
 	Description *string `json:"description"`
 }
 
-func NewUpdateRoomController(roomService *services.RoomService) *UpdateRoomController {
+func NewUpdateRoomController(
+	roomService *services.RoomService,
+	auditService *services.AuditService,
+) *UpdateRoomController {
 	return &UpdateRoomController{
 		roomService,
+		auditService,
 	}
 }
 
 func (c *UpdateRoomController) Handle(ctx echo.Context) error {
 	request := UpdateRoomRequest{}
+	userID := ctx.Get("auth_id").(string)
 
 	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
 	if err != nil {
@@ -42,11 +50,29 @@ func (c *UpdateRoomController) Handle(ctx echo.Context) error {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
-	room, err := c.roomService.Update(request.RoomID, request.Name, request.Description)
+	before, err := c.roomService.Get(request.RoomID, userID)
 	if err != nil {
 		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
 	}
 
+	room, err := c.roomService.Update(request.RoomID, userID, request.Name, request.Description)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, userID),
+		entities.AuditActionUpdate,
+		room.EntityName(),
+		room.ID,
+		room.HouseholdID,
+		before,
+		room,
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
 	return ctx.JSON(http.StatusOK, responses.NewDataResponse(
 		&UpdateRoomResponse{
 			ID:          room.ID,
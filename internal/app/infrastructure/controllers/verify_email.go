@@ -0,0 +1,38 @@
+package controllers
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type VerifyEmailController struct {
+	userService *services.UserService
+}
+
+type VerifyEmailRequest struct {
+	Token string `query:"token"`
+}
+
+func NewVerifyEmailController(userService *services.UserService) *VerifyEmailController {
+	return &VerifyEmailController{
+		userService,
+	}
+}
+
+func (c *VerifyEmailController) Handle(ctx echo.Context) error {
+	request := VerifyEmailRequest{}
+
+	err := (&echo.DefaultBinder{}).BindQueryParams(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.userService.VerifyEmail(request.Token)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewMessageResponse("email verified successfully"))
+}
@@ -0,0 +1,71 @@
+package controllers
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+type VerifyMFAController struct {
+	authService  *services.AuthService
+	auditService *services.AuditService
+}
+
+type VerifyMFARequest struct {
+	MFAToken string `json:"mfa_token"`
+	Code     string `json:"code"`
+}
+
+func NewVerifyMFAController(
+	authService *services.AuthService,
+	auditService *services.AuditService,
+) *VerifyMFAController {
+	return &VerifyMFAController{
+		authService,
+		auditService,
+	}
+}
+
+func (c *VerifyMFAController) Handle(ctx echo.Context) error {
+	request := VerifyMFARequest{}
+
+	err := (&echo.DefaultBinder{}).BindBody(ctx, &request)
+	if err != nil {
+		return ctx.JSON(http.StatusBadRequest, responses.NewMessageResponse(err.Error()))
+	}
+
+	data, err := c.authService.VerifyMFA(request.MFAToken, request.Code, ctx.RealIP())
+	if errors.Is(err, services.ErrLoginThrottleServiceTooManyAttempts) {
+		return ctx.JSON(http.StatusTooManyRequests, responses.NewMessageResponse(err.Error()))
+	}
+	if errors.Is(err, services.ErrAuthServiceInvalidMFAToken) || errors.Is(err, services.ErrTwoFactorServiceInvalidCode) {
+		return ctx.JSON(http.StatusUnauthorized, responses.NewMessageResponse(err.Error()))
+	}
+	if err != nil {
+		return ctx.JSON(http.StatusInternalServerError, responses.NewMessageResponse(err.Error()))
+	}
+
+	err = c.auditService.Record(
+		newAuditActor(ctx, data.User.ID),
+		entities.AuditActionLogIn,
+		entities.AuditEntityTypeUser,
+		data.User.ID,
+		"",
+		nil,
+		map[string]string{"method": "mfa"},
+	)
+	if err != nil {
+		logger.LogError(err)
+	}
+
+	return ctx.JSON(http.StatusOK, responses.NewDataResponse(&LogInResponse{
+		ID:           data.User.ID,
+		Email:        data.User.Email,
+		Token:        data.Token,
+		RefreshToken: data.RefreshToken,
+	}))
+}
@@ -0,0 +1,38 @@
+package middlewares
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// NeedsAdminMiddleware only lets the users configured as admins through, the
+// rest get a forbidden response. It must run after NeedsAuthMiddleware.
+type NeedsAdminMiddleware struct {
+	adminUserIDs map[string]bool
+}
+
+func NewNeedsAdminMiddleware(adminUserIDs []string) *NeedsAdminMiddleware {
+	ids := make(map[string]bool, len(adminUserIDs))
+	for _, id := range adminUserIDs {
+		ids[id] = true
+	}
+
+	return &NeedsAdminMiddleware{
+		adminUserIDs: ids,
+	}
+}
+
+func (m *NeedsAdminMiddleware) Process(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		userID, _ := c.Get("auth_id").(string)
+		if userID == "" || !m.adminUserIDs[userID] {
+			return c.JSON(
+				http.StatusForbidden,
+				responses.NewMessageResponse("this action needs an admin"),
+			)
+		}
+
+		return next(c)
+	}
+}
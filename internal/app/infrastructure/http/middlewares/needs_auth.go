@@ -2,6 +2,7 @@ package middlewares
 
 import (
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
 	"github.com/labstack/echo/v4"
 	"net/http"
@@ -36,8 +37,22 @@ func (m *NeedsAuthMiddleware) Process(next echo.HandlerFunc) echo.HandlerFunc {
 				responses.NewMessageResponse(err.Error()),
 			)
 		}
+
+		if data.Scope == entities.PersonalAccessTokenScopeRead && !isReadOnlyMethod(c.Request().Method) {
+			return c.JSON(
+				http.StatusForbidden,
+				responses.NewMessageResponse("token scope does not allow this action"),
+			)
+		}
+
 		c.Set("auth_id", data.ID)
+		c.Set("auth_session_id", data.SessionID)
+		c.Set("auth_scope", data.Scope)
 
 		return next(c)
 	}
 }
+
+func isReadOnlyMethod(method string) bool {
+	return method == http.MethodGet || method == http.MethodHead || method == http.MethodOptions
+}
@@ -0,0 +1,31 @@
+package middlewares
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/responses"
+	"github.com/labstack/echo/v4"
+	"net/http"
+)
+
+// NeedsSessionMiddleware rejects requests authenticated with a personal
+// access token, so a leaked token can not be used to create more tokens.
+// It must run after NeedsAuthMiddleware.
+type NeedsSessionMiddleware struct {
+}
+
+func NewNeedsSessionMiddleware() *NeedsSessionMiddleware {
+	return &NeedsSessionMiddleware{}
+}
+
+func (m *NeedsSessionMiddleware) Process(next echo.HandlerFunc) echo.HandlerFunc {
+	return func(c echo.Context) error {
+		sessionID, _ := c.Get("auth_session_id").(string)
+		if sessionID == "" {
+			return c.JSON(
+				http.StatusForbidden,
+				responses.NewMessageResponse("this action needs a login session"),
+			)
+		}
+
+		return next(c)
+	}
+}
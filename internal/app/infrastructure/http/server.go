@@ -1,41 +1,127 @@
 package http
 
 import (
+	"context"
+	"errors"
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/listeners"
 	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
 	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/controllers"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/http/middlewares"
 	repositories "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/gorm"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/aesgcm"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/argon2id"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/aws"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/gmail"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/hmac"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/imaging"
 	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/jwt"
-	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/memory"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/mailqueue"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/mailtemplate"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/oidc"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/outbox"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/webhook"
 	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/labstack/echo/v4"
 	"gorm.io/gorm"
+	"net"
+	nethttp "net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
 	"time"
 )
 
-func RunServer(
-	host string,
-	port string,
-	jwtSecret string,
-	jwtDuration time.Duration,
-	awsAccessKeyID string,
-	awsSecretAccessKey string,
-	awsRegion string,
-	s3BucketName string,
-	smtpHost string,
-	smtpPort int,
-	smtpEmail string,
-	smtpPassword string,
-	db *gorm.DB,
-) {
-	tokenGenerator := jwt.NewTokenGenerator(jwtSecret, jwtDuration)
-	fileManager := aws.NewFileManager(awsAccessKeyID, awsSecretAccessKey, awsRegion, s3BucketName)
-	mailSender := gmail.NewMailSender(smtpHost, smtpPort, smtpEmail, smtpPassword)
-	eventBus := memory.NewEventBus()
+// webhookDeliveryBatchSize is how many deliveries are sent on every poll.
+const webhookDeliveryBatchSize = 50
+
+// notificationDigestBatchSize is how many digest entries are read on every
+// poll. The entries of a user over it are sent in the next digest.
+const notificationDigestBatchSize = 500
+
+// mailMessageBatchSize is how many queued mails are sent on every poll.
+const mailMessageBatchSize = 50
+
+// shutdownTimeout is how long the in-flight requests and the workers have to
+// finish once the server is asked to stop.
+const shutdownTimeout = 30 * time.Second
+
+// mailPurgeInterval is how often the sent and dead mails past their
+// retention are removed.
+const mailPurgeInterval = time.Hour
+
+// ServerConfig holds the settings of the server, read from the environment.
+type ServerConfig struct {
+	Host                           string
+	Port                           string
+	AppURL                         string
+	SigningSecret                  string
+	JwtSecret                      string
+	JwtPrivateKeyFile              string
+	JwtPublicKeyFiles              []string
+	JwtAccessDuration              time.Duration
+	JwtRefreshDuration             time.Duration
+	PasswordResetDuration          time.Duration
+	EmailVerificationDuration      time.Duration
+	LoginMaxFailedAttempts         int
+	LoginMaxFailedAttemptsPerIP    int
+	LoginLockoutDuration           time.Duration
+	Argon2Memory                   uint32
+	Argon2Iterations               uint32
+	Argon2Parallelism              uint8
+	Argon2MaxMemory                uint32
+	OIDCIssuerURL                  string
+	OIDCClientID                   string
+	OIDCClientSecret               string
+	AwsAccessKeyID                 string
+	AwsSecretAccessKey             string
+	AwsRegion                      string
+	S3BucketName                   string
+	SmtpHost                       string
+	SmtpPort                       int
+	SmtpEmail                      string
+	SmtpPassword                   string
+	SmtpFromName                   string
+	ImageMetadataRemoval           bool
+	ImageJpegQuality               int
+	ImageMaxSize                   int64
+	OutboxPollInterval             time.Duration
+	OutboxMaxAttempts              int
+	OutboxBaseBackoff              time.Duration
+	OutboxMaxBackoff               time.Duration
+	OutboxWorkers                  int
+	WebhookPollInterval            time.Duration
+	WebhookMaxAttempts             int
+	WebhookBaseBackoff             time.Duration
+	WebhookMaxBackoff              time.Duration
+	WebhookAllowPrivateNetworks    bool
+	NotificationDigestPollInterval time.Duration
+	MailPollInterval               time.Duration
+	MailMaxAttempts                int
+	MailBaseBackoff                time.Duration
+	MailMaxBackoff                 time.Duration
+	MailRetention                  time.Duration
+	AdminUserIDs                   []string
+	TrustedProxies                 []*net.IPNet
+}
+
+func RunServer(config ServerConfig, db *gorm.DB) {
+	tokenGenerator, err := newTokenGenerator(config.JwtSecret, config.JwtPrivateKeyFile, config.JwtPublicKeyFiles, config.JwtAccessDuration)
+	if err != nil {
+		logger.LogError(err)
+		return
+	}
+	fileManager := aws.NewFileManager(config.AwsAccessKeyID, config.AwsSecretAccessKey, config.AwsRegion, config.S3BucketName)
+	smtpMailSender := gmail.NewMailSender(config.SmtpHost, config.SmtpPort, config.SmtpEmail, config.SmtpPassword, config.SmtpFromName)
+	mailRenderer := mailtemplate.NewRenderer()
+	imageProcessor := imaging.NewMetadataRemover(config.ImageMetadataRemoval, config.ImageJpegQuality, config.ImageMaxSize)
+	signer := hmac.NewSigner(config.SigningSecret)
+	cipher := aesgcm.NewCipher(config.SigningSecret)
+	passwordHasher := argon2id.NewPasswordHasher(config.Argon2Memory, config.Argon2Iterations, config.Argon2Parallelism, config.Argon2MaxMemory)
+	webhookSender := webhook.NewSender(config.WebhookAllowPrivateNetworks)
 
 	assetRepository := repositories.NewAssetRepository(db)
 	versionRepository := repositories.NewVersionRepository(db)
@@ -44,67 +130,338 @@ func RunServer(
 	boxRepository := repositories.NewBoxRepository(db)
 	itemRepository := repositories.NewItemRepository(db)
 	itemKeywordRepository := repositories.NewItemKeywordRepository(db)
+	attachmentRepository := repositories.NewAttachmentRepository(db)
+	refreshTokenRepository := repositories.NewRefreshTokenRepository(db)
+	passwordResetTokenRepository := repositories.NewPasswordResetTokenRepository(db)
+	householdRepository := repositories.NewHouseholdRepository(db)
+	personalAccessTokenRepository := repositories.NewPersonalAccessTokenRepository(db)
+	loginThrottleRepository := repositories.NewLoginThrottleRepository(db)
+	userIdentityRepository := repositories.NewUserIdentityRepository(db)
+	twoFactorRepository := repositories.NewTwoFactorRepository(db)
+	auditLogRepository := repositories.NewAuditLogRepository(db)
+	outboxRepository := repositories.NewOutboxRepository(db)
+	webhookRepository := repositories.NewWebhookRepository(db)
+	webhookDeliveryRepository := repositories.NewWebhookDeliveryRepository(db)
+	notificationPreferenceRepository := repositories.NewNotificationPreferenceRepository(db)
+	notificationDigestRepository := repositories.NewNotificationDigestRepository(db)
+	mailMessageRepository := repositories.NewMailMessageRepository(db)
+	transactionManager := repositories.NewTransactionManager(db)
+
+	mailSender := mailqueue.NewMailSender(mailMessageRepository, cipher)
 
-	assetService := services.NewAssetService(fileManager, assetRepository)
-	authService := services.NewAuthService(userRepository, tokenGenerator)
-	userService := services.NewUserService(userRepository)
+	eventBus := outbox.NewEventBus(
+		outboxRepository,
+		entities.RetryPolicy{
+			MaxAttempts: config.OutboxMaxAttempts,
+			BaseBackoff: config.OutboxBaseBackoff,
+			MaxBackoff:  config.OutboxMaxBackoff,
+		},
+		config.OutboxWorkers,
+	)
+
+	householdService := services.NewHouseholdService(householdRepository, userRepository, eventBus, mailSender)
+	assetService := services.NewAssetService(
+		fileManager,
+		imageProcessor,
+		assetRepository,
+		itemRepository,
+		roomRepository,
+		boxRepository,
+		attachmentRepository,
+		transactionManager,
+		householdService,
+	)
+	auditService := services.NewAuditService(auditLogRepository, householdService)
+	loginThrottleService := services.NewLoginThrottleService(
+		loginThrottleRepository,
+		eventBus,
+		mailSender,
+		auditService,
+		entities.LoginThrottlePolicy{MaxFailedAttempts: config.LoginMaxFailedAttempts, LockoutDuration: config.LoginLockoutDuration},
+		entities.LoginThrottlePolicy{MaxFailedAttempts: config.LoginMaxFailedAttemptsPerIP, LockoutDuration: config.LoginLockoutDuration},
+	)
+	twoFactorService := services.NewTwoFactorService(twoFactorRepository, userRepository, loginThrottleService)
+	authService := services.NewAuthService(
+		userRepository,
+		refreshTokenRepository,
+		personalAccessTokenRepository,
+		loginThrottleService,
+		twoFactorService,
+		tokenGenerator,
+		passwordHasher,
+		signer,
+		config.JwtRefreshDuration,
+	)
+	userService := services.NewUserService(
+		userRepository,
+		passwordResetTokenRepository,
+		refreshTokenRepository,
+		eventBus,
+		mailSender,
+		signer,
+		passwordHasher,
+		config.AppURL,
+		config.PasswordResetDuration,
+		config.EmailVerificationDuration,
+	)
+	personalAccessTokenService := services.NewPersonalAccessTokenService(personalAccessTokenRepository)
+	accountDeletionService := services.NewAccountDeletionService(
+		userRepository,
+		refreshTokenRepository,
+		passwordResetTokenRepository,
+		personalAccessTokenRepository,
+		userIdentityRepository,
+		twoFactorRepository,
+		webhookRepository,
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		householdRepository,
+		roomRepository,
+		boxRepository,
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
+		eventBus,
+	)
 	versionService := services.NewVersionService(versionRepository)
-	roomService := services.NewRoomService(roomRepository, boxRepository)
+	notificationService := services.NewNotificationService(
+		notificationPreferenceRepository,
+		notificationDigestRepository,
+		userRepository,
+		roomRepository,
+		householdService,
+		mailSender,
+		mailRenderer,
+	)
+	roomService := services.NewRoomService(roomRepository, boxRepository, assetService, householdService, transactionManager)
 	boxService := services.NewBoxService(
 		boxRepository,
 		itemRepository,
 		roomRepository,
 		userRepository,
+		transactionManager,
+		notificationService,
+		assetService,
+		householdService,
+	)
+	itemService := services.NewItemService(
+		itemRepository,
+		itemKeywordRepository,
+		attachmentRepository,
+		assetService,
 		eventBus,
-		mailSender,
+		householdService,
+	)
+	webhookService := services.NewWebhookService(
+		webhookRepository,
+		webhookDeliveryRepository,
+		webhookSender,
+		householdService,
+		entities.RetryPolicy{
+			MaxAttempts: config.WebhookMaxAttempts,
+			BaseBackoff: config.WebhookBaseBackoff,
+			MaxBackoff:  config.WebhookMaxBackoff,
+		},
+	)
+	mailQueueService := services.NewMailQueueService(
+		mailMessageRepository,
+		smtpMailSender,
+		cipher,
+		entities.RetryPolicy{
+			MaxAttempts: config.MailMaxAttempts,
+			BaseBackoff: config.MailBaseBackoff,
+			MaxBackoff:  config.MailMaxBackoff,
+		},
+		config.MailRetention,
 	)
-	itemService := services.NewItemService(itemRepository, itemKeywordRepository, assetService, eventBus)
 
 	createAddBoxTransactionListener := listeners.NewCreateAddBoxTransactionListener(boxService)
 	createRemoveBoxTransactionListener := listeners.NewCreateRemoveBoxTransactionListener(boxService)
 	rollbackAssetListener := listeners.NewRollbackAssetListener(assetService)
+	sendPasswordResetListener := listeners.NewSendPasswordResetListener(userService)
+	sendEmailVerificationListener := listeners.NewSendEmailVerificationListener(userService)
+	sendHouseholdInvitationListener := listeners.NewSendHouseholdInvitationListener(householdService)
+	sendLoginLockedNotificationListener := listeners.NewSendLoginLockedNotificationListener(loginThrottleService)
+	sendEmailChangeConfirmationListener := listeners.NewSendEmailChangeConfirmationListener(userService)
+	deleteAccountListener := listeners.NewDeleteAccountListener(accountDeletionService)
+	deliverWebhooksListener := listeners.NewDeliverWebhooksListener(webhookService)
+	releaseHeldNotificationsListener := listeners.NewReleaseHeldNotificationsListener(notificationService)
+
+	eventBus.Subscribe(domain.BoxItemAddedEvent{}, "create_add_box_transaction", createAddBoxTransactionListener.Handle)
+	eventBus.Subscribe(domain.BoxItemRemovedEvent{}, "create_remove_box_transaction", createRemoveBoxTransactionListener.Handle)
+	eventBus.Subscribe(domain.ItemNotCreatedEvent{}, "rollback_asset", rollbackAssetListener.Handle)
+	eventBus.Subscribe(domain.ItemKeywordsNotCreatedEvent{}, "rollback_asset", rollbackAssetListener.Handle)
+	eventBus.Subscribe(domain.AttachmentNotCreatedEvent{}, "rollback_asset", rollbackAssetListener.Handle)
+	eventBus.SubscribeAsync(domain.PasswordResetRequestedEvent{}, "send_password_reset", sendPasswordResetListener.Handle)
+	eventBus.SubscribeAsync(domain.UserCreatedEvent{}, "send_email_verification", sendEmailVerificationListener.Handle)
+	eventBus.SubscribeAsync(domain.HouseholdInvitationCreatedEvent{}, "send_household_invitation", sendHouseholdInvitationListener.Handle)
+	eventBus.SubscribeAsync(domain.LoginLockedEvent{}, "send_login_locked_notification", sendLoginLockedNotificationListener.Handle)
+	eventBus.SubscribeAsync(domain.EmailChangeRequestedEvent{}, "send_email_change_confirmation", sendEmailChangeConfirmationListener.Handle)
+	eventBus.Subscribe(domain.AccountDeletionRequestedEvent{}, "delete_account", deleteAccountListener.Handle)
+	eventBus.Subscribe(domain.EmailVerifiedEvent{}, "release_held_notifications", releaseHeldNotificationsListener.Handle)
+	eventBus.SubscribeAsync(domain.BoxItemAddedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.BoxItemRemovedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.ItemCreatedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.ItemUpdatedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.BoxCreatedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.BoxUpdatedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.BoxDeletedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.RoomCreatedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.RoomUpdatedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+	eventBus.SubscribeAsync(domain.RoomDeletedEvent{}, "deliver_webhooks", deliverWebhooksListener.Handle)
+
+	// The workers stop after the server, and the bus after them, so the events
+	// published by their last runs are still drained.
+	workersCtx, stopWorkers := context.WithCancel(context.Background())
+	var workers sync.WaitGroup
+	startWorker := func(run func()) {
+		workers.Add(1)
+		go func() {
+			defer workers.Done()
+			run()
+		}()
+	}
 
-	eventBus.Subscribe(domain.BoxItemAddedEvent{}, createAddBoxTransactionListener.Handle)
-	eventBus.Subscribe(domain.BoxItemRemovedEvent{}, createRemoveBoxTransactionListener.Handle)
-	eventBus.Subscribe(domain.ItemNotCreatedEvent{}, rollbackAssetListener.Handle)
-	eventBus.Subscribe(domain.ItemKeywordsNotCreatedEvent{}, rollbackAssetListener.Handle)
+	go eventBus.Run(config.OutboxPollInterval)
+	startWorker(func() {
+		runEvery(workersCtx, config.WebhookPollInterval, func() error {
+			_, err := webhookService.DeliverPending(webhookDeliveryBatchSize)
+			return err
+		})
+	})
+	startWorker(func() {
+		runEvery(workersCtx, config.NotificationDigestPollInterval, func() error {
+			_, err := notificationService.SendDigests(notificationDigestBatchSize)
+			return err
+		})
+	})
+	startWorker(func() {
+		runEvery(workersCtx, config.MailPollInterval, func() error {
+			_, err := mailQueueService.SendPending(mailMessageBatchSize)
+			return err
+		})
+	})
+	startWorker(func() {
+		runEvery(workersCtx, mailPurgeInterval, func() error {
+			_, err := mailQueueService.Purge()
+			return err
+		})
+	})
 
 	healthController := controllers.NewHealthController(versionService)
-	signOnController := controllers.NewSignOnController(userService)
-	logInController := controllers.NewLogInController(authService)
-	createRoomController := controllers.NewCreateRoomController(roomService)
-	createAssetController := controllers.NewCreateAssetController(assetService)
-	createBoxController := controllers.NewCreateBoxController(boxService)
-	createItemController := controllers.NewCreateItemController(itemService)
-	addItemIntoBoxController := controllers.NewAddItemIntoBoxController(boxService)
-	removeItemFromBoxController := controllers.NewRemoveItemFromBoxController(boxService)
-	getRoomsController := controllers.NewGetRoomsController(roomService)
-	getBoxesController := controllers.NewGetBoxesController(boxService)
+	getJWKSController := controllers.NewGetJWKSController(authService)
+	signOnController := controllers.NewSignOnController(userService, auditService)
+	logInController := controllers.NewLogInController(authService, auditService)
+	verifyMFAController := controllers.NewVerifyMFAController(authService, auditService)
+	refreshTokenController := controllers.NewRefreshTokenController(authService)
+	logOutController := controllers.NewLogOutController(authService, auditService)
+	forgotPasswordController := controllers.NewForgotPasswordController(userService)
+	resetPasswordController := controllers.NewResetPasswordController(userService, auditService)
+	verifyEmailController := controllers.NewVerifyEmailController(userService)
+	createRoomController := controllers.NewCreateRoomController(roomService, auditService)
+	createAssetController := controllers.NewCreateAssetController(assetService, auditService)
+	createBoxController := controllers.NewCreateBoxController(boxService, auditService)
+	createItemController := controllers.NewCreateItemController(itemService, auditService)
+	addItemIntoBoxController := controllers.NewAddItemIntoBoxController(boxService, auditService)
+	removeItemFromBoxController := controllers.NewRemoveItemFromBoxController(boxService, auditService)
+	getRoomsController := controllers.NewGetRoomsController(roomService, assetService)
+	getBoxesController := controllers.NewGetBoxesController(boxService, assetService)
 	getItemsController := controllers.NewGetItemsController(assetService, itemService)
-	transferItemController := controllers.NewTransferItemController(boxService)
-	deleteBoxController := controllers.NewDeleteBoxController(boxService)
-	deleteRoomController := controllers.NewDeleteRoomController(roomService)
-	updateRoomController := controllers.NewUpdateRoomController(roomService)
-	updateBoxController := controllers.NewUpdateBoxController(boxService)
-	updateItemController := controllers.NewUpdateItemController(itemService)
-	changeBoxRoomController := controllers.NewChangeBoxRoomController(boxService)
+	transferItemController := controllers.NewTransferItemController(boxService, auditService)
+	deleteBoxController := controllers.NewDeleteBoxController(boxService, auditService)
+	deleteRoomController := controllers.NewDeleteRoomController(roomService, auditService)
+	updateRoomController := controllers.NewUpdateRoomController(roomService, auditService)
+	updateBoxController := controllers.NewUpdateBoxController(boxService, auditService)
+	updateItemController := controllers.NewUpdateItemController(itemService, auditService)
+	changeBoxRoomController := controllers.NewChangeBoxRoomController(boxService, auditService)
 	getBoxTransactionsController := controllers.NewGetBoxTransactionsController(boxService)
+	createRoomAssetController := controllers.NewCreateRoomAssetController(roomService, assetService, auditService)
+	getRoomAssetsController := controllers.NewGetRoomAssetsController(roomService, assetService)
+	deleteRoomAssetController := controllers.NewDeleteRoomAssetController(roomService, assetService, auditService)
+	createBoxAssetController := controllers.NewCreateBoxAssetController(boxService, assetService, auditService)
+	getBoxAssetsController := controllers.NewGetBoxAssetsController(boxService, assetService)
+	deleteBoxAssetController := controllers.NewDeleteBoxAssetController(boxService, assetService, auditService)
+	getAssetContentController := controllers.NewGetAssetContentController(assetService)
+	createItemAttachmentController := controllers.NewCreateItemAttachmentController(itemService, assetService, auditService)
+	getItemAttachmentsController := controllers.NewGetItemAttachmentsController(itemService, assetService)
+	getItemAttachmentContentController := controllers.NewGetItemAttachmentContentController(itemService)
+	getExpiringWarrantiesController := controllers.NewGetExpiringWarrantiesController(itemService)
+	createHouseholdController := controllers.NewCreateHouseholdController(householdService, auditService)
+	getHouseholdsController := controllers.NewGetHouseholdsController(householdService)
+	getHouseholdMembersController := controllers.NewGetHouseholdMembersController(householdService)
+	inviteHouseholdMemberController := controllers.NewInviteHouseholdMemberController(householdService, auditService)
+	getInvitationsController := controllers.NewGetInvitationsController(householdService)
+	acceptInvitationController := controllers.NewAcceptInvitationController(householdService, auditService)
+	declineInvitationController := controllers.NewDeclineInvitationController(householdService, auditService)
+	createPersonalAccessTokenController := controllers.NewCreatePersonalAccessTokenController(personalAccessTokenService, auditService)
+	getPersonalAccessTokensController := controllers.NewGetPersonalAccessTokensController(personalAccessTokenService)
+	revokePersonalAccessTokenController := controllers.NewRevokePersonalAccessTokenController(personalAccessTokenService, auditService)
+	getMeController := controllers.NewGetMeController(userService)
+	changePasswordController := controllers.NewChangePasswordController(userService, auditService)
+	changeTimezoneController := controllers.NewChangeTimezoneController(userService)
+	changeEmailController := controllers.NewChangeEmailController(userService, auditService)
+	confirmEmailChangeController := controllers.NewConfirmEmailChangeController(userService, auditService)
+	deleteMeController := controllers.NewDeleteMeController(accountDeletionService, auditService)
+	enrollTwoFactorController := controllers.NewEnrollTwoFactorController(twoFactorService)
+	confirmTwoFactorController := controllers.NewConfirmTwoFactorController(twoFactorService, auditService)
+	disableTwoFactorController := controllers.NewDisableTwoFactorController(twoFactorService, auditService)
+	regenerateRecoveryCodesController := controllers.NewRegenerateRecoveryCodesController(twoFactorService, auditService)
+	getAuditLogController := controllers.NewGetAuditLogController(auditService)
+	createWebhookController := controllers.NewCreateWebhookController(webhookService, auditService)
+	getWebhooksController := controllers.NewGetWebhooksController(webhookService)
+	deleteWebhookController := controllers.NewDeleteWebhookController(webhookService, auditService)
+	getWebhookDeliveriesController := controllers.NewGetWebhookDeliveriesController(webhookService)
+	redeliverWebhookDeliveryController := controllers.NewRedeliverWebhookDeliveryController(webhookService, auditService)
+	getNotificationPreferencesController := controllers.NewGetNotificationPreferencesController(notificationService)
+	setNotificationPreferenceController := controllers.NewSetNotificationPreferenceController(notificationService)
+	deleteNotificationPreferenceController := controllers.NewDeleteNotificationPreferenceController(notificationService)
+	getMailMessagesController := controllers.NewGetMailMessagesController(mailQueueService)
+	retryMailMessageController := controllers.NewRetryMailMessageController(mailQueueService, auditService)
 
 	loggerMiddleware := middlewares.NewLoggerMiddleware()
 	needsAuthMiddleware := middlewares.NewNeedsAuthMiddleware(authService)
+	needsSessionMiddleware := middlewares.NewNeedsSessionMiddleware()
+	needsAdminMiddleware := middlewares.NewNeedsAdminMiddleware(config.AdminUserIDs)
 
 	e := echo.New()
+	e.IPExtractor = newIPExtractor(config.TrustedProxies)
 	e.Use(loggerMiddleware.Process)
 
+	e.GET("/.well-known/jwks.json", getJWKSController.Handle)
+
 	api := e.Group("/api/v1")
 	api.POST("/login", logInController.Handle)
+	api.POST("/login/mfa", verifyMFAController.Handle)
+	api.POST("/token/refresh", refreshTokenController.Handle)
+	api.POST("/logout", logOutController.Handle)
+	api.POST("/password/forgot", forgotPasswordController.Handle)
+	api.POST("/password/reset", resetPasswordController.Handle)
 	api.POST("/users", signOnController.Handle)
+	api.GET("/users/verify", verifyEmailController.Handle)
+	api.GET("/me/email/confirm", confirmEmailChangeController.Handle)
+
+	if config.OIDCIssuerURL != "" {
+		identityProvider := oidc.NewIdentityProvider(
+			config.OIDCIssuerURL,
+			config.OIDCClientID,
+			config.OIDCClientSecret,
+			strings.TrimSuffix(config.AppURL, "/")+"/api/v1/oidc/callback",
+		)
+		oidcService := services.NewOIDCService(identityProvider, userRepository, userIdentityRepository, authService, signer)
+		oidcLogInController := controllers.NewOIDCLogInController(oidcService)
+		oidcCallbackController := controllers.NewOIDCCallbackController(oidcService, auditService)
+
+		api.GET("/oidc/login", oidcLogInController.Handle)
+		api.GET("/oidc/callback", oidcCallbackController.Handle)
+	}
 
 	authApi := api.Group("", needsAuthMiddleware.Process)
 	authApi.GET("/", healthController.Handle)
 	authApi.POST("/rooms", createRoomController.Handle)
 	authApi.POST("/rooms/:roomID/boxes", createBoxController.Handle)
 	authApi.POST("/assets", createAssetController.Handle)
+	authApi.GET("/assets/:assetID/content", getAssetContentController.Handle)
 	authApi.POST("/items", createItemController.Handle)
 	authApi.POST("/boxes/:boxID/items", addItemIntoBoxController.Handle)
 	authApi.DELETE("/boxes/:boxID/items/:itemID", removeItemFromBoxController.Handle)
@@ -119,6 +476,151 @@ func RunServer(
 	authApi.PATCH("/items/:itemID", updateItemController.Handle)
 	authApi.PUT("/boxes/:boxID/room", changeBoxRoomController.Handle)
 	authApi.GET("/boxes/:boxID/transactions", getBoxTransactionsController.Handle)
+	authApi.POST("/rooms/:roomID/assets", createRoomAssetController.Handle)
+	authApi.GET("/rooms/:roomID/assets", getRoomAssetsController.Handle)
+	authApi.DELETE("/rooms/:roomID/assets/:assetID", deleteRoomAssetController.Handle)
+	authApi.POST("/boxes/:boxID/assets", createBoxAssetController.Handle)
+	authApi.GET("/boxes/:boxID/assets", getBoxAssetsController.Handle)
+	authApi.DELETE("/boxes/:boxID/assets/:assetID", deleteBoxAssetController.Handle)
+	authApi.POST("/items/:itemID/attachments", createItemAttachmentController.Handle)
+	authApi.GET("/items/:itemID/attachments", getItemAttachmentsController.Handle)
+	authApi.GET("/items/:itemID/attachments/:attachmentID/content", getItemAttachmentContentController.Handle)
+	authApi.GET("/warranties/expiring", getExpiringWarrantiesController.Handle)
+	authApi.POST("/households", createHouseholdController.Handle)
+	authApi.GET("/households", getHouseholdsController.Handle)
+	authApi.GET("/households/:householdID/members", getHouseholdMembersController.Handle)
+	authApi.POST("/households/:householdID/invitations", inviteHouseholdMemberController.Handle)
+	authApi.GET("/invitations", getInvitationsController.Handle)
+	authApi.POST("/invitations/:invitationID/accept", acceptInvitationController.Handle)
+	authApi.POST("/invitations/:invitationID/decline", declineInvitationController.Handle)
+	authApi.POST("/tokens", createPersonalAccessTokenController.Handle, needsSessionMiddleware.Process)
+	authApi.GET("/tokens", getPersonalAccessTokensController.Handle, needsSessionMiddleware.Process)
+	authApi.DELETE("/tokens/:tokenID", revokePersonalAccessTokenController.Handle, needsSessionMiddleware.Process)
+	authApi.GET("/audit-log", getAuditLogController.Handle)
+	authApi.POST("/webhooks", createWebhookController.Handle)
+	authApi.GET("/webhooks", getWebhooksController.Handle)
+	authApi.DELETE("/webhooks/:webhookID", deleteWebhookController.Handle)
+	authApi.GET("/webhooks/:webhookID/deliveries", getWebhookDeliveriesController.Handle)
+	authApi.POST("/webhooks/:webhookID/deliveries/:deliveryID/redeliver", redeliverWebhookDeliveryController.Handle)
+	authApi.GET("/me", getMeController.Handle)
+	authApi.PATCH("/me/password", changePasswordController.Handle, needsSessionMiddleware.Process)
+	authApi.PATCH("/me/timezone", changeTimezoneController.Handle)
+	authApi.POST("/me/email", changeEmailController.Handle, needsSessionMiddleware.Process)
+	authApi.DELETE("/me", deleteMeController.Handle, needsSessionMiddleware.Process)
+	authApi.POST("/me/two-factor", enrollTwoFactorController.Handle, needsSessionMiddleware.Process)
+	authApi.POST("/me/two-factor/confirm", confirmTwoFactorController.Handle, needsSessionMiddleware.Process)
+	authApi.DELETE("/me/two-factor", disableTwoFactorController.Handle, needsSessionMiddleware.Process)
+	authApi.GET("/me/notification-preferences", getNotificationPreferencesController.Handle)
+	authApi.PUT("/me/notification-preferences", setNotificationPreferenceController.Handle)
+	authApi.DELETE("/me/notification-preferences/:preferenceID", deleteNotificationPreferenceController.Handle)
+	authApi.POST("/me/two-factor/recovery-codes", regenerateRecoveryCodesController.Handle, needsSessionMiddleware.Process)
+
+	adminApi := authApi.Group("/admin", needsAdminMiddleware.Process)
+	adminApi.GET("/mail-messages", getMailMessagesController.Handle)
+	adminApi.POST("/mail-messages/:messageID/retry", retryMailMessageController.Handle)
+
+	signalCtx, stopSignals := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stopSignals()
+
+	go func() {
+		if err := e.Start(config.Host + ":" + config.Port); err != nil && !errors.Is(err, nethttp.ErrServerClosed) {
+			logger.LogError(err)
+			stopSignals()
+		}
+	}()
+
+	<-signalCtx.Done()
+
+	shutdownCtx, cancel := context.WithTimeout(context.Background(), shutdownTimeout)
+	defer cancel()
+
+	if err := e.Shutdown(shutdownCtx); err != nil {
+		logger.LogError(err)
+	}
+
+	stopWorkers()
+	stopped := make(chan struct{})
+	go func() {
+		workers.Wait()
+		close(stopped)
+	}()
+
+	select {
+	case <-stopped:
+	case <-shutdownCtx.Done():
+		logger.LogError(shutdownCtx.Err())
+	}
+
+	if err := eventBus.Shutdown(shutdownCtx); err != nil {
+		logger.LogError(err)
+	}
+}
+
+// runEvery calls fn every interval until ctx is done, its errors are only
+// logged.
+func runEvery(ctx context.Context, interval time.Duration, fn func() error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := fn(); err != nil {
+				logger.LogError(err)
+			}
+		}
+	}
+}
+
+// newTokenGenerator signs access tokens with the private key when there is
+// one, and with the shared secret otherwise.
+// newIPExtractor uses the address of the connection, unless the API runs
+// behind trusted proxies. Then the client is the first address in the
+// X-Forwarded-For header that is not one of them, so it can not be forged to
+// skip the login throttle of an IP address.
+func newIPExtractor(trustedProxies []*net.IPNet) echo.IPExtractor {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect()
+	}
+
+	options := []echo.TrustOption{
+		echo.TrustLoopback(false),
+		echo.TrustLinkLocal(false),
+		echo.TrustPrivateNet(false),
+	}
+	for _, trustedProxy := range trustedProxies {
+		options = append(options, echo.TrustIPRange(trustedProxy))
+	}
+
+	return echo.ExtractIPFromXFFHeader(options...)
+}
+
+func newTokenGenerator(
+	secret string,
+	privateKeyFile string,
+	publicKeyFiles []string,
+	accessDuration time.Duration,
+) (*jwt.TokenGenerator, error) {
+	if privateKeyFile == "" {
+		return jwt.NewTokenGenerator(secret, accessDuration), nil
+	}
+
+	signingKey, err := jwt.LoadPrivateKey(privateKeyFile)
+	if err != nil {
+		return nil, err
+	}
+
+	verificationKeys := make([]*jwt.Key, 0, len(publicKeyFiles))
+	for _, publicKeyFile := range publicKeyFiles {
+		key, err := jwt.LoadPublicKey(publicKeyFile)
+		if err != nil {
+			return nil, err
+		}
+
+		verificationKeys = append(verificationKeys, key)
+	}
 
-	logger.LogError(e.Start(host + ":" + port))
+	return jwt.NewKeyPairTokenGenerator(signingKey, verificationKeys, accessDuration)
 }
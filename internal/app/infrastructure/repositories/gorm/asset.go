@@ -8,6 +8,17 @@ import (
 	"gorm.io/gorm"
 )
 
+var assetEntityTables = []struct {
+	EntityName string
+	TableName  string
+}{
+	{(&entities.Item{}).EntityName(), "items"},
+	{(&entities.Room{}).EntityName(), "rooms"},
+	{(&entities.Box{}).EntityName(), "boxes"},
+	{(&entities.Attachment{}).EntityName(), "attachments"},
+	{(&entities.IdentifiableEntity{}).EntityName(), "users"},
+}
+
 type AssetRepository struct {
 	db *gorm.DB
 }
@@ -62,6 +73,21 @@ func (r *AssetRepository) Delete(id string) error {
 	return nil
 }
 
+// UpdateFileID points every asset of a stored file to another one.
+func (r *AssetRepository) UpdateFileID(oldFileID string, newFileID string) error {
+	err := r.db.Model(&entities.Asset{}).
+		Where("file_id = ?", oldFileID).
+		Update("file_id", newFileID).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrAssetRepositoryCanNotUpdateAssets
+	}
+
+	return nil
+}
+
 func (r *AssetRepository) GetByQueryFilters(queryFilter repositories.QueryFilter) ([]*entities.Asset, error) {
 	var assets []*entities.Asset
 	result := applyFilters(r.db, queryFilter).Find(&assets)
@@ -74,3 +100,62 @@ func (r *AssetRepository) GetByQueryFilters(queryFilter repositories.QueryFilter
 
 	return assets, nil
 }
+
+func (r *AssetRepository) CountByQueryFilters(queryFilter repositories.QueryFilter) (int64, error) {
+	var count int64
+	err := applyFilters(r.db.Model(&entities.Asset{}), queryFilter).
+		Count(&count).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		return 0, repositories.ErrAssetRepositoryCanNotCountAssets
+	}
+
+	return count, nil
+}
+
+func (r *AssetRepository) GetByID(id string) (*entities.Asset, error) {
+	var asset entities.Asset
+	if err := r.db.First(&asset, "id = ?", id).Error; err != nil {
+		logger.LogError(err)
+		return nil, repositories.ErrAssetRepositoryAssetNotFound
+	}
+
+	return &asset, nil
+}
+
+func (r *AssetRepository) GetAll() ([]*entities.Asset, error) {
+	var assets []*entities.Asset
+	if err := r.db.Find(&assets).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrAssetRepositoryCanNotGetAssets
+	}
+
+	return assets, nil
+}
+
+func (r *AssetRepository) GetWithoutEntity() ([]*entities.Asset, error) {
+	var assets []*entities.Asset
+	query := r.db
+
+	for i, entityTable := range assetEntityTables {
+		condition := "entity_name = ? AND NOT EXISTS (SELECT 1 FROM " +
+			entityTable.TableName + " WHERE " + entityTable.TableName + ".id = assets.entity_id)"
+
+		if i == 0 {
+			query = query.Where(condition, entityTable.EntityName)
+		} else {
+			query = query.Or(condition, entityTable.EntityName)
+		}
+	}
+
+	if err := query.Find(&assets).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrAssetRepositoryCanNotGetAssetsWithoutEntity
+	}
+
+	return assets, nil
+}
@@ -23,6 +23,7 @@ func TestAssetRepositoryCreateAsset(t *testing.T) {
 		Name:       random.String(100, random.Alphanumeric),
 		Extension:  ".jpg",
 		Size:       89813,
+		Hash:       "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
 		FileID:     uuid.NewString(),
 		EntityID:   uuid.NewString(),
 		EntityName: "user",
@@ -30,12 +31,13 @@ func TestAssetRepositoryCreateAsset(t *testing.T) {
 		UpdatedAt:  time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `assets` (`id`,`name`,`extension`,`size`,`file_id`,`entity_id`,`entity_name`,`created_at`,`updated_at`) VALUES  (?,?,?,?,?,?,?,?,?)")).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `assets` (`id`,`name`,`extension`,`size`,`hash`,`file_id`,`entity_id`,`entity_name`,`created_at`,`updated_at`) VALUES  (?,?,?,?,?,?,?,?,?,?)")).
 		WithArgs(
 			asset.ID,
 			asset.Name,
 			asset.Extension,
 			asset.Size,
+			asset.Hash,
 			asset.FileID,
 			asset.EntityID,
 			asset.EntityName,
@@ -60,6 +62,7 @@ func TestAssetRepositoryCreateAssetErrorCanNotCreateAsset(t *testing.T) {
 		Name:       random.String(100, random.Alphanumeric),
 		Extension:  ".jpg",
 		Size:       89813,
+		Hash:       "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855",
 		FileID:     uuid.NewString(),
 		EntityID:   uuid.NewString(),
 		EntityName: "user",
@@ -67,12 +70,13 @@ func TestAssetRepositoryCreateAssetErrorCanNotCreateAsset(t *testing.T) {
 		UpdatedAt:  time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `assets` (`id`,`name`,`extension`,`size`,`file_id`,`entity_id`,`entity_name`,`created_at`,`updated_at`) VALUES  (?,?,?,?,?,?,?,?,?)")).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `assets` (`id`,`name`,`extension`,`size`,`hash`,`file_id`,`entity_id`,`entity_name`,`created_at`,`updated_at`) VALUES  (?,?,?,?,?,?,?,?,?,?)")).
 		WithArgs(
 			asset.ID,
 			asset.Name,
 			asset.Extension,
 			asset.Size,
+			asset.Hash,
 			asset.FileID,
 			asset.EntityID,
 			asset.EntityName,
@@ -261,6 +265,42 @@ func TestAssetRepositoryDelete(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestAssetRepositoryUpdateFileID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	oldFileID := uuid.NewString()
+	newFileID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `assets` SET `file_id`=?,`updated_at`=? WHERE file_id = ?")).
+		WithArgs(newFileID, sqlmock.AnyArg(), oldFileID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := assetRepository.UpdateFileID(oldFileID, newFileID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryUpdateFileIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `assets` SET `file_id`=?")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := assetRepository.UpdateFileID(uuid.NewString(), uuid.NewString())
+
+	assert.ErrorIs(t, err, repositories.ErrAssetRepositoryCanNotUpdateAssets)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
 func TestAssetRepositoryGetByQueryFilters(t *testing.T) {
 	db, dbMock := makeDBMock()
 	assetRepository := NewAssetRepository(db)
@@ -395,3 +435,260 @@ func TestAssetRepositoryGetByQueryFiltersErrorCanNotGetByQueryFilters(t *testing
 	err = dbMock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestAssetRepositoryGetByID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	expectedAsset := &entities.Asset{
+		ID:         uuid.NewString(),
+		Name:       random.String(100, random.Alphanumeric),
+		Extension:  ".jpg",
+		Size:       89813,
+		FileID:     uuid.NewString(),
+		EntityID:   uuid.NewString(),
+		EntityName: "room",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "extension", "size", "file_id", "entity_id", "entity_name", "created_at", "updated_at"}).
+		AddRow(
+			expectedAsset.ID,
+			expectedAsset.Name,
+			expectedAsset.Extension,
+			expectedAsset.Size,
+			expectedAsset.FileID,
+			expectedAsset.EntityID,
+			expectedAsset.EntityName,
+			expectedAsset.CreatedAt,
+			expectedAsset.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `assets` WHERE id = ? ORDER BY `assets`.`id` LIMIT 1")).
+		WithArgs(expectedAsset.ID).
+		WillReturnRows(rows)
+
+	asset, err := assetRepository.GetByID(expectedAsset.ID)
+
+	assert.NoError(t, err)
+	assert.NotNil(t, asset)
+	assert.Equal(t, expectedAsset.ID, asset.ID)
+	assert.Equal(t, expectedAsset.FileID, asset.FileID)
+	assert.Equal(t, expectedAsset.EntityID, asset.EntityID)
+	assert.Equal(t, expectedAsset.EntityName, asset.EntityName)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryGetByIDErrorAssetNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `assets` WHERE id = ? ORDER BY `assets`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+
+	asset, err := assetRepository.GetByID(id)
+
+	assert.Nil(t, asset)
+	assert.Error(t, err)
+	assert.ErrorIs(t, err, repositories.ErrAssetRepositoryAssetNotFound)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryGetAll(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	expectedAsset := &entities.Asset{
+		ID:         uuid.NewString(),
+		Name:       random.String(100, random.Alphanumeric),
+		Extension:  ".jpg",
+		Size:       89813,
+		FileID:     uuid.NewString(),
+		EntityID:   uuid.NewString(),
+		EntityName: "item",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "extension", "size", "file_id", "entity_id", "entity_name", "created_at", "updated_at"}).
+		AddRow(
+			expectedAsset.ID,
+			expectedAsset.Name,
+			expectedAsset.Extension,
+			expectedAsset.Size,
+			expectedAsset.FileID,
+			expectedAsset.EntityID,
+			expectedAsset.EntityName,
+			expectedAsset.CreatedAt,
+			expectedAsset.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `assets`")).
+		WillReturnRows(rows)
+
+	assets, err := assetRepository.GetAll()
+
+	assert.NoError(t, err)
+	assert.Len(t, assets, 1)
+	assert.Equal(t, expectedAsset.ID, assets[0].ID)
+	assert.Equal(t, expectedAsset.FileID, assets[0].FileID)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryGetAllErrorCanNotGetAssets(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `assets`")).
+		WillReturnError(errors.New("database error"))
+
+	assets, err := assetRepository.GetAll()
+
+	assert.Nil(t, assets)
+	assert.ErrorIs(t, err, repositories.ErrAssetRepositoryCanNotGetAssets)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryGetWithoutEntity(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	expectedAsset := &entities.Asset{
+		ID:         uuid.NewString(),
+		Name:       random.String(100, random.Alphanumeric),
+		Extension:  ".jpg",
+		Size:       89813,
+		FileID:     uuid.NewString(),
+		EntityID:   uuid.NewString(),
+		EntityName: "box",
+		CreatedAt:  time.Now(),
+		UpdatedAt:  time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "name", "extension", "size", "file_id", "entity_id", "entity_name", "created_at", "updated_at"}).
+		AddRow(
+			expectedAsset.ID,
+			expectedAsset.Name,
+			expectedAsset.Extension,
+			expectedAsset.Size,
+			expectedAsset.FileID,
+			expectedAsset.EntityID,
+			expectedAsset.EntityName,
+			expectedAsset.CreatedAt,
+			expectedAsset.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `assets` WHERE "+
+		"(entity_name = ? AND NOT EXISTS (SELECT 1 FROM items WHERE items.id = assets.entity_id)) OR "+
+		"(entity_name = ? AND NOT EXISTS (SELECT 1 FROM rooms WHERE rooms.id = assets.entity_id)) OR "+
+		"(entity_name = ? AND NOT EXISTS (SELECT 1 FROM boxes WHERE boxes.id = assets.entity_id)) OR "+
+		"(entity_name = ? AND NOT EXISTS (SELECT 1 FROM attachments WHERE attachments.id = assets.entity_id)) OR "+
+		"(entity_name = ? AND NOT EXISTS (SELECT 1 FROM users WHERE users.id = assets.entity_id))")).
+		WithArgs("item", "room", "box", "attachment", "mock_entity").
+		WillReturnRows(rows)
+
+	assets, err := assetRepository.GetWithoutEntity()
+
+	assert.NoError(t, err)
+	assert.Len(t, assets, 1)
+	assert.Equal(t, expectedAsset.ID, assets[0].ID)
+	assert.Equal(t, expectedAsset.EntityName, assets[0].EntityName)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryGetWithoutEntityErrorCanNotGetAssetsWithoutEntity(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `assets` WHERE")).
+		WithArgs("item", "room", "box", "attachment", "mock_entity").
+		WillReturnError(errors.New("database error"))
+
+	assets, err := assetRepository.GetWithoutEntity()
+
+	assert.Nil(t, assets)
+	assert.ErrorIs(t, err, repositories.ErrAssetRepositoryCanNotGetAssetsWithoutEntity)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryCountByQueryFilters(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	fileID := uuid.NewString()
+	queryFilter := repositories.QueryFilter{
+		ConditionGroups: []repositories.ConditionGroup{
+			{
+				Operator: repositories.AndLogicalOperator,
+				Conditions: []repositories.Condition{
+					{
+						Field:    "file_id",
+						Operator: repositories.EqualComparisonOperator,
+						Value:    fileID,
+					},
+					{
+						Field:    "extension",
+						Operator: repositories.EqualComparisonOperator,
+						Value:    ".jpg",
+					},
+				},
+			},
+		},
+	}
+
+	count := int64(2)
+	rows := sqlmock.NewRows([]string{"count(*)"}).
+		AddRow(count)
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `assets` WHERE file_id = ? AND extension = ?")).
+		WithArgs(fileID, ".jpg").
+		WillReturnRows(rows)
+
+	result, err := assetRepository.CountByQueryFilters(queryFilter)
+
+	assert.NoError(t, err)
+	assert.Equal(t, count, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAssetRepositoryCountByQueryFiltersErrorCanNotCountAssets(t *testing.T) {
+	db, dbMock := makeDBMock()
+	assetRepository := NewAssetRepository(db)
+
+	fileID := uuid.NewString()
+	queryFilter := repositories.QueryFilter{
+		ConditionGroups: []repositories.ConditionGroup{
+			{
+				Operator: repositories.AndLogicalOperator,
+				Conditions: []repositories.Condition{
+					{
+						Field:    "file_id",
+						Operator: repositories.EqualComparisonOperator,
+						Value:    fileID,
+					},
+				},
+			},
+		},
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `assets` WHERE file_id = ?")).
+		WithArgs(fileID).
+		WillReturnError(errors.New("database error"))
+
+	result, err := assetRepository.CountByQueryFilters(queryFilter)
+
+	assert.ErrorIs(t, err, repositories.ErrAssetRepositoryCanNotCountAssets)
+	assert.Equal(t, int64(0), result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,92 @@
+package gorm
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type AttachmentRepository struct {
+	db *gorm.DB
+}
+
+func NewAttachmentRepository(db *gorm.DB) *AttachmentRepository {
+	return &AttachmentRepository{
+		db,
+	}
+}
+
+func (r *AttachmentRepository) Create(attachment *entities.Attachment) error {
+	if err := r.db.Create(attachment).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrAttachmentRepositoryCanNotCreateAttachment
+	}
+
+	return nil
+}
+
+func (r *AttachmentRepository) GetByID(id string) (*entities.Attachment, error) {
+	var attachment entities.Attachment
+	if err := r.db.First(&attachment, "id = ?", id).Error; err != nil {
+		logger.LogError(err)
+		return nil, repositories.ErrAttachmentRepositoryAttachmentNotFound
+	}
+
+	return &attachment, nil
+}
+
+func (r *AttachmentRepository) GetByItemID(itemID string) ([]*entities.Attachment, error) {
+	var attachments []*entities.Attachment
+	err := r.db.
+		Where("item_id = ?", itemID).
+		Order("created_at").
+		Find(&attachments).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrAttachmentRepositoryCanNotGetAttachments
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) GetByWarrantyExpiringBetween(
+	householdIDs []string,
+	from time.Time,
+	to time.Time,
+) ([]*entities.Attachment, error) {
+	var attachments []*entities.Attachment
+	err := r.db.
+		Select("attachments.*").
+		Joins("join items on items.id = attachments.item_id").
+		Where("items.household_id IN ?", householdIDs).
+		Where("attachments.warranty_expires_at BETWEEN ? AND ?", from, to).
+		Order("attachments.warranty_expires_at").
+		Find(&attachments).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrAttachmentRepositoryCanNotGetAttachments
+	}
+
+	return attachments, nil
+}
+
+func (r *AttachmentRepository) DeleteByItemID(itemID string) error {
+	err := r.db.Where("item_id = ?", itemID).Delete(&entities.Attachment{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrAttachmentRepositoryCanNotDeleteAttachments
+	}
+
+	return nil
+}
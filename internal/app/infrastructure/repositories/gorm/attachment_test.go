@@ -0,0 +1,274 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func makeAttachmentRows(attachments ...*entities.Attachment) *sqlmock.Rows {
+	rows := sqlmock.NewRows([]string{"id", "item_id", "asset_id", "kind", "purchased_at", "warranty_expires_at", "created_at", "updated_at"})
+	for _, attachment := range attachments {
+		rows.AddRow(
+			attachment.ID,
+			attachment.ItemID,
+			attachment.AssetID,
+			attachment.Kind,
+			attachment.PurchasedAt,
+			attachment.WarrantyExpiresAt,
+			attachment.CreatedAt,
+			attachment.UpdatedAt,
+		)
+	}
+
+	return rows
+}
+
+func TestAttachmentRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	purchasedAt := time.Now().AddDate(-1, 0, 0)
+	attachment := &entities.Attachment{
+		ID:          uuid.NewString(),
+		ItemID:      uuid.NewString(),
+		AssetID:     uuid.NewString(),
+		Kind:        entities.AttachmentKindReceipt,
+		PurchasedAt: &purchasedAt,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `attachments` (`id`,`item_id`,`asset_id`,`kind`,`purchased_at`,`warranty_expires_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			attachment.ID,
+			attachment.ItemID,
+			attachment.AssetID,
+			attachment.Kind,
+			attachment.PurchasedAt,
+			attachment.WarrantyExpiresAt,
+			attachment.CreatedAt,
+			attachment.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := attachmentRepository.Create(attachment)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryCreateErrorCanNotCreateAttachment(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	attachment := &entities.Attachment{
+		ID:        uuid.NewString(),
+		ItemID:    uuid.NewString(),
+		AssetID:   uuid.NewString(),
+		Kind:      entities.AttachmentKindManual,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `attachments`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := attachmentRepository.Create(attachment)
+
+	assert.ErrorIs(t, err, repositories.ErrAttachmentRepositoryCanNotCreateAttachment)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryGetByID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	expectedAttachment := &entities.Attachment{
+		ID:        uuid.NewString(),
+		ItemID:    uuid.NewString(),
+		AssetID:   uuid.NewString(),
+		Kind:      entities.AttachmentKindManual,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE id = ? ORDER BY `attachments`.`id` LIMIT 1")).
+		WithArgs(expectedAttachment.ID).
+		WillReturnRows(makeAttachmentRows(expectedAttachment))
+
+	attachment, err := attachmentRepository.GetByID(expectedAttachment.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, expectedAttachment.ID, attachment.ID)
+	assert.Equal(t, expectedAttachment.ItemID, attachment.ItemID)
+	assert.Equal(t, expectedAttachment.AssetID, attachment.AssetID)
+	assert.Equal(t, expectedAttachment.Kind, attachment.Kind)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryGetByIDErrorAttachmentNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE id = ? ORDER BY `attachments`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(errors.New("record not found"))
+
+	attachment, err := attachmentRepository.GetByID(id)
+
+	assert.Nil(t, attachment)
+	assert.ErrorIs(t, err, repositories.ErrAttachmentRepositoryAttachmentNotFound)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryGetByItemID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	itemID := uuid.NewString()
+	expectedAttachment := &entities.Attachment{
+		ID:        uuid.NewString(),
+		ItemID:    itemID,
+		AssetID:   uuid.NewString(),
+		Kind:      entities.AttachmentKindPhoto,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE item_id = ? ORDER BY created_at")).
+		WithArgs(itemID).
+		WillReturnRows(makeAttachmentRows(expectedAttachment))
+
+	attachments, err := attachmentRepository.GetByItemID(itemID)
+
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+	assert.Equal(t, expectedAttachment.ID, attachments[0].ID)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryGetByItemIDErrorCanNotGetAttachments(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	itemID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `attachments` WHERE item_id = ? ORDER BY created_at")).
+		WithArgs(itemID).
+		WillReturnError(errors.New("database error"))
+
+	attachments, err := attachmentRepository.GetByItemID(itemID)
+
+	assert.Nil(t, attachments)
+	assert.ErrorIs(t, err, repositories.ErrAttachmentRepositoryCanNotGetAttachments)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryGetByWarrantyExpiringBetween(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	householdIDs := []string{uuid.NewString(), uuid.NewString()}
+	from := time.Now()
+	to := from.AddDate(0, 0, 30)
+	warrantyExpiresAt := from.AddDate(0, 0, 10)
+	expectedAttachment := &entities.Attachment{
+		ID:                uuid.NewString(),
+		ItemID:            uuid.NewString(),
+		AssetID:           uuid.NewString(),
+		Kind:              entities.AttachmentKindWarranty,
+		WarrantyExpiresAt: &warrantyExpiresAt,
+		CreatedAt:         time.Now(),
+		UpdatedAt:         time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT attachments.* FROM `attachments` join items on items.id = attachments.item_id WHERE items.household_id IN (?,?) AND (attachments.warranty_expires_at BETWEEN ? AND ?) ORDER BY attachments.warranty_expires_at")).
+		WithArgs(householdIDs[0], householdIDs[1], from, to).
+		WillReturnRows(makeAttachmentRows(expectedAttachment))
+
+	attachments, err := attachmentRepository.GetByWarrantyExpiringBetween(householdIDs, from, to)
+
+	assert.NoError(t, err)
+	assert.Len(t, attachments, 1)
+	assert.Equal(t, expectedAttachment.ID, attachments[0].ID)
+	assert.NotNil(t, attachments[0].WarrantyExpiresAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryGetByWarrantyExpiringBetweenErrorCanNotGetAttachments(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	householdIDs := []string{uuid.NewString(), uuid.NewString()}
+	from := time.Now()
+	to := from.AddDate(0, 0, 30)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT attachments.* FROM `attachments`")).
+		WithArgs(householdIDs[0], householdIDs[1], from, to).
+		WillReturnError(errors.New("database error"))
+
+	attachments, err := attachmentRepository.GetByWarrantyExpiringBetween(householdIDs, from, to)
+
+	assert.Nil(t, attachments)
+	assert.ErrorIs(t, err, repositories.ErrAttachmentRepositoryCanNotGetAttachments)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryDeleteByItemID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	itemID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `attachments` WHERE item_id = ?")).
+		WithArgs(itemID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := attachmentRepository.DeleteByItemID(itemID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAttachmentRepositoryDeleteByItemIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	attachmentRepository := NewAttachmentRepository(db)
+
+	itemID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `attachments` WHERE item_id = ?")).
+		WithArgs(itemID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := attachmentRepository.DeleteByItemID(itemID)
+
+	assert.ErrorIs(t, err, repositories.ErrAttachmentRepositoryCanNotDeleteAttachments)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
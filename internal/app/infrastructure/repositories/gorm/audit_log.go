@@ -0,0 +1,63 @@
+package gorm
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+)
+
+type AuditLogRepository struct {
+	db *gorm.DB
+}
+
+func NewAuditLogRepository(db *gorm.DB) *AuditLogRepository {
+	return &AuditLogRepository{
+		db,
+	}
+}
+
+func (r *AuditLogRepository) Create(auditLog *entities.AuditLog) error {
+	if err := r.db.Create(auditLog).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrAuditLogRepositoryCanNotCreateAuditLog
+	}
+
+	return nil
+}
+
+func (r *AuditLogRepository) GetByQueryFilters(
+	queryFilter repositories.QueryFilter,
+	pageFilter *repositories.PageFilter,
+) ([]*entities.AuditLog, error) {
+	var auditLogs []*entities.AuditLog
+	err := applyFilters(r.db, queryFilter).
+		Offset(pageFilter.Offset).
+		Limit(pageFilter.Limit).
+		Order("happened_at desc").
+		Find(&auditLogs).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		return nil, repositories.ErrAuditLogRepositoryCanNotGetAuditLogs
+	}
+
+	return auditLogs, nil
+}
+
+func (r *AuditLogRepository) CountByQueryFilters(queryFilter repositories.QueryFilter) (int64, error) {
+	var count int64
+	err := applyFilters(r.db.Model(&entities.AuditLog{}), queryFilter).
+		Count(&count).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		return 0, repositories.ErrAuditLogRepositoryCanNotCountAuditLogs
+	}
+
+	return count, nil
+}
@@ -0,0 +1,217 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestAuditLogRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	auditLogRepository := NewAuditLogRepository(db)
+
+	householdID := uuid.NewString()
+	after := `{"Name":"Kitchen"}`
+	auditLog := &entities.AuditLog{
+		ID:          uuid.NewString(),
+		ActorID:     uuid.NewString(),
+		Action:      entities.AuditActionCreate,
+		EntityType:  "room",
+		EntityID:    uuid.NewString(),
+		HouseholdID: &householdID,
+		After:       &after,
+		IPAddress:   "127.0.0.1",
+		UserAgent:   "curl/8.0",
+		HappenedAt:  time.Now(),
+		CreatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_logs` (`id`,`actor_id`,`action`,`entity_type`,`entity_id`,`household_id`,`before`,`after`,`ip_address`,`user_agent`,`happened_at`,`created_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			auditLog.ID,
+			auditLog.ActorID,
+			auditLog.Action,
+			auditLog.EntityType,
+			auditLog.EntityID,
+			auditLog.HouseholdID,
+			nil,
+			auditLog.After,
+			auditLog.IPAddress,
+			auditLog.UserAgent,
+			auditLog.HappenedAt,
+			auditLog.CreatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := auditLogRepository.Create(auditLog)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAuditLogRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	auditLogRepository := NewAuditLogRepository(db)
+
+	auditLog := &entities.AuditLog{
+		ID:         uuid.NewString(),
+		ActorID:    uuid.NewString(),
+		Action:     entities.AuditActionLogIn,
+		EntityType: entities.AuditEntityTypeUser,
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `audit_logs`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := auditLogRepository.Create(auditLog)
+
+	assert.ErrorIs(t, err, repositories.ErrAuditLogRepositoryCanNotCreateAuditLog)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAuditLogRepositoryGetByQueryFilters(t *testing.T) {
+	db, dbMock := makeDBMock()
+	auditLogRepository := NewAuditLogRepository(db)
+
+	actorID := uuid.NewString()
+	householdID := uuid.NewString()
+	from := time.Now().Add(-time.Hour)
+
+	auditLogs := []*entities.AuditLog{
+		{
+			ID:          uuid.NewString(),
+			ActorID:     uuid.NewString(),
+			Action:      entities.AuditActionDelete,
+			EntityType:  "box",
+			EntityID:    uuid.NewString(),
+			HouseholdID: &householdID,
+			IPAddress:   "127.0.0.1",
+			UserAgent:   "curl/8.0",
+			HappenedAt:  time.Now(),
+			CreatedAt:   time.Now(),
+		},
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "actor_id", "action", "entity_type", "entity_id", "household_id", "before", "after", "ip_address", "user_agent", "happened_at", "created_at"})
+	for _, auditLog := range auditLogs {
+		rows.AddRow(auditLog.ID, auditLog.ActorID, auditLog.Action, auditLog.EntityType, auditLog.EntityID, auditLog.HouseholdID, auditLog.Before, auditLog.After, auditLog.IPAddress, auditLog.UserAgent, auditLog.HappenedAt, auditLog.CreatedAt)
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_logs` WHERE (actor_id = ? OR household_id IN (?)) AND happened_at >= ? ORDER BY happened_at desc LIMIT 10")).
+		WithArgs(actorID, householdID, from).
+		WillReturnRows(rows)
+
+	result, err := auditLogRepository.GetByQueryFilters(repositories.QueryFilter{
+		ConditionGroups: []repositories.ConditionGroup{
+			{
+				Operator: repositories.OrLogicalOperator,
+				Conditions: []repositories.Condition{
+					{
+						Field:    entities.AuditLogActorIDField,
+						Operator: repositories.EqualComparisonOperator,
+						Value:    actorID,
+					},
+					{
+						Field:    entities.AuditLogHouseholdIDField,
+						Operator: repositories.InComparisonOperator,
+						Value:    []string{householdID},
+					},
+				},
+			},
+			{
+				Operator: repositories.AndLogicalOperator,
+				Conditions: []repositories.Condition{
+					{
+						Field:    entities.AuditLogHappenedAtField,
+						Operator: repositories.GreaterOrEqualComparisonOperator,
+						Value:    from,
+					},
+				},
+			},
+		},
+	}, &repositories.PageFilter{
+		Offset: 0,
+		Limit:  10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, auditLogs, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAuditLogRepositoryGetByQueryFiltersError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	auditLogRepository := NewAuditLogRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `audit_logs`")).
+		WillReturnError(errors.New("database error"))
+
+	result, err := auditLogRepository.GetByQueryFilters(repositories.QueryFilter{}, &repositories.PageFilter{
+		Offset: 0,
+		Limit:  10,
+	})
+
+	assert.ErrorIs(t, err, repositories.ErrAuditLogRepositoryCanNotGetAuditLogs)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAuditLogRepositoryCountByQueryFilters(t *testing.T) {
+	db, dbMock := makeDBMock()
+	auditLogRepository := NewAuditLogRepository(db)
+
+	actorID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `audit_logs` WHERE actor_id = ?")).
+		WithArgs(actorID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := auditLogRepository.CountByQueryFilters(repositories.QueryFilter{
+		ConditionGroups: []repositories.ConditionGroup{
+			{
+				Operator: repositories.AndLogicalOperator,
+				Conditions: []repositories.Condition{
+					{
+						Field:    entities.AuditLogActorIDField,
+						Operator: repositories.EqualComparisonOperator,
+						Value:    actorID,
+					},
+				},
+			},
+		},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestAuditLogRepositoryCountByQueryFiltersError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	auditLogRepository := NewAuditLogRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `audit_logs`")).
+		WillReturnError(errors.New("database error"))
+
+	count, err := auditLogRepository.CountByQueryFilters(repositories.QueryFilter{})
+
+	assert.ErrorIs(t, err, repositories.ErrAuditLogRepositoryCanNotCountAuditLogs)
+	assert.Equal(t, int64(0), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -348,7 +348,7 @@ func TestBoxRepositoryGetByQueryFilters(t *testing.T) {
 	db, dbMock := makeDBMock()
 	boxRepository := NewBoxRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	roomID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
@@ -357,9 +357,9 @@ func TestBoxRepositoryGetByQueryFilters(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "rooms.user_id",
+						Field:    "rooms.household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 					{
 						Field:    "room_id",
@@ -392,8 +392,8 @@ func TestBoxRepositoryGetByQueryFilters(t *testing.T) {
 	for _, box := range boxes {
 		rows.AddRow(box.ID, box.Name, *box.Description, box.RoomID, box.CreatedAt, box.UpdatedAt)
 	}
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `boxes`.`id`,`boxes`.`name`,`boxes`.`description`,`boxes`.`room_id`,`boxes`.`created_at`,`boxes`.`updated_at` FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.user_id = ? AND room_id = ? LIMIT 10")).
-		WithArgs(userID, roomID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `boxes`.`id`,`boxes`.`name`,`boxes`.`description`,`boxes`.`room_id`,`boxes`.`created_at`,`boxes`.`updated_at` FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.household_id = ? AND room_id = ? LIMIT 10")).
+		WithArgs(householdID, roomID).
 		WillReturnRows(rows)
 
 	result, err := boxRepository.GetByQueryFilters(queryFilter, pageFilter)
@@ -408,7 +408,7 @@ func TestBoxRepositoryGetByQueryFiltersErrorBoxRepositoryCanNotGetByQueryFilters
 	db, dbMock := makeDBMock()
 	boxRepository := NewBoxRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	roomID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
@@ -417,9 +417,9 @@ func TestBoxRepositoryGetByQueryFiltersErrorBoxRepositoryCanNotGetByQueryFilters
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "rooms.user_id",
+						Field:    "rooms.household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 					{
 						Field:    "room_id",
@@ -435,8 +435,8 @@ func TestBoxRepositoryGetByQueryFiltersErrorBoxRepositoryCanNotGetByQueryFilters
 		Limit:  10,
 	}
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `boxes`.`id`,`boxes`.`name`,`boxes`.`description`,`boxes`.`room_id`,`boxes`.`created_at`,`boxes`.`updated_at` FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.user_id = ? AND room_id = ? LIMIT 10")).
-		WithArgs(userID, roomID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `boxes`.`id`,`boxes`.`name`,`boxes`.`description`,`boxes`.`room_id`,`boxes`.`created_at`,`boxes`.`updated_at` FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.household_id = ? AND room_id = ? LIMIT 10")).
+		WithArgs(householdID, roomID).
 		WillReturnError(errors.New("database error"))
 
 	result, err := boxRepository.GetByQueryFilters(queryFilter, pageFilter)
@@ -452,7 +452,7 @@ func TestBoxRepositoryCountByQueryFilters(t *testing.T) {
 	db, dbMock := makeDBMock()
 	boxRepository := NewBoxRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	roomID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
@@ -461,9 +461,9 @@ func TestBoxRepositoryCountByQueryFilters(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "rooms.user_id",
+						Field:    "rooms.household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 					{
 						Field:    "room_id",
@@ -475,8 +475,8 @@ func TestBoxRepositoryCountByQueryFilters(t *testing.T) {
 		},
 	}
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.user_id = ? AND room_id = ?")).
-		WithArgs(userID, roomID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.household_id = ? AND room_id = ?")).
+		WithArgs(householdID, roomID).
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(10))
 
 	result, err := boxRepository.CountByQueryFilters(queryFilter)
@@ -491,7 +491,7 @@ func TestBoxRepositoryCountByQueryFiltersErrorBoxRepositoryCanNotCountByQueryFil
 	db, dbMock := makeDBMock()
 	boxRepository := NewBoxRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	roomID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
@@ -500,9 +500,9 @@ func TestBoxRepositoryCountByQueryFiltersErrorBoxRepositoryCanNotCountByQueryFil
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "rooms.user_id",
+						Field:    "rooms.household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 					{
 						Field:    "room_id",
@@ -514,8 +514,8 @@ func TestBoxRepositoryCountByQueryFiltersErrorBoxRepositoryCanNotCountByQueryFil
 		},
 	}
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.user_id = ? AND room_id = ?")).
-		WithArgs(userID, roomID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `boxes` inner join rooms on boxes.room_id = rooms.id WHERE rooms.household_id = ? AND room_id = ?")).
+		WithArgs(householdID, roomID).
 		WillReturnError(errors.New("database error"))
 
 	result, err := boxRepository.CountByQueryFilters(queryFilter)
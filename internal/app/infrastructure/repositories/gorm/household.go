@@ -0,0 +1,212 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+)
+
+type HouseholdRepository struct {
+	db *gorm.DB
+}
+
+func NewHouseholdRepository(db *gorm.DB) *HouseholdRepository {
+	return &HouseholdRepository{
+		db,
+	}
+}
+
+func (r *HouseholdRepository) Create(household *entities.Household) error {
+	if err := r.db.Create(household).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotCreateHousehold
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) GetByIDs(ids []string) ([]*entities.Household, error) {
+	var households []*entities.Household
+	err := r.db.Where("id IN ?", ids).
+		Order("created_at").
+		Find(&households).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrHouseholdRepositoryCanNotGetHouseholds
+	}
+
+	return households, nil
+}
+
+func (r *HouseholdRepository) CreateMember(member *entities.HouseholdMember) error {
+	if err := r.db.Create(member).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotCreateMember
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) GetMember(householdID string, userID string) (*entities.HouseholdMember, error) {
+	member := &entities.HouseholdMember{}
+
+	err := r.db.First(member, "household_id = ? AND user_id = ?", householdID, userID).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrHouseholdRepositoryMemberNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrHouseholdRepositoryCanNotGetMembers
+	}
+
+	return member, nil
+}
+
+func (r *HouseholdRepository) GetMembersByUserID(userID string) ([]*entities.HouseholdMember, error) {
+	var members []*entities.HouseholdMember
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at").
+		Find(&members).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrHouseholdRepositoryCanNotGetMembers
+	}
+
+	return members, nil
+}
+
+func (r *HouseholdRepository) GetMembersByHouseholdID(householdID string) ([]*entities.HouseholdMember, error) {
+	var members []*entities.HouseholdMember
+	err := r.db.Where("household_id = ?", householdID).
+		Order("created_at").
+		Find(&members).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrHouseholdRepositoryCanNotGetMembers
+	}
+
+	return members, nil
+}
+
+func (r *HouseholdRepository) UpdateMember(member *entities.HouseholdMember) error {
+	if err := r.db.Save(member).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotUpdateMember
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) CreateInvitation(invitation *entities.HouseholdInvitation) error {
+	if err := r.db.Create(invitation).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotCreateInvitation
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) GetInvitationByID(id string) (*entities.HouseholdInvitation, error) {
+	invitation := &entities.HouseholdInvitation{}
+
+	err := r.db.First(invitation, "id = ?", id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrHouseholdRepositoryInvitationNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrHouseholdRepositoryCanNotGetInvitations
+	}
+
+	return invitation, nil
+}
+
+func (r *HouseholdRepository) GetPendingInvitationsByEmail(email string) ([]*entities.HouseholdInvitation, error) {
+	var invitations []*entities.HouseholdInvitation
+	err := r.db.Where("email = ? AND status = ?", email, entities.HouseholdInvitationStatusPending).
+		Order("created_at").
+		Find(&invitations).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrHouseholdRepositoryCanNotGetInvitations
+	}
+
+	return invitations, nil
+}
+
+func (r *HouseholdRepository) UpdateInvitation(invitation *entities.HouseholdInvitation) error {
+	if err := r.db.Save(invitation).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotUpdateInvitation
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) Delete(id string) error {
+	err := r.db.Where("id = ?", id).Delete(&entities.Household{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotDeleteHousehold
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) DeleteMember(id string) error {
+	err := r.db.Where("id = ?", id).Delete(&entities.HouseholdMember{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotDeleteMember
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) DeleteInvitationsByHouseholdID(householdID string) error {
+	err := r.db.Where("household_id = ?", householdID).Delete(&entities.HouseholdInvitation{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotDeleteInvitations
+	}
+
+	return nil
+}
+
+func (r *HouseholdRepository) DeleteInvitationsByInviter(userID string) error {
+	err := r.db.Where("invited_by = ?", userID).Delete(&entities.HouseholdInvitation{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrHouseholdRepositoryCanNotDeleteInvitations
+	}
+
+	return nil
+}
@@ -0,0 +1,463 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestHouseholdRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	household := &entities.Household{
+		ID:        uuid.NewString(),
+		Name:      "Home",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `households` (`id`,`name`,`created_at`,`updated_at`) VALUES (?,?,?,?)")).
+		WithArgs(household.ID, household.Name, household.CreatedAt, household.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.Create(household)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	household := &entities.Household{
+		ID:        uuid.NewString(),
+		Name:      "Home",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `households`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := householdRepository.Create(household)
+
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryCanNotCreateHousehold)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryGetByIDs(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	ids := []string{uuid.NewString(), uuid.NewString()}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `households` WHERE id IN (?,?) ORDER BY created_at")).
+		WithArgs(ids[0], ids[1]).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "created_at", "updated_at"}).
+			AddRow(ids[0], "Home", time.Now(), time.Now()).
+			AddRow(ids[1], "Office", time.Now(), time.Now()))
+
+	households, err := householdRepository.GetByIDs(ids)
+
+	assert.NoError(t, err)
+	assert.Len(t, households, 2)
+	assert.Equal(t, ids[0], households[0].ID)
+	assert.Equal(t, "Office", households[1].Name)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryCreateMember(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	member := &entities.HouseholdMember{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		UserID:      uuid.NewString(),
+		Role:        entities.HouseholdRoleOwner,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `household_members` (`id`,`household_id`,`user_id`,`role`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(member.ID, member.HouseholdID, member.UserID, member.Role, member.CreatedAt, member.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.CreateMember(member)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryGetMember(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+	memberID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `household_members` WHERE household_id = ? AND user_id = ? ORDER BY `household_members`.`id` LIMIT 1")).
+		WithArgs(householdID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "household_id", "user_id", "role", "created_at", "updated_at"}).
+			AddRow(memberID, householdID, userID, entities.HouseholdRoleEditor, time.Now(), time.Now()))
+
+	member, err := householdRepository.GetMember(householdID, userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, memberID, member.ID)
+	assert.Equal(t, entities.HouseholdRoleEditor, member.Role)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryGetMemberErrorMemberNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	householdID := uuid.NewString()
+	userID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `household_members` WHERE household_id = ? AND user_id = ?")).
+		WithArgs(householdID, userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "household_id", "user_id", "role", "created_at", "updated_at"}))
+
+	member, err := householdRepository.GetMember(householdID, userID)
+
+	assert.Nil(t, member)
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryMemberNotFound)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryGetMembersByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	userID := uuid.NewString()
+	householdID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `household_members` WHERE user_id = ? ORDER BY created_at")).
+		WithArgs(userID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "household_id", "user_id", "role", "created_at", "updated_at"}).
+			AddRow(uuid.NewString(), householdID, userID, entities.HouseholdRoleOwner, time.Now(), time.Now()))
+
+	members, err := householdRepository.GetMembersByUserID(userID)
+
+	assert.NoError(t, err)
+	assert.Len(t, members, 1)
+	assert.Equal(t, householdID, members[0].HouseholdID)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryGetMembersByHouseholdIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	householdID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `household_members` WHERE household_id = ? ORDER BY created_at")).
+		WithArgs(householdID).
+		WillReturnError(errors.New("database error"))
+
+	members, err := householdRepository.GetMembersByHouseholdID(householdID)
+
+	assert.Nil(t, members)
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryCanNotGetMembers)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryCreateInvitation(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	invitation := &entities.HouseholdInvitation{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		Email:       "test@example.com",
+		Role:        entities.HouseholdRoleViewer,
+		InvitedBy:   uuid.NewString(),
+		Status:      entities.HouseholdInvitationStatusPending,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `household_invitations` (`id`,`household_id`,`email`,`role`,`invited_by`,`status`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			invitation.ID,
+			invitation.HouseholdID,
+			invitation.Email,
+			invitation.Role,
+			invitation.InvitedBy,
+			invitation.Status,
+			invitation.CreatedAt,
+			invitation.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.CreateInvitation(invitation)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryGetInvitationByIDErrorInvitationNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `household_invitations` WHERE id = ? ORDER BY `household_invitations`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnRows(sqlmock.NewRows([]string{"id"}))
+
+	invitation, err := householdRepository.GetInvitationByID(id)
+
+	assert.Nil(t, invitation)
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryInvitationNotFound)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryGetPendingInvitationsByEmail(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	email := "test@example.com"
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `household_invitations` WHERE email = ? AND status = ? ORDER BY created_at")).
+		WithArgs(email, entities.HouseholdInvitationStatusPending).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "email", "status"}).
+			AddRow(id, email, entities.HouseholdInvitationStatusPending))
+
+	invitations, err := householdRepository.GetPendingInvitationsByEmail(email)
+
+	assert.NoError(t, err)
+	assert.Len(t, invitations, 1)
+	assert.Equal(t, id, invitations[0].ID)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryUpdateInvitationError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	invitation := &entities.HouseholdInvitation{
+		ID:     uuid.NewString(),
+		Status: entities.HouseholdInvitationStatusAccepted,
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `household_invitations` SET")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := householdRepository.UpdateInvitation(invitation)
+
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryCanNotUpdateInvitation)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryUpdateMember(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	member := &entities.HouseholdMember{
+		ID:          uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		UserID:      uuid.NewString(),
+		Role:        entities.HouseholdRoleOwner,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `household_members` SET `household_id`=?,`user_id`=?,`role`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(member.HouseholdID, member.UserID, member.Role, member.CreatedAt, sqlmock.AnyArg(), member.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.UpdateMember(member)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDelete(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `households` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.Delete(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDeleteError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `households` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := householdRepository.Delete(id)
+
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryCanNotDeleteHousehold)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDeleteMember(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `household_members` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.DeleteMember(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDeleteMemberError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `household_members` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := householdRepository.DeleteMember(id)
+
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryCanNotDeleteMember)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDeleteInvitationsByHouseholdID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	householdID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `household_invitations` WHERE household_id = ?")).
+		WithArgs(householdID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.DeleteInvitationsByHouseholdID(householdID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDeleteInvitationsByHouseholdIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	householdID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `household_invitations` WHERE household_id = ?")).
+		WithArgs(householdID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := householdRepository.DeleteInvitationsByHouseholdID(householdID)
+
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryCanNotDeleteInvitations)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDeleteInvitationsByInviter(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `household_invitations` WHERE invited_by = ?")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := householdRepository.DeleteInvitationsByInviter(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestHouseholdRepositoryDeleteInvitationsByInviterError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	householdRepository := NewHouseholdRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `household_invitations` WHERE invited_by = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := householdRepository.DeleteInvitationsByInviter(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrHouseholdRepositoryCanNotDeleteInvitations)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
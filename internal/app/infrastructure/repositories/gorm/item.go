@@ -84,3 +84,14 @@ func (r *ItemRepository) Update(item *entities.Item) error {
 
 	return nil
 }
+
+func (r *ItemRepository) Delete(id string) error {
+	err := r.db.Where("id = ?", id).Delete(&entities.Item{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrItemRepositoryCanNotDeleteItem
+	}
+
+	return nil
+}
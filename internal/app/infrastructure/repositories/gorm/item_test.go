@@ -24,13 +24,13 @@ func TestItemRepositoryCreate(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: &description,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `items` (`id`,`sku`,`name`,`description`,`unit`,`user_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(item.ID, item.Sku, item.Name, *item.Description, item.Unit, item.UserID, item.CreatedAt, item.UpdatedAt).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `items` (`id`,`sku`,`name`,`description`,`unit`,`household_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(item.ID, item.Sku, item.Name, *item.Description, item.Unit, item.HouseholdID, item.CreatedAt, item.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	dbMock.ExpectCommit()
 
@@ -52,13 +52,13 @@ func TestItemRepositoryCreateErrorCanNotCreateItem(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: &description,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `items` (`id`,`sku`,`name`,`description`,`unit`,`user_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
-		WithArgs(item.ID, item.Sku, item.Name, *item.Description, item.Unit, item.UserID, item.CreatedAt, item.UpdatedAt).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `items` (`id`,`sku`,`name`,`description`,`unit`,`household_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(item.ID, item.Sku, item.Name, *item.Description, item.Unit, item.HouseholdID, item.CreatedAt, item.UpdatedAt).
 		WillReturnError(errors.New("database error"))
 	dbMock.ExpectRollback()
 
@@ -80,12 +80,12 @@ func TestItemRepositoryGetByID(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: &description,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
-	rows := sqlmock.NewRows([]string{"id", "sku", "name", "description", "unit", "user_id", "created_at", "updated_at"}).
-		AddRow(item.ID, item.Sku, item.Name, item.Description, item.Unit, item.UserID, item.CreatedAt, item.UpdatedAt)
+	rows := sqlmock.NewRows([]string{"id", "sku", "name", "description", "unit", "household_id", "created_at", "updated_at"}).
+		AddRow(item.ID, item.Sku, item.Name, item.Description, item.Unit, item.HouseholdID, item.CreatedAt, item.UpdatedAt)
 	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `items` WHERE id = ? ORDER BY `items`.`id` LIMIT 1")).
 		WithArgs(item.ID).
 		WillReturnRows(rows)
@@ -98,7 +98,7 @@ func TestItemRepositoryGetByID(t *testing.T) {
 	assert.Equal(t, item.Name, itemResult.Name)
 	assert.Equal(t, item.Description, itemResult.Description)
 	assert.Equal(t, item.Unit, itemResult.Unit)
-	assert.Equal(t, item.UserID, itemResult.UserID)
+	assert.Equal(t, item.HouseholdID, itemResult.HouseholdID)
 	assert.Equal(t, item.CreatedAt, itemResult.CreatedAt)
 	assert.Equal(t, item.UpdatedAt, itemResult.UpdatedAt)
 	err = dbMock.ExpectationsWereMet()
@@ -126,7 +126,7 @@ func TestItemRepositoryGetByQueryFilters(t *testing.T) {
 	db, dbMock := makeDBMock()
 	itemRepository := NewItemRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
@@ -154,9 +154,9 @@ func TestItemRepositoryGetByQueryFilters(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "user_id",
+						Field:    "household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
@@ -175,7 +175,7 @@ func TestItemRepositoryGetByQueryFilters(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: &description,
 		Unit:        "unit",
-		UserID:      userID,
+		HouseholdID: householdID,
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
@@ -186,10 +186,10 @@ func TestItemRepositoryGetByQueryFilters(t *testing.T) {
 		CreatedAt: time.Now(),
 		UpdatedAt: time.Now(),
 	}
-	rows := sqlmock.NewRows([]string{"id", "sku", "name", "description", "unit", "user_id", "created_at", "updated_at"}).
-		AddRow(item.ID, item.Sku, item.Name, item.Description, item.Unit, item.UserID, item.CreatedAt, item.UpdatedAt)
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `items`.`id`,`items`.`sku`,`items`.`name`,`items`.`description`,`items`.`unit`,`items`.`user_id`,`items`.`created_at`,`items`.`updated_at` FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND user_id = ? GROUP BY `items`.`id` LIMIT 10 OFFSET 1")).
-		WithArgs("%search%", "%search%", "%search%", item.UserID).
+	rows := sqlmock.NewRows([]string{"id", "sku", "name", "description", "unit", "household_id", "created_at", "updated_at"}).
+		AddRow(item.ID, item.Sku, item.Name, item.Description, item.Unit, item.HouseholdID, item.CreatedAt, item.UpdatedAt)
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `items`.`id`,`items`.`sku`,`items`.`name`,`items`.`description`,`items`.`unit`,`items`.`household_id`,`items`.`created_at`,`items`.`updated_at` FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND household_id = ? GROUP BY `items`.`id` LIMIT 10 OFFSET 1")).
+		WithArgs("%search%", "%search%", "%search%", item.HouseholdID).
 		WillReturnRows(rows)
 	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `item_keywords` WHERE `item_keywords`.`item_id` = ?")).
 		WithArgs(item.ID).
@@ -205,7 +205,7 @@ func TestItemRepositoryGetByQueryFilters(t *testing.T) {
 	assert.Equal(t, item.Name, itemResults[0].Name)
 	assert.Equal(t, item.Description, itemResults[0].Description)
 	assert.Equal(t, item.Unit, itemResults[0].Unit)
-	assert.Equal(t, item.UserID, itemResults[0].UserID)
+	assert.Equal(t, item.HouseholdID, itemResults[0].HouseholdID)
 	assert.Equal(t, item.CreatedAt, itemResults[0].CreatedAt)
 	assert.Equal(t, item.UpdatedAt, itemResults[0].UpdatedAt)
 	err = dbMock.ExpectationsWereMet()
@@ -216,7 +216,7 @@ func TestItemRepositoryGetByQueryFiltersErrorCanNotGetByQueryFilters(t *testing.
 	db, dbMock := makeDBMock()
 	itemRepository := NewItemRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
@@ -244,9 +244,9 @@ func TestItemRepositoryGetByQueryFiltersErrorCanNotGetByQueryFilters(t *testing.
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "user_id",
+						Field:    "household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
@@ -258,8 +258,8 @@ func TestItemRepositoryGetByQueryFiltersErrorCanNotGetByQueryFilters(t *testing.
 		Limit:  10,
 	}
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `items`.`id`,`items`.`sku`,`items`.`name`,`items`.`description`,`items`.`unit`,`items`.`user_id`,`items`.`created_at`,`items`.`updated_at` FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND user_id = ? GROUP BY `items`.`id` LIMIT 10 OFFSET 1")).
-		WithArgs("%search%", "%search%", "%search%", userID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `items`.`id`,`items`.`sku`,`items`.`name`,`items`.`description`,`items`.`unit`,`items`.`household_id`,`items`.`created_at`,`items`.`updated_at` FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND household_id = ? GROUP BY `items`.`id` LIMIT 10 OFFSET 1")).
+		WithArgs("%search%", "%search%", "%search%", householdID).
 		WillReturnError(errors.New("database error"))
 
 	itemResults, err := itemRepository.GetByQueryFilters(queryFilter, &pageFilter)
@@ -275,7 +275,7 @@ func TestItemRepositoryCountByQueryFilters(t *testing.T) {
 	db, dbMock := makeDBMock()
 	itemRepository := NewItemRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
@@ -303,9 +303,9 @@ func TestItemRepositoryCountByQueryFilters(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "user_id",
+						Field:    "household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
@@ -315,8 +315,8 @@ func TestItemRepositoryCountByQueryFilters(t *testing.T) {
 	count := int64(10)
 	rows := sqlmock.NewRows([]string{"count(distinct items.id)"}).
 		AddRow(count)
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(distinct items.id) FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND user_id = ?")).
-		WithArgs("%search%", "%search%", "%search%", userID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(distinct items.id) FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND household_id = ?")).
+		WithArgs("%search%", "%search%", "%search%", householdID).
 		WillReturnRows(rows)
 
 	countResult, err := itemRepository.CountByQueryFilters(queryFilter)
@@ -331,7 +331,7 @@ func TestItemRepositoryCountByQueryFiltersErrorCanNotCountByQueryFilters(t *test
 	db, dbMock := makeDBMock()
 	itemRepository := NewItemRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
 	queryFilter := repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
@@ -359,17 +359,17 @@ func TestItemRepositoryCountByQueryFiltersErrorCanNotCountByQueryFilters(t *test
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    "user_id",
+						Field:    "household_id",
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
 		},
 	}
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(distinct items.id) FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND user_id = ?")).
-		WithArgs("%search%", "%search%", "%search%", userID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(distinct items.id) FROM `items` left join item_keywords on item_keywords.item_id = items.id WHERE (sku LIKE ? OR name LIKE ? OR description LIKE ?) AND household_id = ?")).
+		WithArgs("%search%", "%search%", "%search%", householdID).
 		WillReturnError(errors.New("database error"))
 
 	countResult, err := itemRepository.CountByQueryFilters(queryFilter)
@@ -391,14 +391,14 @@ func TestItemRepositoryUpdate(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: nil,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `items` SET `sku`=?,`name`=?,`description`=?,`unit`=?,`user_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
-		WithArgs(item.Sku, item.Name, item.Description, item.Unit, item.UserID, item.CreatedAt, sqlmock.AnyArg(), item.ID).
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `items` SET `sku`=?,`name`=?,`description`=?,`unit`=?,`household_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(item.Sku, item.Name, item.Description, item.Unit, item.HouseholdID, item.CreatedAt, sqlmock.AnyArg(), item.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	dbMock.ExpectCommit()
 
@@ -419,14 +419,14 @@ func TestItemRepositoryUpdateErrorCanNotUpdateItem(t *testing.T) {
 		Name:        random.String(100, random.Alphanumeric),
 		Description: nil,
 		Unit:        "unit",
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `items` SET `sku`=?,`name`=?,`description`=?,`unit`=?,`user_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
-		WithArgs(item.Sku, item.Name, item.Description, item.Unit, item.UserID, item.CreatedAt, sqlmock.AnyArg(), item.ID).
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `items` SET `sku`=?,`name`=?,`description`=?,`unit`=?,`household_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(item.Sku, item.Name, item.Description, item.Unit, item.HouseholdID, item.CreatedAt, sqlmock.AnyArg(), item.ID).
 		WillReturnError(errors.New("database error"))
 	dbMock.ExpectRollback()
 
@@ -436,3 +436,41 @@ func TestItemRepositoryUpdateErrorCanNotUpdateItem(t *testing.T) {
 	err = dbMock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
+
+func TestItemRepositoryDelete(t *testing.T) {
+	db, dbMock := makeDBMock()
+	itemRepository := NewItemRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `items` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := itemRepository.Delete(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestItemRepositoryDeleteError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	itemRepository := NewItemRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `items` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := itemRepository.Delete(id)
+
+	assert.ErrorIs(t, err, repositories.ErrItemRepositoryCanNotDeleteItem)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
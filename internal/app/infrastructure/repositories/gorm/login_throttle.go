@@ -0,0 +1,91 @@
+package gorm
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+	"time"
+)
+
+type LoginThrottleRepository struct {
+	db *gorm.DB
+}
+
+func NewLoginThrottleRepository(db *gorm.DB) *LoginThrottleRepository {
+	return &LoginThrottleRepository{
+		db,
+	}
+}
+
+func (r *LoginThrottleRepository) GetByIdentifiers(identifiers []string) ([]*entities.LoginThrottle, error) {
+	throttles := make([]*entities.LoginThrottle, 0)
+
+	err := r.db.Where("identifier IN ?", identifiers).
+		Find(&throttles).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrLoginThrottleRepositoryCanNotGetLoginThrottles
+	}
+
+	return throttles, nil
+}
+
+// RegisterFailure increments the counter in the database, so concurrent
+// failed logins are all counted.
+func (r *LoginThrottleRepository) RegisterFailure(identifier string, failedAt time.Time) (*entities.LoginThrottle, error) {
+	err := r.db.Clauses(clause.OnConflict{
+		DoUpdates: clause.Assignments(map[string]interface{}{
+			"failed_attempts": gorm.Expr("failed_attempts + 1"),
+			"last_failed_at":  failedAt,
+			"updated_at":      failedAt,
+		}),
+	}).Create(entities.NewLoginThrottle(identifier, failedAt)).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrLoginThrottleRepositoryCanNotRegisterFailure
+	}
+
+	throttle := &entities.LoginThrottle{}
+	err = r.db.First(throttle, "identifier = ?", identifier).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrLoginThrottleRepositoryCanNotRegisterFailure
+	}
+
+	return throttle, nil
+}
+
+func (r *LoginThrottleRepository) Update(throttle *entities.LoginThrottle) error {
+	err := r.db.Model(&entities.LoginThrottle{}).
+		Where("id = ?", throttle.ID).
+		Updates(map[string]interface{}{
+			"blocked_until": throttle.BlockedUntil,
+			"updated_at":    throttle.UpdatedAt,
+		}).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrLoginThrottleRepositoryCanNotUpdateLoginThrottle
+	}
+
+	return nil
+}
+
+func (r *LoginThrottleRepository) DeleteByIdentifier(identifier string) error {
+	err := r.db.Where("identifier = ?", identifier).Delete(&entities.LoginThrottle{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrLoginThrottleRepositoryCanNotDeleteLoginThrottle
+	}
+
+	return nil
+}
@@ -0,0 +1,178 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestLoginThrottleRepositoryGetByIdentifiers(t *testing.T) {
+	db, dbMock := makeDBMock()
+	loginThrottleRepository := NewLoginThrottleRepository(db)
+
+	throttle := &entities.LoginThrottle{
+		ID:             uuid.NewString(),
+		Identifier:     entities.LoginThrottleIdentifierForEmail("test@example.com"),
+		FailedAttempts: 2,
+		LastFailedAt:   time.Now(),
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+	identifiers := []string{throttle.Identifier, entities.LoginThrottleIdentifierForIP("127.0.0.1")}
+
+	rows := sqlmock.NewRows([]string{"id", "identifier", "failed_attempts", "last_failed_at", "blocked_until", "created_at", "updated_at"}).
+		AddRow(
+			throttle.ID,
+			throttle.Identifier,
+			throttle.FailedAttempts,
+			throttle.LastFailedAt,
+			nil,
+			throttle.CreatedAt,
+			throttle.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_throttles` WHERE identifier IN (?,?)")).
+		WithArgs(identifiers[0], identifiers[1]).
+		WillReturnRows(rows)
+
+	throttles, err := loginThrottleRepository.GetByIdentifiers(identifiers)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.LoginThrottle{throttle}, throttles)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottleRepositoryGetByIdentifiersError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	loginThrottleRepository := NewLoginThrottleRepository(db)
+
+	identifier := entities.LoginThrottleIdentifierForIP("127.0.0.1")
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_throttles` WHERE identifier IN (?)")).
+		WithArgs(identifier).
+		WillReturnError(errors.New("database error"))
+
+	throttles, err := loginThrottleRepository.GetByIdentifiers([]string{identifier})
+
+	assert.ErrorIs(t, err, repositories.ErrLoginThrottleRepositoryCanNotGetLoginThrottles)
+	assert.Nil(t, throttles)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottleRepositoryRegisterFailure(t *testing.T) {
+	db, dbMock := makeDBMock()
+	loginThrottleRepository := NewLoginThrottleRepository(db)
+
+	identifier := entities.LoginThrottleIdentifierForEmail("test@example.com")
+	failedAt := time.Now()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `login_throttles` (`id`,`identifier`,`failed_attempts`,`last_failed_at`,`blocked_until`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?) ON DUPLICATE KEY UPDATE `failed_attempts`=failed_attempts + 1,`last_failed_at`=?,`updated_at`=?")).
+		WithArgs(sqlmock.AnyArg(), identifier, 1, failedAt, nil, failedAt, failedAt, failedAt, failedAt).
+		WillReturnResult(sqlmock.NewResult(1, 2))
+	dbMock.ExpectCommit()
+
+	rows := sqlmock.NewRows([]string{"id", "identifier", "failed_attempts", "last_failed_at", "blocked_until", "created_at", "updated_at"}).
+		AddRow(uuid.NewString(), identifier, 4, failedAt, nil, failedAt, failedAt)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `login_throttles` WHERE identifier = ? ORDER BY `login_throttles`.`id` LIMIT 1")).
+		WithArgs(identifier).
+		WillReturnRows(rows)
+
+	throttle, err := loginThrottleRepository.RegisterFailure(identifier, failedAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, identifier, throttle.Identifier)
+	assert.Equal(t, 4, throttle.FailedAttempts)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottleRepositoryRegisterFailureError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	loginThrottleRepository := NewLoginThrottleRepository(db)
+
+	identifier := entities.LoginThrottleIdentifierForEmail("test@example.com")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `login_throttles`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	throttle, err := loginThrottleRepository.RegisterFailure(identifier, time.Now())
+
+	assert.ErrorIs(t, err, repositories.ErrLoginThrottleRepositoryCanNotRegisterFailure)
+	assert.Nil(t, throttle)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottleRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	loginThrottleRepository := NewLoginThrottleRepository(db)
+
+	blockedUntil := time.Now().Add(time.Minute)
+	throttle := &entities.LoginThrottle{
+		ID:           uuid.NewString(),
+		BlockedUntil: &blockedUntil,
+		UpdatedAt:    time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `login_throttles` SET `blocked_until`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(throttle.BlockedUntil, throttle.UpdatedAt, throttle.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := loginThrottleRepository.Update(throttle)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottleRepositoryDeleteByIdentifier(t *testing.T) {
+	db, dbMock := makeDBMock()
+	loginThrottleRepository := NewLoginThrottleRepository(db)
+
+	identifier := entities.LoginThrottleIdentifierForEmail("test@example.com")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_throttles` WHERE identifier = ?")).
+		WithArgs(identifier).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := loginThrottleRepository.DeleteByIdentifier(identifier)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestLoginThrottleRepositoryDeleteByIdentifierError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	loginThrottleRepository := NewLoginThrottleRepository(db)
+
+	identifier := entities.LoginThrottleIdentifierForEmail("test@example.com")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `login_throttles` WHERE identifier = ?")).
+		WithArgs(identifier).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := loginThrottleRepository.DeleteByIdentifier(identifier)
+
+	assert.ErrorIs(t, err, repositories.ErrLoginThrottleRepositoryCanNotDeleteLoginThrottle)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
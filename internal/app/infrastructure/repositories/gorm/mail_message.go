@@ -0,0 +1,169 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type MailMessageRepository struct {
+	db *gorm.DB
+}
+
+func NewMailMessageRepository(db *gorm.DB) *MailMessageRepository {
+	return &MailMessageRepository{
+		db,
+	}
+}
+
+func (r *MailMessageRepository) Create(message *entities.MailMessage) error {
+	if err := r.db.Create(message).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrMailMessageRepositoryCanNotCreateMessage
+	}
+
+	return nil
+}
+
+func (r *MailMessageRepository) GetByID(id string) (*entities.MailMessage, error) {
+	message := &entities.MailMessage{}
+
+	err := r.db.First(message, "id = ?", id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrMailMessageRepositoryMessageNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrMailMessageRepositoryCanNotGetMessage
+	}
+
+	return message, nil
+}
+
+func (r *MailMessageRepository) GetAvailable(now time.Time, limit int) ([]*entities.MailMessage, error) {
+	messages := make([]*entities.MailMessage, 0)
+
+	err := r.db.Where("status = ? AND available_at <= ?", entities.MailMessageStatusPending, now).
+		Order("available_at asc").
+		Limit(limit).
+		Find(&messages).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrMailMessageRepositoryCanNotGetMessages
+	}
+
+	return messages, nil
+}
+
+func (r *MailMessageRepository) Claim(message *entities.MailMessage, now time.Time, until time.Time) error {
+	result := r.db.Model(&entities.MailMessage{}).
+		Where("id = ? AND status = ? AND available_at <= ?", message.ID, entities.MailMessageStatusPending, now).
+		Updates(map[string]interface{}{
+			"available_at": until,
+			"updated_at":   now,
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrMailMessageRepositoryCanNotClaimMessage
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrMailMessageRepositoryMessageAlreadyClaimed
+	}
+
+	message.AvailableAt = until
+	message.UpdatedAt = now
+
+	return nil
+}
+
+func (r *MailMessageRepository) Update(message *entities.MailMessage) error {
+	err := r.db.Model(&entities.MailMessage{}).
+		Where("id = ?", message.ID).
+		Updates(map[string]interface{}{
+			"text":         message.Text,
+			"html":         message.HTML,
+			"status":       message.Status,
+			"attempts":     message.Attempts,
+			"last_error":   message.LastError,
+			"available_at": message.AvailableAt,
+			"sent_at":      message.SentAt,
+			"updated_at":   message.UpdatedAt,
+		}).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrMailMessageRepositoryCanNotUpdateMessage
+	}
+
+	return nil
+}
+
+func (r *MailMessageRepository) GetByStatus(
+	status string,
+	pageFilter *repositories.PageFilter,
+) ([]*entities.MailMessage, error) {
+	messages := make([]*entities.MailMessage, 0)
+
+	query := r.db
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Offset(pageFilter.Offset).
+		Limit(pageFilter.Limit).
+		Order("created_at desc").
+		Find(&messages).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		return nil, repositories.ErrMailMessageRepositoryCanNotGetMessages
+	}
+
+	return messages, nil
+}
+
+func (r *MailMessageRepository) CountByStatus(status string) (int64, error) {
+	var count int64
+
+	query := r.db.Model(&entities.MailMessage{})
+	if status != "" {
+		query = query.Where("status = ?", status)
+	}
+
+	err := query.Count(&count).Error
+	if err != nil {
+		logger.LogError(err)
+		return 0, repositories.ErrMailMessageRepositoryCanNotCountMessages
+	}
+
+	return count, nil
+}
+
+func (r *MailMessageRepository) DeleteFinishedBefore(before time.Time) (int64, error) {
+	result := r.db.Where(
+		"status IN ? AND updated_at < ?",
+		[]string{entities.MailMessageStatusSent, entities.MailMessageStatusDead},
+		before,
+	).Delete(&entities.MailMessage{})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return 0, repositories.ErrMailMessageRepositoryCanNotDeleteMessages
+	}
+
+	return result.RowsAffected, nil
+}
@@ -0,0 +1,502 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestMailMessageRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `mail_messages` (`id`,`recipient`,`subject`,`text`,`html`,`status`,`attempts`,`last_error`,`available_at`,`sent_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			message.ID,
+			message.Recipient,
+			message.Subject,
+			message.Text,
+			message.HTML,
+			message.Status,
+			message.Attempts,
+			nil,
+			message.AvailableAt,
+			nil,
+			message.CreatedAt,
+			message.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := mailMessageRepository.Create(message)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `mail_messages`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := mailMessageRepository.Create(message)
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotCreateMessage)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryGetByID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mail_messages` WHERE id = ? ORDER BY `mail_messages`.`id` LIMIT 1")).
+		WithArgs(message.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipient", "subject", "text", "html", "status", "attempts", "last_error", "available_at", "sent_at", "created_at", "updated_at"}).AddRow(
+			message.ID,
+			message.Recipient,
+			message.Subject,
+			message.Text,
+			message.HTML,
+			message.Status,
+			message.Attempts,
+			nil,
+			message.AvailableAt,
+			nil,
+			message.CreatedAt,
+			message.UpdatedAt,
+		))
+
+	result, err := mailMessageRepository.GetByID(message.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, message, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryGetByIDErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mail_messages` WHERE id = ? ORDER BY `mail_messages`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := mailMessageRepository.GetByID(id)
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryMessageNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryGetAvailable(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mail_messages` WHERE status = ? AND available_at <= ? ORDER BY available_at asc LIMIT 10")).
+		WithArgs(entities.MailMessageStatusPending, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipient", "subject", "text", "html", "status", "attempts", "last_error", "available_at", "sent_at", "created_at", "updated_at"}).AddRow(
+			message.ID,
+			message.Recipient,
+			message.Subject,
+			message.Text,
+			message.HTML,
+			message.Status,
+			message.Attempts,
+			nil,
+			message.AvailableAt,
+			nil,
+			message.CreatedAt,
+			message.UpdatedAt,
+		))
+
+	messages, err := mailMessageRepository.GetAvailable(now, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.MailMessage{message}, messages)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryGetAvailableError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mail_messages` WHERE status = ? AND available_at <= ? ORDER BY available_at asc LIMIT 10")).
+		WithArgs(entities.MailMessageStatusPending, now).
+		WillReturnError(errors.New("database error"))
+
+	messages, err := mailMessageRepository.GetAvailable(now, 10)
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotGetMessages)
+	assert.Nil(t, messages)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryClaim(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `mail_messages` SET `available_at`=?,`updated_at`=? WHERE id = ? AND status = ? AND available_at <= ?")).
+		WithArgs(until, now, message.ID, entities.MailMessageStatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := mailMessageRepository.Claim(message, now, until)
+
+	assert.NoError(t, err)
+	assert.Equal(t, until, message.AvailableAt)
+	assert.Equal(t, now, message.UpdatedAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryClaimErrorAlreadyClaimed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	availableAt := message.AvailableAt
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `mail_messages` SET `available_at`=?,`updated_at`=? WHERE id = ? AND status = ? AND available_at <= ?")).
+		WithArgs(until, now, message.ID, entities.MailMessageStatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := mailMessageRepository.Claim(message, now, until)
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryMessageAlreadyClaimed)
+	assert.Equal(t, availableAt, message.AvailableAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	message.MarkSent(time.Now())
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `mail_messages` SET `attempts`=?,`available_at`=?,`html`=?,`last_error`=?,`sent_at`=?,`status`=?,`text`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(message.Attempts, message.AvailableAt, "", nil, message.SentAt, message.Status, "", message.UpdatedAt, message.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := mailMessageRepository.Update(message)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryUpdateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `mail_messages`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := mailMessageRepository.Update(message)
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotUpdateMessage)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryGetByStatus(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	message.Status = entities.MailMessageStatusDead
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mail_messages` WHERE status = ? ORDER BY created_at desc LIMIT 10 OFFSET 10")).
+		WithArgs(entities.MailMessageStatusDead).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipient", "subject", "text", "html", "status", "attempts", "last_error", "available_at", "sent_at", "created_at", "updated_at"}).AddRow(
+			message.ID,
+			message.Recipient,
+			message.Subject,
+			message.Text,
+			message.HTML,
+			message.Status,
+			message.Attempts,
+			nil,
+			message.AvailableAt,
+			nil,
+			message.CreatedAt,
+			message.UpdatedAt,
+		))
+
+	messages, err := mailMessageRepository.GetByStatus(entities.MailMessageStatusDead, &repositories.PageFilter{
+		Offset: 10,
+		Limit:  10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.MailMessage{message}, messages)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryGetByStatusEveryStatus(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	message := &entities.MailMessage{
+		ID:          uuid.NewString(),
+		Recipient:   "test@example.com",
+		Subject:     "Verify your email",
+		Text:        "Your code is 123456",
+		HTML:        "<p>Your code is 123456</p>",
+		Status:      entities.MailMessageStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mail_messages` ORDER BY created_at desc LIMIT 10")).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "recipient", "subject", "text", "html", "status", "attempts", "last_error", "available_at", "sent_at", "created_at", "updated_at"}).AddRow(
+			message.ID,
+			message.Recipient,
+			message.Subject,
+			message.Text,
+			message.HTML,
+			message.Status,
+			message.Attempts,
+			nil,
+			message.AvailableAt,
+			nil,
+			message.CreatedAt,
+			message.UpdatedAt,
+		))
+
+	messages, err := mailMessageRepository.GetByStatus("", &repositories.PageFilter{
+		Offset: 0,
+		Limit:  10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.MailMessage{message}, messages)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryGetByStatusError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `mail_messages` WHERE status = ? ORDER BY created_at desc LIMIT 10")).
+		WithArgs(entities.MailMessageStatusDead).
+		WillReturnError(errors.New("database error"))
+
+	messages, err := mailMessageRepository.GetByStatus(entities.MailMessageStatusDead, &repositories.PageFilter{
+		Offset: 0,
+		Limit:  10,
+	})
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotGetMessages)
+	assert.Nil(t, messages)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryCountByStatus(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `mail_messages` WHERE status = ?")).
+		WithArgs(entities.MailMessageStatusDead).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := mailMessageRepository.CountByStatus(entities.MailMessageStatusDead)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryCountByStatusError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `mail_messages`")).
+		WillReturnError(errors.New("database error"))
+
+	count, err := mailMessageRepository.CountByStatus("")
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotCountMessages)
+	assert.Equal(t, int64(0), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryDeleteFinishedBefore(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	before := time.Now()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mail_messages` WHERE status IN (?,?) AND updated_at < ?")).
+		WithArgs(entities.MailMessageStatusSent, entities.MailMessageStatusDead, before).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	dbMock.ExpectCommit()
+
+	count, err := mailMessageRepository.DeleteFinishedBefore(before)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestMailMessageRepositoryDeleteFinishedBeforeError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	mailMessageRepository := NewMailMessageRepository(db)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `mail_messages`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	count, err := mailMessageRepository.DeleteFinishedBefore(time.Now())
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotDeleteMessages)
+	assert.Equal(t, int64(0), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,125 @@
+package gorm
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type NotificationDigestRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationDigestRepository(db *gorm.DB) *NotificationDigestRepository {
+	return &NotificationDigestRepository{
+		db,
+	}
+}
+
+func (r *NotificationDigestRepository) Create(entry *entities.NotificationDigestEntry) error {
+	if err := r.db.Create(entry).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotCreateDigestEntry
+	}
+
+	return nil
+}
+
+func (r *NotificationDigestRepository) GetDueUserIDs(now time.Time, limit int) ([]string, error) {
+	userIDs := make([]string, 0)
+
+	err := r.db.Model(&entities.NotificationDigestEntry{}).
+		Distinct("user_id").
+		Where("held = ? AND due_at <= ?", false, now).
+		Order("user_id asc").
+		Limit(limit).
+		Pluck("user_id", &userIDs).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrNotificationRepositoryCanNotGetDigestEntries
+	}
+
+	return userIDs, nil
+}
+
+func (r *NotificationDigestRepository) ClaimByUserID(userID string, now time.Time, until time.Time) error {
+	result := r.db.Model(&entities.NotificationDigestEntry{}).
+		Where("user_id = ? AND held = ? AND due_at <= ?", userID, false, now).
+		Update("due_at", until)
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrNotificationRepositoryCanNotClaimDigest
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrNotificationRepositoryDigestAlreadyClaimed
+	}
+
+	return nil
+}
+
+func (r *NotificationDigestRepository) ReleaseByUserID(userID string, now time.Time) error {
+	err := r.db.Model(&entities.NotificationDigestEntry{}).
+		Where("user_id = ? AND held = ?", userID, true).
+		Updates(map[string]interface{}{
+			"held":   false,
+			"due_at": now,
+		}).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotReleaseDigest
+	}
+
+	return nil
+}
+
+func (r *NotificationDigestRepository) GetClaimedByUserID(
+	userID string,
+	until time.Time,
+) ([]*entities.NotificationDigestEntry, error) {
+	entries := make([]*entities.NotificationDigestEntry, 0)
+
+	err := r.db.Where("user_id = ? AND due_at = ?", userID, until).
+		Order("happened_at asc").
+		Find(&entries).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrNotificationRepositoryCanNotGetDigestEntries
+	}
+
+	return entries, nil
+}
+
+func (r *NotificationDigestRepository) DeleteByIDs(ids []string) error {
+	err := r.db.Where("id IN ?", ids).Delete(&entities.NotificationDigestEntry{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotDeleteDigest
+	}
+
+	return nil
+}
+
+func (r *NotificationDigestRepository) DeleteByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.NotificationDigestEntry{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotDeleteDigest
+	}
+
+	return nil
+}
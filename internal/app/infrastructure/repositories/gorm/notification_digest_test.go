@@ -0,0 +1,282 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNotificationDigestRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	entry := &entities.NotificationDigestEntry{
+		ID:         uuid.NewString(),
+		UserID:     uuid.NewString(),
+		EventType:  "BoxItemAddedEvent",
+		Message:    "2 item added into box box",
+		HappenedAt: time.Now(),
+		DueAt:      time.Now().Add(time.Hour),
+		CreatedAt:  time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `notification_digest_entries` (`id`,`user_id`,`event_type`,`message`,`happened_at`,`due_at`,`held`,`created_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			entry.ID,
+			entry.UserID,
+			entry.EventType,
+			entry.Message,
+			entry.HappenedAt,
+			entry.DueAt,
+			entry.Held,
+			entry.CreatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := notificationDigestRepository.Create(entry)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	entry := &entities.NotificationDigestEntry{
+		ID:         uuid.NewString(),
+		UserID:     uuid.NewString(),
+		EventType:  "BoxItemAddedEvent",
+		Message:    "2 item added into box box",
+		HappenedAt: time.Now(),
+		DueAt:      time.Now().Add(time.Hour),
+		CreatedAt:  time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `notification_digest_entries`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := notificationDigestRepository.Create(entry)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotCreateDigestEntry)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryGetDueUserIDs(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	userID := uuid.NewString()
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `user_id` FROM `notification_digest_entries` WHERE held = ? AND due_at <= ? ORDER BY user_id asc LIMIT 10")).
+		WithArgs(false, now).
+		WillReturnRows(sqlmock.NewRows([]string{"user_id"}).AddRow(userID))
+
+	userIDs, err := notificationDigestRepository.GetDueUserIDs(now, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{userID}, userIDs)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryGetDueUserIDsError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT DISTINCT `user_id` FROM `notification_digest_entries` WHERE held = ? AND due_at <= ? ORDER BY user_id asc LIMIT 10")).
+		WithArgs(false, now).
+		WillReturnError(errors.New("database error"))
+
+	userIDs, err := notificationDigestRepository.GetDueUserIDs(now, 10)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotGetDigestEntries)
+	assert.Nil(t, userIDs)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryGetClaimedByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	entry := &entities.NotificationDigestEntry{
+		ID:         uuid.NewString(),
+		UserID:     uuid.NewString(),
+		EventType:  "BoxItemAddedEvent",
+		Message:    "2 item added into box box",
+		HappenedAt: time.Now(),
+		DueAt:      time.Now().Add(time.Hour),
+		CreatedAt:  time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `notification_digest_entries` WHERE user_id = ? AND due_at = ? ORDER BY happened_at asc")).
+		WithArgs(entry.UserID, entry.DueAt).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "event_type", "message", "happened_at", "due_at", "held", "created_at"}).AddRow(
+			entry.ID,
+			entry.UserID,
+			entry.EventType,
+			entry.Message,
+			entry.HappenedAt,
+			entry.DueAt,
+			entry.Held,
+			entry.CreatedAt,
+		))
+
+	entries, err := notificationDigestRepository.GetClaimedByUserID(entry.UserID, entry.DueAt)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.NotificationDigestEntry{entry}, entries)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryGetClaimedByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	userID := uuid.NewString()
+	until := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `notification_digest_entries` WHERE user_id = ? AND due_at = ? ORDER BY happened_at asc")).
+		WithArgs(userID, until).
+		WillReturnError(errors.New("database error"))
+
+	entries, err := notificationDigestRepository.GetClaimedByUserID(userID, until)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotGetDigestEntries)
+	assert.Nil(t, entries)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryClaimByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	userID := uuid.NewString()
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `notification_digest_entries` SET `due_at`=? WHERE user_id = ? AND held = ? AND due_at <= ?")).
+		WithArgs(until, userID, false, now).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := notificationDigestRepository.ClaimByUserID(userID, now, until)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryClaimByUserIDErrorAlreadyClaimed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	userID := uuid.NewString()
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `notification_digest_entries` SET `due_at`=? WHERE user_id = ? AND held = ? AND due_at <= ?")).
+		WithArgs(until, userID, false, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := notificationDigestRepository.ClaimByUserID(userID, now, until)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryDigestAlreadyClaimed)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryReleaseByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	userID := uuid.NewString()
+	now := time.Now()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `notification_digest_entries` SET `due_at`=?,`held`=? WHERE user_id = ? AND held = ?")).
+		WithArgs(now, false, userID, true).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := notificationDigestRepository.ReleaseByUserID(userID, now)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryReleaseByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `notification_digest_entries` SET `due_at`=?,`held`=?")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := notificationDigestRepository.ReleaseByUserID(uuid.NewString(), time.Now())
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotReleaseDigest)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryDeleteByIDs(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	ids := []string{uuid.NewString(), uuid.NewString()}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `notification_digest_entries` WHERE id IN (?,?)")).
+		WithArgs(ids[0], ids[1]).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := notificationDigestRepository.DeleteByIDs(ids)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationDigestRepositoryDeleteByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationDigestRepository := NewNotificationDigestRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `notification_digest_entries` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := notificationDigestRepository.DeleteByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotDeleteDigest)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,103 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+)
+
+type NotificationPreferenceRepository struct {
+	db *gorm.DB
+}
+
+func NewNotificationPreferenceRepository(db *gorm.DB) *NotificationPreferenceRepository {
+	return &NotificationPreferenceRepository{
+		db,
+	}
+}
+
+func (r *NotificationPreferenceRepository) Create(preference *entities.NotificationPreference) error {
+	if err := r.db.Create(preference).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotCreatePreference
+	}
+
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) GetByID(id string) (*entities.NotificationPreference, error) {
+	preference := &entities.NotificationPreference{}
+
+	err := r.db.First(preference, "id = ?", id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrNotificationRepositoryPreferenceNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrNotificationRepositoryCanNotGetPreference
+	}
+
+	return preference, nil
+}
+
+// GetByUserID returns the preferences of the user, oldest first.
+func (r *NotificationPreferenceRepository) GetByUserID(userID string) ([]*entities.NotificationPreference, error) {
+	preferences := make([]*entities.NotificationPreference, 0)
+
+	err := r.db.Where("user_id = ?", userID).
+		Order("created_at asc").
+		Find(&preferences).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrNotificationRepositoryCanNotGetPreferences
+	}
+
+	return preferences, nil
+}
+
+func (r *NotificationPreferenceRepository) Update(preference *entities.NotificationPreference) error {
+	err := r.db.Model(&entities.NotificationPreference{}).
+		Where("id = ?", preference.ID).
+		Updates(map[string]interface{}{
+			"frequency":  preference.Frequency,
+			"updated_at": preference.UpdatedAt,
+		}).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotUpdatePreference
+	}
+
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) Delete(id string) error {
+	err := r.db.Where("id = ?", id).Delete(&entities.NotificationPreference{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotDeletePreference
+	}
+
+	return nil
+}
+
+func (r *NotificationPreferenceRepository) DeleteByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.NotificationPreference{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrNotificationRepositoryCanNotDeletePreferences
+	}
+
+	return nil
+}
@@ -0,0 +1,276 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestNotificationPreferenceRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	roomID := uuid.NewString()
+	preference := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		EventType: "BoxItemAddedEvent",
+		RoomID:    &roomID,
+		Frequency: entities.NotificationFrequencyDaily,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `notification_preferences` (`id`,`user_id`,`event_type`,`room_id`,`frequency`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(
+			preference.ID,
+			preference.UserID,
+			preference.EventType,
+			preference.RoomID,
+			preference.Frequency,
+			preference.CreatedAt,
+			preference.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := notificationPreferenceRepository.Create(preference)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	roomID := uuid.NewString()
+	preference := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		EventType: "BoxItemAddedEvent",
+		RoomID:    &roomID,
+		Frequency: entities.NotificationFrequencyDaily,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `notification_preferences`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := notificationPreferenceRepository.Create(preference)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotCreatePreference)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryGetByID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	roomID := uuid.NewString()
+	preference := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		EventType: "BoxItemAddedEvent",
+		RoomID:    &roomID,
+		Frequency: entities.NotificationFrequencyDaily,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `notification_preferences` WHERE id = ? ORDER BY `notification_preferences`.`id` LIMIT 1")).
+		WithArgs(preference.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "event_type", "room_id", "frequency", "created_at", "updated_at"}).AddRow(
+			preference.ID,
+			preference.UserID,
+			preference.EventType,
+			*preference.RoomID,
+			preference.Frequency,
+			preference.CreatedAt,
+			preference.UpdatedAt,
+		))
+
+	result, err := notificationPreferenceRepository.GetByID(preference.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, preference, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryGetByIDErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `notification_preferences` WHERE id = ? ORDER BY `notification_preferences`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := notificationPreferenceRepository.GetByID(id)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryPreferenceNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryGetByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	roomID := uuid.NewString()
+	preference := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		EventType: "BoxItemAddedEvent",
+		RoomID:    &roomID,
+		Frequency: entities.NotificationFrequencyDaily,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `notification_preferences` WHERE user_id = ? ORDER BY created_at asc")).
+		WithArgs(preference.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "event_type", "room_id", "frequency", "created_at", "updated_at"}).AddRow(
+			preference.ID,
+			preference.UserID,
+			preference.EventType,
+			*preference.RoomID,
+			preference.Frequency,
+			preference.CreatedAt,
+			preference.UpdatedAt,
+		))
+
+	preferences, err := notificationPreferenceRepository.GetByUserID(preference.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.NotificationPreference{preference}, preferences)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryGetByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `notification_preferences` WHERE user_id = ? ORDER BY created_at asc")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+
+	preferences, err := notificationPreferenceRepository.GetByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotGetPreferences)
+	assert.Nil(t, preferences)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	roomID := uuid.NewString()
+	preference := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		EventType: "BoxItemAddedEvent",
+		RoomID:    &roomID,
+		Frequency: entities.NotificationFrequencyDaily,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `notification_preferences` SET `frequency`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(preference.Frequency, preference.UpdatedAt, preference.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := notificationPreferenceRepository.Update(preference)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryUpdateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	roomID := uuid.NewString()
+	preference := &entities.NotificationPreference{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		EventType: "BoxItemAddedEvent",
+		RoomID:    &roomID,
+		Frequency: entities.NotificationFrequencyDaily,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `notification_preferences`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := notificationPreferenceRepository.Update(preference)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotUpdatePreference)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryDelete(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `notification_preferences` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := notificationPreferenceRepository.Delete(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestNotificationPreferenceRepositoryDeleteByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	notificationPreferenceRepository := NewNotificationPreferenceRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `notification_preferences` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := notificationPreferenceRepository.DeleteByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrNotificationRepositoryCanNotDeletePreferences)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
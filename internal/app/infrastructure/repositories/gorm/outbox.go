@@ -0,0 +1,168 @@
+package gorm
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{
+		db,
+	}
+}
+
+func (r *OutboxRepository) Create(event *entities.OutboxEvent) error {
+	if err := r.db.Create(event).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrOutboxRepositoryCanNotCreateEvent
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) GetAvailable(now time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	events := make([]*entities.OutboxEvent, 0)
+
+	err := r.db.Where("status = ? AND available_at <= ?", entities.OutboxEventStatusPending, now).
+		Order("available_at asc").
+		Limit(limit).
+		Find(&events).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrOutboxRepositoryCanNotGetEvents
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) Claim(event *entities.OutboxEvent, now time.Time, until time.Time) error {
+	result := r.db.Model(&entities.OutboxEvent{}).
+		Where("id = ? AND status = ? AND available_at <= ?", event.ID, entities.OutboxEventStatusPending, now).
+		Updates(map[string]interface{}{
+			"available_at": until,
+			"updated_at":   now,
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrOutboxRepositoryCanNotClaimEvent
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrOutboxRepositoryEventAlreadyClaimed
+	}
+
+	event.AvailableAt = until
+	event.UpdatedAt = now
+
+	return nil
+}
+
+func (r *OutboxRepository) Update(event *entities.OutboxEvent) error {
+	err := r.db.Model(&entities.OutboxEvent{}).
+		Where("id = ?", event.ID).
+		Updates(map[string]interface{}{
+			"status":       event.Status,
+			"attempts":     event.Attempts,
+			"last_error":   event.LastError,
+			"available_at": event.AvailableAt,
+			"delivered_at": event.DeliveredAt,
+			"updated_at":   event.UpdatedAt,
+		}).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrOutboxRepositoryCanNotUpdateEvent
+	}
+
+	return nil
+}
+
+func (r *OutboxRepository) GetByStatus(status string, limit int) ([]*entities.OutboxEvent, error) {
+	events := make([]*entities.OutboxEvent, 0)
+
+	err := r.db.Where("status = ?", status).
+		Order("created_at asc").
+		Limit(limit).
+		Find(&events).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		return nil, repositories.ErrOutboxRepositoryCanNotGetEvents
+	}
+
+	return events, nil
+}
+
+func (r *OutboxRepository) CountByStatus(status string) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&entities.OutboxEvent{}).
+		Where("status = ?", status).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		return 0, repositories.ErrOutboxRepositoryCanNotCountEvents
+	}
+
+	return count, nil
+}
+
+func (r *OutboxRepository) RequeueDead(now time.Time) (int64, error) {
+	result := r.db.Model(&entities.OutboxEvent{}).
+		Where("status = ?", entities.OutboxEventStatusDead).
+		Updates(map[string]interface{}{
+			"status":       entities.OutboxEventStatusPending,
+			"attempts":     0,
+			"available_at": now,
+			"updated_at":   now,
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return 0, repositories.ErrOutboxRepositoryCanNotRequeueEvents
+	}
+
+	return result.RowsAffected, nil
+}
+
+func (r *OutboxRepository) GetDeliveredHandlers(eventID string) ([]string, error) {
+	handlers := make([]string, 0)
+
+	err := r.db.Model(&entities.OutboxEventHandler{}).
+		Where("event_id = ?", eventID).
+		Pluck("handler", &handlers).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrOutboxRepositoryCanNotGetHandlers
+	}
+
+	return handlers, nil
+}
+
+func (r *OutboxRepository) CreateDeliveredHandler(handler *entities.OutboxEventHandler) error {
+	if err := r.db.Create(handler).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrOutboxRepositoryCanNotCreateHandler
+	}
+
+	return nil
+}
@@ -0,0 +1,473 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestOutboxRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox_events` (`id`,`type`,`payload`,`status`,`attempts`,`last_error`,`available_at`,`delivered_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(event.ID, event.Type, event.Payload, event.Status, event.Attempts, nil, event.AvailableAt, nil, event.CreatedAt, event.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := outboxRepository.Create(event)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox_events`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := outboxRepository.Create(event)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotCreateEvent)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGetAvailable(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_events` WHERE status = ? AND available_at <= ? ORDER BY available_at asc LIMIT 10")).
+		WithArgs(entities.OutboxEventStatusPending, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "payload", "status", "attempts", "last_error", "available_at", "delivered_at", "created_at", "updated_at"}).AddRow(
+			event.ID,
+			event.Type,
+			event.Payload,
+			event.Status,
+			event.Attempts,
+			nil,
+			event.AvailableAt,
+			nil,
+			event.CreatedAt,
+			event.UpdatedAt,
+		))
+
+	events, err := outboxRepository.GetAvailable(now, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.OutboxEvent{event}, events)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGetAvailableError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_events` WHERE status = ? AND available_at <= ? ORDER BY available_at asc LIMIT 10")).
+		WithArgs(entities.OutboxEventStatusPending, now).
+		WillReturnError(errors.New("database error"))
+
+	events, err := outboxRepository.GetAvailable(now, 10)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotGetEvents)
+	assert.Nil(t, events)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryClaim(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_events` SET `available_at`=?,`updated_at`=? WHERE id = ? AND status = ? AND available_at <= ?")).
+		WithArgs(until, now, event.ID, entities.OutboxEventStatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := outboxRepository.Claim(event, now, until)
+
+	assert.NoError(t, err)
+	assert.Equal(t, until, event.AvailableAt)
+	assert.Equal(t, now, event.UpdatedAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryClaimErrorAlreadyClaimed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	availableAt := event.AvailableAt
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_events` SET `available_at`=?,`updated_at`=? WHERE id = ? AND status = ? AND available_at <= ?")).
+		WithArgs(until, now, event.ID, entities.OutboxEventStatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := outboxRepository.Claim(event, now, until)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryEventAlreadyClaimed)
+	assert.Equal(t, availableAt, event.AvailableAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryClaimError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_events` SET `available_at`=?,`updated_at`=? WHERE id = ? AND status = ? AND available_at <= ?")).
+		WithArgs(until, now, event.ID, entities.OutboxEventStatusPending, now).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := outboxRepository.Claim(event, now, until)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotClaimEvent)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	event.MarkDelivered(time.Now())
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_events` SET `attempts`=?,`available_at`=?,`delivered_at`=?,`last_error`=?,`status`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(event.Attempts, event.AvailableAt, event.DeliveredAt, nil, event.Status, event.UpdatedAt, event.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := outboxRepository.Update(event)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryUpdateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_events`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := outboxRepository.Update(event)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotUpdateEvent)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGetByStatus(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusDead,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_events` WHERE status = ? ORDER BY created_at asc LIMIT 5")).
+		WithArgs(entities.OutboxEventStatusDead).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "type", "payload", "status", "attempts", "last_error", "available_at", "delivered_at", "created_at", "updated_at"}).AddRow(
+			event.ID,
+			event.Type,
+			event.Payload,
+			event.Status,
+			event.Attempts,
+			nil,
+			event.AvailableAt,
+			nil,
+			event.CreatedAt,
+			event.UpdatedAt,
+		))
+
+	events, err := outboxRepository.GetByStatus(entities.OutboxEventStatusDead, 5)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.OutboxEvent{event}, events)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGetByStatusError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `outbox_events` WHERE status = ? ORDER BY created_at asc LIMIT 5")).
+		WithArgs(entities.OutboxEventStatusDead).
+		WillReturnError(errors.New("database error"))
+
+	events, err := outboxRepository.GetByStatus(entities.OutboxEventStatusDead, 5)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotGetEvents)
+	assert.Nil(t, events)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryCountByStatus(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `outbox_events` WHERE status = ?")).
+		WithArgs(entities.OutboxEventStatusPending).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := outboxRepository.CountByStatus(entities.OutboxEventStatusPending)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryCountByStatusError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `outbox_events` WHERE status = ?")).
+		WithArgs(entities.OutboxEventStatusPending).
+		WillReturnError(errors.New("database error"))
+
+	count, err := outboxRepository.CountByStatus(entities.OutboxEventStatusPending)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotCountEvents)
+	assert.Equal(t, int64(0), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryRequeueDead(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	now := time.Now()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_events` SET `attempts`=?,`available_at`=?,`status`=?,`updated_at`=? WHERE status = ?")).
+		WithArgs(0, now, entities.OutboxEventStatusPending, now, entities.OutboxEventStatusDead).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	count, err := outboxRepository.RequeueDead(now)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(2), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryRequeueDeadError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	now := time.Now()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `outbox_events` SET `attempts`=?,`available_at`=?,`status`=?,`updated_at`=? WHERE status = ?")).
+		WithArgs(0, now, entities.OutboxEventStatusPending, now, entities.OutboxEventStatusDead).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	count, err := outboxRepository.RequeueDead(now)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotRequeueEvents)
+	assert.Equal(t, int64(0), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGetDeliveredHandlers(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	eventID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `handler` FROM `outbox_event_handlers` WHERE event_id = ?")).
+		WithArgs(eventID).
+		WillReturnRows(sqlmock.NewRows([]string{"handler"}).AddRow("deliver_webhooks"))
+
+	handlers, err := outboxRepository.GetDeliveredHandlers(eventID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"deliver_webhooks"}, handlers)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryGetDeliveredHandlersError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	eventID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `handler` FROM `outbox_event_handlers` WHERE event_id = ?")).
+		WithArgs(eventID).
+		WillReturnError(errors.New("database error"))
+
+	handlers, err := outboxRepository.GetDeliveredHandlers(eventID)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotGetHandlers)
+	assert.Nil(t, handlers)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryCreateDeliveredHandler(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	handler := &entities.OutboxEventHandler{
+		EventID:     uuid.NewString(),
+		Handler:     "deliver_webhooks",
+		DeliveredAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox_event_handlers` (`event_id`,`handler`,`delivered_at`) VALUES (?,?,?)")).
+		WithArgs(handler.EventID, handler.Handler, handler.DeliveredAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := outboxRepository.CreateDeliveredHandler(handler)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestOutboxRepositoryCreateDeliveredHandlerError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	outboxRepository := NewOutboxRepository(db)
+
+	handler := &entities.OutboxEventHandler{
+		EventID:     uuid.NewString(),
+		Handler:     "deliver_webhooks",
+		DeliveredAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox_event_handlers`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := outboxRepository.CreateDeliveredHandler(handler)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotCreateHandler)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
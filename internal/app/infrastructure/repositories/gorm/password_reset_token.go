@@ -0,0 +1,83 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PasswordResetTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPasswordResetTokenRepository(db *gorm.DB) *PasswordResetTokenRepository {
+	return &PasswordResetTokenRepository{
+		db,
+	}
+}
+
+func (r *PasswordResetTokenRepository) Create(token *entities.PasswordResetToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrPasswordResetTokenRepositoryCanNotCreateToken
+	}
+
+	return nil
+}
+
+func (r *PasswordResetTokenRepository) GetByTokenHash(tokenHash string) (*entities.PasswordResetToken, error) {
+	token := &entities.PasswordResetToken{}
+
+	err := r.db.First(token, "token_hash = ?", tokenHash).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrPasswordResetTokenRepositoryTokenNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrPasswordResetTokenRepositoryCanNotGetToken
+	}
+
+	return token, nil
+}
+
+// MarkAsUsed only updates a token that is still unused, so a token can not
+// reset the password twice even with concurrent requests.
+func (r *PasswordResetTokenRepository) MarkAsUsed(id string) error {
+	now := time.Now()
+	result := r.db.Model(&entities.PasswordResetToken{}).
+		Where("id = ? AND used_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"used_at":    now,
+			"updated_at": now,
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrPasswordResetTokenRepositoryCanNotUseToken
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrPasswordResetTokenRepositoryTokenAlreadyUsed
+	}
+
+	return nil
+}
+
+func (r *PasswordResetTokenRepository) DeleteByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.PasswordResetToken{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrPasswordResetTokenRepositoryCanNotDeleteUserTokens
+	}
+
+	return nil
+}
@@ -0,0 +1,187 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestPasswordResetTokenRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	token := &entities.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		TokenHash: entities.HashPasswordResetToken("value"),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `password_reset_tokens` (`id`,`user_id`,`token_hash`,`expires_at`,`used_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(token.ID, token.UserID, token.TokenHash, token.ExpiresAt, token.UsedAt, token.CreatedAt, token.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := passwordResetTokenRepository.Create(token)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetTokenRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	token := &entities.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		TokenHash: entities.HashPasswordResetToken("value"),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `password_reset_tokens`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := passwordResetTokenRepository.Create(token)
+
+	assert.ErrorIs(t, err, repositories.ErrPasswordResetTokenRepositoryCanNotCreateToken)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetTokenRepositoryGetByTokenHash(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	token := &entities.PasswordResetToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		TokenHash: entities.HashPasswordResetToken("value"),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "token_hash", "expires_at", "used_at", "created_at", "updated_at"}).
+		AddRow(token.ID, token.UserID, token.TokenHash, token.ExpiresAt, nil, token.CreatedAt, token.UpdatedAt)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `password_reset_tokens` WHERE token_hash = ? ORDER BY `password_reset_tokens`.`id` LIMIT 1")).
+		WithArgs(token.TokenHash).
+		WillReturnRows(rows)
+
+	result, err := passwordResetTokenRepository.GetByTokenHash(token.TokenHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, token, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetTokenRepositoryGetByTokenHashErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	tokenHash := entities.HashPasswordResetToken("value")
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `password_reset_tokens` WHERE token_hash = ? ORDER BY `password_reset_tokens`.`id` LIMIT 1")).
+		WithArgs(tokenHash).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := passwordResetTokenRepository.GetByTokenHash(tokenHash)
+
+	assert.ErrorIs(t, err, repositories.ErrPasswordResetTokenRepositoryTokenNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetTokenRepositoryMarkAsUsed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens` SET `updated_at`=?,`used_at`=? WHERE id = ? AND used_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := passwordResetTokenRepository.MarkAsUsed(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetTokenRepositoryMarkAsUsedErrorAlreadyUsed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `password_reset_tokens` SET `updated_at`=?,`used_at`=? WHERE id = ? AND used_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := passwordResetTokenRepository.MarkAsUsed(id)
+
+	assert.ErrorIs(t, err, repositories.ErrPasswordResetTokenRepositoryTokenAlreadyUsed)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetTokenRepositoryDeleteByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `password_reset_tokens` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := passwordResetTokenRepository.DeleteByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPasswordResetTokenRepositoryDeleteByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	passwordResetTokenRepository := NewPasswordResetTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `password_reset_tokens` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := passwordResetTokenRepository.DeleteByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrPasswordResetTokenRepositoryCanNotDeleteUserTokens)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
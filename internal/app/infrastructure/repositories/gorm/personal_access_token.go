@@ -0,0 +1,139 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type PersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewPersonalAccessTokenRepository(db *gorm.DB) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{
+		db,
+	}
+}
+
+func (r *PersonalAccessTokenRepository) Create(token *entities.PersonalAccessToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrPersonalAccessTokenRepositoryCanNotCreatePersonalAccessToken
+	}
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) GetByID(id string) (*entities.PersonalAccessToken, error) {
+	return r.getBy("id = ?", id)
+}
+
+func (r *PersonalAccessTokenRepository) GetByTokenHash(tokenHash string) (*entities.PersonalAccessToken, error) {
+	return r.getBy("token_hash = ?", tokenHash)
+}
+
+func (r *PersonalAccessTokenRepository) getBy(query string, value string) (*entities.PersonalAccessToken, error) {
+	token := &entities.PersonalAccessToken{}
+
+	err := r.db.First(token, query, value).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrPersonalAccessTokenRepositoryPersonalAccessTokenNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrPersonalAccessTokenRepositoryCanNotGetPersonalAccessToken
+	}
+
+	return token, nil
+}
+
+// GetByUserID returns the tokens of the user that are not revoked, newest first.
+func (r *PersonalAccessTokenRepository) GetByUserID(userID string) ([]*entities.PersonalAccessToken, error) {
+	tokens := make([]*entities.PersonalAccessToken, 0)
+
+	err := r.db.Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("created_at desc").
+		Find(&tokens).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrPersonalAccessTokenRepositoryCanNotGetPersonalAccessTokens
+	}
+
+	return tokens, nil
+}
+
+func (r *PersonalAccessTokenRepository) Revoke(id string) error {
+	now := time.Now()
+	err := r.db.Model(&entities.PersonalAccessToken{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrPersonalAccessTokenRepositoryCanNotRevokePersonalAccessToken
+	}
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) RevokeByUserID(userID string) error {
+	now := time.Now()
+	err := r.db.Model(&entities.PersonalAccessToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrPersonalAccessTokenRepositoryCanNotRevokePersonalAccessToken
+	}
+
+	return nil
+}
+
+// UpdateLastUsedAt does not touch updated_at, using a token is not a change
+// of the token itself.
+func (r *PersonalAccessTokenRepository) UpdateLastUsedAt(id string, lastUsedAt time.Time) error {
+	err := r.db.Model(&entities.PersonalAccessToken{}).
+		Where("id = ?", id).
+		UpdateColumn("last_used_at", lastUsedAt).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrPersonalAccessTokenRepositoryCanNotUpdateLastUsedAt
+	}
+
+	return nil
+}
+
+func (r *PersonalAccessTokenRepository) DeleteByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.PersonalAccessToken{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrPersonalAccessTokenRepositoryCanNotDeletePersonalAccessTokens
+	}
+
+	return nil
+}
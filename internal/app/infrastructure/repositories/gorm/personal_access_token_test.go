@@ -0,0 +1,348 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestPersonalAccessTokenRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	token := &entities.PersonalAccessToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		Name:      "home assistant",
+		Scope:     entities.PersonalAccessTokenScopeRead,
+		TokenHash: entities.HashPersonalAccessToken("hi_pat_value"),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `personal_access_tokens` (`id`,`user_id`,`name`,`scope`,`token_hash`,`expires_at`,`last_used_at`,`revoked_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			token.ID,
+			token.UserID,
+			token.Name,
+			token.Scope,
+			token.TokenHash,
+			token.ExpiresAt,
+			token.LastUsedAt,
+			token.RevokedAt,
+			token.CreatedAt,
+			token.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := personalAccessTokenRepository.Create(token)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	token := &entities.PersonalAccessToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		Name:      "home assistant",
+		Scope:     entities.PersonalAccessTokenScopeRead,
+		TokenHash: entities.HashPersonalAccessToken("hi_pat_value"),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `personal_access_tokens`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := personalAccessTokenRepository.Create(token)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryCanNotCreatePersonalAccessToken)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryGetByTokenHash(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	token := &entities.PersonalAccessToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		Name:      "home assistant",
+		Scope:     entities.PersonalAccessTokenScopeReadWrite,
+		TokenHash: entities.HashPersonalAccessToken("hi_pat_value"),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "scope", "token_hash", "expires_at", "last_used_at", "revoked_at", "created_at", "updated_at"}).
+		AddRow(
+			token.ID,
+			token.UserID,
+			token.Name,
+			token.Scope,
+			token.TokenHash,
+			nil,
+			nil,
+			nil,
+			token.CreatedAt,
+			token.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE token_hash = ? ORDER BY `personal_access_tokens`.`id` LIMIT 1")).
+		WithArgs(token.TokenHash).
+		WillReturnRows(rows)
+
+	result, err := personalAccessTokenRepository.GetByTokenHash(token.TokenHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, token, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryGetByIDErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE id = ? ORDER BY `personal_access_tokens`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := personalAccessTokenRepository.GetByID(id)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryPersonalAccessTokenNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryGetByIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE id = ? ORDER BY `personal_access_tokens`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+
+	result, err := personalAccessTokenRepository.GetByID(id)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryCanNotGetPersonalAccessToken)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryGetByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	userID := uuid.NewString()
+	token := &entities.PersonalAccessToken{
+		ID:        uuid.NewString(),
+		UserID:    userID,
+		Name:      "home assistant",
+		Scope:     entities.PersonalAccessTokenScopeRead,
+		TokenHash: entities.HashPersonalAccessToken("hi_pat_value"),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "name", "scope", "token_hash", "expires_at", "last_used_at", "revoked_at", "created_at", "updated_at"}).
+		AddRow(
+			token.ID,
+			token.UserID,
+			token.Name,
+			token.Scope,
+			token.TokenHash,
+			nil,
+			nil,
+			nil,
+			token.CreatedAt,
+			token.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE user_id = ? AND revoked_at IS NULL ORDER BY created_at desc")).
+		WithArgs(userID).
+		WillReturnRows(rows)
+
+	tokens, err := personalAccessTokenRepository.GetByUserID(userID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.PersonalAccessToken{token}, tokens)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryGetByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `personal_access_tokens` WHERE user_id = ? AND revoked_at IS NULL ORDER BY created_at desc")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+
+	tokens, err := personalAccessTokenRepository.GetByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryCanNotGetPersonalAccessTokens)
+	assert.Nil(t, tokens)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryRevoke(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := personalAccessTokenRepository.Revoke(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryRevokeError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `revoked_at`=?,`updated_at`=? WHERE id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := personalAccessTokenRepository.Revoke(id)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryCanNotRevokePersonalAccessToken)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryRevokeByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := personalAccessTokenRepository.RevokeByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryUpdateLastUsedAt(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	id := uuid.NewString()
+	lastUsedAt := time.Now()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `last_used_at`=? WHERE id = ?")).
+		WithArgs(lastUsedAt, id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := personalAccessTokenRepository.UpdateLastUsedAt(id, lastUsedAt)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryUpdateLastUsedAtError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	id := uuid.NewString()
+	lastUsedAt := time.Now()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `personal_access_tokens` SET `last_used_at`=? WHERE id = ?")).
+		WithArgs(lastUsedAt, id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := personalAccessTokenRepository.UpdateLastUsedAt(id, lastUsedAt)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryCanNotUpdateLastUsedAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryDeleteByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `personal_access_tokens` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := personalAccessTokenRepository.DeleteByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestPersonalAccessTokenRepositoryDeleteByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	personalAccessTokenRepository := NewPersonalAccessTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `personal_access_tokens` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := personalAccessTokenRepository.DeleteByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrPersonalAccessTokenRepositoryCanNotDeletePersonalAccessTokens)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,158 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type RefreshTokenRepository struct {
+	db *gorm.DB
+}
+
+func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
+	return &RefreshTokenRepository{
+		db,
+	}
+}
+
+func (r *RefreshTokenRepository) Create(token *entities.RefreshToken) error {
+	if err := r.db.Create(token).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrRefreshTokenRepositoryCanNotCreateRefreshToken
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) GetByTokenHash(tokenHash string) (*entities.RefreshToken, error) {
+	token := &entities.RefreshToken{}
+
+	err := r.db.First(token, "token_hash = ?", tokenHash).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrRefreshTokenRepositoryRefreshTokenNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrRefreshTokenRepositoryCanNotGetRefreshToken
+	}
+
+	return token, nil
+}
+
+// MarkAsUsed only updates a token that is still unused, so two concurrent
+// refreshes with the same token can not both succeed.
+func (r *RefreshTokenRepository) MarkAsUsed(id string) error {
+	now := time.Now()
+	result := r.db.Model(&entities.RefreshToken{}).
+		Where("id = ? AND used_at IS NULL AND revoked_at IS NULL", id).
+		Updates(map[string]interface{}{
+			"used_at":    now,
+			"updated_at": now,
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrRefreshTokenRepositoryCanNotUseRefreshToken
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrRefreshTokenRepositoryRefreshTokenAlreadyUsed
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeFamily(familyID string) error {
+	now := time.Now()
+	err := r.db.Model(&entities.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NULL", familyID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrRefreshTokenRepositoryCanNotRevokeFamily
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) RevokeByUserID(userID string) error {
+	now := time.Now()
+	err := r.db.Model(&entities.RefreshToken{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrRefreshTokenRepositoryCanNotRevokeUserTokens
+	}
+
+	return nil
+}
+
+// RevokeByUserIDExceptFamily ends every session of the user but the one of
+// the family, which is usually the session making the request.
+func (r *RefreshTokenRepository) RevokeByUserIDExceptFamily(userID string, familyID string) error {
+	now := time.Now()
+	err := r.db.Model(&entities.RefreshToken{}).
+		Where("user_id = ? AND family_id <> ? AND revoked_at IS NULL", userID, familyID).
+		Updates(map[string]interface{}{
+			"revoked_at": now,
+			"updated_at": now,
+		}).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrRefreshTokenRepositoryCanNotRevokeUserTokens
+	}
+
+	return nil
+}
+
+func (r *RefreshTokenRepository) IsFamilyRevoked(familyID string) (bool, error) {
+	var count int64
+	err := r.db.Model(&entities.RefreshToken{}).
+		Where("family_id = ? AND revoked_at IS NOT NULL", familyID).
+		Count(&count).
+		Error
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return false, repositories.ErrRefreshTokenRepositoryCanNotCheckFamily
+	}
+
+	return count > 0, nil
+}
+
+func (r *RefreshTokenRepository) DeleteByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.RefreshToken{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrRefreshTokenRepositoryCanNotDeleteUserTokens
+	}
+
+	return nil
+}
@@ -0,0 +1,323 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestRefreshTokenRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	token := &entities.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		FamilyID:  uuid.NewString(),
+		TokenHash: entities.HashRefreshToken("value"),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `refresh_tokens` (`id`,`user_id`,`family_id`,`token_hash`,`expires_at`,`used_at`,`revoked_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			token.ID,
+			token.UserID,
+			token.FamilyID,
+			token.TokenHash,
+			token.ExpiresAt,
+			token.UsedAt,
+			token.RevokedAt,
+			token.CreatedAt,
+			token.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := refreshTokenRepository.Create(token)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	token := &entities.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		FamilyID:  uuid.NewString(),
+		TokenHash: entities.HashRefreshToken("value"),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `refresh_tokens`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := refreshTokenRepository.Create(token)
+
+	assert.ErrorIs(t, err, repositories.ErrRefreshTokenRepositoryCanNotCreateRefreshToken)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryGetByTokenHash(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	token := &entities.RefreshToken{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		FamilyID:  uuid.NewString(),
+		TokenHash: entities.HashRefreshToken("value"),
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "family_id", "token_hash", "expires_at", "used_at", "revoked_at", "created_at", "updated_at"}).
+		AddRow(
+			token.ID,
+			token.UserID,
+			token.FamilyID,
+			token.TokenHash,
+			token.ExpiresAt,
+			nil,
+			nil,
+			token.CreatedAt,
+			token.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `refresh_tokens` WHERE token_hash = ? ORDER BY `refresh_tokens`.`id` LIMIT 1")).
+		WithArgs(token.TokenHash).
+		WillReturnRows(rows)
+
+	result, err := refreshTokenRepository.GetByTokenHash(token.TokenHash)
+
+	assert.NoError(t, err)
+	assert.Equal(t, token, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryGetByTokenHashErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	tokenHash := entities.HashRefreshToken("value")
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `refresh_tokens` WHERE token_hash = ? ORDER BY `refresh_tokens`.`id` LIMIT 1")).
+		WithArgs(tokenHash).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := refreshTokenRepository.GetByTokenHash(tokenHash)
+
+	assert.ErrorIs(t, err, repositories.ErrRefreshTokenRepositoryRefreshTokenNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryMarkAsUsed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `updated_at`=?,`used_at`=? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := refreshTokenRepository.MarkAsUsed(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryMarkAsUsedErrorAlreadyUsed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `updated_at`=?,`used_at`=? WHERE id = ? AND used_at IS NULL AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), id).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := refreshTokenRepository.MarkAsUsed(id)
+
+	assert.ErrorIs(t, err, repositories.ErrRefreshTokenRepositoryRefreshTokenAlreadyUsed)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryRevokeFamily(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	familyID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE family_id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), familyID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := refreshTokenRepository.RevokeFamily(familyID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryRevokeFamilyError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	familyID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE family_id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), familyID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := refreshTokenRepository.RevokeFamily(familyID)
+
+	assert.ErrorIs(t, err, repositories.ErrRefreshTokenRepositoryCanNotRevokeFamily)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryRevokeByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	dbMock.ExpectCommit()
+
+	err := refreshTokenRepository.RevokeByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryRevokeByUserIDExceptFamily(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	userID := uuid.NewString()
+	familyID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `refresh_tokens` SET `revoked_at`=?,`updated_at`=? WHERE user_id = ? AND family_id <> ? AND revoked_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, familyID).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := refreshTokenRepository.RevokeByUserIDExceptFamily(userID, familyID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryIsFamilyRevoked(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	familyID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `refresh_tokens` WHERE family_id = ? AND revoked_at IS NOT NULL")).
+		WithArgs(familyID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(1))
+
+	revoked, err := refreshTokenRepository.IsFamilyRevoked(familyID)
+
+	assert.NoError(t, err)
+	assert.True(t, revoked)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryIsFamilyRevokedError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	familyID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `refresh_tokens` WHERE family_id = ? AND revoked_at IS NOT NULL")).
+		WithArgs(familyID).
+		WillReturnError(errors.New("database error"))
+
+	revoked, err := refreshTokenRepository.IsFamilyRevoked(familyID)
+
+	assert.ErrorIs(t, err, repositories.ErrRefreshTokenRepositoryCanNotCheckFamily)
+	assert.False(t, revoked)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryDeleteByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `refresh_tokens` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := refreshTokenRepository.DeleteByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestRefreshTokenRepositoryDeleteByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	refreshTokenRepository := NewRefreshTokenRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `refresh_tokens` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := refreshTokenRepository.DeleteByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrRefreshTokenRepositoryCanNotDeleteUserTokens)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
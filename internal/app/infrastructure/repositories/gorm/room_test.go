@@ -22,13 +22,13 @@ func TestRoomRepositoryCreateRoom(t *testing.T) {
 		ID:          uuid.NewString(),
 		Name:        random.String(100, random.Alphanumeric),
 		Description: &description,
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `rooms` (`id`,`name`,`description`,`user_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).
-		WithArgs(room.ID, room.Name, *room.Description, room.UserID, room.CreatedAt, room.UpdatedAt).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `rooms` (`id`,`name`,`description`,`household_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(room.ID, room.Name, *room.Description, room.HouseholdID, room.CreatedAt, room.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	dbMock.ExpectCommit()
 
@@ -48,13 +48,13 @@ func TestRoomRepositoryCreateRoomErrorCanNotCreateRoom(t *testing.T) {
 		ID:          uuid.NewString(),
 		Name:        random.String(100, random.Alphanumeric),
 		Description: &description,
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `rooms` (`id`,`name`,`description`,`user_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).
-		WithArgs(room.ID, room.Name, *room.Description, room.UserID, room.CreatedAt, room.UpdatedAt).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `rooms` (`id`,`name`,`description`,`household_id`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(room.ID, room.Name, *room.Description, room.HouseholdID, room.CreatedAt, room.UpdatedAt).
 		WillReturnError(errors.New("database error"))
 	dbMock.ExpectRollback()
 
@@ -110,14 +110,14 @@ func TestRoomRepositoryGetByQueryFilters(t *testing.T) {
 	roomID := uuid.NewString()
 	roomName := random.String(100, random.Alphanumeric)
 	roomDescription := random.String(255, random.Alphanumeric)
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	createdAt := time.Now()
 	updatedAt := time.Now()
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `rooms` WHERE (name LIKE ? OR description LIKE ?) AND user_id = ? LIMIT 1 OFFSET 1")).
-		WithArgs("%search%", "%search%", userID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "user_id", "created_at", "updated_at"}).
-			AddRow(roomID, roomName, roomDescription, userID, createdAt, updatedAt))
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `rooms` WHERE (name LIKE ? OR description LIKE ?) AND household_id = ? LIMIT 1 OFFSET 1")).
+		WithArgs("%search%", "%search%", householdID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "household_id", "created_at", "updated_at"}).
+			AddRow(roomID, roomName, roomDescription, householdID, createdAt, updatedAt))
 
 	queryFilter := repositories.QueryFilter{
 		ConditionGroups: []repositories.ConditionGroup{
@@ -140,9 +140,9 @@ func TestRoomRepositoryGetByQueryFilters(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    entities.RoomUserIDField,
+						Field:    entities.RoomHouseholdIDField,
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
@@ -159,7 +159,7 @@ func TestRoomRepositoryGetByQueryFilters(t *testing.T) {
 	assert.Equal(t, roomID, rooms[0].ID)
 	assert.Equal(t, roomName, rooms[0].Name)
 	assert.Equal(t, roomDescription, *rooms[0].Description)
-	assert.Equal(t, userID, rooms[0].UserID)
+	assert.Equal(t, householdID, rooms[0].HouseholdID)
 	assert.Equal(t, createdAt, rooms[0].CreatedAt)
 	assert.Equal(t, updatedAt, rooms[0].UpdatedAt)
 	err = dbMock.ExpectationsWereMet()
@@ -170,10 +170,10 @@ func TestRoomRepositoryGetByQueryFiltersErrorCanNotGetRooms(t *testing.T) {
 	db, dbMock := makeDBMock()
 	roomRepository := NewRoomRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `rooms` WHERE user_id = ? LIMIT 1 OFFSET 1")).
-		WithArgs(userID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `rooms` WHERE household_id = ? LIMIT 1 OFFSET 1")).
+		WithArgs(householdID).
 		WillReturnError(errors.New("database error"))
 
 	queryFilter := repositories.QueryFilter{
@@ -182,9 +182,9 @@ func TestRoomRepositoryGetByQueryFiltersErrorCanNotGetRooms(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    entities.RoomUserIDField,
+						Field:    entities.RoomHouseholdIDField,
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
@@ -207,10 +207,10 @@ func TestRoomRepositoryCountByQueryFilters(t *testing.T) {
 	db, dbMock := makeDBMock()
 	roomRepository := NewRoomRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `rooms` WHERE user_id = ?")).
-		WithArgs(userID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `rooms` WHERE household_id = ?")).
+		WithArgs(householdID).
 		WillReturnRows(sqlmock.NewRows([]string{"count(*)"}).AddRow(1))
 
 	queryFilter := repositories.QueryFilter{
@@ -219,9 +219,9 @@ func TestRoomRepositoryCountByQueryFilters(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    entities.RoomUserIDField,
+						Field:    entities.RoomHouseholdIDField,
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
@@ -239,10 +239,10 @@ func TestRoomRepositoryCountByQueryFiltersErrorCanNotCountRooms(t *testing.T) {
 	db, dbMock := makeDBMock()
 	roomRepository := NewRoomRepository(db)
 
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `rooms` WHERE user_id = ?")).
-		WithArgs(userID).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `rooms` WHERE household_id = ?")).
+		WithArgs(householdID).
 		WillReturnError(errors.New("database error"))
 
 	queryFilter := repositories.QueryFilter{
@@ -251,9 +251,9 @@ func TestRoomRepositoryCountByQueryFiltersErrorCanNotCountRooms(t *testing.T) {
 				Operator: repositories.AndLogicalOperator,
 				Conditions: []repositories.Condition{
 					{
-						Field:    entities.RoomUserIDField,
+						Field:    entities.RoomHouseholdIDField,
 						Operator: repositories.EqualComparisonOperator,
-						Value:    userID,
+						Value:    householdID,
 					},
 				},
 			},
@@ -314,14 +314,14 @@ func TestRoomRepositoryGetByID(t *testing.T) {
 	roomID := uuid.NewString()
 	roomName := random.String(100, random.Alphanumeric)
 	roomDescription := random.String(255, random.Alphanumeric)
-	userID := uuid.NewString()
+	householdID := uuid.NewString()
 	createdAt := time.Now()
 	updatedAt := time.Now()
 
 	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `rooms` WHERE id = ?")).
 		WithArgs(roomID).
-		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "user_id", "created_at", "updated_at"}).
-			AddRow(roomID, roomName, roomDescription, userID, createdAt, updatedAt))
+		WillReturnRows(sqlmock.NewRows([]string{"id", "name", "description", "household_id", "created_at", "updated_at"}).
+			AddRow(roomID, roomName, roomDescription, householdID, createdAt, updatedAt))
 
 	room, err := roomRepository.GetByID(roomID)
 
@@ -329,7 +329,7 @@ func TestRoomRepositoryGetByID(t *testing.T) {
 	assert.Equal(t, roomID, room.ID)
 	assert.Equal(t, roomName, room.Name)
 	assert.Equal(t, roomDescription, *room.Description)
-	assert.Equal(t, userID, room.UserID)
+	assert.Equal(t, householdID, room.HouseholdID)
 	assert.Equal(t, createdAt, room.CreatedAt)
 	assert.Equal(t, updatedAt, room.UpdatedAt)
 	err = dbMock.ExpectationsWereMet()
@@ -363,14 +363,14 @@ func TestRoomRepositoryUpdate(t *testing.T) {
 		ID:          uuid.NewString(),
 		Name:        random.String(100, random.Alphanumeric),
 		Description: nil,
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `rooms` SET `name`=?,`description`=?,`user_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
-		WithArgs(room.Name, nil, room.UserID, room.CreatedAt, sqlmock.AnyArg(), room.ID).
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `rooms` SET `name`=?,`description`=?,`household_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(room.Name, nil, room.HouseholdID, room.CreatedAt, sqlmock.AnyArg(), room.ID).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	dbMock.ExpectCommit()
 
@@ -389,14 +389,14 @@ func TestRoomRepositoryUpdateErrorCanNotUpdateRoom(t *testing.T) {
 		ID:          uuid.NewString(),
 		Name:        random.String(100, random.Alphanumeric),
 		Description: nil,
-		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
 		CreatedAt:   time.Now(),
 		UpdatedAt:   time.Now(),
 	}
 
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `rooms` SET `name`=?,`description`=?,`user_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
-		WithArgs(room.Name, nil, room.UserID, room.CreatedAt, sqlmock.AnyArg(), room.ID).
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `rooms` SET `name`=?,`description`=?,`household_id`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(room.Name, nil, room.HouseholdID, room.CreatedAt, sqlmock.AnyArg(), room.ID).
 		WillReturnError(errors.New("database error"))
 	dbMock.ExpectRollback()
 
@@ -0,0 +1,113 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/go-sql-driver/mysql"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+const (
+	mysqlErrDuplicateEntry = 1062
+	mysqlErrLockDeadlock   = 1213
+)
+
+type StoredFileRepository struct {
+	db *gorm.DB
+}
+
+func NewStoredFileRepository(db *gorm.DB) *StoredFileRepository {
+	return &StoredFileRepository{
+		db,
+	}
+}
+
+func (r *StoredFileRepository) Create(file *entities.StoredFile) error {
+	err := r.db.Create(file).Error
+	if isStoredFileConflict(err) {
+		return repositories.ErrStoredFileRepositoryFileConflict
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrStoredFileRepositoryCanNotCreateFile
+	}
+
+	return nil
+}
+
+func (r *StoredFileRepository) GetByHashForUpdate(hash string, extension string) (*entities.StoredFile, error) {
+	return r.getForUpdate("hash = ? AND extension = ?", hash, extension)
+}
+
+func (r *StoredFileRepository) GetByIDForUpdate(id string) (*entities.StoredFile, error) {
+	return r.getForUpdate("id = ?", id)
+}
+
+func (r *StoredFileRepository) Update(file *entities.StoredFile) error {
+	err := r.db.Model(&entities.StoredFile{}).
+		Where("id = ?", file.ID).
+		Updates(map[string]interface{}{
+			"reference_count": file.ReferenceCount,
+			"updated_at":      file.UpdatedAt,
+		}).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrStoredFileRepositoryCanNotUpdateFile
+	}
+
+	return nil
+}
+
+func (r *StoredFileRepository) Delete(id string) error {
+	if err := r.db.Where("id = ?", id).Delete(&entities.StoredFile{}).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrStoredFileRepositoryCanNotDeleteFile
+	}
+
+	return nil
+}
+
+func (r *StoredFileRepository) getForUpdate(query string, args ...interface{}) (*entities.StoredFile, error) {
+	file := &entities.StoredFile{}
+
+	err := r.db.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Where(query, args...).
+		First(file).
+		Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrStoredFileRepositoryFileNotFound
+	}
+
+	if isStoredFileConflict(err) {
+		return nil, repositories.ErrStoredFileRepositoryFileConflict
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrStoredFileRepositoryCanNotGetFile
+	}
+
+	return file, nil
+}
+
+// isStoredFileConflict tells whether another transaction inserted the same
+// content first, or MySQL rolled the transaction back to break a deadlock
+// between two of them.
+func isStoredFileConflict(err error) bool {
+	var mysqlErr *mysql.MySQLError
+	if !errors.As(err, &mysqlErr) {
+		return false
+	}
+
+	return mysqlErr.Number == mysqlErrDuplicateEntry || mysqlErr.Number == mysqlErrLockDeadlock
+}
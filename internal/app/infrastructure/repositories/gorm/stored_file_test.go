@@ -0,0 +1,246 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/go-sql-driver/mysql"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+)
+
+func TestStoredFileRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	file := entities.NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `stored_files` (`id`,`extension`,`hash`,`reference_count`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?)")).
+		WithArgs(file.ID, file.Extension, file.Hash, file.ReferenceCount, file.CreatedAt, file.UpdatedAt).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := storedFileRepository.Create(file)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	file := entities.NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `stored_files`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := storedFileRepository.Create(file)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotCreateFile)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryCreateErrorConflict(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	file := entities.NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `stored_files`")).
+		WillReturnError(&mysql.MySQLError{Number: 1062, Message: "Duplicate entry"})
+	dbMock.ExpectRollback()
+
+	err := storedFileRepository.Create(file)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryFileConflict)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryGetByHashForUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	file := entities.NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `stored_files` WHERE hash = ? AND extension = ? ORDER BY `stored_files`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(file.Hash, file.Extension).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "extension", "hash", "reference_count", "created_at", "updated_at"}).AddRow(
+			file.ID,
+			file.Extension,
+			file.Hash,
+			file.ReferenceCount,
+			file.CreatedAt,
+			file.UpdatedAt,
+		))
+
+	result, err := storedFileRepository.GetByHashForUpdate(file.Hash, file.Extension)
+
+	assert.NoError(t, err)
+	assert.Equal(t, file, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryGetByHashForUpdateErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `stored_files` WHERE hash = ? AND extension = ? ORDER BY `stored_files`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs("hash", ".png").
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := storedFileRepository.GetByHashForUpdate("hash", ".png")
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryFileNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryGetByIDForUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	file := entities.NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `stored_files` WHERE id = ? ORDER BY `stored_files`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(file.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "extension", "hash", "reference_count", "created_at", "updated_at"}).AddRow(
+			file.ID,
+			file.Extension,
+			file.Hash,
+			file.ReferenceCount,
+			file.CreatedAt,
+			file.UpdatedAt,
+		))
+
+	result, err := storedFileRepository.GetByIDForUpdate(file.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, file, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryGetByIDForUpdateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `stored_files` WHERE id = ? ORDER BY `stored_files`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+
+	result, err := storedFileRepository.GetByIDForUpdate(id)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotGetFile)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryGetByIDForUpdateErrorDeadlock(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `stored_files` WHERE id = ? ORDER BY `stored_files`.`id` LIMIT 1 FOR UPDATE")).
+		WithArgs(id).
+		WillReturnError(&mysql.MySQLError{Number: 1213, Message: "Deadlock found when trying to get lock"})
+
+	result, err := storedFileRepository.GetByIDForUpdate(id)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryFileConflict)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	file := entities.NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `stored_files` SET `reference_count`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(file.ReferenceCount, file.UpdatedAt, file.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := storedFileRepository.Update(file)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryUpdateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	file := entities.NewStoredFile(uuid.NewString(), ".png", "hash")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `stored_files`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := storedFileRepository.Update(file)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotUpdateFile)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryDelete(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `stored_files` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := storedFileRepository.Delete(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestStoredFileRepositoryDeleteError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	storedFileRepository := NewStoredFileRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `stored_files` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := storedFileRepository.Delete(id)
+
+	assert.ErrorIs(t, err, repositories.ErrStoredFileRepositoryCanNotDeleteFile)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -0,0 +1,75 @@
+package gorm
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+)
+
+type TransactionManager struct {
+	db *gorm.DB
+}
+
+func NewTransactionManager(db *gorm.DB) *TransactionManager {
+	return &TransactionManager{
+		db,
+	}
+}
+
+func (m *TransactionManager) Transaction(fn func(tx repositories.Transaction) error) error {
+	db := m.db.Begin()
+	if db.Error != nil {
+		logger.LogError(db.Error)
+		notifier.NotifyError(db.Error)
+		return repositories.ErrTransactionManagerCanNotBegin
+	}
+
+	defer func() {
+		if r := recover(); r != nil {
+			db.Rollback()
+			panic(r)
+		}
+	}()
+
+	if err := fn(&Transaction{db}); err != nil {
+		if rollbackErr := db.Rollback().Error; rollbackErr != nil {
+			logger.LogError(rollbackErr)
+		}
+		return err
+	}
+
+	if err := db.Commit().Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrTransactionManagerCanNotCommit
+	}
+
+	return nil
+}
+
+// Transaction builds its repositories on the transaction, so everything they
+// write is committed by the TransactionManager that created it.
+type Transaction struct {
+	db *gorm.DB
+}
+
+func (t *Transaction) RoomRepository() repositories.RoomRepository {
+	return NewRoomRepository(t.db)
+}
+
+func (t *Transaction) BoxRepository() repositories.BoxRepository {
+	return NewBoxRepository(t.db)
+}
+
+func (t *Transaction) OutboxRepository() repositories.OutboxRepository {
+	return NewOutboxRepository(t.db)
+}
+
+func (t *Transaction) AssetRepository() repositories.AssetRepository {
+	return NewAssetRepository(t.db)
+}
+
+func (t *Transaction) StoredFileRepository() repositories.StoredFileRepository {
+	return NewStoredFileRepository(t.db)
+}
@@ -0,0 +1,104 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestTransactionManagerTransaction(t *testing.T) {
+	db, dbMock := makeDBMock()
+	transactionManager := NewTransactionManager(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox_events`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := transactionManager.Transaction(func(tx repositories.Transaction) error {
+		return tx.OutboxRepository().Create(event)
+	})
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTransactionManagerTransactionRollsBack(t *testing.T) {
+	db, dbMock := makeDBMock()
+	transactionManager := NewTransactionManager(db)
+
+	event := &entities.OutboxEvent{
+		ID:          uuid.NewString(),
+		Type:        "BoxItemAddedEvent",
+		Payload:     `{"Quantity":1}`,
+		Status:      entities.OutboxEventStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	fnErr := errors.New("fn error")
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `outbox_events`")).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectRollback()
+
+	err := transactionManager.Transaction(func(tx repositories.Transaction) error {
+		if err := tx.OutboxRepository().Create(event); err != nil {
+			return err
+		}
+		return fnErr
+	})
+
+	assert.ErrorIs(t, err, fnErr)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTransactionManagerTransactionErrorCanNotBegin(t *testing.T) {
+	db, dbMock := makeDBMock()
+	transactionManager := NewTransactionManager(db)
+
+	dbMock.ExpectBegin().WillReturnError(errors.New("database error"))
+
+	err := transactionManager.Transaction(func(tx repositories.Transaction) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, repositories.ErrTransactionManagerCanNotBegin)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTransactionManagerTransactionErrorCanNotCommit(t *testing.T) {
+	db, dbMock := makeDBMock()
+	transactionManager := NewTransactionManager(db)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectCommit().WillReturnError(errors.New("database error"))
+
+	err := transactionManager.Transaction(func(tx repositories.Transaction) error {
+		return nil
+	})
+
+	assert.ErrorIs(t, err, repositories.ErrTransactionManagerCanNotCommit)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
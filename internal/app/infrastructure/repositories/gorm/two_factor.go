@@ -0,0 +1,137 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type TwoFactorRepository struct {
+	db *gorm.DB
+}
+
+func NewTwoFactorRepository(db *gorm.DB) *TwoFactorRepository {
+	return &TwoFactorRepository{
+		db,
+	}
+}
+
+func (r *TwoFactorRepository) Create(twoFactor *entities.TwoFactor) error {
+	if err := r.db.Create(twoFactor).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrTwoFactorRepositoryCanNotCreateTwoFactor
+	}
+
+	return nil
+}
+
+func (r *TwoFactorRepository) GetByUserID(userID string) (*entities.TwoFactor, error) {
+	twoFactor := &entities.TwoFactor{}
+
+	err := r.db.First(twoFactor, "user_id = ?", userID).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrTwoFactorRepositoryTwoFactorNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrTwoFactorRepositoryCanNotGetTwoFactor
+	}
+
+	return twoFactor, nil
+}
+
+func (r *TwoFactorRepository) Update(twoFactor *entities.TwoFactor) error {
+	if err := r.db.Save(twoFactor).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrTwoFactorRepositoryCanNotUpdateTwoFactor
+	}
+
+	return nil
+}
+
+// UseStep only moves the last used step forward, so the same code sent in
+// two concurrent requests is accepted once.
+func (r *TwoFactorRepository) UseStep(id string, step int64) error {
+	result := r.db.Model(&entities.TwoFactor{}).
+		Where("id = ? AND last_used_step < ?", id, step).
+		Updates(map[string]interface{}{
+			"last_used_step": step,
+			"updated_at":     time.Now(),
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrTwoFactorRepositoryCanNotUpdateTwoFactor
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrTwoFactorRepositoryCodeAlreadyUsed
+	}
+
+	return nil
+}
+
+func (r *TwoFactorRepository) DeleteByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.TwoFactor{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrTwoFactorRepositoryCanNotDeleteTwoFactor
+	}
+
+	return nil
+}
+
+func (r *TwoFactorRepository) CreateRecoveryCodes(codes []*entities.RecoveryCode) error {
+	if err := r.db.Create(codes).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrTwoFactorRepositoryCanNotCreateRecoveryCodes
+	}
+
+	return nil
+}
+
+// UseRecoveryCode marks an unused code of the user as used, in a single
+// statement so it can not be used twice.
+func (r *TwoFactorRepository) UseRecoveryCode(userID string, codeHash string) error {
+	now := time.Now()
+	result := r.db.Model(&entities.RecoveryCode{}).
+		Where("user_id = ? AND code_hash = ? AND used_at IS NULL", userID, codeHash).
+		Updates(map[string]interface{}{
+			"used_at":    now,
+			"updated_at": now,
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrTwoFactorRepositoryCanNotUseRecoveryCode
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrTwoFactorRepositoryRecoveryCodeNotFound
+	}
+
+	return nil
+}
+
+func (r *TwoFactorRepository) DeleteRecoveryCodesByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.RecoveryCode{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrTwoFactorRepositoryCanNotDeleteRecoveryCodes
+	}
+
+	return nil
+}
@@ -0,0 +1,331 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestTwoFactorRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	twoFactor := &entities.TwoFactor{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		Secret:    "JBSWY3DPEHPK3PXP",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `two_factors` (`id`,`user_id`,`secret`,`last_used_step`,`confirmed_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(
+			twoFactor.ID,
+			twoFactor.UserID,
+			twoFactor.Secret,
+			twoFactor.LastUsedStep,
+			twoFactor.ConfirmedAt,
+			twoFactor.CreatedAt,
+			twoFactor.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.Create(twoFactor)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `two_factors`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := twoFactorRepository.Create(&entities.TwoFactor{ID: uuid.NewString(), UserID: uuid.NewString()})
+
+	assert.ErrorIs(t, err, repositories.ErrTwoFactorRepositoryCanNotCreateTwoFactor)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryGetByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	confirmedAt := time.Now()
+	twoFactor := &entities.TwoFactor{
+		ID:           uuid.NewString(),
+		UserID:       uuid.NewString(),
+		Secret:       "JBSWY3DPEHPK3PXP",
+		LastUsedStep: 57000000,
+		ConfirmedAt:  &confirmedAt,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "secret", "last_used_step", "confirmed_at", "created_at", "updated_at"}).
+		AddRow(
+			twoFactor.ID,
+			twoFactor.UserID,
+			twoFactor.Secret,
+			twoFactor.LastUsedStep,
+			twoFactor.ConfirmedAt,
+			twoFactor.CreatedAt,
+			twoFactor.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `two_factors` WHERE user_id = ? ORDER BY `two_factors`.`id` LIMIT 1")).
+		WithArgs(twoFactor.UserID).
+		WillReturnRows(rows)
+
+	result, err := twoFactorRepository.GetByUserID(twoFactor.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, twoFactor, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryGetByUserIDErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `two_factors` WHERE user_id = ? ORDER BY `two_factors`.`id` LIMIT 1")).
+		WithArgs(userID).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := twoFactorRepository.GetByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrTwoFactorRepositoryTwoFactorNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryGetByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `two_factors` WHERE user_id = ? ORDER BY `two_factors`.`id` LIMIT 1")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+
+	result, err := twoFactorRepository.GetByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrTwoFactorRepositoryCanNotGetTwoFactor)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	confirmedAt := time.Now()
+	twoFactor := &entities.TwoFactor{
+		ID:           uuid.NewString(),
+		UserID:       uuid.NewString(),
+		Secret:       "JBSWY3DPEHPK3PXP",
+		LastUsedStep: 57000000,
+		ConfirmedAt:  &confirmedAt,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `two_factors` SET `user_id`=?,`secret`=?,`last_used_step`=?,`confirmed_at`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(
+			twoFactor.UserID,
+			twoFactor.Secret,
+			twoFactor.LastUsedStep,
+			twoFactor.ConfirmedAt,
+			twoFactor.CreatedAt,
+			sqlmock.AnyArg(),
+			twoFactor.ID,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.Update(twoFactor)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryUseStep(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	id := uuid.NewString()
+	step := int64(57000001)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `two_factors` SET `last_used_step`=?,`updated_at`=? WHERE id = ? AND last_used_step < ?")).
+		WithArgs(step, sqlmock.AnyArg(), id, step).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.UseStep(id, step)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryUseStepErrorAlreadyUsed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	id := uuid.NewString()
+	step := int64(57000001)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `two_factors` SET `last_used_step`=?,`updated_at`=? WHERE id = ? AND last_used_step < ?")).
+		WithArgs(step, sqlmock.AnyArg(), id, step).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.UseStep(id, step)
+
+	assert.ErrorIs(t, err, repositories.ErrTwoFactorRepositoryCodeAlreadyUsed)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryDeleteByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `two_factors` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.DeleteByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryCreateRecoveryCodes(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	userID := uuid.NewString()
+	codes := []*entities.RecoveryCode{
+		{ID: uuid.NewString(), UserID: userID, CodeHash: "hash-1", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+		{ID: uuid.NewString(), UserID: userID, CodeHash: "hash-2", CreatedAt: time.Now(), UpdatedAt: time.Now()},
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `recovery_codes` (`id`,`user_id`,`code_hash`,`used_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?),(?,?,?,?,?,?)")).
+		WithArgs(
+			codes[0].ID, codes[0].UserID, codes[0].CodeHash, codes[0].UsedAt, codes[0].CreatedAt, codes[0].UpdatedAt,
+			codes[1].ID, codes[1].UserID, codes[1].CodeHash, codes[1].UsedAt, codes[1].CreatedAt, codes[1].UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.CreateRecoveryCodes(codes)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryCreateRecoveryCodesError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `recovery_codes`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := twoFactorRepository.CreateRecoveryCodes([]*entities.RecoveryCode{{ID: uuid.NewString()}})
+
+	assert.ErrorIs(t, err, repositories.ErrTwoFactorRepositoryCanNotCreateRecoveryCodes)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryUseRecoveryCode(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `recovery_codes` SET `updated_at`=?,`used_at`=? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.UseRecoveryCode(userID, "hash")
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryUseRecoveryCodeErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `recovery_codes` SET `updated_at`=?,`used_at`=? WHERE user_id = ? AND code_hash = ? AND used_at IS NULL")).
+		WithArgs(sqlmock.AnyArg(), sqlmock.AnyArg(), userID, "hash").
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := twoFactorRepository.UseRecoveryCode(userID, "hash")
+
+	assert.ErrorIs(t, err, repositories.ErrTwoFactorRepositoryRecoveryCodeNotFound)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestTwoFactorRepositoryDeleteRecoveryCodesByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	twoFactorRepository := NewTwoFactorRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `recovery_codes` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := twoFactorRepository.DeleteRecoveryCodesByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrTwoFactorRepositoryCanNotDeleteRecoveryCodes)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
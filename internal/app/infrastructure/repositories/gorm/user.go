@@ -45,22 +45,59 @@ func (r *UserRepository) FindByEmail(email string) (*entities.User, error) {
 	return user, nil
 }
 
-func (r *UserRepository) GetUserByBoxID(boxID string) (*entities.User, error) {
+func (r *UserRepository) GetByID(id string) (*entities.User, error) {
 	user := &entities.User{}
 
-	err := r.db.Joins("rooms on rooms.user_id = users.id").
-		Joins("boxes on boxes.room_id = rooms.id").
-		Where("boxes.id = ?", boxID).
-		First(user).
-		Error
+	err := r.db.First(user, "id = ?", id).Error
 	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
 		logger.LogError(err)
 		return nil, repositories.ErrUserRepositoryUserNotFound
 	}
 
 	if err != nil {
-		return nil, repositories.ErrUserRepositoryCanNotGetUserByBoxID
+		return nil, repositories.ErrUserRepositoryCanNotGetUser
 	}
 
 	return user, nil
 }
+
+// GetUsersByBoxID returns the members of the household that owns the box.
+func (r *UserRepository) GetUsersByBoxID(boxID string) ([]*entities.User, error) {
+	var users []*entities.User
+
+	err := r.db.Select("users.*").
+		Joins("join household_members on household_members.user_id = users.id").
+		Joins("join rooms on rooms.household_id = household_members.household_id").
+		Joins("join boxes on boxes.room_id = rooms.id").
+		Where("boxes.id = ?", boxID).
+		Find(&users).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrUserRepositoryCanNotGetUsersByBoxID
+	}
+
+	return users, nil
+}
+
+func (r *UserRepository) Update(user *entities.User) error {
+	if err := r.db.Save(user).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrUserRepositoryCanNotUpdateUser
+	}
+
+	return nil
+}
+
+func (r *UserRepository) Delete(id string) error {
+	err := r.db.Where("id = ?", id).Delete(&entities.User{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrUserRepositoryCanNotDeleteUser
+	}
+
+	return nil
+}
@@ -0,0 +1,58 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+)
+
+type UserIdentityRepository struct {
+	db *gorm.DB
+}
+
+func NewUserIdentityRepository(db *gorm.DB) *UserIdentityRepository {
+	return &UserIdentityRepository{
+		db,
+	}
+}
+
+func (r *UserIdentityRepository) Create(identity *entities.UserIdentity) error {
+	if err := r.db.Create(identity).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrUserIdentityRepositoryCanNotCreateUserIdentity
+	}
+
+	return nil
+}
+
+func (r *UserIdentityRepository) GetByIssuerAndSubject(issuer string, subject string) (*entities.UserIdentity, error) {
+	identity := &entities.UserIdentity{}
+
+	err := r.db.First(identity, "issuer = ? AND subject = ?", issuer, subject).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrUserIdentityRepositoryUserIdentityNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrUserIdentityRepositoryCanNotGetUserIdentity
+	}
+
+	return identity, nil
+}
+
+func (r *UserIdentityRepository) DeleteByUserID(userID string) error {
+	err := r.db.Where("user_id = ?", userID).Delete(&entities.UserIdentity{}).Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrUserIdentityRepositoryCanNotDeleteUserIdentities
+	}
+
+	return nil
+}
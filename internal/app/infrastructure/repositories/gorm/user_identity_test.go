@@ -0,0 +1,179 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestUserIdentityRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userIdentityRepository := NewUserIdentityRepository(db)
+
+	identity := &entities.UserIdentity{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		Issuer:    "https://accounts.example.com",
+		Subject:   "248289761001",
+		Email:     "test@example.com",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_identities` (`id`,`user_id`,`issuer`,`subject`,`email`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?)")).
+		WithArgs(
+			identity.ID,
+			identity.UserID,
+			identity.Issuer,
+			identity.Subject,
+			identity.Email,
+			identity.CreatedAt,
+			identity.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := userIdentityRepository.Create(identity)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserIdentityRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userIdentityRepository := NewUserIdentityRepository(db)
+
+	identity := &entities.UserIdentity{
+		ID:      uuid.NewString(),
+		UserID:  uuid.NewString(),
+		Issuer:  "https://accounts.example.com",
+		Subject: "248289761001",
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `user_identities`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := userIdentityRepository.Create(identity)
+
+	assert.ErrorIs(t, err, repositories.ErrUserIdentityRepositoryCanNotCreateUserIdentity)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserIdentityRepositoryGetByIssuerAndSubject(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userIdentityRepository := NewUserIdentityRepository(db)
+
+	identity := &entities.UserIdentity{
+		ID:        uuid.NewString(),
+		UserID:    uuid.NewString(),
+		Issuer:    "https://accounts.example.com",
+		Subject:   "248289761001",
+		Email:     "test@example.com",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "user_id", "issuer", "subject", "email", "created_at", "updated_at"}).
+		AddRow(
+			identity.ID,
+			identity.UserID,
+			identity.Issuer,
+			identity.Subject,
+			identity.Email,
+			identity.CreatedAt,
+			identity.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE issuer = ? AND subject = ? ORDER BY `user_identities`.`id` LIMIT 1")).
+		WithArgs(identity.Issuer, identity.Subject).
+		WillReturnRows(rows)
+
+	result, err := userIdentityRepository.GetByIssuerAndSubject(identity.Issuer, identity.Subject)
+
+	assert.NoError(t, err)
+	assert.Equal(t, identity, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserIdentityRepositoryGetByIssuerAndSubjectErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userIdentityRepository := NewUserIdentityRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE issuer = ? AND subject = ? ORDER BY `user_identities`.`id` LIMIT 1")).
+		WithArgs("https://accounts.example.com", "248289761001").
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := userIdentityRepository.GetByIssuerAndSubject("https://accounts.example.com", "248289761001")
+
+	assert.ErrorIs(t, err, repositories.ErrUserIdentityRepositoryUserIdentityNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserIdentityRepositoryGetByIssuerAndSubjectError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userIdentityRepository := NewUserIdentityRepository(db)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `user_identities` WHERE issuer = ? AND subject = ? ORDER BY `user_identities`.`id` LIMIT 1")).
+		WithArgs("https://accounts.example.com", "248289761001").
+		WillReturnError(errors.New("database error"))
+
+	result, err := userIdentityRepository.GetByIssuerAndSubject("https://accounts.example.com", "248289761001")
+
+	assert.ErrorIs(t, err, repositories.ErrUserIdentityRepositoryCanNotGetUserIdentity)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserIdentityRepositoryDeleteByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userIdentityRepository := NewUserIdentityRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_identities` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := userIdentityRepository.DeleteByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserIdentityRepositoryDeleteByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userIdentityRepository := NewUserIdentityRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `user_identities` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := userIdentityRepository.DeleteByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrUserIdentityRepositoryCanNotDeleteUserIdentities)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -25,8 +25,8 @@ func TestUserRepositoryCreateUser(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`id`,`email`,`password`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)")).
-		WithArgs(user.ID, user.Email, user.Password, user.CreatedAt, user.UpdatedAt).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`id`,`email`,`password`,`verified_at`,`timezone`,`deletion_requested_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(user.ID, user.Email, user.Password, user.VerifiedAt, user.Timezone, user.DeletionRequestedAt, user.CreatedAt, user.UpdatedAt).
 		WillReturnResult(sqlmock.NewResult(1, 1))
 	dbMock.ExpectCommit()
 
@@ -49,8 +49,8 @@ func TestUserRepositoryCreateUserError(t *testing.T) {
 		UpdatedAt: time.Now(),
 	}
 	dbMock.ExpectBegin()
-	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`id`,`email`,`password`,`created_at`,`updated_at`) VALUES (?,?,?,?,?)")).
-		WithArgs(user.ID, user.Email, user.Password, user.CreatedAt, user.UpdatedAt).
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `users` (`id`,`email`,`password`,`verified_at`,`timezone`,`deletion_requested_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(user.ID, user.Email, user.Password, user.VerifiedAt, user.Timezone, user.DeletionRequestedAt, user.CreatedAt, user.UpdatedAt).
 		WillReturnError(errors.New("some error"))
 	dbMock.ExpectRollback()
 
@@ -119,7 +119,58 @@ func TestUserRepositoryFindByEmailErrorUserNotFound(t *testing.T) {
 	assert.NoError(t, err)
 }
 
-func TestUserRepositoryGetUserByBoxID(t *testing.T) {
+func TestUserRepositoryGetByID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userRepository := NewUserRepository(db)
+
+	expectedUser := entities.User{
+		ID:        uuid.NewString(),
+		Email:     "test@email.com",
+		Password:  "123abc",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	rows := sqlmock.NewRows([]string{"id", "email", "password", "created_at", "updated_at"}).
+		AddRow(
+			expectedUser.ID,
+			expectedUser.Email,
+			expectedUser.Password,
+			expectedUser.CreatedAt,
+			expectedUser.UpdatedAt,
+		)
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? ORDER BY `users`.`id` LIMIT 1")).
+		WillReturnRows(rows).
+		WithArgs(expectedUser.ID)
+
+	user, err := userRepository.GetByID(expectedUser.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &expectedUser, user)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryGetByIDErrorUserNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userRepository := NewUserRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `users` WHERE id = ? ORDER BY `users`.`id` LIMIT 1")).
+		WillReturnError(gorm.ErrRecordNotFound).
+		WithArgs(id)
+
+	user, err := userRepository.GetByID(id)
+
+	assert.Nil(t, user)
+	assert.ErrorIs(t, err, repositories.ErrUserRepositoryUserNotFound)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryGetUsersByBoxID(t *testing.T) {
 	db, dbMock := makeDBMock()
 	userRepository := NewUserRepository(db)
 
@@ -142,37 +193,125 @@ func TestUserRepositoryGetUserByBoxID(t *testing.T) {
 			expectedUser.UpdatedAt,
 		)
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `users`.`id`,`users`.`email`,`users`.`password`,`users`.`created_at`,`users`.`updated_at` FROM `users` rooms on rooms.user_id = users.id boxes on boxes.room_id = rooms.id WHERE boxes.id = ? ORDER BY `users`.`id` LIMIT 1")).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT users.* FROM `users` join household_members on household_members.user_id = users.id join rooms on rooms.household_id = household_members.household_id join boxes on boxes.room_id = rooms.id WHERE boxes.id = ?")).
 		WithArgs(boxID).
 		WillReturnRows(rows)
 
-	user, err := userRepository.GetUserByBoxID(boxID)
+	users, err := userRepository.GetUsersByBoxID(boxID)
 
-	assert.NotNil(t, user)
-	assert.Equal(t, expectedUser.ID, user.ID)
-	assert.Equal(t, expectedUser.Email, user.Email)
-	assert.Equal(t, expectedUser.Password, user.Password)
-	assert.Equal(t, expectedUser.CreatedAt, user.CreatedAt)
-	assert.Equal(t, expectedUser.UpdatedAt, user.UpdatedAt)
+	assert.NoError(t, err)
+	assert.Len(t, users, 1)
+	assert.Equal(t, expectedUser.ID, users[0].ID)
+	assert.Equal(t, expectedUser.Email, users[0].Email)
+	assert.Equal(t, expectedUser.Password, users[0].Password)
+	assert.Equal(t, expectedUser.CreatedAt, users[0].CreatedAt)
+	assert.Equal(t, expectedUser.UpdatedAt, users[0].UpdatedAt)
 	err = dbMock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
 
-func TestUserRepositoryGetUserByBoxIDErrorUserNotFound(t *testing.T) {
+func TestUserRepositoryGetUsersByBoxIDError(t *testing.T) {
 	db, dbMock := makeDBMock()
 	userRepository := NewUserRepository(db)
 
 	boxID := uuid.NewString()
 
-	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT `users`.`id`,`users`.`email`,`users`.`password`,`users`.`created_at`,`users`.`updated_at` FROM `users` rooms on rooms.user_id = users.id boxes on boxes.room_id = rooms.id WHERE boxes.id = ? ORDER BY `users`.`id` LIMIT 1")).
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT users.* FROM `users`")).
 		WithArgs(boxID).
-		WillReturnError(gorm.ErrRecordNotFound)
+		WillReturnError(errors.New("database error"))
 
-	user, err := userRepository.GetUserByBoxID(boxID)
+	users, err := userRepository.GetUsersByBoxID(boxID)
 
-	assert.Nil(t, user)
-	assert.Error(t, err)
-	assert.ErrorIs(t, repositories.ErrUserRepositoryUserNotFound, err)
+	assert.Nil(t, users)
+	assert.ErrorIs(t, err, repositories.ErrUserRepositoryCanNotGetUsersByBoxID)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userRepository := NewUserRepository(db)
+
+	user := &entities.User{
+		ID:        uuid.NewString(),
+		Email:     "test@example.com",
+		Password:  "3ncr1pt3dP44sw0rd",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `email`=?,`password`=?,`verified_at`=?,`timezone`=?,`deletion_requested_at`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(user.Email, user.Password, user.VerifiedAt, user.Timezone, user.DeletionRequestedAt, user.CreatedAt, sqlmock.AnyArg(), user.ID).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := userRepository.Update(user)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryUpdateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userRepository := NewUserRepository(db)
+
+	user := &entities.User{
+		ID:        uuid.NewString(),
+		Email:     "test@example.com",
+		Password:  "3ncr1pt3dP44sw0rd",
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `users` SET `email`=?,`password`=?,`verified_at`=?,`timezone`=?,`deletion_requested_at`=?,`created_at`=?,`updated_at`=? WHERE `id` = ?")).
+		WithArgs(user.Email, user.Password, user.VerifiedAt, user.Timezone, user.DeletionRequestedAt, user.CreatedAt, sqlmock.AnyArg(), user.ID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := userRepository.Update(user)
+
+	assert.ErrorIs(t, err, repositories.ErrUserRepositoryCanNotUpdateUser)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryDelete(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userRepository := NewUserRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := userRepository.Delete(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestUserRepositoryDeleteError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	userRepository := NewUserRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `users` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := userRepository.Delete(id)
+
+	assert.ErrorIs(t, err, repositories.ErrUserRepositoryCanNotDeleteUser)
 	err = dbMock.ExpectationsWereMet()
 	assert.NoError(t, err)
 }
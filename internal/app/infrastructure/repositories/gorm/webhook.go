@@ -0,0 +1,112 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+)
+
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{
+		db,
+	}
+}
+
+func (r *WebhookRepository) Create(webhook *entities.Webhook) error {
+	if err := r.db.Create(webhook).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrWebhookRepositoryCanNotCreateWebhook
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) GetByID(id string) (*entities.Webhook, error) {
+	webhook := &entities.Webhook{}
+
+	err := r.db.First(webhook, "id = ?", id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrWebhookRepositoryWebhookNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrWebhookRepositoryCanNotGetWebhook
+	}
+
+	return webhook, nil
+}
+
+// GetByUserID returns the webhooks of the user, newest first.
+func (r *WebhookRepository) GetByUserID(userID string) ([]*entities.Webhook, error) {
+	return r.getAllBy("user_id = ?", userID)
+}
+
+func (r *WebhookRepository) GetByHouseholdID(householdID string) ([]*entities.Webhook, error) {
+	return r.getAllBy("household_id = ?", householdID)
+}
+
+func (r *WebhookRepository) getAllBy(query string, value string) ([]*entities.Webhook, error) {
+	webhooks := make([]*entities.Webhook, 0)
+
+	err := r.db.Where(query, value).
+		Order("created_at desc").
+		Find(&webhooks).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrWebhookRepositoryCanNotGetWebhooks
+	}
+
+	return webhooks, nil
+}
+
+func (r *WebhookRepository) Delete(id string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("webhook_id = ?", id).Delete(&entities.WebhookDelivery{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("id = ?", id).Delete(&entities.Webhook{}).Error
+	})
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrWebhookRepositoryCanNotDeleteWebhook
+	}
+
+	return nil
+}
+
+func (r *WebhookRepository) DeleteByUserID(userID string) error {
+	err := r.db.Transaction(func(tx *gorm.DB) error {
+		webhookIDs := tx.Model(&entities.Webhook{}).Select("id").Where("user_id = ?", userID)
+
+		err := tx.Where("webhook_id IN (?)", webhookIDs).Delete(&entities.WebhookDelivery{}).Error
+		if err != nil {
+			return err
+		}
+
+		return tx.Where("user_id = ?", userID).Delete(&entities.Webhook{}).Error
+	})
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrWebhookRepositoryCanNotDeleteWebhooks
+	}
+
+	return nil
+}
@@ -0,0 +1,146 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"github.com/jibaru/home-inventory-api/m/notifier"
+	"gorm.io/gorm"
+	"time"
+)
+
+type WebhookDeliveryRepository struct {
+	db *gorm.DB
+}
+
+func NewWebhookDeliveryRepository(db *gorm.DB) *WebhookDeliveryRepository {
+	return &WebhookDeliveryRepository{
+		db,
+	}
+}
+
+func (r *WebhookDeliveryRepository) Create(delivery *entities.WebhookDelivery) error {
+	if err := r.db.Create(delivery).Error; err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrWebhookRepositoryCanNotCreateDelivery
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) GetByID(id string) (*entities.WebhookDelivery, error) {
+	delivery := &entities.WebhookDelivery{}
+
+	err := r.db.First(delivery, "id = ?", id).Error
+	if err != nil && errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, repositories.ErrWebhookRepositoryDeliveryNotFound
+	}
+
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrWebhookRepositoryCanNotGetDelivery
+	}
+
+	return delivery, nil
+}
+
+func (r *WebhookDeliveryRepository) GetAvailable(now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	deliveries := make([]*entities.WebhookDelivery, 0)
+
+	err := r.db.Where("status = ? AND available_at <= ?", entities.WebhookDeliveryStatusPending, now).
+		Order("available_at asc").
+		Limit(limit).
+		Find(&deliveries).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return nil, repositories.ErrWebhookRepositoryCanNotGetDeliveries
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) Claim(delivery *entities.WebhookDelivery, now time.Time, until time.Time) error {
+	result := r.db.Model(&entities.WebhookDelivery{}).
+		Where("id = ? AND status = ? AND available_at <= ?", delivery.ID, entities.WebhookDeliveryStatusPending, now).
+		Updates(map[string]interface{}{
+			"available_at": until,
+			"updated_at":   now,
+		})
+
+	if result.Error != nil {
+		logger.LogError(result.Error)
+		notifier.NotifyError(result.Error)
+		return repositories.ErrWebhookRepositoryCanNotClaimDelivery
+	}
+
+	if result.RowsAffected == 0 {
+		return repositories.ErrWebhookRepositoryDeliveryAlreadyClaimed
+	}
+
+	delivery.AvailableAt = until
+	delivery.UpdatedAt = now
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) Update(delivery *entities.WebhookDelivery) error {
+	err := r.db.Model(&entities.WebhookDelivery{}).
+		Where("id = ?", delivery.ID).
+		Updates(map[string]interface{}{
+			"status":          delivery.Status,
+			"attempts":        delivery.Attempts,
+			"response_status": delivery.ResponseStatus,
+			"last_error":      delivery.LastError,
+			"available_at":    delivery.AvailableAt,
+			"delivered_at":    delivery.DeliveredAt,
+			"updated_at":      delivery.UpdatedAt,
+		}).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		notifier.NotifyError(err)
+		return repositories.ErrWebhookRepositoryCanNotUpdateDelivery
+	}
+
+	return nil
+}
+
+func (r *WebhookDeliveryRepository) GetByWebhookID(
+	webhookID string,
+	pageFilter *repositories.PageFilter,
+) ([]*entities.WebhookDelivery, error) {
+	deliveries := make([]*entities.WebhookDelivery, 0)
+
+	err := r.db.Where("webhook_id = ?", webhookID).
+		Offset(pageFilter.Offset).
+		Limit(pageFilter.Limit).
+		Order("created_at desc").
+		Find(&deliveries).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		return nil, repositories.ErrWebhookRepositoryCanNotGetDeliveries
+	}
+
+	return deliveries, nil
+}
+
+func (r *WebhookDeliveryRepository) CountByWebhookID(webhookID string) (int64, error) {
+	var count int64
+
+	err := r.db.Model(&entities.WebhookDelivery{}).
+		Where("webhook_id = ?", webhookID).
+		Count(&count).
+		Error
+	if err != nil {
+		logger.LogError(err)
+		return 0, repositories.ErrWebhookRepositoryCanNotCountDeliveries
+	}
+
+	return count, nil
+}
@@ -0,0 +1,419 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestWebhookDeliveryRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `webhook_deliveries` (`id`,`webhook_id`,`event_type`,`payload`,`status`,`attempts`,`response_status`,`last_error`,`available_at`,`delivered_at`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Status,
+			delivery.Attempts,
+			nil,
+			nil,
+			delivery.AvailableAt,
+			nil,
+			delivery.CreatedAt,
+			delivery.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := webhookDeliveryRepository.Create(delivery)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `webhook_deliveries`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := webhookDeliveryRepository.Create(delivery)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotCreateDelivery)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryGetByID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_deliveries` WHERE id = ? ORDER BY `webhook_deliveries`.`id` LIMIT 1")).
+		WithArgs(delivery.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_type", "payload", "status", "attempts", "response_status", "last_error", "available_at", "delivered_at", "created_at", "updated_at"}).AddRow(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Status,
+			delivery.Attempts,
+			nil,
+			nil,
+			delivery.AvailableAt,
+			nil,
+			delivery.CreatedAt,
+			delivery.UpdatedAt,
+		))
+
+	result, err := webhookDeliveryRepository.GetByID(delivery.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, delivery, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryGetByIDErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_deliveries` WHERE id = ? ORDER BY `webhook_deliveries`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := webhookDeliveryRepository.GetByID(id)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryDeliveryNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryGetAvailable(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_deliveries` WHERE status = ? AND available_at <= ? ORDER BY available_at asc LIMIT 10")).
+		WithArgs(entities.WebhookDeliveryStatusPending, now).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_type", "payload", "status", "attempts", "response_status", "last_error", "available_at", "delivered_at", "created_at", "updated_at"}).AddRow(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Status,
+			delivery.Attempts,
+			nil,
+			nil,
+			delivery.AvailableAt,
+			nil,
+			delivery.CreatedAt,
+			delivery.UpdatedAt,
+		))
+
+	deliveries, err := webhookDeliveryRepository.GetAvailable(now, 10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.WebhookDelivery{delivery}, deliveries)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryGetAvailableError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	now := time.Now()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_deliveries` WHERE status = ? AND available_at <= ? ORDER BY available_at asc LIMIT 10")).
+		WithArgs(entities.WebhookDeliveryStatusPending, now).
+		WillReturnError(errors.New("database error"))
+
+	deliveries, err := webhookDeliveryRepository.GetAvailable(now, 10)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotGetDeliveries)
+	assert.Nil(t, deliveries)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryClaim(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `webhook_deliveries` SET `available_at`=?,`updated_at`=? WHERE id = ? AND status = ? AND available_at <= ?")).
+		WithArgs(until, now, delivery.ID, entities.WebhookDeliveryStatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := webhookDeliveryRepository.Claim(delivery, now, until)
+
+	assert.NoError(t, err)
+	assert.Equal(t, until, delivery.AvailableAt)
+	assert.Equal(t, now, delivery.UpdatedAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryClaimErrorAlreadyClaimed(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	availableAt := delivery.AvailableAt
+	now := time.Now()
+	until := now.Add(time.Minute)
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `webhook_deliveries` SET `available_at`=?,`updated_at`=? WHERE id = ? AND status = ? AND available_at <= ?")).
+		WithArgs(until, now, delivery.ID, entities.WebhookDeliveryStatusPending, now).
+		WillReturnResult(sqlmock.NewResult(0, 0))
+	dbMock.ExpectCommit()
+
+	err := webhookDeliveryRepository.Claim(delivery, now, until)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryDeliveryAlreadyClaimed)
+	assert.Equal(t, availableAt, delivery.AvailableAt)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryUpdate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+	delivery.MarkDelivered(204, time.Now())
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `webhook_deliveries` SET `attempts`=?,`available_at`=?,`delivered_at`=?,`last_error`=?,`response_status`=?,`status`=?,`updated_at`=? WHERE id = ?")).
+		WithArgs(delivery.Attempts, delivery.AvailableAt, delivery.DeliveredAt, nil, 204, delivery.Status, delivery.UpdatedAt, delivery.ID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := webhookDeliveryRepository.Update(delivery)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryUpdateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("UPDATE `webhook_deliveries`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := webhookDeliveryRepository.Update(delivery)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotUpdateDelivery)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryGetByWebhookID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	delivery := &entities.WebhookDelivery{
+		ID:          uuid.NewString(),
+		WebhookID:   uuid.NewString(),
+		EventType:   "BoxItemAddedEvent",
+		Payload:     `{"event":"BoxItemAddedEvent"}`,
+		Status:      entities.WebhookDeliveryStatusPending,
+		AvailableAt: time.Now(),
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_deliveries` WHERE webhook_id = ? ORDER BY created_at desc LIMIT 10 OFFSET 10")).
+		WithArgs(delivery.WebhookID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "webhook_id", "event_type", "payload", "status", "attempts", "response_status", "last_error", "available_at", "delivered_at", "created_at", "updated_at"}).AddRow(
+			delivery.ID,
+			delivery.WebhookID,
+			delivery.EventType,
+			delivery.Payload,
+			delivery.Status,
+			delivery.Attempts,
+			nil,
+			nil,
+			delivery.AvailableAt,
+			nil,
+			delivery.CreatedAt,
+			delivery.UpdatedAt,
+		))
+
+	deliveries, err := webhookDeliveryRepository.GetByWebhookID(delivery.WebhookID, &repositories.PageFilter{
+		Offset: 10,
+		Limit:  10,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.WebhookDelivery{delivery}, deliveries)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryGetByWebhookIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	webhookID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhook_deliveries` WHERE webhook_id = ? ORDER BY created_at desc LIMIT 10")).
+		WithArgs(webhookID).
+		WillReturnError(errors.New("database error"))
+
+	deliveries, err := webhookDeliveryRepository.GetByWebhookID(webhookID, &repositories.PageFilter{
+		Offset: 0,
+		Limit:  10,
+	})
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotGetDeliveries)
+	assert.Nil(t, deliveries)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryCountByWebhookID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	webhookID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `webhook_deliveries` WHERE webhook_id = ?")).
+		WithArgs(webhookID).
+		WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(3))
+
+	count, err := webhookDeliveryRepository.CountByWebhookID(webhookID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(3), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookDeliveryRepositoryCountByWebhookIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookDeliveryRepository := NewWebhookDeliveryRepository(db)
+
+	webhookID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT count(*) FROM `webhook_deliveries` WHERE webhook_id = ?")).
+		WithArgs(webhookID).
+		WillReturnError(errors.New("database error"))
+
+	count, err := webhookDeliveryRepository.CountByWebhookID(webhookID)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotCountDeliveries)
+	assert.Equal(t, int64(0), count)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
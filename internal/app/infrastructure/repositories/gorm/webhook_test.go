@@ -0,0 +1,325 @@
+package gorm
+
+import (
+	"errors"
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/assert"
+	"gorm.io/gorm"
+	"regexp"
+	"testing"
+	"time"
+)
+
+func TestWebhookRepositoryCreate(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "BoxItemAddedEvent,BoxItemRemovedEvent",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `webhooks` (`id`,`user_id`,`household_id`,`url`,`secret`,`event_types`,`created_at`,`updated_at`) VALUES (?,?,?,?,?,?,?,?)")).
+		WithArgs(
+			webhook.ID,
+			webhook.UserID,
+			webhook.HouseholdID,
+			webhook.URL,
+			webhook.Secret,
+			webhook.EventTypes,
+			webhook.CreatedAt,
+			webhook.UpdatedAt,
+		).
+		WillReturnResult(sqlmock.NewResult(1, 1))
+	dbMock.ExpectCommit()
+
+	err := webhookRepository.Create(webhook)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryCreateError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "BoxItemAddedEvent,BoxItemRemovedEvent",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("INSERT INTO `webhooks`")).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := webhookRepository.Create(webhook)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotCreateWebhook)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryGetByID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "BoxItemAddedEvent,BoxItemRemovedEvent",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE id = ? ORDER BY `webhooks`.`id` LIMIT 1")).
+		WithArgs(webhook.ID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "household_id", "url", "secret", "event_types", "created_at", "updated_at"}).AddRow(
+			webhook.ID,
+			webhook.UserID,
+			webhook.HouseholdID,
+			webhook.URL,
+			webhook.Secret,
+			webhook.EventTypes,
+			webhook.CreatedAt,
+			webhook.UpdatedAt,
+		))
+
+	result, err := webhookRepository.GetByID(webhook.ID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, webhook, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryGetByIDErrorNotFound(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE id = ? ORDER BY `webhooks`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(gorm.ErrRecordNotFound)
+
+	result, err := webhookRepository.GetByID(id)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryWebhookNotFound)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryGetByIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE id = ? ORDER BY `webhooks`.`id` LIMIT 1")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+
+	result, err := webhookRepository.GetByID(id)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotGetWebhook)
+	assert.Nil(t, result)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryGetByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "BoxItemAddedEvent,BoxItemRemovedEvent",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE user_id = ? ORDER BY created_at desc")).
+		WithArgs(webhook.UserID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "household_id", "url", "secret", "event_types", "created_at", "updated_at"}).AddRow(
+			webhook.ID,
+			webhook.UserID,
+			webhook.HouseholdID,
+			webhook.URL,
+			webhook.Secret,
+			webhook.EventTypes,
+			webhook.CreatedAt,
+			webhook.UpdatedAt,
+		))
+
+	webhooks, err := webhookRepository.GetByUserID(webhook.UserID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Webhook{webhook}, webhooks)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryGetByHouseholdID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	webhook := &entities.Webhook{
+		ID:          uuid.NewString(),
+		UserID:      uuid.NewString(),
+		HouseholdID: uuid.NewString(),
+		URL:         "https://example.com/hooks",
+		Secret:      entities.WebhookSecretPrefix + "secret",
+		EventTypes:  "BoxItemAddedEvent,BoxItemRemovedEvent",
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE household_id = ? ORDER BY created_at desc")).
+		WithArgs(webhook.HouseholdID).
+		WillReturnRows(sqlmock.NewRows([]string{"id", "user_id", "household_id", "url", "secret", "event_types", "created_at", "updated_at"}).AddRow(
+			webhook.ID,
+			webhook.UserID,
+			webhook.HouseholdID,
+			webhook.URL,
+			webhook.Secret,
+			webhook.EventTypes,
+			webhook.CreatedAt,
+			webhook.UpdatedAt,
+		))
+
+	webhooks, err := webhookRepository.GetByHouseholdID(webhook.HouseholdID)
+
+	assert.NoError(t, err)
+	assert.Equal(t, []*entities.Webhook{webhook}, webhooks)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryGetByHouseholdIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	householdID := uuid.NewString()
+
+	dbMock.ExpectQuery(regexp.QuoteMeta("SELECT * FROM `webhooks` WHERE household_id = ? ORDER BY created_at desc")).
+		WithArgs(householdID).
+		WillReturnError(errors.New("database error"))
+
+	webhooks, err := webhookRepository.GetByHouseholdID(householdID)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotGetWebhooks)
+	assert.Nil(t, webhooks)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryDelete(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `webhook_deliveries` WHERE webhook_id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `webhooks` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := webhookRepository.Delete(id)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryDeleteError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	id := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `webhook_deliveries` WHERE webhook_id = ?")).
+		WithArgs(id).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `webhooks` WHERE id = ?")).
+		WithArgs(id).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := webhookRepository.Delete(id)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotDeleteWebhook)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryDeleteByUserID(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `webhook_deliveries` WHERE webhook_id IN (SELECT `id` FROM `webhooks` WHERE user_id = ?)")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 3))
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `webhooks` WHERE user_id = ?")).
+		WithArgs(userID).
+		WillReturnResult(sqlmock.NewResult(0, 1))
+	dbMock.ExpectCommit()
+
+	err := webhookRepository.DeleteByUserID(userID)
+
+	assert.NoError(t, err)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
+
+func TestWebhookRepositoryDeleteByUserIDError(t *testing.T) {
+	db, dbMock := makeDBMock()
+	webhookRepository := NewWebhookRepository(db)
+
+	userID := uuid.NewString()
+
+	dbMock.ExpectBegin()
+	dbMock.ExpectExec(regexp.QuoteMeta("DELETE FROM `webhook_deliveries` WHERE webhook_id IN (SELECT `id` FROM `webhooks` WHERE user_id = ?)")).
+		WithArgs(userID).
+		WillReturnError(errors.New("database error"))
+	dbMock.ExpectRollback()
+
+	err := webhookRepository.DeleteByUserID(userID)
+
+	assert.ErrorIs(t, err, repositories.ErrWebhookRepositoryCanNotDeleteWebhooks)
+	err = dbMock.ExpectationsWereMet()
+	assert.NoError(t, err)
+}
@@ -44,3 +44,45 @@ func (r *AssetRepositoryMock) GetByQueryFilters(
 
 	return nil, args.Error(1)
 }
+
+func (r *AssetRepositoryMock) CountByQueryFilters(
+	queryFilter repositories.QueryFilter,
+) (int64, error) {
+	args := r.Called(queryFilter)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (r *AssetRepositoryMock) GetByID(id string) (*entities.Asset, error) {
+	args := r.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.Asset), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (r *AssetRepositoryMock) GetAll() ([]*entities.Asset, error) {
+	args := r.Called()
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.Asset), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (r *AssetRepositoryMock) GetWithoutEntity() ([]*entities.Asset, error) {
+	args := r.Called()
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.Asset), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (r *AssetRepositoryMock) UpdateFileID(oldFileID string, newFileID string) error {
+	args := r.Called(oldFileID, newFileID)
+	return args.Error(0)
+}
@@ -0,0 +1,55 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type AttachmentRepositoryMock struct {
+	mock.Mock
+}
+
+func (r *AttachmentRepositoryMock) Create(attachment *entities.Attachment) error {
+	args := r.Called(attachment)
+	return args.Error(0)
+}
+
+func (r *AttachmentRepositoryMock) GetByID(id string) (*entities.Attachment, error) {
+	args := r.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.Attachment), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (r *AttachmentRepositoryMock) GetByItemID(itemID string) ([]*entities.Attachment, error) {
+	args := r.Called(itemID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.Attachment), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (r *AttachmentRepositoryMock) GetByWarrantyExpiringBetween(
+	householdIDs []string,
+	from time.Time,
+	to time.Time,
+) ([]*entities.Attachment, error) {
+	args := r.Called(householdIDs, from, to)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.Attachment), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (r *AttachmentRepositoryMock) DeleteByItemID(itemID string) error {
+	args := r.Called(itemID)
+	return args.Error(0)
+}
@@ -0,0 +1,36 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type AuditLogRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *AuditLogRepositoryMock) Create(auditLog *entities.AuditLog) error {
+	args := m.Called(auditLog)
+	return args.Error(0)
+}
+
+func (m *AuditLogRepositoryMock) GetByQueryFilters(
+	queryFilter repositories.QueryFilter,
+	pageFilter *repositories.PageFilter,
+) ([]*entities.AuditLog, error) {
+	args := m.Called(queryFilter, pageFilter)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]*entities.AuditLog), args.Error(1)
+}
+
+func (m *AuditLogRepositoryMock) CountByQueryFilters(
+	queryFilter repositories.QueryFilter,
+) (int64, error) {
+	args := m.Called(queryFilter)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,115 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type HouseholdRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *HouseholdRepositoryMock) Create(household *entities.Household) error {
+	args := m.Called(household)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) GetByIDs(ids []string) ([]*entities.Household, error) {
+	args := m.Called(ids)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.Household), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *HouseholdRepositoryMock) CreateMember(member *entities.HouseholdMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) GetMember(householdID string, userID string) (*entities.HouseholdMember, error) {
+	args := m.Called(householdID, userID)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.HouseholdMember), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *HouseholdRepositoryMock) GetMembersByUserID(userID string) ([]*entities.HouseholdMember, error) {
+	args := m.Called(userID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.HouseholdMember), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *HouseholdRepositoryMock) GetMembersByHouseholdID(householdID string) ([]*entities.HouseholdMember, error) {
+	args := m.Called(householdID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.HouseholdMember), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *HouseholdRepositoryMock) UpdateMember(member *entities.HouseholdMember) error {
+	args := m.Called(member)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) CreateInvitation(invitation *entities.HouseholdInvitation) error {
+	args := m.Called(invitation)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) GetInvitationByID(id string) (*entities.HouseholdInvitation, error) {
+	args := m.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.HouseholdInvitation), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *HouseholdRepositoryMock) GetPendingInvitationsByEmail(email string) ([]*entities.HouseholdInvitation, error) {
+	args := m.Called(email)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.HouseholdInvitation), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *HouseholdRepositoryMock) UpdateInvitation(invitation *entities.HouseholdInvitation) error {
+	args := m.Called(invitation)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) DeleteMember(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) DeleteInvitationsByHouseholdID(householdID string) error {
+	args := m.Called(householdID)
+	return args.Error(0)
+}
+
+func (m *HouseholdRepositoryMock) DeleteInvitationsByInviter(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
@@ -49,3 +49,8 @@ func (r *ItemRepositoryMock) Update(item *entities.Item) error {
 	args := r.Called(item)
 	return args.Error(0)
 }
+
+func (r *ItemRepositoryMock) Delete(id string) error {
+	args := r.Called(id)
+	return args.Error(0)
+}
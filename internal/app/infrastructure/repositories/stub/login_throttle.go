@@ -0,0 +1,41 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type LoginThrottleRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *LoginThrottleRepositoryMock) GetByIdentifiers(identifiers []string) ([]*entities.LoginThrottle, error) {
+	args := m.Called(identifiers)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.LoginThrottle), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *LoginThrottleRepositoryMock) RegisterFailure(identifier string, failedAt time.Time) (*entities.LoginThrottle, error) {
+	args := m.Called(identifier, failedAt)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.LoginThrottle), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *LoginThrottleRepositoryMock) Update(throttle *entities.LoginThrottle) error {
+	args := m.Called(throttle)
+	return args.Error(0)
+}
+
+func (m *LoginThrottleRepositoryMock) DeleteByIdentifier(identifier string) error {
+	args := m.Called(identifier)
+	return args.Error(0)
+}
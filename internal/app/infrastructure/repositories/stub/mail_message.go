@@ -0,0 +1,70 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type MailMessageRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *MailMessageRepositoryMock) Create(message *entities.MailMessage) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func (m *MailMessageRepositoryMock) GetByID(id string) (*entities.MailMessage, error) {
+	args := m.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.MailMessage), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MailMessageRepositoryMock) GetAvailable(now time.Time, limit int) ([]*entities.MailMessage, error) {
+	args := m.Called(now, limit)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.MailMessage), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MailMessageRepositoryMock) Claim(message *entities.MailMessage, now time.Time, until time.Time) error {
+	args := m.Called(message, now, until)
+	return args.Error(0)
+}
+
+func (m *MailMessageRepositoryMock) Update(message *entities.MailMessage) error {
+	args := m.Called(message)
+	return args.Error(0)
+}
+
+func (m *MailMessageRepositoryMock) GetByStatus(
+	status string,
+	pageFilter *repositories.PageFilter,
+) ([]*entities.MailMessage, error) {
+	args := m.Called(status, pageFilter)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.MailMessage), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *MailMessageRepositoryMock) CountByStatus(status string) (int64, error) {
+	args := m.Called(status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *MailMessageRepositoryMock) DeleteFinishedBefore(before time.Time) (int64, error) {
+	args := m.Called(before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,103 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type NotificationPreferenceRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *NotificationPreferenceRepositoryMock) Create(preference *entities.NotificationPreference) error {
+	args := m.Called(preference)
+	return args.Error(0)
+}
+
+func (m *NotificationPreferenceRepositoryMock) GetByID(id string) (*entities.NotificationPreference, error) {
+	args := m.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.NotificationPreference), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *NotificationPreferenceRepositoryMock) GetByUserID(userID string) ([]*entities.NotificationPreference, error) {
+	args := m.Called(userID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.NotificationPreference), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *NotificationPreferenceRepositoryMock) Update(preference *entities.NotificationPreference) error {
+	args := m.Called(preference)
+	return args.Error(0)
+}
+
+func (m *NotificationPreferenceRepositoryMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *NotificationPreferenceRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+type NotificationDigestRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *NotificationDigestRepositoryMock) Create(entry *entities.NotificationDigestEntry) error {
+	args := m.Called(entry)
+	return args.Error(0)
+}
+
+func (m *NotificationDigestRepositoryMock) GetDueUserIDs(now time.Time, limit int) ([]string, error) {
+	args := m.Called(now, limit)
+
+	if data := args.Get(0); data != nil {
+		return data.([]string), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *NotificationDigestRepositoryMock) ClaimByUserID(userID string, now time.Time, until time.Time) error {
+	args := m.Called(userID, now, until)
+	return args.Error(0)
+}
+
+func (m *NotificationDigestRepositoryMock) GetClaimedByUserID(
+	userID string,
+	until time.Time,
+) ([]*entities.NotificationDigestEntry, error) {
+	args := m.Called(userID, until)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.NotificationDigestEntry), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *NotificationDigestRepositoryMock) DeleteByIDs(ids []string) error {
+	args := m.Called(ids)
+	return args.Error(0)
+}
+
+func (m *NotificationDigestRepositoryMock) ReleaseByUserID(userID string, now time.Time) error {
+	args := m.Called(userID, now)
+	return args.Error(0)
+}
+
+func (m *NotificationDigestRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
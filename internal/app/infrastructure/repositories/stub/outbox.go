@@ -0,0 +1,71 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type OutboxRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *OutboxRepositoryMock) Create(event *entities.OutboxEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *OutboxRepositoryMock) GetAvailable(now time.Time, limit int) ([]*entities.OutboxEvent, error) {
+	args := m.Called(now, limit)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.OutboxEvent), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) Claim(event *entities.OutboxEvent, now time.Time, until time.Time) error {
+	args := m.Called(event, now, until)
+	return args.Error(0)
+}
+
+func (m *OutboxRepositoryMock) Update(event *entities.OutboxEvent) error {
+	args := m.Called(event)
+	return args.Error(0)
+}
+
+func (m *OutboxRepositoryMock) GetByStatus(status string, limit int) ([]*entities.OutboxEvent, error) {
+	args := m.Called(status, limit)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.OutboxEvent), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) CountByStatus(status string) (int64, error) {
+	args := m.Called(status)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) RequeueDead(now time.Time) (int64, error) {
+	args := m.Called(now)
+	return args.Get(0).(int64), args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) GetDeliveredHandlers(eventID string) ([]string, error) {
+	args := m.Called(eventID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]string), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *OutboxRepositoryMock) CreateDeliveredHandler(handler *entities.OutboxEventHandler) error {
+	args := m.Called(handler)
+	return args.Error(0)
+}
@@ -0,0 +1,35 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type PasswordResetTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *PasswordResetTokenRepositoryMock) Create(token *entities.PasswordResetToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *PasswordResetTokenRepositoryMock) GetByTokenHash(tokenHash string) (*entities.PasswordResetToken, error) {
+	args := m.Called(tokenHash)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.PasswordResetToken), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *PasswordResetTokenRepositoryMock) MarkAsUsed(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *PasswordResetTokenRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
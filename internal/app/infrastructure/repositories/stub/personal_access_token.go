@@ -0,0 +1,66 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type PersonalAccessTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *PersonalAccessTokenRepositoryMock) Create(token *entities.PersonalAccessToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *PersonalAccessTokenRepositoryMock) GetByID(id string) (*entities.PersonalAccessToken, error) {
+	args := m.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.PersonalAccessToken), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *PersonalAccessTokenRepositoryMock) GetByTokenHash(tokenHash string) (*entities.PersonalAccessToken, error) {
+	args := m.Called(tokenHash)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.PersonalAccessToken), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *PersonalAccessTokenRepositoryMock) GetByUserID(userID string) ([]*entities.PersonalAccessToken, error) {
+	args := m.Called(userID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.PersonalAccessToken), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *PersonalAccessTokenRepositoryMock) Revoke(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *PersonalAccessTokenRepositoryMock) RevokeByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *PersonalAccessTokenRepositoryMock) UpdateLastUsedAt(id string, lastUsedAt time.Time) error {
+	args := m.Called(id, lastUsedAt)
+	return args.Error(0)
+}
+
+func (m *PersonalAccessTokenRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
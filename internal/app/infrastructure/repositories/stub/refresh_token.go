@@ -0,0 +1,55 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type RefreshTokenRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *RefreshTokenRepositoryMock) Create(token *entities.RefreshToken) error {
+	args := m.Called(token)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) GetByTokenHash(tokenHash string) (*entities.RefreshToken, error) {
+	args := m.Called(tokenHash)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.RefreshToken), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *RefreshTokenRepositoryMock) MarkAsUsed(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeFamily(familyID string) error {
+	args := m.Called(familyID)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) RevokeByUserIDExceptFamily(userID string, familyID string) error {
+	args := m.Called(userID, familyID)
+	return args.Error(0)
+}
+
+func (m *RefreshTokenRepositoryMock) IsFamilyRevoked(familyID string) (bool, error) {
+	args := m.Called(familyID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *RefreshTokenRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
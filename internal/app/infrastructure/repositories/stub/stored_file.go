@@ -0,0 +1,45 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type StoredFileRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *StoredFileRepositoryMock) Create(file *entities.StoredFile) error {
+	args := m.Called(file)
+	return args.Error(0)
+}
+
+func (m *StoredFileRepositoryMock) GetByHashForUpdate(hash string, extension string) (*entities.StoredFile, error) {
+	args := m.Called(hash, extension)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.StoredFile), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *StoredFileRepositoryMock) GetByIDForUpdate(id string) (*entities.StoredFile, error) {
+	args := m.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.StoredFile), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *StoredFileRepositoryMock) Update(file *entities.StoredFile) error {
+	args := m.Called(file)
+	return args.Error(0)
+}
+
+func (m *StoredFileRepositoryMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
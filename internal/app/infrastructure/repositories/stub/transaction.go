@@ -0,0 +1,51 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/mock"
+)
+
+type TransactionMock struct {
+	mock.Mock
+}
+
+func (m *TransactionMock) RoomRepository() repositories.RoomRepository {
+	args := m.Called()
+	return args.Get(0).(repositories.RoomRepository)
+}
+
+func (m *TransactionMock) BoxRepository() repositories.BoxRepository {
+	args := m.Called()
+	return args.Get(0).(repositories.BoxRepository)
+}
+
+func (m *TransactionMock) OutboxRepository() repositories.OutboxRepository {
+	args := m.Called()
+	return args.Get(0).(repositories.OutboxRepository)
+}
+
+func (m *TransactionMock) AssetRepository() repositories.AssetRepository {
+	args := m.Called()
+	return args.Get(0).(repositories.AssetRepository)
+}
+
+func (m *TransactionMock) StoredFileRepository() repositories.StoredFileRepository {
+	args := m.Called()
+	return args.Get(0).(repositories.StoredFileRepository)
+}
+
+// TransactionManagerMock runs fn with the transaction given to Return, and
+// returns the error of fn when Return has no error.
+type TransactionManagerMock struct {
+	mock.Mock
+}
+
+func (m *TransactionManagerMock) Transaction(fn func(tx repositories.Transaction) error) error {
+	args := m.Called()
+
+	if err := args.Error(1); err != nil {
+		return err
+	}
+
+	return fn(args.Get(0).(repositories.Transaction))
+}
@@ -0,0 +1,55 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type TwoFactorRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *TwoFactorRepositoryMock) Create(twoFactor *entities.TwoFactor) error {
+	args := m.Called(twoFactor)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) GetByUserID(userID string) (*entities.TwoFactor, error) {
+	args := m.Called(userID)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.TwoFactor), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *TwoFactorRepositoryMock) Update(twoFactor *entities.TwoFactor) error {
+	args := m.Called(twoFactor)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) UseStep(id string, step int64) error {
+	args := m.Called(id, step)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) CreateRecoveryCodes(codes []*entities.RecoveryCode) error {
+	args := m.Called(codes)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) UseRecoveryCode(userID string, codeHash string) error {
+	args := m.Called(userID, codeHash)
+	return args.Error(0)
+}
+
+func (m *TwoFactorRepositoryMock) DeleteRecoveryCodesByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
@@ -24,8 +24,8 @@ func (m *UserRepositoryMock) FindByEmail(email string) (*entities.User, error) {
 	return nil, args.Error(1)
 }
 
-func (m *UserRepositoryMock) GetUserByBoxID(boxID string) (*entities.User, error) {
-	args := m.Called(boxID)
+func (m *UserRepositoryMock) GetByID(id string) (*entities.User, error) {
+	args := m.Called(id)
 
 	if args.Get(0) != nil {
 		return args.Get(0).(*entities.User), args.Error(1)
@@ -33,3 +33,23 @@ func (m *UserRepositoryMock) GetUserByBoxID(boxID string) (*entities.User, error
 
 	return nil, args.Error(1)
 }
+
+func (m *UserRepositoryMock) GetUsersByBoxID(boxID string) ([]*entities.User, error) {
+	args := m.Called(boxID)
+
+	if args.Get(0) != nil {
+		return args.Get(0).([]*entities.User), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *UserRepositoryMock) Update(user *entities.User) error {
+	args := m.Called(user)
+	return args.Error(0)
+}
+
+func (m *UserRepositoryMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
@@ -0,0 +1,30 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/stretchr/testify/mock"
+)
+
+type UserIdentityRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *UserIdentityRepositoryMock) Create(identity *entities.UserIdentity) error {
+	args := m.Called(identity)
+	return args.Error(0)
+}
+
+func (m *UserIdentityRepositoryMock) GetByIssuerAndSubject(issuer string, subject string) (*entities.UserIdentity, error) {
+	args := m.Called(issuer, subject)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.UserIdentity), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *UserIdentityRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
@@ -0,0 +1,114 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type WebhookRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *WebhookRepositoryMock) Create(webhook *entities.Webhook) error {
+	args := m.Called(webhook)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) GetByID(id string) (*entities.Webhook, error) {
+	args := m.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.Webhook), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) GetByUserID(userID string) ([]*entities.Webhook, error) {
+	args := m.Called(userID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.Webhook), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) GetByHouseholdID(householdID string) ([]*entities.Webhook, error) {
+	args := m.Called(householdID)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.Webhook), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *WebhookRepositoryMock) Delete(id string) error {
+	args := m.Called(id)
+	return args.Error(0)
+}
+
+func (m *WebhookRepositoryMock) DeleteByUserID(userID string) error {
+	args := m.Called(userID)
+	return args.Error(0)
+}
+
+type WebhookDeliveryRepositoryMock struct {
+	mock.Mock
+}
+
+func (m *WebhookDeliveryRepositoryMock) Create(delivery *entities.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *WebhookDeliveryRepositoryMock) GetByID(id string) (*entities.WebhookDelivery, error) {
+	args := m.Called(id)
+
+	if data := args.Get(0); data != nil {
+		return data.(*entities.WebhookDelivery), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *WebhookDeliveryRepositoryMock) GetAvailable(now time.Time, limit int) ([]*entities.WebhookDelivery, error) {
+	args := m.Called(now, limit)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.WebhookDelivery), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *WebhookDeliveryRepositoryMock) Claim(delivery *entities.WebhookDelivery, now time.Time, until time.Time) error {
+	args := m.Called(delivery, now, until)
+	return args.Error(0)
+}
+
+func (m *WebhookDeliveryRepositoryMock) Update(delivery *entities.WebhookDelivery) error {
+	args := m.Called(delivery)
+	return args.Error(0)
+}
+
+func (m *WebhookDeliveryRepositoryMock) GetByWebhookID(
+	webhookID string,
+	pageFilter *repositories.PageFilter,
+) ([]*entities.WebhookDelivery, error) {
+	args := m.Called(webhookID, pageFilter)
+
+	if data := args.Get(0); data != nil {
+		return data.([]*entities.WebhookDelivery), args.Error(1)
+	}
+
+	return nil, args.Error(1)
+}
+
+func (m *WebhookDeliveryRepositoryMock) CountByWebhookID(webhookID string) (int64, error) {
+	args := m.Called(webhookID)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,65 @@
+package aesgcm
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+)
+
+// keyLabel derives the encryption key from the secret, so the same secret can
+// also sign values without both uses sharing a key.
+const keyLabel = "aes-gcm encryption key"
+
+type Cipher struct {
+	aead cipher.AEAD
+}
+
+func NewCipher(secret string) *Cipher {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(keyLabel))
+
+	block, err := aes.NewCipher(mac.Sum(nil))
+	if err != nil {
+		panic(err)
+	}
+
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		panic(err)
+	}
+
+	return &Cipher{
+		aead,
+	}
+}
+
+// Encrypt returns the value encrypted with AES-256-GCM under a random nonce,
+// encoded in base64 after the nonce.
+func (c *Cipher) Encrypt(value string) (string, error) {
+	nonce := make([]byte, c.aead.NonceSize())
+	if _, err := rand.Read(nonce); err != nil {
+		return "", err
+	}
+
+	sealed := c.aead.Seal(nonce, nonce, []byte(value), nil)
+	return base64.StdEncoding.EncodeToString(sealed), nil
+}
+
+func (c *Cipher) Decrypt(encrypted string) (string, error) {
+	sealed, err := base64.StdEncoding.DecodeString(encrypted)
+	if err != nil || len(sealed) < c.aead.NonceSize() {
+		return "", services.ErrCipherCanNotDecrypt
+	}
+
+	nonce, ciphertext := sealed[:c.aead.NonceSize()], sealed[c.aead.NonceSize():]
+	value, err := c.aead.Open(nil, nonce, ciphertext, nil)
+	if err != nil {
+		return "", services.ErrCipherCanNotDecrypt
+	}
+
+	return string(value), nil
+}
@@ -0,0 +1,65 @@
+package aesgcm
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/assert"
+	"strings"
+	"testing"
+)
+
+func TestCipherEncryptAndDecrypt(t *testing.T) {
+	cipher := NewCipher("secret-key")
+	value := "https://example.com/reset-password?token=secret"
+
+	encrypted, err := cipher.Encrypt(value)
+
+	assert.NoError(t, err)
+	assert.NotContains(t, encrypted, "token")
+
+	decrypted, err := cipher.Decrypt(encrypted)
+
+	assert.NoError(t, err)
+	assert.Equal(t, value, decrypted)
+}
+
+func TestCipherEncryptUsesRandomNonce(t *testing.T) {
+	cipher := NewCipher("secret-key")
+
+	first, err := cipher.Encrypt("value")
+	assert.NoError(t, err)
+	second, err := cipher.Encrypt("value")
+	assert.NoError(t, err)
+
+	assert.NotEqual(t, first, second)
+}
+
+func TestCipherDecryptErrorOtherSecret(t *testing.T) {
+	encrypted, err := NewCipher("secret-key").Encrypt("value")
+	assert.NoError(t, err)
+
+	decrypted, err := NewCipher("other-key").Decrypt(encrypted)
+
+	assert.ErrorIs(t, err, services.ErrCipherCanNotDecrypt)
+	assert.Empty(t, decrypted)
+}
+
+func TestCipherDecryptErrorInvalidValue(t *testing.T) {
+	cipher := NewCipher("secret-key")
+	encrypted, err := cipher.Encrypt("value")
+	assert.NoError(t, err)
+
+	testCases := map[string]string{
+		"plain text": "value",
+		"too short":  "AAAA",
+		"tampered":   strings.ToLower(encrypted),
+	}
+
+	for name, value := range testCases {
+		t.Run(name, func(t *testing.T) {
+			decrypted, err := cipher.Decrypt(value)
+
+			assert.ErrorIs(t, err, services.ErrCipherCanNotDecrypt)
+			assert.Empty(t, decrypted)
+		})
+	}
+}
@@ -0,0 +1,174 @@
+package argon2id
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"fmt"
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"sync"
+)
+
+const (
+	saltLength = 16
+	keyLength  = 32
+)
+
+// PasswordHasher hashes passwords with Argon2id in the PHC string format,
+// like $argon2id$v=19$m=65536,t=3,p=2$salt$hash. It also verifies the bcrypt
+// hashes stored before, and asks to rehash them.
+type PasswordHasher struct {
+	params  params
+	limiter *memoryLimiter
+}
+
+type params struct {
+	memory      uint32
+	iterations  uint32
+	parallelism uint8
+}
+
+// NewPasswordHasher takes the memory in KiB. The hashes computed at the same
+// time use up to maxMemory KiB together, the others wait, so a burst of logins
+// can not run the API out of memory.
+func NewPasswordHasher(memory uint32, iterations uint32, parallelism uint8, maxMemory uint32) *PasswordHasher {
+	return &PasswordHasher{
+		params{
+			memory,
+			iterations,
+			parallelism,
+		},
+		newMemoryLimiter(maxMemory),
+	}
+}
+
+func (h *PasswordHasher) Hash(password string) (string, error) {
+	salt := make([]byte, saltLength)
+	_, err := rand.Read(salt)
+	if err != nil {
+		return "", err
+	}
+
+	key := h.idKey([]byte(password), salt, h.params, keyLength)
+
+	return fmt.Sprintf(
+		"$argon2id$v=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2.Version,
+		h.params.memory,
+		h.params.iterations,
+		h.params.parallelism,
+		base64.RawStdEncoding.EncodeToString(salt),
+		base64.RawStdEncoding.EncodeToString(key),
+	), nil
+}
+
+// Verify checks the password with the parameters stored in the hash, not
+// with the current ones, so hashes keep working after a change of settings.
+func (h *PasswordHasher) Verify(password string, hash string) bool {
+	if isBcrypt(hash) {
+		return bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)) == nil
+	}
+
+	p, salt, key, ok := decode(hash)
+	if !ok {
+		return false
+	}
+
+	other := h.idKey([]byte(password), salt, *p, uint32(len(key)))
+
+	return subtle.ConstantTimeCompare(key, other) == 1
+}
+
+func (h *PasswordHasher) NeedsRehash(hash string) bool {
+	p, _, _, ok := decode(hash)
+	if !ok {
+		return true
+	}
+
+	return *p != h.params
+}
+
+func (h *PasswordHasher) idKey(password []byte, salt []byte, p params, length uint32) []byte {
+	weight := h.limiter.acquire(p.memory)
+	defer h.limiter.release(weight)
+
+	return argon2.IDKey(password, salt, p.iterations, p.memory, p.parallelism, length)
+}
+
+func isBcrypt(hash string) bool {
+	return strings.HasPrefix(hash, "$2a$") || strings.HasPrefix(hash, "$2b$") || strings.HasPrefix(hash, "$2y$")
+}
+
+func decode(hash string) (*params, []byte, []byte, bool) {
+	parts := strings.Split(hash, "$")
+	if len(parts) != 6 || parts[1] != "argon2id" {
+		return nil, nil, nil, false
+	}
+
+	var version int
+	_, err := fmt.Sscanf(parts[2], "v=%d", &version)
+	if err != nil || version != argon2.Version {
+		return nil, nil, nil, false
+	}
+
+	p := &params{}
+	_, err = fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &p.memory, &p.iterations, &p.parallelism)
+	if err != nil || p.iterations == 0 || p.parallelism == 0 {
+		return nil, nil, nil, false
+	}
+
+	salt, err := base64.RawStdEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, false
+	}
+
+	key, err := base64.RawStdEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, false
+	}
+
+	return p, salt, key, true
+}
+
+// memoryLimiter is a semaphore weighted by the KiB each hash uses.
+type memoryLimiter struct {
+	mu        sync.Mutex
+	cond      *sync.Cond
+	capacity  uint32
+	available uint32
+}
+
+func newMemoryLimiter(capacity uint32) *memoryLimiter {
+	l := &memoryLimiter{capacity: capacity, available: capacity}
+	l.cond = sync.NewCond(&l.mu)
+	return l
+}
+
+// acquire waits until memory KiB are available and returns the weight to
+// release. A hash that needs more than the capacity waits for all of it.
+func (l *memoryLimiter) acquire(memory uint32) uint32 {
+	weight := memory
+	if weight > l.capacity {
+		weight = l.capacity
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for l.available < weight {
+		l.cond.Wait()
+	}
+	l.available -= weight
+
+	return weight
+}
+
+func (l *memoryLimiter) release(weight uint32) {
+	l.mu.Lock()
+	l.available += weight
+	l.mu.Unlock()
+
+	l.cond.Broadcast()
+}
@@ -0,0 +1,122 @@
+package argon2id
+
+import (
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/crypto/bcrypt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestPasswordHasherHashAndVerify(t *testing.T) {
+	passwordHasher := NewPasswordHasher(1024, 1, 1, 4096)
+
+	hash, err := passwordHasher.Hash("secret123")
+
+	assert.NoError(t, err)
+	assert.True(t, strings.HasPrefix(hash, "$argon2id$v=19$m=1024,t=1,p=1$"))
+	assert.True(t, passwordHasher.Verify("secret123", hash))
+	assert.False(t, passwordHasher.Verify("secret124", hash))
+	assert.False(t, passwordHasher.NeedsRehash(hash))
+}
+
+func TestPasswordHasherHashUsesRandomSalt(t *testing.T) {
+	passwordHasher := NewPasswordHasher(1024, 1, 1, 4096)
+
+	hash1, err1 := passwordHasher.Hash("secret123")
+	hash2, err2 := passwordHasher.Hash("secret123")
+
+	assert.NoError(t, err1)
+	assert.NoError(t, err2)
+	assert.NotEqual(t, hash1, hash2)
+}
+
+func TestPasswordHasherHashDoesNotTruncateLongPasswords(t *testing.T) {
+	passwordHasher := NewPasswordHasher(1024, 1, 1, 4096)
+	password := strings.Repeat("a1", 40)
+
+	hash, err := passwordHasher.Hash(password)
+
+	assert.NoError(t, err)
+	assert.False(t, passwordHasher.Verify(password+"b", hash))
+}
+
+func TestPasswordHasherVerifyWithPreviousParameters(t *testing.T) {
+	hash, err := NewPasswordHasher(1024, 1, 1, 4096).Hash("secret123")
+	assert.NoError(t, err)
+
+	passwordHasher := NewPasswordHasher(2048, 2, 1, 4096)
+
+	assert.True(t, passwordHasher.Verify("secret123", hash))
+	assert.True(t, passwordHasher.NeedsRehash(hash))
+}
+
+func TestPasswordHasherVerifyBcrypt(t *testing.T) {
+	hash, err := bcrypt.GenerateFromPassword([]byte("secret123"), bcrypt.MinCost)
+	assert.NoError(t, err)
+
+	passwordHasher := NewPasswordHasher(1024, 1, 1, 4096)
+
+	assert.True(t, passwordHasher.Verify("secret123", string(hash)))
+	assert.False(t, passwordHasher.Verify("secret124", string(hash)))
+	assert.True(t, passwordHasher.NeedsRehash(string(hash)))
+}
+
+func TestPasswordHasherVerifyInvalidHash(t *testing.T) {
+	passwordHasher := NewPasswordHasher(1024, 1, 1, 4096)
+
+	for _, hash := range []string{
+		"",
+		"plain",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=18$m=1024,t=1,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=0,p=1$c2FsdA$a2V5",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$",
+	} {
+		assert.False(t, passwordHasher.Verify("secret123", hash), hash)
+		assert.True(t, passwordHasher.NeedsRehash(hash), hash)
+	}
+}
+
+func TestPasswordHasherLimitsConcurrentMemory(t *testing.T) {
+	passwordHasher := NewPasswordHasher(1024, 1, 1, 2048)
+
+	var wg sync.WaitGroup
+	for i := 0; i < 8; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hash, err := passwordHasher.Hash("secret123")
+			assert.NoError(t, err)
+			assert.True(t, passwordHasher.Verify("secret123", hash))
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, uint32(2048), passwordHasher.limiter.available)
+}
+
+func TestMemoryLimiterAcquire(t *testing.T) {
+	limiter := newMemoryLimiter(2048)
+
+	weight := limiter.acquire(1024)
+	assert.Equal(t, uint32(1024), weight)
+	assert.Equal(t, uint32(1024), limiter.available)
+
+	acquired := make(chan uint32)
+	go func() {
+		acquired <- limiter.acquire(4096)
+	}()
+
+	select {
+	case <-acquired:
+		t.Fatal("acquired more memory than available")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	limiter.release(weight)
+
+	assert.Equal(t, uint32(2048), <-acquired)
+	assert.Equal(t, uint32(0), limiter.available)
+}
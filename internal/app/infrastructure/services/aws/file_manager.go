@@ -1,16 +1,20 @@
 package aws
 
 import (
+	"errors"
 	"fmt"
 	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/aws/awserr"
 	"github.com/aws/aws-sdk-go/aws/credentials"
 	"github.com/aws/aws-sdk-go/aws/session"
 	"github.com/aws/aws-sdk-go/service/s3"
 	"github.com/aws/aws-sdk-go/service/s3/s3manager"
 	"github.com/google/uuid"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
-	"os"
+	"io"
+	"net/http"
 	"path/filepath"
+	"strings"
 )
 
 type FileManager struct {
@@ -68,12 +72,12 @@ func (m *FileManager) getNewS3Client() (*s3.S3, error) {
 	return s3.New(sess), nil
 }
 
-func (m *FileManager) Upload(file *os.File) (string, error) {
-	_, err := file.Seek(0, 0)
-	if err != nil {
-		return "", services.ErrFileManagerCanNotSeekFile
-	}
-
+func (m *FileManager) Upload(
+	content io.Reader,
+	size int64,
+	contentType string,
+	extension string,
+) (string, error) {
 	uploader, err := m.getUploader()
 	if err != nil {
 		return "", services.ErrFileManagerCanNotUploadFile
@@ -81,12 +85,15 @@ func (m *FileManager) Upload(file *os.File) (string, error) {
 
 	id := uuid.NewString()
 
-	extension := filepath.Ext(file.Name())
+	// The content is streamed in parts, the part size grows with the known size so
+	// large files stay under the S3 parts limit.
+	uploader.PartSize = max(s3manager.DefaultUploadPartSize, size/s3manager.MaxUploadParts+1)
 
 	_, err = uploader.Upload(&s3manager.UploadInput{
-		Bucket: aws.String(m.bucketName),
-		Key:    aws.String(id + extension),
-		Body:   file,
+		Bucket:      aws.String(m.bucketName),
+		Key:         aws.String(id + extension),
+		Body:        content,
+		ContentType: aws.String(contentType),
 	})
 	if err != nil {
 		return "", services.ErrFileManagerUploadingFile
@@ -127,3 +134,74 @@ func (m *FileManager) Delete(id string, extension string) error {
 
 	return nil
 }
+
+func (m *FileManager) List() ([]services.StoredFile, error) {
+	client, err := m.getNewS3Client()
+	if err != nil {
+		return nil, services.ErrFileManagerCanNotListFiles
+	}
+
+	files := make([]services.StoredFile, 0)
+	err = client.ListObjectsV2Pages(&s3.ListObjectsV2Input{
+		Bucket: aws.String(m.bucketName),
+	}, func(page *s3.ListObjectsV2Output, lastPage bool) bool {
+		for _, object := range page.Contents {
+			key := aws.StringValue(object.Key)
+			extension := filepath.Ext(key)
+
+			files = append(files, services.StoredFile{
+				ID:           strings.TrimSuffix(key, extension),
+				Extension:    extension,
+				LastModified: aws.TimeValue(object.LastModified),
+			})
+		}
+
+		return true
+	})
+	if err != nil {
+		return nil, services.ErrFileManagerCanNotListFiles
+	}
+
+	return files, nil
+}
+
+func (m *FileManager) Open(id string, extension string) (io.ReadSeekCloser, error) {
+	client, err := m.getNewS3Client()
+	if err != nil {
+		return nil, services.ErrFileManagerCanNotOpenFile
+	}
+
+	key := id + extension
+	head, err := client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(m.bucketName),
+		Key:    aws.String(key),
+	})
+	if err != nil {
+		return nil, services.ErrFileManagerCanNotOpenFile
+	}
+
+	return newObjectReader(client, m.bucketName, key, aws.Int64Value(head.ContentLength)), nil
+}
+
+func (m *FileManager) Exists(id string, extension string) (bool, error) {
+	client, err := m.getNewS3Client()
+	if err != nil {
+		return false, services.ErrFileManagerCanNotCheckFile
+	}
+
+	_, err = client.HeadObject(&s3.HeadObjectInput{
+		Bucket: aws.String(m.bucketName),
+		Key:    aws.String(id + extension),
+	})
+
+	var requestErr awserr.RequestFailure
+	if errors.As(err, &requestErr) && requestErr.StatusCode() == http.StatusNotFound {
+		return false, nil
+	}
+
+	if err != nil {
+		return false, services.ErrFileManagerCanNotCheckFile
+	}
+
+	return true, nil
+}
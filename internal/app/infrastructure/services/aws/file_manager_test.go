@@ -8,6 +8,7 @@ import (
 	"github.com/stretchr/testify/assert"
 	"log"
 	"os"
+	"path/filepath"
 	"testing"
 )
 
@@ -33,7 +34,10 @@ func TestFileManagerUpload(t *testing.T) {
 	assert.NoError(t, err)
 	assert.NotEmpty(t, file)
 
-	id, err := manager.Upload(file)
+	info, err := file.Stat()
+	assert.NoError(t, err)
+
+	id, err := manager.Upload(file, info.Size(), "application/octet-stream", filepath.Ext(filePath))
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, id)
@@ -106,3 +110,25 @@ func TestFileManagerDeleteErrorCanNotDeleteFile(t *testing.T) {
 	assert.Error(t, err)
 	assert.ErrorIs(t, err, services.ErrFileManagerCanNotDeleteFile)
 }
+
+func TestFileManagerList(t *testing.T) {
+	accessKey := os.Getenv("AWS_ACCESS_KEY_ID")
+	secretKey := os.Getenv("AWS_SECRET_ACCESS_KEY")
+	region := os.Getenv("AWS_REGION")
+	bucketName := os.Getenv("S3_BUCKET_NAME")
+
+	if accessKey == "" ||
+		secretKey == "" ||
+		region == "" ||
+		bucketName == "" {
+		log.Println("all required environment variables are not set")
+		return
+	}
+
+	manager := NewFileManager(accessKey, secretKey, region, bucketName)
+
+	files, err := manager.List()
+
+	assert.NoError(t, err)
+	assert.NotNil(t, files)
+}
@@ -0,0 +1,105 @@
+package aws
+
+import (
+	"errors"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"io"
+)
+
+var (
+	errObjectReaderInvalidWhence = errors.New("invalid whence")
+	errObjectReaderNegativeSeek  = errors.New("negative position")
+)
+
+type objectGetter interface {
+	GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error)
+}
+
+// objectReader reads an S3 object lazily. Seeking only moves the offset, the
+// next read requests the remaining bytes from that offset with a Range header.
+type objectReader struct {
+	client objectGetter
+	bucket string
+	key    string
+	size   int64
+	offset int64
+	body   io.ReadCloser
+}
+
+func newObjectReader(client objectGetter, bucket string, key string, size int64) *objectReader {
+	return &objectReader{
+		client: client,
+		bucket: bucket,
+		key:    key,
+		size:   size,
+	}
+}
+
+func (r *objectReader) Read(p []byte) (int, error) {
+	if r.offset >= r.size {
+		return 0, io.EOF
+	}
+
+	if r.body == nil {
+		output, err := r.client.GetObject(&s3.GetObjectInput{
+			Bucket: aws.String(r.bucket),
+			Key:    aws.String(r.key),
+			Range:  aws.String(fmt.Sprintf("bytes=%d-", r.offset)),
+		})
+		if err != nil {
+			return 0, err
+		}
+
+		r.body = output.Body
+	}
+
+	n, err := r.body.Read(p)
+	r.offset += int64(n)
+
+	return n, err
+}
+
+func (r *objectReader) Seek(offset int64, whence int) (int64, error) {
+	var position int64
+
+	switch whence {
+	case io.SeekStart:
+		position = offset
+	case io.SeekCurrent:
+		position = r.offset + offset
+	case io.SeekEnd:
+		position = r.size + offset
+	default:
+		return 0, errObjectReaderInvalidWhence
+	}
+
+	if position < 0 {
+		return 0, errObjectReaderNegativeSeek
+	}
+
+	if position != r.offset {
+		if err := r.closeBody(); err != nil {
+			return 0, err
+		}
+		r.offset = position
+	}
+
+	return position, nil
+}
+
+func (r *objectReader) Close() error {
+	return r.closeBody()
+}
+
+func (r *objectReader) closeBody() error {
+	if r.body == nil {
+		return nil
+	}
+
+	err := r.body.Close()
+	r.body = nil
+
+	return err
+}
@@ -0,0 +1,85 @@
+package aws
+
+import (
+	"bytes"
+	"fmt"
+	"github.com/aws/aws-sdk-go/aws"
+	"github.com/aws/aws-sdk-go/service/s3"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"testing"
+)
+
+type objectGetterFake struct {
+	content []byte
+	ranges  []string
+}
+
+func (f *objectGetterFake) GetObject(input *s3.GetObjectInput) (*s3.GetObjectOutput, error) {
+	var start int
+	_, err := fmt.Sscanf(aws.StringValue(input.Range), "bytes=%d-", &start)
+	if err != nil {
+		return nil, err
+	}
+
+	f.ranges = append(f.ranges, aws.StringValue(input.Range))
+
+	return &s3.GetObjectOutput{
+		Body: io.NopCloser(bytes.NewReader(f.content[start:])),
+	}, nil
+}
+
+func TestObjectReaderRead(t *testing.T) {
+	client := &objectGetterFake{content: []byte("home inventory")}
+	reader := newObjectReader(client, "bucket", "key.txt", int64(len(client.content)))
+
+	content, err := io.ReadAll(reader)
+
+	assert.NoError(t, err)
+	assert.Equal(t, client.content, content)
+	assert.Equal(t, []string{"bytes=0-"}, client.ranges)
+	assert.NoError(t, reader.Close())
+}
+
+func TestObjectReaderSeek(t *testing.T) {
+	client := &objectGetterFake{content: []byte("home inventory")}
+	reader := newObjectReader(client, "bucket", "key.txt", int64(len(client.content)))
+
+	size, err := reader.Seek(0, io.SeekEnd)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(client.content)), size)
+
+	position, err := reader.Seek(5, io.SeekStart)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(5), position)
+
+	part := make([]byte, 3)
+	_, err = io.ReadFull(reader, part)
+	assert.NoError(t, err)
+	assert.Equal(t, "inv", string(part))
+
+	position, err = reader.Seek(-2, io.SeekCurrent)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(6), position)
+
+	rest, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, "nventory", string(rest))
+	assert.Equal(t, []string{"bytes=5-", "bytes=6-"}, client.ranges)
+}
+
+func TestObjectReaderSeekErrorNegativePosition(t *testing.T) {
+	reader := newObjectReader(&objectGetterFake{}, "bucket", "key.txt", 10)
+
+	_, err := reader.Seek(-1, io.SeekStart)
+
+	assert.ErrorIs(t, err, errObjectReaderNegativeSeek)
+}
+
+func TestObjectReaderSeekErrorInvalidWhence(t *testing.T) {
+	reader := newObjectReader(&objectGetterFake{}, "bucket", "key.txt", 10)
+
+	_, err := reader.Seek(0, 3)
+
+	assert.ErrorIs(t, err, errObjectReaderInvalidWhence)
+}
@@ -1,11 +1,20 @@
 package gmail
 
 import (
+	"bytes"
 	"fmt"
+	"github.com/google/uuid"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/jibaru/home-inventory-api/m/logger"
 	"github.com/jibaru/home-inventory-api/m/notifier"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
 	"net/smtp"
+	"net/textproto"
+	"strings"
+	"time"
 )
 
 type MailSender struct {
@@ -13,6 +22,7 @@ type MailSender struct {
 	smtpPort     int
 	smtpEmail    string
 	smtpPassword string
+	smtpFromName string
 }
 
 func NewMailSender(
@@ -20,12 +30,14 @@ func NewMailSender(
 	smtpPort int,
 	smtpEmail string,
 	smtpPassword string,
+	smtpFromName string,
 ) *MailSender {
 	return &MailSender{
 		smtpHost:     smtpHost,
 		smtpPort:     smtpPort,
 		smtpEmail:    smtpEmail,
 		smtpPassword: smtpPassword,
+		smtpFromName: smtpFromName,
 	}
 }
 
@@ -34,16 +46,25 @@ func (ms *MailSender) hostPort() string {
 }
 
 func (ms *MailSender) SendMail(to string, subject string, body string) error {
-	from := ms.smtpEmail
+	return ms.Send(services.Mail{
+		To:      to,
+		Subject: subject,
+		Text:    body,
+	})
+}
 
-	auth := smtp.PlainAuth("", from, ms.smtpPassword, ms.smtpHost)
+func (ms *MailSender) Send(m services.Mail) error {
+	from := mail.Address{Name: ms.smtpFromName, Address: ms.smtpEmail}
 
-	msg := []byte(
-		"To: " + to + "\r\n" +
-			"Subject: " + subject + "\r\n" +
-			"\r\n" + body + "\r\n")
+	msg, err := buildMessage(from, m, time.Now(), makeMessageID(ms.smtpEmail))
+	if err != nil {
+		logger.LogError(err)
+		return services.ErrMailSenderCannotSendMail
+	}
 
-	err := smtp.SendMail(ms.hostPort(), auth, from, []string{to}, msg)
+	auth := smtp.PlainAuth("", ms.smtpEmail, ms.smtpPassword, ms.smtpHost)
+
+	err = smtp.SendMail(ms.hostPort(), auth, ms.smtpEmail, []string{m.To}, msg)
 	if err != nil {
 		logger.LogError(err)
 		notifier.NotifyError(err)
@@ -52,3 +73,95 @@ func (ms *MailSender) SendMail(to string, subject string, body string) error {
 
 	return nil
 }
+
+// makeMessageID returns a unique message id on the domain of the email the
+// mails are sent from.
+func makeMessageID(email string) string {
+	domain := "localhost"
+	if at := strings.LastIndex(email, "@"); at != -1 && at < len(email)-1 {
+		domain = email[at+1:]
+	}
+
+	return "<" + uuid.NewString() + "@" + domain + ">"
+}
+
+// buildMessage builds the mail as a multipart/alternative message with the
+// plain text and html bodies, or as a plain text one when it has no html.
+func buildMessage(from mail.Address, m services.Mail, date time.Time, messageID string) ([]byte, error) {
+	var msg bytes.Buffer
+
+	msg.WriteString("From: " + from.String() + "\r\n")
+	msg.WriteString("To: " + m.To + "\r\n")
+	msg.WriteString("Subject: " + mime.QEncoding.Encode("utf-8", m.Subject) + "\r\n")
+	msg.WriteString("Date: " + date.Format(time.RFC1123Z) + "\r\n")
+	msg.WriteString("Message-ID: " + messageID + "\r\n")
+	msg.WriteString("MIME-Version: 1.0\r\n")
+
+	if m.HTML == "" {
+		msg.WriteString("Content-Type: text/plain; charset=utf-8\r\n")
+		msg.WriteString("Content-Transfer-Encoding: quoted-printable\r\n")
+		msg.WriteString("\r\n")
+
+		err := writeQuotedPrintable(&msg, m.Text)
+		if err != nil {
+			return nil, err
+		}
+
+		return msg.Bytes(), nil
+	}
+
+	var body bytes.Buffer
+	writer := multipart.NewWriter(&body)
+
+	msg.WriteString("Content-Type: multipart/alternative; boundary=" + writer.Boundary() + "\r\n")
+	msg.WriteString("\r\n")
+
+	parts := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", m.Text},
+		{"text/html; charset=utf-8", m.HTML},
+	}
+
+	for _, part := range parts {
+		partWriter, err := writer.CreatePart(textproto.MIMEHeader{
+			"Content-Type":              {part.contentType},
+			"Content-Transfer-Encoding": {"quoted-printable"},
+		})
+		if err != nil {
+			return nil, err
+		}
+
+		var content bytes.Buffer
+		err = writeQuotedPrintable(&content, part.content)
+		if err != nil {
+			return nil, err
+		}
+
+		_, err = partWriter.Write(content.Bytes())
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	err := writer.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	msg.Write(body.Bytes())
+
+	return msg.Bytes(), nil
+}
+
+func writeQuotedPrintable(buffer *bytes.Buffer, content string) error {
+	writer := quotedprintable.NewWriter(buffer)
+
+	_, err := writer.Write([]byte(content))
+	if err != nil {
+		return err
+	}
+
+	return writer.Close()
+}
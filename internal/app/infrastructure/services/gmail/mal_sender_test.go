@@ -1,9 +1,17 @@
 package gmail
 
 import (
+	"bytes"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/stretchr/testify/assert"
+	"io"
+	"mime"
+	"mime/multipart"
+	"mime/quotedprintable"
+	"net/mail"
 	"os"
 	"testing"
+	"time"
 )
 
 func TestMailSenderSendMail(t *testing.T) {
@@ -18,7 +26,7 @@ func TestMailSenderSendMail(t *testing.T) {
 		return
 	}
 
-	sender := NewMailSender(smtpHost, smtpPort, smtpEmail, smtpPassword)
+	sender := NewMailSender(smtpHost, smtpPort, smtpEmail, smtpPassword, "Home Inventory")
 
 	subject := "Test"
 	body := "Test of body"
@@ -27,3 +35,88 @@ func TestMailSenderSendMail(t *testing.T) {
 
 	assert.NoError(t, err)
 }
+
+func TestBuildMessageMultipart(t *testing.T) {
+	from := mail.Address{Name: "Home Inventory", Address: "inventory@example.com"}
+	date := time.Date(2026, 10, 19, 9, 20, 0, 0, time.UTC)
+	messageID := "<id@example.com>"
+
+	msg, err := buildMessage(from, services.Mail{
+		To:      "user@example.com",
+		Subject: "Screwdriver añadido",
+		Text:    "2 Screwdriver added",
+		HTML:    "<p>2 Screwdriver added</p>",
+	}, date, messageID)
+	assert.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	assert.NoError(t, err)
+
+	decoder := new(mime.WordDecoder)
+	subject, err := decoder.DecodeHeader(parsed.Header.Get("Subject"))
+	assert.NoError(t, err)
+
+	assert.Equal(t, "\"Home Inventory\" <inventory@example.com>", parsed.Header.Get("From"))
+	assert.Equal(t, "user@example.com", parsed.Header.Get("To"))
+	assert.Equal(t, "Screwdriver añadido", subject)
+	assert.Equal(t, messageID, parsed.Header.Get("Message-ID"))
+	assert.Equal(t, "1.0", parsed.Header.Get("MIME-Version"))
+
+	parsedDate, err := parsed.Header.Date()
+	assert.NoError(t, err)
+	assert.True(t, date.Equal(parsedDate))
+
+	mediaType, params, err := mime.ParseMediaType(parsed.Header.Get("Content-Type"))
+	assert.NoError(t, err)
+	assert.Equal(t, "multipart/alternative", mediaType)
+
+	reader := multipart.NewReader(parsed.Body, params["boundary"])
+	expected := []struct {
+		contentType string
+		content     string
+	}{
+		{"text/plain; charset=utf-8", "2 Screwdriver added"},
+		{"text/html; charset=utf-8", "<p>2 Screwdriver added</p>"},
+	}
+
+	for _, part := range expected {
+		p, err := reader.NextRawPart()
+		assert.NoError(t, err)
+		assert.Equal(t, part.contentType, p.Header.Get("Content-Type"))
+		assert.Equal(t, "quoted-printable", p.Header.Get("Content-Transfer-Encoding"))
+
+		content, err := io.ReadAll(quotedprintable.NewReader(p))
+		assert.NoError(t, err)
+		assert.Equal(t, part.content, string(content))
+	}
+
+	_, err = reader.NextPart()
+	assert.ErrorIs(t, err, io.EOF)
+}
+
+func TestBuildMessagePlainText(t *testing.T) {
+	from := mail.Address{Name: "Home Inventory", Address: "inventory@example.com"}
+
+	msg, err := buildMessage(from, services.Mail{
+		To:      "user@example.com",
+		Subject: "Verify your email",
+		Text:    "Your code is 123456",
+	}, time.Now(), "<id@example.com>")
+	assert.NoError(t, err)
+
+	parsed, err := mail.ReadMessage(bytes.NewReader(msg))
+	assert.NoError(t, err)
+	assert.Equal(t, "text/plain; charset=utf-8", parsed.Header.Get("Content-Type"))
+	assert.Equal(t, "quoted-printable", parsed.Header.Get("Content-Transfer-Encoding"))
+
+	content, err := io.ReadAll(quotedprintable.NewReader(parsed.Body))
+	assert.NoError(t, err)
+	assert.Equal(t, "Your code is 123456", string(content))
+}
+
+func TestMakeMessageID(t *testing.T) {
+	messageID := makeMessageID("inventory@example.com")
+
+	assert.Regexp(t, `^<[0-9a-f-]{36}@example\.com>$`, messageID)
+	assert.NotEqual(t, messageID, makeMessageID("inventory@example.com"))
+}
@@ -0,0 +1,69 @@
+package hmac
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"strconv"
+	"strings"
+	"time"
+)
+
+type Signer struct {
+	secret string
+}
+
+func NewSigner(secret string) *Signer {
+	return &Signer{
+		secret,
+	}
+}
+
+// Sign returns the value and its expiration encoded with an HMAC-SHA256
+// signature, the result is safe to use in urls.
+func (s *Signer) Sign(value string, expiresAt time.Time) string {
+	payload := base64.RawURLEncoding.EncodeToString(
+		[]byte(strconv.FormatInt(expiresAt.Unix(), 10) + ":" + value),
+	)
+
+	return payload + "." + s.signature(payload)
+}
+
+func (s *Signer) Verify(signed string) (string, error) {
+	payload, signature, found := strings.Cut(signed, ".")
+	if !found {
+		return "", services.ErrSignerSignatureIsNotValid
+	}
+
+	if !hmac.Equal([]byte(signature), []byte(s.signature(payload))) {
+		return "", services.ErrSignerSignatureIsNotValid
+	}
+
+	decoded, err := base64.RawURLEncoding.DecodeString(payload)
+	if err != nil {
+		return "", services.ErrSignerSignatureIsNotValid
+	}
+
+	expiration, value, found := strings.Cut(string(decoded), ":")
+	if !found {
+		return "", services.ErrSignerSignatureIsNotValid
+	}
+
+	expiresAt, err := strconv.ParseInt(expiration, 10, 64)
+	if err != nil {
+		return "", services.ErrSignerSignatureIsNotValid
+	}
+
+	if time.Now().Unix() >= expiresAt {
+		return "", services.ErrSignerSignatureExpired
+	}
+
+	return value, nil
+}
+
+func (s *Signer) signature(payload string) string {
+	mac := hmac.New(sha256.New, []byte(s.secret))
+	mac.Write([]byte(payload))
+	return base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
@@ -0,0 +1,70 @@
+package hmac
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/assert"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSignerSignAndVerify(t *testing.T) {
+	signer := NewSigner("secret-key")
+	value := "user-id:test@example.com"
+
+	signed := signer.Sign(value, time.Now().Add(time.Hour))
+
+	assert.Equal(t, signed, url.QueryEscape(signed))
+
+	verified, err := signer.Verify(signed)
+
+	assert.NoError(t, err)
+	assert.Equal(t, value, verified)
+}
+
+func TestSignerVerifyErrorSignatureExpired(t *testing.T) {
+	signer := NewSigner("secret-key")
+
+	signed := signer.Sign("value", time.Now().Add(-time.Second))
+
+	verified, err := signer.Verify(signed)
+
+	assert.ErrorIs(t, err, services.ErrSignerSignatureExpired)
+	assert.Empty(t, verified)
+}
+
+func TestSignerVerifyErrorOtherSecret(t *testing.T) {
+	signed := NewSigner("secret-key").Sign("value", time.Now().Add(time.Hour))
+
+	verified, err := NewSigner("other-key").Verify(signed)
+
+	assert.ErrorIs(t, err, services.ErrSignerSignatureIsNotValid)
+	assert.Empty(t, verified)
+}
+
+func TestSignerVerifyErrorTamperedPayload(t *testing.T) {
+	signer := NewSigner("secret-key")
+	signed := signer.Sign("value", time.Now().Add(time.Hour))
+	other := signer.Sign("other", time.Now().Add(time.Hour))
+
+	otherPayload, _, _ := strings.Cut(other, ".")
+	_, signature, _ := strings.Cut(signed, ".")
+	tampered := otherPayload + "." + signature
+
+	verified, err := signer.Verify(tampered)
+
+	assert.ErrorIs(t, err, services.ErrSignerSignatureIsNotValid)
+	assert.Empty(t, verified)
+}
+
+func TestSignerVerifyErrorMalformed(t *testing.T) {
+	signer := NewSigner("secret-key")
+
+	for _, signed := range []string{"", "no-dot", "a.b", "%%%.sig"} {
+		verified, err := signer.Verify(signed)
+
+		assert.ErrorIs(t, err, services.ErrSignerSignatureIsNotValid)
+		assert.Empty(t, verified)
+	}
+}
@@ -0,0 +1,83 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	exifOrientationTag    = 0x0112
+	exifShortType         = 3
+	exifNormalOrientation = 1
+)
+
+var exifHeader = []byte("Exif\x00\x00")
+
+// exifOrientation reads the orientation tag of the first IFD of an EXIF
+// payload. It returns the normal orientation when the tag is missing or the
+// payload can not be read.
+func exifOrientation(data []byte) int {
+	data = bytes.TrimPrefix(data, exifHeader)
+	if len(data) < 8 {
+		return exifNormalOrientation
+	}
+
+	var order binary.ByteOrder
+	switch string(data[:2]) {
+	case "II":
+		order = binary.LittleEndian
+	case "MM":
+		order = binary.BigEndian
+	default:
+		return exifNormalOrientation
+	}
+
+	if order.Uint16(data[2:4]) != 42 {
+		return exifNormalOrientation
+	}
+
+	offset := int64(order.Uint32(data[4:8]))
+	if offset < 8 || offset+2 > int64(len(data)) {
+		return exifNormalOrientation
+	}
+
+	count := int64(order.Uint16(data[offset:]))
+	for i := int64(0); i < count; i++ {
+		entry := offset + 2 + i*12
+		if entry+12 > int64(len(data)) {
+			return exifNormalOrientation
+		}
+
+		if order.Uint16(data[entry:]) != exifOrientationTag {
+			continue
+		}
+
+		if order.Uint16(data[entry+2:]) != exifShortType {
+			return exifNormalOrientation
+		}
+
+		orientation := int(order.Uint16(data[entry+8:]))
+		if orientation < 1 || orientation > 8 {
+			return exifNormalOrientation
+		}
+
+		return orientation
+	}
+
+	return exifNormalOrientation
+}
+
+// orientationOnlyExif builds a little endian EXIF payload whose only tag is
+// the orientation.
+func orientationOnlyExif(orientation int) []byte {
+	data := make([]byte, 26)
+	copy(data, "II*\x00")
+	binary.LittleEndian.PutUint32(data[4:], 8)
+	binary.LittleEndian.PutUint16(data[8:], 1)
+	binary.LittleEndian.PutUint16(data[10:], exifOrientationTag)
+	binary.LittleEndian.PutUint16(data[12:], exifShortType)
+	binary.LittleEndian.PutUint32(data[14:], 1)
+	binary.LittleEndian.PutUint16(data[18:], uint16(orientation))
+
+	return data
+}
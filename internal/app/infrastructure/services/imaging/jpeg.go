@@ -0,0 +1,105 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/jpeg"
+)
+
+const (
+	jpegStartOfScanMarker = 0xDA
+	jpegApp1Marker        = 0xE1
+	jpegApp2Marker        = 0xE2
+	jpegApp13Marker       = 0xED
+	jpegCommentMarker     = 0xFE
+)
+
+var (
+	jpegSignature  = []byte{0xFF, 0xD8, 0xFF}
+	jpegICCProfile = []byte("ICC_PROFILE\x00")
+)
+
+// removeJPEGMetadata drops the APP1 (EXIF and XMP), APP13 (IPTC) and COM
+// segments without touching the compressed data. The image is only re-encoded
+// when its EXIF orientation has to be applied to the pixels, and then the ICC
+// profile (APP2) segments are copied so the colors do not change.
+func removeJPEGMetadata(data []byte, quality int) ([]byte, error) {
+	if !bytes.HasPrefix(data, jpegSignature) {
+		return nil, errInvalidImage
+	}
+
+	orientation := exifNormalOrientation
+	var cleaned bytes.Buffer
+	var iccProfile bytes.Buffer
+	cleaned.Write(data[:2])
+
+	i := 2
+	for {
+		if i+2 > len(data) || data[i] != 0xFF {
+			return nil, errInvalidImage
+		}
+
+		marker := data[i+1]
+		if marker == 0xFF {
+			i++
+			continue
+		}
+
+		if marker == jpegStartOfScanMarker {
+			cleaned.Write(data[i:])
+			break
+		}
+
+		if marker == 0x01 || (marker >= 0xD0 && marker <= 0xD7) {
+			cleaned.Write(data[i : i+2])
+			i += 2
+			continue
+		}
+
+		if i+4 > len(data) {
+			return nil, errInvalidImage
+		}
+
+		length := int(binary.BigEndian.Uint16(data[i+2:]))
+		end := i + 2 + length
+		if length < 2 || end > len(data) {
+			return nil, errInvalidImage
+		}
+
+		payload := data[i+4 : end]
+		if marker == jpegApp1Marker && bytes.HasPrefix(payload, exifHeader) {
+			orientation = exifOrientation(payload)
+		}
+
+		if marker == jpegApp2Marker && bytes.HasPrefix(payload, jpegICCProfile) {
+			iccProfile.Write(data[i:end])
+		}
+
+		if marker != jpegApp1Marker && marker != jpegApp13Marker && marker != jpegCommentMarker {
+			cleaned.Write(data[i:end])
+		}
+
+		i = end
+	}
+
+	if orientation == exifNormalOrientation {
+		return cleaned.Bytes(), nil
+	}
+
+	img, err := jpeg.Decode(bytes.NewReader(cleaned.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded bytes.Buffer
+	err = jpeg.Encode(&encoded, applyOrientation(img, orientation), &jpeg.Options{Quality: quality})
+	if err != nil {
+		return nil, err
+	}
+
+	reencoded := make([]byte, 0, encoded.Len()+iccProfile.Len())
+	reencoded = append(reencoded, encoded.Bytes()[:2]...)
+	reencoded = append(reencoded, iccProfile.Bytes()...)
+
+	return append(reencoded, encoded.Bytes()[2:]...), nil
+}
@@ -0,0 +1,88 @@
+package imaging
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"image/jpeg"
+	"io"
+)
+
+const defaultImageMaxSize = 20 << 20
+
+var (
+	errInvalidImage = errors.New("invalid image")
+)
+
+// MetadataRemover removes EXIF, XMP and text metadata (which includes GPS
+// coordinates and device info) from JPEG, PNG and WebP images. Any other
+// content is streamed unchanged. Images are read in memory, so the ones bigger
+// than maxSize bytes are rejected.
+type MetadataRemover struct {
+	enabled     bool
+	jpegQuality int
+	maxSize     int64
+}
+
+func NewMetadataRemover(enabled bool, jpegQuality int, maxSize int64) *MetadataRemover {
+	if jpegQuality <= 0 || jpegQuality > 100 {
+		jpegQuality = jpeg.DefaultQuality
+	}
+
+	if maxSize <= 0 {
+		maxSize = defaultImageMaxSize
+	}
+
+	return &MetadataRemover{
+		enabled:     enabled,
+		jpegQuality: jpegQuality,
+		maxSize:     maxSize,
+	}
+}
+
+func (r *MetadataRemover) RemoveMetadata(content io.Reader, size int64) (io.Reader, int64, error) {
+	if !r.enabled {
+		return content, size, nil
+	}
+
+	reader := bufio.NewReader(content)
+	header, _ := reader.Peek(12)
+
+	var remove func(data []byte) ([]byte, error)
+	switch {
+	case bytes.HasPrefix(header, jpegSignature):
+		remove = func(data []byte) ([]byte, error) {
+			return removeJPEGMetadata(data, r.jpegQuality)
+		}
+	case bytes.HasPrefix(header, pngSignature):
+		remove = removePNGMetadata
+	case len(header) == 12 && string(header[:4]) == "RIFF" && string(header[8:]) == "WEBP":
+		remove = removeWebPMetadata
+	default:
+		return reader, size, nil
+	}
+
+	if size > r.maxSize {
+		return nil, 0, services.ErrImageProcessorImageTooLarge
+	}
+
+	data, err := io.ReadAll(io.LimitReader(reader, r.maxSize+1))
+	if err != nil {
+		logger.LogError(err)
+		return nil, 0, services.ErrImageProcessorCanNotProcessImage
+	}
+
+	if int64(len(data)) > r.maxSize {
+		return nil, 0, services.ErrImageProcessorImageTooLarge
+	}
+
+	cleaned, err := remove(data)
+	if err != nil {
+		logger.LogError(err)
+		return nil, 0, services.ErrImageProcessorCanNotProcessImage
+	}
+
+	return bytes.NewReader(cleaned), int64(len(cleaned)), nil
+}
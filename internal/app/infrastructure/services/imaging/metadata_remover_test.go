@@ -0,0 +1,259 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/assert"
+	"hash/crc32"
+	"image"
+	"image/color"
+	"image/jpeg"
+	"image/png"
+	"io"
+	"testing"
+)
+
+const gpsMarker = "GPSLatitude-12.0464"
+
+func makeTestImage(width int, height int) *image.RGBA {
+	img := image.NewRGBA(image.Rect(0, 0, width, height))
+	for y := 0; y < height; y++ {
+		for x := 0; x < width; x++ {
+			img.Set(x, y, color.RGBA{R: uint8(x * 60), G: uint8(y * 60), B: 0, A: 255})
+		}
+	}
+
+	return img
+}
+
+func makeExif(orientation int) []byte {
+	exif := append([]byte{}, exifHeader...)
+	exif = append(exif, orientationOnlyExif(orientation)...)
+	return append(exif, gpsMarker...)
+}
+
+func makeJPEGSegment(marker byte, payload []byte) []byte {
+	header := []byte{0xFF, marker, 0, 0}
+	binary.BigEndian.PutUint16(header[2:], uint16(len(payload)+2))
+	return append(header, payload...)
+}
+
+func makeJPEG(t *testing.T, orientation int) []byte {
+	var encoded bytes.Buffer
+	err := jpeg.Encode(&encoded, makeTestImage(4, 2), nil)
+	assert.NoError(t, err)
+
+	data := append([]byte{}, encoded.Bytes()[:2]...)
+	data = append(data, makeJPEGSegment(jpegApp1Marker, makeExif(orientation))...)
+	data = append(data, makeJPEGSegment(jpegApp1Marker, []byte("http://ns.adobe.com/xap/1.0/\x00<x:xmpmeta/>"))...)
+	data = append(data, makeJPEGSegment(jpegApp13Marker, []byte("Photoshop 3.0\x00"))...)
+	data = append(data, makeJPEGSegment(jpegApp2Marker, []byte("ICC_PROFILE\x00\x01\x01sRGB profile"))...)
+	data = append(data, makeJPEGSegment(jpegCommentMarker, []byte(gpsMarker))...)
+
+	return append(data, encoded.Bytes()[2:]...)
+}
+
+func makePNG(t *testing.T, orientation int) []byte {
+	var encoded bytes.Buffer
+	err := png.Encode(&encoded, makeTestImage(4, 2))
+	assert.NoError(t, err)
+
+	chunk := func(chunkType string, payload []byte) []byte {
+		data := make([]byte, 8, 12+len(payload))
+		binary.BigEndian.PutUint32(data, uint32(len(payload)))
+		copy(data[4:], chunkType)
+		data = append(data, payload...)
+		return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data[4:]))
+	}
+
+	// The IHDR chunk is always the first one and is 25 bytes long.
+	headerEnd := len(pngSignature) + 25
+	data := append([]byte{}, encoded.Bytes()[:headerEnd]...)
+	data = append(data, chunk("eXIf", makeExif(orientation)[len(exifHeader):])...)
+	data = append(data, chunk("tEXt", []byte("Comment\x00"+gpsMarker))...)
+	data = append(data, chunk("iTXt", []byte("XML:com.adobe.xmp\x00\x00\x00\x00\x00<x:xmpmeta/>"))...)
+
+	return append(data, encoded.Bytes()[headerEnd:]...)
+}
+
+func makeWebP(orientation int) []byte {
+	chunk := func(fourCC string, payload []byte) []byte {
+		data := make([]byte, 8, 9+len(payload))
+		copy(data, fourCC)
+		binary.LittleEndian.PutUint32(data[4:], uint32(len(payload)))
+		data = append(data, payload...)
+		if len(payload)%2 == 1 {
+			data = append(data, 0)
+		}
+		return data
+	}
+
+	vp8x := make([]byte, 10)
+	vp8x[0] = webpExifFlag | webpXmpFlag
+
+	var chunks []byte
+	chunks = append(chunks, chunk("VP8X", vp8x)...)
+	chunks = append(chunks, chunk("VP8L", []byte{0x2F, 1, 2, 3, 4})...)
+	chunks = append(chunks, chunk("EXIF", makeExif(orientation))...)
+	chunks = append(chunks, chunk("XMP ", []byte("<x:xmpmeta/>"))...)
+
+	data := make([]byte, 12)
+	copy(data, "RIFF")
+	binary.LittleEndian.PutUint32(data[4:], uint32(4+len(chunks)))
+	copy(data[8:], "WEBP")
+
+	return append(data, chunks...)
+}
+
+func removeMetadata(t *testing.T, data []byte) []byte {
+	remover := NewMetadataRemover(true, 90, 0)
+
+	content, size, err := remover.RemoveMetadata(bytes.NewReader(data), int64(len(data)))
+	assert.NoError(t, err)
+
+	cleaned, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(cleaned)), size)
+
+	return cleaned
+}
+
+func TestMetadataRemoverRemoveMetadataFromJPEG(t *testing.T) {
+	cleaned := removeMetadata(t, makeJPEG(t, exifNormalOrientation))
+
+	assert.NotContains(t, string(cleaned), "Exif")
+	assert.NotContains(t, string(cleaned), gpsMarker)
+	assert.NotContains(t, string(cleaned), "xmpmeta")
+	assert.NotContains(t, string(cleaned), "Photoshop")
+	assert.Contains(t, string(cleaned), "sRGB profile")
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(cleaned))
+	assert.NoError(t, err)
+	assert.Equal(t, 4, config.Width)
+	assert.Equal(t, 2, config.Height)
+}
+
+func TestMetadataRemoverRemoveMetadataFromJPEGApplyingOrientation(t *testing.T) {
+	cleaned := removeMetadata(t, makeJPEG(t, 6))
+
+	assert.NotContains(t, string(cleaned), "Exif")
+	assert.NotContains(t, string(cleaned), gpsMarker)
+	assert.Contains(t, string(cleaned), "ICC_PROFILE\x00\x01\x01sRGB profile")
+
+	config, err := jpeg.DecodeConfig(bytes.NewReader(cleaned))
+	assert.NoError(t, err)
+	assert.Equal(t, 2, config.Width)
+	assert.Equal(t, 4, config.Height)
+}
+
+func TestMetadataRemoverRemoveMetadataFromPNG(t *testing.T) {
+	cleaned := removeMetadata(t, makePNG(t, exifNormalOrientation))
+
+	assert.NotContains(t, string(cleaned), "eXIf")
+	assert.NotContains(t, string(cleaned), gpsMarker)
+	assert.NotContains(t, string(cleaned), "xmpmeta")
+
+	img, err := png.Decode(bytes.NewReader(cleaned))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 4, 2), img.Bounds())
+}
+
+func TestMetadataRemoverRemoveMetadataFromPNGApplyingOrientation(t *testing.T) {
+	cleaned := removeMetadata(t, makePNG(t, 8))
+
+	assert.NotContains(t, string(cleaned), gpsMarker)
+
+	img, err := png.Decode(bytes.NewReader(cleaned))
+	assert.NoError(t, err)
+	assert.Equal(t, image.Rect(0, 0, 2, 4), img.Bounds())
+	// Rotating 90 degrees counterclockwise moves the top right pixel to the top left.
+	assert.Equal(t, makeTestImage(4, 2).At(3, 0), color.RGBAModel.Convert(img.At(0, 0)))
+}
+
+func TestMetadataRemoverRemoveMetadataFromWebP(t *testing.T) {
+	cleaned := removeMetadata(t, makeWebP(exifNormalOrientation))
+
+	assert.NotContains(t, string(cleaned), "EXIF")
+	assert.NotContains(t, string(cleaned), "XMP ")
+	assert.NotContains(t, string(cleaned), gpsMarker)
+	assert.Equal(t, uint32(len(cleaned)-8), binary.LittleEndian.Uint32(cleaned[4:]))
+	assert.Equal(t, byte(0), cleaned[20]&(webpExifFlag|webpXmpFlag))
+	assert.Contains(t, string(cleaned), "VP8L")
+}
+
+func TestMetadataRemoverRemoveMetadataFromWebPKeepingOrientation(t *testing.T) {
+	cleaned := removeMetadata(t, makeWebP(6))
+
+	assert.NotContains(t, string(cleaned), "XMP ")
+	assert.NotContains(t, string(cleaned), gpsMarker)
+	assert.Equal(t, uint32(len(cleaned)-8), binary.LittleEndian.Uint32(cleaned[4:]))
+	assert.Equal(t, byte(webpExifFlag), cleaned[20]&(webpExifFlag|webpXmpFlag))
+	assert.Contains(t, string(cleaned), "VP8L")
+
+	exifStart := bytes.Index(cleaned, []byte("EXIF"))
+	assert.NotEqual(t, -1, exifStart)
+	length := binary.LittleEndian.Uint32(cleaned[exifStart+4:])
+	exif := cleaned[exifStart+8 : exifStart+8+int(length)]
+	assert.Equal(t, orientationOnlyExif(6), exif)
+	assert.Equal(t, 6, exifOrientation(exif))
+}
+
+func TestMetadataRemoverRemoveMetadataKeepsOtherContent(t *testing.T) {
+	remover := NewMetadataRemover(true, 90, 0)
+	data := []byte("%PDF-1.7 " + gpsMarker)
+
+	content, size, err := remover.RemoveMetadata(bytes.NewReader(data), int64(len(data)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+	got, err := io.ReadAll(content)
+	assert.NoError(t, err)
+	assert.Equal(t, data, got)
+}
+
+func TestMetadataRemoverRemoveMetadataDisabled(t *testing.T) {
+	remover := NewMetadataRemover(false, 90, 0)
+	data := makeJPEG(t, 6)
+	reader := bytes.NewReader(data)
+
+	content, size, err := remover.RemoveMetadata(reader, int64(len(data)))
+
+	assert.NoError(t, err)
+	assert.Equal(t, int64(len(data)), size)
+	assert.Same(t, reader, content)
+}
+
+func TestMetadataRemoverRemoveMetadataErrorCanNotProcessImage(t *testing.T) {
+	remover := NewMetadataRemover(true, 90, 0)
+	data := makeJPEG(t, exifNormalOrientation)[:30]
+
+	content, size, err := remover.RemoveMetadata(bytes.NewReader(data), int64(len(data)))
+
+	assert.ErrorIs(t, err, services.ErrImageProcessorCanNotProcessImage)
+	assert.Nil(t, content)
+	assert.Equal(t, int64(0), size)
+}
+
+func TestMetadataRemoverRemoveMetadataErrorImageTooLarge(t *testing.T) {
+	remover := NewMetadataRemover(true, 90, 100)
+	data := makeJPEG(t, exifNormalOrientation)
+
+	testCases := []struct {
+		name string
+		size int64
+	}{
+		{"declared size", int64(len(data))},
+		{"read content", 10},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			content, size, err := remover.RemoveMetadata(bytes.NewReader(data), testCase.size)
+
+			assert.ErrorIs(t, err, services.ErrImageProcessorImageTooLarge)
+			assert.Nil(t, content)
+			assert.Equal(t, int64(0), size)
+		})
+	}
+}
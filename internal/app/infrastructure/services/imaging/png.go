@@ -0,0 +1,75 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+	"image/png"
+)
+
+var (
+	pngSignature      = []byte("\x89PNG\r\n\x1a\n")
+	pngMetadataChunks = map[string]bool{
+		"eXIf": true,
+		"tEXt": true,
+		"zTXt": true,
+		"iTXt": true,
+	}
+)
+
+// removePNGMetadata drops the eXIf chunk and the text chunks, which is where
+// XMP and other comments are stored. The image is only re-encoded when its
+// EXIF orientation has to be applied to the pixels.
+func removePNGMetadata(data []byte) ([]byte, error) {
+	if !bytes.HasPrefix(data, pngSignature) {
+		return nil, errInvalidImage
+	}
+
+	orientation := exifNormalOrientation
+	var cleaned bytes.Buffer
+	cleaned.Write(pngSignature)
+
+	i := int64(len(pngSignature))
+	for i < int64(len(data)) {
+		if i+8 > int64(len(data)) {
+			return nil, errInvalidImage
+		}
+
+		length := int64(binary.BigEndian.Uint32(data[i:]))
+		chunkType := string(data[i+4 : i+8])
+		end := i + 12 + length
+		if end > int64(len(data)) {
+			return nil, errInvalidImage
+		}
+
+		if chunkType == "eXIf" {
+			orientation = exifOrientation(data[i+8 : i+8+length])
+		}
+
+		if !pngMetadataChunks[chunkType] {
+			cleaned.Write(data[i:end])
+		}
+
+		i = end
+
+		if chunkType == "IEND" {
+			break
+		}
+	}
+
+	if orientation == exifNormalOrientation {
+		return cleaned.Bytes(), nil
+	}
+
+	img, err := png.Decode(bytes.NewReader(cleaned.Bytes()))
+	if err != nil {
+		return nil, err
+	}
+
+	var encoded bytes.Buffer
+	err = png.Encode(&encoded, applyOrientation(img, orientation))
+	if err != nil {
+		return nil, err
+	}
+
+	return encoded.Bytes(), nil
+}
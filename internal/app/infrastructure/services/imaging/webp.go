@@ -0,0 +1,77 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/binary"
+)
+
+const (
+	webpExifFlag = 0x08
+	webpXmpFlag  = 0x04
+)
+
+// removeWebPMetadata drops the EXIF and XMP chunks of a WebP container. There
+// is no WebP encoder to rotate the pixels with, and dropping the orientation
+// would show the image sideways, so the EXIF chunk of an image that is not in
+// the normal orientation is replaced by one with only the orientation tag.
+func removeWebPMetadata(data []byte) ([]byte, error) {
+	if len(data) < 12 || string(data[:4]) != "RIFF" || string(data[8:12]) != "WEBP" {
+		return nil, errInvalidImage
+	}
+
+	vp8xOffset := -1
+	keepsExif := false
+	var chunks bytes.Buffer
+
+	i := int64(12)
+	for i+8 <= int64(len(data)) {
+		fourCC := string(data[i : i+4])
+		length := int64(binary.LittleEndian.Uint32(data[i+4:]))
+		end := i + 8 + length
+		if end > int64(len(data)) {
+			return nil, errInvalidImage
+		}
+
+		padded := end + length%2
+		if padded > int64(len(data)) {
+			padded = end
+		}
+
+		switch fourCC {
+		case "EXIF":
+			orientation := exifOrientation(data[i+8 : end])
+			if orientation != exifNormalOrientation {
+				exif := orientationOnlyExif(orientation)
+				header := make([]byte, 8)
+				copy(header, "EXIF")
+				binary.LittleEndian.PutUint32(header[4:], uint32(len(exif)))
+				chunks.Write(header)
+				chunks.Write(exif)
+				keepsExif = true
+			}
+		case "XMP ":
+		default:
+			if fourCC == "VP8X" && length > 0 {
+				vp8xOffset = chunks.Len()
+			}
+			chunks.Write(data[i:padded])
+		}
+
+		i = padded
+	}
+
+	if vp8xOffset >= 0 {
+		flagsOffset := vp8xOffset + 8
+		chunks.Bytes()[flagsOffset] &^= webpExifFlag | webpXmpFlag
+		if keepsExif {
+			chunks.Bytes()[flagsOffset] |= webpExifFlag
+		}
+	}
+
+	cleaned := make([]byte, 12, 12+chunks.Len())
+	copy(cleaned, "RIFF")
+	binary.LittleEndian.PutUint32(cleaned[4:], uint32(4+chunks.Len()))
+	copy(cleaned[8:], "WEBP")
+
+	return append(cleaned, chunks.Bytes()...), nil
+}
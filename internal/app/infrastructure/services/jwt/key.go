@@ -0,0 +1,158 @@
+package jwt
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"math/big"
+	"os"
+)
+
+const minRSAKeyBits = 2048
+
+var (
+	ErrKeyIsNotPEMEncoded    = errors.New("key is not PEM encoded")
+	ErrKeyTypeIsNotSupported = errors.New("key type is not supported, use RSA or Ed25519")
+	ErrKeyIsTooShort         = errors.New("RSA key must have at least 2048 bits")
+	ErrKeyIsNotPrivate       = errors.New("signing key must be a private key")
+)
+
+// Key is an RSA or Ed25519 key. Its ID is the RFC 7638 thumbprint of the
+// public key, so the same file always gets the same kid on every instance.
+type Key struct {
+	ID         string
+	Method     jwt.SigningMethod
+	privateKey crypto.Signer
+	publicKey  crypto.PublicKey
+}
+
+func LoadPrivateKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePrivateKey(data)
+}
+
+func LoadPublicKey(path string) (*Key, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+
+	return ParsePublicKey(data)
+}
+
+// ParsePrivateKey reads a PKCS #8 or PKCS #1 private key in PEM format.
+func ParsePrivateKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrKeyIsNotPEMEncoded
+	}
+
+	privateKey, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		privateKey, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	signer, ok := privateKey.(crypto.Signer)
+	if !ok {
+		return nil, ErrKeyTypeIsNotSupported
+	}
+
+	return newKey(signer.Public(), signer)
+}
+
+// ParsePublicKey reads a PKIX or PKCS #1 public key in PEM format.
+func ParsePublicKey(data []byte) (*Key, error) {
+	block, _ := pem.Decode(data)
+	if block == nil {
+		return nil, ErrKeyIsNotPEMEncoded
+	}
+
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return newKey(publicKey, nil)
+}
+
+func newKey(publicKey crypto.PublicKey, privateKey crypto.Signer) (*Key, error) {
+	key := &Key{
+		privateKey: privateKey,
+		publicKey:  publicKey,
+	}
+
+	switch k := publicKey.(type) {
+	case *rsa.PublicKey:
+		if k.N.BitLen() < minRSAKeyBits {
+			return nil, ErrKeyIsTooShort
+		}
+		key.Method = jwt.SigningMethodRS256
+	case ed25519.PublicKey:
+		key.Method = jwt.SigningMethodEdDSA
+	default:
+		return nil, ErrKeyTypeIsNotSupported
+	}
+
+	key.ID = thumbprint(key.PublicKey())
+
+	return key, nil
+}
+
+func (k *Key) CanSign() bool {
+	return k.privateKey != nil
+}
+
+// PublicKey returns the key in the fields of a JSON Web Key, without any
+// private part.
+func (k *Key) PublicKey() services.PublicKey {
+	publicKey := services.PublicKey{
+		ID:        k.ID,
+		Algorithm: k.Method.Alg(),
+	}
+
+	switch key := k.publicKey.(type) {
+	case *rsa.PublicKey:
+		publicKey.Type = "RSA"
+		publicKey.Modulus = base64.RawURLEncoding.EncodeToString(key.N.Bytes())
+		publicKey.Exponent = base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes())
+	case ed25519.PublicKey:
+		publicKey.Type = "OKP"
+		publicKey.Curve = "Ed25519"
+		publicKey.X = base64.RawURLEncoding.EncodeToString(key)
+	}
+
+	return publicKey
+}
+
+// thumbprint hashes the required members of the JSON Web Key in
+// lexicographic order, as described in RFC 7638.
+func thumbprint(key services.PublicKey) string {
+	var members string
+	switch key.Type {
+	case "RSA":
+		members = `{"e":"` + key.Exponent + `","kty":"RSA","n":"` + key.Modulus + `"}`
+	case "OKP":
+		members = `{"crv":"` + key.Curve + `","kty":"OKP","x":"` + key.X + `"}`
+	}
+
+	hash := sha256.Sum256([]byte(members))
+
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
@@ -0,0 +1,121 @@
+package jwt
+
+import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"testing"
+)
+
+func encodePrivateKey(t *testing.T, privateKey interface{}) []byte {
+	data, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: data})
+}
+
+func encodePublicKey(t *testing.T, publicKey interface{}) []byte {
+	data, err := x509.MarshalPKIXPublicKey(publicKey)
+	assert.NoError(t, err)
+
+	return pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: data})
+}
+
+func TestParsePrivateKeyRSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	key, err := ParsePrivateKey(encodePrivateKey(t, privateKey))
+
+	assert.NoError(t, err)
+	assert.True(t, key.CanSign())
+	assert.Equal(t, jwt.SigningMethodRS256, key.Method)
+	assert.NotEmpty(t, key.ID)
+
+	publicKey := key.PublicKey()
+	assert.Equal(t, "RSA", publicKey.Type)
+	assert.Equal(t, "RS256", publicKey.Algorithm)
+	assert.Equal(t, "AQAB", publicKey.Exponent)
+	assert.NotEmpty(t, publicKey.Modulus)
+}
+
+func TestParsePrivateKeyPKCS1RSA(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	data := pem.EncodeToMemory(&pem.Block{Type: "RSA PRIVATE KEY", Bytes: x509.MarshalPKCS1PrivateKey(privateKey)})
+	key, err := ParsePrivateKey(data)
+
+	assert.NoError(t, err)
+	assert.Equal(t, jwt.SigningMethodRS256, key.Method)
+}
+
+func TestParsePrivateKeyEd25519(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	key, err := ParsePrivateKey(encodePrivateKey(t, privateKey))
+
+	assert.NoError(t, err)
+	assert.True(t, key.CanSign())
+	assert.Equal(t, jwt.SigningMethodEdDSA, key.Method)
+	assert.Equal(t, services.PublicKey{
+		ID:        key.ID,
+		Type:      "OKP",
+		Algorithm: "EdDSA",
+		Curve:     "Ed25519",
+		X:         base64.RawURLEncoding.EncodeToString(publicKey),
+	}, key.PublicKey())
+}
+
+func TestParsePublicKeyHasTheIDOfItsPrivateKey(t *testing.T) {
+	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	signingKey, err := ParsePrivateKey(encodePrivateKey(t, privateKey))
+	assert.NoError(t, err)
+	verificationKey, err := ParsePublicKey(encodePublicKey(t, publicKey))
+	assert.NoError(t, err)
+
+	assert.False(t, verificationKey.CanSign())
+	assert.Equal(t, signingKey.ID, verificationKey.ID)
+}
+
+func TestParsePrivateKeyErrorNotPEMEncoded(t *testing.T) {
+	key, err := ParsePrivateKey([]byte("not a key"))
+
+	assert.ErrorIs(t, err, ErrKeyIsNotPEMEncoded)
+	assert.Nil(t, key)
+}
+
+func TestParsePrivateKeyErrorRSAKeyIsTooShort(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	assert.NoError(t, err)
+
+	key, err := ParsePrivateKey(encodePrivateKey(t, privateKey))
+
+	assert.ErrorIs(t, err, ErrKeyIsTooShort)
+	assert.Nil(t, key)
+}
+
+func TestKeyIDIsTheRFC7638Thumbprint(t *testing.T) {
+	modulus, err := base64.RawURLEncoding.DecodeString(
+		"0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECP" +
+			"ebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2Qvz" +
+			"qY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZ" +
+			"u0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw",
+	)
+	assert.NoError(t, err)
+
+	key, err := newKey(&rsa.PublicKey{N: new(big.Int).SetBytes(modulus), E: 65537}, nil)
+
+	assert.NoError(t, err)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", key.ID)
+}
@@ -3,17 +3,21 @@ package jwt
 import (
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"sort"
 	"time"
 )
 
 type TokenGenerator struct {
-	Secret         string
-	ExpirationTime time.Duration
+	Secret           string
+	ExpirationTime   time.Duration
+	signingKey       *Key
+	verificationKeys map[string]*Key
 }
 
 type CustomClaims struct {
-	ID    string `json:"id" mapstructure:"id"`
-	Email string `json:"email" mapstructure:"email"`
+	ID        string `json:"id" mapstructure:"id"`
+	Email     string `json:"email" mapstructure:"email"`
+	SessionID string `json:"sid" mapstructure:"sid"`
 	jwt.RegisteredClaims
 }
 
@@ -27,17 +31,56 @@ func NewTokenGenerator(
 	}
 }
 
-func (s *TokenGenerator) GenerateToken(id string, email string) (string, error) {
+// NewKeyPairTokenGenerator signs with the private key and accepts the tokens
+// of the signing key and of any of the verification keys, so a key can be
+// rotated without logging anyone out: publish the new public key, sign with
+// it, and remove the old one once its last tokens have expired.
+func NewKeyPairTokenGenerator(
+	signingKey *Key,
+	verificationKeys []*Key,
+	expirationTime time.Duration,
+) (*TokenGenerator, error) {
+	if !signingKey.CanSign() {
+		return nil, ErrKeyIsNotPrivate
+	}
+
+	keys := map[string]*Key{signingKey.ID: signingKey}
+	for _, key := range verificationKeys {
+		if _, ok := keys[key.ID]; !ok {
+			keys[key.ID] = key
+		}
+	}
+
+	return &TokenGenerator{
+		ExpirationTime:   expirationTime,
+		signingKey:       signingKey,
+		verificationKeys: keys,
+	}, nil
+}
+
+func (s *TokenGenerator) GenerateToken(id string, email string, sessionID string) (string, error) {
 	claims := &CustomClaims{
 		id,
 		email,
+		sessionID,
 		jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Now().Add(s.ExpirationTime)),
 		},
 	}
 
-	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
-	encodedToken, err := token.SignedString([]byte(s.Secret))
+	var signingMethod jwt.SigningMethod = jwt.SigningMethodHS256
+	var signingKey interface{} = []byte(s.Secret)
+	if s.signingKey != nil {
+		signingMethod = s.signingKey.Method
+		signingKey = s.signingKey.privateKey
+	}
+
+	token := jwt.NewWithClaims(signingMethod, claims)
+	if s.signingKey != nil {
+		token.Header["kid"] = s.signingKey.ID
+	}
+
+	encodedToken, err := token.SignedString(signingKey)
 	if err != nil {
 		return "", services.ErrTokenGeneratorCanNotGenerateToken
 	}
@@ -46,12 +89,7 @@ func (s *TokenGenerator) GenerateToken(id string, email string) (string, error)
 }
 
 func (s *TokenGenerator) DecodeToken(tokenString string) (*CustomClaims, error) {
-	verifier, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, services.ErrTokenGeneratorCanNotVerifyToken
-		}
-		return []byte(s.Secret), nil
-	})
+	verifier, err := jwt.Parse(tokenString, s.verificationKey)
 	if err != nil {
 		return nil, services.ErrTokenGeneratorCanNotVerifyToken
 	}
@@ -65,19 +103,65 @@ func (s *TokenGenerator) DecodeToken(tokenString string) (*CustomClaims, error)
 		return nil, services.ErrTokenGeneratorUnableToParseClaims
 	}
 
+	// Tokens issued before sessions existed have no sid claim.
+	sessionID, _ := claims["sid"].(string)
+
 	return &CustomClaims{
-		ID:    claims["id"].(string),
-		Email: claims["email"].(string),
+		ID:        claims["id"].(string),
+		Email:     claims["email"].(string),
+		SessionID: sessionID,
 		RegisteredClaims: jwt.RegisteredClaims{
 			ExpiresAt: jwt.NewNumericDate(time.Unix(int64(claims["exp"].(float64)), 0)),
 		},
 	}, nil
 }
 
+// verificationKey picks the key by the kid header. The algorithm must be the
+// one of that key, so a token can not be signed with a public key as if it
+// were an HMAC secret.
+func (s *TokenGenerator) verificationKey(token *jwt.Token) (interface{}, error) {
+	if s.signingKey == nil {
+		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+			return nil, services.ErrTokenGeneratorCanNotVerifyToken
+		}
+		return []byte(s.Secret), nil
+	}
+
+	kid, _ := token.Header["kid"].(string)
+	key, ok := s.verificationKeys[kid]
+	if !ok || token.Method.Alg() != key.Method.Alg() {
+		return nil, services.ErrTokenGeneratorCanNotVerifyToken
+	}
+
+	return key.publicKey, nil
+}
+
+// PublicKeys returns the keys that verify tokens, none when tokens are signed
+// with a shared secret.
+func (s *TokenGenerator) PublicKeys() []services.PublicKey {
+	publicKeys := make([]services.PublicKey, 0, len(s.verificationKeys))
+	for _, key := range s.verificationKeys {
+		publicKeys = append(publicKeys, key.PublicKey())
+	}
+
+	sort.Slice(publicKeys, func(i, j int) bool {
+		if publicKeys[i].ID == s.signingKey.ID {
+			return true
+		}
+		if publicKeys[j].ID == s.signingKey.ID {
+			return false
+		}
+		return publicKeys[i].ID < publicKeys[j].ID
+	})
+
+	return publicKeys
+}
+
 func (s *TokenGenerator) ParseToken(token string) (
 	*struct {
-		ID    string
-		Email string
+		ID        string
+		Email     string
+		SessionID string
 	},
 	error,
 ) {
@@ -87,10 +171,12 @@ func (s *TokenGenerator) ParseToken(token string) (
 	}
 
 	return &struct {
-		ID    string
-		Email string
+		ID        string
+		Email     string
+		SessionID string
 	}{
 		claims.ID,
 		claims.Email,
+		claims.SessionID,
 	}, nil
 }
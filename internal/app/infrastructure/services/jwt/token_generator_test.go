@@ -1,7 +1,12 @@
 package jwt
 
 import (
+	"crypto/ed25519"
+	"crypto/rand"
+	"crypto/rsa"
+	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/stretchr/testify/assert"
 	"testing"
 	"time"
@@ -14,8 +19,9 @@ func TestTokenGeneratorGenerateAndDecodeToken(t *testing.T) {
 
 	id := uuid.NewString()
 	email := "test@example.com"
+	sessionID := uuid.NewString()
 
-	token, err := jwtGenerator.GenerateToken(id, email)
+	token, err := jwtGenerator.GenerateToken(id, email, sessionID)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -25,6 +31,7 @@ func TestTokenGeneratorGenerateAndDecodeToken(t *testing.T) {
 
 	assert.Equal(t, id, decodedClaims.ID)
 	assert.Equal(t, email, decodedClaims.Email)
+	assert.Equal(t, sessionID, decodedClaims.SessionID)
 	assert.WithinDuration(t, time.Now().Add(expirationTime), time.Unix(decodedClaims.ExpiresAt.Unix(), 0), 5*time.Second)
 }
 
@@ -35,8 +42,9 @@ func TestTokenGeneratorParseToken(t *testing.T) {
 
 	id := uuid.NewString()
 	email := "test@example.com"
+	sessionID := uuid.NewString()
 
-	token, err := jwtGenerator.GenerateToken(id, email)
+	token, err := jwtGenerator.GenerateToken(id, email, sessionID)
 
 	assert.NoError(t, err)
 	assert.NotEmpty(t, token)
@@ -47,4 +55,101 @@ func TestTokenGeneratorParseToken(t *testing.T) {
 	assert.NotNil(t, data)
 	assert.Equal(t, id, data.ID)
 	assert.Equal(t, email, data.Email)
+	assert.Equal(t, sessionID, data.SessionID)
+}
+
+func generateEd25519Key(t *testing.T) *Key {
+	_, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+
+	key, err := ParsePrivateKey(encodePrivateKey(t, privateKey))
+	assert.NoError(t, err)
+
+	return key
+}
+
+func TestKeyPairTokenGeneratorGenerateAndParseToken(t *testing.T) {
+	privateKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+	rsaKey, err := ParsePrivateKey(encodePrivateKey(t, privateKey))
+	assert.NoError(t, err)
+
+	for _, signingKey := range []*Key{rsaKey, generateEd25519Key(t)} {
+		jwtGenerator, err := NewKeyPairTokenGenerator(signingKey, nil, time.Hour)
+		assert.NoError(t, err)
+
+		id := uuid.NewString()
+		token, err := jwtGenerator.GenerateToken(id, "test@example.com", uuid.NewString())
+		assert.NoError(t, err)
+
+		parsed, _, err := jwt.NewParser().ParseUnverified(token, jwt.MapClaims{})
+		assert.NoError(t, err)
+		assert.Equal(t, signingKey.ID, parsed.Header["kid"])
+		assert.Equal(t, signingKey.Method.Alg(), parsed.Header["alg"])
+
+		data, err := jwtGenerator.ParseToken(token)
+		assert.NoError(t, err)
+		assert.Equal(t, id, data.ID)
+	}
+}
+
+func TestKeyPairTokenGeneratorParseTokenOfPreviousKey(t *testing.T) {
+	previousKey := generateEd25519Key(t)
+	currentKey := generateEd25519Key(t)
+
+	previousGenerator, err := NewKeyPairTokenGenerator(previousKey, nil, time.Hour)
+	assert.NoError(t, err)
+	token, err := previousGenerator.GenerateToken(uuid.NewString(), "test@example.com", uuid.NewString())
+	assert.NoError(t, err)
+
+	rotatedGenerator, err := NewKeyPairTokenGenerator(currentKey, []*Key{previousKey}, time.Hour)
+	assert.NoError(t, err)
+	data, err := rotatedGenerator.ParseToken(token)
+	assert.NoError(t, err)
+	assert.NotNil(t, data)
+
+	withoutPreviousGenerator, err := NewKeyPairTokenGenerator(currentKey, nil, time.Hour)
+	assert.NoError(t, err)
+	data, err = withoutPreviousGenerator.ParseToken(token)
+	assert.ErrorIs(t, err, services.ErrTokenGeneratorCanNotVerifyToken)
+	assert.Nil(t, data)
+}
+
+func TestKeyPairTokenGeneratorParseTokenErrorSignedWithSecret(t *testing.T) {
+	jwtGenerator, err := NewKeyPairTokenGenerator(generateEd25519Key(t), nil, time.Hour)
+	assert.NoError(t, err)
+
+	token, err := NewTokenGenerator("secret-key", time.Hour).
+		GenerateToken(uuid.NewString(), "test@example.com", uuid.NewString())
+	assert.NoError(t, err)
+
+	data, err := jwtGenerator.ParseToken(token)
+
+	assert.ErrorIs(t, err, services.ErrTokenGeneratorCanNotVerifyToken)
+	assert.Nil(t, data)
+}
+
+func TestKeyPairTokenGeneratorErrorPublicKeyCanNotSign(t *testing.T) {
+	publicKey, _, err := ed25519.GenerateKey(rand.Reader)
+	assert.NoError(t, err)
+	key, err := ParsePublicKey(encodePublicKey(t, publicKey))
+	assert.NoError(t, err)
+
+	jwtGenerator, err := NewKeyPairTokenGenerator(key, nil, time.Hour)
+
+	assert.ErrorIs(t, err, ErrKeyIsNotPrivate)
+	assert.Nil(t, jwtGenerator)
+}
+
+func TestKeyPairTokenGeneratorPublicKeys(t *testing.T) {
+	signingKey := generateEd25519Key(t)
+	previousKey := generateEd25519Key(t)
+
+	jwtGenerator, err := NewKeyPairTokenGenerator(signingKey, []*Key{previousKey, signingKey}, time.Hour)
+	assert.NoError(t, err)
+
+	publicKeys := jwtGenerator.PublicKeys()
+
+	assert.Equal(t, []services.PublicKey{signingKey.PublicKey(), previousKey.PublicKey()}, publicKeys)
+	assert.Empty(t, NewTokenGenerator("secret-key", time.Hour).PublicKeys())
 }
@@ -0,0 +1,50 @@
+package mailqueue
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+)
+
+// MailSender does not send the mails, it writes them to the queue and the
+// MailQueueService sends them later, retrying the ones that fail. A mail is
+// only lost when it can not be written. The bodies are encrypted, as they can
+// hold the tokens to reset a password or to verify an email.
+type MailSender struct {
+	mailMessageRepository repositories.MailMessageRepository
+	cipher                services.Cipher
+}
+
+func NewMailSender(mailMessageRepository repositories.MailMessageRepository, cipher services.Cipher) *MailSender {
+	return &MailSender{
+		mailMessageRepository,
+		cipher,
+	}
+}
+
+func (ms *MailSender) SendMail(to string, subject string, body string) error {
+	return ms.Send(services.Mail{
+		To:      to,
+		Subject: subject,
+		Text:    body,
+	})
+}
+
+func (ms *MailSender) Send(mail services.Mail) error {
+	message, err := entities.NewMailMessage(mail.To, mail.Subject, mail.Text, mail.HTML)
+	if err != nil {
+		return err
+	}
+
+	message.Text, err = ms.cipher.Encrypt(mail.Text)
+	if err != nil {
+		return err
+	}
+
+	message.HTML, err = ms.cipher.Encrypt(mail.HTML)
+	if err != nil {
+		return err
+	}
+
+	return ms.mailMessageRepository.Create(message)
+}
@@ -0,0 +1,103 @@
+package mailqueue
+
+import (
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	serviceStub "github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/services/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"testing"
+)
+
+func TestMailSenderSend(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	cipher := new(serviceStub.CipherMock)
+	mailSender := NewMailSender(mailMessageRepository, cipher)
+
+	cipher.On("Encrypt", "text").Return("encrypted text", nil)
+	cipher.On("Encrypt", "<p>html</p>").Return("encrypted html", nil)
+	mailMessageRepository.On("Create", mock.MatchedBy(func(message *entities.MailMessage) bool {
+		return message.Recipient == "test@example.com" &&
+			message.Subject == "Screwdriver added into Tools" &&
+			message.Text == "encrypted text" &&
+			message.HTML == "encrypted html" &&
+			message.IsPending()
+	})).Return(nil)
+
+	err := mailSender.Send(services.Mail{
+		To:      "test@example.com",
+		Subject: "Screwdriver added into Tools",
+		Text:    "text",
+		HTML:    "<p>html</p>",
+	})
+
+	assert.NoError(t, err)
+	mailMessageRepository.AssertExpectations(t)
+	cipher.AssertExpectations(t)
+}
+
+func TestMailSenderSendMail(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	cipher := new(serviceStub.CipherMock)
+	mailSender := NewMailSender(mailMessageRepository, cipher)
+
+	cipher.On("Encrypt", "body").Return("encrypted body", nil)
+	cipher.On("Encrypt", "").Return("encrypted html", nil)
+	mailMessageRepository.On("Create", mock.MatchedBy(func(message *entities.MailMessage) bool {
+		return message.Recipient == "test@example.com" &&
+			message.Subject == "Verify your email" &&
+			message.Text == "encrypted body" &&
+			message.HTML == "encrypted html"
+	})).Return(nil)
+
+	err := mailSender.SendMail("test@example.com", "Verify your email", "body")
+
+	assert.NoError(t, err)
+	mailMessageRepository.AssertExpectations(t)
+	cipher.AssertExpectations(t)
+}
+
+func TestMailSenderSendErrorInvalidMail(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	cipher := new(serviceStub.CipherMock)
+	mailSender := NewMailSender(mailMessageRepository, cipher)
+
+	err := mailSender.SendMail("", "Verify your email", "body")
+
+	assert.ErrorIs(t, err, entities.ErrMailMessageRecipientShouldNotBeEmpty)
+	mailMessageRepository.AssertNotCalled(t, "Create", mock.Anything)
+	cipher.AssertNotCalled(t, "Encrypt", mock.Anything)
+}
+
+func TestMailSenderSendErrorCanNotEncrypt(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	cipher := new(serviceStub.CipherMock)
+	mailSender := NewMailSender(mailMessageRepository, cipher)
+
+	encryptErr := errors.New("encrypt error")
+	cipher.On("Encrypt", "body").Return("", encryptErr)
+
+	err := mailSender.SendMail("test@example.com", "Verify your email", "body")
+
+	assert.ErrorIs(t, err, encryptErr)
+	mailMessageRepository.AssertNotCalled(t, "Create", mock.Anything)
+	cipher.AssertExpectations(t)
+}
+
+func TestMailSenderSendErrorCanNotCreate(t *testing.T) {
+	mailMessageRepository := new(stub.MailMessageRepositoryMock)
+	cipher := new(serviceStub.CipherMock)
+	mailSender := NewMailSender(mailMessageRepository, cipher)
+
+	cipher.On("Encrypt", mock.AnythingOfType("string")).Return("encrypted", nil)
+	mailMessageRepository.On("Create", mock.AnythingOfType("*entities.MailMessage")).
+		Return(repositories.ErrMailMessageRepositoryCanNotCreateMessage)
+
+	err := mailSender.SendMail("test@example.com", "Verify your email", "body")
+
+	assert.ErrorIs(t, err, repositories.ErrMailMessageRepositoryCanNotCreateMessage)
+	mailMessageRepository.AssertExpectations(t)
+}
@@ -0,0 +1,78 @@
+package mailtemplate
+
+import (
+	"bytes"
+	"embed"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	htmltemplate "html/template"
+	"io/fs"
+	"path"
+	"strings"
+	texttemplate "text/template"
+)
+
+const layoutFile = "templates/layout.html"
+
+//go:embed templates
+var templates embed.FS
+
+// Renderer renders the mails from the templates embedded in the binary. Every
+// mail has a name.txt template for the plain text body and a name.html one,
+// with the title and content blocks of the layout, for the html body. The
+// html body is escaped by html/template.
+type Renderer struct {
+	textTemplates *texttemplate.Template
+	htmlTemplates map[string]*htmltemplate.Template
+}
+
+// NewRenderer panics when a template can not be parsed, they are part of the
+// binary so it can only happen when one of them is wrong.
+func NewRenderer() *Renderer {
+	textTemplates := texttemplate.Must(texttemplate.ParseFS(templates, "templates/*.txt"))
+
+	htmlFiles, err := fs.Glob(templates, "templates/*.html")
+	if err != nil {
+		panic(err)
+	}
+
+	htmlTemplates := make(map[string]*htmltemplate.Template)
+	for _, htmlFile := range htmlFiles {
+		if htmlFile == layoutFile {
+			continue
+		}
+
+		name := strings.TrimSuffix(path.Base(htmlFile), ".html")
+		htmlTemplates[name] = htmltemplate.Must(htmltemplate.ParseFS(templates, layoutFile, htmlFile))
+	}
+
+	return &Renderer{
+		textTemplates: textTemplates,
+		htmlTemplates: htmlTemplates,
+	}
+}
+
+func (r *Renderer) Render(name string, data interface{}) (string, string, error) {
+	htmlTemplate, ok := r.htmlTemplates[name]
+	if !ok {
+		logger.LogError(fmt.Errorf("mail template %s does not exist", name))
+		return "", "", services.ErrMailRendererCanNotRenderMail
+	}
+
+	var text bytes.Buffer
+	err := r.textTemplates.ExecuteTemplate(&text, name+".txt", data)
+	if err != nil {
+		logger.LogError(err)
+		return "", "", services.ErrMailRendererCanNotRenderMail
+	}
+
+	var html bytes.Buffer
+	err = htmlTemplate.ExecuteTemplate(&html, "layout", data)
+	if err != nil {
+		logger.LogError(err)
+		return "", "", services.ErrMailRendererCanNotRenderMail
+	}
+
+	return strings.TrimSpace(text.String()), html.String(), nil
+}
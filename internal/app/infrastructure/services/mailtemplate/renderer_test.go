@@ -0,0 +1,85 @@
+package mailtemplate
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/assert"
+	"testing"
+)
+
+func TestRendererRenderBoxItemAdded(t *testing.T) {
+	renderer := NewRenderer()
+
+	text, html, err := renderer.Render(services.MailTemplateBoxItemAdded, services.BoxItemMailData{
+		Quantity:   "2",
+		ItemName:   "Screwdriver",
+		BoxName:    "Tools",
+		RoomName:   "Garage",
+		HappenedAt: "Mon, 19 Oct 2026 09:20 -05",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "2 Screwdriver added into the box Tools of the room Garage.\n\nMon, 19 Oct 2026 09:20 -05", text)
+	assert.Contains(t, html, "<title>Screwdriver added into Tools</title>")
+	assert.Contains(t, html, "<strong>2 Screwdriver</strong> added into the box <strong>Tools</strong> of the room <strong>Garage</strong>.")
+	assert.Contains(t, html, "Mon, 19 Oct 2026 09:20 -05")
+}
+
+func TestRendererRenderBoxItemRemoved(t *testing.T) {
+	renderer := NewRenderer()
+
+	text, html, err := renderer.Render(services.MailTemplateBoxItemRemoved, services.BoxItemMailData{
+		Quantity:   "1",
+		ItemName:   "Hammer",
+		BoxName:    "Tools",
+		RoomName:   "Garage",
+		HappenedAt: "Mon, 19 Oct 2026 09:20 -05",
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "1 Hammer removed from the box Tools of the room Garage.\n\nMon, 19 Oct 2026 09:20 -05", text)
+	assert.Contains(t, html, "<strong>1 Hammer</strong> removed from the box <strong>Tools</strong>")
+}
+
+func TestRendererRenderEscapesHTML(t *testing.T) {
+	renderer := NewRenderer()
+
+	text, html, err := renderer.Render(services.MailTemplateBoxItemAdded, services.BoxItemMailData{
+		Quantity: "1",
+		ItemName: "<script>alert(1)</script>",
+		BoxName:  "Tools & Parts",
+		RoomName: "Garage",
+	})
+
+	assert.NoError(t, err)
+	assert.Contains(t, text, "<script>alert(1)</script>")
+	assert.NotContains(t, html, "<script>")
+	assert.Contains(t, html, "&lt;script&gt;alert(1)&lt;/script&gt;")
+	assert.Contains(t, html, "Tools &amp; Parts")
+}
+
+func TestRendererRenderNotificationDigest(t *testing.T) {
+	renderer := NewRenderer()
+
+	text, html, err := renderer.Render(services.MailTemplateNotificationDigest, services.NotificationDigestMailData{
+		Messages: []string{"2 Screwdriver added into Tools", "1 Hammer removed from Tools"},
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(
+		t,
+		"This is what happened in your households since your last digest:\n\n- 2 Screwdriver added into Tools\n- 1 Hammer removed from Tools",
+		text,
+	)
+	assert.Contains(t, html, "<li style=\"margin:0 0 4px;\">2 Screwdriver added into Tools</li>")
+	assert.Contains(t, html, "<li style=\"margin:0 0 4px;\">1 Hammer removed from Tools</li>")
+}
+
+func TestRendererRenderErrorTemplateDoesNotExist(t *testing.T) {
+	renderer := NewRenderer()
+
+	text, html, err := renderer.Render("unknown", nil)
+
+	assert.ErrorIs(t, err, services.ErrMailRendererCanNotRenderMail)
+	assert.Empty(t, text)
+	assert.Empty(t, html)
+}
@@ -0,0 +1,364 @@
+package oidc
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"math/big"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+)
+
+// keysRefreshInterval limits how often the keys are fetched again when an id
+// token has an unknown kid, so forged tokens can not flood the provider.
+const keysRefreshInterval = time.Minute
+
+type configuration struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type jsonWebKey struct {
+	KeyType string `json:"kty"`
+	Use     string `json:"use"`
+	KeyID   string `json:"kid"`
+	N       string `json:"n"`
+	E       string `json:"e"`
+	Curve   string `json:"crv"`
+	X       string `json:"x"`
+	Y       string `json:"y"`
+}
+
+type tokenResponse struct {
+	IDToken string `json:"id_token"`
+}
+
+type idTokenClaims struct {
+	Nonce           string      `json:"nonce"`
+	Email           string      `json:"email"`
+	EmailVerified   interface{} `json:"email_verified"`
+	AuthorizedParty string      `json:"azp"`
+	jwt.RegisteredClaims
+}
+
+// IdentityProvider talks to an OpenID Connect provider found by discovery
+// from its issuer url. The configuration and the keys are fetched on first
+// use and kept in memory.
+type IdentityProvider struct {
+	issuerURL     string
+	clientID      string
+	clientSecret  string
+	redirectURL   string
+	httpClient    *http.Client
+	mutex         sync.Mutex
+	configuration *configuration
+	keys          map[string]crypto.PublicKey
+	keysFetchedAt time.Time
+}
+
+func NewIdentityProvider(
+	issuerURL string,
+	clientID string,
+	clientSecret string,
+	redirectURL string,
+) *IdentityProvider {
+	return &IdentityProvider{
+		issuerURL:    strings.TrimSuffix(issuerURL, "/"),
+		clientID:     clientID,
+		clientSecret: clientSecret,
+		redirectURL:  redirectURL,
+		httpClient:   &http.Client{Timeout: 10 * time.Second},
+	}
+}
+
+func (p *IdentityProvider) AuthorizationURL(state string, nonce string, codeChallenge string) (string, error) {
+	config, err := p.discover()
+	if err != nil {
+		return "", err
+	}
+
+	authorizationURL, err := url.Parse(config.AuthorizationEndpoint)
+	if err != nil {
+		logger.LogError(err)
+		return "", services.ErrIdentityProviderCanNotDiscover
+	}
+
+	query := authorizationURL.Query()
+	query.Set("response_type", "code")
+	query.Set("client_id", p.clientID)
+	query.Set("redirect_uri", p.redirectURL)
+	query.Set("scope", "openid email")
+	query.Set("state", state)
+	query.Set("nonce", nonce)
+	query.Set("code_challenge", codeChallenge)
+	query.Set("code_challenge_method", "S256")
+	authorizationURL.RawQuery = query.Encode()
+
+	return authorizationURL.String(), nil
+}
+
+func (p *IdentityProvider) Exchange(code string, codeVerifier string, nonce string) (*services.Identity, error) {
+	config, err := p.discover()
+	if err != nil {
+		return nil, err
+	}
+
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", p.redirectURL)
+	form.Set("code_verifier", codeVerifier)
+	form.Set("client_id", p.clientID)
+
+	request, err := http.NewRequest(http.MethodPost, config.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		logger.LogError(err)
+		return nil, services.ErrIdentityProviderCanNotExchangeCode
+	}
+
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("Accept", "application/json")
+	if p.clientSecret != "" {
+		request.SetBasicAuth(url.QueryEscape(p.clientID), url.QueryEscape(p.clientSecret))
+	}
+
+	response := tokenResponse{}
+	err = p.do(request, &response)
+	if err != nil || response.IDToken == "" {
+		logger.LogError(err)
+		return nil, services.ErrIdentityProviderCanNotExchangeCode
+	}
+
+	return p.verifyIDToken(config, response.IDToken, nonce)
+}
+
+// verifyIDToken checks the signature, issuer, audience, expiration and nonce
+// of the id token, as required by OpenID Connect Core 3.1.3.7.
+func (p *IdentityProvider) verifyIDToken(config *configuration, idToken string, nonce string) (*services.Identity, error) {
+	claims := &idTokenClaims{}
+
+	_, err := jwt.ParseWithClaims(
+		idToken,
+		claims,
+		p.verificationKey,
+		jwt.WithValidMethods([]string{"RS256", "ES256"}),
+		jwt.WithIssuer(config.Issuer),
+		jwt.WithAudience(p.clientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(30*time.Second),
+	)
+	if err != nil {
+		logger.LogError(err)
+		return nil, services.ErrIdentityProviderInvalidIDToken
+	}
+
+	if nonce == "" || claims.Nonce != nonce || claims.Subject == "" {
+		return nil, services.ErrIdentityProviderInvalidIDToken
+	}
+
+	if len(claims.Audience) > 1 && claims.AuthorizedParty != p.clientID {
+		return nil, services.ErrIdentityProviderInvalidIDToken
+	}
+
+	return &services.Identity{
+		Issuer:        claims.Issuer,
+		Subject:       claims.Subject,
+		Email:         claims.Email,
+		EmailVerified: claims.EmailVerified == true || claims.EmailVerified == "true",
+	}, nil
+}
+
+func (p *IdentityProvider) verificationKey(token *jwt.Token) (interface{}, error) {
+	kid, _ := token.Header["kid"].(string)
+
+	key, err := p.key(kid)
+	if err != nil {
+		return nil, err
+	}
+
+	switch key.(type) {
+	case *rsa.PublicKey:
+		if token.Method.Alg() != "RS256" {
+			return nil, services.ErrIdentityProviderInvalidIDToken
+		}
+	case *ecdsa.PublicKey:
+		if token.Method.Alg() != "ES256" {
+			return nil, services.ErrIdentityProviderInvalidIDToken
+		}
+	}
+
+	return key, nil
+}
+
+// key looks for the key in memory first and fetches the keys again when the
+// provider may have rotated them.
+func (p *IdentityProvider) key(kid string) (crypto.PublicKey, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if key, ok := p.keys[kid]; ok {
+		return key, nil
+	}
+
+	if time.Since(p.keysFetchedAt) < keysRefreshInterval {
+		return nil, services.ErrIdentityProviderInvalidIDToken
+	}
+
+	keys, err := p.fetchKeys(p.configuration.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	key, ok := p.keys[kid]
+	if !ok {
+		return nil, services.ErrIdentityProviderInvalidIDToken
+	}
+
+	return key, nil
+}
+
+func (p *IdentityProvider) fetchKeys(jwksURI string) (map[string]crypto.PublicKey, error) {
+	request, err := http.NewRequest(http.MethodGet, jwksURI, nil)
+	if err != nil {
+		logger.LogError(err)
+		return nil, services.ErrIdentityProviderCanNotDiscover
+	}
+
+	response := struct {
+		Keys []jsonWebKey `json:"keys"`
+	}{}
+	err = p.do(request, &response)
+	if err != nil {
+		logger.LogError(err)
+		return nil, services.ErrIdentityProviderCanNotDiscover
+	}
+
+	keys := make(map[string]crypto.PublicKey)
+	for _, key := range response.Keys {
+		if key.Use != "" && key.Use != "sig" {
+			continue
+		}
+
+		publicKey, err := parseJSONWebKey(key)
+		if err != nil {
+			logger.LogError(err)
+			continue
+		}
+
+		keys[key.KeyID] = publicKey
+	}
+
+	return keys, nil
+}
+
+func (p *IdentityProvider) discover() (*configuration, error) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+
+	if p.configuration != nil {
+		return p.configuration, nil
+	}
+
+	request, err := http.NewRequest(http.MethodGet, p.issuerURL+"/.well-known/openid-configuration", nil)
+	if err != nil {
+		logger.LogError(err)
+		return nil, services.ErrIdentityProviderCanNotDiscover
+	}
+
+	config := &configuration{}
+	err = p.do(request, config)
+	if err != nil {
+		logger.LogError(err)
+		return nil, services.ErrIdentityProviderCanNotDiscover
+	}
+
+	if strings.TrimSuffix(config.Issuer, "/") != p.issuerURL ||
+		config.AuthorizationEndpoint == "" ||
+		config.TokenEndpoint == "" ||
+		config.JWKSURI == "" {
+		return nil, services.ErrIdentityProviderCanNotDiscover
+	}
+
+	keys, err := p.fetchKeys(config.JWKSURI)
+	if err != nil {
+		return nil, err
+	}
+
+	p.configuration = config
+	p.keys = keys
+	p.keysFetchedAt = time.Now()
+
+	return config, nil
+}
+
+func (p *IdentityProvider) do(request *http.Request, result interface{}) error {
+	response, err := p.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+
+	if response.StatusCode != http.StatusOK {
+		return errors.New("identity provider answered " + response.Status + " to " + request.URL.String())
+	}
+
+	return json.NewDecoder(response.Body).Decode(result)
+}
+
+func parseJSONWebKey(key jsonWebKey) (crypto.PublicKey, error) {
+	switch key.KeyType {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(key.N)
+		if err != nil {
+			return nil, err
+		}
+
+		e, err := base64.RawURLEncoding.DecodeString(key.E)
+		if err != nil {
+			return nil, err
+		}
+
+		return &rsa.PublicKey{
+			N: new(big.Int).SetBytes(n),
+			E: int(new(big.Int).SetBytes(e).Int64()),
+		}, nil
+	case "EC":
+		if key.Curve != "P-256" {
+			return nil, errors.New("curve " + key.Curve + " is not supported")
+		}
+
+		x, err := base64.RawURLEncoding.DecodeString(key.X)
+		if err != nil {
+			return nil, err
+		}
+
+		y, err := base64.RawURLEncoding.DecodeString(key.Y)
+		if err != nil {
+			return nil, err
+		}
+
+		return &ecdsa.PublicKey{
+			Curve: elliptic.P256(),
+			X:     new(big.Int).SetBytes(x),
+			Y:     new(big.Int).SetBytes(y),
+		}, nil
+	}
+
+	return nil, errors.New("key type " + key.KeyType + " is not supported")
+}
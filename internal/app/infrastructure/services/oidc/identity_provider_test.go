@@ -0,0 +1,304 @@
+package oidc
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/assert"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+)
+
+const (
+	testClientID     = "home-inventory"
+	testClientSecret = "client-secret"
+	testRedirectURL  = "http://localhost:8080/api/v1/oidc/callback"
+	testCode         = "authorization-code"
+	testVerifier     = "dBjftJeZ4CVP-mJ92K9Ea5xMvKWi2Mk7TYlIiYPr9Hg"
+	testNonce        = "nonce"
+	testKeyID        = "test-key"
+)
+
+// mockProvider is a minimal OpenID Connect provider that serves discovery,
+// the keys and a token endpoint that checks the PKCE verifier.
+type mockProvider struct {
+	server      *httptest.Server
+	key         *rsa.PrivateKey
+	claims      jwt.MapClaims
+	keysFetched int
+}
+
+func newMockProvider(t *testing.T) *mockProvider {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	provider := &mockProvider{key: key}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"issuer":                 provider.server.URL,
+			"authorization_endpoint": provider.server.URL + "/authorize",
+			"token_endpoint":         provider.server.URL + "/token",
+			"jwks_uri":               provider.server.URL + "/jwks",
+		})
+	})
+	mux.HandleFunc("/jwks", func(w http.ResponseWriter, r *http.Request) {
+		provider.keysFetched++
+		_ = json.NewEncoder(w).Encode(map[string]interface{}{
+			"keys": []map[string]string{
+				{
+					"kty": "RSA",
+					"use": "sig",
+					"kid": testKeyID,
+					"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+					"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+				},
+			},
+		})
+	})
+	mux.HandleFunc("/token", func(w http.ResponseWriter, r *http.Request) {
+		clientID, clientSecret, ok := r.BasicAuth()
+		if !ok || clientID != testClientID || clientSecret != testClientSecret {
+			w.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		challenge := sha256.Sum256([]byte(r.PostFormValue("code_verifier")))
+		if r.PostFormValue("grant_type") != "authorization_code" ||
+			r.PostFormValue("code") != testCode ||
+			r.PostFormValue("redirect_uri") != testRedirectURL ||
+			base64.RawURLEncoding.EncodeToString(challenge[:]) != testChallenge() {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		_ = json.NewEncoder(w).Encode(map[string]string{
+			"access_token": "access-token",
+			"token_type":   "Bearer",
+			"id_token":     provider.idToken(t, testKeyID),
+		})
+	})
+
+	provider.server = httptest.NewServer(mux)
+	provider.claims = jwt.MapClaims{
+		"iss":            provider.server.URL,
+		"sub":            "subject",
+		"aud":            testClientID,
+		"exp":            time.Now().Add(time.Minute).Unix(),
+		"iat":            time.Now().Unix(),
+		"nonce":          testNonce,
+		"email":          "test@example.com",
+		"email_verified": true,
+	}
+
+	t.Cleanup(provider.server.Close)
+
+	return provider
+}
+
+func (p *mockProvider) idToken(t *testing.T, kid string) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, p.claims)
+	token.Header["kid"] = kid
+
+	signed, err := token.SignedString(p.key)
+	assert.NoError(t, err)
+
+	return signed
+}
+
+func (p *mockProvider) identityProvider() *IdentityProvider {
+	return NewIdentityProvider(p.server.URL, testClientID, testClientSecret, testRedirectURL)
+}
+
+func testChallenge() string {
+	challenge := sha256.Sum256([]byte(testVerifier))
+	return base64.RawURLEncoding.EncodeToString(challenge[:])
+}
+
+func TestIdentityProviderAuthorizationURL(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	identityProvider := mockProvider.identityProvider()
+
+	authorizationURL, err := identityProvider.AuthorizationURL("state", testNonce, testChallenge())
+
+	assert.NoError(t, err)
+
+	parsed, err := url.Parse(authorizationURL)
+	assert.NoError(t, err)
+	assert.Equal(t, mockProvider.server.URL+"/authorize", parsed.Scheme+"://"+parsed.Host+parsed.Path)
+
+	query := parsed.Query()
+	assert.Equal(t, "code", query.Get("response_type"))
+	assert.Equal(t, testClientID, query.Get("client_id"))
+	assert.Equal(t, testRedirectURL, query.Get("redirect_uri"))
+	assert.Equal(t, "openid email", query.Get("scope"))
+	assert.Equal(t, "state", query.Get("state"))
+	assert.Equal(t, testNonce, query.Get("nonce"))
+	assert.Equal(t, testChallenge(), query.Get("code_challenge"))
+	assert.Equal(t, "S256", query.Get("code_challenge_method"))
+}
+
+func TestIdentityProviderAuthorizationURLErrorIssuerMismatch(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	identityProvider := NewIdentityProvider(mockProvider.server.URL+"/other", testClientID, testClientSecret, testRedirectURL)
+
+	authorizationURL, err := identityProvider.AuthorizationURL("state", testNonce, testChallenge())
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderCanNotDiscover)
+	assert.Empty(t, authorizationURL)
+}
+
+func TestIdentityProviderExchange(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	identityProvider := mockProvider.identityProvider()
+
+	identity, err := identityProvider.Exchange(testCode, testVerifier, testNonce)
+
+	assert.NoError(t, err)
+	assert.Equal(t, &services.Identity{
+		Issuer:        mockProvider.server.URL,
+		Subject:       "subject",
+		Email:         "test@example.com",
+		EmailVerified: true,
+	}, identity)
+}
+
+func TestIdentityProviderExchangeEmailVerifiedAsString(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	mockProvider.claims["email_verified"] = "true"
+	identityProvider := mockProvider.identityProvider()
+
+	identity, err := identityProvider.Exchange(testCode, testVerifier, testNonce)
+
+	assert.NoError(t, err)
+	assert.True(t, identity.EmailVerified)
+}
+
+func TestIdentityProviderExchangeEmailNotVerified(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	delete(mockProvider.claims, "email_verified")
+	identityProvider := mockProvider.identityProvider()
+
+	identity, err := identityProvider.Exchange(testCode, testVerifier, testNonce)
+
+	assert.NoError(t, err)
+	assert.False(t, identity.EmailVerified)
+}
+
+func TestIdentityProviderExchangeErrorWrongVerifier(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	identityProvider := mockProvider.identityProvider()
+
+	identity, err := identityProvider.Exchange(testCode, "wrong-verifier", testNonce)
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderCanNotExchangeCode)
+	assert.Nil(t, identity)
+}
+
+func TestIdentityProviderExchangeErrorInvalidIDToken(t *testing.T) {
+	testCases := map[string]func(claims jwt.MapClaims){
+		"wrong nonce": func(claims jwt.MapClaims) {
+			claims["nonce"] = "other-nonce"
+		},
+		"wrong issuer": func(claims jwt.MapClaims) {
+			claims["iss"] = "https://other.example.com"
+		},
+		"wrong audience": func(claims jwt.MapClaims) {
+			claims["aud"] = "other-client"
+		},
+		"expired": func(claims jwt.MapClaims) {
+			claims["exp"] = time.Now().Add(-time.Hour).Unix()
+		},
+		"without expiration": func(claims jwt.MapClaims) {
+			delete(claims, "exp")
+		},
+		"other authorized party": func(claims jwt.MapClaims) {
+			claims["aud"] = []string{testClientID, "other-client"}
+			claims["azp"] = "other-client"
+		},
+	}
+
+	for name, change := range testCases {
+		t.Run(name, func(t *testing.T) {
+			mockProvider := newMockProvider(t)
+			change(mockProvider.claims)
+			identityProvider := mockProvider.identityProvider()
+
+			identity, err := identityProvider.Exchange(testCode, testVerifier, testNonce)
+
+			assert.ErrorIs(t, err, services.ErrIdentityProviderInvalidIDToken)
+			assert.Nil(t, identity)
+		})
+	}
+}
+
+func TestIdentityProviderVerifyIDTokenErrorWrongSignature(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	identityProvider := mockProvider.identityProvider()
+
+	config, err := identityProvider.discover()
+	assert.NoError(t, err)
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	assert.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, mockProvider.claims)
+	token.Header["kid"] = testKeyID
+	idToken, err := token.SignedString(otherKey)
+	assert.NoError(t, err)
+
+	identity, err := identityProvider.verifyIDToken(config, idToken, testNonce)
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderInvalidIDToken)
+	assert.Nil(t, identity)
+}
+
+func TestIdentityProviderVerifyIDTokenErrorNoneAlgorithm(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	identityProvider := mockProvider.identityProvider()
+
+	config, err := identityProvider.discover()
+	assert.NoError(t, err)
+
+	token := jwt.NewWithClaims(jwt.SigningMethodNone, mockProvider.claims)
+	token.Header["kid"] = testKeyID
+	idToken, err := token.SignedString(jwt.UnsafeAllowNoneSignatureType)
+	assert.NoError(t, err)
+
+	identity, err := identityProvider.verifyIDToken(config, idToken, testNonce)
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderInvalidIDToken)
+	assert.Nil(t, identity)
+}
+
+func TestIdentityProviderVerifyIDTokenUnknownKeyIsNotFetchedTooOften(t *testing.T) {
+	mockProvider := newMockProvider(t)
+	identityProvider := mockProvider.identityProvider()
+
+	config, err := identityProvider.discover()
+	assert.NoError(t, err)
+	assert.Equal(t, 1, mockProvider.keysFetched)
+
+	identity, err := identityProvider.verifyIDToken(config, mockProvider.idToken(t, "unknown-key"), testNonce)
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderInvalidIDToken)
+	assert.Nil(t, identity)
+	assert.Equal(t, 1, mockProvider.keysFetched)
+
+	identityProvider.keysFetchedAt = time.Now().Add(-keysRefreshInterval)
+
+	identity, err = identityProvider.verifyIDToken(config, mockProvider.idToken(t, "unknown-key"), testNonce)
+
+	assert.ErrorIs(t, err, services.ErrIdentityProviderInvalidIDToken)
+	assert.Nil(t, identity)
+	assert.Equal(t, 2, mockProvider.keysFetched)
+}
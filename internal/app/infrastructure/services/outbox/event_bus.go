@@ -0,0 +1,332 @@
+package outbox
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/application/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	domain "github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"reflect"
+	"sync"
+	"time"
+)
+
+const dispatchBatchSize = 50
+
+var (
+	ErrEventBusCanNotDecodeEvent = errors.New("can not decode outbox event")
+	ErrEventBusShutDown          = errors.New("outbox event bus is shut down")
+)
+
+// EventBus writes the published events to the outbox, and delivers them to
+// the subscribers when Dispatch runs. Every handler that gets an event is
+// recorded, so a retry only runs the handlers that failed. A handler can still
+// see the same event again when it fails to be recorded. It is safe to
+// Subscribe while events are dispatched. The handlers subscribed with
+// SubscribeAsync run on a pool of workers, so no more than the workers given
+// to NewEventBus run at once.
+type EventBus struct {
+	outboxRepository repositories.OutboxRepository
+	retryPolicy      entities.RetryPolicy
+	mu               sync.RWMutex
+	subscribers      map[string][]subscriber
+	eventTypes       map[string]reflect.Type
+	jobs             chan func()
+	workers          sync.WaitGroup
+	stateMu          sync.RWMutex
+	stopping         bool
+	closed           bool
+	running          sync.WaitGroup
+	stop             chan struct{}
+	shutdownOnce     sync.Once
+	shutdownDone     chan struct{}
+}
+
+// subscriber is a handler with the name its deliveries are recorded with.
+type subscriber struct {
+	name    string
+	handler domain.EventHandler
+	async   bool
+}
+
+func NewEventBus(
+	outboxRepository repositories.OutboxRepository,
+	retryPolicy entities.RetryPolicy,
+	workers int,
+) *EventBus {
+	e := &EventBus{
+		outboxRepository: outboxRepository,
+		retryPolicy:      retryPolicy,
+		subscribers:      make(map[string][]subscriber),
+		eventTypes:       make(map[string]reflect.Type),
+		jobs:             make(chan func()),
+		stop:             make(chan struct{}),
+		shutdownDone:     make(chan struct{}),
+	}
+
+	for i := 0; i < max(workers, 1); i++ {
+		e.workers.Add(1)
+		go e.work()
+	}
+
+	return e
+}
+
+// Publish writes the event with the repository of the bus. To write it in
+// the same transaction as a change, create the entities.OutboxEvent with the
+// OutboxRepository of the repositories.Transaction instead.
+func (e *EventBus) Publish(event domain.Event) error {
+	outboxEvent, err := entities.NewOutboxEvent(domain.GetEventType(event), event)
+	if err != nil {
+		return err
+	}
+
+	return e.outboxRepository.Create(outboxEvent)
+}
+
+// Subscribe panics when the event already has a handler with the name, as
+// their deliveries could not be told apart.
+func (e *EventBus) Subscribe(event domain.Event, name string, handler domain.EventHandler) {
+	e.subscribe(event, name, handler, false)
+}
+
+// SubscribeAsync is Subscribe for a handler that does not depend on the
+// others, it runs on a worker while they run.
+func (e *EventBus) SubscribeAsync(event domain.Event, name string, handler domain.EventHandler) {
+	e.subscribe(event, name, handler, true)
+}
+
+func (e *EventBus) subscribe(event domain.Event, name string, handler domain.EventHandler, async bool) {
+	eventType := domain.GetEventType(event)
+
+	e.mu.Lock()
+	defer e.mu.Unlock()
+
+	for _, subscriber := range e.subscribers[eventType] {
+		if subscriber.name == name {
+			panic(fmt.Sprintf("handler %s is already subscribed to %s", name, eventType))
+		}
+	}
+
+	e.subscribers[eventType] = append(e.subscribers[eventType], subscriber{name, handler, async})
+	e.eventTypes[eventType] = reflect.TypeOf(event)
+}
+
+// Dispatch delivers up to limit available events and returns how many of
+// them were delivered. A failed event waits for the backoff of the retry
+// policy and is dead once it runs out of attempts.
+func (e *EventBus) Dispatch(limit int) (int, error) {
+	return services.ProcessAvailable(
+		e.outboxRepository,
+		repositories.ErrOutboxRepositoryEventAlreadyClaimed,
+		limit,
+		e.dispatch,
+	)
+}
+
+// dispatch delivers the event and marks it as delivered or failed.
+func (e *EventBus) dispatch(outboxEvent *entities.OutboxEvent) (bool, error) {
+	if err := e.deliver(outboxEvent); err != nil {
+		logger.LogError(err)
+		outboxEvent.MarkFailed(err, time.Now(), e.retryPolicy)
+		if !outboxEvent.IsPending() {
+			logger.LogError(fmt.Errorf("outbox event %s of type %s is dead after %d attempts", outboxEvent.ID, outboxEvent.Type, outboxEvent.Attempts))
+		}
+		return false, nil
+	}
+
+	outboxEvent.MarkDelivered(time.Now())
+	return true, nil
+}
+
+// Run dispatches the available events every interval until Shutdown is
+// called.
+func (e *EventBus) Run(interval time.Duration) {
+	e.stateMu.Lock()
+	if e.stopping {
+		e.stateMu.Unlock()
+		return
+	}
+	e.running.Add(1)
+	e.stateMu.Unlock()
+	defer e.running.Done()
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-e.stop:
+			return
+		case <-ticker.C:
+			if _, err := e.Dispatch(dispatchBatchSize); err != nil {
+				logger.LogError(err)
+			}
+		}
+	}
+}
+
+// Shutdown stops Run, drains the events that are still available and waits
+// for the handlers that are running, so the events published by the last
+// requests are not left for the next start. When ctx is done first it returns
+// its error, and the shutdown goes on in the background.
+func (e *EventBus) Shutdown(ctx context.Context) error {
+	e.shutdownOnce.Do(func() {
+		go e.shutdown()
+	})
+
+	select {
+	case <-e.shutdownDone:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+func (e *EventBus) shutdown() {
+	e.stateMu.Lock()
+	e.stopping = true
+	close(e.stop)
+	e.stateMu.Unlock()
+
+	e.running.Wait()
+	e.drain()
+
+	e.stateMu.Lock()
+	e.closed = true
+	close(e.jobs)
+	e.stateMu.Unlock()
+
+	e.workers.Wait()
+	close(e.shutdownDone)
+}
+
+// drain dispatches batches until one is not fully delivered. The events that
+// failed wait for their backoff, so they are not retried here.
+func (e *EventBus) drain() {
+	for {
+		delivered, err := e.Dispatch(dispatchBatchSize)
+		if err != nil {
+			logger.LogError(err)
+			return
+		}
+		if delivered < dispatchBatchSize {
+			return
+		}
+	}
+}
+
+// deliver runs the handlers that did not get the event yet. The synchronous
+// ones stop at the first one that fails or panics, while the asynchronous ones
+// run on the workers, and the event fails when any of them failed. An event
+// nobody subscribed to is delivered as there is nothing to do with it.
+func (e *EventBus) deliver(outboxEvent *entities.OutboxEvent) error {
+	e.mu.RLock()
+	eventType, ok := e.eventTypes[outboxEvent.Type]
+	subscribers := e.subscribers[outboxEvent.Type]
+	e.mu.RUnlock()
+
+	if !ok {
+		return nil
+	}
+
+	value := reflect.New(eventType)
+	if err := json.Unmarshal([]byte(outboxEvent.Payload), value.Interface()); err != nil {
+		logger.LogError(err)
+		return ErrEventBusCanNotDecodeEvent
+	}
+	event := value.Elem().Interface()
+
+	deliveredHandlers, err := e.outboxRepository.GetDeliveredHandlers(outboxEvent.ID)
+	if err != nil {
+		return err
+	}
+
+	delivered := make(map[string]bool, len(deliveredHandlers))
+	for _, name := range deliveredHandlers {
+		delivered[name] = true
+	}
+
+	var running sync.WaitGroup
+	errs := make([]error, len(subscribers)+1)
+	for i, subscriber := range subscribers {
+		if !subscriber.async || delivered[subscriber.name] {
+			continue
+		}
+
+		i, subscriber := i, subscriber
+		running.Add(1)
+		err = e.submit(func() {
+			defer running.Done()
+			errs[i] = e.run(outboxEvent, subscriber, event)
+		})
+		if err != nil {
+			running.Done()
+			errs[i] = err
+			break
+		}
+	}
+
+	for _, subscriber := range subscribers {
+		if subscriber.async || delivered[subscriber.name] {
+			continue
+		}
+
+		if err = e.run(outboxEvent, subscriber, event); err != nil {
+			errs[len(subscribers)] = err
+			break
+		}
+	}
+
+	running.Wait()
+
+	return errors.Join(errs...)
+}
+
+// run gives the event to the handler and records it.
+func (e *EventBus) run(outboxEvent *entities.OutboxEvent, subscriber subscriber, event domain.Event) error {
+	if err := handle(outboxEvent.Type, subscriber.handler, event); err != nil {
+		return err
+	}
+
+	return e.outboxRepository.CreateDeliveredHandler(&entities.OutboxEventHandler{
+		EventID:     outboxEvent.ID,
+		Handler:     subscriber.name,
+		DeliveredAt: time.Now(),
+	})
+}
+
+// submit waits for a free worker and gives it fn.
+func (e *EventBus) submit(fn func()) error {
+	e.stateMu.RLock()
+	defer e.stateMu.RUnlock()
+
+	if e.closed {
+		return ErrEventBusShutDown
+	}
+
+	e.jobs <- fn
+	return nil
+}
+
+func (e *EventBus) work() {
+	defer e.workers.Done()
+
+	for job := range e.jobs {
+		job()
+	}
+}
+
+func handle(eventType string, handler domain.EventHandler, event domain.Event) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("handler of %s panicked: %v", eventType, r)
+		}
+	}()
+
+	return handler(event)
+}
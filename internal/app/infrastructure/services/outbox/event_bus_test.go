@@ -0,0 +1,633 @@
+package outbox
+
+import (
+	"context"
+	"errors"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/entities"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/repositories"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/internal/app/infrastructure/repositories/stub"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+var retryPolicy = entities.RetryPolicy{MaxAttempts: 3, BaseBackoff: time.Minute, MaxBackoff: time.Hour}
+
+func makeStringOutboxEvent(t *testing.T, value string) *entities.OutboxEvent {
+	outboxEvent, err := entities.NewOutboxEvent("StringEvent", services.StringEvent{Value: value})
+	assert.NoError(t, err)
+	return outboxEvent
+}
+
+func TestNewEventBus(t *testing.T) {
+	eventBus := NewEventBus(&stub.OutboxRepositoryMock{}, retryPolicy, 2)
+
+	assert.NotNil(t, eventBus)
+	assert.Len(t, eventBus.subscribers, 0)
+	assert.Len(t, eventBus.eventTypes, 0)
+}
+
+func TestEventBusPublish(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxRepository.On("Create", mock.MatchedBy(func(outboxEvent *entities.OutboxEvent) bool {
+		return outboxEvent.Type == "StringEvent" &&
+			outboxEvent.Payload == `{"Value":"hello"}` &&
+			outboxEvent.Status == entities.OutboxEventStatusPending
+	})).Return(nil)
+
+	err := eventBus.Publish(services.StringEvent{Value: "hello"})
+
+	assert.NoError(t, err)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusPublishError(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxRepository.On("Create", mock.Anything).Return(repositories.ErrOutboxRepositoryCanNotCreateEvent)
+
+	err := eventBus.Publish(services.StringEvent{Value: "hello"})
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotCreateEvent)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatch(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	calledValue := ""
+	eventBus.Subscribe(services.StringEvent{}, "handler", func(event services.Event) error {
+		calledValue = event.(services.StringEvent).Value
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("CreateDeliveredHandler", mock.MatchedBy(func(handler *entities.OutboxEventHandler) bool {
+		return handler.EventID == outboxEvent.ID && handler.Handler == "handler"
+	})).Return(nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, "hello", calledValue)
+	assert.Equal(t, entities.OutboxEventStatusDelivered, outboxEvent.Status)
+	assert.Equal(t, 1, outboxEvent.Attempts)
+	assert.NotNil(t, outboxEvent.DeliveredAt)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusSubscribePanicsWithRepeatedName(t *testing.T) {
+	eventBus := NewEventBus(&stub.OutboxRepositoryMock{}, retryPolicy, 2)
+	handler := func(event services.Event) error {
+		return nil
+	}
+
+	eventBus.Subscribe(services.StringEvent{}, "handler", handler)
+	eventBus.Subscribe(services.BoxItemAddedEvent{}, "handler", handler)
+
+	assert.PanicsWithValue(t, "handler handler is already subscribed to StringEvent", func() {
+		eventBus.SubscribeAsync(services.StringEvent{}, "handler", handler)
+	})
+	assert.Len(t, eventBus.subscribers["StringEvent"], 1)
+	assert.Len(t, eventBus.subscribers["BoxItemAddedEvent"], 1)
+}
+
+func TestEventBusSubscribeWhileDispatching(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	eventBus.Subscribe(services.StringEvent{}, "handler", func(event services.Event) error {
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("CreateDeliveredHandler", mock.Anything).Return(nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			eventBus.Subscribe(services.StringEvent{}, "handler"+strconv.Itoa(i), func(event services.Event) error {
+				return nil
+			})
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		_, err := eventBus.Dispatch(10)
+		assert.NoError(t, err)
+	}()
+	wg.Wait()
+
+	assert.Len(t, eventBus.subscribers["StringEvent"], 11)
+}
+
+func TestEventBusSubscribeAsyncWhileDispatching(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	firstEvent := makeStringOutboxEvent(t, "first")
+	secondEvent := makeStringOutboxEvent(t, "second")
+	var calls atomic.Int32
+	eventBus.SubscribeAsync(services.StringEvent{}, "handler", func(event services.Event) error {
+		calls.Add(1)
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{firstEvent}, nil).Once()
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{secondEvent}, nil).Once()
+	outboxRepository.On("Claim", mock.Anything, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", mock.Anything).Return([]string{}, nil)
+	outboxRepository.On("CreateDeliveredHandler", mock.Anything).Return(nil)
+	outboxRepository.On("Update", mock.Anything).Return(nil)
+
+	var wg sync.WaitGroup
+	wg.Add(3)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			eventBus.SubscribeAsync(services.StringEvent{}, "handler"+strconv.Itoa(i), func(event services.Event) error {
+				calls.Add(1)
+				return nil
+			})
+		}
+	}()
+	for i := 0; i < 2; i++ {
+		go func() {
+			defer wg.Done()
+			_, err := eventBus.Dispatch(10)
+			assert.NoError(t, err)
+		}()
+	}
+	wg.Wait()
+
+	assert.Len(t, eventBus.subscribers["StringEvent"], 11)
+	assert.GreaterOrEqual(t, calls.Load(), int32(2))
+}
+
+func TestEventBusDispatchRunsAsyncHandlersOnWorkers(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	var running atomic.Int32
+	var maxRunning atomic.Int32
+	var calls atomic.Int32
+	for i := 0; i < 4; i++ {
+		eventBus.SubscribeAsync(services.StringEvent{}, "handler"+strconv.Itoa(i), func(event services.Event) error {
+			current := running.Add(1)
+			defer running.Add(-1)
+			for {
+				previous := maxRunning.Load()
+				if current <= previous || maxRunning.CompareAndSwap(previous, current) {
+					break
+				}
+			}
+			time.Sleep(20 * time.Millisecond)
+			calls.Add(1)
+			return nil
+		})
+	}
+	syncCalled := false
+	eventBus.Subscribe(services.StringEvent{}, "handler", func(event services.Event) error {
+		syncCalled = true
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("CreateDeliveredHandler", mock.Anything).Return(nil).Times(5)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.True(t, syncCalled)
+	assert.Equal(t, int32(4), calls.Load())
+	assert.Equal(t, int32(2), maxRunning.Load())
+	assert.Equal(t, entities.OutboxEventStatusDelivered, outboxEvent.Status)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchRecoversAsyncHandlerPanic(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	eventBus.SubscribeAsync(services.StringEvent{}, "first", func(event services.Event) error {
+		panic("handler panic")
+	})
+	otherCalled := false
+	eventBus.SubscribeAsync(services.StringEvent{}, "second", func(event services.Event) error {
+		otherCalled = true
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("CreateDeliveredHandler", mock.MatchedBy(func(handler *entities.OutboxEventHandler) bool {
+		return handler.Handler == "second"
+	})).Return(nil).Once()
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.True(t, otherCalled)
+	assert.Equal(t, entities.OutboxEventStatusPending, outboxEvent.Status)
+	assert.Contains(t, *outboxEvent.LastError, "handler of StringEvent panicked: handler panic")
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusShutdownStopsRunAndDrains(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	var calledValue atomic.Value
+	eventBus.SubscribeAsync(services.StringEvent{}, "handler", func(event services.Event) error {
+		calledValue.Store(event.(services.StringEvent).Value)
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), dispatchBatchSize).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil).Once()
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("CreateDeliveredHandler", mock.Anything).Return(nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	stopped := make(chan struct{})
+	go func() {
+		eventBus.Run(time.Hour)
+		close(stopped)
+	}()
+
+	err := eventBus.Shutdown(context.Background())
+
+	assert.NoError(t, err)
+	<-stopped
+	assert.Equal(t, "hello", calledValue.Load())
+	assert.Equal(t, entities.OutboxEventStatusDelivered, outboxEvent.Status)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusShutdownStopsDrainingOnError(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), dispatchBatchSize).
+		Return(nil, repositories.ErrOutboxRepositoryCanNotGetEvents).Once()
+
+	err := eventBus.Shutdown(context.Background())
+
+	assert.NoError(t, err)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusShutdownErrorContextDone(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	release := make(chan struct{})
+	eventBus.SubscribeAsync(services.StringEvent{}, "handler", func(event services.Event) error {
+		<-release
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), dispatchBatchSize).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil).Once()
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("CreateDeliveredHandler", mock.Anything).Return(nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+
+	err := eventBus.Shutdown(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+
+	close(release)
+	err = eventBus.Shutdown(context.Background())
+
+	assert.NoError(t, err)
+	assert.Equal(t, entities.OutboxEventStatusDelivered, outboxEvent.Status)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchErrorShutDown(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), dispatchBatchSize).
+		Return([]*entities.OutboxEvent{}, nil).Once()
+	err := eventBus.Shutdown(context.Background())
+	assert.NoError(t, err)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	eventBus.SubscribeAsync(services.StringEvent{}, "handler", func(event services.Event) error {
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, ErrEventBusShutDown.Error(), *outboxEvent.LastError)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchOnlyRetriesFailedHandlers(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	firstCalls := 0
+	secondCalls := 0
+	eventBus.Subscribe(services.StringEvent{}, "first", func(event services.Event) error {
+		firstCalls++
+		return nil
+	})
+	eventBus.Subscribe(services.StringEvent{}, "second", func(event services.Event) error {
+		secondCalls++
+		if secondCalls == 1 {
+			return errors.New("handler error")
+		}
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil).Once()
+	outboxRepository.On("CreateDeliveredHandler", mock.MatchedBy(func(handler *entities.OutboxEventHandler) bool {
+		return handler.EventID == outboxEvent.ID && handler.Handler == "first"
+	})).Return(nil).Once()
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, 1, firstCalls)
+	assert.Equal(t, 1, secondCalls)
+	assert.Equal(t, entities.OutboxEventStatusPending, outboxEvent.Status)
+
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{"first"}, nil).Once()
+	outboxRepository.On("CreateDeliveredHandler", mock.MatchedBy(func(handler *entities.OutboxEventHandler) bool {
+		return handler.EventID == outboxEvent.ID && handler.Handler == "second"
+	})).Return(nil).Once()
+
+	delivered, err = eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, 1, firstCalls)
+	assert.Equal(t, 2, secondCalls)
+	assert.Equal(t, entities.OutboxEventStatusDelivered, outboxEvent.Status)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchErrorCanNotGetDeliveredHandlers(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	called := false
+	eventBus.Subscribe(services.StringEvent{}, "handler", func(event services.Event) error {
+		called = true
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).
+		Return(nil, repositories.ErrOutboxRepositoryCanNotGetHandlers)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.False(t, called)
+	assert.Equal(t, repositories.ErrOutboxRepositoryCanNotGetHandlers.Error(), *outboxEvent.LastError)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchWithoutSubscribers(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 1, delivered)
+	assert.Equal(t, entities.OutboxEventStatusDelivered, outboxEvent.Status)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchSkipsClaimedEvents(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	called := false
+	eventBus.Subscribe(services.StringEvent{}, "handler", func(event services.Event) error {
+		called = true
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(repositories.ErrOutboxRepositoryEventAlreadyClaimed)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.False(t, called)
+	outboxRepository.AssertExpectations(t)
+	outboxRepository.AssertNotCalled(t, "Update", mock.Anything)
+}
+
+func TestEventBusDispatchRetriesFailedEvents(t *testing.T) {
+	testCases := []struct {
+		name    string
+		handler services.EventHandler
+	}{
+		{"handler error", func(event services.Event) error {
+			return errors.New("handler error")
+		}},
+		{"handler panic", func(event services.Event) error {
+			panic("handler panic")
+		}},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			outboxRepository := &stub.OutboxRepositoryMock{}
+			eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+			outboxEvent := makeStringOutboxEvent(t, "hello")
+			eventBus.Subscribe(services.StringEvent{}, "handler", testCase.handler)
+
+			outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+				Return([]*entities.OutboxEvent{outboxEvent}, nil)
+			outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+				Return(nil)
+			outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+			outboxRepository.On("Update", outboxEvent).Return(nil)
+
+			delivered, err := eventBus.Dispatch(10)
+
+			assert.NoError(t, err)
+			assert.Equal(t, 0, delivered)
+			assert.Equal(t, entities.OutboxEventStatusPending, outboxEvent.Status)
+			assert.Equal(t, 1, outboxEvent.Attempts)
+			assert.Contains(t, *outboxEvent.LastError, testCase.name)
+			assert.True(t, outboxEvent.AvailableAt.After(time.Now()))
+			outboxRepository.AssertExpectations(t)
+		})
+	}
+}
+
+func TestEventBusDispatchMarksDeadEvents(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	outboxEvent.Attempts = retryPolicy.MaxAttempts - 1
+	eventBus.Subscribe(services.StringEvent{}, "handler", func(event services.Event) error {
+		return errors.New("handler error")
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("GetDeliveredHandlers", outboxEvent.ID).Return([]string{}, nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, entities.OutboxEventStatusDead, outboxEvent.Status)
+	assert.Equal(t, retryPolicy.MaxAttempts, outboxEvent.Attempts)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchErrorCanNotDecodeEvent(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+	outboxEvent.Payload = `{"Value":1}`
+	eventBus.Subscribe(services.StringEvent{}, "handler", func(event services.Event) error {
+		return nil
+	})
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("Update", outboxEvent).Return(nil)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.NoError(t, err)
+	assert.Equal(t, 0, delivered)
+	assert.Equal(t, ErrEventBusCanNotDecodeEvent.Error(), *outboxEvent.LastError)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchErrorCanNotGetAvailable(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return(nil, repositories.ErrOutboxRepositoryCanNotGetEvents)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotGetEvents)
+	assert.Equal(t, 0, delivered)
+	outboxRepository.AssertExpectations(t)
+}
+
+func TestEventBusDispatchErrorCanNotUpdate(t *testing.T) {
+	outboxRepository := &stub.OutboxRepositoryMock{}
+	eventBus := NewEventBus(outboxRepository, retryPolicy, 2)
+
+	outboxEvent := makeStringOutboxEvent(t, "hello")
+
+	outboxRepository.On("GetAvailable", mock.AnythingOfType("time.Time"), 10).
+		Return([]*entities.OutboxEvent{outboxEvent}, nil)
+	outboxRepository.On("Claim", outboxEvent, mock.AnythingOfType("time.Time"), mock.AnythingOfType("time.Time")).
+		Return(nil)
+	outboxRepository.On("Update", outboxEvent).Return(repositories.ErrOutboxRepositoryCanNotUpdateEvent)
+
+	delivered, err := eventBus.Dispatch(10)
+
+	assert.ErrorIs(t, err, repositories.ErrOutboxRepositoryCanNotUpdateEvent)
+	assert.Equal(t, 1, delivered)
+	outboxRepository.AssertExpectations(t)
+}
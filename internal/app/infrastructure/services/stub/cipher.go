@@ -0,0 +1,17 @@
+package stub
+
+import "github.com/stretchr/testify/mock"
+
+type CipherMock struct {
+	mock.Mock
+}
+
+func (m *CipherMock) Encrypt(value string) (string, error) {
+	args := m.Called(value)
+	return args.String(0), args.Error(1)
+}
+
+func (m *CipherMock) Decrypt(encrypted string) (string, error) {
+	args := m.Called(encrypted)
+	return args.String(0), args.Error(1)
+}
@@ -14,6 +14,10 @@ func (m *EventBusMock) Publish(event services.Event) error {
 	return args.Error(0)
 }
 
-func (m *EventBusMock) Subscribe(event services.Event, handler services.EventHandler) {
-	m.Called(event, handler)
+func (m *EventBusMock) Subscribe(event services.Event, name string, handler services.EventHandler) {
+	m.Called(event, name, handler)
+}
+
+func (m *EventBusMock) SubscribeAsync(event services.Event, name string, handler services.EventHandler) {
+	m.Called(event, name, handler)
 }
@@ -1,16 +1,22 @@
 package stub
 
 import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/stretchr/testify/mock"
-	"os"
+	"io"
 )
 
 type FileManagerMock struct {
 	mock.Mock
 }
 
-func (m *FileManagerMock) Upload(file *os.File) (string, error) {
-	args := m.Called(file)
+func (m *FileManagerMock) Upload(
+	content io.Reader,
+	size int64,
+	contentType string,
+	extension string,
+) (string, error) {
+	args := m.Called(content, size, contentType, extension)
 	return args.String(0), args.Error(1)
 }
 
@@ -23,3 +29,28 @@ func (m *FileManagerMock) Delete(id string, extension string) error {
 	args := m.Called(id, extension)
 	return args.Error(0)
 }
+
+func (m *FileManagerMock) List() ([]services.StoredFile, error) {
+	args := m.Called()
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).([]services.StoredFile), args.Error(1)
+}
+
+func (m *FileManagerMock) Open(id string, extension string) (io.ReadSeekCloser, error) {
+	args := m.Called(id, extension)
+
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+
+	return args.Get(0).(io.ReadSeekCloser), args.Error(1)
+}
+
+func (m *FileManagerMock) Exists(id string, extension string) (bool, error) {
+	args := m.Called(id, extension)
+	return args.Bool(0), args.Error(1)
+}
@@ -0,0 +1,23 @@
+package stub
+
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/mock"
+)
+
+type IdentityProviderMock struct {
+	mock.Mock
+}
+
+func (m *IdentityProviderMock) AuthorizationURL(state string, nonce string, codeChallenge string) (string, error) {
+	args := m.Called(state, nonce, codeChallenge)
+	return args.String(0), args.Error(1)
+}
+
+func (m *IdentityProviderMock) Exchange(code string, codeVerifier string, nonce string) (*services.Identity, error) {
+	args := m.Called(code, codeVerifier, nonce)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*services.Identity), args.Error(1)
+}
@@ -0,0 +1,20 @@
+package stub
+
+import (
+	"github.com/stretchr/testify/mock"
+	"io"
+)
+
+type ImageProcessorMock struct {
+	mock.Mock
+}
+
+func (m *ImageProcessorMock) RemoveMetadata(content io.Reader, size int64) (io.Reader, int64, error) {
+	args := m.Called(content, size)
+
+	if args.Get(0) == nil {
+		return nil, 0, args.Error(2)
+	}
+
+	return args.Get(0).(io.Reader), args.Get(1).(int64), args.Error(2)
+}
@@ -0,0 +1,12 @@
+package stub
+
+import "github.com/stretchr/testify/mock"
+
+type MailRendererMock struct {
+	mock.Mock
+}
+
+func (m *MailRendererMock) Render(name string, data interface{}) (string, string, error) {
+	args := m.Called(name, data)
+	return args.String(0), args.String(1), args.Error(2)
+}
@@ -1,6 +1,9 @@
 package stub
 
-import "github.com/stretchr/testify/mock"
+import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/mock"
+)
 
 type MailSenderMock struct {
 	mock.Mock
@@ -10,3 +13,8 @@ func (m *MailSenderMock) SendMail(to string, subject string, body string) error
 	args := m.Called(to, subject, body)
 	return args.Error(0)
 }
+
+func (m *MailSenderMock) Send(mail services.Mail) error {
+	args := m.Called(mail)
+	return args.Error(0)
+}
@@ -0,0 +1,24 @@
+package stub
+
+import (
+	"github.com/stretchr/testify/mock"
+)
+
+type PasswordHasherMock struct {
+	mock.Mock
+}
+
+func (m *PasswordHasherMock) Hash(password string) (string, error) {
+	args := m.Called(password)
+	return args.String(0), args.Error(1)
+}
+
+func (m *PasswordHasherMock) Verify(password string, hash string) bool {
+	args := m.Called(password, hash)
+	return args.Bool(0)
+}
+
+func (m *PasswordHasherMock) NeedsRehash(hash string) bool {
+	args := m.Called(hash)
+	return args.Bool(0)
+}
@@ -0,0 +1,20 @@
+package stub
+
+import (
+	"github.com/stretchr/testify/mock"
+	"time"
+)
+
+type SignerMock struct {
+	mock.Mock
+}
+
+func (m *SignerMock) Sign(value string, expiresAt time.Time) string {
+	args := m.Called(value, expiresAt)
+	return args.String(0)
+}
+
+func (m *SignerMock) Verify(signed string) (string, error) {
+	args := m.Called(signed)
+	return args.String(0), args.Error(1)
+}
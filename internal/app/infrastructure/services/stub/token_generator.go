@@ -1,6 +1,7 @@
 package stub
 
 import (
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -8,25 +9,32 @@ type TokenGeneratorMock struct {
 	mock.Mock
 }
 
-func (m *TokenGeneratorMock) GenerateToken(userID, userEmail string) (string, error) {
-	args := m.Called(userID, userEmail)
+func (m *TokenGeneratorMock) GenerateToken(userID, userEmail, sessionID string) (string, error) {
+	args := m.Called(userID, userEmail, sessionID)
 	return args.String(0), args.Error(1)
 }
 
 func (s *TokenGeneratorMock) ParseToken(token string) (
 	*struct {
-		ID    string
-		Email string
+		ID        string
+		Email     string
+		SessionID string
 	},
 	error,
 ) {
 	args := s.Called(token)
 	if args.Get(0) != nil {
 		return args.Get(0).(*struct {
-			ID    string
-			Email string
+			ID        string
+			Email     string
+			SessionID string
 		}), args.Error(1)
 	}
 
 	return nil, args.Error(1)
 }
+
+func (m *TokenGeneratorMock) PublicKeys() []services.PublicKey {
+	args := m.Called()
+	return args.Get(0).([]services.PublicKey)
+}
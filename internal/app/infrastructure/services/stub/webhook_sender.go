@@ -0,0 +1,18 @@
+package stub
+
+import "github.com/stretchr/testify/mock"
+
+type WebhookSenderMock struct {
+	mock.Mock
+}
+
+func (m *WebhookSenderMock) Send(
+	url string,
+	secret string,
+	deliveryID string,
+	eventType string,
+	payload string,
+) (int, error) {
+	args := m.Called(url, secret, deliveryID, eventType, payload)
+	return args.Int(0), args.Error(1)
+}
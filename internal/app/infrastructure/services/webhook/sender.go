@@ -0,0 +1,132 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/jibaru/home-inventory-api/m/logger"
+	"io"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
+)
+
+const (
+	SignatureHeader  = "X-Webhook-Signature"
+	EventHeader      = "X-Webhook-Event"
+	DeliveryHeader   = "X-Webhook-Delivery"
+	userAgent        = "home-inventory-webhooks"
+	sendTimeout      = 10 * time.Second
+	maxResponseBytes = 64 * 1024
+)
+
+// Sender posts the deliveries with a signature header like
+// "t=1700000000,v1=<hex>", where v1 is the HMAC-SHA256 of the timestamp, a dot
+// and the body, keyed with the secret of the webhook. Redirects are not
+// followed, and unless private networks are allowed, it does not connect to
+// loopback, private nor link local addresses, so a webhook can not reach the
+// services next to the API.
+type Sender struct {
+	httpClient *http.Client
+}
+
+func NewSender(allowPrivateNetworks bool) *Sender {
+	dialer := &net.Dialer{Timeout: sendTimeout}
+	if !allowPrivateNetworks {
+		dialer.Control = denyPrivateAddresses
+	}
+
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.DialContext = dialer.DialContext
+	transport.Proxy = nil
+
+	return &Sender{
+		httpClient: &http.Client{
+			Timeout:   sendTimeout,
+			Transport: transport,
+			CheckRedirect: func(*http.Request, []*http.Request) error {
+				return http.ErrUseLastResponse
+			},
+		},
+	}
+}
+
+func (s *Sender) Send(
+	url string,
+	secret string,
+	deliveryID string,
+	eventType string,
+	payload string,
+) (int, error) {
+	request, err := http.NewRequest(http.MethodPost, url, strings.NewReader(payload))
+	if err != nil {
+		logger.LogError(err)
+		return 0, services.ErrWebhookSenderCanNotSend
+	}
+
+	request.Header.Set("Content-Type", "application/json")
+	request.Header.Set("User-Agent", userAgent)
+	request.Header.Set(EventHeader, eventType)
+	request.Header.Set(DeliveryHeader, deliveryID)
+	request.Header.Set(SignatureHeader, Sign(secret, time.Now(), payload))
+
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		logger.LogError(err)
+		if errors.Is(err, services.ErrWebhookSenderAddressIsNotAllowed) {
+			return 0, services.ErrWebhookSenderAddressIsNotAllowed
+		}
+
+		return 0, services.ErrWebhookSenderCanNotSend
+	}
+	defer response.Body.Close()
+
+	// The body is read, up to a limit, so the connection can be reused.
+	_, _ = io.Copy(io.Discard, io.LimitReader(response.Body, maxResponseBytes))
+
+	if response.StatusCode < 200 || response.StatusCode > 299 {
+		return response.StatusCode, fmt.Errorf("%w: %d", services.ErrWebhookSenderUnexpectedStatusCode, response.StatusCode)
+	}
+
+	return response.StatusCode, nil
+}
+
+// Sign returns the value of the signature header of a payload sent at
+// timestamp.
+func Sign(secret string, timestamp time.Time, payload string) string {
+	unix := strconv.FormatInt(timestamp.Unix(), 10)
+
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(unix + "." + payload))
+
+	return "t=" + unix + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// denyPrivateAddresses runs with the resolved address, so a host name that
+// points to a private address is denied too.
+func denyPrivateAddresses(_ string, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+
+	ip := net.ParseIP(host)
+	if ip == nil ||
+		ip.IsLoopback() ||
+		ip.IsPrivate() ||
+		ip.IsUnspecified() ||
+		ip.IsLinkLocalUnicast() ||
+		ip.IsLinkLocalMulticast() ||
+		ip.IsInterfaceLocalMulticast() ||
+		ip.IsMulticast() {
+		return services.ErrWebhookSenderAddressIsNotAllowed
+	}
+
+	return nil
+}
@@ -0,0 +1,117 @@
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"github.com/jibaru/home-inventory-api/m/internal/app/domain/services"
+	"github.com/stretchr/testify/assert"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestSenderSend(t *testing.T) {
+	var request *http.Request
+	var body string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		request = r
+		content, _ := io.ReadAll(r.Body)
+		body = string(content)
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewSender(true)
+
+	statusCode, err := sender.Send(server.URL, "whsec_secret", "delivery-id", "BoxItemAddedEvent", `{"event":"BoxItemAddedEvent"}`)
+
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusNoContent, statusCode)
+	assert.Equal(t, http.MethodPost, request.Method)
+	assert.Equal(t, `{"event":"BoxItemAddedEvent"}`, body)
+	assert.Equal(t, "application/json", request.Header.Get("Content-Type"))
+	assert.Equal(t, "BoxItemAddedEvent", request.Header.Get(EventHeader))
+	assert.Equal(t, "delivery-id", request.Header.Get(DeliveryHeader))
+
+	timestamp, signature, found := strings.Cut(request.Header.Get(SignatureHeader), ",")
+	assert.True(t, found)
+	assert.True(t, strings.HasPrefix(timestamp, "t="))
+
+	mac := hmac.New(sha256.New, []byte("whsec_secret"))
+	mac.Write([]byte(strings.TrimPrefix(timestamp, "t=") + "." + body))
+	assert.Equal(t, "v1="+hex.EncodeToString(mac.Sum(nil)), signature)
+}
+
+func TestSenderSendErrorUnexpectedStatusCode(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+
+	sender := NewSender(true)
+
+	statusCode, err := sender.Send(server.URL, "whsec_secret", "delivery-id", "BoxItemAddedEvent", "{}")
+
+	assert.ErrorIs(t, err, services.ErrWebhookSenderUnexpectedStatusCode)
+	assert.Equal(t, http.StatusInternalServerError, statusCode)
+}
+
+func TestSenderSendDoesNotFollowRedirects(t *testing.T) {
+	followed := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/elsewhere" {
+			followed = true
+			return
+		}
+
+		http.Redirect(w, r, "/elsewhere", http.StatusFound)
+	}))
+	defer server.Close()
+
+	sender := NewSender(true)
+
+	statusCode, err := sender.Send(server.URL, "whsec_secret", "delivery-id", "BoxItemAddedEvent", "{}")
+
+	assert.ErrorIs(t, err, services.ErrWebhookSenderUnexpectedStatusCode)
+	assert.Equal(t, http.StatusFound, statusCode)
+	assert.False(t, followed)
+}
+
+func TestSenderSendErrorAddressIsNotAllowed(t *testing.T) {
+	called := false
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		called = true
+	}))
+	defer server.Close()
+
+	sender := NewSender(false)
+
+	statusCode, err := sender.Send(server.URL, "whsec_secret", "delivery-id", "BoxItemAddedEvent", "{}")
+
+	assert.ErrorIs(t, err, services.ErrWebhookSenderAddressIsNotAllowed)
+	assert.Equal(t, 0, statusCode)
+	assert.False(t, called)
+}
+
+func TestSenderSendErrorCanNotSend(t *testing.T) {
+	sender := NewSender(true)
+
+	statusCode, err := sender.Send("http://127.0.0.1:1", "whsec_secret", "delivery-id", "BoxItemAddedEvent", "{}")
+
+	assert.ErrorIs(t, err, services.ErrWebhookSenderCanNotSend)
+	assert.Equal(t, 0, statusCode)
+}
+
+func TestSign(t *testing.T) {
+	timestamp := time.Unix(1700000000, 0)
+
+	signature := Sign("whsec_secret", timestamp, "{}")
+
+	mac := hmac.New(sha256.New, []byte("whsec_secret"))
+	mac.Write([]byte("1700000000.{}"))
+	assert.Equal(t, "t=1700000000,v1="+hex.EncodeToString(mac.Sum(nil)), signature)
+}
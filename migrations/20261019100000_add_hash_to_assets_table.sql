@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE assets ADD COLUMN hash CHAR(64) NOT NULL DEFAULT '' AFTER size;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE assets DROP COLUMN hash;
+-- +goose StatementEnd
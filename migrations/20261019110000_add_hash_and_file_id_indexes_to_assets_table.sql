@@ -0,0 +1,11 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE INDEX assets_hash_extension_idx ON assets (hash, extension);
+CREATE INDEX assets_file_id_extension_idx ON assets (file_id, extension);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX assets_file_id_extension_idx ON assets;
+DROP INDEX assets_hash_extension_idx ON assets;
+-- +goose StatementEnd
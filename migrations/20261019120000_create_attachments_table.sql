@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS attachments (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    item_id CHAR(36) NOT NULL,
+    asset_id CHAR(36) NOT NULL,
+    kind VARCHAR(20) NOT NULL,
+    purchased_at TIMESTAMP NULL,
+    warranty_expires_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    INDEX attachments_warranty_expires_at_idx (warranty_expires_at),
+    CONSTRAINT attachments_item_id_fk FOREIGN KEY (item_id) REFERENCES items(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE attachments;
+-- +goose StatementEnd
@@ -0,0 +1,19 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS household_members (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    household_id CHAR(36) NOT NULL,
+    user_id CHAR(36) NOT NULL,
+    role VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE INDEX household_members_household_id_user_id_idx (household_id, user_id),
+    CONSTRAINT household_members_household_id_fk FOREIGN KEY (household_id) REFERENCES households(id),
+    CONSTRAINT household_members_user_id_fk FOREIGN KEY (user_id) REFERENCES users(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE household_members;
+-- +goose StatementEnd
@@ -0,0 +1,21 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS household_invitations (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    household_id CHAR(36) NOT NULL,
+    email VARCHAR(100) NOT NULL,
+    role VARCHAR(10) NOT NULL,
+    invited_by CHAR(36) NOT NULL,
+    status VARCHAR(10) NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    INDEX household_invitations_email_status_idx (email, status),
+    CONSTRAINT household_invitations_household_id_fk FOREIGN KEY (household_id) REFERENCES households(id),
+    CONSTRAINT household_invitations_invited_by_fk FOREIGN KEY (invited_by) REFERENCES users(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE household_invitations;
+-- +goose StatementEnd
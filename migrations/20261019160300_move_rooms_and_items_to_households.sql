@@ -0,0 +1,91 @@
+-- Every existing user gets a household with the same id that owns their rooms and items.
+
+-- +goose Up
+-- +goose StatementBegin
+INSERT INTO households (id, name, created_at, updated_at)
+SELECT id, 'My home', NOW(), NOW() FROM users;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO household_members (id, household_id, user_id, role, created_at, updated_at)
+SELECT UUID(), id, id, 'owner', NOW(), NOW() FROM users;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE rooms ADD COLUMN household_id CHAR(36) NULL AFTER user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE rooms SET household_id = user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE rooms
+    DROP FOREIGN KEY rooms_user_id_fk,
+    DROP COLUMN user_id,
+    MODIFY household_id CHAR(36) NOT NULL,
+    ADD CONSTRAINT rooms_household_id_fk FOREIGN KEY (household_id) REFERENCES households(id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE items ADD COLUMN household_id CHAR(36) NULL AFTER user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE items SET household_id = user_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE items
+    DROP FOREIGN KEY items_user_id_fk,
+    DROP COLUMN user_id,
+    MODIFY household_id CHAR(36) NOT NULL,
+    ADD CONSTRAINT items_household_id_fk FOREIGN KEY (household_id) REFERENCES households(id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE items ADD COLUMN user_id CHAR(36) NULL AFTER household_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE items SET user_id = (
+    SELECT MIN(user_id) FROM household_members
+    WHERE household_members.household_id = items.household_id AND household_members.role = 'owner'
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE items
+    DROP FOREIGN KEY items_household_id_fk,
+    DROP COLUMN household_id,
+    MODIFY user_id CHAR(36) NOT NULL,
+    ADD CONSTRAINT items_user_id_fk FOREIGN KEY (user_id) REFERENCES users(id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE rooms ADD COLUMN user_id CHAR(36) NULL AFTER household_id;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+UPDATE rooms SET user_id = (
+    SELECT MIN(user_id) FROM household_members
+    WHERE household_members.household_id = rooms.household_id AND household_members.role = 'owner'
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+ALTER TABLE rooms
+    DROP FOREIGN KEY rooms_household_id_fk,
+    DROP COLUMN household_id,
+    MODIFY user_id CHAR(36) NOT NULL,
+    ADD CONSTRAINT rooms_user_id_fk FOREIGN KEY (user_id) REFERENCES users(id);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM household_members;
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+DELETE FROM households;
+-- +goose StatementEnd
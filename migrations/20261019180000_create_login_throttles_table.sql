@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS login_throttles (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    identifier VARCHAR(150) NOT NULL,
+    failed_attempts INT NOT NULL,
+    last_failed_at TIMESTAMP NOT NULL,
+    blocked_until TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE INDEX login_throttles_identifier_idx (identifier)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE login_throttles;
+-- +goose StatementEnd
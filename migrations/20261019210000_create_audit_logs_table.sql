@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS audit_logs (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    actor_id CHAR(36) NOT NULL,
+    action VARCHAR(50) NOT NULL,
+    entity_type VARCHAR(50) NOT NULL,
+    entity_id CHAR(36) NOT NULL,
+    household_id CHAR(36) NULL,
+    `before` JSON NULL,
+    `after` JSON NULL,
+    ip_address VARCHAR(45) NOT NULL,
+    user_agent VARCHAR(255) NOT NULL,
+    happened_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    INDEX audit_logs_actor_id_idx (actor_id, happened_at),
+    INDEX audit_logs_household_id_idx (household_id, happened_at),
+    INDEX audit_logs_entity_idx (entity_type, entity_id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE audit_logs;
+-- +goose StatementEnd
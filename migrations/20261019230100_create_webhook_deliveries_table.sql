@@ -0,0 +1,25 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS webhook_deliveries (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    webhook_id CHAR(36) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    payload JSON NOT NULL,
+    status VARCHAR(20) NOT NULL,
+    attempts INT NOT NULL DEFAULT 0,
+    response_status INT NULL,
+    last_error VARCHAR(1000) NULL,
+    available_at TIMESTAMP NOT NULL,
+    delivered_at TIMESTAMP NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    INDEX webhook_deliveries_status_idx (status, available_at),
+    INDEX webhook_deliveries_webhook_id_idx (webhook_id, created_at),
+    CONSTRAINT webhook_deliveries_webhook_id_fk FOREIGN KEY (webhook_id) REFERENCES webhooks(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE webhook_deliveries;
+-- +goose StatementEnd
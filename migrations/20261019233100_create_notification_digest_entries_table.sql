@@ -0,0 +1,20 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS notification_digest_entries (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    user_id CHAR(36) NOT NULL,
+    event_type VARCHAR(100) NOT NULL,
+    message VARCHAR(1000) NOT NULL,
+    happened_at TIMESTAMP NOT NULL,
+    due_at TIMESTAMP NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    INDEX notification_digest_entries_due_at_idx (due_at),
+    INDEX notification_digest_entries_user_id_idx (user_id),
+    CONSTRAINT notification_digest_entries_user_id_fk FOREIGN KEY (user_id) REFERENCES users(id)
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE notification_digest_entries;
+-- +goose StatementEnd
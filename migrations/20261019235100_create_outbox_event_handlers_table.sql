@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS outbox_event_handlers (
+    event_id CHAR(36) NOT NULL,
+    handler VARCHAR(255) NOT NULL,
+    delivered_at TIMESTAMP NOT NULL,
+    PRIMARY KEY (event_id, handler),
+    CONSTRAINT outbox_event_handlers_event_id_fk FOREIGN KEY (event_id) REFERENCES outbox_events(id) ON DELETE CASCADE
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE outbox_event_handlers;
+-- +goose StatementEnd
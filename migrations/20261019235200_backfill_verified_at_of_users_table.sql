@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- Users created before the email verification could not verify their email,
+-- they are trusted as before instead of no longer getting notifications.
+UPDATE users SET verified_at = created_at WHERE verified_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+-- The backfilled users can not be told apart from the ones that verified.
+SELECT 1;
+-- +goose StatementEnd
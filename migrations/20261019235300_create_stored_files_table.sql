@@ -0,0 +1,36 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stored_files (
+    id CHAR(36) NOT NULL PRIMARY KEY,
+    extension VARCHAR(10) NOT NULL,
+    hash CHAR(64) NULL,
+    reference_count INT NOT NULL,
+    created_at TIMESTAMP NOT NULL,
+    updated_at TIMESTAMP NOT NULL,
+    UNIQUE INDEX stored_files_hash_extension_idx (hash, extension)
+);
+-- +goose StatementEnd
+
+-- +goose StatementBegin
+INSERT INTO stored_files (id, extension, hash, reference_count, created_at, updated_at)
+SELECT
+    file_id,
+    extension,
+    CASE
+        WHEN hash <> '' AND ROW_NUMBER() OVER (PARTITION BY hash, extension ORDER BY file_id) = 1 THEN hash
+    END,
+    reference_count,
+    created_at,
+    updated_at
+FROM (
+    SELECT file_id, MAX(extension) AS extension, MAX(hash) AS hash, COUNT(*) AS reference_count,
+        MIN(created_at) AS created_at, MAX(updated_at) AS updated_at
+    FROM assets
+    GROUP BY file_id
+) AS files;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE stored_files;
+-- +goose StatementEnd
@@ -0,0 +1,58 @@
+-- The handlers were recorded with the name of their function, they are now
+-- recorded with the name given when subscribing them.
+
+-- +goose Up
+-- +goose StatementBegin
+UPDATE outbox_event_handlers SET handler = CASE handler
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*CreateAddBoxTransactionListener).Handle' THEN 'create_add_box_transaction'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*CreateRemoveBoxTransactionListener).Handle' THEN 'create_remove_box_transaction'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*RollbackAssetListener).Handle' THEN 'rollback_asset'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendPasswordResetListener).Handle' THEN 'send_password_reset'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendEmailVerificationListener).Handle' THEN 'send_email_verification'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendHouseholdInvitationListener).Handle' THEN 'send_household_invitation'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendLoginLockedNotificationListener).Handle' THEN 'send_login_locked_notification'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendEmailChangeConfirmationListener).Handle' THEN 'send_email_change_confirmation'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*DeleteAccountListener).Handle' THEN 'delete_account'
+    WHEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*DeliverWebhooksListener).Handle' THEN 'deliver_webhooks'
+END
+WHERE handler IN (
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*CreateAddBoxTransactionListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*CreateRemoveBoxTransactionListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*RollbackAssetListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendPasswordResetListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendEmailVerificationListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendHouseholdInvitationListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendLoginLockedNotificationListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendEmailChangeConfirmationListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*DeleteAccountListener).Handle',
+    'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*DeliverWebhooksListener).Handle'
+);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+UPDATE outbox_event_handlers SET handler = CASE handler
+    WHEN 'create_add_box_transaction' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*CreateAddBoxTransactionListener).Handle'
+    WHEN 'create_remove_box_transaction' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*CreateRemoveBoxTransactionListener).Handle'
+    WHEN 'rollback_asset' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*RollbackAssetListener).Handle'
+    WHEN 'send_password_reset' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendPasswordResetListener).Handle'
+    WHEN 'send_email_verification' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendEmailVerificationListener).Handle'
+    WHEN 'send_household_invitation' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendHouseholdInvitationListener).Handle'
+    WHEN 'send_login_locked_notification' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendLoginLockedNotificationListener).Handle'
+    WHEN 'send_email_change_confirmation' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*SendEmailChangeConfirmationListener).Handle'
+    WHEN 'delete_account' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*DeleteAccountListener).Handle'
+    WHEN 'deliver_webhooks' THEN 'github.com/jibaru/home-inventory-api/m/internal/app/application/listeners.(*DeliverWebhooksListener).Handle'
+END
+WHERE handler IN (
+    'create_add_box_transaction',
+    'create_remove_box_transaction',
+    'rollback_asset',
+    'send_password_reset',
+    'send_email_verification',
+    'send_household_invitation',
+    'send_login_locked_notification',
+    'send_email_change_confirmation',
+    'delete_account',
+    'deliver_webhooks'
+);
+-- +goose StatementEnd
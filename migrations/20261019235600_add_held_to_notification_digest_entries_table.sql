@@ -0,0 +1,9 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE notification_digest_entries ADD COLUMN held BOOLEAN NOT NULL DEFAULT FALSE AFTER due_at;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE notification_digest_entries DROP COLUMN held;
+-- +goose StatementEnd